@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/unifi-go/gofi/transport"
+)
+
+func TestAPIKeyManager(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/proxy/network/integration/v1/info" {
+			t.Errorf("Path = %s, want the Integrations API info", r.URL.Path)
+		}
+		if r.Header.Get(APIKeyHeader) != "k3y" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"applicationVersion":"9.0.114"}`))
+	}))
+	defer server.Close()
+
+	config := transport.DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	trans, err := transport.New(config)
+	if err != nil {
+		t.Fatalf("transport.New() error = %v", err)
+	}
+	defer trans.Close()
+	ctx := context.Background()
+
+	if err := NewAPIKey(trans, "wrong").Login(ctx); err == nil {
+		t.Error("Login() with a wrong key succeeded")
+	}
+
+	mgr := NewAPIKey(trans, "k3y")
+	if err := mgr.EnsureAuthenticated(ctx); err != nil {
+		t.Fatalf("EnsureAuthenticated() error = %v", err)
+	}
+	if err := mgr.EnsureAuthenticated(ctx); err != nil {
+		t.Fatalf("EnsureAuthenticated() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want the key checked once", requests)
+	}
+	if session := mgr.Session(); session == nil || session.NeedsRefresh() || session.CSRFToken != "" {
+		t.Errorf("Session() = %+v, want one without expiry or CSRF token", session)
+	}
+
+	if err := mgr.Logout(ctx); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if mgr.IsAuthenticated() || requests != 2 {
+		t.Errorf("after Logout: authenticated = %v, requests = %d", mgr.IsAuthenticated(), requests)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
 )
 
 // Manager manages authentication state.
@@ -26,6 +27,10 @@ type Manager interface {
 
 	// IsAuthenticated returns true if there is a valid session.
 	IsAuthenticated() bool
+
+	// SetPassword replaces the password used by later logins, after it
+	// was changed on the controller.
+	SetPassword(password string)
 }
 
 // manager implements the Manager interface.
@@ -83,7 +88,7 @@ func (m *manager) Login(ctx context.Context) error {
 		if err := json.Unmarshal(resp.Body, &errResp); err == nil && errResp.Message != "" {
 			return fmt.Errorf("login failed: %s", errResp.Message)
 		}
-		return fmt.Errorf("login failed: status %d (check credentials)", resp.StatusCode)
+		return fmt.Errorf("%w (check credentials)", types.NewResponseError("login", resp.StatusCode, resp.Body))
 	}
 
 	if !resp.IsSuccess() {
@@ -95,7 +100,7 @@ func (m *manager) Login(ctx context.Context) error {
 		if err := json.Unmarshal(resp.Body, &errResp); err == nil && errResp.Message != "" {
 			return fmt.Errorf("login failed: %s", errResp.Message)
 		}
-		return fmt.Errorf("login failed: status %d, body: %s", resp.StatusCode, truncateBody(resp.Body))
+		return fmt.Errorf("%w, body: %s", types.NewResponseError("login", resp.StatusCode, resp.Body), truncateBody(resp.Body))
 	}
 
 	// Try parsing as standard UniFi response with meta.rc
@@ -178,8 +183,8 @@ func (m *manager) Logout(ctx context.Context) error {
 		return nil // Already logged out
 	}
 
-	// Create logout request
-	req := transport.NewRequest("POST", "/api/logout")
+	// Create logout request; classic controllers serve it at /api/logout
+	req := transport.NewRequest("POST", "/api/auth/logout")
 
 	// Execute logout (ignore errors, we're clearing session anyway)
 	_, _ = m.transport.Do(ctx, req)
@@ -192,6 +197,13 @@ func (m *manager) Logout(ctx context.Context) error {
 	return nil
 }
 
+// SetPassword replaces the password used by later logins.
+func (m *manager) SetPassword(password string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.password = password
+}
+
 // EnsureAuthenticated ensures there is a valid session, refreshing if needed.
 func (m *manager) EnsureAuthenticated(ctx context.Context) error {
 	// Quick check without lock
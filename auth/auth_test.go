@@ -141,7 +141,7 @@ func TestManager_Logout(t *testing.T) {
 			return
 		}
 
-		if r.URL.Path == "/api/logout" {
+		if r.URL.Path == "/api/auth/logout" {
 			logoutCalled = true
 			w.WriteHeader(http.StatusOK)
 			return
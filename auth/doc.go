@@ -2,6 +2,7 @@
 //
 // This package handles:
 //   - Login/logout operations
+//   - API key authentication
 //   - Session token management
 //   - CSRF token handling
 //   - Automatic session refresh
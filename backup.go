@@ -0,0 +1,290 @@
+package gofi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// BackupDownloadOptions controls DownloadBackup and DownloadFreshBackup.
+type BackupDownloadOptions struct {
+	// Dir is the directory the backup is written to. Defaults to ".".
+	Dir string
+
+	// Keep prunes local backups in Dir beyond the newest Keep after a
+	// successful download. Zero keeps everything.
+	Keep int
+
+	// PollInterval is how often DownloadFreshBackup checks whether the new
+	// backup has been listed. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// WaitTimeout bounds how long DownloadFreshBackup waits for the new
+	// backup to be listed. Defaults to 5 minutes.
+	WaitTimeout time.Duration
+}
+
+// BackupDownload describes a verified local copy of a controller backup.
+type BackupDownload struct {
+	// Backup is the controller's listing for the file.
+	Backup types.Backup
+
+	// Path is the local file the backup was written to.
+	Path string
+
+	// Size is the number of bytes written.
+	Size int64
+
+	// SHA256 is the hex-encoded checksum of the file. It is also written
+	// next to the backup as Path + ".sha256" in sha256sum format.
+	SHA256 string
+
+	// Pruned lists local backups removed because of Keep.
+	Pruned []string
+}
+
+// backupExt is the extension of controller backup files.
+const backupExt = ".unf"
+
+// DownloadFreshBackup creates a new backup on the controller, waits for it
+// to appear in the backup list and downloads it with DownloadBackup.
+func DownloadFreshBackup(ctx context.Context, c Client, opts BackupDownloadOptions) (*BackupDownload, error) {
+	before, err := c.System().ListBackups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	existing := make(map[string]bool, len(before))
+	for _, b := range before {
+		existing[b.Filename] = true
+	}
+
+	if err := c.System().CreateBackup(ctx); err != nil {
+		return nil, err
+	}
+
+	backup, err := waitForBackup(ctx, c, existing, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return DownloadBackup(ctx, c, *backup, opts)
+}
+
+// waitForBackup polls the backup list until a file not in existing appears
+// and returns the newest such file.
+func waitForBackup(ctx context.Context, c Client, existing map[string]bool, opts BackupDownloadOptions) (*types.Backup, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	timeout := opts.WaitTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		backups, err := c.System().ListBackups(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", err)
+		}
+
+		var newest *types.Backup
+		for i := range backups {
+			b := &backups[i]
+			if existing[b.Filename] {
+				continue
+			}
+			if newest == nil || b.Time.Val.After(newest.Time.Val) {
+				newest = b
+			}
+		}
+		if newest != nil {
+			return newest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for new backup to be listed: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// DownloadBackup downloads a listed backup into opts.Dir. The file is
+// streamed to a temporary file while its SHA-256 is computed, then checked
+// against the listed size and re-read to confirm the checksum before it is
+// renamed into place. A partial or corrupt download never replaces an
+// existing file.
+func DownloadBackup(ctx context.Context, c Client, backup types.Backup, opts BackupDownloadOptions) (*BackupDownload, error) {
+	name := filepath.Base(backup.Filename)
+	if name != backup.Filename || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid backup filename %q", backup.Filename)
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*.part")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	n, err := copyBackup(ctx, c, backup.Filename, io.MultiWriter(tmp, h))
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.Size > 0 && n != backup.Size {
+		return nil, fmt.Errorf("backup %s: downloaded %d bytes, controller listed %d", name, n, backup.Size)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	written, err := fileSHA256(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	if written != sum {
+		return nil, fmt.Errorf("backup %s: checksum mismatch after write (streamed %s, on disk %s)", name, sum, written)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to save backup: %w", err)
+	}
+	if err := os.WriteFile(path+".sha256", []byte(sum+"  "+name+"\n"), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write checksum: %w", err)
+	}
+
+	result := &BackupDownload{Backup: backup, Path: path, Size: n, SHA256: sum}
+
+	if opts.Keep > 0 {
+		result.Pruned, err = PruneBackups(dir, opts.Keep)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// copyBackup streams a backup from the controller to w.
+func copyBackup(ctx context.Context, c Client, filename string, w io.Writer) (int64, error) {
+	body, err := c.System().DownloadBackup(ctx, filename)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download backup: %w", err)
+	}
+	return n, nil
+}
+
+// VerifyBackup checks a downloaded backup against its ".sha256" file.
+func VerifyBackup(path string) error {
+	data, err := os.ReadFile(path + ".sha256")
+	if err != nil {
+		return fmt.Errorf("failed to read checksum: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return fmt.Errorf("empty checksum file %s.sha256", path)
+	}
+
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if sum != strings.ToLower(fields[0]) {
+		return fmt.Errorf("backup %s: checksum mismatch (expected %s, got %s)", filepath.Base(path), fields[0], sum)
+	}
+	return nil
+}
+
+// PruneBackups removes all but the newest keep backup files in dir, along
+// with their checksum files, and returns the removed paths. Files are
+// ordered by modification time.
+func PruneBackups(dir string, keep int) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+backupExt))
+	if err != nil {
+		return nil, err
+	}
+
+	type localBackup struct {
+		path    string
+		modTime time.Time
+	}
+	var files []localBackup
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, localBackup{m, info.ModTime()})
+	}
+	if len(files) <= keep {
+		return nil, nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].modTime.Equal(files[j].modTime) {
+			return files[i].path > files[j].path
+		}
+		return files[i].modTime.After(files[j].modTime)
+	})
+
+	var pruned []string
+	for _, f := range files[keep:] {
+		if err := os.Remove(f.path); err != nil {
+			return pruned, fmt.Errorf("failed to prune backup: %w", err)
+		}
+		os.Remove(f.path + ".sha256")
+		pruned = append(pruned, f.path)
+	}
+	return pruned, nil
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of a file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read backup: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
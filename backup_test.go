@@ -0,0 +1,128 @@
+package gofi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestDownloadFreshBackup(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddBackup(&types.Backup{Filename: "old.unf", Size: 10, Time: types.NewFlexTime(1)})
+	c := connectMock(t, server)
+
+	dir := t.TempDir()
+	got, err := DownloadFreshBackup(context.Background(), c, BackupDownloadOptions{
+		Dir:          dir,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DownloadFreshBackup() error = %v", err)
+	}
+
+	if got.Backup.Filename == "old.unf" {
+		t.Errorf("Backup = %s, want the newly created backup", got.Backup.Filename)
+	}
+	info, err := os.Stat(got.Path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Size() != got.Backup.Size || got.Size != got.Backup.Size {
+		t.Errorf("Size = %d (file %d), want %d", got.Size, info.Size(), got.Backup.Size)
+	}
+	if err := VerifyBackup(got.Path); err != nil {
+		t.Errorf("VerifyBackup() error = %v", err)
+	}
+
+	// No temporary files are left behind.
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Dir has %d entries, want backup and checksum", len(entries))
+	}
+}
+
+func TestDownloadBackup_SizeMismatch(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddBackup(&types.Backup{Filename: "b.unf", Size: 100, Time: types.NewFlexTime(1)})
+	c := connectMock(t, server)
+
+	dir := t.TempDir()
+	_, err := DownloadBackup(context.Background(), c, types.Backup{Filename: "b.unf", Size: 99}, BackupDownloadOptions{Dir: dir})
+	if err == nil {
+		t.Fatal("DownloadBackup() expected size mismatch error")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("Dir has %d entries after failed download, want 0", len(entries))
+	}
+}
+
+func TestDownloadBackup_InvalidFilename(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	_, err := DownloadBackup(context.Background(), c, types.Backup{Filename: "../evil.unf"}, BackupDownloadOptions{Dir: t.TempDir()})
+	if err == nil {
+		t.Fatal("DownloadBackup() expected error for path traversal")
+	}
+}
+
+func TestVerifyBackup_Corrupt(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddBackup(&types.Backup{Filename: "b.unf", Size: 100, Time: types.NewFlexTime(1)})
+	c := connectMock(t, server)
+
+	got, err := DownloadBackup(context.Background(), c, types.Backup{Filename: "b.unf", Size: 100}, BackupDownloadOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("DownloadBackup() error = %v", err)
+	}
+
+	if err := os.WriteFile(got.Path, []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyBackup(got.Path); err == nil {
+		t.Error("VerifyBackup() expected checksum mismatch")
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	for i, name := range []string{"a.unf", "b.unf", "c.unf"} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(name), 0o644)
+		os.WriteFile(path+".sha256", []byte("x  "+name+"\n"), 0o644)
+		mtime := base.Add(time.Duration(i) * time.Minute)
+		os.Chtimes(path, mtime, mtime)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644)
+
+	pruned, err := PruneBackups(dir, 1)
+	if err != nil {
+		t.Fatalf("PruneBackups() error = %v", err)
+	}
+	if len(pruned) != 2 {
+		t.Fatalf("PruneBackups() removed %v, want 2 files", pruned)
+	}
+
+	for _, name := range []string{"a.unf", "a.unf.sha256", "b.unf", "b.unf.sha256"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s still exists", name)
+		}
+	}
+	for _, name := range []string{"c.unf", "c.unf.sha256", "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was removed", name)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package gofi
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // BatchResult represents the result of a batch operation.
@@ -17,31 +18,131 @@ type BatchResult[T any] struct {
 	Index int
 }
 
+// BatchOption configures BatchGet and BatchGetStream.
+type BatchOption func(*batchOptions)
+
+// batchOptions holds options for BatchGet and BatchGetStream.
+type batchOptions struct {
+	workers     int
+	itemTimeout time.Duration
+	interval    time.Duration
+}
+
+// WithBatchWorkers limits how many getter calls run at once. The default
+// runs one per ID.
+func WithBatchWorkers(n int) BatchOption {
+	return func(opts *batchOptions) {
+		opts.workers = n
+	}
+}
+
+// WithBatchItemTimeout gives each getter call a context that expires after
+// d, so that one slow item cannot hold up the batch.
+func WithBatchItemTimeout(d time.Duration) BatchOption {
+	return func(opts *batchOptions) {
+		opts.itemTimeout = d
+	}
+}
+
+// WithBatchRateLimit starts at most perSecond getter calls per second.
+func WithBatchRateLimit(perSecond float64) BatchOption {
+	return func(opts *batchOptions) {
+		if perSecond > 0 {
+			opts.interval = time.Duration(float64(time.Second) / perSecond)
+		}
+	}
+}
+
 // BatchGet performs concurrent Get operations and returns results.
-// The getter function is called for each ID concurrently.
+// The getter function is called for each ID concurrently, subject to
+// the options.
 func BatchGet[T any](
 	ctx context.Context,
 	ids []string,
 	getter func(ctx context.Context, id string) (*T, error),
+	opts ...BatchOption,
 ) []BatchResult[T] {
 	results := make([]BatchResult[T], len(ids))
-	var wg sync.WaitGroup
+	for result := range BatchGetStream(ctx, ids, getter, opts...) {
+		results[result.Index] = result
+	}
+	return results
+}
 
-	for i, id := range ids {
-		wg.Add(1)
-		go func(idx int, itemID string) {
-			defer wg.Done()
+// BatchGetStream is like BatchGet but delivers each result on the returned
+// channel as soon as its getter returns, so results arrive out of order.
+// The channel is closed after the last result. IDs not yet started when
+// ctx is done are reported with ctx's error without calling getter.
+func BatchGetStream[T any](
+	ctx context.Context,
+	ids []string,
+	getter func(ctx context.Context, id string) (*T, error),
+	opts ...BatchOption,
+) <-chan BatchResult[T] {
+	options := &batchOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	workers := options.workers
+	if workers <= 0 {
+		workers = max(len(ids), 1)
+	}
 
-			item, err := getter(ctx, itemID)
-			results[idx] = BatchResult[T]{
-				Item:  item,
-				Error: err,
-				Index: idx,
+	// Buffered for every result, so workers never block on a slow reader.
+	results := make(chan BatchResult[T], len(ids))
+
+	go func() {
+		defer close(results)
+
+		var tick <-chan time.Time
+		if options.interval > 0 {
+			ticker := time.NewTicker(options.interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, workers)
+		for i, id := range ids {
+			if i > 0 && tick != nil {
+				select {
+				case <-ctx.Done():
+				case <-tick:
+				}
+			}
+			select {
+			case <-ctx.Done():
+			case sem <- struct{}{}:
+			}
+			if err := ctx.Err(); err != nil {
+				results <- BatchResult[T]{Error: err, Index: i}
+				continue
 			}
-		}(i, id)
-	}
 
-	wg.Wait()
+			wg.Add(1)
+			go func(idx int, itemID string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				itemCtx := ctx
+				if options.itemTimeout > 0 {
+					var cancel context.CancelFunc
+					itemCtx, cancel = context.WithTimeout(ctx, options.itemTimeout)
+					defer cancel()
+				}
+
+				item, err := getter(itemCtx, itemID)
+				results <- BatchResult[T]{
+					Item:  item,
+					Error: err,
+					Index: idx,
+				}
+			}(i, id)
+		}
+
+		wg.Wait()
+	}()
+
 	return results
 }
 
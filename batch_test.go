@@ -3,6 +3,7 @@ package gofi
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -217,6 +218,95 @@ func TestBatch_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestBatchGet_Options(t *testing.T) {
+	ids := []string{"id1", "id2", "id3", "id4", "id5", "id6"}
+
+	var running, peak atomic.Int32
+	getter := func(ctx context.Context, id string) (*string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if id == "id6" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		time.Sleep(5 * time.Millisecond)
+		return stringPtr("item-" + id), nil
+	}
+
+	start := time.Now()
+	results := BatchGet(context.Background(), ids, getter,
+		WithBatchWorkers(2), WithBatchItemTimeout(50*time.Millisecond), WithBatchRateLimit(200))
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", p)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("BatchGet() took %s, want rate limited to at least 25ms", elapsed)
+	}
+	for i, result := range results[:5] {
+		if result.Error != nil || result.Item == nil {
+			t.Errorf("results[%d] = %+v, want item", i, result)
+		}
+	}
+	if !errors.Is(results[5].Error, context.DeadlineExceeded) {
+		t.Errorf("results[5].Error = %v, want item timeout", results[5].Error)
+	}
+}
+
+func TestBatchGetStream(t *testing.T) {
+	ids := []string{"slow", "fast"}
+
+	getter := func(ctx context.Context, id string) (*string, error) {
+		if id == "slow" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		return stringPtr(id), nil
+	}
+
+	var order []string
+	for result := range BatchGetStream(context.Background(), ids, getter) {
+		if result.Error != nil {
+			t.Fatalf("result %d error = %v", result.Index, result.Error)
+		}
+		if *result.Item != ids[result.Index] {
+			t.Errorf("result %d = %s, want %s", result.Index, *result.Item, ids[result.Index])
+		}
+		order = append(order, *result.Item)
+	}
+
+	if len(order) != 2 || order[0] != "fast" {
+		t.Errorf("stream order = %v, want fast first", order)
+	}
+}
+
+func TestBatchGetStream_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	getter := func(ctx context.Context, id string) (*string, error) {
+		called = true
+		return stringPtr(id), nil
+	}
+
+	n := 0
+	for result := range BatchGetStream(ctx, []string{"id1", "id2"}, getter) {
+		if !errors.Is(result.Error, context.Canceled) {
+			t.Errorf("result %d error = %v, want context.Canceled", result.Index, result.Error)
+		}
+		n++
+	}
+	if n != 2 || called {
+		t.Errorf("got %d results, getter called = %v; want 2 results and no calls", n, called)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,224 @@
+package gofi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// ListCache sets how long List results of hot collections are cached. A
+// zero TTL leaves that collection uncached. Every write a client sends
+// (any request other than a GET or a stat/ or list/ query) drops the
+// cached lists of the site it
+// targets, so a client always sees its own changes; changes made by others
+// show up once the TTL has passed.
+type ListCache struct {
+	// Networks caches Networks().List.
+	Networks time.Duration
+
+	// Users caches Users().List.
+	Users time.Duration
+
+	// Devices caches Devices().List.
+	Devices time.Duration
+}
+
+// Cached collections.
+const (
+	cacheNetworks = "networks"
+	cacheUsers    = "users"
+	cacheDevices  = "devices"
+)
+
+// listCache holds cached lists by collection and site. It is shared by a
+// client and its clones.
+type listCache struct {
+	config ListCache
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+
+	// generation counts invalidations, so that a list fetched while a
+	// write was in flight is not stored.
+	generation uint64
+}
+
+type cacheKey struct {
+	collection string
+	site       string
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newListCache(config ListCache) *listCache {
+	return &listCache{
+		config:  config,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// invalidate drops the cached lists of site, or of every site if site is
+// empty.
+func (c *listCache) invalidate(site string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key := range c.entries {
+		if site == "" || key.site == site {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// InvalidateCache drops the cached lists of site, or of every site if site
+// is empty.
+func (c *client) InvalidateCache(site string) {
+	if c.cache != nil {
+		c.cache.invalidate(site)
+	}
+}
+
+// cachedList returns a copy of the cached list for collection and site,
+// calling list on a miss. A ttl of zero bypasses the cache.
+func cachedList[T any](ctx context.Context, c *listCache, collection, site string, ttl time.Duration, list func(context.Context, string) ([]T, error)) ([]T, error) {
+	if ttl <= 0 {
+		return list(ctx, site)
+	}
+
+	key := cacheKey{collection: collection, site: site}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return types.DeepCopy(entry.value.([]T)), nil
+	}
+
+	items, err := list(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = cacheEntry{value: types.DeepCopy(items), expires: time.Now().Add(ttl)}
+	}
+	c.mu.Unlock()
+	return items, nil
+}
+
+// siteFromPath returns the site a request path targets, or "" if it has
+// none.
+func siteFromPath(path string) string {
+	site, _ := splitSitePath(path)
+	return site
+}
+
+// splitSitePath splits a request path into its site and the path below
+// the site, or returns "" for both if it targets no site.
+func splitSitePath(path string) (site, rest string) {
+	for _, marker := range []string{"/api/s/", "/v2/api/site/"} {
+		if i := strings.Index(path, marker); i >= 0 {
+			site, rest, _ = strings.Cut(path[i+len(marker):], "/")
+			return site, rest
+		}
+	}
+	return "", ""
+}
+
+// isRead reports whether req only reads: a GET, or a query POSTed to a
+// stat/ or list/ endpoint, such as stat/event and stat/report.
+func isRead(req *transport.Request) bool {
+	if req.Method == "GET" {
+		return true
+	}
+	_, rest := splitSitePath(req.Path)
+	return req.Method == "POST" && (strings.HasPrefix(rest, "stat/") || strings.HasPrefix(rest, "list/"))
+}
+
+// cacheTransport invalidates a listCache after every request that is not
+// a read.
+type cacheTransport struct {
+	transport transport.Transport
+	cache     *listCache
+}
+
+func (t *cacheTransport) Do(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	resp, err := t.transport.Do(ctx, req)
+	if !isRead(req) {
+		t.cache.invalidate(siteFromPath(req.Path))
+	}
+	return resp, err
+}
+
+func (t *cacheTransport) Stream(ctx context.Context, req *transport.Request) (*transport.StreamResponse, error) {
+	return transport.Stream(ctx, t.transport, req)
+}
+
+func (t *cacheTransport) Stats() transport.Stats {
+	return transport.GetStats(t.transport)
+}
+
+func (t *cacheTransport) SetCSRFToken(token string) {
+	t.transport.SetCSRFToken(token)
+}
+
+func (t *cacheTransport) GetCSRFToken() string {
+	return t.transport.GetCSRFToken()
+}
+
+func (t *cacheTransport) Close() {
+	t.transport.Close()
+}
+
+// cachedNetworks is a NetworkService whose List is cached.
+type cachedNetworks struct {
+	services.NetworkService
+	cache *listCache
+}
+
+func (n *cachedNetworks) List(ctx context.Context, site string) ([]types.Network, error) {
+	return cachedList(ctx, n.cache, cacheNetworks, site, n.cache.config.Networks, n.NetworkService.List)
+}
+
+// cachedUsers is a UserService whose List is cached.
+type cachedUsers struct {
+	services.UserService
+	cache *listCache
+}
+
+// List is cached only without options, as searches are sent to the
+// controller.
+func (u *cachedUsers) List(ctx context.Context, site string, opts ...services.UserListOption) ([]types.User, error) {
+	if len(opts) > 0 {
+		return u.UserService.List(ctx, site, opts...)
+	}
+	return cachedList(ctx, u.cache, cacheUsers, site, u.cache.config.Users, func(ctx context.Context, site string) ([]types.User, error) {
+		return u.UserService.List(ctx, site)
+	})
+}
+
+// cachedDevices is a DeviceService whose List is cached.
+type cachedDevices struct {
+	services.DeviceService
+	cache *listCache
+}
+
+// List is cached only without options, as field selection returns
+// partial devices.
+func (d *cachedDevices) List(ctx context.Context, site string, opts ...services.DeviceListOption) ([]types.Device, error) {
+	if len(opts) > 0 {
+		return d.DeviceService.List(ctx, site, opts...)
+	}
+	return cachedList(ctx, d.cache, cacheDevices, site, d.cache.config.Devices, func(ctx context.Context, site string) ([]types.Device, error) {
+		return d.DeviceService.List(ctx, site)
+	})
+}
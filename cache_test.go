@@ -0,0 +1,115 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestListCache(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddNetwork(&types.Network{ID: "net1", Name: "LAN", Purpose: "corporate"})
+
+	c := connectMock(t, server, WithListCache(ListCache{Networks: time.Minute}))
+	ctx := context.Background()
+
+	requests := func() uint64 { return c.Diagnostics().Transport.Requests }
+
+	first, err := c.Networks().List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	before := requests()
+	first[0].Name = "changed by caller"
+
+	second, err := c.ForSite("default").Networks().List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if requests() != before {
+		t.Errorf("second List() sent %d requests, want a cache hit", requests()-before)
+	}
+	if second[0].Name != "LAN" {
+		t.Errorf("cached Name = %q, want a copy unaffected by the caller", second[0].Name)
+	}
+
+	// A write through the client drops the site's cached lists.
+	if _, err := c.Networks().Create(ctx, "default", &types.Network{Name: "IoT", Purpose: "corporate"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	third, err := c.Networks().List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(third) != 2 {
+		t.Errorf("List() after Create() = %d networks, want 2", len(third))
+	}
+
+	// Explicit invalidation picks up changes made elsewhere.
+	server.State().AddNetwork(&types.Network{ID: "net3", Name: "Guest", Purpose: "guest"})
+	if stale, _ := c.Networks().List(ctx, "default"); len(stale) != 2 {
+		t.Errorf("cached List() = %d networks, want 2", len(stale))
+	}
+	c.InvalidateCache("default")
+	if fresh, _ := c.Networks().List(ctx, "default"); len(fresh) != 3 {
+		t.Errorf("List() after InvalidateCache() = %d networks, want 3", len(fresh))
+	}
+
+	// Queries POSTed to stat/ endpoints are reads and keep the cache.
+	if _, err := c.Events().History(ctx, "default"); err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	before = requests()
+	c.Networks().List(ctx, "default")
+	if requests() != before {
+		t.Error("List() after History() missed the cache")
+	}
+
+	// Uncached collections always hit the controller.
+	before = requests()
+	c.Users().List(ctx, "default")
+	c.Users().List(ctx, "default")
+	if got := requests() - before; got != 2 {
+		t.Errorf("uncached Users().List sent %d requests, want 2", got)
+	}
+}
+
+func TestSiteFromPath(t *testing.T) {
+	tests := map[string]string{
+		"/proxy/network/api/s/default/rest/networkconf/1":  "default",
+		"/api/s/branch/cmd/devmgr":                         "branch",
+		"/proxy/network/v2/api/site/default/trafficrule/1": "default",
+		"/proxy/network/api/cmd/system":                    "",
+	}
+	for path, want := range tests {
+		if got := siteFromPath(path); got != want {
+			t.Errorf("siteFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestIsRead(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/proxy/network/api/s/default/rest/networkconf", true},
+		{"POST", "/proxy/network/api/s/default/stat/event", true},
+		{"POST", "/proxy/network/api/s/default/stat/report/hourly.site", true},
+		{"POST", "/api/s/default/list/alarm", true},
+		{"POST", "/proxy/network/api/s/default/rest/networkconf", false},
+		{"PUT", "/proxy/network/api/s/default/rest/networkconf/1", false},
+		{"POST", "/proxy/network/api/s/default/cmd/stat", false},
+		{"POST", "/proxy/network/api/cmd/stat/x", false},
+	}
+	for _, tt := range tests {
+		if got := isRead(transport.NewRequest(tt.method, tt.path)); got != tt.want {
+			t.Errorf("isRead(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
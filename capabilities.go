@@ -0,0 +1,80 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+)
+
+// Minimum Network Application versions for version-gated features.
+const (
+	// MinVersionTrafficRoutes is the first release with v2 traffic routes.
+	MinVersionTrafficRoutes = "7.4.0"
+
+	// MinVersionPPSK is the first release with private pre-shared keys.
+	MinVersionPPSK = "7.3.0"
+
+	// MinVersionZoneFirewall is the first release with the zone-based firewall.
+	MinVersionZoneFirewall = "9.0.0"
+
+	// MinVersionIntegrationAPI is the first release shipping the Integrations API.
+	MinVersionIntegrationAPI = "9.0.0"
+)
+
+// Capabilities describes the controller version and available feature families.
+type Capabilities struct {
+	// Version is the Network Application version.
+	Version string
+
+	// ZoneFirewall is true if the zone-based firewall is available.
+	ZoneFirewall bool
+
+	// TrafficRoutes is true if v2 traffic routes are available.
+	TrafficRoutes bool
+
+	// PPSK is true if WLANs support private pre-shared keys.
+	PPSK bool
+
+	// IntegrationAPI is true if the official Integrations API answered a probe.
+	IntegrationAPI bool
+}
+
+// AtLeast returns true if the controller version is at least version.
+func (c *Capabilities) AtLeast(version string) bool {
+	return internal.CompareVersions(c.Version, version) >= 0
+}
+
+// capabilitiesForVersion derives the version-gated feature flags.
+func capabilitiesForVersion(version string) *Capabilities {
+	caps := &Capabilities{Version: version}
+	caps.TrafficRoutes = caps.AtLeast(MinVersionTrafficRoutes)
+	caps.PPSK = caps.AtLeast(MinVersionPPSK)
+	caps.ZoneFirewall = caps.AtLeast(MinVersionZoneFirewall)
+	return caps
+}
+
+// Capabilities detects the Network Application version and feature families.
+// The client must be connected.
+func (c *client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	if !c.connected.Load() {
+		return nil, ErrNotConnected
+	}
+
+	sysInfo, err := c.Sites().SysInfo(ctx, c.config.Site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect capabilities: %w", err)
+	}
+
+	caps := capabilitiesForVersion(sysInfo.Version)
+
+	// The Integrations API may answer 401 to a session cookie (it expects an
+	// API key); only a 404 means it is absent.
+	resp, err := c.transport.Do(ctx, transport.NewRequest("GET", internal.BuildIntegrationPath("info")))
+	if err == nil && resp.StatusCode != 404 {
+		caps.IntegrationAPI = true
+	}
+
+	return caps, nil
+}
@@ -0,0 +1,88 @@
+package gofi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+)
+
+func TestCapabilitiesForVersion(t *testing.T) {
+	tests := []struct {
+		version       string
+		trafficRoutes bool
+		ppsk          bool
+		zoneFirewall  bool
+	}{
+		{"7.2.97", false, false, false},
+		{"7.3.83", false, true, false},
+		{"7.5.174", true, true, false},
+		{"8.6.9", true, true, false},
+		{"9.0.108", true, true, true},
+		{"", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			caps := capabilitiesForVersion(tt.version)
+			if caps.TrafficRoutes != tt.trafficRoutes {
+				t.Errorf("TrafficRoutes = %v, want %v", caps.TrafficRoutes, tt.trafficRoutes)
+			}
+			if caps.PPSK != tt.ppsk {
+				t.Errorf("PPSK = %v, want %v", caps.PPSK, tt.ppsk)
+			}
+			if caps.ZoneFirewall != tt.zoneFirewall {
+				t.Errorf("ZoneFirewall = %v, want %v", caps.ZoneFirewall, tt.zoneFirewall)
+			}
+		})
+	}
+}
+
+func TestClient_Capabilities(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := c.Capabilities(ctx); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Capabilities() before Connect() error = %v, want ErrNotConnected", err)
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+
+	if caps.Version != "7.5.174" {
+		t.Errorf("Version = %s, want 7.5.174", caps.Version)
+	}
+	if !caps.TrafficRoutes {
+		t.Error("TrafficRoutes should be available on 7.5")
+	}
+	if caps.ZoneFirewall {
+		t.Error("ZoneFirewall should not be available on 7.5")
+	}
+	if caps.IntegrationAPI {
+		t.Error("IntegrationAPI should not be detected on the mock")
+	}
+	if !caps.AtLeast("7.0") || caps.AtLeast("8.0") {
+		t.Error("AtLeast() returned unexpected result")
+	}
+}
@@ -13,6 +13,24 @@ type Client interface {
 	Disconnect(ctx context.Context) error
 	IsConnected() bool
 
+	// ChangePassword changes the admin's password and logs in again with it.
+	ChangePassword(ctx context.Context, newPassword string) error
+
+	// Ping probes reachability, session state and controller version
+	// without logging in or changing anything.
+	Ping(ctx context.Context) (*PingResult, error)
+
+	// Capabilities detects the controller version and available feature families.
+	Capabilities(ctx context.Context) (*Capabilities, error)
+
+	// Diagnostics returns a snapshot of session, transport and event stream state.
+	Diagnostics() Diagnostics
+
+	// InvalidateCache drops the lists cached under Config.ListCache for a
+	// site, or for every site if site is empty, for changes made outside
+	// the client. Without a cache it does nothing.
+	InvalidateCache(site string)
+
 	// Service accessors
 	Sites() services.SiteService
 	Devices() services.DeviceService
@@ -28,4 +46,21 @@ type Client interface {
 	System() services.SystemService
 	Events() services.EventService
 	DNS() services.DNSService
+	Hotspot() services.HotspotService
+	Alarms() services.AlarmService
+	Stats() services.StatsService
+
+	// Integration returns the official Integrations API service. Sites()
+	// and Devices().ListBasic prefer that API when the controller serves
+	// it to this client, falling back to the legacy API.
+	Integration() services.IntegrationService
+
+	// Site returns service accessors bound to a single site.
+	// An empty site selects the configured default site.
+	Site(site string) SiteClient
+
+	// Cheap clones sharing the connection pool and session.
+	// Disconnecting any clone ends the session for all of them.
+	ForSite(site string) Client
+	WithLogger(logger Logger) Client
 }
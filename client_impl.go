@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strconv"
 	"sync"
@@ -17,26 +18,42 @@ import (
 
 // client implements the Client interface.
 type client struct {
-	config    *Config
-	transport transport.Transport
-	auth      auth.Manager
-	connected atomic.Bool
+	config      *Config
+	transport   transport.Transport
+	paths       *transport.PathTransport
+	cache       *listCache  // nil without Config.ListCache
+	writes      *writeQueue // nil without Config.SerializeWrites
+	inflight    *inflight   // nil without Config.GracefulDisconnect
+	integration *integrationRouter
+	auth        auth.Manager
+	connected   *atomic.Bool // shared between clones
+	events      *eventHub    // shared between clones
+	devices     *deviceHub   // shared between clones
+
+	// Where the event service connects its WebSockets
+	baseURL   string
+	tlsConfig *tls.Config
+	headers   http.Header
+	cookies   http.CookieJar // the session cookies of a login
 
 	// Lazy-initialized services
-	mu                  sync.Mutex
-	sitesService        services.SiteService
-	devicesService      services.DeviceService
-	networksService     services.NetworkService
-	wlansService        services.WLANService
-	firewallService     services.FirewallService
-	clientsService      services.ClientService
-	usersService        services.UserService
-	routingService      services.RoutingService
-	portForwardService  services.PortForwardService
-	portProfileService  services.PortProfileService
-	settingService      services.SettingService
-	systemService       services.SystemService
-	dnsService          services.DNSService
+	mu                 sync.Mutex
+	sitesService       services.SiteService
+	devicesService     services.DeviceService
+	networksService    services.NetworkService
+	wlansService       services.WLANService
+	firewallService    services.FirewallService
+	clientsService     services.ClientService
+	usersService       services.UserService
+	routingService     services.RoutingService
+	portForwardService services.PortForwardService
+	portProfileService services.PortProfileService
+	settingService     services.SettingService
+	systemService      services.SystemService
+	dnsService         services.DNSService
+	hotspotService     services.HotspotService
+	alarmService       services.AlarmService
+	statsService       services.StatsService
 
 	logger Logger
 }
@@ -47,24 +64,26 @@ func New(config *Config, opts ...Option) (Client, error) {
 		return nil, ErrInvalidConfig
 	}
 
-	// Validate required fields
+	// Validate required fields, reporting every missing one
+	var verrs ValidationErrors
 	if config.Host == "" {
-		return nil, NewValidationError("Host", "required")
+		verrs.Add("Host", "required")
 	}
 
-	if config.Username == "" {
-		return nil, NewValidationError("Username", "required")
+	// An API key replaces the username and password
+	if config.Username == "" && config.APIKey == "" {
+		verrs.Add("Username", "required")
 	}
 
-	if config.Password == "" {
-		return nil, NewValidationError("Password", "required")
+	if config.Password == "" && config.APIKey == "" {
+		verrs.Add("Password", "required")
 	}
 
-	// Apply defaults
-	if config.Port == 0 {
-		config.Port = 443
+	if err := verrs.Err(); err != nil {
+		return nil, err
 	}
 
+	// Apply defaults
 	if config.Site == "" {
 		config.Site = "default"
 	}
@@ -82,6 +101,25 @@ func New(config *Config, opts ...Option) (Client, error) {
 		opt(config)
 	}
 
+	// Self-hosted controllers listen on 8443, UniFi OS consoles on 443
+	if config.Port == 0 {
+		config.Port = 443
+		if config.ConsoleType == ConsoleClassic {
+			config.Port = 8443
+		}
+	}
+
+	if config.RateLimit != nil && !(config.RateLimit.RequestsPerSecond > 0) {
+		verrs.Add("RateLimit.RequestsPerSecond", "must be positive")
+		return nil, verrs.Err()
+	}
+
+	if config.MaintenancePolicy != nil {
+		if err := config.MaintenancePolicy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid maintenance policy: %w", err)
+		}
+	}
+
 	// Build base URL
 	baseURL := &url.URL{
 		Scheme: "https",
@@ -93,6 +131,24 @@ func New(config *Config, opts ...Option) (Client, error) {
 	transportConfig.Timeout = config.Timeout
 	transportConfig.MaxIdleConns = config.MaxIdleConns
 	transportConfig.TLSConfig = config.TLSConfig
+	if config.UserAgent != "" {
+		transportConfig.UserAgent = config.UserAgent
+	}
+	transportConfig.Headers = config.Headers
+
+	// API keys only exist on UniFi OS, and go with every request
+	if config.APIKey != "" {
+		if config.ConsoleType == ConsoleClassic {
+			verrs.Add("APIKey", "not supported by classic controllers")
+			return nil, verrs.Err()
+		}
+		config.ConsoleType = ConsoleUniFiOS
+		transportConfig.Headers = config.Headers.Clone()
+		if transportConfig.Headers == nil {
+			transportConfig.Headers = make(http.Header)
+		}
+		transportConfig.Headers.Set(auth.APIKeyHeader, config.APIKey)
+	}
 
 	// Apply TLS skip verify if configured
 	if config.SkipTLSVerify {
@@ -108,38 +164,133 @@ func New(config *Config, opts ...Option) (Client, error) {
 		return nil, fmt.Errorf("failed to create transport: %w", err)
 	}
 
+	// Rewrite paths for classic controllers once the console type is known
+	paths := transport.NewPathTransport(baseTransport)
+	paths.SetClassic(config.ConsoleType == ConsoleClassic)
+
+	// Run requests with an idempotency key at most once, below the
+	// retries so that a retry cannot repeat an ambiguous command
+	var trans transport.Transport = transport.NewIdempotencyTransport(paths, 0)
+
+	// Limit the request rate below the retries, so that they count too,
+	// but above the idempotency keys: a request the limiter refuses was
+	// never sent, and must not leave its key looking ambiguous
+	if config.RateLimit != nil {
+		trans = transport.NewRateLimitTransport(trans, config.RateLimit.RequestsPerSecond, config.RateLimit.Burst)
+	}
+
 	// Wrap with retry if configured
-	var trans transport.Transport
 	if config.RetryConfig != nil {
-		retryConfig := &transport.RetryConfig{
-			MaxRetries:     config.RetryConfig.MaxRetries,
-			InitialBackoff: config.RetryConfig.InitialBackoff,
-			MaxBackoff:     config.RetryConfig.MaxBackoff,
-		}
-		trans = transport.NewRetryTransport(baseTransport, retryConfig)
-	} else {
-		trans = baseTransport
+		trans = transport.NewRetryTransport(trans, transportRetryConfig(config.RetryConfig, config.Logger))
+	}
+
+	// Send each site's writes one at a time; retries of a write keep its
+	// place in the queue
+	var writes *writeQueue
+	if config.SerializeWrites {
+		writes = newWriteQueue()
+		trans = &writeQueueTransport{transport: trans, queue: writes}
+	}
+
+	// Drop cached lists on writes
+	var cache *listCache
+	if config.ListCache != nil {
+		cache = newListCache(*config.ListCache)
+		trans = &cacheTransport{transport: trans, cache: cache}
 	}
 
 	// Create auth manager
 	authMgr := auth.New(trans, config.Username, config.Password)
+	if config.APIKey != "" {
+		authMgr = auth.NewAPIKey(trans, config.APIKey)
+	}
+
+	// Count the services' requests so that Disconnect can wait for them;
+	// login and logout go around the count
+	var requests *inflight
+	if config.GracefulDisconnect {
+		requests = &inflight{}
+		trans = &drainTransport{transport: trans, inflight: requests}
+	}
 
 	c := &client{
-		config:    config,
-		transport: trans,
-		auth:      authMgr,
-		logger:    config.Logger,
+		config:      config,
+		transport:   trans,
+		paths:       paths,
+		cache:       cache,
+		writes:      writes,
+		inflight:    requests,
+		integration: newIntegrationRouter(services.NewIntegrationService(trans), config.Logger, config.UseIntegrationAPI),
+		auth:        authMgr,
+		connected:   new(atomic.Bool),
+		events:      &eventHub{},
+		devices:     &deviceHub{},
+		baseURL:     baseURL.String(),
+		tlsConfig:   transportConfig.TLSConfig,
+		headers:     transportConfig.Headers,
+		cookies:     transport.GetCookieJar(baseTransport),
+		logger:      config.Logger,
 	}
 
 	return c, nil
 }
 
+// clone returns a shallow copy of the client that shares the transport,
+// session and connection state but has its own config and service cache.
+func (c *client) clone() *client {
+	config := *c.config
+	return &client{
+		config:      &config,
+		transport:   c.transport,
+		paths:       c.paths,
+		cache:       c.cache,
+		writes:      c.writes,
+		inflight:    c.inflight,
+		integration: c.integration,
+		auth:        c.auth,
+		connected:   c.connected,
+		events:      c.events,
+		devices:     c.devices,
+		baseURL:     c.baseURL,
+		tlsConfig:   c.tlsConfig,
+		headers:     c.headers,
+		cookies:     c.cookies,
+		logger:      c.logger,
+	}
+}
+
+// ForSite returns a client whose default site is site.
+func (c *client) ForSite(site string) Client {
+	clone := c.clone()
+	clone.config.Site = site
+	return clone
+}
+
+// WithLogger returns a client that logs to logger.
+func (c *client) WithLogger(logger Logger) Client {
+	clone := c.clone()
+	clone.config.Logger = logger
+	clone.logger = logger
+	return clone
+}
+
 // Connect establishes a connection to the UniFi controller.
 func (c *client) Connect(ctx context.Context) error {
 	if c.connected.Load() {
 		return ErrAlreadyConnected
 	}
 
+	// Pick the API paths before logging in, since the login path differs
+	if c.config.ConsoleType == "" || c.config.ConsoleType == ConsoleUnknown {
+		consoleType := c.detectConsoleType(ctx)
+		if consoleType != ConsoleUnknown {
+			c.paths.SetClassic(consoleType == ConsoleClassic)
+		}
+		if c.logger != nil {
+			c.logger.Debug("Detected console type", "type", consoleType)
+		}
+	}
+
 	// Perform authentication
 	if err := c.auth.Login(ctx); err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
@@ -147,6 +298,13 @@ func (c *client) Connect(ctx context.Context) error {
 
 	c.connected.Store(true)
 
+	// The controller may have changed since the last connection. A key
+	// was just checked against the Integrations API, so it needs no probe
+	c.integration.reset()
+	if c.config.APIKey != "" {
+		c.integration.assume(true)
+	}
+
 	if c.logger != nil {
 		c.logger.Info("Connected to UniFi controller", "host", c.config.Host)
 	}
@@ -154,12 +312,34 @@ func (c *client) Connect(ctx context.Context) error {
 	return nil
 }
 
-// Disconnect closes the connection to the UniFi controller.
+// Disconnect closes the connection to the UniFi controller. With
+// GracefulDisconnect it first waits for in-flight requests, returning
+// ctx's error if they had not finished when ctx was done; it disconnects
+// either way.
 func (c *client) Disconnect(ctx context.Context) error {
 	if !c.connected.Load() {
 		return nil // Already disconnected
 	}
 
+	// Turn off the LEDs of LocateFor calls while requests are still
+	// accepted and the session is open
+	if err := c.devices.unlocate(ctx); err != nil && c.logger != nil {
+		c.logger.Warn("Locate LEDs left on", "error", err)
+	}
+
+	var drainErr error
+	if c.inflight != nil {
+		if drainErr = c.inflight.drain(ctx); drainErr != nil && c.logger != nil {
+			c.logger.Warn("Disconnecting with requests in flight", "requests", c.inflight.count(), "error", drainErr)
+		}
+		defer c.inflight.reopen()
+	}
+
+	// Stop the event streams before the session they use ends
+	if err := c.events.shutdown(ctx); err != nil && c.logger != nil {
+		c.logger.Warn("Event streams did not stop", "error", err)
+	}
+
 	// Logout
 	if err := c.auth.Logout(ctx); err != nil {
 		if c.logger != nil {
@@ -175,7 +355,7 @@ func (c *client) Disconnect(ctx context.Context) error {
 		c.logger.Info("Disconnected from UniFi controller")
 	}
 
-	return nil
+	return drainErr
 }
 
 // IsConnected returns true if the client is connected.
@@ -189,7 +369,7 @@ func (c *client) Sites() services.SiteService {
 	defer c.mu.Unlock()
 
 	if c.sitesService == nil {
-		c.sitesService = services.NewSiteService(c.transport)
+		c.sitesService = &integrationSites{services.NewSiteService(c.transport), c.integration}
 	}
 
 	return c.sitesService
@@ -201,12 +381,28 @@ func (c *client) Devices() services.DeviceService {
 	defer c.mu.Unlock()
 
 	if c.devicesService == nil {
-		c.devicesService = services.NewDeviceService(c.transport)
+		c.devicesService = c.devices.get(func() services.DeviceService {
+			return services.NewDeviceService(c.transport, services.WithUnlocateErrorHandler(c.logUnlocateError))
+		})
+		c.devicesService = &integrationDevices{c.devicesService, c.integration}
+		if c.cache != nil {
+			c.devicesService = &cachedDevices{c.devicesService, c.cache}
+		}
+		if c.config.MaintenancePolicy != nil {
+			c.devicesService = &maintenanceDevices{c.devicesService, c.config.MaintenancePolicy}
+		}
 	}
 
 	return c.devicesService
 }
 
+// logUnlocateError logs a LocateFor timer that failed to turn an LED off.
+func (c *client) logUnlocateError(site, mac string, err error) {
+	if c.logger != nil {
+		c.logger.Warn("Failed to turn off locate LED", "site", site, "mac", mac, "error", err)
+	}
+}
+
 // Networks returns the network service.
 func (c *client) Networks() services.NetworkService {
 	c.mu.Lock()
@@ -214,6 +410,9 @@ func (c *client) Networks() services.NetworkService {
 
 	if c.networksService == nil {
 		c.networksService = services.NewNetworkService(c.transport)
+		if c.cache != nil {
+			c.networksService = &cachedNetworks{c.networksService, c.cache}
+		}
 	}
 
 	return c.networksService
@@ -249,7 +448,7 @@ func (c *client) Clients() services.ClientService {
 	defer c.mu.Unlock()
 
 	if c.clientsService == nil {
-		c.clientsService = services.NewClientService(c.transport)
+		c.clientsService = &integrationClients{services.NewClientService(c.transport), c.integration}
 	}
 
 	return c.clientsService
@@ -262,6 +461,9 @@ func (c *client) Users() services.UserService {
 
 	if c.usersService == nil {
 		c.usersService = services.NewUserService(c.transport)
+		if c.cache != nil {
+			c.usersService = &cachedUsers{c.usersService, c.cache}
+		}
 	}
 
 	return c.usersService
@@ -327,9 +529,21 @@ func (c *client) System() services.SystemService {
 	return c.systemService
 }
 
-// Events returns the event service.
+// Events returns the event service, shared with the client's clones. Its
+// streams end for good when it is closed; History keeps working.
+// Disconnect closes it, and the next call after that returns a new one.
 func (c *client) Events() services.EventService {
-	return nil // Implemented in Phase 18
+	return c.events.get(func() services.EventService {
+		opts := []services.EventServiceOption{
+			services.WithEventTransport(c.transport),
+			services.WithEventHeaders(c.headers),
+			services.WithEventCookieJar(c.cookies),
+		}
+		if c.paths != nil {
+			opts = append(opts, services.WithEventPaths(c.paths.Path))
+		}
+		return services.NewEventService(c.baseURL, c.tlsConfig, opts...)
+	})
 }
 
 // DNS returns the DNS service.
@@ -343,3 +557,44 @@ func (c *client) DNS() services.DNSService {
 
 	return c.dnsService
 }
+
+// Hotspot returns the hotspot service.
+func (c *client) Hotspot() services.HotspotService {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hotspotService == nil {
+		c.hotspotService = services.NewHotspotService(c.transport)
+	}
+
+	return c.hotspotService
+}
+
+// Alarms returns the alarm service.
+func (c *client) Alarms() services.AlarmService {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.alarmService == nil {
+		c.alarmService = services.NewAlarmService(c.transport)
+	}
+
+	return c.alarmService
+}
+
+// Integration returns the Integrations API service.
+func (c *client) Integration() services.IntegrationService {
+	return c.integration.service
+}
+
+// Stats returns the stats service.
+func (c *client) Stats() services.StatsService {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statsService == nil {
+		c.statsService = services.NewStatsService(c.transport)
+	}
+
+	return c.statsService
+}
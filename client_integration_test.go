@@ -178,7 +178,7 @@ func TestClient_Integration_ClientService(t *testing.T) {
 		MAC:      "aa:bb:cc:dd:ee:f1",
 		Hostname: "test-device",
 		IP:       "192.168.1.100",
-		LastSeen: time.Now().Unix() - 60, // Recent (1 minute ago)
+		LastSeen: types.NewFlexTime(time.Now().Unix() - 60), // Recent (1 minute ago)
 	})
 
 	config := &Config{
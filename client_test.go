@@ -2,10 +2,17 @@ package gofi
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
 )
 
 func TestNew_ValidConfig(t *testing.T) {
@@ -46,6 +53,19 @@ func TestNew_InvalidConfig(t *testing.T) {
 	}
 }
 
+func TestNew_InvalidConfig_AllFields(t *testing.T) {
+	_, err := New(&Config{})
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("New() error = %T, want ValidationErrors", err)
+	}
+
+	if len(verrs) != 3 {
+		t.Errorf("got %d violations, want 3: %v", len(verrs), err)
+	}
+}
+
 func TestNew_Defaults(t *testing.T) {
 	config := &Config{
 		Host:     "192.168.1.1",
@@ -310,4 +330,406 @@ func TestOptions(t *testing.T) {
 	if config.RetryConfig.MaxRetries != 5 {
 		t.Errorf("MaxRetries = %d, want 5", config.RetryConfig.MaxRetries)
 	}
+
+	WithUserAgent("backup-job/2.1")(config)
+	if config.UserAgent != "backup-job/2.1" {
+		t.Errorf("UserAgent = %s, want backup-job/2.1", config.UserAgent)
+	}
+
+	WithHeader("X-Team", "netops")(config)
+	WithHeader("x-team", "oncall")(config)
+	if got := config.Headers.Values("X-Team"); len(got) != 2 {
+		t.Errorf("Headers[X-Team] = %v, want both values", got)
+	}
+}
+
+type recordingLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *recordingLogger) record(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) { l.record(msg) }
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{})  { l.record(msg) }
+func (l *recordingLogger) Warn(msg string, keysAndValues ...interface{})  { l.record(msg) }
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) { l.record(msg) }
+
+func TestClient_RetryHooks(t *testing.T) {
+	server := mock.NewServer(mock.WithScenario(&mock.ErrorScenario{
+		Path:       "/proxy/network/api/s/default/stat/device",
+		StatusCode: http.StatusServiceUnavailable,
+		RC:         "error",
+		Message:    "Service unavailable",
+	}))
+	defer server.Close()
+
+	var retries, gaveUp []RetryEvent
+	logger := &recordingLogger{}
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+		Logger:        logger,
+		RetryConfig: &RetryConfig{
+			MaxRetries:     2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			OnRetry:        func(e RetryEvent) { retries = append(retries, e) },
+			OnGiveUp:       func(e RetryEvent) { gaveUp = append(gaveUp, e) },
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	if _, err := c.Devices().List(ctx, "default"); err == nil {
+		t.Fatal("Devices().List() error = nil, want 503")
+	}
+
+	if len(retries) != 2 || retries[1].Attempt != 2 || retries[1].StatusCode != http.StatusServiceUnavailable || !strings.Contains(retries[1].Path, "stat/device") {
+		t.Errorf("OnRetry events = %+v, want 2 retries of stat/device with status 503", retries)
+	}
+	if len(gaveUp) != 1 || gaveUp[0].Attempt != 3 {
+		t.Errorf("OnGiveUp events = %+v, want one after attempt 3", gaveUp)
+	}
+
+	counts := map[string]int{}
+	for _, msg := range logger.msgs {
+		counts[msg]++
+	}
+	if counts["Retrying request"] != 2 || counts["Request failed after retries"] != 1 {
+		t.Errorf("logged %v, want 2 retries and 1 give-up", logger.msgs)
+	}
+}
+
+func TestClient_ForSite(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	branch := c.ForSite("branch")
+
+	// Clone shares the session
+	if !branch.IsConnected() {
+		t.Error("ForSite() clone should share connection state")
+	}
+
+	impl := branch.(*client)
+	if impl.config.Site != "branch" {
+		t.Errorf("clone Site = %s, want branch", impl.config.Site)
+	}
+	if impl.transport != c.(*client).transport {
+		t.Error("clone should share the transport")
+	}
+
+	// Original is unchanged
+	if site := c.(*client).config.Site; site != "default" {
+		t.Errorf("original Site = %s, want default", site)
+	}
+
+	if name := branch.Site("").Name(); name != "branch" {
+		t.Errorf("Site(\"\").Name() = %s, want branch", name)
+	}
+
+	if _, err := branch.Devices().List(ctx, "default"); err != nil {
+		t.Fatalf("clone Devices().List() error = %v", err)
+	}
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	logger := &recordingLogger{}
+	logged := c.WithLogger(logger)
+
+	if c.(*client).logger != nil {
+		t.Error("original client logger should be unchanged")
+	}
+
+	ctx := context.Background()
+	if err := logged.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	if !c.IsConnected() {
+		t.Error("original client should see the clone's session")
+	}
+
+	_ = logged.Disconnect(ctx)
+
+	if len(logger.msgs) == 0 {
+		t.Error("expected clone to log connection messages")
+	}
+	if c.IsConnected() {
+		t.Error("Disconnect() on a clone should disconnect all clones")
+	}
+}
+
+// connectMock returns a client connected to server.
+func TestClient_Connect_DetectsClassicController(t *testing.T) {
+	server := mock.NewServer(mock.WithClassicController())
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01", Name: "ap"})
+
+	c := connectMock(t, server)
+	devices, err := c.Devices().List(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(devices) != 1 {
+		t.Errorf("List() = %d devices, want 1", len(devices))
+	}
+}
+
+func TestClient_Classic_EventsAndLogout(t *testing.T) {
+	rec := &pathRecorder{}
+	server := mock.NewServer(mock.WithClassicController(), mock.WithScenario(rec))
+	defer server.Close()
+	c := connectMock(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, _, err := c.Events().Subscribe(ctx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for received := false; !received; {
+		select {
+		case <-events:
+			received = true
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for an event from the classic WebSocket")
+		}
+	}
+
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	if n := rec.count("/api/logout"); n != 1 {
+		t.Errorf("classic logout requests = %d, want 1", n)
+	}
+
+	impl, err := New(&Config{Host: "h", Username: "admin", Password: "admin"}, WithConsoleType(ConsoleClassic))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if port := impl.(*client).config.Port; port != 8443 {
+		t.Errorf("classic Port = %d, want 8443", port)
+	}
+}
+
+func TestClient_Connect_ConsoleTypeOverride(t *testing.T) {
+	server := mock.NewServer(mock.WithClassicController())
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	}, WithConsoleType(ConsoleUniFiOS))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err == nil {
+		t.Error("Connect() with UniFi OS paths to a classic controller succeeded, want error")
+	}
+}
+
+func TestClient_CSRFRefresh(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	c := connectMock(t, server)
+	ctx := context.Background()
+	network := &types.Network{Name: "IoT", Purpose: "corporate", IPSubnet: "10.20.0.1/24"}
+
+	server.InvalidateCSRF(true)
+	if _, err := c.Networks().Create(ctx, "default", network); err == nil {
+		t.Fatal("Create() with the stale CSRF token succeeded")
+	}
+	if _, err := c.Networks().Create(ctx, "default", network); err != nil {
+		t.Errorf("Create() after the token was refreshed error = %v", err)
+	}
+}
+
+func TestClient_SessionExpiredMidRequest(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	server.ExpireSessionAfter(0)
+	if _, err := c.Devices().List(ctx, "default"); err == nil {
+		t.Fatal("List() with the expired session succeeded")
+	}
+
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if _, err := c.Devices().List(ctx, "default"); err != nil {
+		t.Errorf("List() after logging in again error = %v", err)
+	}
+}
+
+func connectMock(t *testing.T, server *mock.Server, opts ...Option) Client {
+	t.Helper()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	}, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+func TestClient_APIKey(t *testing.T) {
+	rec := &pathRecorder{}
+	server := mock.NewServer(mock.WithAPIKey("k3y"), mock.WithIntegrationAPI(), mock.WithScenario(rec))
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01", Name: "ap"})
+
+	c, err := New(&Config{Host: server.Host(), Port: server.Port(), APIKey: "k3y", SkipTLSVerify: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Disconnect(ctx)
+
+	// Writes need no CSRF token and summary lists use the Integrations API
+	network := &types.Network{Name: "IoT", Purpose: "corporate", IPSubnet: "10.20.0.1/24"}
+	if _, err := c.Networks().Create(ctx, "default", network); err != nil {
+		t.Errorf("Create() error = %v", err)
+	}
+	if devices, err := c.Devices().ListBasic(ctx, "default"); err != nil || len(devices) != 1 {
+		t.Errorf("ListBasic() = %v, %v", devices, err)
+	}
+
+	if n := rec.count("/api/auth/login"); n != 0 {
+		t.Errorf("login requests = %d, want 0", n)
+	}
+	if n := rec.count("/basicstat/device"); n != 0 {
+		t.Errorf("legacy device list requests = %d, want 0", n)
+	}
+	if n := rec.count("/integration/v1/info"); n != 1 {
+		t.Errorf("info requests = %d, want only the key check", n)
+	}
+}
+
+func TestClient_APIKey_Rejected(t *testing.T) {
+	server := mock.NewServer(mock.WithAPIKey("k3y"), mock.WithIntegrationAPI())
+	defer server.Close()
+
+	c, err := New(&Config{Host: server.Host(), Port: server.Port(), APIKey: "wrong", SkipTLSVerify: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err == nil {
+		t.Error("Connect() with a wrong API key succeeded")
+	}
+
+	_, err = New(&Config{Host: "h", APIKey: "k3y"}, WithConsoleType(ConsoleClassic))
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Errorf("New() with an API key for a classic controller error = %v, want ValidationErrors", err)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server, WithRateLimit(1000, 5))
+	if _, err := c.Devices().List(context.Background(), "default"); err != nil {
+		t.Errorf("List() error = %v", err)
+	}
+
+	_, err := New(&Config{Host: "h", Username: "u", Password: "p"}, WithRateLimit(0, 5))
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Errorf("New() with a zero rate error = %v, want ValidationErrors", err)
+	}
+}
+
+func TestClient_RateLimit_IdempotencyKey(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01", Name: "ap"})
+
+	// The login takes the only token; the next one comes after 500ms
+	c := connectMock(t, server, WithRateLimit(2, 1))
+	key := transport.NewIdempotencyKey()
+
+	ctx, cancel := context.WithTimeout(services.WithIdempotencyKey(context.Background(), key), 20*time.Millisecond)
+	defer cancel()
+	err := c.Devices().Restart(ctx, "default", "aa:bb:cc:dd:ee:01")
+	if err == nil || errors.Is(err, transport.ErrOutcomeUnknown) {
+		t.Fatalf("Restart() held back by the limiter error = %v, want a deadline error", err)
+	}
+
+	// Nothing was sent, so the key can be used again
+	ctx = services.WithIdempotencyKey(context.Background(), key)
+	if err := c.Devices().Restart(ctx, "default", "aa:bb:cc:dd:ee:01"); err != nil {
+		t.Errorf("Restart() with the same key error = %v", err)
+	}
 }
@@ -0,0 +1,337 @@
+// Package collectors provides Prometheus collectors for UniFi controller
+// metrics that any prometheus.Registerer can mount:
+//
+//	reg.MustRegister(
+//		collectors.NewDeviceCollector(client, "default"),
+//		collectors.NewPoECollector(client, "default"),
+//		collectors.NewClientCollector(client, "default"),
+//		collectors.NewWANCollector(client, "default"),
+//	)
+//
+// Each collector queries the controller when it is collected, so scrape
+// timeouts should allow for controller latency (see WithTimeout). A failed
+// query is reported to the registry as an invalid metric, which fails the
+// scrape rather than exporting partial data as complete. The client must
+// be connected; collectors do not log in.
+//
+// It is a separate module so that the core gofi module stays free of the
+// Prometheus client dependency. Metric names match those of the
+// gofi-exporter utility.
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/types"
+)
+
+// Option configures a collector.
+type Option func(*options)
+
+// options holds collector options.
+type options struct {
+	timeout time.Duration
+}
+
+// WithTimeout bounds the controller queries of one collection
+// (default: 10s).
+func WithTimeout(d time.Duration) Option {
+	return func(opts *options) {
+		opts.timeout = d
+	}
+}
+
+// base holds what every collector needs.
+type base struct {
+	client  gofi.Client
+	site    string
+	options options
+}
+
+func newBase(c gofi.Client, site string, opts []Option) base {
+	b := base{client: c, site: site, options: options{timeout: 10 * time.Second}}
+	for _, opt := range opts {
+		opt(&b.options)
+	}
+	return b
+}
+
+// context returns a context bounded by the collector's timeout.
+func (b *base) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), b.options.timeout)
+}
+
+// desc is a shorthand for prometheus.NewDesc without constant labels.
+func desc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(name, help, labels, nil)
+}
+
+// gauge sends a gauge sample.
+func gauge(ch chan<- prometheus.Metric, d *prometheus.Desc, v float64, labels ...string) {
+	ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, labels...)
+}
+
+// deviceName returns the device name, falling back to its MAC.
+func deviceName(d *types.Device) string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.MAC
+}
+
+// boolValue converts b to 1 or 0.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+var deviceLabels = []string{"site", "mac", "name", "type"}
+
+// DeviceCollector exports device status.
+type DeviceCollector struct {
+	base
+	info, up, state, uptime *prometheus.Desc
+}
+
+// NewDeviceCollector returns a collector of the site's device status.
+func NewDeviceCollector(c gofi.Client, site string, opts ...Option) *DeviceCollector {
+	return &DeviceCollector{
+		base:   newBase(c, site, opts),
+		info:   desc("unifi_device_info", "Device metadata; always 1.", append(deviceLabels, "model", "version")...),
+		up:     desc("unifi_device_up", "Whether the device is online (1) or not (0).", deviceLabels...),
+		state:  desc("unifi_device_state", "Raw controller device state code.", deviceLabels...),
+		uptime: desc("unifi_device_uptime_seconds", "Device uptime in seconds.", deviceLabels...),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *DeviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.up
+	ch <- c.state
+	ch <- c.uptime
+}
+
+// Collect implements prometheus.Collector.
+func (c *DeviceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := c.context()
+	defer cancel()
+	devices, err := c.client.Devices().List(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.up, err)
+		return
+	}
+
+	for i := range devices {
+		d := &devices[i]
+		labels := []string{c.site, strings.ToLower(d.MAC), deviceName(d), d.Type}
+		gauge(ch, c.info, 1, append(labels, d.ModelName(), d.Version)...)
+		gauge(ch, c.up, boolValue(d.State.IsOnline()), labels...)
+		gauge(ch, c.state, float64(d.State), labels...)
+		gauge(ch, c.uptime, d.Uptime.Float64(), labels...)
+	}
+}
+
+// PoECollector exports PoE power drawn per port and per device, and the
+// PoE budget of device models that publish one.
+type PoECollector struct {
+	base
+	portWatts, portEnabled, used, budget *prometheus.Desc
+}
+
+// NewPoECollector returns a collector of the site's PoE power.
+func NewPoECollector(c gofi.Client, site string, opts ...Option) *PoECollector {
+	portLabels := append(append([]string(nil), deviceLabels...), "port", "port_name")
+	return &PoECollector{
+		base:        newBase(c, site, opts),
+		portWatts:   desc("unifi_port_poe_watts", "PoE power drawn on the port in watts.", portLabels...),
+		portEnabled: desc("unifi_port_poe_enabled", "Whether PoE output is enabled on the port.", portLabels...),
+		used:        desc("unifi_device_poe_used_watts", "Total PoE power drawn from the device in watts.", deviceLabels...),
+		budget:      desc("unifi_device_poe_budget_watts", "Published PoE budget of the device model in watts.", deviceLabels...),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoECollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.portWatts
+	ch <- c.portEnabled
+	ch <- c.used
+	ch <- c.budget
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoECollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := c.context()
+	defer cancel()
+	devices, err := c.client.Devices().List(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.used, err)
+		return
+	}
+
+	for i := range devices {
+		d := &devices[i]
+		labels := []string{c.site, strings.ToLower(d.MAC), deviceName(d), d.Type}
+
+		var used float64
+		hasPoE := false
+		for _, p := range d.PoEPorts() {
+			hasPoE = true
+			watts := p.PoePower.Float64()
+			used += watts
+			port := append(append([]string(nil), labels...), strconv.Itoa(p.PortIdx), p.Name)
+			gauge(ch, c.portWatts, watts, port...)
+			gauge(ch, c.portEnabled, boolValue(p.PoeEnable), port...)
+		}
+		if !hasPoE {
+			continue
+		}
+		gauge(ch, c.used, used, labels...)
+		if info, ok := d.ModelInfo(); ok && info.PoEBudget > 0 {
+			gauge(ch, c.budget, info.PoEBudget, labels...)
+		}
+	}
+}
+
+// ClientCollector exports connected client counts by connection type and
+// per access point.
+type ClientCollector struct {
+	base
+	clients, guests, apClients *prometheus.Desc
+}
+
+// NewClientCollector returns a collector of the site's client counts.
+func NewClientCollector(c gofi.Client, site string, opts ...Option) *ClientCollector {
+	return &ClientCollector{
+		base:      newBase(c, site, opts),
+		clients:   desc("unifi_clients", "Connected clients by connection type.", "site", "connection"),
+		guests:    desc("unifi_guest_clients", "Connected guest clients.", "site"),
+		apClients: desc("unifi_ap_clients", "Wireless clients associated with the access point.", "site", "mac", "name"),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ClientCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clients
+	ch <- c.guests
+	ch <- c.apClients
+}
+
+// Collect implements prometheus.Collector. Every access point is
+// reported, including those with no clients.
+func (c *ClientCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := c.context()
+	defer cancel()
+	clients, err := c.client.Clients().ListActive(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.clients, err)
+		return
+	}
+	devices, err := c.client.Devices().List(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.apClients, err)
+		return
+	}
+
+	perAP := make(map[string]int)
+	var wired, wireless, guests int
+	for _, cl := range clients {
+		if cl.IsGuest.Val {
+			guests++
+		}
+		if cl.IsWired.Val {
+			wired++
+			continue
+		}
+		wireless++
+		perAP[strings.ToLower(cl.APMA)]++
+	}
+
+	gauge(ch, c.clients, float64(wired), c.site, "wired")
+	gauge(ch, c.clients, float64(wireless), c.site, "wireless")
+	gauge(ch, c.guests, float64(guests), c.site)
+	for i := range devices {
+		d := &devices[i]
+		if d.Type != "uap" && len(d.RadioTable) == 0 {
+			continue
+		}
+		mac := strings.ToLower(d.MAC)
+		gauge(ch, c.apClients, float64(perAP[mac]), c.site, mac, deviceName(d))
+	}
+}
+
+// WANCollector exports WAN health: subsystem status, internet latency and
+// drops, and the state of each gateway WAN interface.
+type WANCollector struct {
+	base
+	healthOK, latency, drops, wanUp, wanLatency *prometheus.Desc
+}
+
+// NewWANCollector returns a collector of the site's WAN health.
+func NewWANCollector(c gofi.Client, site string, opts ...Option) *WANCollector {
+	wanLabels := append(append([]string(nil), deviceLabels...), "wan", "ifname")
+	return &WANCollector{
+		base:       newBase(c, site, opts),
+		healthOK:   desc("unifi_health_ok", "Whether the subsystem reports status ok (1) or not (0).", "site", "subsystem"),
+		latency:    desc("unifi_internet_latency_seconds", "Internet latency measured by the gateway in seconds.", "site"),
+		drops:      desc("unifi_internet_drops", "Internet connectivity drops reported by the gateway.", "site"),
+		wanUp:      desc("unifi_wan_up", "Whether the WAN interface is up (1) or not (0).", wanLabels...),
+		wanLatency: desc("unifi_wan_latency_seconds", "WAN latency reported by the gateway in seconds.", wanLabels...),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *WANCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.healthOK
+	ch <- c.latency
+	ch <- c.drops
+	ch <- c.wanUp
+	ch <- c.wanLatency
+}
+
+// Collect implements prometheus.Collector.
+func (c *WANCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := c.context()
+	defer cancel()
+	health, err := c.client.Sites().Health(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.healthOK, err)
+		return
+	}
+	devices, err := c.client.Devices().List(ctx, c.site)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.wanUp, err)
+		return
+	}
+
+	for _, h := range health {
+		gauge(ch, c.healthOK, boolValue(h.Status == "ok"), c.site, h.Subsystem)
+		if h.Subsystem == "www" {
+			gauge(ch, c.latency, float64(h.Latency)/1000, c.site)
+			gauge(ch, c.drops, float64(h.Drops), c.site)
+		}
+	}
+
+	for i := range devices {
+		d := &devices[i]
+		labels := []string{c.site, strings.ToLower(d.MAC), deviceName(d), d.Type}
+		for _, w := range []struct {
+			name string
+			wan  *types.WAN
+		}{{"wan1", d.Wan1}, {"wan2", d.Wan2}} {
+			if w.wan == nil || !w.wan.Enable {
+				continue
+			}
+			wan := append(append([]string(nil), labels...), w.name, w.wan.IFNAME)
+			gauge(ch, c.wanUp, boolValue(w.wan.Up), wan...)
+			gauge(ch, c.wanLatency, float64(w.wan.Latency)/1000, wan...)
+		}
+	}
+}
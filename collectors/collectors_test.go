@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func connectMock(t *testing.T, server *mock.Server) gofi.Client {
+	t.Helper()
+
+	c, err := gofi.New(&gofi.Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+func newTestServer() *mock.Server {
+	server := mock.NewServer()
+	server.State().AddDevice(&types.Device{ID: "sw", MAC: "AA:00:00:00:00:01", Name: "core", Type: "usw",
+		Model: "US24P250", State: types.DeviceStateConnected, Uptime: types.FlexInt{Val: 3600},
+		PortTable: []types.PortTable{
+			{PortIdx: 1, Name: "Port 1", PortPoe: true, PoeEnable: true, PoePower: types.FlexInt{Val: 6.5}},
+			{PortIdx: 2, Name: "Port 2", PortPoe: true, PoeEnable: true, PoePower: types.FlexInt{Val: 3.5}},
+			{PortIdx: 25, Name: "SFP 1"},
+		}})
+	server.State().AddDevice(&types.Device{ID: "ap", MAC: "aa:00:00:00:00:02", Name: "office-ap", Type: "uap",
+		State: types.DeviceStateDisconnected})
+	server.State().AddDevice(&types.Device{ID: "gw", MAC: "aa:00:00:00:00:03", Name: "gateway", Type: "udm",
+		State: types.DeviceStateConnected, Wan1: &types.WAN{Enable: true, Up: true, IFNAME: "eth8", Latency: 12}})
+	now := time.Now().Unix()
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:01", APMA: "aa:00:00:00:00:02", LastSeen: types.NewFlexTime(now)})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:02", IsWired: types.FlexBool{Val: true}, LastSeen: types.NewFlexTime(now)})
+	return server
+}
+
+func TestCollectors(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(
+		NewDeviceCollector(c, "default"),
+		NewPoECollector(c, "default"),
+		NewClientCollector(c, "default"),
+		NewWANCollector(c, "default"),
+	)
+
+	expected := `
+# HELP unifi_device_poe_used_watts Total PoE power drawn from the device in watts.
+# TYPE unifi_device_poe_used_watts gauge
+unifi_device_poe_used_watts{mac="aa:00:00:00:00:01",name="core",site="default",type="usw"} 10
+# HELP unifi_device_poe_budget_watts Published PoE budget of the device model in watts.
+# TYPE unifi_device_poe_budget_watts gauge
+unifi_device_poe_budget_watts{mac="aa:00:00:00:00:01",name="core",site="default",type="usw"} 250
+# HELP unifi_device_up Whether the device is online (1) or not (0).
+# TYPE unifi_device_up gauge
+unifi_device_up{mac="aa:00:00:00:00:01",name="core",site="default",type="usw"} 1
+unifi_device_up{mac="aa:00:00:00:00:02",name="office-ap",site="default",type="uap"} 0
+unifi_device_up{mac="aa:00:00:00:00:03",name="gateway",site="default",type="udm"} 1
+# HELP unifi_clients Connected clients by connection type.
+# TYPE unifi_clients gauge
+unifi_clients{connection="wired",site="default"} 1
+unifi_clients{connection="wireless",site="default"} 1
+# HELP unifi_ap_clients Wireless clients associated with the access point.
+# TYPE unifi_ap_clients gauge
+unifi_ap_clients{mac="aa:00:00:00:00:02",name="office-ap",site="default"} 1
+# HELP unifi_wan_up Whether the WAN interface is up (1) or not (0).
+# TYPE unifi_wan_up gauge
+unifi_wan_up{ifname="eth8",mac="aa:00:00:00:00:03",name="gateway",site="default",type="udm",wan="wan1"} 1
+# HELP unifi_health_ok Whether the subsystem reports status ok (1) or not (0).
+# TYPE unifi_health_ok gauge
+unifi_health_ok{site="default",subsystem="lan"} 1
+unifi_health_ok{site="default",subsystem="wan"} 1
+unifi_health_ok{site="default",subsystem="wlan"} 1
+unifi_health_ok{site="default",subsystem="www"} 1
+`
+	names := []string{"unifi_device_poe_used_watts", "unifi_device_poe_budget_watts", "unifi_device_up",
+		"unifi_clients", "unifi_ap_clients", "unifi_wan_up", "unifi_health_ok"}
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), names...); err != nil {
+		t.Error(err)
+	}
+
+	if n := testutil.CollectAndCount(NewPoECollector(c, "default"), "unifi_port_poe_watts"); n != 2 {
+		t.Errorf("unifi_port_poe_watts samples = %d, want 2 PoE ports", n)
+	}
+}
+
+func TestCollector_Error(t *testing.T) {
+	server := newTestServer()
+	c := connectMock(t, server)
+	server.Close()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewDeviceCollector(c, "default", WithTimeout(time.Second)))
+	if _, err := reg.Gather(); err == nil {
+		t.Error("Gather() with controller down succeeded, want error")
+	}
+}
@@ -2,7 +2,10 @@ package gofi
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
+
+	"github.com/unifi-go/gofi/transport"
 )
 
 // Config holds the configuration for connecting to a UDM Pro.
@@ -10,7 +13,7 @@ type Config struct {
 	// Host is the IP address or hostname of the UDM Pro.
 	Host string
 
-	// Port is the HTTPS port (default: 443).
+	// Port is the HTTPS port (default: 443, or 8443 with ConsoleClassic).
 	Port int
 
 	// Username for local admin authentication.
@@ -19,6 +22,12 @@ type Config struct {
 	// Password for local admin authentication.
 	Password string
 
+	// APIKey authenticates with a UniFi OS API key, sent as X-API-KEY,
+	// instead of Username and Password. There is no login session or CSRF
+	// token, and the official Integrations API is preferred where it
+	// serves a call. Classic controllers do not support API keys.
+	APIKey string
+
 	// Site is the default site ID (default: "default").
 	Site string
 
@@ -35,11 +44,51 @@ type Config struct {
 	// MaxIdleConns is the maximum number of idle connections (default: 10).
 	MaxIdleConns int
 
+	// UserAgent identifies the client in controller and proxy logs
+	// (default: "gofi/1.0").
+	UserAgent string
+
+	// Headers are sent with every request, for example the credentials
+	// an authenticating proxy in front of the controller requires.
+	Headers http.Header
+
 	// RetryConfig configures automatic retries.
 	RetryConfig *RetryConfig
 
+	// RateLimit spaces out requests on the client side (optional).
+	RateLimit *RateLimitConfig
+
 	// Logger for debug output (optional).
 	Logger Logger
+
+	// MaintenancePolicy restricts disruptive device operations to
+	// maintenance windows (optional).
+	MaintenancePolicy *MaintenancePolicy
+
+	// ListCache caches the results of hot List calls (optional).
+	ListCache *ListCache
+
+	// SerializeWrites sends the writes of each site one at a time
+	// (optional).
+	SerializeWrites bool
+
+	// GracefulDisconnect makes Disconnect wait, bounded by its context,
+	// for in-flight requests before logging out (optional).
+	GracefulDisconnect bool
+
+	// UseIntegrationAPI serves sites, devices and clients from the official
+	// Integrations API (/proxy/network/integration/v1) only: without a
+	// probe and without falling back to the legacy API. Full device lists,
+	// GetByMAC, Restart and the active client list come from it too, with
+	// less detail than the legacy records. Other calls still use the
+	// legacy API.
+	UseIntegrationAPI bool
+
+	// ConsoleType selects the API paths: ConsoleUniFiOS for
+	// /proxy/network/api/... and /api/auth/login, ConsoleClassic for
+	// /api/... and /api/login. Empty (or ConsoleUnknown) detects it on
+	// Connect.
+	ConsoleType ConsoleType
 }
 
 // RetryConfig configures retry behavior.
@@ -55,6 +104,59 @@ type RetryConfig struct {
 
 	// RetryableErrors are error types that trigger retries.
 	RetryableErrors []error
+
+	// OnRetry is called for each failed attempt that will be retried, and
+	// OnGiveUp when the last attempt has failed (optional). The client's
+	// Logger reports them too, at Warn and Error level.
+	OnRetry  func(RetryEvent)
+	OnGiveUp func(RetryEvent)
+}
+
+// RateLimitConfig configures client-side rate limiting. Every request
+// counts, logins and retries included; a request waits for its turn for
+// as long as its context allows.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained request rate. It must be
+	// positive.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that may be sent at once before
+	// the rate applies (default: 1).
+	Burst int
+}
+
+// RetryEvent describes a failed attempt: the endpoint, the attempt number,
+// the wait before the next attempt, and the status or error.
+type RetryEvent = transport.RetryEvent
+
+// transportRetryConfig converts the client's retry configuration, filling
+// in the backoff multiplier and retryable status codes and reporting
+// retries to logger.
+func transportRetryConfig(rc *RetryConfig, logger Logger) *transport.RetryConfig {
+	config := transport.DefaultRetryConfig()
+	config.MaxRetries = rc.MaxRetries
+	config.InitialBackoff = rc.InitialBackoff
+	config.MaxBackoff = rc.MaxBackoff
+
+	config.OnRetry = func(e RetryEvent) {
+		if logger != nil {
+			logger.Warn("Retrying request", "method", e.Method, "path", e.Path, "attempt", e.Attempt,
+				"wait", e.Wait, "status", e.StatusCode, "error", e.Err)
+		}
+		if rc.OnRetry != nil {
+			rc.OnRetry(e)
+		}
+	}
+	config.OnGiveUp = func(e RetryEvent) {
+		if logger != nil {
+			logger.Error("Request failed after retries", "method", e.Method, "path", e.Path, "attempts", e.Attempt,
+				"status", e.StatusCode, "error", e.Err)
+		}
+		if rc.OnGiveUp != nil {
+			rc.OnGiveUp(e)
+		}
+	}
+	return config
 }
 
 // Logger is a simple logging interface.
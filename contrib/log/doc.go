@@ -0,0 +1,8 @@
+// Package log holds gofi.Logger adapters for popular logging libraries.
+//
+// It is a separate module so that the core gofi module stays free of
+// logging dependencies. Import only the adapter you need:
+//
+//   - zaplog adapts a *zap.Logger
+//   - logruslog adapts a logrus.FieldLogger
+package log
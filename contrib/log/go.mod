@@ -0,0 +1,17 @@
+module github.com/unifi-go/gofi/contrib/log
+
+go 1.24
+
+require (
+	github.com/sirupsen/logrus v1.9.3
+	github.com/unifi-go/gofi v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/gorilla/websocket v1.5.3 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/sys v0.9.0 // indirect
+)
+
+replace github.com/unifi-go/gofi => ../..
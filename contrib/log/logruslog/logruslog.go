@@ -0,0 +1,67 @@
+// Package logruslog adapts a logrus logger to gofi.Logger.
+package logruslog
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/unifi-go/gofi"
+)
+
+// logger implements gofi.Logger on top of a logrus.FieldLogger.
+type logger struct {
+	log logrus.FieldLogger
+}
+
+// New returns a gofi.Logger that writes to l, which may be a *logrus.Logger
+// or a *logrus.Entry carrying fields of its own. gofi's key/value pairs
+// become logrus fields; an "error" value is attached with WithError.
+func New(l logrus.FieldLogger) gofi.Logger {
+	return &logger{log: l}
+}
+
+// Debug logs a debug message.
+func (l *logger) Debug(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Debug(msg)
+}
+
+// Info logs an informational message.
+func (l *logger) Info(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Info(msg)
+}
+
+// Warn logs a warning message.
+func (l *logger) Warn(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Warn(msg)
+}
+
+// Error logs an error message.
+func (l *logger) Error(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Error(msg)
+}
+
+// entry converts key/value pairs into logrus fields. A trailing key
+// without a value is kept under "extra" rather than dropped.
+func (l *logger) entry(keysAndValues []interface{}) logrus.FieldLogger {
+	if len(keysAndValues) == 0 {
+		return l.log
+	}
+
+	fields := make(logrus.Fields, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			fields["extra"] = keysAndValues[i]
+			break
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		if err, ok := keysAndValues[i+1].(error); ok && key == "error" {
+			fields[logrus.ErrorKey] = err
+			continue
+		}
+		fields[key] = keysAndValues[i+1]
+	}
+	return l.log.WithFields(fields)
+}
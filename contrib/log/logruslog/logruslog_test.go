@@ -0,0 +1,56 @@
+package logruslog
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogger(t *testing.T) {
+	base, hook := test.NewNullLogger()
+	base.SetLevel(logrus.DebugLevel)
+	l := New(base)
+
+	l.Debug("probing")
+	l.Info("Connected to UniFi controller", "host", "192.168.1.1")
+	l.Warn("Logout failed", "error", errors.New("timeout"))
+	l.Error("odd pairs", "status", 500, "dangling")
+
+	entries := hook.AllEntries()
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+
+	levels := []logrus.Level{logrus.DebugLevel, logrus.InfoLevel, logrus.WarnLevel, logrus.ErrorLevel}
+	for i, e := range entries {
+		if e.Level != levels[i] {
+			t.Errorf("entry %d level = %v, want %v", i, e.Level, levels[i])
+		}
+	}
+
+	if got := entries[1].Data["host"]; got != "192.168.1.1" {
+		t.Errorf("host field = %v, want 192.168.1.1", got)
+	}
+	if err, ok := entries[2].Data[logrus.ErrorKey].(error); !ok || err.Error() != "timeout" {
+		t.Errorf("error field = %v, want timeout", entries[2].Data[logrus.ErrorKey])
+	}
+	if got := entries[3].Data["extra"]; got != "dangling" {
+		t.Errorf("extra field = %v, want dangling", got)
+	}
+}
+
+func TestLogger_Entry(t *testing.T) {
+	base := logrus.New()
+	base.SetOutput(io.Discard)
+	hook := test.NewLocal(base)
+
+	New(base.WithField("component", "unifi")).Info("hello", "site", "default")
+
+	e := hook.LastEntry()
+	if e == nil || e.Data["component"] != "unifi" || e.Data["site"] != "default" {
+		t.Errorf("entry = %+v, want component and site fields", e)
+	}
+}
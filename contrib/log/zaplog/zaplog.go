@@ -0,0 +1,44 @@
+// Package zaplog adapts a zap logger to gofi.Logger.
+package zaplog
+
+import (
+	"github.com/unifi-go/gofi"
+	"go.uber.org/zap"
+)
+
+// logger implements gofi.Logger on top of a zap.SugaredLogger.
+type logger struct {
+	sugar *zap.SugaredLogger
+}
+
+// New returns a gofi.Logger that writes to l. gofi's key/value pairs
+// become zap fields.
+func New(l *zap.Logger) gofi.Logger {
+	// Skip the adapter's own frame so caller annotations point at gofi.
+	return &logger{sugar: l.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// NewSugared returns a gofi.Logger that writes to an existing sugared logger.
+func NewSugared(s *zap.SugaredLogger) gofi.Logger {
+	return &logger{sugar: s.WithOptions(zap.AddCallerSkip(1))}
+}
+
+// Debug logs a debug message.
+func (l *logger) Debug(msg string, keysAndValues ...interface{}) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+// Info logs an informational message.
+func (l *logger) Info(msg string, keysAndValues ...interface{}) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+// Warn logs a warning message.
+func (l *logger) Warn(msg string, keysAndValues ...interface{}) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+// Error logs an error message.
+func (l *logger) Error(msg string, keysAndValues ...interface{}) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
@@ -0,0 +1,39 @@
+package zaplog
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := New(zap.New(core))
+
+	l.Debug("probing", "host", "192.168.1.1")
+	l.Info("Connected to UniFi controller", "host", "192.168.1.1")
+	l.Warn("Logout failed", "error", errors.New("timeout"))
+	l.Error("request failed", "status", 500)
+
+	entries := logs.All()
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+
+	levels := []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel}
+	for i, e := range entries {
+		if e.Level != levels[i] {
+			t.Errorf("entry %d level = %v, want %v", i, e.Level, levels[i])
+		}
+	}
+
+	if got := entries[1].ContextMap()["host"]; got != "192.168.1.1" {
+		t.Errorf("host field = %v, want 192.168.1.1", got)
+	}
+	if got := entries[3].ContextMap()["status"]; got != int64(500) {
+		t.Errorf("status field = %v (%T), want 500", got, got)
+	}
+}
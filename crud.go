@@ -0,0 +1,215 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// ImportID returns the stable identifier "<site>/<id>" for an object, for
+// use as an import key by infrastructure-as-code tools such as Terraform.
+func ImportID(site, id string) string {
+	return site + "/" + id
+}
+
+// ParseImportID splits an identifier produced by ImportID. The site name
+// may not contain "/"; the ID is everything after the first "/".
+func ParseImportID(importID string) (site, id string, err error) {
+	site, id, ok := strings.Cut(importID, "/")
+	if !ok || site == "" || id == "" {
+		return "", "", fmt.Errorf("import ID %q is not of the form <site>/<id>: %w", importID, ErrInvalidRequest)
+	}
+	return site, id, nil
+}
+
+// IgnoreNotFound returns nil if err reports a missing resource and err
+// otherwise. It is meant for reads that refresh external state, where a
+// missing object means "remove from state" rather than failure.
+func IgnoreNotFound(err error) error {
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// CreateIfAbsent makes a create idempotent by natural key. It returns the
+// object found by find if there is one, otherwise the result of create.
+// If create reports a conflict, typically because an earlier attempt
+// succeeded but its response was lost, find is tried again. created is
+// true only if this call created the object.
+func CreateIfAbsent[T any](
+	ctx context.Context,
+	find func(ctx context.Context) (*T, error),
+	create func(ctx context.Context) (*T, error),
+) (obj *T, created bool, err error) {
+	obj, err = find(ctx)
+	if err == nil {
+		return obj, false, nil
+	}
+	if !IsNotFound(err) {
+		return nil, false, err
+	}
+
+	obj, err = create(ctx)
+	if err == nil {
+		return obj, true, nil
+	}
+	if !IsConflict(err) {
+		return nil, false, err
+	}
+
+	existing, findErr := find(ctx)
+	if findErr != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+// UpdateIfChanged calls update only if desired differs from current
+// according to types.Diff, so applying the same configuration twice sends
+// one request. desired should be derived from current (for example with
+// Clone) so that fields filled in by the controller compare equal. changed
+// reports whether update was called.
+func UpdateIfChanged[T any](
+	ctx context.Context,
+	current, desired *T,
+	update func(ctx context.Context, obj *T) (*T, error),
+) (obj *T, changed bool, err error) {
+	if len(types.Diff(current, desired)) == 0 {
+		return current, false, nil
+	}
+
+	obj, err = update(ctx, desired)
+	if err != nil {
+		return nil, false, err
+	}
+	return obj, true, nil
+}
+
+// FindNetworkByName returns the network with the given name.
+func FindNetworkByName(ctx context.Context, c Client, site, name string) (*types.Network, error) {
+	networks, err := c.Networks().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	return findOne(networks, "network", name, func(n *types.Network) bool { return n.Name == name })
+}
+
+// FindWLANBySSID returns the WLAN broadcasting the given SSID.
+func FindWLANBySSID(ctx context.Context, c Client, site, ssid string) (*types.WLAN, error) {
+	wlans, err := c.WLANs().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WLANs: %w", err)
+	}
+	return findOne(wlans, "WLAN", ssid, func(w *types.WLAN) bool { return w.Name == ssid })
+}
+
+// FindWLANGroupByName returns the WLAN group with the given name.
+func FindWLANGroupByName(ctx context.Context, c Client, site, name string) (*types.WLANGroup, error) {
+	groups, err := c.WLANs().ListGroups(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WLAN groups: %w", err)
+	}
+	return findOne(groups, "WLAN group", name, func(g *types.WLANGroup) bool { return g.Name == name })
+}
+
+// FindFirewallRuleByName returns the firewall rule with the given name in
+// a ruleset such as "LAN_IN". Rule names are only unique per ruleset.
+func FindFirewallRuleByName(ctx context.Context, c Client, site, ruleset, name string) (*types.FirewallRule, error) {
+	rules, err := c.Firewall().ListRules(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+	return findOne(rules, "firewall rule", ruleset+"/"+name, func(r *types.FirewallRule) bool {
+		return r.Ruleset == ruleset && r.Name == name
+	})
+}
+
+// FindFirewallGroupByName returns the firewall group with the given name.
+func FindFirewallGroupByName(ctx context.Context, c Client, site, name string) (*types.FirewallGroup, error) {
+	groups, err := c.Firewall().ListGroups(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+	return findOne(groups, "firewall group", name, func(g *types.FirewallGroup) bool { return g.Name == name })
+}
+
+// FindTrafficRuleByName returns the traffic rule with the given name.
+func FindTrafficRuleByName(ctx context.Context, c Client, site, name string) (*types.TrafficRule, error) {
+	rules, err := c.Firewall().ListTrafficRules(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list traffic rules: %w", err)
+	}
+	return findOne(rules, "traffic rule", name, func(r *types.TrafficRule) bool { return r.Name == name })
+}
+
+// FindRouteByName returns the static route with the given name.
+func FindRouteByName(ctx context.Context, c Client, site, name string) (*types.Route, error) {
+	routes, err := c.Routing().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+	return findOne(routes, "route", name, func(r *types.Route) bool { return r.Name == name })
+}
+
+// FindPortForwardByName returns the port forward with the given name.
+func FindPortForwardByName(ctx context.Context, c Client, site, name string) (*types.PortForward, error) {
+	forwards, err := c.PortForwards().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list port forwards: %w", err)
+	}
+	return findOne(forwards, "port forward", name, func(f *types.PortForward) bool { return f.Name == name })
+}
+
+// FindPortProfileByName returns the port profile with the given name.
+func FindPortProfileByName(ctx context.Context, c Client, site, name string) (*types.PortProfile, error) {
+	profiles, err := c.PortProfiles().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list port profiles: %w", err)
+	}
+	return findOne(profiles, "port profile", name, func(p *types.PortProfile) bool { return p.Name == name })
+}
+
+// FindUserGroupByName returns the user group with the given name.
+func FindUserGroupByName(ctx context.Context, c Client, site, name string) (*types.UserGroup, error) {
+	groups, err := c.Users().ListGroups(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+	return findOne(groups, "user group", name, func(g *types.UserGroup) bool { return g.Name == name })
+}
+
+// FindRADIUSProfileByName returns the RADIUS profile with the given name.
+func FindRADIUSProfileByName(ctx context.Context, c Client, site, name string) (*types.RADIUSProfile, error) {
+	profiles, err := c.Settings().ListRadiusProfiles(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RADIUS profiles: %w", err)
+	}
+	return findOne(profiles, "RADIUS profile", name, func(p *types.RADIUSProfile) bool { return p.Name == name })
+}
+
+// findOne returns the single item matching a natural key. A missing item
+// wraps ErrNotFound; more than one match wraps ErrAlreadyExists, since the
+// key cannot identify an object until the duplicates are resolved.
+func findOne[T any](items []T, kind, key string, match func(*T) bool) (*T, error) {
+	var found *T
+	n := 0
+	for i := range items {
+		if match(&items[i]) {
+			if found == nil {
+				found = &items[i]
+			}
+			n++
+		}
+	}
+
+	switch {
+	case n == 0:
+		return nil, fmt.Errorf("%s %q: %w", kind, key, ErrNotFound)
+	case n > 1:
+		return nil, fmt.Errorf("%s %q matches %d objects: %w", kind, key, n, ErrAlreadyExists)
+	}
+	return found, nil
+}
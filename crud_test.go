@@ -0,0 +1,159 @@
+package gofi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestParseImportID(t *testing.T) {
+	site, id, err := ParseImportID(ImportID("default", "5f1a2b"))
+	if err != nil {
+		t.Fatalf("ParseImportID() error = %v", err)
+	}
+	if site != "default" || id != "5f1a2b" {
+		t.Errorf("ParseImportID() = %q, %q, want default, 5f1a2b", site, id)
+	}
+
+	for _, bad := range []string{"", "5f1a2b", "/5f1a2b", "default/"} {
+		if _, _, err := ParseImportID(bad); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("ParseImportID(%q) error = %v, want ErrInvalidRequest", bad, err)
+		}
+	}
+}
+
+func TestFindNetworkByName(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddNetwork(&types.Network{ID: "n1", Name: "IoT", Purpose: "corporate"})
+	server.State().AddNetwork(&types.Network{ID: "n2", Name: "Guest", Purpose: "guest"})
+	server.State().AddNetwork(&types.Network{ID: "n3", Name: "Guest", Purpose: "guest"})
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	n, err := FindNetworkByName(ctx, c, "default", "IoT")
+	if err != nil {
+		t.Fatalf("FindNetworkByName() error = %v", err)
+	}
+	if n.ID != "n1" {
+		t.Errorf("FindNetworkByName() ID = %s, want n1", n.ID)
+	}
+
+	if _, err := FindNetworkByName(ctx, c, "default", "Lab"); !IsNotFound(err) {
+		t.Errorf("FindNetworkByName(Lab) error = %v, want not found", err)
+	}
+	if _, err := FindNetworkByName(ctx, c, "default", "Guest"); !IsConflict(err) {
+		t.Errorf("FindNetworkByName(Guest) error = %v, want conflict", err)
+	}
+}
+
+func TestCreateIfAbsent(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	find := func(ctx context.Context) (*types.PortForward, error) {
+		return FindPortForwardByName(ctx, c, "default", "web")
+	}
+	create := func(ctx context.Context) (*types.PortForward, error) {
+		return c.PortForwards().Create(ctx, "default", &types.PortForward{
+			Name: "web", Enabled: true, Protocol: "tcp", DstPort: "443", FwdIP: "192.168.1.10", FwdPort: "443",
+		})
+	}
+
+	first, created, err := CreateIfAbsent(ctx, find, create)
+	if err != nil {
+		t.Fatalf("CreateIfAbsent() error = %v", err)
+	}
+	if !created || first.ID == "" {
+		t.Fatalf("CreateIfAbsent() = %+v, created %v, want a new port forward", first, created)
+	}
+
+	second, created, err := CreateIfAbsent(ctx, find, create)
+	if err != nil {
+		t.Fatalf("CreateIfAbsent() second call error = %v", err)
+	}
+	if created || second.ID != first.ID {
+		t.Errorf("CreateIfAbsent() second call = %s, created %v, want existing %s", second.ID, created, first.ID)
+	}
+
+	forwards, _ := c.PortForwards().List(ctx, "default")
+	if len(forwards) != 1 {
+		t.Errorf("got %d port forwards, want 1", len(forwards))
+	}
+}
+
+func TestCreateIfAbsent_ConflictRefind(t *testing.T) {
+	existing := &types.Network{ID: "n1", Name: "IoT"}
+	calls := 0
+	find := func(context.Context) (*types.Network, error) {
+		calls++
+		if calls == 1 {
+			return nil, ErrNotFound
+		}
+		return existing, nil
+	}
+	create := func(context.Context) (*types.Network, error) {
+		return nil, NewAPIError(400, "error", "api.err.NetworkNameExists", "/rest/networkconf")
+	}
+
+	got, created, err := CreateIfAbsent(context.Background(), find, create)
+	if err != nil {
+		t.Fatalf("CreateIfAbsent() error = %v", err)
+	}
+	if created || got != existing {
+		t.Errorf("CreateIfAbsent() = %+v, created %v, want the existing network", got, created)
+	}
+}
+
+func TestUpdateIfChanged(t *testing.T) {
+	current := &types.Network{ID: "n1", Name: "IoT", VLAN: 20}
+	calls := 0
+	update := func(_ context.Context, n *types.Network) (*types.Network, error) {
+		calls++
+		return n, nil
+	}
+
+	if _, changed, err := UpdateIfChanged(context.Background(), current, current.Clone(), update); err != nil || changed {
+		t.Errorf("UpdateIfChanged() unchanged = %v, %v, want no update", changed, err)
+	}
+
+	desired := current.Clone()
+	desired.VLAN = 30
+	got, changed, err := UpdateIfChanged(context.Background(), current, desired, update)
+	if err != nil || !changed || got.VLAN != 30 {
+		t.Errorf("UpdateIfChanged() = %+v, %v, %v, want update to VLAN 30", got, changed, err)
+	}
+	if calls != 1 {
+		t.Errorf("update called %d times, want 1", calls)
+	}
+}
+
+func TestDelete_AlreadyDeleted(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddNetwork(&types.Network{ID: "n1", Name: "IoT"})
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := c.Networks().Delete(ctx, "default", "n1"); err != nil {
+			t.Fatalf("Delete() attempt %d error = %v", i+1, err)
+		}
+	}
+	if err := c.Users().DeleteByMAC(ctx, "default", "aa:bb:cc:dd:ee:ff"); err != nil {
+		t.Errorf("DeleteByMAC() of unknown MAC error = %v", err)
+	}
+
+	_, err := c.Networks().Get(ctx, "default", "n1")
+	if IgnoreNotFound(err) != nil {
+		t.Errorf("IgnoreNotFound(Get() of deleted network) = %v, want nil", err)
+	}
+}
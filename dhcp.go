@@ -0,0 +1,272 @@
+package gofi
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// DHCPIssue is a problem found with a DHCP reservation.
+type DHCPIssue string
+
+// DHCP reservation issues.
+const (
+	// DHCPOutsideSubnet means the reserved IP is not in the subnet of the
+	// reservation's network, or of any network. The client cannot get it.
+	DHCPOutsideSubnet DHCPIssue = "outside-subnet"
+
+	// DHCPOutsidePool means the reserved IP is in the subnet but outside
+	// the network's DHCP range.
+	DHCPOutsidePool DHCPIssue = "outside-pool"
+
+	// DHCPDuplicate means another reservation has the same IP.
+	DHCPDuplicate DHCPIssue = "duplicate"
+
+	// DHCPLeaseConflict means another connected client uses the reserved
+	// IP; see ConflictMAC.
+	DHCPLeaseConflict DHCPIssue = "lease-conflict"
+
+	// DHCPNotApplied means the client is connected with a different IP,
+	// typically until it renews its lease.
+	DHCPNotApplied DHCPIssue = "not-applied"
+)
+
+// DHCPReservation is a fixed IP reservation with the issues found for it.
+type DHCPReservation struct {
+	// MAC is the client MAC address, lowercased.
+	MAC string
+
+	// Name is the client alias, falling back to its hostname.
+	Name string
+
+	IP string
+
+	// LeaseIP is the address the client uses now, if it is connected.
+	LeaseIP string
+
+	// ConflictMAC is the connected client using IP, for DHCPLeaseConflict.
+	ConflictMAC string
+
+	Issues []DHCPIssue
+}
+
+// Has reports whether the reservation has the issue.
+func (r DHCPReservation) Has(issue DHCPIssue) bool {
+	for _, i := range r.Issues {
+		if i == issue {
+			return true
+		}
+	}
+	return false
+}
+
+// DHCPNetwork is the DHCP configuration of one network with its
+// reservations.
+type DHCPNetwork struct {
+	ID     string
+	Name   string
+	Subnet string
+
+	// DHCPEnabled is true if the gateway serves DHCP on the network, and
+	// PoolStart and PoolStop bound its range.
+	DHCPEnabled bool
+	PoolStart   string
+	PoolStop    string
+
+	// PoolSize is the number of addresses in the range, or 0 if unknown.
+	PoolSize int
+
+	// ActiveLeases is the number of connected clients with an address in
+	// the subnet. It is 0 when leases are not known.
+	ActiveLeases int
+
+	// Reservations are sorted by IP.
+	Reservations []DHCPReservation
+}
+
+// DHCPReport is the consolidated view of a site's DHCP reservations.
+type DHCPReport struct {
+	Site string
+
+	// Networks are the networks with a subnet, sorted by name.
+	Networks []DHCPNetwork
+
+	// Unassigned are reservations whose IP is in no network's subnet.
+	Unassigned []DHCPReservation
+
+	// LeasesKnown is false if connected clients could not be listed, in
+	// which case lease conflicts and pending reservations are not flagged.
+	LeasesKnown bool
+}
+
+// Flagged returns the reservations with at least one issue, by network
+// and then IP, followed by the unassigned ones.
+func (r *DHCPReport) Flagged() []DHCPReservation {
+	var flagged []DHCPReservation
+	for _, n := range r.Networks {
+		for _, res := range n.Reservations {
+			if len(res.Issues) > 0 {
+				flagged = append(flagged, res)
+			}
+		}
+	}
+	return append(flagged, r.Unassigned...)
+}
+
+// CollectDHCPReport fetches a site's fixed IP reservations, networks and
+// connected clients and combines them with NewDHCPReport. If connected
+// clients cannot be listed, the report is built without leases.
+func CollectDHCPReport(ctx context.Context, c Client, site string) (*DHCPReport, error) {
+	users, err := c.Users().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	networks, err := c.Networks().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	clients, err := c.Clients().ListActive(ctx, site)
+	if err != nil {
+		clients = nil
+	} else if clients == nil {
+		clients = []types.Client{}
+	}
+
+	return NewDHCPReport(site, users, networks, clients), nil
+}
+
+// NewDHCPReport groups fixed IP reservations by network and flags their
+// issues. A reservation belongs to the network it names, or else to the
+// network whose subnet contains its IP. Pass nil clients when leases are
+// not known; an empty slice means no client is connected.
+func NewDHCPReport(site string, users []types.User, networks []types.Network, clients []types.Client) *DHCPReport {
+	report := &DHCPReport{Site: site, LeasesKnown: clients != nil}
+
+	byID := make(map[string]int)
+	subnets := make([]*net.IPNet, 0, len(networks))
+	var withSubnet []types.Network
+	for _, n := range networks {
+		_, subnet, err := net.ParseCIDR(n.IPSubnet)
+		if err != nil {
+			continue
+		}
+		byID[n.ID] = len(report.Networks)
+		subnets = append(subnets, subnet)
+		withSubnet = append(withSubnet, n)
+		report.Networks = append(report.Networks, DHCPNetwork{
+			ID:          n.ID,
+			Name:        n.Name,
+			Subnet:      n.IPSubnet,
+			DHCPEnabled: n.DHCPDEnabled,
+			PoolStart:   n.DHCPDStart,
+			PoolStop:    n.DHCPDStop,
+			PoolSize:    poolSize(n.DHCPDStart, n.DHCPDStop),
+		})
+	}
+
+	leases := make(map[string]string) // IP -> MAC
+	active := make(map[string]string) // MAC -> IP
+	for _, cl := range clients {
+		if cl.IP == "" {
+			continue
+		}
+		mac := strings.ToLower(cl.MAC)
+		leases[cl.IP] = mac
+		active[mac] = cl.IP
+		if n := networkForAddress(withSubnet, cl.IP); n != nil {
+			report.Networks[byID[n.ID]].ActiveLeases++
+		}
+	}
+
+	reservedBy := make(map[string]int)
+	for _, u := range users {
+		if u.UseFixedIP && u.FixedIP != "" {
+			reservedBy[u.FixedIP]++
+		}
+	}
+
+	for _, u := range users {
+		if !u.UseFixedIP || u.FixedIP == "" {
+			continue
+		}
+		res := DHCPReservation{MAC: strings.ToLower(u.MAC), Name: u.Name, IP: u.FixedIP}
+		if res.Name == "" {
+			res.Name = u.Hostname
+		}
+
+		i, ok := byID[u.NetworkID]
+		if !ok {
+			if n := networkForAddress(withSubnet, u.FixedIP); n != nil {
+				i, ok = byID[n.ID], true
+			}
+		}
+
+		ip := net.ParseIP(u.FixedIP)
+		switch {
+		case !ok || ip == nil || !subnets[i].Contains(ip):
+			res.Issues = append(res.Issues, DHCPOutsideSubnet)
+		case !inPool(ip, report.Networks[i].PoolStart, report.Networks[i].PoolStop):
+			res.Issues = append(res.Issues, DHCPOutsidePool)
+		}
+		if reservedBy[u.FixedIP] > 1 {
+			res.Issues = append(res.Issues, DHCPDuplicate)
+		}
+		if mac, held := leases[u.FixedIP]; held && mac != res.MAC {
+			res.Issues = append(res.Issues, DHCPLeaseConflict)
+			res.ConflictMAC = mac
+		}
+		if leaseIP, connected := active[res.MAC]; connected {
+			res.LeaseIP = leaseIP
+			if leaseIP != u.FixedIP {
+				res.Issues = append(res.Issues, DHCPNotApplied)
+			}
+		}
+
+		if ok {
+			report.Networks[i].Reservations = append(report.Networks[i].Reservations, res)
+		} else {
+			report.Unassigned = append(report.Unassigned, res)
+		}
+	}
+
+	for i := range report.Networks {
+		sortReservations(report.Networks[i].Reservations)
+	}
+	sortReservations(report.Unassigned)
+	sort.SliceStable(report.Networks, func(i, j int) bool { return report.Networks[i].Name < report.Networks[j].Name })
+	return report
+}
+
+// sortReservations orders reservations by IP.
+func sortReservations(r []DHCPReservation) {
+	sort.SliceStable(r, func(i, j int) bool { return compareIPs(r[i].IP, r[j].IP) < 0 })
+}
+
+// inPool reports whether ip is within the DHCP range from start to stop.
+// An unset or unparsable range counts as containing every address.
+func inPool(ip net.IP, start, stop string) bool {
+	if net.ParseIP(start) == nil || net.ParseIP(stop) == nil {
+		return true
+	}
+	return compareIPs(ip.String(), start) >= 0 && compareIPs(ip.String(), stop) <= 0
+}
+
+// poolSize returns the number of IPv4 addresses from start to stop, or 0.
+func poolSize(start, stop string) int {
+	a, b := net.ParseIP(start).To4(), net.ParseIP(stop).To4()
+	if a == nil || b == nil {
+		return 0
+	}
+	first, last := binary.BigEndian.Uint32(a), binary.BigEndian.Uint32(b)
+	if last < first {
+		return 0
+	}
+	return int(last-first) + 1
+}
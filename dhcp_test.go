@@ -0,0 +1,114 @@
+package gofi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestNewDHCPReport(t *testing.T) {
+	networks := []types.Network{
+		{ID: "lan", Name: "LAN", IPSubnet: "192.168.1.1/24", DHCPDEnabled: true, DHCPDStart: "192.168.1.100", DHCPDStop: "192.168.1.199"},
+		{ID: "iot", Name: "IoT", IPSubnet: "10.20.0.1/24", DHCPDEnabled: true},
+		{ID: "wan", Name: "WAN", Purpose: "wan"},
+	}
+	users := []types.User{
+		{MAC: "AA:BB:CC:DD:EE:01", Name: "nas", UseFixedIP: true, FixedIP: "192.168.1.150", NetworkID: "lan"},
+		{MAC: "aa:bb:cc:dd:ee:02", Hostname: "printer", UseFixedIP: true, FixedIP: "192.168.1.20", NetworkID: "lan"},
+		{MAC: "aa:bb:cc:dd:ee:03", UseFixedIP: true, FixedIP: "10.20.0.9", NetworkID: "lan"},
+		{MAC: "aa:bb:cc:dd:ee:04", UseFixedIP: true, FixedIP: "10.20.0.50"},
+		{MAC: "aa:bb:cc:dd:ee:05", UseFixedIP: true, FixedIP: "10.20.0.50", NetworkID: "iot"},
+		{MAC: "aa:bb:cc:dd:ee:06", UseFixedIP: true, FixedIP: "172.16.0.1"},
+		{MAC: "aa:bb:cc:dd:ee:07", Name: "no reservation"},
+	}
+	clients := []types.Client{
+		{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.1.150"},
+		{MAC: "aa:bb:cc:dd:ee:02", IP: "192.168.1.120"},
+		{MAC: "aa:bb:cc:dd:ee:99", IP: "192.168.1.20"},
+		{MAC: "aa:bb:cc:dd:ee:98", IP: "10.20.0.77"},
+	}
+
+	report := NewDHCPReport("default", users, networks, clients)
+	if !report.LeasesKnown {
+		t.Error("LeasesKnown = false with clients")
+	}
+
+	if len(report.Networks) != 2 || report.Networks[0].ID != "iot" || report.Networks[1].ID != "lan" {
+		t.Fatalf("Networks = %+v, want iot and lan", report.Networks)
+	}
+	iot, lan := report.Networks[0], report.Networks[1]
+	if lan.PoolSize != 100 || lan.ActiveLeases != 3 || iot.PoolSize != 0 || iot.ActiveLeases != 1 {
+		t.Errorf("lan pool %d leases %d, iot pool %d leases %d, want 100/3, 0/1", lan.PoolSize, lan.ActiveLeases, iot.PoolSize, iot.ActiveLeases)
+	}
+
+	issues := func(r []DHCPReservation) map[string][]DHCPIssue {
+		m := make(map[string][]DHCPIssue)
+		for _, res := range r {
+			m[res.MAC] = res.Issues
+		}
+		return m
+	}
+	wantLAN := map[string][]DHCPIssue{
+		"aa:bb:cc:dd:ee:01": nil,
+		"aa:bb:cc:dd:ee:02": {DHCPOutsidePool, DHCPLeaseConflict, DHCPNotApplied},
+		"aa:bb:cc:dd:ee:03": {DHCPOutsideSubnet},
+	}
+	if got := issues(lan.Reservations); !reflect.DeepEqual(got, wantLAN) {
+		t.Errorf("LAN issues = %v, want %v", got, wantLAN)
+	}
+	wantIoT := map[string][]DHCPIssue{
+		"aa:bb:cc:dd:ee:04": {DHCPDuplicate},
+		"aa:bb:cc:dd:ee:05": {DHCPDuplicate},
+	}
+	if got := issues(iot.Reservations); !reflect.DeepEqual(got, wantIoT) {
+		t.Errorf("IoT issues = %v, want %v", got, wantIoT)
+	}
+
+	if lan.Reservations[0].IP != "10.20.0.9" || lan.Reservations[1].IP != "192.168.1.20" {
+		t.Errorf("LAN reservations not sorted by IP: %+v", lan.Reservations)
+	}
+	printer := lan.Reservations[1]
+	if printer.Name != "printer" || printer.ConflictMAC != "aa:bb:cc:dd:ee:99" || printer.LeaseIP != "192.168.1.120" {
+		t.Errorf("printer reservation = %+v", printer)
+	}
+
+	if len(report.Unassigned) != 1 || !report.Unassigned[0].Has(DHCPOutsideSubnet) {
+		t.Errorf("Unassigned = %+v, want 172.16.0.1 outside any subnet", report.Unassigned)
+	}
+	if got := len(report.Flagged()); got != 5 {
+		t.Errorf("Flagged() = %d reservations, want 5", got)
+	}
+
+	// Without leases, nothing lease-related is flagged.
+	report = NewDHCPReport("default", users, networks, nil)
+	if report.LeasesKnown {
+		t.Error("LeasesKnown = true without clients")
+	}
+	for _, res := range report.Flagged() {
+		if res.Has(DHCPLeaseConflict) || res.Has(DHCPNotApplied) {
+			t.Errorf("lease issue flagged without leases: %+v", res)
+		}
+	}
+}
+
+func TestCollectDHCPReport(t *testing.T) {
+	server := newFixedIPServer()
+	defer server.Close()
+	server.State().AddClient(&types.Client{MAC: "aa:bb:cc:dd:ee:42", IP: "192.168.1.10", LastSeen: types.NewFlexTime(time.Now().Unix())})
+	c := connectMock(t, server)
+
+	report, err := CollectDHCPReport(context.Background(), c, "default")
+	if err != nil {
+		t.Fatalf("CollectDHCPReport() error = %v", err)
+	}
+	if !report.LeasesKnown || len(report.Networks) != 1 || len(report.Networks[0].Reservations) != 4 {
+		t.Fatalf("report = %+v, want 4 reservations on LAN", report)
+	}
+	flagged := report.Flagged()
+	if len(flagged) != 1 || flagged[0].IP != "192.168.1.10" || flagged[0].ConflictMAC != "aa:bb:cc:dd:ee:42" {
+		t.Errorf("Flagged() = %+v, want lease conflict on 192.168.1.10", flagged)
+	}
+}
@@ -0,0 +1,88 @@
+package gofi
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/transport"
+)
+
+// Diagnostics is a snapshot of a client's internal state for operators of
+// long-running programs. Clones made with ForSite and WithLogger share the
+// session and transport, so they report the same values.
+type Diagnostics struct {
+	// Host is the controller the client talks to.
+	Host string
+
+	// Connected is true between Connect and Disconnect while the session
+	// is valid.
+	Connected bool
+
+	// SessionAge is how long ago the current session was created, or 0.
+	SessionAge time.Duration
+
+	// SessionExpiresIn is the time left before the session expires, or 0
+	// if unknown.
+	SessionExpiresIn time.Duration
+
+	// Transport holds the HTTP request, error and retry counters.
+	Transport transport.Stats
+
+	// Events describes the event service, or is nil if it has not been
+	// started.
+	Events *services.EventStats
+
+	// WriteQueue describes the per-site write queue, if writes are
+	// serialized.
+	WriteQueue *WriteQueueStats
+}
+
+// MarshalJSON encodes durations as seconds, as expected by most tools
+// that read expvar output.
+func (s Diagnostics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Host             string               `json:"host"`
+		Connected        bool                 `json:"connected"`
+		SessionAge       float64              `json:"session_age_seconds"`
+		SessionExpiresIn float64              `json:"session_expires_in_seconds"`
+		Transport        transport.Stats      `json:"transport"`
+		Events           *services.EventStats `json:"events,omitempty"`
+		WriteQueue       *WriteQueueStats     `json:"write_queue,omitempty"`
+	}{
+		Host:             s.Host,
+		Connected:        s.Connected,
+		SessionAge:       s.SessionAge.Seconds(),
+		SessionExpiresIn: s.SessionExpiresIn.Seconds(),
+		Transport:        s.Transport,
+		Events:           s.Events,
+		WriteQueue:       s.WriteQueue,
+	})
+}
+
+// Diagnostics returns a snapshot of the client's internal state.
+func (c *client) Diagnostics() Diagnostics {
+	s := Diagnostics{
+		Host:      c.config.Host,
+		Connected: c.IsConnected(),
+		Transport: transport.GetStats(c.transport),
+	}
+
+	if session := c.auth.Session(); session != nil {
+		s.SessionAge = session.Age()
+		s.SessionExpiresIn = max(session.TimeUntilExpiry(), 0)
+	}
+
+	// Reading the stats must not start the service
+	if events, ok := c.events.current().(interface{ Stats() services.EventStats }); ok {
+		es := events.Stats()
+		s.Events = &es
+	}
+
+	if c.writes != nil {
+		ws := c.writes.stats()
+		s.WriteQueue = &ws
+	}
+
+	return s
+}
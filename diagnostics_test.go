@@ -0,0 +1,43 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+)
+
+func TestClient_Diagnostics(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server)
+	if _, err := c.Networks().Get(context.Background(), "default", "missing"); err == nil {
+		t.Fatal("Get() of missing network succeeded")
+	}
+
+	s := c.Diagnostics()
+	if !s.Connected || s.Host != server.Host() {
+		t.Errorf("Diagnostics() = %+v, want connected to %s", s, server.Host())
+	}
+	if s.SessionExpiresIn <= 0 {
+		t.Errorf("SessionExpiresIn = %v, want > 0", s.SessionExpiresIn)
+	}
+	if s.Transport.Requests < 2 || s.Transport.StatusErrors != 1 {
+		t.Errorf("Transport = %+v, want login and 1 failed get", s.Transport)
+	}
+	if got := c.ForSite("other").Diagnostics().Transport; got != s.Transport {
+		t.Errorf("ForSite().Diagnostics().Transport = %+v, want shared %+v", got, s.Transport)
+	}
+	if s.Events != nil {
+		t.Errorf("Events = %+v, want nil before the event service is started", s.Events)
+	}
+	if c.(*client).events.current() != nil {
+		t.Error("Diagnostics() started the event service")
+	}
+
+	c.Events()
+	if c.Diagnostics().Events == nil {
+		t.Error("Events = nil after the event service was started")
+	}
+}
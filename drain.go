@@ -0,0 +1,214 @@
+package gofi
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/transport"
+)
+
+// inflight counts the requests in flight so that Disconnect can wait for
+// them. It is shared by a client and its clones.
+type inflight struct {
+	mu      sync.Mutex
+	n       int
+	closing bool
+
+	// idle is closed when the last request leaves while closing.
+	idle chan struct{}
+}
+
+// enter registers a request, failing once the client is closing.
+func (f *inflight) enter() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closing {
+		return ErrClientClosing
+	}
+	f.n++
+	return nil
+}
+
+// leave unregisters a request.
+func (f *inflight) leave() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n--
+	if f.n == 0 && f.closing {
+		close(f.idle)
+	}
+}
+
+// drain refuses new requests and waits until those in flight have left
+// or ctx is done. The client stays closed until reopen.
+func (f *inflight) drain(ctx context.Context) error {
+	f.mu.Lock()
+	if !f.closing {
+		f.closing = true
+		f.idle = make(chan struct{})
+		if f.n == 0 {
+			close(f.idle)
+		}
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reopen accepts requests again, after Disconnect.
+func (f *inflight) reopen() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closing = false
+}
+
+// count returns the number of requests in flight.
+func (f *inflight) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.n
+}
+
+// drainTransport tracks the requests of the services in inflight. Login
+// and logout use the transport below it, so they are not held up.
+type drainTransport struct {
+	transport transport.Transport
+	inflight  *inflight
+}
+
+func (t *drainTransport) Do(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	if err := t.inflight.enter(); err != nil {
+		return nil, err
+	}
+	defer t.inflight.leave()
+	return t.transport.Do(ctx, req)
+}
+
+// Stream keeps the request in flight until the response body is closed,
+// so that Disconnect waits for downloads still being read.
+func (t *drainTransport) Stream(ctx context.Context, req *transport.Request) (*transport.StreamResponse, error) {
+	if err := t.inflight.enter(); err != nil {
+		return nil, err
+	}
+	resp, err := transport.Stream(ctx, t.transport, req)
+	if err != nil {
+		t.inflight.leave()
+		return nil, err
+	}
+	resp.Body = &leaveOnClose{ReadCloser: resp.Body, leave: sync.OnceFunc(t.inflight.leave)}
+	return resp, nil
+}
+
+// leaveOnClose leaves the inflight count when a streamed body is closed.
+type leaveOnClose struct {
+	io.ReadCloser
+	leave func()
+}
+
+func (b *leaveOnClose) Close() error {
+	defer b.leave()
+	return b.ReadCloser.Close()
+}
+
+func (t *drainTransport) Stats() transport.Stats {
+	return transport.GetStats(t.transport)
+}
+
+func (t *drainTransport) SetCSRFToken(token string) {
+	t.transport.SetCSRFToken(token)
+}
+
+func (t *drainTransport) GetCSRFToken() string {
+	return t.transport.GetCSRFToken()
+}
+
+func (t *drainTransport) Close() {
+	t.transport.Close()
+}
+
+// eventHub holds the event service of a client and its clones, so that
+// Disconnect can stop every stream of the session.
+type eventHub struct {
+	mu      sync.Mutex
+	service services.EventService
+}
+
+// get returns the event service, creating it with create if there is
+// none.
+func (h *eventHub) get(create func() services.EventService) services.EventService {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.service == nil {
+		h.service = create()
+	}
+	return h.service
+}
+
+// current returns the event service without creating one, or nil if it
+// has not been started.
+func (h *eventHub) current() services.EventService {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.service
+}
+
+// shutdown closes the event service and waits, until ctx is done, for
+// its streams to stop. The next get creates a new one.
+func (h *eventHub) shutdown(ctx context.Context) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	service := h.service
+	h.service = nil
+	h.mu.Unlock()
+
+	if service == nil {
+		return nil
+	}
+	return service.Shutdown(ctx)
+}
+
+// deviceHub holds the device service of a client and its clones, so that
+// Disconnect can turn off the LEDs of all their LocateFor calls.
+type deviceHub struct {
+	mu      sync.Mutex
+	service services.DeviceService
+}
+
+// get returns the device service, creating it with create if there is
+// none.
+func (h *deviceHub) get(create func() services.DeviceService) services.DeviceService {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.service == nil {
+		h.service = create()
+	}
+	return h.service
+}
+
+// unlocate turns off the LEDs of pending LocateFor calls.
+func (h *deviceHub) unlocate(ctx context.Context) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	service := h.service
+	h.mu.Unlock()
+
+	if service == nil {
+		return nil
+	}
+	return service.UnlocatePending(ctx)
+}
@@ -0,0 +1,200 @@
+package gofi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+// holdScenario holds requests to paths containing path until release is
+// closed, then lets the server answer them.
+type holdScenario struct {
+	path    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *holdScenario) Apply(w http.ResponseWriter, r *http.Request) bool {
+	if strings.Contains(r.URL.Path, h.path) {
+		h.started <- struct{}{}
+		<-h.release
+	}
+	return false
+}
+
+func TestClient_GracefulDisconnect(t *testing.T) {
+	hold := &holdScenario{path: "/stat/device", started: make(chan struct{}, 2), release: make(chan struct{})}
+	server := mock.NewServer(mock.WithScenario(hold))
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	}, WithGracefulDisconnect())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	listed := make(chan error, 1)
+	go func() {
+		_, err := c.Devices().List(ctx, "default")
+		listed <- err
+	}()
+	<-hold.started
+
+	disconnected := make(chan error, 1)
+	go func() { disconnected <- c.Disconnect(ctx) }()
+
+	// Disconnect waits for the list, and new requests are refused.
+	select {
+	case err := <-disconnected:
+		t.Fatalf("Disconnect() returned %v with a request in flight", err)
+	case <-time.After(30 * time.Millisecond):
+	}
+	if _, err := c.Networks().List(ctx, "default"); !errors.Is(err, ErrClientClosing) {
+		t.Errorf("request during Disconnect error = %v, want ErrClientClosing", err)
+	}
+
+	close(hold.release)
+	if err := <-listed; err != nil {
+		t.Errorf("in-flight List() error = %v", err)
+	}
+	if err := <-disconnected; err != nil {
+		t.Errorf("Disconnect() error = %v", err)
+	}
+	if c.IsConnected() {
+		t.Error("IsConnected() = true after Disconnect()")
+	}
+
+	// The client can connect again.
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("second Connect() error = %v", err)
+	}
+	if _, err := c.Networks().List(ctx, "default"); err != nil {
+		t.Errorf("List() after reconnecting error = %v", err)
+	}
+	_ = c.Disconnect(ctx)
+}
+
+func TestClient_GracefulDisconnectTimeout(t *testing.T) {
+	hold := &holdScenario{path: "/stat/device", started: make(chan struct{}, 1), release: make(chan struct{})}
+	server := mock.NewServer(mock.WithScenario(hold))
+	defer server.Close()
+	defer close(hold.release)
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	}, WithGracefulDisconnect())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	go c.Devices().List(context.Background(), "default")
+	<-hold.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Disconnect(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Disconnect() error = %v, want DeadlineExceeded", err)
+	}
+	if c.IsConnected() {
+		t.Error("IsConnected() = true after Disconnect() timed out")
+	}
+}
+
+func TestClient_DisconnectClosesEvents(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, _, err := c.ForSite("default").Events().Subscribe(ctx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	devices, _, err := c.Events().SubscribeDeviceUpdates(ctx, "default")
+	if err != nil {
+		t.Fatalf("SubscribeDeviceUpdates() error = %v", err)
+	}
+	before := c.Events()
+
+	// Streams of clones stop too, before Disconnect returns.
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	for range events {
+	}
+	for range devices {
+	}
+
+	if c.Events() == before {
+		t.Error("Events() after Disconnect() returned the closed service")
+	}
+}
+
+func TestClient_DisconnectUnlocates(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:bb:cc:dd:ee:01", Type: "uap"})
+	c := connectMock(t, server, WithGracefulDisconnect())
+	ctx := context.Background()
+
+	// A clone's timer is turned off too, before the session ends.
+	if err := c.ForSite("default").Devices().LocateFor(ctx, "default", "aa:bb:cc:dd:ee:01", time.Hour); err != nil {
+		t.Fatalf("LocateFor() error = %v", err)
+	}
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	if device, _ := server.State().GetDevice("d1"); device.LEDOverride != "default" {
+		t.Errorf("LEDOverride = %q after Disconnect(), want default", device.LEDOverride)
+	}
+}
+
+func TestClient_GracefulDisconnectStream(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddBackup(&types.Backup{Filename: "backup1.unf", Size: 4096})
+	c := connectMock(t, server, WithGracefulDisconnect())
+
+	body, err := c.System().DownloadBackup(context.Background(), "backup1.unf")
+	if err != nil {
+		t.Fatalf("DownloadBackup() error = %v", err)
+	}
+
+	// Disconnect waits for the body being read, not only for the headers.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Disconnect(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Disconnect() with a download open error = %v, want DeadlineExceeded", err)
+	}
+	body.Close()
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := c.Disconnect(context.Background()); err != nil {
+		t.Errorf("Disconnect() after the download was closed error = %v", err)
+	}
+}
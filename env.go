@@ -0,0 +1,92 @@
+package gofi
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	// EnvHost is the UDM Pro host address.
+	EnvHost = "UNIFI_HOST"
+
+	// EnvUDMIP is the legacy host variable used by the bundled utilities.
+	// It is consulted only when EnvHost is unset.
+	EnvUDMIP = "UNIFI_UDM_IP"
+
+	// EnvUsername is the local admin username.
+	EnvUsername = "UNIFI_USERNAME"
+
+	// EnvPassword is the local admin password.
+	EnvPassword = "UNIFI_PASSWORD"
+
+	// EnvAPIKey is a UniFi OS API key, used instead of the username and
+	// password.
+	EnvAPIKey = "UNIFI_API_KEY"
+
+	// EnvSite is the default site ID.
+	EnvSite = "UNIFI_SITE"
+
+	// EnvInsecure disables TLS certificate verification when set to a true value.
+	EnvInsecure = "UNIFI_INSECURE"
+)
+
+// ConfigFromEnv builds a Config from the UNIFI_* environment variables.
+//
+// UNIFI_HOST (or UNIFI_UDM_IP) is required, and so are UNIFI_USERNAME and
+// UNIFI_PASSWORD unless UNIFI_API_KEY is set.
+// UNIFI_SITE defaults to "default" and UNIFI_INSECURE accepts any value
+// understood by strconv.ParseBool. Every problem is reported at once as
+// ValidationErrors. The returned Config can be further adjusted before being
+// passed to New.
+func ConfigFromEnv() (*Config, error) {
+	var verrs ValidationErrors
+
+	host := strings.TrimSpace(os.Getenv(EnvHost))
+	if host == "" {
+		host = strings.TrimSpace(os.Getenv(EnvUDMIP))
+	}
+	if host == "" {
+		verrs.Add(EnvHost, "required")
+	}
+
+	apiKey := strings.TrimSpace(os.Getenv(EnvAPIKey))
+
+	username := os.Getenv(EnvUsername)
+	if username == "" && apiKey == "" {
+		verrs.Add(EnvUsername, "required")
+	}
+
+	password := os.Getenv(EnvPassword)
+	if password == "" && apiKey == "" {
+		verrs.Add(EnvPassword, "required")
+	}
+
+	site := strings.TrimSpace(os.Getenv(EnvSite))
+	if site == "" {
+		site = "default"
+	}
+
+	var insecure bool
+	if v := strings.TrimSpace(os.Getenv(EnvInsecure)); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			verrs.Add(EnvInsecure, "must be a boolean (true/false/1/0)")
+		}
+		insecure = b
+	}
+
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		Host:          host,
+		Username:      username,
+		Password:      password,
+		APIKey:        apiKey,
+		Site:          site,
+		SkipTLSVerify: insecure,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package gofi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func setEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for _, k := range []string{EnvHost, EnvUDMIP, EnvUsername, EnvPassword, EnvAPIKey, EnvSite, EnvInsecure} {
+		t.Setenv(k, vars[k])
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	setEnv(t, map[string]string{
+		EnvHost:     "192.168.1.1",
+		EnvUsername: "admin",
+		EnvPassword: "secret",
+		EnvSite:     "branch",
+		EnvInsecure: "true",
+	})
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+
+	if config.Host != "192.168.1.1" {
+		t.Errorf("Host = %s, want 192.168.1.1", config.Host)
+	}
+	if config.Username != "admin" {
+		t.Errorf("Username = %s, want admin", config.Username)
+	}
+	if config.Password != "secret" {
+		t.Errorf("Password = %s, want secret", config.Password)
+	}
+	if config.Site != "branch" {
+		t.Errorf("Site = %s, want branch", config.Site)
+	}
+	if !config.SkipTLSVerify {
+		t.Error("SkipTLSVerify should be true")
+	}
+}
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	setEnv(t, map[string]string{
+		EnvUDMIP:    "10.0.0.1",
+		EnvUsername: "admin",
+		EnvPassword: "secret",
+	})
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+
+	if config.Host != "10.0.0.1" {
+		t.Errorf("Host = %s, want 10.0.0.1 (from %s)", config.Host, EnvUDMIP)
+	}
+	if config.Site != "default" {
+		t.Errorf("Site = %s, want default", config.Site)
+	}
+	if config.SkipTLSVerify {
+		t.Error("SkipTLSVerify should default to false")
+	}
+}
+
+func TestConfigFromEnv_APIKey(t *testing.T) {
+	setEnv(t, map[string]string{
+		EnvHost:   "192.168.1.1",
+		EnvAPIKey: "k3y",
+	})
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if config.APIKey != "k3y" || config.Username != "" {
+		t.Errorf("APIKey = %q, Username = %q, want k3y and none", config.APIKey, config.Username)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		vars      map[string]string
+		wantField string
+	}{
+		{"missing host", map[string]string{EnvUsername: "admin", EnvPassword: "pass"}, EnvHost},
+		{"missing username", map[string]string{EnvHost: "h", EnvPassword: "pass"}, EnvUsername},
+		{"missing password", map[string]string{EnvHost: "h", EnvUsername: "admin"}, EnvPassword},
+		{"bad insecure", map[string]string{EnvHost: "h", EnvUsername: "admin", EnvPassword: "pass", EnvInsecure: "maybe"}, EnvInsecure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.vars)
+
+			_, err := ConfigFromEnv()
+			if err == nil {
+				t.Fatal("ConfigFromEnv() should return error")
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("error = %T, want *ValidationError", err)
+			}
+			if verr.Field != tt.wantField {
+				t.Errorf("Field = %s, want %s", verr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv_ReportsAllViolations(t *testing.T) {
+	setEnv(t, map[string]string{EnvInsecure: "maybe"})
+
+	_, err := ConfigFromEnv()
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("error = %T, want ValidationErrors", err)
+	}
+
+	want := []string{EnvHost, EnvUsername, EnvPassword, EnvInsecure}
+	if got := verrs.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+}
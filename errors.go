@@ -1,8 +1,18 @@
 package gofi
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
 )
 
 // Sentinel errors for common error conditions.
@@ -22,8 +32,9 @@ var (
 	// ErrInvalidCSRFToken is returned when the CSRF token is invalid or missing.
 	ErrInvalidCSRFToken = errors.New("invalid or missing CSRF token")
 
-	// ErrNotFound is returned when a requested resource is not found.
-	ErrNotFound = errors.New("resource not found")
+	// ErrNotFound is returned when a requested resource is not found. It
+	// is the same error the services wrap when a lookup finds nothing.
+	ErrNotFound = services.ErrNotFound
 
 	// ErrPermissionDenied is returned when the user lacks permission for an operation.
 	ErrPermissionDenied = errors.New("permission denied")
@@ -45,6 +56,14 @@ var (
 
 	// ErrInvalidConfig is returned when the configuration is invalid.
 	ErrInvalidConfig = errors.New("invalid configuration")
+
+	// ErrOutsideMaintenanceWindow is returned when a disruptive operation
+	// is attempted outside the configured MaintenancePolicy.
+	ErrOutsideMaintenanceWindow = errors.New("outside maintenance window")
+
+	// ErrClientClosing is returned for requests started while a graceful
+	// Disconnect is waiting for in-flight requests.
+	ErrClientClosing = errors.New("client is disconnecting")
 )
 
 // APIError represents an error returned by the UniFi API.
@@ -135,21 +154,10 @@ func NewAPIError(statusCode int, rc, message, endpoint string) *APIError {
 }
 
 // ValidationError represents a validation error for input data.
-type ValidationError struct {
-	// Field is the name of the field that failed validation.
-	Field string
+type ValidationError = types.ValidationError
 
-	// Message is the validation error message.
-	Message string
-}
-
-// Error implements the error interface.
-func (e *ValidationError) Error() string {
-	if e.Field != "" {
-		return fmt.Sprintf("validation error: %s: %s", e.Field, e.Message)
-	}
-	return fmt.Sprintf("validation error: %s", e.Message)
-}
+// ValidationErrors collects every validation violation found in a value.
+type ValidationErrors = types.ValidationErrors
 
 // NewValidationError creates a new ValidationError.
 func NewValidationError(field, message string) *ValidationError {
@@ -158,3 +166,164 @@ func NewValidationError(field, message string) *ValidationError {
 		Message: message,
 	}
 }
+
+// ResponseError is the error the services return for a request the
+// controller refused. It carries the HTTP status and the response's rc,
+// message and api.err code.
+type ResponseError = types.ResponseError
+
+// StatusCode returns the HTTP status code carried by err, or 0 if unknown.
+// It understands *APIError and the *ResponseError returned by the services.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode != 0 {
+		return apiErr.StatusCode
+	}
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+
+	return 0
+}
+
+// ErrorCode returns the UniFi api.err code carried by err (for example
+// "api.err.IdInvalid"), or "" if there is none.
+func ErrorCode(err error) string {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Code
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Message, "api.err.") {
+		return apiErr.Message
+	}
+
+	return ""
+}
+
+// IsNotFound returns true if err indicates a missing resource: it wraps
+// ErrNotFound, carries a 404 status or carries an api.err.*NotFound code
+// (or api.err.UnknownDevice or api.err.UnknownStation).
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrNotFound) || StatusCode(err) == 404 {
+		return true
+	}
+
+	switch code := ErrorCode(err); code {
+	case "api.err.UnknownDevice", "api.err.UnknownStation":
+		return true
+	default:
+		return strings.HasSuffix(code, "NotFound")
+	}
+}
+
+// notFoundf formats a lookup error that wraps ErrNotFound.
+func notFoundf(format string, args ...any) error {
+	return fmt.Errorf(format+": %w", append(args, ErrNotFound)...)
+}
+
+// IsConflict returns true if err indicates a conflict with existing state,
+// such as a duplicate name or an object still referenced elsewhere.
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrAlreadyExists) || StatusCode(err) == 409 {
+		return true
+	}
+
+	code := ErrorCode(err)
+	return strings.HasSuffix(code, "Exists") ||
+		strings.HasPrefix(code, "api.err.Duplicate") ||
+		code == "api.err.ObjectReferredBy"
+}
+
+// IsRateLimited returns true if err indicates the controller throttled the request.
+func IsRateLimited(err error) bool {
+	return err != nil && (errors.Is(err, ErrRateLimited) || StatusCode(err) == 429)
+}
+
+// IsServerError returns true if err carries a 5xx status.
+func IsServerError(err error) bool {
+	if err == nil {
+		return false
+	}
+	code := StatusCode(err)
+	return errors.Is(err, ErrServerError) || (code >= 500 && code < 600)
+}
+
+// IsClientError returns true if err carries a 4xx status.
+func IsClientError(err error) bool {
+	code := StatusCode(err)
+	return code >= 400 && code < 500
+}
+
+// IsRetryable returns true if err is transient and the operation may
+// succeed if retried: timeouts, connection failures, rate limiting and
+// 5xx responses other than 501. Context cancellation is never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	if IsRateLimited(err) {
+		return true
+	}
+
+	if IsServerError(err) {
+		return StatusCode(err) != 501
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// An unknown host or an untrusted certificate fails the same way
+	// every time.
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+	if isCertificateError(err) {
+		return false
+	}
+
+	// Dial and read/write failures.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// isCertificateError returns true if err is a failed TLS certificate
+// verification.
+func isCertificateError(err error) bool {
+	var (
+		verifyErr   *tls.CertificateVerificationError
+		unknownErr  x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidErr  x509.CertificateInvalidError
+	)
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &unknownErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
@@ -1,8 +1,19 @@
 package gofi
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
 	"testing"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -235,3 +246,116 @@ func TestSentinelErrors(t *testing.T) {
 		seen[msg] = true
 	}
 }
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"api error", NewAPIError(404, "error", "missing", "/x"), 404},
+		{"wrapped api error", fmt.Errorf("wrap: %w", NewAPIError(500, "error", "", "/x")), 500},
+		{"service error", fmt.Errorf("wrap: %w", types.NewResponseError("list devices", 502, nil)), 502},
+		{"status in message only", errors.New("list devices failed with status 502"), 0},
+		{"no status", errors.New("boom"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCode(tt.err); got != tt.want {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"meta msg", types.NewResponseError("get device", 400, []byte(`{"meta":{"rc":"error","msg":"api.err.IdInvalid"}}`)), "api.err.IdInvalid"},
+		{"v2 code", types.NewResponseError("list traffic rules", 400, []byte(`{"code":"api.err.InvalidPayload","message":"bad payload"}`)), "api.err.InvalidPayload"},
+		{"code in message only", errors.New("API error: api.err.IdInvalid (rc=error)"), ""},
+		{"api error message", NewAPIError(400, "error", "api.err.DuplicateName", "/x"), "api.err.DuplicateName"},
+		{"no code", errors.New("boom"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// rcError returns the error of a successful response whose meta has an
+// error rc with the given message.
+func rcError(msg string) error {
+	_, err := internal.ParseAPIResponse[struct{}]([]byte(`{"meta":{"rc":"error","msg":"` + msg + `"}}`))
+	return err
+}
+
+func TestErrorPredicates(t *testing.T) {
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	noHost := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "unifi.invalid", IsNotFound: true}}
+	badCert := &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		notFound  bool
+		conflict  bool
+		client    bool
+		server    bool
+	}{
+		{"nil", nil, false, false, false, false, false},
+		{"sentinel not found", ErrNotFound, false, true, false, false, false},
+		{"service not found", fmt.Errorf("get device: %w", services.ErrNotFound), false, true, false, false, false},
+		{"not found in message", errors.New("config file not found: gofi.yaml"), false, false, false, false, false},
+		{"not found code", rcError("api.err.UserNotFound"), false, true, false, false, false},
+		{"not found code in message only", errors.New("wrapped: api.err.UserNotFound"), false, false, false, false, false},
+		{"404", NewAPIError(404, "error", "", "/x"), false, true, false, true, false},
+		{"409", NewAPIError(409, "error", "", "/x"), false, false, true, true, false},
+		{"duplicate name", rcError("api.err.DuplicateName"), false, false, true, false, false},
+		{"name exists", rcError("api.err.NameExists"), false, false, true, false, false},
+		{"referred by", types.NewResponseError("delete network", 400, []byte(`{"meta":{"rc":"error","msg":"api.err.ObjectReferredBy"}}`)), false, false, true, true, false},
+		{"429", types.NewResponseError("list devices", 429, nil), true, false, false, true, false},
+		{"503", fmt.Errorf("update: %w", types.NewResponseError("update wlan", 503, nil)), true, false, false, false, true},
+		{"status in message only", errors.New("proxy said: status 503"), false, false, false, false, false},
+		{"501", NewAPIError(501, "error", "", "/x"), false, false, false, false, true},
+		{"400", types.NewResponseError("create network", 400, nil), false, false, false, true, false},
+		{"timeout sentinel", ErrTimeout, true, false, false, false, false},
+		{"deadline", fmt.Errorf("failed: %w", context.DeadlineExceeded), true, false, false, false, false},
+		{"canceled", fmt.Errorf("failed: %w", context.Canceled), false, false, false, false, false},
+		{"connection refused", fmt.Errorf("request failed: %w", opErr), true, false, false, false, false},
+		{"no such host", fmt.Errorf("request failed: %w", noHost), false, false, false, false, false},
+		{"untrusted certificate", fmt.Errorf("request failed: %w", badCert), false, false, false, false, false},
+		{"unexpected eof", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.retryable)
+			}
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.notFound)
+			}
+			if got := IsConflict(tt.err); got != tt.conflict {
+				t.Errorf("IsConflict() = %v, want %v", got, tt.conflict)
+			}
+			if got := IsClientError(tt.err); got != tt.client {
+				t.Errorf("IsClientError() = %v, want %v", got, tt.client)
+			}
+			if got := IsServerError(tt.err); got != tt.server {
+				t.Errorf("IsServerError() = %v, want %v", got, tt.server)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+// Package eventstore appends UniFi events to JSON Lines files, so that
+// event history survives process restarts and can be queried offline.
+//
+// A Store writes one Record per line to <prefix>.jsonl in its directory.
+// When the file reaches MaxSize it is renamed to
+// <prefix>-<UTC timestamp>.jsonl and a new one is started; rotated files
+// beyond MaxFiles or older than MaxAge are deleted:
+//
+//	store, err := eventstore.Open(eventstore.Config{
+//		Dir:     "/var/lib/unifi-events",
+//		MaxSize: 64 << 20,
+//		MaxAge:  30 * 24 * time.Hour,
+//	})
+//	defer store.Close()
+//	err = store.Subscribe(ctx, client, "default")
+//
+// Read queries the files of a directory without a running Store:
+//
+//	err = eventstore.Read("/var/lib/unifi-events", "", eventstore.Filter{
+//		Keys:  []string{"EVT_AP_*"},
+//		Since: time.Now().Add(-24 * time.Hour),
+//	}, func(r eventstore.Record) error {
+//		fmt.Println(r.Time, r.Event.Key, r.Event.Message)
+//		return nil
+//	})
+package eventstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/internal/eventsub"
+	"github.com/unifi-go/gofi/types"
+)
+
+// DefaultPrefix names the files of a Store without a Prefix.
+const DefaultPrefix = "events"
+
+// rotatedFormat is the timestamp in rotated file names. It sorts in time
+// order.
+const rotatedFormat = "20060102T150405.000000000"
+
+// Record is one stored event.
+type Record struct {
+	Site  string      `json:"site"`
+	Time  time.Time   `json:"time"`
+	Event types.Event `json:"event"`
+}
+
+// Config configures a Store.
+type Config struct {
+	// Dir is the directory holding the files. It is created if missing.
+	Dir string
+
+	// Prefix names the files. Defaults to DefaultPrefix.
+	Prefix string
+
+	// MaxSize rotates the current file once it reaches this many bytes.
+	// Defaults to 64 MiB.
+	MaxSize int64
+
+	// MaxFiles is the number of rotated files kept. Zero keeps all.
+	MaxFiles int
+
+	// MaxAge deletes rotated files whose newest event is older than this.
+	// Zero keeps all.
+	MaxAge time.Duration
+
+	// Sync flushes each event to stable storage before Append returns.
+	Sync bool
+
+	// Logger reports failed appends in Run and stream errors (optional).
+	Logger gofi.Logger
+}
+
+// Store appends events to the current file of a directory.
+type Store struct {
+	config Config
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open validates cfg, applies retention and opens the current file for
+// appending.
+func Open(cfg Config) (*Store, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("directory is required")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+	if strings.ContainsAny(cfg.Prefix, `/\`) {
+		return nil, fmt.Errorf("invalid prefix %q", cfg.Prefix)
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 64 << 20
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event directory: %w", err)
+	}
+
+	s := &Store{config: cfg}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	if err := s.prune(); err != nil {
+		s.file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// current returns the path of the file being appended to.
+func (s *Store) current() string {
+	return filepath.Join(s.config.Dir, s.config.Prefix+".jsonl")
+}
+
+// open opens the current file for appending.
+func (s *Store) open() error {
+	f, err := os.OpenFile(s.current(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open event file: %w", err)
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// Append writes an event received from site's stream, rotating the file
+// first if it is full.
+func (s *Store) Append(site string, ev types.Event) error {
+	line, err := json.Marshal(Record{Site: site, Time: eventsub.Time(ev), Event: ev})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errors.New("event store is closed")
+	}
+	if s.size > 0 && s.size+int64(len(line)) > s.config.MaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	if s.config.Sync {
+		if err := s.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync event file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Rotate starts a new current file, even if the current one is not full.
+// An empty current file is kept.
+func (s *Store) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errors.New("event store is closed")
+	}
+	if s.size == 0 {
+		return nil
+	}
+	return s.rotate()
+}
+
+// rotate renames the current file, opens a new one and applies retention.
+func (s *Store) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event file: %w", err)
+	}
+	s.file = nil
+
+	rotated := filepath.Join(s.config.Dir, s.config.Prefix+"-"+time.Now().UTC().Format(rotatedFormat)+".jsonl")
+	if err := os.Rename(s.current(), rotated); err != nil {
+		// Keep appending to the full file rather than losing events.
+		if openErr := s.open(); openErr != nil {
+			return errors.Join(fmt.Errorf("failed to rotate event file: %w", err), openErr)
+		}
+		return fmt.Errorf("failed to rotate event file: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.prune()
+}
+
+// prune deletes rotated files beyond MaxFiles or older than MaxAge.
+func (s *Store) prune() error {
+	rotated, err := rotatedFiles(s.config.Dir, s.config.Prefix)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	cutoff := time.Now().Add(-s.config.MaxAge)
+	for i, path := range rotated {
+		keep := len(rotated) - i
+		expired := s.config.MaxFiles > 0 && keep > s.config.MaxFiles
+		if !expired && s.config.MaxAge > 0 {
+			// A file was last written just before it was rotated.
+			if info, err := os.Stat(path); err == nil && info.ModTime().Before(cutoff) {
+				expired = true
+			}
+		}
+		if expired {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to remove old event file: %w", err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the current file.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Run appends events from the channel until it is closed or ctx is done.
+// Failed appends are logged and do not stop it.
+func (s *Store) Run(ctx context.Context, site string, events <-chan types.Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := s.Append(site, ev); err != nil {
+				eventsub.Warn(s.config.Logger, "Failed to store event", "event", ev.Key, "error", err)
+			}
+		}
+	}
+}
+
+// Subscribe subscribes to the site's event stream and stores its events
+// until ctx is done or the stream ends. Stream errors are logged.
+func (s *Store) Subscribe(ctx context.Context, c gofi.Client, site string) error {
+	return eventsub.Subscribe(ctx, c, site, s.config.Logger, func(ctx context.Context, events <-chan types.Event) error {
+		return s.Run(ctx, site, events)
+	})
+}
+
+// Read reads the store's files like the package-level Read. Events
+// appended concurrently may or may not be seen.
+func (s *Store) Read(f Filter, fn func(Record) error) error {
+	return Read(s.config.Dir, s.config.Prefix, f, fn)
+}
+
+// Filter selects records for Read. Zero fields match everything.
+type Filter struct {
+	// Site matches the site the event was received from.
+	Site string
+
+	// Keys selects event keys such as "EVT_WU_Connected". A trailing "*"
+	// matches a prefix ("EVT_AP_*").
+	Keys []string
+
+	// Since and Until bound the event time, inclusive and exclusive.
+	Since time.Time
+	Until time.Time
+}
+
+// Match reports whether the filter selects r.
+func (f Filter) Match(r Record) bool {
+	if f.Site != "" && r.Site != f.Site {
+		return false
+	}
+	if !f.Since.IsZero() && r.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !r.Time.Before(f.Until) {
+		return false
+	}
+	return eventsub.MatchKey(f.Keys, r.Event.Key)
+}
+
+// Read calls fn for every record in dir's files with the given prefix
+// (DefaultPrefix if empty) that f selects, oldest file first and in
+// append order within a file. Lines that cannot be decoded, such as one
+// cut short by a crash, are skipped. An error from fn stops the read and
+// is returned.
+func Read(dir, prefix string, f Filter, fn func(Record) error) error {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	files, err := rotatedFiles(dir, prefix)
+	if err != nil {
+		return err
+	}
+	files = append(files, filepath.Join(dir, prefix+".jsonl"))
+
+	for _, path := range files {
+		if err := readFile(path, f, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFile reads one file for Read. A missing file is skipped, since it
+// may have been pruned or rotated meanwhile.
+func readFile(path string, f Filter, fn func(Record) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open event file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if !f.Match(r) {
+			continue
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// rotatedFiles returns the rotated files of prefix in dir, oldest first.
+func rotatedFiles(dir, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event files: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), prefix+"-")
+		if !ok || e.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, ".jsonl")
+		if _, err := time.Parse(rotatedFormat, stamp); !ok || err != nil {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,182 @@
+package eventstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+// readAll returns the keys of the records f selects.
+func readAll(t *testing.T, dir string, f Filter) []string {
+	t.Helper()
+	var keys []string
+	err := Read(dir, "", f, func(r Record) error {
+		keys = append(keys, r.Event.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return keys
+}
+
+func TestStore_AppendAndRead(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []types.Event{
+		{Key: "EVT_WU_Connected", Time: types.NewFlexTime(base.UnixMilli())},
+		{Key: "EVT_AP_Lost_Contact", Time: types.NewFlexTime(base.Add(time.Hour).UnixMilli())},
+		{Key: "EVT_AP_Connected", Time: types.NewFlexTime(base.Add(2 * time.Hour).UnixMilli())},
+	}
+	for _, ev := range events {
+		if err := store.Append("default", ev); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	store.Append("branch", types.Event{Key: "EVT_AP_Connected", Time: types.NewFlexTime(base.UnixMilli())})
+	store.Close()
+
+	// History survives reopening the store.
+	store, err = Open(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	defer store.Close()
+	store.Append("default", types.Event{Key: "EVT_WU_Disconnected", Time: types.NewFlexTime(base.Add(3 * time.Hour).UnixMilli())})
+
+	if got := readAll(t, dir, Filter{}); len(got) != 5 {
+		t.Errorf("Read() = %v, want 5 records", got)
+	}
+	got := readAll(t, dir, Filter{Site: "default", Keys: []string{"EVT_AP_*"}, Since: base.Add(time.Hour), Until: base.Add(2 * time.Hour)})
+	if strings.Join(got, ",") != "EVT_AP_Lost_Contact" {
+		t.Errorf("filtered Read() = %v, want [EVT_AP_Lost_Contact]", got)
+	}
+}
+
+func TestStore_RotationAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(Config{Dir: dir, MaxSize: 200, MaxFiles: 2})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	for i := 0; i < 10; i++ {
+		if err := store.Append("default", types.Event{Key: "EVT_WU_Connected", Message: strings.Repeat("x", 100)}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	rotated, _ := rotatedFiles(dir, DefaultPrefix)
+	if len(rotated) != 2 {
+		t.Errorf("rotated files = %v, want 2 kept", rotated)
+	}
+	// The kept files and the current one hold the newest events.
+	if got := readAll(t, dir, Filter{}); len(got) != 3 {
+		t.Errorf("Read() after pruning = %d records, want 3", len(got))
+	}
+
+	// Old rotated files are deleted on rotation.
+	store.config.MaxFiles, store.config.MaxAge = 0, time.Hour
+	old := time.Now().Add(-2 * time.Hour)
+	for _, path := range rotated {
+		os.Chtimes(path, old, old)
+	}
+	if err := store.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if rotated, _ := rotatedFiles(dir, DefaultPrefix); len(rotated) != 1 {
+		t.Errorf("rotated files after MaxAge = %v, want only the new one", rotated)
+	}
+}
+
+func TestStore_Run(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(Config{Dir: dir, Prefix: "site"})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	events := make(chan types.Event, 2)
+	events <- types.Event{Key: "EVT_WU_Connected"}
+	events <- types.Event{Key: "EVT_WU_Disconnected"}
+	close(events)
+	if err := store.Run(context.Background(), "default", events); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// A line cut short by a crash is skipped.
+	f, _ := os.OpenFile(filepath.Join(dir, "site.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"site":"default","ev`)
+	f.Close()
+
+	var n int
+	err = store.Read(Filter{}, func(r Record) error {
+		n++
+		if r.Time.IsZero() || r.Site != "default" {
+			t.Errorf("record = %+v, want receive time and site", r)
+		}
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Errorf("Read() = %d records, %v; want 2", n, err)
+	}
+}
+
+func TestStore_SubscribeLeavesEventsOpen(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := gofi.New(&gofi.Config{Host: server.Host(), Port: server.Port(), Username: "admin", Password: "admin", SkipTLSVerify: true})
+	if err != nil {
+		t.Fatalf("gofi.New() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Disconnect(context.Background())
+
+	dir := t.TempDir()
+	store, err := Open(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- store.Subscribe(subCtx, c, "default") }()
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for len(readAll(t, dir, Filter{})) == 0 {
+		select {
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a stored event")
+		}
+	}
+	subCancel()
+	<-done
+
+	// Ending the store's subscription leaves the client's shared event
+	// service usable.
+	if _, _, err := c.Events().Subscribe(ctx, "default"); err != nil {
+		t.Errorf("Subscribe() after the store stopped: %v", err)
+	}
+}
@@ -13,12 +13,6 @@ import (
 	"github.com/unifi-go/gofi/types"
 )
 
-const (
-	envUsername = "UNIFI_USERNAME"
-	envPassword = "UNIFI_PASSWORD"
-	envUDMIP    = "UNIFI_UDM_IP"
-)
-
 var macRegex = regexp.MustCompile(`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`)
 
 func main() {
@@ -48,11 +42,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Add a fixed IP assignment for a device and create DNS record.\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
-		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", envUsername)
-		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n", envPassword)
-		fmt.Fprintf(os.Stderr, "  %s\tUDM Pro host address (optional, can use -H instead)\n\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tUDM Pro host address (optional, can use -H instead; %s is also read)\n\n", gofi.EnvHost, gofi.EnvUDMIP)
 		fmt.Fprintf(os.Stderr, "Options:\n")
-		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (required unless %s is set)\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (required unless %s is set)\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  -p, --port int\tUDM Pro port (default 443)\n")
 		fmt.Fprintf(os.Stderr, "  -s, --site string\tSite name (default \"default\")\n")
 		fmt.Fprintf(os.Stderr, "  -k, --insecure\tSkip TLS certificate verification\n")
@@ -64,21 +58,18 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  -h, --help\t\tShow this help message\n\n")
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -m aa:bb:cc:dd:ee:ff -i 192.168.1.100 -n \"My Device\"\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -m aa:bb:cc:dd:ee:ff -i 192.168.1.100 -n \"mydevice\" -k  # Uses %s\n", os.Args[0], envUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s -m aa:bb:cc:dd:ee:ff -i 192.168.1.100 -n \"mydevice\" -k  # Uses %s\n", os.Args[0], gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -m aa:bb:cc:dd:ee:ff -i 192.168.1.100 -n \"My Device\" -N \"LAN\"\n", os.Args[0])
 	}
 
 	flag.Parse()
 
-	// Check for host from environment variable if not provided
-	if *host == "" {
-		*host = os.Getenv(envUDMIP)
+	// A --host flag stands in for the host variables
+	if *host != "" {
+		os.Setenv(gofi.EnvHost, *host)
 	}
 
 	// Validate required parameters
-	if *host == "" {
-		exitError("--host is required (or set " + envUDMIP + " environment variable)")
-	}
 	if *mac == "" {
 		exitError("--mac is required")
 	}
@@ -104,26 +95,16 @@ func main() {
 		exitError("only IPv4 addresses are supported")
 	}
 
-	// Get credentials
-	username := os.Getenv(envUsername)
-	password := os.Getenv(envPassword)
-	if username == "" {
-		exitError(envUsername + " environment variable is required")
-	}
-	if password == "" {
-		exitError(envPassword + " environment variable is required")
+	// Read the host and credentials from the environment
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		exitError("incomplete configuration: " + err.Error())
 	}
+	config.Port = *port
+	config.Site = *site
+	config.SkipTLSVerify = config.SkipTLSVerify || *insecure
 
 	// Create client
-	config := &gofi.Config{
-		Host:          *host,
-		Port:          *port,
-		Username:      username,
-		Password:      password,
-		Site:          *site,
-		SkipTLSVerify: *insecure,
-	}
-
 	client, err := gofi.New(config)
 	if err != nil {
 		exitError("failed to create client: " + err.Error())
@@ -11,11 +11,6 @@ import (
 	"github.com/unifi-go/gofi"
 )
 
-const (
-	envUsername = "UNIFI_USERNAME"
-	envPassword = "UNIFI_PASSWORD"
-)
-
 // debugLogger implements gofi.Logger for debug output.
 type debugLogger struct{}
 
@@ -60,10 +55,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Basic example demonstrating gofi library usage.\n")
 		fmt.Fprintf(os.Stderr, "Lists sites, devices, networks, and health status.\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
-		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", envUsername)
-		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n\n", envPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tUDM Pro host address (optional, can use -H instead)\n\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "Options:\n")
-		fmt.Fprintf(os.Stderr, "  -H, --host string\t\tUDM Pro host address (required)\n")
+		fmt.Fprintf(os.Stderr, "  -H, --host string\t\tUDM Pro host address (required unless %s is set)\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  -p, --port int\t\tUDM Pro port (default 443)\n")
 		fmt.Fprintf(os.Stderr, "  -s, --site string\t\tSite name (default \"default\")\n")
 		fmt.Fprintf(os.Stderr, "  -k, --insecure\t\tSkip TLS certificate verification\n")
@@ -77,40 +73,27 @@ func main() {
 
 	flag.Parse()
 
-	// Validate required parameters
-	if *host == "" {
-		fmt.Fprintf(os.Stderr, "Error: --host is required\n\n")
-		flag.Usage()
-		os.Exit(1)
+	// A --host flag stands in for the host variables
+	if *host != "" {
+		os.Setenv(gofi.EnvHost, *host)
 	}
 
-	// Get credentials from environment
-	username := os.Getenv(envUsername)
-	password := os.Getenv(envPassword)
-
-	if username == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envUsername)
+	// Read the host and credentials from the environment
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: incomplete configuration: %v\n\n", err)
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	if password == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envPassword)
-		os.Exit(1)
-	}
+	// Apply the connection flags
+	config.Port = *port
+	config.Site = *site
+	config.SkipTLSVerify = config.SkipTLSVerify || *insecure
+	config.Timeout = *timeout
 
 	if *debug {
-		log.Printf("Connecting to %s:%d as user %s", *host, *port, username)
-	}
-
-	// Create client configuration
-	config := &gofi.Config{
-		Host:          *host,
-		Port:          *port,
-		Username:      username,
-		Password:      password,
-		Site:          *site,
-		SkipTLSVerify: *insecure,
-		Timeout:       *timeout,
+		log.Printf("Connecting to %s:%d as user %s", config.Host, config.Port, config.Username)
 	}
 
 	if *debug {
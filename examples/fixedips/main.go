@@ -12,12 +12,6 @@ import (
 	"github.com/unifi-go/gofi"
 )
 
-const (
-	envUsername = "UNIFI_USERNAME"
-	envPassword = "UNIFI_PASSWORD"
-	envUDMIP    = "UNIFI_UDM_IP"
-)
-
 // FixedIPEntry holds information about a fixed IP assignment.
 type FixedIPEntry struct {
 	Name     string `json:"name"`
@@ -45,11 +39,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "List all clients with fixed IP addresses assigned.\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
-		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", envUsername)
-		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n", envPassword)
-		fmt.Fprintf(os.Stderr, "  %s\tUDM Pro host address (optional, can use -H instead)\n\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s\tUsername for UDM authentication (required)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s\tPassword for UDM authentication (required)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tUDM Pro host address (optional, can use -H instead; %s is also read)\n\n", gofi.EnvHost, gofi.EnvUDMIP)
 		fmt.Fprintf(os.Stderr, "Options:\n")
-		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (required unless %s is set)\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (required unless %s is set)\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  -p, --port int\tUDM Pro port (default 443)\n")
 		fmt.Fprintf(os.Stderr, "  -s, --site string\tSite name (default \"default\")\n")
 		fmt.Fprintf(os.Stderr, "  -k, --insecure\tSkip TLS certificate verification\n")
@@ -57,46 +51,29 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  -h, --help\t\tShow this help message\n\n")
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -k  # Uses %s\n", os.Args[0], envUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s -k  # Uses %s\n", os.Args[0], gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -j | jq\n", os.Args[0])
 	}
 
 	flag.Parse()
 
-	// Check for host from environment variable if not provided
-	if *host == "" {
-		*host = os.Getenv(envUDMIP)
+	// A --host flag stands in for the host variables
+	if *host != "" {
+		os.Setenv(gofi.EnvHost, *host)
 	}
 
-	if *host == "" {
-		fmt.Fprintf(os.Stderr, "Error: --host is required (or set %s environment variable)\n\n", envUDMIP)
+	// Read the host and credentials from the environment
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: incomplete configuration: %v\n\n", err)
 		flag.Usage()
 		os.Exit(1)
 	}
-
-	username := os.Getenv(envUsername)
-	password := os.Getenv(envPassword)
-
-	if username == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envUsername)
-		os.Exit(1)
-	}
-
-	if password == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envPassword)
-		os.Exit(1)
-	}
+	config.Port = *port
+	config.Site = *site
+	config.SkipTLSVerify = config.SkipTLSVerify || *insecure
 
 	// Create client
-	config := &gofi.Config{
-		Host:          *host,
-		Port:          *port,
-		Username:      username,
-		Password:      password,
-		Site:          *site,
-		SkipTLSVerify: *insecure,
-	}
-
 	client, err := gofi.New(config)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to create client: %v\n", err)
@@ -15,11 +15,6 @@ import (
 	"github.com/unifi-go/gofi/types"
 )
 
-const (
-	envUsername = "UNIFI_USERNAME"
-	envPassword = "UNIFI_PASSWORD"
-)
-
 // PoE actions
 const (
 	ActionEnable  = "enable"
@@ -184,10 +179,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  -W, --wait-timeout <duration> Timeout for wait (default 30s)\n")
 		fmt.Fprintf(os.Stderr, "  -T, --settle <duration>       Hardware settle time (default 2s)\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
-		fmt.Fprintf(os.Stderr, "  %s               Username for UDM authentication (required)\n", envUsername)
-		fmt.Fprintf(os.Stderr, "  %s               Password for UDM authentication (required)\n\n", envPassword)
+		fmt.Fprintf(os.Stderr, "  %s               Username for UDM authentication (required)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s               Password for UDM authentication (required)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s                   UDM Pro host address (optional, can use -H instead)\n\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "Options:\n")
-		fmt.Fprintf(os.Stderr, "  -H, --host <host>             UDM Pro host address (required)\n")
+		fmt.Fprintf(os.Stderr, "  -H, --host <host>             UDM Pro host address (required unless %s is set)\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  -p, --port <port>             UDM Pro port (default 443)\n")
 		fmt.Fprintf(os.Stderr, "  -s, --site <site>             Site name (default \"default\")\n")
 		fmt.Fprintf(os.Stderr, "  -k, --insecure                Skip TLS certificate verification\n")
@@ -206,11 +202,9 @@ func main() {
 
 	flag.Parse()
 
-	// Validate required parameters
-	if *host == "" {
-		fmt.Fprintf(os.Stderr, "Error: --host is required\n\n")
-		flag.Usage()
-		os.Exit(1)
+	// A --host flag stands in for the host variables
+	if *host != "" {
+		os.Setenv(gofi.EnvHost, *host)
 	}
 
 	// Validate commands
@@ -243,34 +237,23 @@ func main() {
 		}
 	}
 
-	// Get credentials from environment
-	username := os.Getenv(envUsername)
-	password := os.Getenv(envPassword)
-
-	if username == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envUsername)
-		os.Exit(1)
-	}
-
-	if password == "" {
-		fmt.Fprintf(os.Stderr, "Error: %s environment variable is required\n", envPassword)
+	// Read the host and credentials from the environment
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: incomplete configuration: %v\n\n", err)
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	// Create client configuration
-	config := &gofi.Config{
-		Host:          *host,
-		Port:          *port,
-		Username:      username,
-		Password:      password,
-		Site:          *site,
-		SkipTLSVerify: *insecure,
-		Timeout:       *timeout,
-	}
+	// Apply the connection flags
+	config.Port = *port
+	config.Site = *site
+	config.SkipTLSVerify = config.SkipTLSVerify || *insecure
+	config.Timeout = *timeout
 
 	if *debug {
 		config.Logger = &debugLogger{}
-		log.Printf("Connecting to %s:%d as user %s", *host, *port, username)
+		log.Printf("Connecting to %s:%d as user %s", config.Host, config.Port, config.Username)
 	}
 
 	// Create client
@@ -323,14 +306,7 @@ func controlPoE(ctx context.Context, client gofi.Client, host, site, action, swi
 	}
 
 	// Validate port exists and is PoE capable
-	var targetPort *types.PortTable
-	for i := range sw.PortTable {
-		if sw.PortTable[i].PortIdx == portNum {
-			targetPort = &sw.PortTable[i]
-			break
-		}
-	}
-
+	targetPort := sw.FindPort(portNum)
 	if targetPort == nil {
 		return fmt.Errorf("port %d not found on switch %s", portNum, sw.Name)
 	}
@@ -474,33 +450,30 @@ func waitForState(ctx context.Context, client gofi.Client, site, mac string, por
 		}
 
 		// Find the port
-		for _, p := range sw.PortTable {
-			if p.PortIdx == portNum {
-				if debug {
-					log.Printf("Port %d: poe_mode=%s (want %s)", portNum, p.PoeMode, expectedMode)
-				}
+		if p := sw.FindPort(portNum); p != nil {
+			if debug {
+				log.Printf("Port %d: poe_mode=%s (want %s)", portNum, p.PoeMode, expectedMode)
+			}
 
-				// Check if poe_mode matches expected
-				modeMatches := (expectedEnabled && p.PoeMode != "off" && p.PoeMode != "") ||
-					(!expectedEnabled && p.PoeMode == "off")
+			// Check if poe_mode matches expected
+			modeMatches := (expectedEnabled && p.PoeMode != "off" && p.PoeMode != "") ||
+				(!expectedEnabled && p.PoeMode == "off")
 
-				if modeMatches {
-					if debug {
-						log.Printf("Config applied, waiting %v for hardware settle", settleTime)
-					}
-					// Phase 2: Wait for hardware to settle
-					select {
-					case <-ctx.Done():
-						return "", ctx.Err()
-					case <-time.After(settleTime):
-						// Done - config applied and hardware had time to settle
-						if expectedEnabled {
-							return "enabled", nil
-						}
-						return "disabled", nil
+			if modeMatches {
+				if debug {
+					log.Printf("Config applied, waiting %v for hardware settle", settleTime)
+				}
+				// Phase 2: Wait for hardware to settle
+				select {
+				case <-ctx.Done():
+					return "", ctx.Err()
+				case <-time.After(settleTime):
+					// Done - config applied and hardware had time to settle
+					if expectedEnabled {
+						return "enabled", nil
 					}
+					return "disabled", nil
 				}
-				break
 			}
 		}
 
@@ -654,12 +627,7 @@ func listSwitches(ctx context.Context, client gofi.Client, host, site string, js
 func toSwitchInfo(d types.Device, includePorts bool) SwitchInfo {
 	// Count total ports and PoE ports
 	numPorts := len(d.PortTable)
-	poePorts := 0
-	for _, p := range d.PortTable {
-		if p.PortPoe {
-			poePorts++
-		}
-	}
+	poePorts := len(d.PoEPorts())
 
 	info := SwitchInfo{
 		ID:            d.ID,
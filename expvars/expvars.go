@@ -0,0 +1,27 @@
+// Package expvars publishes gofi client internals through the standard
+// expvar package.
+//
+// It is a separate package because importing expvar registers the
+// /debug/vars handler on http.DefaultServeMux, which programs that do not
+// want it should not get just by importing gofi.
+//
+//	expvars.Publish("unifi", client)
+//	go http.ListenAndServe("localhost:6060", nil) // GET /debug/vars
+package expvars
+
+import (
+	"expvar"
+
+	"github.com/unifi-go/gofi"
+)
+
+// Func returns an expvar.Func reporting c.Diagnostics() each time it is read.
+func Func(c gofi.Client) expvar.Func {
+	return func() any { return c.Diagnostics() }
+}
+
+// Publish registers c's diagnostics under name. Like expvar.Publish, it panics
+// if name is already registered, so call it once per client.
+func Publish(name string, c gofi.Client) {
+	expvar.Publish(name, Func(c))
+}
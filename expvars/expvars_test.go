@@ -0,0 +1,61 @@
+package expvars
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/mock"
+)
+
+func TestPublish(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := gofi.New(&gofi.Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Disconnect(ctx)
+
+	if _, err := c.Devices().List(ctx, "default"); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	Publish("gofi_test", c)
+
+	v := expvar.Get("gofi_test")
+	if v == nil {
+		t.Fatal("expvar.Get() = nil, want published stats")
+	}
+
+	var got struct {
+		Host      string `json:"host"`
+		Connected bool   `json:"connected"`
+		Transport struct {
+			Requests uint64 `json:"requests"`
+		} `json:"transport"`
+	}
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", v.String(), err)
+	}
+
+	if got.Host != server.Host() || !got.Connected {
+		t.Errorf("stats = %+v, want connected to %s", got, server.Host())
+	}
+	if got.Transport.Requests < 2 {
+		t.Errorf("transport.requests = %d, want login and list counted", got.Transport.Requests)
+	}
+}
@@ -0,0 +1,286 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// FirewallEndpoint is the source or destination of an exported rule, with
+// group and network IDs resolved to names.
+type FirewallEndpoint struct {
+	Groups  []string `json:"groups,omitempty"`
+	Network string   `json:"network,omitempty"`
+	Address string   `json:"address,omitempty"`
+	MAC     string   `json:"mac,omitempty"`
+	Port    string   `json:"port,omitempty"`
+}
+
+// String describes the endpoint, e.g. `group "Servers" port 443`, or
+// "any" if it matches everything.
+func (e FirewallEndpoint) String() string {
+	var parts []string
+	for _, g := range e.Groups {
+		parts = append(parts, fmt.Sprintf("group %q", g))
+	}
+	if e.Network != "" {
+		parts = append(parts, fmt.Sprintf("network %q", e.Network))
+	}
+	if e.Address != "" {
+		parts = append(parts, e.Address)
+	}
+	if e.MAC != "" {
+		parts = append(parts, "mac "+e.MAC)
+	}
+	if e.Port != "" {
+		parts = append(parts, "port "+e.Port)
+	}
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, " ")
+}
+
+// FirewallRuleExport is one rule of a FirewallExport.
+type FirewallRuleExport struct {
+	Index       int              `json:"index"`
+	Name        string           `json:"name"`
+	Enabled     bool             `json:"enabled"`
+	Action      string           `json:"action"`
+	Protocol    string           `json:"protocol"`
+	ICMPType    string           `json:"icmp_type,omitempty"`
+	Source      FirewallEndpoint `json:"source"`
+	Destination FirewallEndpoint `json:"destination"`
+	States      []string         `json:"states,omitempty"`
+	IPSec       string           `json:"ipsec,omitempty"`
+	Logging     bool             `json:"logging,omitempty"`
+}
+
+// FirewallRulesetExport is the rules of one ruleset in evaluation order.
+type FirewallRulesetExport struct {
+	Name  string               `json:"name"`
+	Rules []FirewallRuleExport `json:"rules"`
+}
+
+// FirewallGroupExport is a firewall group and the rules that use it.
+type FirewallGroupExport struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Members []string `json:"members"`
+
+	// UsedBy lists the referencing rules as "RULESET index".
+	UsedBy []string `json:"used_by,omitempty"`
+}
+
+// FirewallExport is a site's firewall configuration in a form meant for
+// people: audit trails, change reviews and diffs between exports. It
+// encodes to JSON as is; WriteText renders it as text.
+type FirewallExport struct {
+	Site     string                  `json:"site,omitempty"`
+	Rulesets []FirewallRulesetExport `json:"rulesets"`
+	Groups   []FirewallGroupExport   `json:"groups"`
+}
+
+// ExportFirewall fetches a site's firewall rules, groups and networks and
+// combines them with NewFirewallExport.
+func ExportFirewall(ctx context.Context, c Client, site string) (*FirewallExport, error) {
+	rules, err := c.Firewall().ListRules(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	groups, err := c.Firewall().ListGroups(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+
+	networks, err := c.Networks().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	export := NewFirewallExport(rules, groups, networks)
+	export.Site = site
+	return export, nil
+}
+
+// NewFirewallExport groups rules by ruleset and orders them by rule index.
+// Rulesets appear in the order of types.Rulesets and types.RulesetsV6,
+// followed by any others by name; empty rulesets are left out. Group and
+// network IDs that cannot be resolved are shown as "unknown <id>". Groups
+// are sorted by name.
+func NewFirewallExport(rules []types.FirewallRule, groups []types.FirewallGroup, networks []types.Network) *FirewallExport {
+	groupNames := make(map[string]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+	networkNames := make(map[string]string, len(networks))
+	for _, n := range networks {
+		networkNames[n.ID] = n.Name
+	}
+	resolve := func(names map[string]string, id string) string {
+		if id == "" {
+			return ""
+		}
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return "unknown " + id
+	}
+
+	byRuleset := make(map[string][]types.FirewallRule)
+	for _, r := range rules {
+		byRuleset[r.Ruleset] = append(byRuleset[r.Ruleset], r)
+	}
+
+	order := append(append([]string{}, types.Rulesets...), types.RulesetsV6...)
+	known := make(map[string]bool, len(order))
+	for _, name := range order {
+		known[name] = true
+	}
+	var others []string
+	for name := range byRuleset {
+		if !known[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	order = append(order, others...)
+
+	export := &FirewallExport{Rulesets: []FirewallRulesetExport{}}
+	usedBy := make(map[string][]string)
+	for _, name := range order {
+		list := byRuleset[name]
+		if len(list) == 0 {
+			continue
+		}
+		sort.SliceStable(list, func(i, j int) bool { return list[i].RuleIndex < list[j].RuleIndex })
+
+		rs := FirewallRulesetExport{Name: name}
+		for i := range list {
+			r := &list[i]
+			e := FirewallRuleExport{
+				Index:    r.RuleIndex,
+				Name:     r.Name,
+				Enabled:  r.Enabled,
+				Action:   r.Action,
+				Protocol: r.Protocol,
+				ICMPType: r.ICMPTypename,
+				IPSec:    r.IPSecMatchIPSec,
+				Logging:  r.Logging,
+				Source: FirewallEndpoint{
+					Network: resolve(networkNames, r.SrcNetworkConfID),
+					Address: r.SrcAddress,
+					MAC:     r.SrcMACAddress,
+					Port:    r.SrcPort,
+				},
+				Destination: FirewallEndpoint{
+					Network: resolve(networkNames, r.DstNetworkConfID),
+					Address: r.DstAddress,
+					Port:    r.DstPort,
+				},
+			}
+			if r.IsIPv6() {
+				e.Protocol, e.ICMPType = r.ProtocolV6, r.ICMPv6Typename
+			}
+			if e.Protocol == "" {
+				e.Protocol = "all"
+			}
+			if r.ProtocolMatchExcepted {
+				e.Protocol = "!" + e.Protocol
+			}
+			for _, st := range []struct {
+				on   bool
+				name string
+			}{
+				{r.StateNew, "new"},
+				{r.StateEstablished, "established"},
+				{r.StateRelated, "related"},
+				{r.StateInvalid, "invalid"},
+			} {
+				if st.on {
+					e.States = append(e.States, st.name)
+				}
+			}
+
+			ref := fmt.Sprintf("%s %d", name, r.RuleIndex)
+			for _, id := range r.SrcFirewallGroupIDs {
+				e.Source.Groups = append(e.Source.Groups, resolve(groupNames, id))
+				usedBy[id] = appendUnique(usedBy[id], ref)
+			}
+			for _, id := range r.DstFirewallGroupIDs {
+				e.Destination.Groups = append(e.Destination.Groups, resolve(groupNames, id))
+				usedBy[id] = appendUnique(usedBy[id], ref)
+			}
+			rs.Rules = append(rs.Rules, e)
+		}
+		export.Rulesets = append(export.Rulesets, rs)
+	}
+
+	export.Groups = make([]FirewallGroupExport, 0, len(groups))
+	for _, g := range groups {
+		members := append([]string{}, g.GroupMembers...)
+		export.Groups = append(export.Groups, FirewallGroupExport{
+			Name:    g.Name,
+			Type:    g.GroupType,
+			Members: members,
+			UsedBy:  usedBy[g.ID],
+		})
+	}
+	sort.SliceStable(export.Groups, func(i, j int) bool { return export.Groups[i].Name < export.Groups[j].Name })
+	return export
+}
+
+// WriteText writes the export as plain text: each ruleset with one line
+// per rule, then the groups with their members and users.
+func (e *FirewallExport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	if e.Site != "" {
+		fmt.Fprintf(&b, "# Firewall rules for site %s\n", e.Site)
+	} else {
+		b.WriteString("# Firewall rules\n")
+	}
+
+	for _, rs := range e.Rulesets {
+		fmt.Fprintf(&b, "\n[%s]\n", rs.Name)
+		for _, r := range rs.Rules {
+			fmt.Fprintf(&b, "%5d  %-6s %-4s %q from %s to %s", r.Index, r.Action, r.Protocol, r.Name, r.Source, r.Destination)
+			if r.ICMPType != "" {
+				fmt.Fprintf(&b, " icmp %s", r.ICMPType)
+			}
+			if len(r.States) > 0 {
+				fmt.Fprintf(&b, " state %s", strings.Join(r.States, ","))
+			}
+			if r.IPSec != "" {
+				fmt.Fprintf(&b, " ipsec %s", r.IPSec)
+			}
+			if r.Logging {
+				b.WriteString(" log")
+			}
+			if !r.Enabled {
+				b.WriteString(" (disabled)")
+			}
+			b.WriteByte('\n')
+		}
+	}
+
+	if len(e.Groups) > 0 {
+		b.WriteString("\n[groups]\n")
+		for _, g := range e.Groups {
+			fmt.Fprintf(&b, "%q (%s): %s", g.Name, g.Type, strings.Join(g.Members, ", "))
+			if len(g.UsedBy) > 0 {
+				fmt.Fprintf(&b, "; used by %s", strings.Join(g.UsedBy, ", "))
+			} else {
+				b.WriteString("; unused")
+			}
+			b.WriteByte('\n')
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,119 @@
+package gofi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func firewallFixture() ([]types.FirewallRule, []types.FirewallGroup, []types.Network) {
+	rules := []types.FirewallRule{
+		{ID: "r3", Name: "Block IoT to LAN", Enabled: true, Ruleset: types.RulesetLANIn, RuleIndex: 2001, Action: "drop",
+			Protocol: "all", SrcNetworkConfID: "iot", DstFirewallGroupIDs: []string{"lan-hosts"}},
+		{ID: "r1", Name: "Allow web", Enabled: true, Ruleset: types.RulesetWANIn, RuleIndex: 2000, Action: "accept",
+			Protocol: "tcp", DstFirewallGroupIDs: []string{"servers", "web-ports"}, StateNew: true, Logging: true},
+		{ID: "r2", Name: "Allow IoT DNS", Enabled: false, Ruleset: types.RulesetLANIn, RuleIndex: 2000, Action: "accept",
+			Protocol: "udp", SrcNetworkConfID: "iot", DstAddress: "192.168.1.1", DstPort: "53"},
+		{ID: "r4", Name: "ICMPv6", Enabled: true, Ruleset: types.RulesetWANv6In, RuleIndex: 2000, Action: "accept",
+			Protocol: "tcp", ProtocolV6: "icmpv6", ICMPv6Typename: "echo-request", SrcFirewallGroupIDs: []string{"gone"}},
+	}
+	groups := []types.FirewallGroup{
+		{ID: "web-ports", Name: "Web", GroupType: "port-group", GroupMembers: []string{"80", "443"}},
+		{ID: "servers", Name: "Servers", GroupType: "address-group", GroupMembers: []string{"192.168.1.10"}},
+		{ID: "lan-hosts", Name: "LAN Hosts", GroupType: "address-group", GroupMembers: []string{"192.168.1.0/24"}},
+		{ID: "spare", Name: "Spare", GroupType: "address-group"},
+	}
+	networks := []types.Network{{ID: "iot", Name: "IoT"}}
+	return rules, groups, networks
+}
+
+func TestNewFirewallExport(t *testing.T) {
+	export := NewFirewallExport(firewallFixture())
+
+	var names []string
+	for _, rs := range export.Rulesets {
+		names = append(names, rs.Name)
+	}
+	if want := []string{"WAN_IN", "LAN_IN", "WANv6_IN"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("rulesets = %v, want %v", names, want)
+	}
+
+	lan := export.Rulesets[1].Rules
+	if lan[0].Name != "Allow IoT DNS" || lan[1].Name != "Block IoT to LAN" {
+		t.Errorf("LAN_IN order = %q, %q, want by rule index", lan[0].Name, lan[1].Name)
+	}
+	if lan[1].Source.Network != "IoT" || !reflect.DeepEqual(lan[1].Destination.Groups, []string{"LAN Hosts"}) {
+		t.Errorf("LAN_IN rule = %+v, want network and group names resolved", lan[1])
+	}
+
+	v6 := export.Rulesets[2].Rules[0]
+	if v6.Protocol != "icmpv6" || v6.ICMPType != "echo-request" || v6.Source.Groups[0] != "unknown gone" {
+		t.Errorf("IPv6 rule = %+v, want icmpv6 and unresolved group", v6)
+	}
+
+	var groups []string
+	for _, g := range export.Groups {
+		groups = append(groups, g.Name)
+	}
+	if want := []string{"LAN Hosts", "Servers", "Spare", "Web"}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups = %v, want %v", groups, want)
+	}
+	if got := export.Groups[3].UsedBy; !reflect.DeepEqual(got, []string{"WAN_IN 2000"}) {
+		t.Errorf("Web UsedBy = %v, want [WAN_IN 2000]", got)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"destination":{"groups":["Servers","Web"]`) {
+		t.Errorf("JSON = %s, want resolved group names", data)
+	}
+}
+
+func TestFirewallExport_WriteText(t *testing.T) {
+	export := NewFirewallExport(firewallFixture())
+	export.Site = "default"
+
+	var buf bytes.Buffer
+	if err := export.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"# Firewall rules for site default\n",
+		"\n[WAN_IN]\n" + ` 2000  accept tcp  "Allow web" from any to group "Servers" group "Web" state new log` + "\n",
+		` 2000  accept udp  "Allow IoT DNS" from network "IoT" to 192.168.1.1 port 53 (disabled)` + "\n",
+		` 2001  drop   all  "Block IoT to LAN" from network "IoT" to group "LAN Hosts"` + "\n",
+		`"Spare" (address-group): ; unused` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteText() missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportFirewall(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	rules, groups, _ := firewallFixture()
+	server.State().AddFirewallRule(&rules[1])
+	server.State().AddFirewallGroup(&groups[0])
+	server.State().AddFirewallGroup(&groups[1])
+	c := connectMock(t, server)
+
+	export, err := ExportFirewall(context.Background(), c, "default")
+	if err != nil {
+		t.Fatalf("ExportFirewall() error = %v", err)
+	}
+	if export.Site != "default" || len(export.Rulesets) != 1 || len(export.Groups) != 2 {
+		t.Errorf("ExportFirewall() = %+v, want one ruleset and two groups", export)
+	}
+}
@@ -0,0 +1,326 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+)
+
+// FixedIP is a desired DHCP reservation: a client MAC pinned to an IP.
+type FixedIP struct {
+	IP  string
+	MAC string
+
+	// Name is the client alias. Empty leaves the current name untouched.
+	Name string
+}
+
+// FixedIPAction is what a sync does for one reservation.
+type FixedIPAction string
+
+// Fixed IP sync actions.
+const (
+	// FixedIPUnchanged means the reservation already matches.
+	FixedIPUnchanged FixedIPAction = "unchanged"
+
+	// FixedIPCreate means a new known client with the reservation is created.
+	FixedIPCreate FixedIPAction = "create"
+
+	// FixedIPUpdate means an existing known client gets a new or changed reservation.
+	FixedIPUpdate FixedIPAction = "update"
+
+	// FixedIPRemove means a reservation missing from the desired set is cleared.
+	// Only planned when FixedIPSyncOptions.Prune is set.
+	FixedIPRemove FixedIPAction = "remove"
+
+	// FixedIPInvalid means the reservation cannot be applied; see Err.
+	FixedIPInvalid FixedIPAction = "invalid"
+)
+
+// FixedIPChange is a single planned change.
+type FixedIPChange struct {
+	Action FixedIPAction
+
+	// MAC is the client MAC address, lowercased with colon separators.
+	MAC string
+
+	// IP is the desired address (empty for FixedIPRemove).
+	IP string
+
+	// OldIP is the current reserved address, if any.
+	OldIP string
+
+	// Name is the desired client alias (empty to leave it unchanged).
+	Name string
+
+	// OldName is the current client alias, if any.
+	OldName string
+
+	// NetworkID is the network containing IP.
+	NetworkID string
+
+	// Err explains why a FixedIPInvalid change cannot be applied, or why
+	// applying the change failed.
+	Err error
+
+	user *types.User
+}
+
+// String formats the change as a diff line: "+" create, "~" update,
+// "-" remove, "!" invalid and " " unchanged.
+func (c FixedIPChange) String() string {
+	switch c.Action {
+	case FixedIPCreate:
+		return fmt.Sprintf("+ %s %s", c.IP, c.MAC)
+	case FixedIPUpdate:
+		var notes []string
+		switch {
+		case c.OldIP == "":
+			notes = append(notes, "reservation added to known client")
+		case c.OldIP != c.IP:
+			notes = append(notes, "was "+c.OldIP)
+		}
+		if c.Name != "" && c.Name != c.OldName {
+			notes = append(notes, fmt.Sprintf("name %q -> %q", c.OldName, c.Name))
+		}
+		return fmt.Sprintf("~ %s %s (%s)", c.IP, c.MAC, strings.Join(notes, ", "))
+	case FixedIPRemove:
+		return fmt.Sprintf("- %s %s", c.OldIP, c.MAC)
+	case FixedIPInvalid:
+		return fmt.Sprintf("! %s %s: %v", c.IP, c.MAC, c.Err)
+	default:
+		return fmt.Sprintf("  %s %s", c.IP, c.MAC)
+	}
+}
+
+// FixedIPSyncOptions controls PlanFixedIPs.
+type FixedIPSyncOptions struct {
+	// Prune clears reservations that are not in the desired set.
+	Prune bool
+}
+
+// FixedIPPlan lists the changes needed to reach a desired set of reservations.
+type FixedIPPlan struct {
+	Site    string
+	Changes []FixedIPChange
+}
+
+// Count returns the number of changes with the given action.
+func (p *FixedIPPlan) Count(action FixedIPAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges returns true if applying the plan would modify the controller.
+func (p *FixedIPPlan) HasChanges() bool {
+	return p.Count(FixedIPCreate)+p.Count(FixedIPUpdate)+p.Count(FixedIPRemove) > 0
+}
+
+// PlanFixedIPs compares desired reservations against the controller and
+// returns the changes needed, without modifying anything. Entries with an
+// invalid MAC, a MAC or IP that appears more than once in desired, an IP
+// reserved for a known client missing from desired, or an IP in no
+// configured network are planned as FixedIPInvalid.
+func PlanFixedIPs(ctx context.Context, c Client, site string, desired []FixedIP, opts FixedIPSyncOptions) (*FixedIPPlan, error) {
+	users, err := c.Users().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	networks, err := c.Networks().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	known := make(map[string]*types.User, len(users))
+	for i := range users {
+		known[internal.FormatMAC(users[i].MAC)] = &users[i]
+	}
+
+	// Count each MAC and IP so duplicates can be rejected; applying two
+	// reservations for one client, or one address for two, would leave the
+	// result up to the order of the changes
+	macs := make(map[string]int, len(desired))
+	ips := make(map[string]int, len(desired))
+	for _, d := range desired {
+		macs[internal.FormatMAC(d.MAC)]++
+		ips[canonicalIP(d.IP)]++
+	}
+
+	// Reservations of known clients outside the desired set keep their
+	// addresses until pruned, which happens after every other change
+	reservedBy := make(map[string]string)
+	for mac, u := range known {
+		if u.UseFixedIP && u.FixedIP != "" && macs[mac] == 0 {
+			reservedBy[canonicalIP(u.FixedIP)] = mac
+		}
+	}
+
+	plan := &FixedIPPlan{Site: site}
+	wanted := make(map[string]bool, len(desired))
+
+	for _, d := range desired {
+		mac := internal.FormatMAC(d.MAC)
+		wanted[mac] = true
+		change := FixedIPChange{MAC: mac, IP: d.IP, Name: d.Name}
+
+		switch {
+		case !internal.ValidateMAC(d.MAC):
+			change.Err = fmt.Errorf("invalid MAC address %q", d.MAC)
+		case macs[mac] > 1:
+			change.Err = fmt.Errorf("MAC %s appears %d times in the desired set", mac, macs[mac])
+		case ips[canonicalIP(d.IP)] > 1:
+			change.Err = fmt.Errorf("IP %s appears %d times in the desired set", d.IP, ips[canonicalIP(d.IP)])
+		case reservedBy[canonicalIP(d.IP)] != "":
+			change.Err = fmt.Errorf("IP %s is reserved for %s, which is not in the desired set", d.IP, reservedBy[canonicalIP(d.IP)])
+		}
+		if change.Err != nil {
+			change.Action = FixedIPInvalid
+			plan.Changes = append(plan.Changes, change)
+			continue
+		}
+
+		u := known[mac]
+		if u != nil {
+			change.OldName = u.Name
+			if u.UseFixedIP {
+				change.OldIP = u.FixedIP
+			}
+		}
+
+		switch {
+		case u != nil && u.UseFixedIP && u.FixedIP == d.IP && (d.Name == "" || d.Name == u.Name):
+			change.Action = FixedIPUnchanged
+			change.NetworkID = u.NetworkID
+		default:
+			networkID, err := networkForIP(networks, d.IP)
+			if err != nil {
+				change.Action = FixedIPInvalid
+				change.Err = err
+				break
+			}
+			change.NetworkID = networkID
+			change.user = u
+			if u == nil {
+				change.Action = FixedIPCreate
+			} else {
+				change.Action = FixedIPUpdate
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		var removals []FixedIPChange
+		for mac, u := range known {
+			if u.UseFixedIP && u.FixedIP != "" && !wanted[mac] {
+				removals = append(removals, FixedIPChange{
+					Action:    FixedIPRemove,
+					MAC:       mac,
+					OldIP:     u.FixedIP,
+					NetworkID: u.NetworkID,
+					user:      u,
+				})
+			}
+		}
+		sort.Slice(removals, func(i, j int) bool { return removals[i].MAC < removals[j].MAC })
+		plan.Changes = append(plan.Changes, removals...)
+	}
+
+	return plan, nil
+}
+
+// ApplyFixedIPs carries out a plan from PlanFixedIPs. Every change is
+// attempted; failures are recorded in the change's Err and the number of
+// failed changes is returned.
+func ApplyFixedIPs(ctx context.Context, c Client, plan *FixedIPPlan) int {
+	failed := 0
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+
+		var err error
+		switch change.Action {
+		case FixedIPCreate:
+			_, err = c.Users().Create(ctx, plan.Site, &types.User{
+				MAC:        change.MAC,
+				Name:       change.Name,
+				UseFixedIP: true,
+				FixedIP:    change.IP,
+				NetworkID:  change.NetworkID,
+			})
+		case FixedIPUpdate:
+			u := change.user.Clone()
+			u.UseFixedIP = true
+			u.FixedIP = change.IP
+			u.NetworkID = change.NetworkID
+			if change.Name != "" {
+				u.Name = change.Name
+			}
+			_, err = c.Users().Update(ctx, plan.Site, u)
+		case FixedIPRemove:
+			err = c.Users().ClearFixedIP(ctx, plan.Site, change.MAC)
+		case FixedIPInvalid:
+			failed++
+			continue
+		default:
+			continue
+		}
+
+		if err != nil {
+			change.Err = fmt.Errorf("failed to %s: %w", change.Action, err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// SyncFixedIPs plans and applies the reservations in one step.
+// It returns the applied plan and the number of failed changes.
+func SyncFixedIPs(ctx context.Context, c Client, site string, desired []FixedIP, opts FixedIPSyncOptions) (*FixedIPPlan, int, error) {
+	plan, err := PlanFixedIPs(ctx, c, site, desired, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return plan, ApplyFixedIPs(ctx, c, plan), nil
+}
+
+// canonicalIP returns ip in its canonical form, or as given if it does not parse.
+func canonicalIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
+
+// networkForIP finds the network whose subnet contains ip.
+func networkForIP(networks []types.Network, ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address %s", ip)
+	}
+
+	for _, n := range networks {
+		if n.IPSubnet == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(n.IPSubnet)
+		if err != nil {
+			continue
+		}
+		if subnet.Contains(parsed) {
+			return n.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no network found containing IP %s", ip)
+}
@@ -0,0 +1,174 @@
+package gofi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func newFixedIPServer() *mock.Server {
+	server := mock.NewServer()
+	server.State().AddNetwork(&types.Network{ID: "lan", Name: "LAN", IPSubnet: "192.168.1.1/24"})
+	server.State().AddKnownClient(&types.User{ID: "u1", MAC: "aa:bb:cc:dd:ee:01", UseFixedIP: true, FixedIP: "192.168.1.10", NetworkID: "lan"})
+	server.State().AddKnownClient(&types.User{ID: "u2", MAC: "aa:bb:cc:dd:ee:02", UseFixedIP: true, FixedIP: "192.168.1.11", NetworkID: "lan"})
+	server.State().AddKnownClient(&types.User{ID: "u3", MAC: "aa:bb:cc:dd:ee:03"})
+	server.State().AddKnownClient(&types.User{ID: "u4", MAC: "aa:bb:cc:dd:ee:04", UseFixedIP: true, FixedIP: "192.168.1.14", NetworkID: "lan"})
+	server.State().AddKnownClient(&types.User{ID: "u7", MAC: "aa:bb:cc:dd:ee:07", Name: "printer", UseFixedIP: true, FixedIP: "192.168.1.17", NetworkID: "lan"})
+	return server
+}
+
+var desiredFixedIPs = []FixedIP{
+	{IP: "192.168.1.10", MAC: "AA:BB:CC:DD:EE:01"},                         // unchanged
+	{IP: "192.168.1.21", MAC: "aa:bb:cc:dd:ee:02"},                         // moved
+	{IP: "192.168.1.13", MAC: "aa:bb:cc:dd:ee:03"},                         // known client, no reservation
+	{IP: "192.168.1.50", MAC: "aa:bb:cc:dd:ee:05"},                         // new client
+	{IP: "10.9.9.9", MAC: "aa:bb:cc:dd:ee:06"},                             // no matching network
+	{IP: "192.168.1.17", MAC: "aa:bb:cc:dd:ee:07", Name: "office-printer"}, // renamed
+}
+
+func TestPlanFixedIPs(t *testing.T) {
+	server := newFixedIPServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	plan, err := PlanFixedIPs(context.Background(), c, "default", desiredFixedIPs, FixedIPSyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("PlanFixedIPs() error = %v", err)
+	}
+
+	want := []string{
+		"  192.168.1.10 aa:bb:cc:dd:ee:01",
+		"~ 192.168.1.21 aa:bb:cc:dd:ee:02 (was 192.168.1.11)",
+		"~ 192.168.1.13 aa:bb:cc:dd:ee:03 (reservation added to known client)",
+		"+ 192.168.1.50 aa:bb:cc:dd:ee:05",
+		"! 10.9.9.9 aa:bb:cc:dd:ee:06: no network found containing IP 10.9.9.9",
+		`~ 192.168.1.17 aa:bb:cc:dd:ee:07 (name "printer" -> "office-printer")`,
+		"- 192.168.1.14 aa:bb:cc:dd:ee:04",
+	}
+	if len(plan.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(plan.Changes), len(want))
+	}
+	for i, c := range plan.Changes {
+		if c.String() != want[i] {
+			t.Errorf("change %d = %q, want %q", i, c.String(), want[i])
+		}
+	}
+
+	// Planning must not modify anything.
+	if u := server.State().GetKnownClient("u2"); u.FixedIP != "192.168.1.11" {
+		t.Errorf("PlanFixedIPs modified u2: FixedIP = %s", u.FixedIP)
+	}
+
+	without, _ := PlanFixedIPs(context.Background(), c, "default", desiredFixedIPs, FixedIPSyncOptions{})
+	if without.Count(FixedIPRemove) != 0 {
+		t.Error("removals planned without Prune")
+	}
+}
+
+func TestSyncFixedIPs(t *testing.T) {
+	server := newFixedIPServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	plan, failed, err := SyncFixedIPs(context.Background(), c, "default", append(desiredFixedIPs[:4:4], desiredFixedIPs[5]), FixedIPSyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncFixedIPs() error = %v", err)
+	}
+	if failed != 0 {
+		for _, ch := range plan.Changes {
+			if ch.Err != nil {
+				t.Errorf("%s: %v", ch.MAC, ch.Err)
+			}
+		}
+		t.Fatalf("failed = %d", failed)
+	}
+
+	if u := server.State().GetKnownClient("u2"); u.FixedIP != "192.168.1.21" {
+		t.Errorf("u2 FixedIP = %s, want 192.168.1.21", u.FixedIP)
+	}
+	if u := server.State().GetKnownClient("u3"); !u.UseFixedIP || u.FixedIP != "192.168.1.13" {
+		t.Errorf("u3 = %v/%s, want reservation 192.168.1.13", u.UseFixedIP, u.FixedIP)
+	}
+	if u := server.State().GetKnownClient("u7"); u.Name != "office-printer" {
+		t.Errorf("u7 Name = %s, want office-printer", u.Name)
+	}
+
+	again, _ := PlanFixedIPs(context.Background(), c, "default", append(desiredFixedIPs[:4:4], desiredFixedIPs[5]), FixedIPSyncOptions{})
+	if again.HasChanges() {
+		var lines []string
+		for _, ch := range again.Changes {
+			lines = append(lines, ch.String())
+		}
+		t.Errorf("plan after sync still has changes:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestPlanFixedIPs_InvalidEntries(t *testing.T) {
+	server := newFixedIPServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	desired := []FixedIP{
+		{IP: "192.168.1.10", MAC: "aa:bb:cc:dd:ee:01"},
+		{IP: "192.168.1.30", MAC: "AA-BB-CC-DD-EE-01"}, // same MAC, other notation
+		{IP: "192.168.1.40", MAC: "aa:bb:cc:dd:ee:05"},
+		{IP: "192.168.1.40", MAC: "aa:bb:cc:dd:ee:06"},
+		{IP: "192.168.1.41", MAC: "aa:bb:cc:dd:ee"},
+		{IP: "192.168.1.42", MAC: "not-a-mac"},
+		{IP: "192.168.1.14", MAC: "aa:bb:cc:dd:ee:09"}, // reserved for u4
+		{IP: "192.168.1.43", MAC: "aa:bb:cc:dd:ee:08"},
+	}
+
+	plan, err := PlanFixedIPs(context.Background(), c, "default", desired, FixedIPSyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("PlanFixedIPs() error = %v", err)
+	}
+
+	tests := []struct {
+		mac     string
+		action  FixedIPAction
+		wantErr string
+	}{
+		{"aa:bb:cc:dd:ee:01", FixedIPInvalid, "appears 2 times"},
+		{"aa:bb:cc:dd:ee:01", FixedIPInvalid, "appears 2 times"},
+		{"aa:bb:cc:dd:ee:05", FixedIPInvalid, "IP 192.168.1.40 appears 2 times"},
+		{"aa:bb:cc:dd:ee:06", FixedIPInvalid, "IP 192.168.1.40 appears 2 times"},
+		{"aa:bb:cc:dd:ee", FixedIPInvalid, "invalid MAC address"},
+		{"not-a-mac", FixedIPInvalid, "invalid MAC address"},
+		{"aa:bb:cc:dd:ee:09", FixedIPInvalid, "IP 192.168.1.14 is reserved for aa:bb:cc:dd:ee:04"},
+		{"aa:bb:cc:dd:ee:08", FixedIPCreate, ""},
+	}
+	if len(plan.Changes) < len(tests) {
+		t.Fatalf("got %d changes, want at least %d", len(plan.Changes), len(tests))
+	}
+	for i, tt := range tests {
+		ch := plan.Changes[i]
+		if ch.MAC != tt.mac || ch.Action != tt.action {
+			t.Errorf("change %d = %s %s, want %s %s", i, ch.Action, ch.MAC, tt.action, tt.mac)
+		}
+		if tt.wantErr == "" {
+			if ch.Err != nil {
+				t.Errorf("change %d Err = %v, want nil", i, ch.Err)
+			}
+		} else if ch.Err == nil || !strings.Contains(ch.Err.Error(), tt.wantErr) {
+			t.Errorf("change %d Err = %v, want containing %q", i, ch.Err, tt.wantErr)
+		}
+	}
+
+	// A duplicated MAC is still wanted, so its reservation is not pruned.
+	for _, ch := range plan.Changes[len(tests):] {
+		if ch.Action == FixedIPRemove && ch.MAC == "aa:bb:cc:dd:ee:01" {
+			t.Error("reservation of duplicated MAC planned for removal")
+		}
+	}
+
+	if failed := ApplyFixedIPs(context.Background(), c, plan); failed != 7 {
+		t.Errorf("ApplyFixedIPs() failed = %d, want 7", failed)
+	}
+	if u := server.State().GetKnownClient("u1"); u.FixedIP != "192.168.1.10" {
+		t.Errorf("u1 FixedIP = %s, want unchanged 192.168.1.10", u.FixedIP)
+	}
+}
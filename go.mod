@@ -1,5 +1,5 @@
 module github.com/unifi-go/gofi
 
-go 1.22
+go 1.24
 
 require github.com/gorilla/websocket v1.5.3
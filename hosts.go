@@ -0,0 +1,270 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// HostRecord is an address with the names that resolve to it and, for
+// DHCP reservations, the client MAC.
+type HostRecord struct {
+	IP string
+
+	// MAC is set for fixed IP reservations and empty for addresses that
+	// only come from local DNS records.
+	MAC string
+
+	// Names are hostnames for IP, first the reservation's name and then
+	// any DNS record names, without duplicates.
+	Names []string
+}
+
+// HostAlias is a CNAME from a local DNS record.
+type HostAlias struct {
+	Name   string
+	Target string
+}
+
+// HostInventory is the combined view of fixed IP reservations and local
+// DNS records, ready to be written for secondary DNS/DHCP servers.
+type HostInventory struct {
+	Hosts   []HostRecord
+	Aliases []HostAlias
+}
+
+// CollectHosts fetches a site's fixed IP reservations and local DNS
+// records and combines them with NewHostInventory.
+func CollectHosts(ctx context.Context, c Client, site string) (*HostInventory, error) {
+	users, err := c.Users().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	records, err := c.DNS().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	return NewHostInventory(users, records), nil
+}
+
+// NewHostInventory combines fixed IP reservations with enabled A, AAAA and
+// CNAME DNS records. A DNS name for a reserved address is added to that
+// reservation. Reservations are named from the client alias, falling back
+// to the reported hostname, converted to a valid DNS label. Hosts are
+// sorted by IP address.
+func NewHostInventory(users []types.User, records []types.DNSRecord) *HostInventory {
+	inv := &HostInventory{}
+	byIP := make(map[string]int)
+
+	for _, u := range users {
+		if !u.UseFixedIP || u.FixedIP == "" {
+			continue
+		}
+		h := HostRecord{IP: u.FixedIP, MAC: strings.ToLower(u.MAC)}
+		name := HostLabel(u.Name)
+		if name == "" {
+			name = HostLabel(u.Hostname)
+		}
+		if name != "" {
+			h.Names = []string{name}
+		}
+		byIP[h.IP] = len(inv.Hosts)
+		inv.Hosts = append(inv.Hosts, h)
+	}
+
+	for _, r := range records {
+		if !r.Enabled || r.Key == "" {
+			continue
+		}
+		name := strings.TrimSuffix(strings.ToLower(r.Key), ".")
+
+		switch r.RecordType {
+		case types.DNSRecordTypeA, types.DNSRecordTypeAAAA, "":
+			if net.ParseIP(r.Value) == nil {
+				continue
+			}
+			i, ok := byIP[r.Value]
+			if !ok {
+				i = len(inv.Hosts)
+				byIP[r.Value] = i
+				inv.Hosts = append(inv.Hosts, HostRecord{IP: r.Value})
+			}
+			inv.Hosts[i].Names = appendUnique(inv.Hosts[i].Names, name)
+		case types.DNSRecordTypeCNAME:
+			inv.Aliases = append(inv.Aliases, HostAlias{Name: name, Target: strings.TrimSuffix(strings.ToLower(r.Value), ".")})
+		}
+	}
+
+	sort.SliceStable(inv.Hosts, func(i, j int) bool {
+		return compareIPs(inv.Hosts[i].IP, inv.Hosts[j].IP) < 0
+	})
+	sort.SliceStable(inv.Aliases, func(i, j int) bool { return inv.Aliases[i].Name < inv.Aliases[j].Name })
+	return inv
+}
+
+// HostLabel converts a client name such as "Living Room TV" into a DNS
+// label ("living-room-tv"). It returns "" if nothing usable remains.
+func HostLabel(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			dash = false
+		case r == '.' && b.Len() > 0:
+			// Keep dotted names such as "nas.home" intact.
+			b.WriteRune(r)
+			dash = true
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	label := strings.Trim(b.String(), "-.")
+	if len(label) > 63 && !strings.Contains(label, ".") {
+		label = strings.TrimRight(label[:63], "-")
+	}
+	return label
+}
+
+// WriteDnsmasq writes dnsmasq configuration: a dhcp-host line per
+// reservation, a host-record line per name and a cname line per alias.
+// If domain is set, unqualified names also get a fully qualified form.
+func (inv *HostInventory) WriteDnsmasq(w io.Writer, domain string) error {
+	var b strings.Builder
+	b.WriteString("# Generated by gofi from UniFi fixed IP reservations and local DNS records\n")
+
+	for _, h := range inv.Hosts {
+		if h.MAC == "" {
+			continue
+		}
+		if len(h.Names) > 0 && !strings.Contains(h.Names[0], ".") {
+			fmt.Fprintf(&b, "dhcp-host=%s,%s,%s\n", h.MAC, h.IP, h.Names[0])
+		} else {
+			fmt.Fprintf(&b, "dhcp-host=%s,%s\n", h.MAC, h.IP)
+		}
+	}
+
+	for _, h := range inv.Hosts {
+		if names := qualifyNames(h.Names, domain); len(names) > 0 {
+			fmt.Fprintf(&b, "host-record=%s,%s\n", strings.Join(names, ","), h.IP)
+		}
+	}
+
+	for _, a := range inv.Aliases {
+		fmt.Fprintf(&b, "cname=%s,%s\n", a.Name, a.Target)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHosts writes an /etc/hosts style file. Aliases are resolved to the
+// address of their target; aliases whose target is not in the inventory
+// are listed as comments since hosts files cannot express them.
+func (inv *HostInventory) WriteHosts(w io.Writer, domain string) error {
+	var b strings.Builder
+	b.WriteString("# Generated by gofi from UniFi fixed IP reservations and local DNS records\n")
+
+	names := make(map[string]string)
+	lines := make([][]string, len(inv.Hosts))
+	for i, h := range inv.Hosts {
+		lines[i] = qualifyNames(h.Names, domain)
+		for _, n := range h.Names {
+			names[n] = h.IP
+		}
+	}
+
+	var unresolved []HostAlias
+	byIP := make(map[string]int, len(inv.Hosts))
+	for i, h := range inv.Hosts {
+		byIP[h.IP] = i
+	}
+	for _, a := range inv.Aliases {
+		ip, ok := names[a.Target]
+		if !ok {
+			unresolved = append(unresolved, a)
+			continue
+		}
+		i := byIP[ip]
+		lines[i] = appendUnique(lines[i], qualifyNames([]string{a.Name}, domain)...)
+	}
+
+	for i, h := range inv.Hosts {
+		if len(lines[i]) > 0 {
+			fmt.Fprintf(&b, "%s\t%s\n", h.IP, strings.Join(lines[i], " "))
+		}
+	}
+	for _, a := range unresolved {
+		fmt.Fprintf(&b, "# %s is an alias for %s, which is not a local name\n", a.Name, a.Target)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// qualifyNames returns names with a domain-qualified form placed before
+// each unqualified name, the conventional hosts file order.
+func qualifyNames(names []string, domain string) []string {
+	domain = strings.Trim(domain, ".")
+	var out []string
+	for _, n := range names {
+		if domain != "" && !strings.Contains(n, ".") {
+			out = appendUnique(out, n+"."+domain)
+		}
+		out = appendUnique(out, n)
+	}
+	return out
+}
+
+// appendUnique appends the values not already in s.
+func appendUnique(s []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range s {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// compareIPs orders IP addresses numerically, IPv4 before IPv6, falling
+// back to string order for unparsable values.
+func compareIPs(a, b string) int {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return strings.Compare(a, b)
+	}
+	v4A, v4B := ipA.To4(), ipB.To4()
+	switch {
+	case v4A != nil && v4B == nil:
+		return -1
+	case v4A == nil && v4B != nil:
+		return 1
+	case v4A != nil:
+		ipA, ipB = v4A, v4B
+	}
+	for i := range ipA {
+		if ipA[i] != ipB[i] {
+			if ipA[i] < ipB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package gofi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func hostsFixture() *HostInventory {
+	users := []types.User{
+		{MAC: "AA:BB:CC:DD:EE:02", Name: "Living Room TV", UseFixedIP: true, FixedIP: "192.168.1.20"},
+		{MAC: "aa:bb:cc:dd:ee:01", Hostname: "nas", UseFixedIP: true, FixedIP: "192.168.1.3"},
+		{MAC: "aa:bb:cc:dd:ee:03", UseFixedIP: true, FixedIP: "192.168.1.4"},
+		{MAC: "aa:bb:cc:dd:ee:04", Name: "roaming"},
+	}
+	records := []types.DNSRecord{
+		{Key: "files", Value: "192.168.1.3", RecordType: "A", Enabled: true},
+		{Key: "printer.home", Value: "192.168.1.9", RecordType: "A", Enabled: true},
+		{Key: "old", Value: "192.168.1.50", RecordType: "A", Enabled: false},
+		{Key: "media", Value: "living-room-tv", RecordType: "CNAME", Enabled: true},
+		{Key: "www", Value: "example.com", RecordType: "CNAME", Enabled: true},
+	}
+	return NewHostInventory(users, records)
+}
+
+func TestNewHostInventory(t *testing.T) {
+	inv := hostsFixture()
+
+	want := []HostRecord{
+		{IP: "192.168.1.3", MAC: "aa:bb:cc:dd:ee:01", Names: []string{"nas", "files"}},
+		{IP: "192.168.1.4", MAC: "aa:bb:cc:dd:ee:03"},
+		{IP: "192.168.1.9", Names: []string{"printer.home"}},
+		{IP: "192.168.1.20", MAC: "aa:bb:cc:dd:ee:02", Names: []string{"living-room-tv"}},
+	}
+	if len(inv.Hosts) != len(want) {
+		t.Fatalf("Hosts = %+v, want %d hosts", inv.Hosts, len(want))
+	}
+	for i, h := range inv.Hosts {
+		if h.IP != want[i].IP || h.MAC != want[i].MAC || len(h.Names) != len(want[i].Names) {
+			t.Errorf("Hosts[%d] = %+v, want %+v", i, h, want[i])
+			continue
+		}
+		for j := range h.Names {
+			if h.Names[j] != want[i].Names[j] {
+				t.Errorf("Hosts[%d].Names = %v, want %v", i, h.Names, want[i].Names)
+			}
+		}
+	}
+	if len(inv.Aliases) != 2 {
+		t.Errorf("Aliases = %+v, want 2", inv.Aliases)
+	}
+}
+
+func TestHostInventory_WriteDnsmasq(t *testing.T) {
+	var buf bytes.Buffer
+	if err := hostsFixture().WriteDnsmasq(&buf, "lan"); err != nil {
+		t.Fatalf("WriteDnsmasq() error = %v", err)
+	}
+
+	want := `# Generated by gofi from UniFi fixed IP reservations and local DNS records
+dhcp-host=aa:bb:cc:dd:ee:01,192.168.1.3,nas
+dhcp-host=aa:bb:cc:dd:ee:03,192.168.1.4
+dhcp-host=aa:bb:cc:dd:ee:02,192.168.1.20,living-room-tv
+host-record=nas.lan,nas,files.lan,files,192.168.1.3
+host-record=printer.home,192.168.1.9
+host-record=living-room-tv.lan,living-room-tv,192.168.1.20
+cname=media,living-room-tv
+cname=www,example.com
+`
+	if buf.String() != want {
+		t.Errorf("WriteDnsmasq() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestHostInventory_WriteHosts(t *testing.T) {
+	var buf bytes.Buffer
+	if err := hostsFixture().WriteHosts(&buf, ""); err != nil {
+		t.Fatalf("WriteHosts() error = %v", err)
+	}
+
+	want := `# Generated by gofi from UniFi fixed IP reservations and local DNS records
+192.168.1.3	nas files
+192.168.1.9	printer.home
+192.168.1.20	living-room-tv media
+# www is an alias for example.com, which is not a local name
+`
+	if buf.String() != want {
+		t.Errorf("WriteHosts() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestHostLabel(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Living Room TV", "living-room-tv"},
+		{"  NAS  ", "nas"},
+		{"Bob's iPhone", "bob-s-iphone"},
+		{"nas.home", "nas.home"},
+		{"---", ""},
+	}
+	for _, tt := range tests {
+		if got := HostLabel(tt.in); got != tt.want {
+			t.Errorf("HostLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
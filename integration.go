@@ -0,0 +1,236 @@
+package gofi
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
+)
+
+// integrationRouter decides whether summary lists are read from the
+// official Integrations API. It probes the API on first use after Connect
+// and prefers it only if the probe succeeds; a controller that lacks the
+// API, or refuses the session cookie, keeps using the legacy API. It is
+// shared by a client and its clones.
+//
+// With Config.UseIntegrationAPI the router is forced: it uses the API
+// without a probe, never falls back to the legacy API, and also serves
+// full device lists, device lookups and restarts, and client lists.
+type integrationRouter struct {
+	service services.IntegrationService
+	logger  Logger
+	forced  bool
+
+	mu     sync.Mutex
+	probed bool
+	usable bool
+}
+
+func newIntegrationRouter(service services.IntegrationService, logger Logger, forced bool) *integrationRouter {
+	return &integrationRouter{service: service, logger: logger, forced: forced}
+}
+
+// available reports whether the Integrations API answered the probe. A
+// forced router does not probe.
+func (r *integrationRouter) available(ctx context.Context) bool {
+	if r.forced {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.probed {
+		_, err := r.service.Info(ctx)
+		if err != nil && ctx.Err() != nil {
+			return false // Probe again next time
+		}
+		r.probed = true
+		r.usable = err == nil
+		if r.logger != nil {
+			r.logger.Debug("Detected Integrations API", "available", r.usable)
+		}
+	}
+	return r.usable
+}
+
+// reset forgets the probe result, so that the next call probes again.
+func (r *integrationRouter) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probed = false
+	r.usable = false
+}
+
+// assume records the result of a probe made by other means.
+func (r *integrationRouter) assume(usable bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probed = true
+	r.usable = usable
+}
+
+// isForced reports whether the router is forced. Clients built without New
+// have no router.
+func (r *integrationRouter) isForced() bool {
+	return r != nil && r.forced
+}
+
+// fallback reports whether a failed Integrations API call is retried on
+// the legacy API, logging it if so. A forced router never falls back.
+func (r *integrationRouter) fallback(call string, err error) bool {
+	if r.forced {
+		return false
+	}
+	if r.logger != nil {
+		r.logger.Debug("Integrations API failed, using legacy API", "call", call, "error", err)
+	}
+	return true
+}
+
+// integrationSites is a SiteService that lists sites from the Integrations
+// API when it is available.
+type integrationSites struct {
+	services.SiteService
+	router *integrationRouter
+}
+
+func (s *integrationSites) List(ctx context.Context) ([]types.Site, error) {
+	if s.router.available(ctx) {
+		sites, err := s.router.service.ListSites(ctx)
+		if err == nil {
+			converted := make([]types.Site, len(sites))
+			for i := range sites {
+				converted[i] = sites[i].Site()
+			}
+			return converted, nil
+		}
+		if !s.router.fallback("Sites().List", err) {
+			return nil, err
+		}
+	}
+	return s.SiteService.List(ctx)
+}
+
+// integrationDevices is a DeviceService that lists device summaries from
+// the Integrations API when it is available. Full device records and
+// field selection use the legacy API, which has far more detail, unless
+// the router is forced.
+type integrationDevices struct {
+	services.DeviceService
+	router *integrationRouter
+}
+
+func (d *integrationDevices) ListBasic(ctx context.Context, site string, opts ...services.DeviceListOption) ([]types.DeviceBasic, error) {
+	if len(opts) == 0 && d.router.available(ctx) {
+		devices, err := d.router.service.ListDevices(ctx, site)
+		if err == nil {
+			converted := make([]types.DeviceBasic, len(devices))
+			for i := range devices {
+				converted[i] = devices[i].DeviceBasic()
+			}
+			return converted, nil
+		}
+		if !d.router.fallback("Devices().ListBasic", err) {
+			return nil, err
+		}
+	}
+	return d.DeviceService.ListBasic(ctx, site, opts...)
+}
+
+func (d *integrationDevices) List(ctx context.Context, site string, opts ...services.DeviceListOption) ([]types.Device, error) {
+	if len(opts) > 0 || !d.router.isForced() {
+		return d.DeviceService.List(ctx, site, opts...)
+	}
+
+	devices, err := d.router.service.ListDevices(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]types.Device, len(devices))
+	for i := range devices {
+		converted[i] = devices[i].Device()
+	}
+	return converted, nil
+}
+
+func (d *integrationDevices) GetByMAC(ctx context.Context, site, mac string) (*types.Device, error) {
+	if !d.router.isForced() {
+		return d.DeviceService.GetByMAC(ctx, site, mac)
+	}
+
+	device, err := d.find(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	converted := device.Device()
+	return &converted, nil
+}
+
+func (d *integrationDevices) Restart(ctx context.Context, site, mac string) error {
+	if !d.router.isForced() {
+		return d.DeviceService.Restart(ctx, site, mac)
+	}
+
+	device, err := d.find(ctx, site, mac)
+	if err != nil {
+		return err
+	}
+	return d.router.service.RestartDevice(ctx, site, device.ID)
+}
+
+// find returns the Integrations API device with a MAC address.
+func (d *integrationDevices) find(ctx context.Context, site, mac string) (*types.IntegrationDevice, error) {
+	devices, err := d.router.service.ListDevices(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range devices {
+		if strings.EqualFold(devices[i].MACAddress, mac) {
+			return &devices[i], nil
+		}
+	}
+	return nil, notFoundf("device not found with MAC %s", mac)
+}
+
+// integrationClients is a ClientService that lists connected clients from
+// the Integrations API when the router is forced.
+type integrationClients struct {
+	services.ClientService
+	router *integrationRouter
+}
+
+func (c *integrationClients) ListActive(ctx context.Context, site string) ([]types.Client, error) {
+	if !c.router.isForced() {
+		return c.ClientService.ListActive(ctx, site)
+	}
+
+	clients, err := c.router.service.ListClients(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]types.Client, len(clients))
+	for i := range clients {
+		converted[i] = clients[i].Client()
+	}
+	return converted, nil
+}
+
+func (c *integrationClients) Get(ctx context.Context, site, mac string) (*types.Client, error) {
+	if !c.router.isForced() {
+		return c.ClientService.Get(ctx, site, mac)
+	}
+
+	clients, err := c.ListActive(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clients {
+		if strings.EqualFold(clients[i].MAC, mac) {
+			return &clients[i], nil
+		}
+	}
+	return nil, notFoundf("client not found: %s", mac)
+}
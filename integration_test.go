@@ -0,0 +1,240 @@
+package gofi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+// pathRecorder records the path of every request the server receives.
+type pathRecorder struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (p *pathRecorder) Apply(w http.ResponseWriter, r *http.Request) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paths = append(p.paths, r.URL.Path)
+	return false
+}
+
+// count returns the number of requests to paths containing path.
+func (p *pathRecorder) count(path string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, got := range p.paths {
+		if strings.Contains(got, path) {
+			n++
+		}
+	}
+	return n
+}
+
+func newIntegrationTestClient(t *testing.T, opts ...mock.Option) (Client, *mock.Server) {
+	t.Helper()
+	server := mock.NewServer(opts...)
+	t.Cleanup(server.Close)
+	server.State().AddDevice(&types.Device{
+		ID:    "device1",
+		MAC:   "aa:bb:cc:dd:ee:01",
+		Type:  "uap",
+		Model: "U6-LR",
+		Name:  "Office AP",
+		State: types.DeviceStateConnected,
+	})
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c, server
+}
+
+func TestClient_IntegrationPreferred(t *testing.T) {
+	rec := &pathRecorder{}
+	c, _ := newIntegrationTestClient(t, mock.WithIntegrationAPI(), mock.WithScenario(rec))
+	ctx := context.Background()
+
+	devices, err := c.Devices().ListBasic(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListBasic() error = %v", err)
+	}
+	want := types.DeviceBasic{MAC: "aa:bb:cc:dd:ee:01", Type: "uap", Model: "U6-LR", Name: "Office AP", State: types.DeviceStateConnected}
+	if len(devices) != 1 || devices[0] != want {
+		t.Errorf("ListBasic() = %+v, want [%+v]", devices, want)
+	}
+
+	sites, err := c.Sites().List(ctx)
+	if err != nil {
+		t.Fatalf("Sites().List() error = %v", err)
+	}
+	if len(sites) != 1 || sites[0].Name != "default" {
+		t.Errorf("Sites().List() = %+v", sites)
+	}
+
+	if n := rec.count("/basicstat/device"); n != 0 {
+		t.Errorf("legacy device list requests = %d, want 0", n)
+	}
+	if n := rec.count("/self/sites"); n != 0 {
+		t.Errorf("legacy site list requests = %d, want 0", n)
+	}
+	if n := rec.count("/integration/v1/info"); n != 1 {
+		t.Errorf("probes = %d, want 1", n)
+	}
+
+	// Full device records still come from the legacy API
+	if _, err := c.Devices().List(ctx, "default"); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if n := rec.count("/stat/device"); n != 1 {
+		t.Errorf("legacy full device list requests = %d, want 1", n)
+	}
+
+	// A clone shares the probe result
+	if _, err := c.ForSite("default").Devices().ListBasic(ctx, "default"); err != nil {
+		t.Fatalf("ListBasic() on clone error = %v", err)
+	}
+	if n := rec.count("/integration/v1/info"); n != 1 {
+		t.Errorf("probes after clone = %d, want 1", n)
+	}
+
+	if _, err := c.Integration().Info(ctx); err != nil {
+		t.Errorf("Integration().Info() error = %v", err)
+	}
+}
+
+func TestClient_IntegrationUnavailable(t *testing.T) {
+	rec := &pathRecorder{}
+	c, _ := newIntegrationTestClient(t, mock.WithScenario(rec))
+	ctx := context.Background()
+
+	devices, err := c.Devices().ListBasic(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListBasic() error = %v", err)
+	}
+	if len(devices) != 1 || devices[0].MAC != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("ListBasic() = %+v", devices)
+	}
+	if _, err := c.Devices().ListBasic(ctx, "default"); err != nil {
+		t.Fatalf("ListBasic() error = %v", err)
+	}
+
+	if n := rec.count("/basicstat/device"); n != 2 {
+		t.Errorf("legacy device list requests = %d, want 2", n)
+	}
+	if n := rec.count("/integration/v1/"); n != 1 {
+		t.Errorf("Integrations API requests = %d, want only the probe", n)
+	}
+}
+
+func TestClient_IntegrationFallback(t *testing.T) {
+	rec := &pathRecorder{}
+	failing := &mock.ErrorScenario{
+		Path:       "/proxy/network/integration/v1/sites/default/devices",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "internal error",
+	}
+	c, _ := newIntegrationTestClient(t, mock.WithIntegrationAPI(), mock.WithScenario(scenarios{rec, failing}))
+
+	devices, err := c.Devices().ListBasic(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListBasic() error = %v", err)
+	}
+	if len(devices) != 1 || devices[0].MAC != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("ListBasic() = %+v", devices)
+	}
+	if n := rec.count("/basicstat/device"); n != 1 {
+		t.Errorf("legacy device list requests = %d, want 1 after the failure", n)
+	}
+}
+
+func TestClient_IntegrationForced(t *testing.T) {
+	rec := &pathRecorder{}
+	server := mock.NewServer(mock.WithIntegrationAPI(), mock.WithScenario(rec))
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "device1", MAC: "aa:bb:cc:dd:ee:01", Type: "uap", Name: "Office AP", State: types.DeviceStateConnected})
+	server.State().AddClient(&types.Client{ID: "client1", MAC: "11:22:33:44:55:66", Name: "laptop", LastSeen: types.NewFlexTime(time.Now().Unix())})
+
+	c := connectMock(t, server, WithIntegrationAPI())
+	ctx := context.Background()
+
+	devices, err := c.Devices().List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(devices) != 1 || devices[0].Name != "Office AP" || devices[0].State != types.DeviceStateConnected {
+		t.Errorf("List() = %+v", devices)
+	}
+	if _, err := c.Devices().GetByMAC(ctx, "default", "AA:BB:CC:DD:EE:01"); err != nil {
+		t.Errorf("GetByMAC() error = %v", err)
+	}
+	if err := c.Devices().Restart(ctx, "default", "aa:bb:cc:dd:ee:01"); err != nil {
+		t.Errorf("Restart() error = %v", err)
+	}
+	if _, err := c.Devices().GetByMAC(ctx, "default", "aa:bb:cc:dd:ee:99"); !IsNotFound(err) {
+		t.Errorf("GetByMAC() of an unknown device error = %v, want not found", err)
+	}
+	if _, err := c.Clients().Get(ctx, "default", "11:22:33:44:55:99"); !IsNotFound(err) {
+		t.Errorf("Get() of an unknown client error = %v, want not found", err)
+	}
+	clients, err := c.Clients().ListActive(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListActive() error = %v", err)
+	}
+	if len(clients) != 1 || clients[0].Name != "laptop" {
+		t.Errorf("ListActive() = %+v", clients)
+	}
+
+	for _, legacy := range []string{"/stat/device", "/cmd/devmgr", "/stat/sta", "/integration/v1/info"} {
+		if n := rec.count(legacy); n != 0 {
+			t.Errorf("%s requests = %d, want 0", legacy, n)
+		}
+	}
+	if n := rec.count("/devices/device1/actions"); n != 1 {
+		t.Errorf("restart actions = %d, want 1", n)
+	}
+}
+
+func TestClient_IntegrationForced_NoFallback(t *testing.T) {
+	rec := &pathRecorder{}
+	server := mock.NewServer(mock.WithScenario(rec))
+	defer server.Close()
+
+	c := connectMock(t, server, WithIntegrationAPI())
+	if _, err := c.Devices().ListBasic(context.Background(), "default"); err == nil {
+		t.Error("ListBasic() without the Integrations API succeeded")
+	}
+	if n := rec.count("/basicstat/device"); n != 0 {
+		t.Errorf("legacy device list requests = %d, want 0", n)
+	}
+}
+
+// scenarios applies each scenario in turn until one handles the request.
+type scenarios []mock.Scenario
+
+func (s scenarios) Apply(w http.ResponseWriter, r *http.Request) bool {
+	for _, scenario := range s {
+		if scenario.Apply(w, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Package eventsub holds the event stream plumbing shared by the event
+// consumers (webhook, eventstore): key matching, subscribing and logging.
+//
+// This package is not part of the public API and may change without notice.
+package eventsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/types"
+)
+
+// MatchKey reports whether patterns select an event key such as
+// "EVT_WU_Connected". A trailing "*" matches a prefix ("EVT_AP_*"). No
+// patterns select every key.
+func MatchKey(patterns []string, key string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if pattern == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Time returns when an event happened in UTC, or the current time if the
+// controller did not say.
+func Time(ev types.Event) time.Time {
+	if ev.Time.IsZero() {
+		return time.Now().UTC()
+	}
+	return ev.Timestamp().UTC()
+}
+
+// Subscribe subscribes to the site's event stream and calls run with its
+// events. Stream errors are logged. The subscription ends when run returns;
+// the client's event service is shared and stays open.
+func Subscribe(ctx context.Context, c gofi.Client, site string, logger gofi.Logger, run func(context.Context, <-chan types.Event) error) error {
+	svc := c.Events()
+	if svc == nil {
+		return errors.New("event streaming is not available on this client")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, errs, err := svc.Subscribe(ctx, site)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	go func() {
+		for err := range errs {
+			Warn(logger, "Event stream error", "error", err)
+		}
+	}()
+
+	return run(ctx, events)
+}
+
+// Warn writes a warning if logger is not nil.
+func Warn(logger gofi.Logger, msg string, keysAndValues ...interface{}) {
+	if logger != nil {
+		logger.Warn(msg, keysAndValues...)
+	}
+}
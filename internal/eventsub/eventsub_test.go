@@ -0,0 +1,45 @@
+package eventsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestMatchKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		key      string
+		want     bool
+	}{
+		{"no patterns", nil, "EVT_WU_Connected", true},
+		{"exact", []string{"EVT_WU_Connected"}, "EVT_WU_Connected", true},
+		{"exact mismatch", []string{"EVT_WU_Connected"}, "EVT_WU_Disconnected", false},
+		{"prefix", []string{"EVT_AP_*"}, "EVT_AP_Lost_Contact", true},
+		{"prefix mismatch", []string{"EVT_AP_*"}, "EVT_SW_Lost_Contact", false},
+		{"any of several", []string{"EVT_SW_*", "EVT_WU_Connected"}, "EVT_WU_Connected", true},
+		{"star alone", []string{"*"}, "EVT_LU_Connected", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchKey(tt.patterns, tt.key); got != tt.want {
+				t.Errorf("MatchKey(%v, %q) = %v, want %v", tt.patterns, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTime(t *testing.T) {
+	ev := types.Event{Time: types.NewFlexTime(1642567890000)}
+	if got := Time(ev); !got.Equal(time.UnixMilli(1642567890000)) || got.Location() != time.UTC {
+		t.Errorf("Time() = %v, want the event time in UTC", got)
+	}
+
+	before := time.Now()
+	if got := Time(types.Event{}); got.Before(before) {
+		t.Errorf("Time() of an event without a time = %v, want now", got)
+	}
+}
@@ -16,7 +16,7 @@ func ParseAPIResponse[T any](data []byte) (*types.APIResponse[T], error) {
 
 	// Check if the response indicates an error
 	if resp.Meta.RC != "ok" && resp.Meta.RC != "" {
-		return nil, fmt.Errorf("API error: %s (rc=%s)", resp.Meta.Message, resp.Meta.RC)
+		return nil, types.NewResponseError("", 0, data)
 	}
 
 	return &resp, nil
@@ -14,6 +14,9 @@ const (
 
 	// APIv2Base is the base path for v2 API endpoints
 	APIv2Base = "/v2/api"
+
+	// IntegrationBase is the base path of the official Integrations API
+	IntegrationBase = "/integration/v1"
 )
 
 // BuildAPIPath builds a v1 API path for a given site and endpoint.
@@ -32,6 +35,12 @@ func BuildV2APIPath(_, endpoint string) string {
 	return path.Join(BasePath, APIv2Base, endpoint)
 }
 
+// BuildIntegrationPath builds an Integrations API path from its segments.
+// Example: BuildIntegrationPath("sites", id, "devices") -> "/proxy/network/integration/v1/sites/{id}/devices"
+func BuildIntegrationPath(segments ...string) string {
+	return path.Join(append([]string{BasePath, IntegrationBase}, segments...)...)
+}
+
 // BuildRESTPath builds a REST API path for a given site, resource, and optional ID.
 // Example: BuildRESTPath("default", "networkconf", "abc123") -> "/proxy/network/api/s/default/rest/networkconf/abc123"
 func BuildRESTPath(site, resource, id string) string {
@@ -83,3 +92,9 @@ func BuildWebSocketPath(site string) string {
 	}
 	return path.Join(BasePath, "wss", "s", site, "events")
 }
+
+// BuildSystemWebSocketPath builds the WebSocket path of the UniFi OS event bus.
+// Example: BuildSystemWebSocketPath() -> "/api/ws/system"
+func BuildSystemWebSocketPath() string {
+	return BuildSystemPath("ws/system")
+}
@@ -47,6 +47,22 @@ func TestBuildV2APIPath(t *testing.T) {
 	}
 }
 
+func TestBuildIntegrationPath(t *testing.T) {
+	tests := []struct {
+		segments []string
+		want     string
+	}{
+		{[]string{"info"}, "/proxy/network/integration/v1/info"},
+		{[]string{"sites", "88f7af54", "devices"}, "/proxy/network/integration/v1/sites/88f7af54/devices"},
+	}
+
+	for _, tt := range tests {
+		if got := BuildIntegrationPath(tt.segments...); got != tt.want {
+			t.Errorf("BuildIntegrationPath(%q) = %q, want %q", tt.segments, got, tt.want)
+		}
+	}
+}
+
 func TestBuildRESTPath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -153,3 +169,9 @@ func TestBuildWebSocketPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSystemWebSocketPath(t *testing.T) {
+	if got := BuildSystemWebSocketPath(); got != "/api/ws/system" {
+		t.Errorf("BuildSystemWebSocketPath() = %q, want %q", got, "/api/ws/system")
+	}
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two dotted version strings numerically.
+// It returns -1 if a < b, 0 if a == b and 1 if a > b. Missing components
+// count as zero and any non-numeric suffix (e.g. "-beta") is ignored.
+// Example: CompareVersions("8.0.26", "7.5.174") -> 1
+func CompareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version string into its numeric components.
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
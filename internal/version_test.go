@@ -0,0 +1,29 @@
+package internal
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"equal", "7.5.174", "7.5.174", 0},
+		{"major greater", "8.0.26", "7.5.174", 1},
+		{"minor less", "7.4.162", "7.5.0", -1},
+		{"numeric not lexical", "7.10.0", "7.9.0", 1},
+		{"missing components", "9.0", "9.0.0", 0},
+		{"prefix and suffix", "v9.0.108-beta", "9.0.108", 0},
+		{"empty", "", "1.0", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareVersions(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package gofi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// AnsibleHost holds the variables of one inventory host. They are exposed
+// to playbooks as hostvars.
+type AnsibleHost struct {
+	Host    string           `json:"ansible_host"`
+	MAC     string           `json:"unifi_mac"`
+	Name    string           `json:"unifi_name,omitempty"`
+	Kind    TopologyNodeKind `json:"unifi_type"`
+	Model   string           `json:"unifi_model,omitempty"`
+	Network string           `json:"unifi_network,omitempty"`
+	VLAN    int              `json:"unifi_vlan,omitempty"`
+	FixedIP bool             `json:"unifi_fixed_ip,omitempty"`
+	Tags    []string         `json:"unifi_tags,omitempty"`
+}
+
+// AnsibleInventory is an Ansible dynamic inventory. Hosts are keyed by
+// inventory hostname. Groups map group names to sorted host names:
+//
+//   - unifi_devices and unifi_clients
+//   - type_gateway, type_switch, type_ap, type_device
+//   - network_<name> for the network a host's address belongs to
+//   - vlan_<id> for hosts on a VLAN network
+//   - tag_<tag> for each "#tag" in a client's note
+type AnsibleInventory struct {
+	Hosts  map[string]*AnsibleHost
+	Groups map[string][]string
+}
+
+// AnsibleInventoryOptions controls BuildAnsibleInventory.
+type AnsibleInventoryOptions struct {
+	// SkipDevices leaves out UniFi devices.
+	SkipDevices bool
+
+	// SkipClients leaves out clients.
+	SkipClients bool
+
+	// AllClients includes every client with an address. By default only
+	// clients with an alias or a fixed IP are included, since the host
+	// names of the others change with whatever they report over DHCP.
+	AllClients bool
+}
+
+// BuildAnsibleInventory fetches a site's devices, clients and networks and
+// builds an inventory with NewAnsibleInventory.
+func BuildAnsibleInventory(ctx context.Context, c Client, site string, opts AnsibleInventoryOptions) (*AnsibleInventory, error) {
+	networks, err := c.Networks().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var devices []types.Device
+	if !opts.SkipDevices {
+		devices, err = c.Devices().List(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list devices: %w", err)
+		}
+	}
+
+	var users []types.User
+	var clients []types.Client
+	if !opts.SkipClients {
+		users, err = c.Users().List(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		clients, err = c.Clients().ListActive(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clients: %w", err)
+		}
+	}
+
+	return NewAnsibleInventory(devices, users, clients, networks, opts), nil
+}
+
+// NewAnsibleInventory builds an inventory from devices, known clients
+// (users) and active clients. A client's address is its fixed IP if it has
+// one, otherwise its current IP; hosts without an address are skipped.
+// Names come from the alias, falling back to the reported hostname, and are
+// made unique by appending the end of the MAC address.
+func NewAnsibleInventory(devices []types.Device, users []types.User, clients []types.Client, networks []types.Network, opts AnsibleInventoryOptions) *AnsibleInventory {
+	inv := &AnsibleInventory{
+		Hosts:  make(map[string]*AnsibleHost),
+		Groups: make(map[string][]string),
+	}
+	byID := make(map[string]*types.Network, len(networks))
+	for i := range networks {
+		byID[networks[i].ID] = &networks[i]
+	}
+
+	if !opts.SkipDevices {
+		for i := range devices {
+			d := &devices[i]
+			if d.IP == "" {
+				continue
+			}
+			h := &AnsibleHost{
+				Host:  d.IP,
+				MAC:   strings.ToLower(d.MAC),
+				Name:  d.Name,
+				Kind:  d.TopologyKind(),
+				Model: d.ModelName(),
+			}
+			inv.add(HostLabel(d.Name), h, networkForAddress(networks, d.IP), "unifi_devices")
+		}
+	}
+
+	if !opts.SkipClients {
+		for _, cl := range mergeClients(users, clients) {
+			if cl.ip == "" || (!opts.AllClients && cl.name == "" && !cl.fixed) {
+				continue
+			}
+			h := &AnsibleHost{
+				Host:    cl.ip,
+				MAC:     cl.mac,
+				Name:    cl.name,
+				Kind:    TopologyClient,
+				FixedIP: cl.fixed,
+				Tags:    NoteTags(cl.note),
+			}
+			n := byID[cl.networkID]
+			if n == nil {
+				n = networkForAddress(networks, cl.ip)
+			}
+			label := HostLabel(cl.name)
+			if label == "" {
+				label = HostLabel(cl.hostname)
+			}
+			inv.add(label, h, n, "unifi_clients")
+		}
+	}
+
+	for g := range inv.Groups {
+		sort.Strings(inv.Groups[g])
+	}
+	return inv
+}
+
+// add registers a host under a unique name and adds it to its groups.
+func (inv *AnsibleInventory) add(name string, h *AnsibleHost, n *types.Network, group string) {
+	if name == "" {
+		name = strings.ReplaceAll(h.MAC, ":", "")
+	}
+	if _, taken := inv.Hosts[name]; taken {
+		name += "-" + strings.ReplaceAll(h.MAC[max(0, len(h.MAC)-8):], ":", "")
+	}
+	inv.Hosts[name] = h
+
+	groups := []string{group, "type_" + string(h.Kind)}
+	if n != nil {
+		h.Network = n.Name
+		if g := ansibleGroupName(n.Name); g != "" {
+			groups = append(groups, "network_"+g)
+		}
+		if n.VLANEnabled && n.VLAN > 0 {
+			h.VLAN = n.VLAN
+			groups = append(groups, "vlan_"+strconv.Itoa(n.VLAN))
+		}
+	}
+	for _, tag := range h.Tags {
+		if g := ansibleGroupName(tag); g != "" {
+			groups = append(groups, "tag_"+g)
+		}
+	}
+	for _, g := range groups {
+		inv.Groups[g] = append(inv.Groups[g], name)
+	}
+}
+
+// MarshalJSON encodes the inventory in the format Ansible expects from
+// "--list": one object per group plus "_meta.hostvars".
+func (inv *AnsibleInventory) MarshalJSON() ([]byte, error) {
+	type group struct {
+		Hosts    []string `json:"hosts,omitempty"`
+		Children []string `json:"children,omitempty"`
+	}
+
+	out := make(map[string]interface{}, len(inv.Groups)+2)
+	children := make([]string, 0, len(inv.Groups))
+	for name, hosts := range inv.Groups {
+		out[name] = group{Hosts: hosts}
+		children = append(children, name)
+	}
+	sort.Strings(children)
+	out["all"] = group{Children: children}
+
+	hostvars := inv.Hosts
+	if hostvars == nil {
+		hostvars = map[string]*AnsibleHost{}
+	}
+	out["_meta"] = map[string]interface{}{"hostvars": hostvars}
+	return json.Marshal(out)
+}
+
+// clientEntry is a known or active client merged by MAC address.
+type clientEntry struct {
+	mac, name, hostname, ip, networkID, note string
+	fixed                                    bool
+}
+
+// mergeClients combines known clients with active ones, preferring the
+// stored alias, note and fixed IP over live data.
+func mergeClients(users []types.User, clients []types.Client) []*clientEntry {
+	byMAC := make(map[string]*clientEntry)
+	var order []*clientEntry
+	get := func(mac string) *clientEntry {
+		mac = strings.ToLower(mac)
+		e := byMAC[mac]
+		if e == nil {
+			e = &clientEntry{mac: mac}
+			byMAC[mac] = e
+			order = append(order, e)
+		}
+		return e
+	}
+
+	for _, u := range users {
+		if u.MAC == "" {
+			continue
+		}
+		e := get(u.MAC)
+		e.name, e.hostname, e.note, e.networkID = u.Name, u.Hostname, u.Note, u.NetworkID
+		if u.UseFixedIP && u.FixedIP != "" {
+			e.ip, e.fixed = u.FixedIP, true
+		}
+	}
+
+	for _, c := range clients {
+		if c.MAC == "" {
+			continue
+		}
+		e := get(c.MAC)
+		if e.name == "" {
+			e.name = c.Name
+		}
+		if e.hostname == "" {
+			e.hostname = c.Hostname
+		}
+		if e.note == "" {
+			e.note = c.Note
+		}
+		if !e.fixed {
+			e.ip = c.IP
+			if c.NetworkID != "" {
+				e.networkID = c.NetworkID
+			}
+		}
+	}
+
+	// Sort so that which of two clients with the same name gets the MAC
+	// suffix does not depend on the order the controller listed them in.
+	sort.Slice(order, func(i, j int) bool { return order[i].mac < order[j].mac })
+	return order
+}
+
+// NoteTags returns the "#tag" words in a client note, lowercased and
+// without the "#", e.g. "Rack 2 #web #prod" gives [web prod]. UniFi has no
+// client tags, so notes are used to carry them.
+func NoteTags(note string) []string {
+	var tags []string
+	for _, word := range strings.Fields(note) {
+		tag := strings.ToLower(strings.TrimRight(strings.TrimPrefix(word, "#"), ".,;"))
+		if strings.HasPrefix(word, "#") && tag != "" {
+			tags = appendUnique(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ansibleGroupName converts a name into a valid Ansible group name:
+// lowercase letters, digits and underscores.
+func ansibleGroupName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			b.WriteByte('_')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// networkForAddress returns the network whose subnet contains ip, or nil.
+func networkForAddress(networks []types.Network, ip string) *types.Network {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil
+	}
+	for i := range networks {
+		_, subnet, err := net.ParseCIDR(networks[i].IPSubnet)
+		if err == nil && subnet.Contains(addr) {
+			return &networks[i]
+		}
+	}
+	return nil
+}
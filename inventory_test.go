@@ -0,0 +1,124 @@
+package gofi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestNewAnsibleInventory(t *testing.T) {
+	networks := []types.Network{
+		{ID: "lan", Name: "Default", IPSubnet: "192.168.1.1/24"},
+		{ID: "iot", Name: "IoT Devices", IPSubnet: "192.168.20.1/24", VLANEnabled: true, VLAN: 20},
+	}
+	devices := []types.Device{
+		{MAC: "AA:00:00:00:00:01", Name: "Gateway", Type: "udm", Model: "UDMPRO", IP: "192.168.1.1"},
+		{MAC: "aa:00:00:00:00:02", Name: "Office AP", Type: "uap", IP: "192.168.1.2"},
+		{MAC: "aa:00:00:00:00:03", Name: "Offline", Type: "usw"},
+	}
+	users := []types.User{
+		{MAC: "bb:00:00:00:00:01", Name: "NAS", Note: "rack 1 #storage #Prod", UseFixedIP: true, FixedIP: "192.168.1.10", NetworkID: "lan"},
+		{MAC: "bb:00:00:00:00:02", Hostname: "plug", UseFixedIP: true, FixedIP: "192.168.20.5"},
+		{MAC: "bb:00:00:00:00:03", Name: "Laptop"},
+	}
+	clients := []types.Client{
+		{MAC: "bb:00:00:00:00:03", IP: "192.168.1.50", NetworkID: "lan"},
+		{MAC: "bb:00:00:00:00:04", Hostname: "android-1234", IP: "192.168.1.51"},
+		{MAC: "bb:00:00:00:00:05", Name: "NAS", IP: "192.168.20.9"},
+	}
+
+	inv := NewAnsibleInventory(devices, users, clients, networks, AnsibleInventoryOptions{})
+
+	wantHosts := []string{"gateway", "laptop", "nas", "nas-000005", "office-ap", "plug"}
+	var gotHosts []string
+	for name := range inv.Hosts {
+		gotHosts = append(gotHosts, name)
+	}
+	sort.Strings(gotHosts)
+	if !reflect.DeepEqual(gotHosts, wantHosts) {
+		t.Errorf("hosts = %v, want %v", gotHosts, wantHosts)
+	}
+
+	nas := inv.Hosts["nas"]
+	if nas.Host != "192.168.1.10" || !nas.FixedIP || nas.Network != "Default" {
+		t.Errorf("nas = %+v, want fixed 192.168.1.10 on Default", nas)
+	}
+	if !reflect.DeepEqual(nas.Tags, []string{"storage", "prod"}) {
+		t.Errorf("nas tags = %v, want [storage prod]", nas.Tags)
+	}
+	if plug := inv.Hosts["plug"]; plug.VLAN != 20 || plug.Network != "IoT Devices" {
+		t.Errorf("plug = %+v, want VLAN 20 on IoT Devices", plug)
+	}
+	if got := inv.Hosts["laptop"].Host; got != "192.168.1.50" {
+		t.Errorf("laptop address = %s, want current IP 192.168.1.50", got)
+	}
+
+	groups := map[string][]string{
+		"unifi_devices":       {"gateway", "office-ap"},
+		"type_gateway":        {"gateway"},
+		"type_ap":             {"office-ap"},
+		"network_default":     {"gateway", "laptop", "nas", "office-ap"},
+		"network_iot_devices": {"nas-000005", "plug"},
+		"vlan_20":             {"nas-000005", "plug"},
+		"tag_storage":         {"nas"},
+		"tag_prod":            {"nas"},
+	}
+	for g, want := range groups {
+		if got := inv.Groups[g]; !reflect.DeepEqual(got, want) {
+			t.Errorf("group %s = %v, want %v", g, got, want)
+		}
+	}
+
+	all := NewAnsibleInventory(nil, nil, clients, networks, AnsibleInventoryOptions{SkipDevices: true, AllClients: true})
+	if all.Hosts["android-1234"] == nil {
+		t.Errorf("AllClients did not include unnamed client, hosts = %v", all.Hosts)
+	}
+}
+
+func TestAnsibleInventory_MarshalJSON(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddNetwork(&types.Network{ID: "lan", Name: "Default", IPSubnet: "192.168.1.1/24"})
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:00:00:00:00:01", Name: "core", Type: "usw", IP: "192.168.1.3"})
+	c := connectMock(t, server)
+
+	inv, err := BuildAnsibleInventory(context.Background(), c, "default", AnsibleInventoryOptions{})
+	if err != nil {
+		t.Fatalf("BuildAnsibleInventory() error = %v", err)
+	}
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var out struct {
+		All struct {
+			Children []string `json:"children"`
+		} `json:"all"`
+		Switches struct {
+			Hosts []string `json:"hosts"`
+		} `json:"type_switch"`
+		Meta struct {
+			HostVars map[string]map[string]interface{} `json:"hostvars"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(out.Switches.Hosts, []string{"core"}) {
+		t.Errorf("type_switch hosts = %v, want [core]", out.Switches.Hosts)
+	}
+	if got := out.Meta.HostVars["core"]["ansible_host"]; got != "192.168.1.3" {
+		t.Errorf("core ansible_host = %v, want 192.168.1.3", got)
+	}
+	if len(out.All.Children) == 0 {
+		t.Errorf("all has no children: %s", data)
+	}
+}
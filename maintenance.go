@@ -0,0 +1,239 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/services"
+)
+
+// MaintenanceWindow is a recurring period in which disruptive device
+// operations are allowed.
+type MaintenanceWindow struct {
+	// Days the window starts on. Empty means every day.
+	Days []time.Weekday
+
+	// Start is the offset from midnight at which the window opens, e.g.
+	// 2*time.Hour for 02:00.
+	Start time.Duration
+
+	// Duration is how long the window stays open. A window may run past
+	// midnight into the next day.
+	Duration time.Duration
+}
+
+// appliesOn reports whether the window opens on weekday d.
+func (w MaintenanceWindow) appliesOn(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenancePolicy restricts disruptive device operations (restarts,
+// upgrades, provisioning and PoE power cycles) to maintenance windows.
+// The controller has no such setting, so the policy is enforced by the
+// client: with WithMaintenancePolicy, those calls on Devices(), including
+// the same commands sent through Command, fail with
+// ErrOutsideMaintenanceWindow outside the windows. Use Run to defer an
+// operation to the next window instead.
+type MaintenancePolicy struct {
+	Windows []MaintenanceWindow
+
+	// Location is the time zone of the windows (default: time.Local).
+	Location *time.Location
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+// Validate checks that the policy has at least one well-formed window.
+func (p *MaintenancePolicy) Validate() error {
+	var verrs ValidationErrors
+	if len(p.Windows) == 0 {
+		verrs.Add("Windows", "at least one window is required")
+	}
+	for i, w := range p.Windows {
+		if w.Start < 0 || w.Start >= 24*time.Hour {
+			verrs.Add(fmt.Sprintf("Windows[%d].Start", i), "must be within a day")
+		}
+		if w.Duration <= 0 {
+			verrs.Add(fmt.Sprintf("Windows[%d].Duration", i), "must be positive")
+		}
+	}
+	return verrs.Err()
+}
+
+// Allowed reports whether t falls inside a window.
+func (p *MaintenancePolicy) Allowed(t time.Time) bool {
+	t = t.In(p.location())
+	// A window that opened yesterday may still be open.
+	for _, day := range []time.Time{midnight(t).AddDate(0, 0, -1), midnight(t)} {
+		for _, w := range p.Windows {
+			if !w.appliesOn(day.Weekday()) {
+				continue
+			}
+			start := day.Add(w.Start)
+			if !t.Before(start) && t.Before(start.Add(w.Duration)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Next returns t if it falls inside a window, otherwise the time the next
+// window opens. It returns the zero time if the policy has no windows.
+func (p *MaintenancePolicy) Next(t time.Time) time.Time {
+	if p.Allowed(t) {
+		return t
+	}
+
+	t = t.In(p.location())
+	var next time.Time
+	for i := 0; i <= 7; i++ {
+		day := midnight(t).AddDate(0, 0, i)
+		for _, w := range p.Windows {
+			if !w.appliesOn(day.Weekday()) {
+				continue
+			}
+			start := day.Add(w.Start)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+	return next
+}
+
+// Check returns nil if the current time falls inside a window, or an
+// error wrapping ErrOutsideMaintenanceWindow that names op and the next
+// window.
+func (p *MaintenancePolicy) Check(op string) error {
+	now := p.currentTime()
+	if p.Allowed(now) {
+		return nil
+	}
+	return fmt.Errorf("%s not allowed until %s: %w", op, p.Next(now).Format(time.RFC3339), ErrOutsideMaintenanceWindow)
+}
+
+// Run waits for the next window and then calls fn. It returns ctx's error
+// if ctx is done first.
+func (p *MaintenancePolicy) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		now := p.currentTime()
+		next := p.Next(now)
+		if next.IsZero() {
+			return fmt.Errorf("maintenance policy has no windows: %w", ErrInvalidConfig)
+		}
+		if !next.After(now) {
+			return fn(ctx)
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *MaintenancePolicy) location() *time.Location {
+	if p.Location != nil {
+		return p.Location
+	}
+	return time.Local
+}
+
+func (p *MaintenancePolicy) currentTime() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// midnight returns the start of t's day in t's location.
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// maintenanceDevices is a DeviceService that checks a MaintenancePolicy
+// before disruptive operations.
+type maintenanceDevices struct {
+	services.DeviceService
+	policy *MaintenancePolicy
+}
+
+func (d *maintenanceDevices) Restart(ctx context.Context, site, mac string) error {
+	if err := d.policy.Check("restart of " + mac); err != nil {
+		return err
+	}
+	return d.DeviceService.Restart(ctx, site, mac)
+}
+
+func (d *maintenanceDevices) RestartMany(ctx context.Context, site string, macs []string, opts ...services.RestartOption) (*services.RestartReport, error) {
+	if err := d.policy.Check(fmt.Sprintf("restart of %d devices", len(macs))); err != nil {
+		return nil, err
+	}
+	return d.DeviceService.RestartMany(ctx, site, macs, opts...)
+}
+
+func (d *maintenanceDevices) ForceProvision(ctx context.Context, site, mac string) error {
+	if err := d.policy.Check("provision of " + mac); err != nil {
+		return err
+	}
+	return d.DeviceService.ForceProvision(ctx, site, mac)
+}
+
+func (d *maintenanceDevices) Upgrade(ctx context.Context, site, mac string) error {
+	if err := d.policy.Check("upgrade of " + mac); err != nil {
+		return err
+	}
+	return d.DeviceService.Upgrade(ctx, site, mac)
+}
+
+func (d *maintenanceDevices) UpgradeExternal(ctx context.Context, site, mac, url string) error {
+	if err := d.policy.Check("upgrade of " + mac); err != nil {
+		return err
+	}
+	return d.DeviceService.UpgradeExternal(ctx, site, mac, url)
+}
+
+// disruptiveCommands are the Command commands that the policy gates, with
+// the operation named in the error.
+var disruptiveCommands = map[services.DeviceCommand]string{
+	services.DeviceCmdRestart:         "restart",
+	services.DeviceCmdForceProvision:  "provision",
+	services.DeviceCmdUpgrade:         "upgrade",
+	services.DeviceCmdUpgradeExternal: "upgrade",
+	services.DeviceCmdPowerCycle:      "power cycle",
+	services.DeviceCmdSetRollUpgrade:  "rolling upgrade",
+}
+
+func (d *maintenanceDevices) Command(ctx context.Context, site string, cmd services.DeviceCommand, params map[string]interface{}) error {
+	if op, ok := disruptiveCommands[cmd]; ok {
+		if mac, ok := params["mac"]; ok {
+			op = fmt.Sprintf("%s of %v", op, mac)
+		}
+		if err := d.policy.Check(op); err != nil {
+			return err
+		}
+	}
+	return d.DeviceService.Command(ctx, site, cmd, params)
+}
+
+func (d *maintenanceDevices) PowerCyclePort(ctx context.Context, site, switchMAC string, portIdx int) error {
+	if err := d.policy.Check(fmt.Sprintf("power cycle of %s port %d", switchMAC, portIdx)); err != nil {
+		return err
+	}
+	return d.DeviceService.PowerCyclePort(ctx, site, switchMAC, portIdx)
+}
@@ -0,0 +1,123 @@
+package gofi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestMaintenancePolicy_Allowed(t *testing.T) {
+	// Saturdays 23:00 to Sunday 01:00 UTC.
+	p := &MaintenancePolicy{
+		Windows:  []MaintenanceWindow{{Days: []time.Weekday{time.Saturday}, Start: 23 * time.Hour, Duration: 2 * time.Hour}},
+		Location: time.UTC,
+	}
+	sat := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) // a Saturday
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{sat.Add(22 * time.Hour), false},
+		{sat.Add(23 * time.Hour), true},
+		{sat.Add(24*time.Hour + 30*time.Minute), true}, // Sunday 00:30
+		{sat.Add(25 * time.Hour), false},
+		{sat.Add(-24*time.Hour + 23*time.Hour + 30*time.Minute), false}, // Friday 23:30
+	}
+	for _, tt := range tests {
+		if got := p.Allowed(tt.at); got != tt.want {
+			t.Errorf("Allowed(%s) = %v, want %v", tt.at.Format(time.RFC1123), got, tt.want)
+		}
+	}
+
+	if next := p.Next(sat.Add(25 * time.Hour)); !next.Equal(sat.AddDate(0, 0, 7).Add(23 * time.Hour)) {
+		t.Errorf("Next() = %s, want the following Saturday 23:00", next)
+	}
+}
+
+func TestMaintenancePolicy_Validate(t *testing.T) {
+	if err := (&MaintenancePolicy{}).Validate(); err == nil {
+		t.Error("Validate() of empty policy = nil, want error")
+	}
+	p := &MaintenancePolicy{Windows: []MaintenanceWindow{{Start: 25 * time.Hour}}}
+	if err := p.Validate(); err == nil {
+		t.Error("Validate() of bad window = nil, want error")
+	}
+}
+
+func TestWithMaintenancePolicy(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:bb:cc:dd:ee:01", Type: "uap"})
+
+	inWindow := false
+	policy := &MaintenancePolicy{
+		Windows:  []MaintenanceWindow{{Start: 2 * time.Hour, Duration: time.Hour}},
+		Location: time.UTC,
+		now: func() time.Time {
+			if inWindow {
+				return time.Date(2024, 6, 1, 2, 30, 0, 0, time.UTC)
+			}
+			return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		},
+	}
+	c := connectMock(t, server, WithMaintenancePolicy(policy))
+	ctx := context.Background()
+
+	err := c.Devices().Restart(ctx, "default", "aa:bb:cc:dd:ee:01")
+	if !errors.Is(err, ErrOutsideMaintenanceWindow) {
+		t.Fatalf("Restart() outside window error = %v, want ErrOutsideMaintenanceWindow", err)
+	}
+	if err := c.Devices().Locate(ctx, "default", "aa:bb:cc:dd:ee:01"); err != nil {
+		t.Errorf("Locate() outside window error = %v, want nil", err)
+	}
+	for _, cmd := range []services.DeviceCommand{services.DeviceCmdRestart, services.DeviceCmdUpgrade, services.DeviceCmdForceProvision} {
+		err := c.Devices().Command(ctx, "default", cmd, map[string]interface{}{"mac": "aa:bb:cc:dd:ee:01"})
+		if !errors.Is(err, ErrOutsideMaintenanceWindow) {
+			t.Errorf("Command(%s) outside window error = %v, want ErrOutsideMaintenanceWindow", cmd, err)
+		}
+	}
+	if err := c.Devices().Command(ctx, "default", services.DeviceCmdSetLocate, map[string]interface{}{"mac": "aa:bb:cc:dd:ee:01"}); err != nil {
+		t.Errorf("Command(set-locate) outside window error = %v, want nil", err)
+	}
+
+	inWindow = true
+	if err := c.Site("default").Devices().Restart(ctx, "aa:bb:cc:dd:ee:01"); err != nil {
+		t.Errorf("Restart() inside window error = %v", err)
+	}
+	if err := c.Devices().Command(ctx, "default", services.DeviceCmdRestart, map[string]interface{}{"mac": "aa:bb:cc:dd:ee:01"}); err != nil {
+		t.Errorf("Command(restart) inside window error = %v", err)
+	}
+}
+
+func TestMaintenancePolicy_Run(t *testing.T) {
+	now := time.Date(2024, 6, 1, 2, 30, 0, 0, time.UTC)
+	open := &MaintenancePolicy{
+		Windows:  []MaintenanceWindow{{Start: 2 * time.Hour, Duration: time.Hour}},
+		Location: time.UTC,
+		now:      func() time.Time { return now },
+	}
+	called := false
+	if err := open.Run(context.Background(), func(context.Context) error { called = true; return nil }); err != nil || !called {
+		t.Errorf("Run() inside window = %v, called %v, want call", err, called)
+	}
+
+	// Outside any window, Run waits until ctx is done.
+	closed := &MaintenancePolicy{
+		Windows:  []MaintenanceWindow{{Start: 4 * time.Hour, Duration: time.Hour}},
+		Location: time.UTC,
+		now:      func() time.Time { return now },
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	called = false
+	err := closed.Run(ctx, func(context.Context) error { called = true; return nil })
+	if !errors.Is(err, context.DeadlineExceeded) || called {
+		t.Errorf("Run() outside window = %v, called %v, want deadline exceeded without call", err, called)
+	}
+}
@@ -0,0 +1,157 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// CommandDelays sets how long simulated device commands take, so that code
+// polling device state sees realistic transitions. See WithCommandDelays.
+type CommandDelays struct {
+	// Restart is how long a rebooting device stays disconnected, both
+	// after a restart command and at the end of an upgrade.
+	Restart time.Duration
+
+	// Upgrade is how long a device stays upgrading before it reboots.
+	Upgrade time.Duration
+
+	// Provision is how long a device stays provisioning.
+	Provision time.Duration
+}
+
+// WithCommandDelays makes restart, upgrade and force-provision commands
+// take time:
+//
+//   - restart: disconnected for Restart, then connected with fresh uptime
+//   - upgrade: upgrading for Upgrade, then restarts as above, coming back
+//     on the new firmware and no longer upgradable
+//   - force-provision and device updates: provisioning for Provision,
+//     then connected with a new cfgversion and provisioned_at
+//
+// Without this option restarts are instant and upgrades and provisioning
+// never finish.
+func WithCommandDelays(delays CommandDelays) Option {
+	return func(s *Server) {
+		s.delays = &delays
+		s.timers = make(map[string]*time.Timer)
+	}
+}
+
+// after runs step for the device with the given ID once d has passed,
+// replacing any step still pending for it. step modifies a copy of the
+// stored device, which is stored if step returns true.
+func (s *Server) after(id string, d time.Duration, step func(device *types.Device) bool) {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+	if s.timersStopped {
+		return
+	}
+	if t := s.timers[id]; t != nil {
+		t.Stop()
+	}
+	s.timers[id] = time.AfterFunc(d, func() {
+		s.timerMu.Lock()
+		delete(s.timers, id)
+		s.timerMu.Unlock()
+
+		stored, ok := s.state.GetDevice(id)
+		if !ok {
+			return
+		}
+		device := *stored
+		if step(&device) {
+			s.state.AddDevice(&device)
+		}
+	})
+}
+
+// cancelPending drops any step pending for the device with the given ID.
+func (s *Server) cancelPending(id string) {
+	if s.delays == nil {
+		return
+	}
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+	if t := s.timers[id]; t != nil {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// stopTimers cancels all pending steps; used when the server closes.
+func (s *Server) stopTimers() {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+	s.timersStopped = true
+	for id, t := range s.timers {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// The simulate functions update and store a copy of a stored device, then
+// schedule the next step if command delays are set.
+
+// simulateRestart takes the device offline and schedules its return.
+func (s *Server) simulateRestart(device *types.Device) {
+	s.cancelPending(device.ID)
+	device.Uptime = types.FlexInt{}
+	if s.delays == nil {
+		s.state.AddDevice(device)
+		return
+	}
+	device.State = types.DeviceStateDisconnected
+	s.state.AddDevice(device)
+	s.after(device.ID, s.delays.Restart, func(d *types.Device) bool {
+		d.State = types.DeviceStateConnected
+		d.Uptime = types.FlexInt{}
+		d.LastSeen = types.NewFlexTime(time.Now().Unix())
+		return true
+	})
+}
+
+// simulateUpgrade moves the device to upgrading and, with delays, through
+// a restart onto the new firmware.
+func (s *Server) simulateUpgrade(device *types.Device) {
+	s.cancelPending(device.ID)
+	device.State = types.DeviceStateUpgrading
+	s.state.AddDevice(device)
+	if s.delays == nil {
+		return
+	}
+	s.after(device.ID, s.delays.Upgrade, func(d *types.Device) bool {
+		if d.State != types.DeviceStateUpgrading {
+			return false
+		}
+		d.Upgradable = false
+		if d.UpgradeToFirmware != "" {
+			d.Version = strings.TrimPrefix(d.UpgradeToFirmware, "v")
+			d.UpgradeToFirmware = ""
+		}
+		s.simulateRestart(d)
+		return false
+	})
+}
+
+// simulateProvision moves the device to provisioning and, with delays,
+// back to connected.
+func (s *Server) simulateProvision(device *types.Device) {
+	s.cancelPending(device.ID)
+	device.State = types.DeviceStateProvisioning
+	s.state.AddDevice(device)
+	if s.delays == nil {
+		return
+	}
+	s.after(device.ID, s.delays.Provision, func(d *types.Device) bool {
+		if d.State != types.DeviceStateProvisioning {
+			return false
+		}
+		d.State = types.DeviceStateConnected
+		d.ConfigVersion = fmt.Sprintf("%016x", time.Now().UnixNano())
+		d.ProvisionedAt = types.NewFlexTime(time.Now().Unix())
+		return true
+	})
+}
@@ -0,0 +1,124 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// sendDeviceCommand posts a devmgr command for the given MAC.
+func sendDeviceCommand(t *testing.T, server *Server, cmd, mac string) {
+	t.Helper()
+	body, _ := json.Marshal(types.CommandRequest{Cmd: cmd, MAC: mac})
+	req, _ := http.NewRequest("POST", server.URL()+"/proxy/network/api/s/default/cmd/devmgr", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := testHTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// waitForState polls the stored device until it reaches state.
+func waitForState(t *testing.T, server *Server, id string, state types.DeviceState) *types.Device {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if d, ok := server.State().GetDevice(id); ok && d.State == state {
+			return d
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	d, _ := server.State().GetDevice(id)
+	t.Fatalf("Device did not reach state %d, last state %d", state, d.State)
+	return nil
+}
+
+func TestCommandDelays_Restart(t *testing.T) {
+	server := NewServer(WithoutAuth(), WithoutCSRF(), WithCommandDelays(CommandDelays{Restart: 50 * time.Millisecond}))
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:     "dev1",
+		MAC:    "aa:bb:cc:dd:ee:01",
+		State:  types.DeviceStateConnected,
+		Uptime: types.FlexInt{Val: 3600},
+	})
+
+	sendDeviceCommand(t, server, "restart", "aa:bb:cc:dd:ee:01")
+
+	d, _ := server.State().GetDevice("dev1")
+	if d.State != types.DeviceStateDisconnected {
+		t.Errorf("Expected disconnected right after restart, got state %d", d.State)
+	}
+
+	d = waitForState(t, server, "dev1", types.DeviceStateConnected)
+	if d.Uptime.Int64() != 0 {
+		t.Errorf("Expected fresh uptime, got %d", d.Uptime.Int64())
+	}
+}
+
+func TestCommandDelays_Upgrade(t *testing.T) {
+	server := NewServer(WithoutAuth(), WithoutCSRF(), WithCommandDelays(CommandDelays{
+		Restart: 30 * time.Millisecond,
+		Upgrade: 30 * time.Millisecond,
+	}))
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:                "dev1",
+		MAC:               "aa:bb:cc:dd:ee:01",
+		State:             types.DeviceStateConnected,
+		Version:           "6.5.28",
+		Upgradable:        true,
+		UpgradeToFirmware: "6.6.55",
+	})
+
+	sendDeviceCommand(t, server, "upgrade", "aa:bb:cc:dd:ee:01")
+
+	d, _ := server.State().GetDevice("dev1")
+	if d.State != types.DeviceStateUpgrading {
+		t.Errorf("Expected upgrading right after upgrade, got state %d", d.State)
+	}
+
+	waitForState(t, server, "dev1", types.DeviceStateDisconnected)
+	d = waitForState(t, server, "dev1", types.DeviceStateConnected)
+	if d.Version != "6.6.55" {
+		t.Errorf("Expected version 6.6.55, got %q", d.Version)
+	}
+	if d.Upgradable {
+		t.Error("Expected device to no longer be upgradable")
+	}
+}
+
+func TestCommandDelays_Provision(t *testing.T) {
+	server := NewServer(WithoutAuth(), WithoutCSRF(), WithCommandDelays(CommandDelays{Provision: 30 * time.Millisecond}))
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01", State: types.DeviceStateConnected})
+
+	sendDeviceCommand(t, server, "force-provision", "aa:bb:cc:dd:ee:01")
+	waitForState(t, server, "dev1", types.DeviceStateConnected)
+}
+
+func TestWithoutCommandDelays_UpgradeNeverFinishes(t *testing.T) {
+	server := NewServer(WithoutAuth(), WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "dev1", MAC: "aa:bb:cc:dd:ee:01", State: types.DeviceStateConnected})
+
+	sendDeviceCommand(t, server, "upgrade", "aa:bb:cc:dd:ee:01")
+	time.Sleep(20 * time.Millisecond)
+
+	d, _ := server.State().GetDevice("dev1")
+	if d.State != types.DeviceStateUpgrading {
+		t.Errorf("Expected device to stay upgrading, got state %d", d.State)
+	}
+}
@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// handleAlarms lists alarms (list/alarm and stat/alarm, filtered by the
+// "archived" field of a POST body) and runs cmd/evtmgr commands.
+func (s *Server) handleAlarms(w http.ResponseWriter, r *http.Request, site string) {
+	if strings.Contains(r.URL.Path, "/cmd/evtmgr") {
+		s.handleAlarmCommand(w, r)
+		return
+	}
+
+	var filter struct {
+		Archived *bool `json:"archived"`
+	}
+	if r.Method == "POST" && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			writeBadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	alarms := s.state.ListAlarms()
+	sort.Slice(alarms, func(i, j int) bool {
+		if !alarms[i].Time.Val.Equal(alarms[j].Time.Val) {
+			return alarms[i].Time.Val.After(alarms[j].Time.Val)
+		}
+		return alarms[i].ID < alarms[j].ID
+	})
+
+	data := []interface{}{}
+	for _, alarm := range alarms {
+		if filter.Archived == nil || alarm.Archived == *filter.Archived {
+			data = append(data, *alarm)
+		}
+	}
+	writeAPIResponse(w, data)
+}
+
+// handleAlarmCommand archives one or all alarms.
+func (s *Server) handleAlarmCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	var cmd struct {
+		CMD string `json:"cmd"`
+		ID  string `json:"_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeBadRequest(w, "Invalid request body")
+		return
+	}
+
+	archive := func(alarm *types.Alarm) {
+		archived := *alarm
+		archived.Archived = true
+		archived.Handled = true
+		archived.HandledTime = types.NewFlexTime(time.Now().UnixMilli())
+		s.state.AddAlarm(&archived)
+	}
+
+	switch cmd.CMD {
+	case "archive-alarm":
+		alarm := s.state.GetAlarm(cmd.ID)
+		if alarm == nil {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.NotFound")
+			return
+		}
+		archive(alarm)
+	case "archive-all-alarms":
+		for _, alarm := range s.state.ListAlarms() {
+			if !alarm.Archived {
+				archive(alarm)
+			}
+		}
+	default:
+		writeBadRequest(w, "Unknown command")
+		return
+	}
+
+	writeAPIResponse(w, []interface{}{})
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// handleAnomalies returns the anomalies with their occurrences limited to
+// the start and end query parameters, in epoch milliseconds.
+func (s *Server) handleAnomalies(w http.ResponseWriter, r *http.Request, site string) {
+	if r.Method != "GET" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	bound := func(name string) time.Time {
+		ms, err := strconv.ParseInt(r.URL.Query().Get(name), 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.UnixMilli(ms)
+	}
+	start, end := bound("start"), bound("end")
+
+	data := []interface{}{}
+	for _, anomaly := range s.state.ListAnomalies() {
+		if a := anomaly.Between(start, end); len(a.Timestamps) > 0 {
+			data = append(data, a)
+		}
+	}
+
+	writeAPIResponse(w, data)
+}
@@ -94,6 +94,18 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// handleRoot serves the start page: a UniFi OS console answers it, a
+// classic controller redirects to /manage.
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	if s.classic {
+		http.Redirect(w, r, "/manage", http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("<!DOCTYPE html><html><head><title>UniFi OS</title></head></html>"))
+}
+
 // handleStatus handles status requests (no auth required).
 func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	status := &types.Status{
@@ -128,3 +140,44 @@ func (s *Server) handleSelf(w http.ResponseWriter, r *http.Request) {
 	// Self endpoint returns data in API response format (array)
 	writeAPIResponse(w, []interface{}{*admin})
 }
+
+// handleUsersSelf handles password changes of the logged-in admin on
+// /api/users/self, a UniFi OS console endpoint.
+func (s *Server) handleUsersSelf(w http.ResponseWriter, r *http.Request) {
+	if s.classic {
+		writeNotFound(w)
+		return
+	}
+	if r.Method != "PUT" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	cookie, err := r.Cookie("unifises")
+	if err != nil {
+		writeUnauthorized(w)
+		return
+	}
+	session, exists := s.state.GetSession(cookie.Value)
+	if !exists {
+		writeUnauthorized(w)
+		return
+	}
+
+	var req struct {
+		OldPassword string `json:"oldPassword"`
+		Password    string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password == "" {
+		writeBadRequest(w, "Invalid request body")
+		return
+	}
+	if !s.state.ValidateCredentials(session.Username, req.OldPassword) {
+		writeBadRequest(w, "Current password is incorrect")
+		return
+	}
+
+	s.state.ChangePassword(session.Username, req.Password)
+
+	writeJSON(w, http.StatusOK, map[string]string{"username": session.Username})
+}
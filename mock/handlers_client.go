@@ -25,6 +25,11 @@ func (s *Server) handleClients(w http.ResponseWriter, r *http.Request, site stri
 		return
 	}
 
+	if strings.Contains(path, "/stat/guest") {
+		s.handleGuestStat(w, r, site)
+		return
+	}
+
 	// Client commands
 	if strings.Contains(path, "/cmd/stamgr") {
 		s.handleClientCommand(w, r, site)
@@ -47,7 +52,7 @@ func (s *Server) handleClientStat(w http.ResponseWriter, r *http.Request, site s
 	now := time.Now().Unix()
 	activeClients := make([]interface{}, 0)
 	for _, client := range clients {
-		if client.LastSeen > 0 && now-client.LastSeen < 300 {
+		if !client.LastSeen.IsZero() && now-client.LastSeen.Val.Unix() < 300 {
 			activeClients = append(activeClients, *client)
 		}
 	}
@@ -76,7 +81,7 @@ func (s *Server) handleAllUserStat(w http.ResponseWriter, r *http.Request, site
 	cutoff := time.Now().Unix() - int64(withinHours*3600)
 	filteredClients := make([]interface{}, 0)
 	for _, client := range clients {
-		if client.LastSeen >= cutoff {
+		if !client.LastSeen.IsZero() && client.LastSeen.Val.Unix() >= cutoff {
 			filteredClients = append(filteredClients, *client)
 		}
 	}
@@ -115,6 +120,14 @@ func (s *Server) handleClientCommand(w http.ResponseWriter, r *http.Request, sit
 		return
 	}
 
+	// A sleeping client is usually not connected, so Wake-on-LAN does not
+	// need a known client.
+	if cmd.CMD == "wol" {
+		s.state.RecordWakeOnLAN(cmd.MAC)
+		writeAPIResponse(w, []interface{}{})
+		return
+	}
+
 	// Get or create client
 	client := s.state.GetClient(cmd.MAC)
 	if client == nil {
@@ -126,9 +139,9 @@ func (s *Server) handleClientCommand(w http.ResponseWriter, r *http.Request, sit
 		// Create guest client
 		client = &types.Client{
 			MAC:       cmd.MAC,
-			IsGuest:   true,
-			FirstSeen: time.Now().Unix(),
-			LastSeen:  time.Now().Unix(),
+			IsGuest:   types.FlexBool{Val: true},
+			FirstSeen: types.NewFlexTime(time.Now().Unix()),
+			LastSeen:  types.NewFlexTime(time.Now().Unix()),
 		}
 		s.state.AddClient(client)
 	}
@@ -152,13 +165,37 @@ func (s *Server) handleClientCommand(w http.ResponseWriter, r *http.Request, sit
 		client.Authorized = true
 		if cmd.Minutes > 0 {
 			// Set expiration (not fully modeled in mock)
-			client.LastSeen = time.Now().Unix()
+			client.LastSeen = types.NewFlexTime(time.Now().Unix())
 		}
 		s.state.UpdateClient(client)
+
+		minutes := cmd.Minutes
+		if minutes == 0 {
+			minutes = 480
+		}
+		now := time.Now().Unix()
+		s.state.AddGuest(&types.Guest{
+			ID:             cmd.MAC,
+			MAC:            cmd.MAC,
+			APMAC:          cmd.APMAC,
+			AuthorizedBy:   "api",
+			Start:          now,
+			End:            now + int64(minutes)*60,
+			Duration:       minutes,
+			QOSRateMaxUp:   cmd.Up,
+			QOSRateMaxDown: cmd.Down,
+			QOSUsageQuota:  cmd.Bytes,
+		})
 	case "unauthorize-guest":
 		client.GuestAuthorized = false
 		client.Authorized = false
 		s.state.UpdateClient(client)
+		if guest := s.state.GetGuest(cmd.MAC); guest != nil {
+			g := *guest
+			g.Expired = true
+			g.End = time.Now().Unix()
+			s.state.AddGuest(&g)
+		}
 	case "set-sta-dev-id":
 		if cmd.DevID > 0 {
 			client.DeviceIDOverride = cmd.DevID
@@ -171,3 +208,19 @@ func (s *Server) handleClientCommand(w http.ResponseWriter, r *http.Request, sit
 
 	writeAPIResponse(w, []interface{}{})
 }
+
+// handleGuestStat returns guest authorizations.
+func (s *Server) handleGuestStat(w http.ResponseWriter, r *http.Request, site string) {
+	if r.Method != "GET" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	guests := s.state.ListGuests()
+	data := make([]interface{}, 0, len(guests))
+	for _, guest := range guests {
+		data = append(data, *guest)
+	}
+
+	writeAPIResponse(w, data)
+}
@@ -28,12 +28,12 @@ func TestHandleClientStat(t *testing.T) {
 	activeClient := &types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f1",
 		Hostname: "active-device",
-		LastSeen: now - 60, // 1 minute ago (active)
+		LastSeen: types.NewFlexTime(now - 60), // 1 minute ago (active)
 	}
 	inactiveClient := &types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f2",
 		Hostname: "inactive-device",
-		LastSeen: now - 600, // 10 minutes ago (inactive)
+		LastSeen: types.NewFlexTime(now - 600), // 10 minutes ago (inactive)
 	}
 
 	server.state.AddClient(activeClient)
@@ -77,12 +77,12 @@ func TestHandleAllUserStat(t *testing.T) {
 	recentClient := &types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f1",
 		Hostname: "recent-device",
-		LastSeen: now - 3600, // 1 hour ago
+		LastSeen: types.NewFlexTime(now - 3600), // 1 hour ago
 	}
 	oldClient := &types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f2",
 		Hostname: "old-device",
-		LastSeen: now - 86400*30, // 30 days ago
+		LastSeen: types.NewFlexTime(now - 86400*30), // 30 days ago
 	}
 
 	server.state.AddClient(recentClient)
@@ -125,7 +125,7 @@ func TestHandleClientCommand(t *testing.T) {
 	testClient := &types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
 		Hostname: "test-device",
-		LastSeen: time.Now().Unix(),
+		LastSeen: types.NewFlexTime(time.Now().Unix()),
 	}
 	server.state.AddClient(testClient)
 
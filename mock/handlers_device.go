@@ -61,7 +61,7 @@ func (s *Server) handleDeviceStat(w http.ResponseWriter, r *http.Request, site s
 		data[i] = *device
 	}
 
-	writeAPIResponse(w, data)
+	writeAPIResponse(w, selectAttrs(r, data))
 }
 
 // handleDeviceBasicStat returns basic device info.
@@ -91,7 +91,33 @@ func (s *Server) handleDeviceBasicStat(w http.ResponseWriter, r *http.Request, s
 		data[i] = basic
 	}
 
-	writeAPIResponse(w, data)
+	writeAPIResponse(w, selectAttrs(r, data))
+}
+
+// selectAttrs reduces each item to the comma-separated attributes of the
+// attrs query parameter, if given.
+func selectAttrs(r *http.Request, data []interface{}) []interface{} {
+	attrs := r.URL.Query().Get("attrs")
+	if attrs == "" {
+		return data
+	}
+
+	keep := strings.Split(attrs, ",")
+	out := make([]interface{}, len(data))
+	for i, item := range data {
+		raw, _ := json.Marshal(item)
+		var fields map[string]json.RawMessage
+		_ = json.Unmarshal(raw, &fields)
+
+		selected := make(map[string]json.RawMessage, len(keep))
+		for _, key := range keep {
+			if v, ok := fields[key]; ok {
+				selected[key] = v
+			}
+		}
+		out[i] = selected
+	}
+	return out
 }
 
 // handleDeviceUpdate updates a device.
@@ -102,11 +128,14 @@ func (s *Server) handleDeviceUpdate(w http.ResponseWriter, r *http.Request, site
 	}
 
 	// Get existing device
-	device, exists := s.state.GetDevice(id)
+	stored, exists := s.state.GetDevice(id)
 	if !exists {
 		writeNotFound(w)
 		return
 	}
+	// Modify a copy so readers of the stored device don't race
+	d := *stored
+	device := &d
 
 	// Parse update request
 	var updateReq types.Device
@@ -125,14 +154,33 @@ func (s *Server) handleDeviceUpdate(w http.ResponseWriter, r *http.Request, site
 	if updateReq.LEDOverrideColor != "" {
 		device.LEDOverrideColor = updateReq.LEDOverrideColor
 	}
+	if updateReq.MapID != "" {
+		device.MapID = updateReq.MapID
+		device.X, device.Y = updateReq.X, updateReq.Y
+		device.HeightInMeters = updateReq.HeightInMeters
+	}
 
-	// Save updated device
-	s.state.AddDevice(device)
+	// Save updated device; with command delays it provisions the change
+	if s.delays != nil {
+		s.simulateProvision(device)
+	} else {
+		s.state.AddDevice(device)
+	}
 
 	// Return updated device
 	writeAPIResponse(w, []interface{}{*device})
 }
 
+// siteDeviceCommands are devmgr commands that do not target a device.
+var siteDeviceCommands = map[string]bool{
+	"set-default":           true,
+	"speedtest":             true,
+	"speedtest-status":      true,
+	"set-rollupgrade":       true,
+	"unset-rollupgrade":     true,
+	"check-firmware-update": true,
+}
+
 // handleDeviceCommand handles device commands.
 func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, site string) {
 	if r.Method != "POST" {
@@ -148,7 +196,7 @@ func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, sit
 	}
 
 	// Validate MAC address for most commands
-	if cmdReq.MAC == "" && cmdReq.Cmd != "set-default" {
+	if cmdReq.MAC == "" && !siteDeviceCommands[cmdReq.Cmd] {
 		writeBadRequest(w, "MAC address required")
 		return
 	}
@@ -159,7 +207,9 @@ func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, sit
 		devices := s.state.ListDevices()
 		for i := range devices {
 			if strings.EqualFold(devices[i].MAC, cmdReq.MAC) {
-				device = devices[i]
+				// Modify a copy so readers of the stored device don't race
+				d := *devices[i]
+				device = &d
 				break
 			}
 		}
@@ -178,17 +228,30 @@ func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, sit
 			device.State = types.DeviceStateConnected
 			s.state.AddDevice(device)
 		}
+	case "adv-adopt":
+		if cmdReq.URL == "" || cmdReq.IP == "" || cmdReq.Username == "" || cmdReq.Password == "" {
+			writeBadRequest(w, "url, ip, username and password required for advanced adoption")
+			return
+		}
+		if device != nil {
+			device.Adopted = true
+			device.State = types.DeviceStateConnected
+			device.InformURL = cmdReq.URL
+			s.state.AddDevice(device)
+		}
 	case "restart":
-		// Simulate restart - no state change needed
+		// Without command delays the reboot is instant: the device is back
+		// with fresh uptime
+		if device != nil {
+			s.simulateRestart(device)
+		}
 	case "force-provision":
 		if device != nil {
-			device.State = types.DeviceStateProvisioning
-			s.state.AddDevice(device)
+			s.simulateProvision(device)
 		}
 	case "upgrade":
 		if device != nil {
-			device.State = types.DeviceStateUpgrading
-			s.state.AddDevice(device)
+			s.simulateUpgrade(device)
 		}
 	case "upgrade-external":
 		if cmdReq.URL == "" {
@@ -196,8 +259,7 @@ func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, sit
 			return
 		}
 		if device != nil {
-			device.State = types.DeviceStateUpgrading
-			s.state.AddDevice(device)
+			s.simulateUpgrade(device)
 		}
 	case "set-locate":
 		if device != nil {
@@ -217,6 +279,10 @@ func (s *Server) handleDeviceCommand(w http.ResponseWriter, r *http.Request, sit
 		// Simulate power cycle - no state change needed
 	case "spectrum-scan":
 		// Simulate spectrum scan - no state change needed
+	case "migrate", "cancel-migrate":
+		// Simulate migration - no state change needed
+	case "speedtest", "speedtest-status", "set-rollupgrade", "unset-rollupgrade", "check-firmware-update":
+		// Site-wide commands - no state change needed
 	default:
 		writeBadRequest(w, fmt.Sprintf("Unknown command: %s", cmdReq.Cmd))
 		return
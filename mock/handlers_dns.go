@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// handleDNSRecords lists, gets, creates, updates and deletes static DNS
+// records (v2 API).
+func (s *Server) handleDNSRecords(w http.ResponseWriter, r *http.Request, site string) {
+	_, id, _ := strings.Cut(strings.TrimSuffix(r.URL.Path, "/"), "/static-dns")
+	id = strings.TrimPrefix(id, "/")
+
+	switch {
+	case r.Method == "GET" && id == "":
+		records := s.state.ListDNSRecords()
+		sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+		data := make([]interface{}, len(records))
+		for i, record := range records {
+			data[i] = *record
+		}
+		writeJSON(w, http.StatusOK, data)
+
+	case r.Method == "GET":
+		record := s.state.GetDNSRecord(id)
+		if record == nil {
+			writeNotFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, record)
+
+	case (r.Method == "POST" && id == "") || (r.Method == "PUT" && id != ""):
+		var record types.DNSRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if record.Key == "" || record.Value == "" {
+			writeBadRequest(w, "Key and value are required")
+			return
+		}
+		if id != "" {
+			if s.state.GetDNSRecord(id) == nil {
+				writeNotFound(w)
+				return
+			}
+			record.ID = id
+		} else {
+			record.ID = generateID()
+		}
+		s.state.AddDNSRecord(&record)
+		writeJSON(w, http.StatusOK, record)
+
+	case r.Method == "DELETE" && id != "":
+		if s.state.GetDNSRecord(id) == nil {
+			writeNotFound(w)
+			return
+		}
+		s.state.DeleteDNSRecord(id)
+		writeJSON(w, http.StatusOK, []interface{}{})
+
+	default:
+		writeBadRequest(w, "Method not allowed")
+	}
+}
@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// handleEventHistory returns the recorded events newest first, limited to
+// the last "within" hours and paged by "_start" and "_limit" like the
+// controller's stat/event.
+func (s *Server) handleEventHistory(w http.ResponseWriter, r *http.Request, site string) {
+	query := struct {
+		Within int `json:"within"`
+		Start  int `json:"_start"`
+		Limit  int `json:"_limit"`
+	}{Within: 720, Limit: 3000}
+	if r.Method == "POST" && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			writeBadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	events := s.state.ListEvents()
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Val.After(events[j].Time.Val)
+	})
+
+	since := time.Now().Add(-time.Duration(query.Within) * time.Hour)
+	data := []interface{}{}
+	skipped := 0
+	for _, event := range events {
+		if event.Time.Val.Before(since) {
+			continue
+		}
+		if skipped < query.Start {
+			skipped++
+			continue
+		}
+		if len(data) == query.Limit {
+			break
+		}
+		data = append(data, event)
+	}
+
+	writeAPIResponse(w, data)
+}
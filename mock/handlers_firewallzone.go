@@ -0,0 +1,267 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// defaultFirewallZones returns the built-in zones of a new site.
+func defaultFirewallZones() map[string]*types.FirewallZone {
+	zones := make(map[string]*types.FirewallZone)
+	for _, key := range []string{types.ZoneKeyInternal, types.ZoneKeyExternal, types.ZoneKeyGateway, types.ZoneKeyVPN, types.ZoneKeyHotspot, types.ZoneKeyDMZ} {
+		zones["zone-"+key] = &types.FirewallZone{
+			ID:          "zone-" + key,
+			Name:        strings.ToUpper(key[:1]) + key[1:],
+			ZoneKey:     key,
+			NetworkIDs:  []string{},
+			DefaultZone: true,
+			AttrNoEdit:  true,
+		}
+	}
+	return zones
+}
+
+// handleFirewallZones routes zone-based firewall requests (v2 API):
+// firewall/zone, firewall/zone-matrix and firewall-policies.
+func (s *Server) handleFirewallZones(w http.ResponseWriter, r *http.Request, site string) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case strings.HasSuffix(path, "/firewall/zone-matrix"):
+		if r.Method != "GET" {
+			writeBadRequest(w, "Method not allowed")
+			return
+		}
+		s.handleZoneMatrix(w)
+
+	case strings.Contains(path, "/firewall/zone"):
+		_, id, _ := strings.Cut(path, "/firewall/zone")
+		s.handleZones(w, r, strings.TrimPrefix(id, "/"))
+
+	case strings.HasSuffix(path, "/firewall-policies/batch-delete"):
+		if r.Method != "POST" {
+			writeBadRequest(w, "Method not allowed")
+			return
+		}
+		s.handleDeletePolicies(w, r)
+
+	case strings.HasSuffix(path, "/firewall-policies/batch-reorder"):
+		if r.Method != "PUT" {
+			writeBadRequest(w, "Method not allowed")
+			return
+		}
+		s.handleReorderPolicies(w, r)
+
+	default:
+		_, id, _ := strings.Cut(path, "/firewall-policies")
+		s.handlePolicies(w, r, strings.TrimPrefix(id, "/"))
+	}
+}
+
+// handleZones lists, creates, updates and deletes zones.
+func (s *Server) handleZones(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case r.Method == "GET" && id == "":
+		zones := s.state.ListFirewallZones()
+		sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+		data := make([]interface{}, len(zones))
+		for i, zone := range zones {
+			data[i] = *zone
+		}
+		writeJSON(w, http.StatusOK, data)
+
+	case (r.Method == "POST" && id == "") || (r.Method == "PUT" && id != ""):
+		var zone types.FirewallZone
+		if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if zone.Name == "" {
+			writeBadRequest(w, "Zone name is required")
+			return
+		}
+		if id != "" {
+			existing := s.state.GetFirewallZone(id)
+			if existing == nil {
+				writeNotFound(w)
+				return
+			}
+			zone.ID, zone.ZoneKey, zone.DefaultZone, zone.AttrNoEdit = id, existing.ZoneKey, existing.DefaultZone, existing.AttrNoEdit
+		} else {
+			zone.ID = generateID()
+		}
+		if zone.NetworkIDs == nil {
+			zone.NetworkIDs = []string{}
+		}
+		s.state.AddFirewallZone(&zone)
+		writeJSON(w, http.StatusOK, zone)
+
+	case r.Method == "DELETE" && id != "":
+		zone := s.state.GetFirewallZone(id)
+		if zone == nil {
+			writeNotFound(w)
+			return
+		}
+		if zone.DefaultZone {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.DefaultZoneCannotBeDeleted")
+			return
+		}
+		s.state.DeleteFirewallZone(id)
+		writeJSON(w, http.StatusOK, []interface{}{})
+
+	default:
+		writeBadRequest(w, "Method not allowed")
+	}
+}
+
+// handleZoneMatrix returns the action between every pair of zones: traffic
+// from the external zone is blocked unless a policy allows it, other
+// traffic is allowed.
+func (s *Server) handleZoneMatrix(w http.ResponseWriter) {
+	zones := s.state.ListFirewallZones()
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+	policies := s.state.ListFirewallPolicies()
+
+	matrix := make(types.FirewallZoneMatrix, len(zones))
+	for i, src := range zones {
+		row := types.FirewallZoneMatrixRow{ZoneID: src.ID, Name: src.Name, ZoneKey: src.ZoneKey}
+		for _, dst := range zones {
+			cell := types.FirewallZoneMatrixCell{ZoneID: dst.ID, Action: types.PolicyActionAllow}
+			if src.ZoneKey == types.ZoneKeyExternal && dst.ZoneKey != types.ZoneKeyExternal {
+				cell.Action = types.PolicyActionBlock
+			}
+			for _, p := range policies {
+				if p.Source.ZoneID == src.ID && p.Destination.ZoneID == dst.ID {
+					cell.PolicyCount++
+				}
+			}
+			row.Data = append(row.Data, cell)
+		}
+		matrix[i] = row
+	}
+
+	writeJSON(w, http.StatusOK, matrix)
+}
+
+// handlePolicies lists, creates and updates policies. Policies between the
+// same zones are listed in index order.
+func (s *Server) handlePolicies(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case r.Method == "GET" && id == "":
+		policies := s.state.ListFirewallPolicies()
+		sort.Slice(policies, func(i, j int) bool {
+			a, b := policies[i], policies[j]
+			if a.Source.ZoneID != b.Source.ZoneID {
+				return a.Source.ZoneID < b.Source.ZoneID
+			}
+			if a.Destination.ZoneID != b.Destination.ZoneID {
+				return a.Destination.ZoneID < b.Destination.ZoneID
+			}
+			return a.Index < b.Index
+		})
+		data := make([]interface{}, len(policies))
+		for i, policy := range policies {
+			data[i] = *policy
+		}
+		writeJSON(w, http.StatusOK, data)
+
+	case (r.Method == "POST" && id == "") || (r.Method == "PUT" && id != ""):
+		var policy types.FirewallPolicy
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if policy.Name == "" {
+			writeBadRequest(w, "Policy name is required")
+			return
+		}
+		if s.state.GetFirewallZone(policy.Source.ZoneID) == nil || s.state.GetFirewallZone(policy.Destination.ZoneID) == nil {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.FirewallZoneNotFound")
+			return
+		}
+		if id != "" {
+			existing := s.state.GetFirewallPolicy(id)
+			if existing == nil {
+				writeNotFound(w)
+				return
+			}
+			if existing.Predefined {
+				writeAPIError(w, http.StatusBadRequest, "error", "api.err.PredefinedPolicyCannotBeModified")
+				return
+			}
+			policy.ID = id
+		} else {
+			policy.ID = generateID()
+			policy.Predefined = false
+			if policy.Index == 0 {
+				policy.Index = s.nextPolicyIndex(policy.Source.ZoneID, policy.Destination.ZoneID)
+			}
+		}
+		s.state.AddFirewallPolicy(&policy)
+		writeJSON(w, http.StatusOK, policy)
+
+	default:
+		writeBadRequest(w, "Method not allowed")
+	}
+}
+
+// nextPolicyIndex returns the index after the last policy between two
+// zones. User-defined indexes start at 10000, like the controller's.
+func (s *Server) nextPolicyIndex(source, destination string) int {
+	next := 10000
+	for _, p := range s.state.ListFirewallPolicies() {
+		if p.Source.ZoneID == source && p.Destination.ZoneID == destination && p.Index >= next {
+			next = p.Index + 1
+		}
+	}
+	return next
+}
+
+// handleDeletePolicies deletes the policies whose IDs are in the body.
+func (s *Server) handleDeletePolicies(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		writeBadRequest(w, "Invalid JSON")
+		return
+	}
+	for _, id := range ids {
+		if p := s.state.GetFirewallPolicy(id); p != nil && p.Predefined {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.PredefinedPolicyCannotBeDeleted")
+			return
+		}
+	}
+	for _, id := range ids {
+		s.state.DeleteFirewallPolicy(id)
+	}
+	writeJSON(w, http.StatusOK, []interface{}{})
+}
+
+// handleReorderPolicies renumbers the listed policies between two zones in
+// the given order.
+func (s *Server) handleReorderPolicies(w http.ResponseWriter, r *http.Request) {
+	var order types.FirewallPolicyOrder
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		writeBadRequest(w, "Invalid JSON")
+		return
+	}
+
+	ids := append(append([]string(nil), order.BeforePredefinedIDs...), order.AfterPredefinedIDs...)
+	for _, id := range ids {
+		p := s.state.GetFirewallPolicy(id)
+		if p == nil || p.Predefined || p.Source.ZoneID != order.SourceZoneID || p.Destination.ZoneID != order.DestinationZoneID {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.InvalidPolicyOrder")
+			return
+		}
+	}
+
+	for i, id := range ids {
+		updated := *s.state.GetFirewallPolicy(id)
+		updated.Index = 10000 + i
+		s.state.AddFirewallPolicy(&updated)
+	}
+	writeJSON(w, http.StatusOK, []interface{}{})
+}
@@ -0,0 +1,187 @@
+package mock
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// handleHotspot routes hotspot requests: cmd/hotspot, stat/voucher and
+// rest/hotspotop.
+func (s *Server) handleHotspot(w http.ResponseWriter, r *http.Request, site string) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case strings.Contains(path, "/cmd/hotspot"):
+		s.handleHotspotCommand(w, r, site)
+	case strings.Contains(path, "/stat/voucher"):
+		s.handleListVouchers(w, r)
+	default:
+		_, id, _ := strings.Cut(path, "/rest/hotspotop")
+		s.handleHotspotOperators(w, r, site, strings.TrimPrefix(id, "/"))
+	}
+}
+
+// handleHotspotCommand creates and deletes vouchers.
+func (s *Server) handleHotspotCommand(w http.ResponseWriter, r *http.Request, site string) {
+	if r.Method != "POST" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	var cmd struct {
+		CMD    string `json:"cmd"`
+		ID     string `json:"_id"`
+		N      int    `json:"n"`
+		Expire int    `json:"expire"`
+		Quota  int    `json:"quota"`
+		Up     int    `json:"up"`
+		Down   int    `json:"down"`
+		Bytes  int64  `json:"bytes"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeBadRequest(w, "Invalid request body")
+		return
+	}
+
+	switch cmd.CMD {
+	case "create-voucher":
+		if cmd.N < 1 || cmd.Expire < 1 {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.InvalidPayload")
+			return
+		}
+
+		// Each batch gets its own creation time, which is how the
+		// client finds the vouchers it created.
+		created := time.Now().Unix()
+		for _, v := range s.state.ListHotspotVouchers() {
+			if v.CreateTime >= created {
+				created = v.CreateTime + 1
+			}
+		}
+
+		for i := 0; i < cmd.N; i++ {
+			code, _ := rand.Int(rand.Reader, big.NewInt(1e10))
+			s.state.AddHotspotVoucher(&types.Voucher{
+				ID:             generateID(),
+				SiteID:         site,
+				Code:           fmt.Sprintf("%010d", code),
+				CreateTime:     created,
+				Note:           cmd.Note,
+				Duration:       cmd.Expire,
+				Quota:          cmd.Quota,
+				QOSOverwrite:   cmd.Up > 0 || cmd.Down > 0 || cmd.Bytes > 0,
+				QOSRateMaxUp:   cmd.Up,
+				QOSRateMaxDown: cmd.Down,
+				QOSUsageQuota:  cmd.Bytes,
+				Status:         voucherStatus(cmd.Quota),
+			})
+		}
+		writeAPIResponse(w, []interface{}{map[string]int64{"create_time": created}})
+
+	case "delete-voucher":
+		if s.state.GetHotspotVoucher(cmd.ID) == nil {
+			writeAPIError(w, http.StatusBadRequest, "error", "api.err.NotFound")
+			return
+		}
+		s.state.DeleteHotspotVoucher(cmd.ID)
+		writeAPIResponse(w, []interface{}{})
+
+	default:
+		writeBadRequest(w, "Unknown command")
+	}
+}
+
+// voucherStatus returns the status of an unused voucher with quota.
+func voucherStatus(quota int) string {
+	if quota == 1 {
+		return "VALID_ONE"
+	}
+	return "VALID_MULTI"
+}
+
+// handleListVouchers lists vouchers, newest first, filtered by the
+// create_time of a POST body.
+func (s *Server) handleListVouchers(w http.ResponseWriter, r *http.Request) {
+	var filter struct {
+		CreateTime int64 `json:"create_time"`
+	}
+	if r.Method == "POST" {
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			writeBadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	vouchers := s.state.ListHotspotVouchers()
+	sort.Slice(vouchers, func(i, j int) bool {
+		if vouchers[i].CreateTime != vouchers[j].CreateTime {
+			return vouchers[i].CreateTime > vouchers[j].CreateTime
+		}
+		return vouchers[i].ID < vouchers[j].ID
+	})
+
+	data := []interface{}{}
+	for _, v := range vouchers {
+		if filter.CreateTime == 0 || v.CreateTime == filter.CreateTime {
+			data = append(data, *v)
+		}
+	}
+	writeAPIResponse(w, data)
+}
+
+// handleHotspotOperators lists, creates, updates and deletes operators.
+func (s *Server) handleHotspotOperators(w http.ResponseWriter, r *http.Request, site, id string) {
+	switch {
+	case r.Method == "GET" && id == "":
+		operators := s.state.ListHotspotOperators()
+		sort.Slice(operators, func(i, j int) bool { return operators[i].ID < operators[j].ID })
+		data := make([]interface{}, len(operators))
+		for i, op := range operators {
+			data[i] = *op
+		}
+		writeAPIResponse(w, data)
+
+	case (r.Method == "POST" && id == "") || (r.Method == "PUT" && id != ""):
+		var op types.HotspotOperator
+		if err := json.NewDecoder(r.Body).Decode(&op); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if op.Name == "" {
+			writeBadRequest(w, "Name is required")
+			return
+		}
+		if id != "" {
+			if s.state.GetHotspotOperator(id) == nil {
+				writeNotFound(w)
+				return
+			}
+			op.ID = id
+		} else {
+			op.ID = generateID()
+		}
+		op.SiteID = site
+		s.state.AddHotspotOperator(&op)
+		writeAPIResponse(w, []interface{}{op})
+
+	case r.Method == "DELETE" && id != "":
+		if s.state.GetHotspotOperator(id) == nil {
+			writeNotFound(w)
+			return
+		}
+		s.state.DeleteHotspotOperator(id)
+		writeAPIResponse(w, []interface{}{})
+
+	default:
+		writeBadRequest(w, "Method not allowed")
+	}
+}
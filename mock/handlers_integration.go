@@ -0,0 +1,265 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// integrationVersion is the application version reported by the
+// Integrations API info endpoint.
+const integrationVersion = "9.0.114"
+
+// handleIntegration serves the official Integrations API
+// (/proxy/network/integration/v1), which is enabled with WithIntegrationAPI.
+// It answers with plain JSON, not the legacy meta/data envelope.
+func (s *Server) handleIntegration(w http.ResponseWriter, r *http.Request) {
+	if !s.integration || s.classic {
+		writeNotFound(w)
+		return
+	}
+
+	_, rest, _ := strings.Cut(r.URL.Path, "/integration/v1/")
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+
+	if len(parts) == 1 && parts[0] == "info" && r.Method == "GET" {
+		writeJSON(w, http.StatusOK, types.IntegrationInfo{ApplicationVersion: integrationVersion})
+		return
+	}
+
+	if parts[0] != "sites" {
+		writeIntegrationError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != "GET" {
+			writeIntegrationError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		sites := []types.IntegrationSite{}
+		for _, site := range s.state.ListSites() {
+			sites = append(sites, types.IntegrationSite{ID: site.ID, InternalReference: site.Name, Name: site.Desc})
+		}
+		sort.Slice(sites, func(i, j int) bool { return sites[i].ID < sites[j].ID })
+		writeIntegrationPage(w, r, sites)
+		return
+	}
+
+	if _, ok := s.state.GetSite(parts[1]); !ok {
+		writeIntegrationError(w, http.StatusNotFound, "site not found")
+		return
+	}
+
+	switch {
+	case len(parts) >= 3 && parts[2] == "devices":
+		s.handleIntegrationDevices(w, r, parts[3:])
+	case len(parts) == 3 && parts[2] == "clients" && r.Method == "GET":
+		s.handleIntegrationClients(w, r)
+	case len(parts) >= 4 && parts[2] == "hotspot" && parts[3] == "vouchers":
+		s.handleIntegrationVouchers(w, r, parts[4:])
+	default:
+		writeIntegrationError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// handleIntegrationDevices lists devices, gets one by ID or runs a device
+// action.
+func (s *Server) handleIntegrationDevices(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		devices := []types.IntegrationDevice{}
+		for _, device := range s.state.ListDevices() {
+			devices = append(devices, integrationDevice(device))
+		}
+		sort.Slice(devices, func(i, j int) bool { return devices[i].ID < devices[j].ID })
+		writeIntegrationPage(w, r, devices)
+
+	case len(parts) == 1 && r.Method == "GET":
+		device, ok := s.state.GetDevice(parts[0])
+		if !ok {
+			writeIntegrationError(w, http.StatusNotFound, "device not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, integrationDevice(device))
+
+	case len(parts) == 2 && parts[1] == "actions" && r.Method == "POST":
+		if _, ok := s.state.GetDevice(parts[0]); !ok {
+			writeIntegrationError(w, http.StatusNotFound, "device not found")
+			return
+		}
+		var action struct {
+			Action string `json:"action"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&action); err != nil || action.Action != "RESTART" {
+			writeIntegrationError(w, http.StatusBadRequest, "unsupported action")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeIntegrationError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// handleIntegrationClients lists the clients seen in the last 5 minutes,
+// like stat/sta.
+func (s *Server) handleIntegrationClients(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().Unix()
+	clients := []types.IntegrationClient{}
+	for _, client := range s.state.ListClients() {
+		if client.LastSeen.IsZero() || now-client.LastSeen.Val.Unix() >= 300 {
+			continue
+		}
+		clientType := "WIRELESS"
+		if client.IsWired.Val {
+			clientType = "WIRED"
+		}
+		clients = append(clients, types.IntegrationClient{
+			ID:          client.ID,
+			Type:        clientType,
+			Name:        client.Name,
+			ConnectedAt: client.LastSeen.Val.Add(-time.Duration(client.Uptime.Int64()) * time.Second).UTC(),
+			IPAddress:   client.IP,
+			MACAddress:  client.MAC,
+		})
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	writeIntegrationPage(w, r, clients)
+}
+
+// handleIntegrationVouchers lists, creates and deletes hotspot vouchers.
+func (s *Server) handleIntegrationVouchers(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		vouchers := []types.IntegrationVoucher{}
+		for _, v := range s.state.ListVouchers() {
+			vouchers = append(vouchers, *v)
+		}
+		sort.Slice(vouchers, func(i, j int) bool { return vouchers[i].ID < vouchers[j].ID })
+		writeIntegrationPage(w, r, vouchers)
+
+	case len(parts) == 0 && r.Method == "POST":
+		var req types.IntegrationVoucherRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Count < 1 || req.Name == "" || req.TimeLimitMinutes < 1 {
+			writeIntegrationError(w, http.StatusBadRequest, "invalid voucher request")
+			return
+		}
+		created := make([]types.IntegrationVoucher, req.Count)
+		for i := range created {
+			v := &types.IntegrationVoucher{
+				ID:                   generateID(),
+				CreatedAt:            time.Now().UTC().Truncate(time.Second),
+				Name:                 req.Name,
+				Code:                 generateID()[:10],
+				AuthorizedGuestLimit: req.AuthorizedGuestLimit,
+				TimeLimitMinutes:     req.TimeLimitMinutes,
+				DataUsageLimitMBytes: req.DataUsageLimitMBytes,
+				RxRateLimitKbps:      req.RxRateLimitKbps,
+				TxRateLimitKbps:      req.TxRateLimitKbps,
+			}
+			s.state.AddVoucher(v)
+			created[i] = *v
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"vouchers": created})
+
+	case len(parts) == 1 && r.Method == "GET":
+		v := s.state.GetVoucher(parts[0])
+		if v == nil {
+			writeIntegrationError(w, http.StatusNotFound, "voucher not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+
+	case len(parts) == 1 && r.Method == "DELETE":
+		if s.state.GetVoucher(parts[0]) == nil {
+			writeIntegrationError(w, http.StatusNotFound, "voucher not found")
+			return
+		}
+		s.state.DeleteVoucher(parts[0])
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeIntegrationError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// integrationDevice converts a device to the Integrations API type.
+func integrationDevice(d *types.Device) types.IntegrationDevice {
+	device := types.IntegrationDevice{
+		ID:                d.ID,
+		Name:              d.Name,
+		Model:             d.Model,
+		Supported:         true,
+		MACAddress:        d.MAC,
+		IPAddress:         d.IP,
+		FirmwareVersion:   d.Version,
+		FirmwareUpdatable: d.Upgradable,
+	}
+
+	switch d.State {
+	case types.DeviceStateConnected:
+		device.State = types.IntegrationDeviceOnline
+	case types.DeviceStatePending:
+		device.State = types.IntegrationDevicePendingAdoption
+	case types.DeviceStateUpgrading:
+		device.State = types.IntegrationDeviceUpdating
+	case types.DeviceStateProvisioning:
+		device.State = types.IntegrationDeviceGettingReady
+	case types.DeviceStateAdopting:
+		device.State = types.IntegrationDeviceAdopting
+	default:
+		device.State = types.IntegrationDeviceOffline
+	}
+
+	switch d.Type {
+	case "uap":
+		device.Features = []string{"accessPoint"}
+	case "usw":
+		device.Features = []string{"switching"}
+	}
+	return device
+}
+
+// writeIntegrationPage writes one page of items, honoring the offset and
+// limit query parameters. The limit defaults to 25 and is capped at 200.
+// items must be in the same order on every request.
+func writeIntegrationPage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 || offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	writeJSON(w, http.StatusOK, types.IntegrationPage[T]{
+		Offset:     offset,
+		Limit:      limit,
+		Count:      end - offset,
+		TotalCount: len(items),
+		Data:       items[offset:end],
+	})
+}
+
+// writeIntegrationError writes an error in the Integrations API format.
+func writeIntegrationError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"statusCode": statusCode,
+		"statusName": strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_")),
+		"message":    message,
+	})
+}
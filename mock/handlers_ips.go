@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// handleIPSEvents returns the IPS events between the start and end of the
+// request body, in epoch milliseconds. Either bound may be omitted.
+func (s *Server) handleIPSEvents(w http.ResponseWriter, r *http.Request, site string) {
+	var query struct {
+		Start int64 `json:"start"`
+		End   int64 `json:"end"`
+	}
+	if r.Method == "POST" && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			writeBadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	data := []interface{}{}
+	for _, event := range s.state.ListIPSEvents() {
+		at := types.EpochTime(event.Time).UnixMilli()
+		if (query.Start != 0 && at < query.Start) || (query.End != 0 && at > query.End) {
+			continue
+		}
+		data = append(data, event)
+	}
+
+	writeAPIResponse(w, data)
+}
@@ -0,0 +1,102 @@
+package mock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// handleSiteMaps routes site map requests and map image uploads.
+func (s *Server) handleSiteMaps(w http.ResponseWriter, r *http.Request, site string) {
+	if strings.Contains(r.URL.Path, "/upload/map") {
+		s.handleUploadMapImage(w, r)
+		return
+	}
+
+	// Extract ID if present: /rest/map/{id}
+	parts := strings.Split(r.URL.Path, "/")
+	var id string
+	for i, part := range parts {
+		if part == "map" && i+1 < len(parts) && parts[i+1] != "" {
+			id = parts[i+1]
+			break
+		}
+	}
+
+	switch r.Method {
+	case "GET":
+		maps := s.state.ListSiteMaps()
+		data := make([]interface{}, 0, len(maps))
+		for _, m := range maps {
+			if id == "" || m.ID == id {
+				data = append(data, *m)
+			}
+		}
+		writeAPIResponse(w, data)
+	case "POST", "PUT":
+		if r.Method == "PUT" && (id == "" || s.state.GetSiteMap(id) == nil) {
+			writeNotFound(w)
+			return
+		}
+		var m types.SiteMap
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if m.Name == "" {
+			writeBadRequest(w, "Map name is required")
+			return
+		}
+		if m.Upload != "" && s.state.GetMapImage(m.Upload) == nil {
+			writeBadRequest(w, "Unknown map image")
+			return
+		}
+		if id != "" {
+			m.ID = id
+		} else if m.ID == "" {
+			m.ID = generateID()
+		}
+		m.SiteID = site
+		s.state.AddSiteMap(&m)
+		writeAPIResponse(w, []interface{}{m})
+	case "DELETE":
+		if id == "" || s.state.GetSiteMap(id) == nil {
+			writeNotFound(w)
+			return
+		}
+		s.state.DeleteSiteMap(id)
+		writeAPIResponse(w, []interface{}{})
+	default:
+		writeNotFound(w)
+	}
+}
+
+// handleUploadMapImage stores the "file" part of a multipart upload under
+// a generated name.
+func (s *Server) handleUploadMapImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeBadRequest(w, "Map image file required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil || len(data) == 0 {
+		writeBadRequest(w, "Map image file required")
+		return
+	}
+
+	name := generateID() + path.Ext(header.Filename)
+	s.state.AddMapImage(name, data)
+	writeAPIResponse(w, []interface{}{map[string]string{"filename": name}})
+}
@@ -127,6 +127,12 @@ func (s *Server) handleDeleteNetwork(w http.ResponseWriter, r *http.Request, sit
 		return
 	}
 
+	// Like the controller, refuse while WLANs or fixed IPs use the network.
+	if refs := s.state.NetworkReferences(id); len(refs) > 0 {
+		writeAPIError(w, http.StatusBadRequest, "error", "api.err.ObjectReferredBy")
+		return
+	}
+
 	s.state.DeleteNetwork(id)
 
 	writeAPIResponse(w, []interface{}{})
@@ -0,0 +1,78 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// maxReportRows bounds the rows of one report.
+const maxReportRows = 10000
+
+// handleReports serves stat/report/{interval}.{type} with synthetic data:
+// one row per interval from start to end (per MAC for AP, user and gateway
+// reports with macs), and every requested metric of row i set to
+// (i+1)*1000.
+func (s *Server) handleReports(w http.ResponseWriter, r *http.Request, site string) {
+	if r.Method != "POST" {
+		writeBadRequest(w, "Method not allowed")
+		return
+	}
+
+	interval, kind, ok := strings.Cut(path.Base(r.URL.Path), ".")
+	step := types.ReportInterval(interval).Duration()
+	if !ok || step == 0 {
+		writeBadRequest(w, "Unknown report interval")
+		return
+	}
+	switch types.ReportType(kind) {
+	case types.ReportSite, types.ReportAP, types.ReportUser, types.ReportGateway:
+	default:
+		writeBadRequest(w, "Unknown report type")
+		return
+	}
+
+	var query struct {
+		Attrs []string `json:"attrs"`
+		Start int64    `json:"start"`
+		End   int64    `json:"end"`
+		MACs  []string `json:"macs"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		writeBadRequest(w, "Invalid JSON")
+		return
+	}
+
+	owners := []string{""}
+	if kind != string(types.ReportSite) && len(query.MACs) > 0 {
+		owners = query.MACs
+	}
+
+	data := []interface{}{}
+	start := time.UnixMilli(query.Start).Truncate(step)
+	for i, t := 0, start; t.UnixMilli() < query.End && len(data) < maxReportRows; i, t = i+1, t.Add(step) {
+		for _, owner := range owners {
+			row := types.ReportRow{Time: t.UnixMilli(), OID: site, Metrics: make(map[string]float64)}
+			switch types.ReportType(kind) {
+			case types.ReportAP:
+				row.AP = owner
+			case types.ReportUser:
+				row.User = owner
+			case types.ReportGateway:
+				row.Gateway = owner
+			}
+			for _, attr := range query.Attrs {
+				if attr != "time" {
+					row.Metrics[attr] = float64(i+1) * 1000
+				}
+			}
+			data = append(data, row)
+		}
+	}
+
+	writeAPIResponse(w, data)
+}
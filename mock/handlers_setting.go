@@ -2,6 +2,7 @@ package mock
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 
@@ -24,6 +25,29 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request, site str
 		return
 	}
 
+	// Legacy setting endpoints: /get/setting/{key} and /set/setting/{key}.
+	// Controller-wide (super_*) settings are only reachable through these.
+	if strings.Contains(path, "/get/setting/") || strings.Contains(path, "/set/setting/") {
+		key := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case strings.Contains(path, "/get/setting/") && r.Method == "GET":
+			if strings.HasPrefix(key, "super_") {
+				s.handleGetSuperSetting(w, key)
+			} else {
+				s.handleGetSetting(w, r, site, key)
+			}
+		case strings.Contains(path, "/set/setting/") && r.Method == "POST":
+			if strings.HasPrefix(key, "super_") {
+				s.handleSetSuperSetting(w, r, key)
+			} else {
+				s.handleUpdateSetting(w, r, site, key)
+			}
+		default:
+			writeNotFound(w)
+		}
+		return
+	}
+
 	// Setting endpoints: /rest/setting/{key}
 	if strings.Contains(path, "/rest/setting") {
 		parts := strings.Split(path, "/")
@@ -57,19 +81,44 @@ func (s *Server) handleGetSetting(w http.ResponseWriter, r *http.Request, site,
 		return
 	}
 
-	writeAPIResponse(w, []interface{}{*setting})
+	writeAPIResponse(w, []interface{}{s.settingResponse(setting)})
+}
+
+// settingResponse returns a stored setting with its typed fields.
+func (s *Server) settingResponse(setting *types.Setting) interface{} {
+	fields := s.state.GetSettingFields(setting.Key)
+	if fields == nil {
+		return *setting
+	}
+	fields["key"] = setting.Key
+	if setting.ID != "" {
+		fields["_id"] = setting.ID
+	}
+	if setting.SiteID != "" {
+		fields["site_id"] = setting.SiteID
+	}
+	return fields
 }
 
 // handleUpdateSetting updates a setting.
 func (s *Server) handleUpdateSetting(w http.ResponseWriter, r *http.Request, site, key string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeBadRequest(w, "Invalid request body")
+		return
+	}
 	var setting types.Setting
-	if err := json.NewDecoder(r.Body).Decode(&setting); err != nil {
+	var fields map[string]interface{}
+	if json.Unmarshal(body, &setting) != nil || json.Unmarshal(body, &fields) != nil {
 		writeBadRequest(w, "Invalid request body")
 		return
 	}
 
-	// Ensure key matches
+	// Ensure key matches and keep the stored ID
 	setting.Key = key
+	if existing := s.state.GetSetting(key); existing != nil && setting.ID == "" {
+		setting.ID = existing.ID
+	}
 
 	// Set site ID if not provided
 	if setting.SiteID == "" {
@@ -77,9 +126,35 @@ func (s *Server) handleUpdateSetting(w http.ResponseWriter, r *http.Request, sit
 	}
 
 	s.state.UpdateSetting(&setting)
+	s.state.SetSettingFields(key, fields)
+	writeAPIResponse(w, []interface{}{s.settingResponse(&setting)})
+}
+
+// handleGetSuperSetting returns a controller-wide setting.
+func (s *Server) handleGetSuperSetting(w http.ResponseWriter, key string) {
+	setting := s.state.GetSuperSetting(key)
+	if setting == nil {
+		writeNotFound(w)
+		return
+	}
+
 	writeAPIResponse(w, []interface{}{setting})
 }
 
+// handleSetSuperSetting updates a controller-wide setting.
+func (s *Server) handleSetSuperSetting(w http.ResponseWriter, r *http.Request, key string) {
+	var fields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeBadRequest(w, "Invalid request body")
+		return
+	}
+	delete(fields, "site_id")
+	fields["key"] = key
+
+	s.state.SetSuperSetting(key, fields)
+	writeAPIResponse(w, []interface{}{s.state.GetSuperSetting(key)})
+}
+
 // handleRADIUSProfiles routes RADIUS profile requests.
 func (s *Server) handleRADIUSProfiles(w http.ResponseWriter, r *http.Request, site string) {
 	path := r.URL.Path
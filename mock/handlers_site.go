@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/unifi-go/gofi/types"
 )
@@ -94,8 +95,29 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request, site strin
 		{
 			Subsystem: "lan",
 			Status:    "ok",
-			NumSta:    5,
 		},
+		{
+			Subsystem: "wlan",
+			Status:    "ok",
+		},
+	}
+
+	// Count active clients (seen in last 5 minutes) like stat/sta
+	now := time.Now().Unix()
+	for _, client := range s.state.ListClients() {
+		if client.LastSeen.IsZero() || now-client.LastSeen.Val.Unix() >= 300 {
+			continue
+		}
+		h := &health[3]
+		if client.IsWired.Val {
+			h = &health[2]
+		}
+		if client.IsGuest.Val {
+			h.NumGuest++
+		} else {
+			h.NumUser++
+		}
+		h.NumSta++
 	}
 
 	// Convert to interface slice
@@ -1,9 +1,11 @@
 package mock
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +28,12 @@ func (s *Server) handleSystem(w http.ResponseWriter, r *http.Request, site strin
 		return
 	}
 
+	// Backup download: /dl/backup/{filename}
+	if strings.Contains(path, "/dl/backup/") {
+		s.handleBackupDownload(w, r, path[strings.LastIndex(path, "/")+1:])
+		return
+	}
+
 	// Admin list: /api/stat/admin
 	if strings.Contains(path, "/api/stat/admin") {
 		s.handleAdminList(w, r)
@@ -46,7 +54,7 @@ func (s *Server) handleSystem(w http.ResponseWriter, r *http.Request, site strin
 	writeNotFound(w)
 }
 
-// handleReboot handles system reboot command.
+// handleReboot handles the system reboot and poweroff commands.
 func (s *Server) handleReboot(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		writeNotFound(w)
@@ -71,7 +79,7 @@ func (s *Server) handleReboot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Cmd != "reboot" {
+	if req.Cmd != "reboot" && req.Cmd != "poweroff" {
 		writeBadRequest(w, "Invalid command")
 		return
 	}
@@ -130,7 +138,7 @@ func (s *Server) handleBackupCreate(w http.ResponseWriter, r *http.Request) {
 	backup := &types.Backup{
 		Filename: filename,
 		Size:     1024 * 1024, // 1MB mock size
-		Time:     now.Unix(),
+		Time:     types.NewFlexTime(now.Unix()),
 		Datetime: now.Format(time.RFC3339),
 	}
 
@@ -144,6 +152,37 @@ func (s *Server) handleBackupDelete(w http.ResponseWriter, r *http.Request, file
 	writeAPIResponse(w, []interface{}{})
 }
 
+// handleBackupDownload serves the contents of a backup file. The contents
+// are generated from the filename so repeated downloads are identical.
+func (s *Server) handleBackupDownload(w http.ResponseWriter, r *http.Request, filename string) {
+	if r.Method != "GET" {
+		writeNotFound(w)
+		return
+	}
+
+	var backup *types.Backup
+	for _, b := range s.state.ListBackups() {
+		if b.Filename == filename {
+			backup = b
+			break
+		}
+	}
+	if backup == nil {
+		writeNotFound(w)
+		return
+	}
+
+	data := make([]byte, backup.Size)
+	seed := sha256.Sum256([]byte(backup.Filename))
+	for i := range data {
+		data[i] = seed[i%len(seed)]
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(backup.Size, 10))
+	w.Write(data)
+}
+
 // handleAdminList returns all admin users.
 func (s *Server) handleAdminList(w http.ResponseWriter, r *http.Request) {
 	admins := s.state.ListAdmins()
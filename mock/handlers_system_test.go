@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
 
@@ -47,7 +48,7 @@ func TestHandleBackupList(t *testing.T) {
 	server.state.AddBackup(&types.Backup{
 		Filename: "backup1.unf",
 		Size:     1024,
-		Time:     1234567890,
+		Time:     types.NewFlexTime(1234567890),
 	})
 
 	// Test list backups
@@ -105,7 +106,7 @@ func TestHandleBackupDelete(t *testing.T) {
 	server.state.AddBackup(&types.Backup{
 		Filename: "backup1.unf",
 		Size:     1024,
-		Time:     1234567890,
+		Time:     types.NewFlexTime(1234567890),
 	})
 
 	// Delete backup
@@ -127,6 +128,51 @@ func TestHandleBackupDelete(t *testing.T) {
 	}
 }
 
+func TestHandleBackupDownload(t *testing.T) {
+	server := NewServer(WithoutAuth(), WithoutCSRF())
+	defer server.Close()
+
+	server.state.AddBackup(&types.Backup{
+		Filename: "backup1.unf",
+		Size:     1024,
+		Time:     types.NewFlexTime(1234567890),
+	})
+
+	get := func() []byte {
+		t.Helper()
+		req, _ := http.NewRequest("GET", server.URL()+"/proxy/network/dl/backup/backup1.unf", nil)
+		resp, err := testSystemHTTPClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to download backup: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+		data, _ := io.ReadAll(resp.Body)
+		return data
+	}
+
+	first := get()
+	if len(first) != 1024 {
+		t.Errorf("Expected 1024 bytes, got %d", len(first))
+	}
+	if !bytes.Equal(first, get()) {
+		t.Error("Expected repeated downloads to be identical")
+	}
+
+	req, _ := http.NewRequest("GET", server.URL()+"/proxy/network/dl/backup/missing.unf", nil)
+	resp, err := testSystemHTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to request missing backup: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+}
+
 func TestHandleAdminList(t *testing.T) {
 	server := NewServer(WithoutAuth(), WithoutCSRF())
 	defer server.Close()
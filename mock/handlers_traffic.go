@@ -57,12 +57,13 @@ func (s *Server) handleListTrafficRules(w http.ResponseWriter, r *http.Request,
 
 	rules := s.state.ListTrafficRules()
 
+	// Like the controller's v2 API, return a plain array, not an envelope
 	data := make([]interface{}, len(rules))
 	for i, rule := range rules {
 		data[i] = *rule
 	}
 
-	writeAPIResponse(w, data)
+	writeJSON(w, http.StatusOK, data)
 }
 
 // handleGetTrafficRule returns a specific traffic rule by ID.
@@ -78,7 +79,7 @@ func (s *Server) handleGetTrafficRule(w http.ResponseWriter, r *http.Request, si
 		return
 	}
 
-	writeAPIResponse(w, []interface{}{*rule})
+	writeJSON(w, http.StatusOK, rule)
 }
 
 // handleCreateTrafficRule creates a new traffic rule.
@@ -108,7 +109,7 @@ func (s *Server) handleCreateTrafficRule(w http.ResponseWriter, r *http.Request,
 
 	s.state.AddTrafficRule(&rule)
 
-	writeAPIResponse(w, []interface{}{rule})
+	writeJSON(w, http.StatusOK, rule)
 }
 
 // handleUpdateTrafficRule updates an existing traffic rule.
@@ -138,7 +139,7 @@ func (s *Server) handleUpdateTrafficRule(w http.ResponseWriter, r *http.Request,
 	s.state.UpdateTrafficRule(&rule)
 
 	// Note: v2 API returns 201 for PUT operations
-	writeAPIResponseWithStatus(w, []interface{}{rule}, http.StatusCreated)
+	writeJSON(w, http.StatusCreated, rule)
 }
 
 // handleDeleteTrafficRule deletes a traffic rule.
@@ -18,6 +18,12 @@ func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, site string
 		return
 	}
 
+	// Lookup by MAC: /stat/user/<mac>
+	if i := strings.Index(path, "/stat/user/"); i >= 0 && r.Method == "GET" {
+		s.handleGetUserByMAC(w, r, site, path[i+len("/stat/user/"):])
+		return
+	}
+
 	// User endpoints: /rest/user
 	if strings.Contains(path, "/rest/user") {
 		parts := strings.Split(path, "/")
@@ -59,13 +65,17 @@ func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, site string
 	writeNotFound(w)
 }
 
-// handleListUsers returns all users.
+// handleListUsers returns all users, or with a mac query only the users
+// with that MAC address, as the controller's REST field filter does.
 func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request, site string) {
 	users := s.state.ListKnownClients()
+	mac := r.URL.Query().Get("mac")
 
-	data := make([]interface{}, len(users))
-	for i, user := range users {
-		data[i] = *user
+	data := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		if mac == "" || strings.EqualFold(user.MAC, mac) {
+			data = append(data, *user)
+		}
 	}
 
 	writeAPIResponse(w, data)
@@ -82,6 +92,19 @@ func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request, site, id
 	writeAPIResponse(w, []interface{}{*user})
 }
 
+// handleGetUserByMAC returns the user with a MAC address, or
+// api.err.UnknownUser as the controller does for MACs it has never seen.
+func (s *Server) handleGetUserByMAC(w http.ResponseWriter, r *http.Request, site, mac string) {
+	for _, user := range s.state.ListKnownClients() {
+		if strings.EqualFold(user.MAC, mac) {
+			writeAPIResponse(w, []interface{}{*user})
+			return
+		}
+	}
+
+	writeAPIError(w, http.StatusBadRequest, "error", "api.err.UnknownUser")
+}
+
 // handleCreateUser creates a new user.
 func (s *Server) handleCreateUser(w http.ResponseWriter, r *http.Request, site string) {
 	var user types.User
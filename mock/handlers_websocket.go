@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/unifi-go/gofi/types"
@@ -19,6 +20,9 @@ var upgrader = websocket.Upgrader{
 type wsConnection struct {
 	conn *websocket.Conn
 	site string
+
+	// system is true for connections to the UniFi OS event bus.
+	system bool
 }
 
 // handleWebSocket handles WebSocket connections for event streaming.
@@ -48,6 +52,30 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleSystemWebSocket handles WebSocket connections to the UniFi OS
+// event bus.
+func (s *Server) handleSystemWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	wsConn := &wsConnection{
+		conn:   conn,
+		system: true,
+	}
+
+	s.addWebSocketConnection(wsConn)
+	defer s.removeWebSocketConnection(wsConn)
+
+	for {
+		_, _, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+	}
+}
+
 var (
 	wsConnections   = make(map[*wsConnection]bool)
 	wsConnectionsMu sync.RWMutex
@@ -81,20 +109,69 @@ func (s *Server) BroadcastEvent(event *types.Event) {
 
 	for _, conn := range connections {
 		// Only send to connections for the same site
+		if conn.system {
+			continue
+		}
 		if conn.site == "" || conn.site == event.SiteID {
 			_ = conn.conn.WriteMessage(websocket.TextMessage, data)
 		}
 	}
 }
 
+// BroadcastSystemEvent broadcasts a message to all clients connected to
+// the UniFi OS event bus.
+func (s *Server) BroadcastSystemEvent(msg *types.SystemMessage) {
+	wsConnectionsMu.RLock()
+	connections := make([]*wsConnection, 0, len(wsConnections))
+	for conn := range wsConnections {
+		if conn.system {
+			connections = append(connections, conn)
+		}
+	}
+	wsConnectionsMu.RUnlock()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	for _, conn := range connections {
+		_ = conn.conn.WriteMessage(websocket.TextMessage, data)
+	}
+}
+
+// SimulateApplicationStateChange simulates a UniFi OS application
+// changing state, for example "network" becoming "running".
+func (s *Server) SimulateApplicationStateChange(app, state string) {
+	s.BroadcastSystemEvent(&types.SystemMessage{
+		Type:    "APPLICATION_STATE_CHANGED",
+		App:     app,
+		Message: app + " is " + state,
+	})
+}
+
 // SimulateClientConnect simulates a client connection event.
 func (s *Server) SimulateClientConnect(site string, client *types.Client) {
 	event := &types.Event{
 		Key:     "EVT_WU_Connected",
 		SiteID:  site,
-		Time:    0,
+		Time:    types.NewFlexTime(0),
 		Message: "User connected",
 	}
+	if client != nil {
+		medium, role := "W", "U"
+		if client.IsWired.Val {
+			medium = "L"
+		}
+		if client.IsGuest.Val {
+			role = "G"
+		}
+		event.Key = "EVT_" + medium + role + "_Connected"
+		event.User = client.MAC
+		event.Hostname = client.Hostname
+		event.SSID = client.ESSID
+		event.AP = client.APMA
+	}
 	s.BroadcastEvent(event)
 }
 
@@ -103,29 +180,77 @@ func (s *Server) SimulateClientDisconnect(site, mac string) {
 	event := &types.Event{
 		Key:     "EVT_WU_Disconnected",
 		SiteID:  site,
-		Time:    0,
+		Time:    types.NewFlexTime(0),
 		Message: "User disconnected",
+		User:    mac,
 	}
 	s.BroadcastEvent(event)
 }
 
-// SimulateDeviceUpdate simulates a device update event.
+// SimulateDeviceUpdate simulates a device update event, followed by a
+// device:sync message with the device's full state.
 func (s *Server) SimulateDeviceUpdate(site string, device *types.Device) {
 	event := &types.Event{
 		Key:     "EVT_AP_Updated",
 		SiteID:  site,
-		Time:    0,
+		Time:    types.NewFlexTime(0),
 		Message: "Device updated",
 	}
 	s.BroadcastEvent(event)
+
+	if device != nil {
+		s.BroadcastMessage(site, types.WebSocketMessageDeviceSync, device)
+	}
 }
 
-// SimulateAlarm simulates an alarm event.
+// BroadcastMessage broadcasts a message in the controller's envelope form,
+// such as a device:update with only some fields of a device, to the
+// clients connected to a site's WebSocket.
+func (s *Server) BroadcastMessage(site, message string, data ...interface{}) {
+	if data == nil {
+		data = []interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"meta": types.WebSocketMeta{RC: "ok", Message: message},
+		"data": data,
+	})
+	if err != nil {
+		return
+	}
+
+	wsConnectionsMu.RLock()
+	connections := make([]*wsConnection, 0, len(wsConnections))
+	for conn := range wsConnections {
+		if !conn.system && (conn.site == "" || conn.site == site) {
+			connections = append(connections, conn)
+		}
+	}
+	wsConnectionsMu.RUnlock()
+
+	for _, conn := range connections {
+		_ = conn.conn.WriteMessage(websocket.TextMessage, payload)
+	}
+}
+
+// SimulateAlarm records an active alarm, served by list/alarm, and
+// broadcasts an alarm event.
 func (s *Server) SimulateAlarm(site string, alarm *types.Alarm) {
+	if alarm != nil {
+		stored := *alarm
+		if stored.ID == "" {
+			stored.ID = generateID()
+		}
+		if stored.Time.IsZero() {
+			stored.Time = types.NewFlexTime(time.Now().UnixMilli())
+		}
+		stored.SiteID = site
+		s.state.AddAlarm(&stored)
+	}
+
 	event := &types.Event{
 		Key:     "EVT_AD_Alarm",
 		SiteID:  site,
-		Time:    0,
+		Time:    types.NewFlexTime(0),
 		Message: "Alarm triggered",
 	}
 	s.BroadcastEvent(event)
@@ -292,3 +292,72 @@ func (s *Server) handleDeleteWLANGroup(w http.ResponseWriter, r *http.Request, s
 
 	writeAPIResponse(w, []interface{}{})
 }
+
+// handleAPGroups routes AP group requests (v2 API).
+func (s *Server) handleAPGroups(w http.ResponseWriter, r *http.Request, site string) {
+	// Extract ID if present: /v2/api/site/{site}/apgroups/{id}
+	parts := strings.Split(r.URL.Path, "/")
+	var id string
+	for i, part := range parts {
+		if part == "apgroups" && i+1 < len(parts) && parts[i+1] != "" {
+			id = parts[i+1]
+			break
+		}
+	}
+
+	switch r.Method {
+	case "GET":
+		// Like the controller's v2 API, return a plain array, not an envelope
+		groups := s.state.ListAPGroups()
+		data := make([]interface{}, len(groups))
+		for i, group := range groups {
+			data[i] = *group
+		}
+		writeJSON(w, http.StatusOK, data)
+	case "POST":
+		var group types.APGroup
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		if group.Name == "" {
+			writeBadRequest(w, "AP group name is required")
+			return
+		}
+		if group.ID == "" {
+			group.ID = generateID()
+		}
+		s.state.AddAPGroup(&group)
+		writeJSON(w, http.StatusOK, group)
+	case "PUT":
+		if id == "" {
+			writeBadRequest(w, "AP group ID required for update")
+			return
+		}
+		if s.state.GetAPGroup(id) == nil {
+			writeNotFound(w)
+			return
+		}
+		var group types.APGroup
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+			writeBadRequest(w, "Invalid JSON")
+			return
+		}
+		group.ID = id
+		s.state.UpdateAPGroup(&group)
+		writeJSON(w, http.StatusOK, group)
+	case "DELETE":
+		if id == "" {
+			writeBadRequest(w, "AP group ID required for delete")
+			return
+		}
+		if s.state.GetAPGroup(id) == nil {
+			writeNotFound(w)
+			return
+		}
+		s.state.DeleteAPGroup(id)
+		writeJSON(w, http.StatusOK, []interface{}{})
+	default:
+		writeNotFound(w)
+	}
+}
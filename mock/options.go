@@ -17,6 +17,16 @@ func WithoutCSRF() Option {
 	}
 }
 
+// WithClassicController makes the server behave like a classic
+// (self-hosted) Network Application instead of a UniFi OS console: the API
+// is served without the /proxy/network prefix, login is at /api/login and
+// the start page redirects to /manage.
+func WithClassicController() Option {
+	return func(s *Server) {
+		s.classic = true
+	}
+}
+
 // WithFixtures loads fixtures into the server state.
 func WithFixtures(fixtures *Fixtures) Option {
 	return func(s *Server) {
@@ -32,3 +42,20 @@ func WithScenario(scenario Scenario) Option {
 		s.scenario = scenario
 	}
 }
+
+// WithIntegrationAPI serves the official Integrations API
+// (/proxy/network/integration/v1) from the server state. Without it the
+// server behaves like a console that predates the API and answers 404.
+func WithIntegrationAPI() Option {
+	return func(s *Server) {
+		s.integration = true
+	}
+}
+
+// WithAPIKey accepts requests carrying key in the X-API-KEY header in place
+// of a login session. Such requests need no CSRF token.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
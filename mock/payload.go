@@ -0,0 +1,184 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// maxPayloadDepth bounds the nesting of generated payloads.
+const maxPayloadDepth = 4
+
+var (
+	flexIntType    = reflect.TypeOf(types.FlexInt{})
+	flexBoolType   = reflect.TypeOf(types.FlexBool{})
+	flexStringType = reflect.TypeOf(types.FlexString{})
+	flexTimeType   = reflect.TypeOf(types.FlexTime{})
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// RandomPayload returns the JSON encoding of a random value of v's type,
+// in the shapes real controllers send: numbers as numbers or strings,
+// booleans as true, "yes" or 1, epoch times in seconds or milliseconds,
+// optional fields present, null or missing, and unknown fields added.
+// Every payload decodes into v's type. The same r state gives the same
+// payload, so fuzz targets can derive payloads from a seed.
+func RandomPayload(r *rand.Rand, v any) []byte {
+	g := payloadGen{r: r}
+	data, err := json.Marshal(g.value(reflect.TypeOf(v), 0))
+	if err != nil {
+		panic(fmt.Sprintf("mock: payload for %T: %v", v, err))
+	}
+	return data
+}
+
+// payloadGen generates one payload.
+type payloadGen struct {
+	r *rand.Rand
+}
+
+// pick returns one of the values at random.
+func (g payloadGen) pick(values ...any) any {
+	return values[g.r.Intn(len(values))]
+}
+
+// value returns a random value encoding as t.
+func (g payloadGen) value(t reflect.Type, depth int) any {
+	if t.Kind() == reflect.Pointer {
+		if depth >= maxPayloadDepth || g.r.Intn(4) == 0 {
+			return nil
+		}
+		return g.value(t.Elem(), depth)
+	}
+
+	n := g.r.Int63n(1 << 20)
+	switch t {
+	case flexIntType:
+		return g.pick(n, float64(n)/8, strconv.FormatInt(n, 10), "", nil, -n)
+	case flexBoolType:
+		return g.pick(true, false, "true", "FALSE", "yes", "on", "enabled", "off", "", 0, 1, nil)
+	case flexStringType:
+		return g.pick(g.text(), []string{}, []string{g.text()}, []string{g.text(), g.text()}, nil)
+	case flexTimeType:
+		sec := 1_500_000_000 + n*100
+		return g.pick(sec, sec*1000, strconv.FormatInt(sec, 10), 0, nil)
+	case timeType:
+		return time.Unix(1_500_000_000+n*100, 0).UTC().Format(time.RFC3339)
+	case rawMessageType:
+		return g.pick(n, g.text(), true, map[string]any{"k": g.text()}, nil)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return g.r.Intn(2) == 0
+	case reflect.Int, reflect.Int64:
+		return n - 1<<19
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return g.r.Intn(128) - 64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return g.r.Intn(256)
+	case reflect.Float32, reflect.Float64:
+		return float64(n) / 16
+	case reflect.String:
+		return g.text()
+	case reflect.Interface:
+		return g.pick(n, g.text(), false, nil, []any{g.text()})
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return []byte(g.text())
+		}
+		count := g.r.Intn(4)
+		if depth >= maxPayloadDepth {
+			count = 0
+		}
+		if t.Kind() == reflect.Array {
+			count = t.Len()
+		}
+		items := make([]any, count)
+		for i := range items {
+			items[i] = g.value(t.Elem(), depth+1)
+		}
+		return items
+	case reflect.Map:
+		m := make(map[string]any)
+		if t.Key().Kind() != reflect.String || depth >= maxPayloadDepth {
+			return m
+		}
+		for i := g.r.Intn(3); i > 0; i-- {
+			m[g.text()] = g.value(t.Elem(), depth+1)
+		}
+		return m
+	case reflect.Struct:
+		m := make(map[string]any)
+		g.fields(m, t, depth)
+		if g.r.Intn(4) == 0 {
+			// Newer controller releases add fields.
+			m["x_unknown_"+strconv.Itoa(g.r.Intn(100))] = g.pick(n, g.text(), nil)
+		}
+		return m
+	}
+	return nil
+}
+
+// fields adds random values for a struct's fields to m, flattening
+// embedded structs the way encoding/json does.
+func (g payloadGen) fields(m map[string]any, t reflect.Type, depth int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			g.fields(m, ft, depth)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		optional := strings.Contains(","+opts+",", ",omitempty,")
+		if optional && g.r.Intn(3) == 0 {
+			continue
+		}
+		v := g.value(f.Type, depth+1)
+		if strings.Contains(","+opts+",", ",string,") && v != nil {
+			// The ,string option quotes the encoded value.
+			encoded, _ := json.Marshal(v)
+			v = string(encoded)
+		}
+		m[name] = v
+	}
+}
+
+// payloadWords are mixed into generated strings: identifiers, addresses,
+// empty and non-ASCII text.
+var payloadWords = []string{
+	"", "default", "00:11:22:33:44:55", "aa:bb:cc:dd:ee:ff", "192.168.1.1",
+	"10.0.0.0/8", "any", "all", "5f0e6c4d8a1b2c3d4e5f6a7b", "wan", "lan",
+	"ng", "na", "6e", "auto", "Büro", "カメラ", " ", "a\"b", "0", "null",
+}
+
+// text returns a random string.
+func (g payloadGen) text() string {
+	if g.r.Intn(4) == 0 {
+		return strconv.Itoa(g.r.Intn(10000))
+	}
+	return payloadWords[g.r.Intn(len(payloadWords))]
+}
@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestRandomPayload(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		a := RandomPayload(rand.New(rand.NewSource(seed)), types.Device{})
+		b := RandomPayload(rand.New(rand.NewSource(seed)), types.Device{})
+		if !bytes.Equal(a, b) {
+			t.Fatalf("seed %d: payloads differ", seed)
+		}
+
+		var device types.Device
+		if err := json.Unmarshal(a, &device); err != nil {
+			t.Fatalf("seed %d: Unmarshal(%s): %v", seed, a, err)
+		}
+	}
+}
+
+// fuzzEndpoint is a write endpoint exercised by FuzzHandlers.
+type fuzzEndpoint struct {
+	path string
+	body any
+	// cmds are the commands of a cmd endpoint, set as "cmd".
+	cmds []string
+}
+
+// stamgrCommand is the body of a client command.
+type stamgrCommand struct {
+	MAC     string `json:"mac"`
+	Minutes int    `json:"minutes,omitempty"`
+	Up      int    `json:"up,omitempty"`
+	Down    int    `json:"down,omitempty"`
+	Bytes   int    `json:"bytes,omitempty"`
+	APMAC   string `json:"ap_mac,omitempty"`
+	DevID   int    `json:"dev_id,omitempty"`
+}
+
+var fuzzEndpoints = []fuzzEndpoint{
+	{path: "/rest/networkconf", body: types.Network{}},
+	{path: "/rest/wlanconf", body: types.WLAN{}},
+	{path: "/rest/wlangroup", body: types.WLANGroup{}},
+	{path: "/rest/firewallrule", body: types.FirewallRule{}},
+	{path: "/rest/firewallgroup", body: types.FirewallGroup{}},
+	{path: "/rest/portforward", body: types.PortForward{}},
+	{path: "/rest/portconf", body: types.PortProfile{}},
+	{path: "/rest/routing", body: types.Route{}},
+	{path: "/rest/user", body: types.User{}},
+	{path: "/rest/usergroup", body: types.UserGroup{}},
+	{path: "trafficrule", body: types.TrafficRule{}},
+	{path: "/rest/device", body: types.Device{}},
+	{path: "/cmd/devmgr", body: types.CommandRequest{}, cmds: []string{
+		"adopt", "adv-adopt", "restart", "force-provision", "upgrade", "upgrade-external",
+		"set-locate", "unset-locate", "power-cycle", "spectrum-scan", "migrate", "speedtest",
+	}},
+	{path: "/cmd/stamgr", body: stamgrCommand{}, cmds: []string{
+		"block-sta", "unblock-sta", "kick-sta", "forget-sta", "authorize-guest",
+		"unauthorize-guest", "set-sta-dev-id", "wol",
+	}},
+}
+
+// fuzzServe serves one request without a network round trip, so that a
+// handler panic fails the fuzz target.
+func fuzzServe(t *testing.T, s *Server, method, path string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code >= 500 {
+		t.Fatalf("%s %s with %s: status %d: %s", method, path, body, rec.Code, rec.Body)
+	}
+	if !json.Valid(rec.Body.Bytes()) {
+		t.Fatalf("%s %s with %s: invalid JSON response %q", method, path, body, rec.Body)
+	}
+	return rec
+}
+
+// FuzzHandlers sends random controller-shaped bodies to the write
+// endpoints of the mock server, creating, updating and reading back
+// objects, and checks that no handler panics or fails.
+func FuzzHandlers(f *testing.F) {
+	for i := range fuzzEndpoints {
+		f.Add(int64(i), uint8(i))
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, endpoint uint8) {
+		s := &Server{state: NewState()}
+		s.state.LoadFixtures(DefaultFixtures())
+		s.state.AddDevice(&types.Device{ID: "fuzz", MAC: "00:11:22:33:44:55", State: types.DeviceStateConnected})
+		s.state.AddClient(&types.Client{ID: "fuzz", MAC: "00:11:22:33:44:55"})
+
+		r := rand.New(rand.NewSource(seed))
+		e := fuzzEndpoints[int(endpoint)%len(fuzzEndpoints)]
+		base := "/proxy/network/api/s/default" + e.path
+		if e.path == "trafficrule" {
+			base = "/proxy/network/v2/api/site/default/trafficrule"
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(RandomPayload(r, e.body), &fields); err != nil {
+			t.Fatal(err)
+		}
+		if e.cmds != nil {
+			fields["cmd"] = e.cmds[r.Intn(len(e.cmds))]
+			body, _ := json.Marshal(fields)
+			fuzzServe(t, s, http.MethodPost, base, body)
+			return
+		}
+
+		fields["_id"] = "fuzz"
+		body, _ := json.Marshal(fields)
+		if e.path != "/rest/device" {
+			fuzzServe(t, s, http.MethodPost, base, body)
+		}
+		fuzzServe(t, s, http.MethodPut, base+"/fuzz", RandomPayload(r, e.body))
+		fuzzServe(t, s, http.MethodGet, base, nil)
+		fuzzServe(t, s, http.MethodDelete, base+"/fuzz", nil)
+	})
+}
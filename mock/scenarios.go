@@ -1,6 +1,9 @@
 package mock
 
-import "net/http"
+import (
+	"net/http"
+	"sync/atomic"
+)
 
 // Scenario defines a test scenario that modifies server behavior.
 type Scenario interface {
@@ -27,6 +30,34 @@ func (e *ErrorScenario) Apply(w http.ResponseWriter, r *http.Request) bool {
 	return true
 }
 
+// NthRequestScenario applies Scenario to the Nth request only, counting
+// from 1. Logins are not counted, so a client that logs in again after
+// the failure does not shift the count.
+type NthRequestScenario struct {
+	N        int
+	Scenario Scenario
+
+	count atomic.Int64
+}
+
+// Apply implements Scenario.
+func (n *NthRequestScenario) Apply(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Path {
+	case "/api/auth/login", "/api/login":
+		return false
+	}
+	if n.count.Add(1) != int64(n.N) {
+		return false
+	}
+	return n.Scenario.Apply(w, r)
+}
+
+// UnauthorizedOnRequest returns a scenario that answers the nth request
+// with 401, as if the session had expired.
+func UnauthorizedOnRequest(n int) *NthRequestScenario {
+	return &NthRequestScenario{N: n, Scenario: ScenarioSessionExpired}
+}
+
 // Predefined scenarios
 var (
 	// ScenarioSessionExpired simulates a session expiration.
@@ -78,3 +78,19 @@ func TestPredefinedScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestNthRequestScenario(t *testing.T) {
+	scenario := UnauthorizedOnRequest(2)
+
+	paths := []string{"/api/self", "/api/auth/login", "/api/self", "/api/self"}
+	want := []int{http.StatusOK, http.StatusOK, http.StatusUnauthorized, http.StatusOK}
+	for i, path := range paths {
+		w := httptest.NewRecorder()
+		if !scenario.Apply(w, httptest.NewRequest("GET", path, nil)) {
+			w.WriteHeader(http.StatusOK)
+		}
+		if w.Code != want[i] {
+			t.Errorf("request %d (%s): status = %d, want %d", i+1, path, w.Code, want[i])
+		}
+	}
+}
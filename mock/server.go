@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Server is a mock UniFi controller server.
@@ -18,6 +20,20 @@ type Server struct {
 	requireAuth bool
 	requireCSRF bool
 	scenario    Scenario
+	classic     bool
+	integration bool
+	apiKey      string
+
+	// Simulated command timing; see WithCommandDelays.
+	delays        *CommandDelays
+	timerMu       sync.Mutex
+	timers        map[string]*time.Timer
+	timersStopped bool
+
+	// Injected auth failures; see ExpireSessionAfter and InvalidateCSRF.
+	authMu      sync.Mutex
+	expireAfter int
+	csrfRefresh map[string]bool
 }
 
 // NewServer creates a new mock server.
@@ -26,6 +42,8 @@ func NewServer(opts ...Option) *Server {
 		state:       NewState(),
 		requireAuth: true,
 		requireCSRF: true,
+		expireAfter: -1,
+		csrfRefresh: make(map[string]bool),
 	}
 
 	// Apply options
@@ -55,38 +73,62 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Route requests
 	path := r.URL.Path
 
+	// Start page (no auth required), used to detect the console type
+	if path == "/" {
+		s.handleRoot(w, r)
+		return
+	}
+
+	// A classic controller serves the API at the root
+	if s.classic && (strings.HasPrefix(path, "/proxy/network/") || strings.HasPrefix(path, "/api/auth/")) {
+		writeNotFound(w)
+		return
+	}
+
 	// Auth endpoints (no auth required)
-	if path == "/api/auth/login" {
+	if path == "/api/auth/login" || (s.classic && path == "/api/login") {
 		s.handleLogin(w, r)
 		return
 	}
 
-	if path == "/api/logout" {
+	if path == "/api/auth/logout" || path == "/api/logout" {
 		s.handleLogout(w, r)
 		return
 	}
 
-	if path == "/api/status" {
+	if path == "/api/status" || (s.classic && path == "/status") {
 		s.handleStatus(w, r)
 		return
 	}
 
-	// WebSocket endpoint
+	// All other endpoints require authentication, by session or API key
+	keyed := s.hasAPIKey(r)
+	if s.requireAuth && !keyed {
+		if s.expireSession(r) || !s.isAuthenticated(r) {
+			writeUnauthorized(w)
+			return
+		}
+	}
+
+	// WebSocket endpoints, which like a real controller refuse upgrades
+	// without a session or API key
 	if strings.Contains(path, "/wss/") && strings.Contains(path, "/events") {
 		s.handleWebSocket(w, r)
 		return
 	}
 
-	// All other endpoints require authentication
-	if s.requireAuth {
-		if !s.isAuthenticated(r) {
-			writeUnauthorized(w)
-			return
-		}
+	if path == "/api/ws/system" {
+		s.handleSystemWebSocket(w, r)
+		return
+	}
+
+	// Deliver a rotated CSRF token, even with a rejection
+	if !keyed {
+		s.sendRefreshedCSRF(w, r)
 	}
 
 	// Check CSRF token for non-GET requests
-	if s.requireCSRF && r.Method != "GET" && r.Method != "HEAD" {
+	if s.requireCSRF && !keyed && r.Method != "GET" && r.Method != "HEAD" {
 		if !s.validateCSRF(r) {
 			writeForbidden(w, "Invalid CSRF token")
 			return
@@ -98,6 +140,17 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if path == "/api/users/self" {
+		s.handleUsersSelf(w, r)
+		return
+	}
+
+	// Official Integrations API; see WithIntegrationAPI
+	if strings.Contains(path, "/integration/v1/") {
+		s.handleIntegration(w, r)
+		return
+	}
+
 	// Extract site from path for API calls
 	site := ""
 	parts := strings.Split(path, "/")
@@ -141,14 +194,38 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Zone-based firewall (v2 API)
+	if strings.Contains(path, "/v2/api/site/") && (strings.Contains(path, "/firewall/zone") || strings.Contains(path, "/firewall-policies")) {
+		s.handleFirewallZones(w, r, site)
+		return
+	}
+
+	// Static DNS (v2 API)
+	if strings.Contains(path, "/v2/api/site/") && strings.Contains(path, "/static-dns") {
+		s.handleDNSRecords(w, r, site)
+		return
+	}
+
+	// AP groups (v2 API)
+	if strings.Contains(path, "/v2/api/site/") && strings.Contains(path, "/apgroups") {
+		s.handleAPGroups(w, r, site)
+		return
+	}
+
+	// Hotspot vouchers and operators
+	if strings.Contains(path, "/cmd/hotspot") || strings.Contains(path, "/stat/voucher") || strings.Contains(path, "/rest/hotspotop") {
+		s.handleHotspot(w, r, site)
+		return
+	}
+
 	// Client/station endpoints
-	if strings.Contains(path, "/stat/sta") || strings.Contains(path, "/stat/alluser") || strings.Contains(path, "/cmd/stamgr") {
+	if strings.Contains(path, "/stat/sta") || strings.Contains(path, "/stat/alluser") || strings.Contains(path, "/stat/guest") || strings.Contains(path, "/cmd/stamgr") {
 		s.handleClients(w, r, site)
 		return
 	}
 
 	// User/known client endpoints
-	if strings.Contains(path, "/rest/user") {
+	if strings.Contains(path, "/rest/user") || strings.Contains(path, "/stat/user/") {
 		s.handleUsers(w, r, site)
 		return
 	}
@@ -166,15 +243,52 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Settings endpoints
-	if strings.Contains(path, "/rest/setting") || strings.Contains(path, "/rest/radiusprofile") || strings.Contains(path, "/rest/dynamicdns") {
+	if strings.Contains(path, "/rest/setting") || strings.Contains(path, "/rest/radiusprofile") || strings.Contains(path, "/rest/dynamicdns") ||
+		strings.Contains(path, "/get/setting/") || strings.Contains(path, "/set/setting/") {
 		s.handleSettings(w, r, site)
 		return
 	}
 
-	// System endpoints (reboot, backup, admin, speedtest)
+	// Site maps and map image uploads
+	if strings.Contains(path, "/rest/map") || strings.Contains(path, "/upload/map") {
+		s.handleSiteMaps(w, r, site)
+		return
+	}
+
+	// Historical reports
+	if strings.Contains(path, "/stat/report/") {
+		s.handleReports(w, r, site)
+		return
+	}
+
+	// Client anomalies
+	if strings.Contains(path, "/stat/anomalies") {
+		s.handleAnomalies(w, r, site)
+		return
+	}
+
+	// Alarms
+	if strings.Contains(path, "/list/alarm") || strings.Contains(path, "/stat/alarm") || strings.Contains(path, "/cmd/evtmgr") {
+		s.handleAlarms(w, r, site)
+		return
+	}
+
+	// Event history
+	if strings.Contains(path, "/stat/event") {
+		s.handleEventHistory(w, r, site)
+		return
+	}
+
+	// IPS events
+	if strings.Contains(path, "/stat/ips/event") {
+		s.handleIPSEvents(w, r, site)
+		return
+	}
+
+	// System endpoints (reboot, backup, backup download, admin, speedtest)
 	if strings.Contains(path, "/api/cmd/system") || strings.Contains(path, "/api/cmd/backup") ||
 	   strings.Contains(path, "/api/stat/admin") || strings.Contains(path, "/cmd/speedtest") ||
-	   strings.Contains(path, "/stat/speedtest") {
+	   strings.Contains(path, "/stat/speedtest") || strings.Contains(path, "/dl/backup/") {
 		s.handleSystem(w, r, site)
 		return
 	}
@@ -194,6 +308,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // Close shuts down the server.
 func (s *Server) Close() {
+	s.stopTimers()
 	if s.server != nil {
 		s.server.Close()
 	}
@@ -245,6 +360,11 @@ func (s *Server) isAuthenticated(r *http.Request) bool {
 	return exists
 }
 
+// hasAPIKey checks if the request carries the key set by WithAPIKey.
+func (s *Server) hasAPIKey(r *http.Request) bool {
+	return s.apiKey != "" && r.Header.Get("X-API-KEY") == s.apiKey
+}
+
 // validateCSRF validates the CSRF token.
 func (s *Server) validateCSRF(r *http.Request) bool {
 	// Get CSRF token from header
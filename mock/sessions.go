@@ -0,0 +1,71 @@
+package mock
+
+import "net/http"
+
+// ExpireSessionAfter makes a session expire while a request is in
+// flight: after n more authenticated requests, the session of the next
+// one is deleted and the request is answered with 401. Later requests
+// with that session fail too, until the client logs in again.
+func (s *Server) ExpireSessionAfter(n int) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+	s.expireAfter = n
+}
+
+// InvalidateCSRF gives every session a new CSRF token, so requests with
+// the old one are rejected with 403. With refresh, the new token is sent
+// in the X-CSRF-Token header of each session's next response, as UniFi
+// OS does; without it, the client must log in again to get one.
+func (s *Server) InvalidateCSRF(refresh bool) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+	for _, token := range s.state.SessionTokens() {
+		session, ok := s.state.GetSession(token)
+		if !ok {
+			continue
+		}
+		s.state.CreateSession(token, &Session{Username: session.Username, CSRFToken: generateCSRFToken()})
+		if refresh {
+			s.csrfRefresh[token] = true
+		}
+	}
+}
+
+// expireSession counts down ExpireSessionAfter and, when it runs out,
+// deletes the session of r and reports true.
+func (s *Server) expireSession(r *http.Request) bool {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+	if s.expireAfter < 0 || !s.isAuthenticated(r) {
+		return false
+	}
+	if s.expireAfter > 0 {
+		s.expireAfter--
+		return false
+	}
+
+	s.expireAfter = -1
+	if cookie, err := r.Cookie("unifises"); err == nil {
+		s.state.DeleteSession(cookie.Value)
+	}
+	return true
+}
+
+// sendRefreshedCSRF sets the X-CSRF-Token header to the session's new
+// token if InvalidateCSRF rotated it and it was not delivered yet.
+func (s *Server) sendRefreshedCSRF(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie("unifises")
+	if err != nil {
+		return
+	}
+
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+	if !s.csrfRefresh[cookie.Value] {
+		return
+	}
+	delete(s.csrfRefresh, cookie.Value)
+	if session, ok := s.state.GetSession(cookie.Value); ok {
+		w.Header().Set("X-CSRF-Token", session.CSRFToken)
+	}
+}
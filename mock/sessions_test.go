@@ -0,0 +1,86 @@
+package mock
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+)
+
+// loggedInClient logs in to server and returns a client with the session
+// cookie and the CSRF token.
+func loggedInClient(t *testing.T, server *Server) (*http.Client, string) {
+	t.Helper()
+
+	client := newTestClient()
+	client.Jar, _ = cookiejar.New(nil)
+	resp, err := client.Post(server.URL()+"/api/auth/login", "application/json",
+		bytes.NewReader([]byte(`{"username":"admin","password":"admin"}`)))
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	resp.Body.Close()
+	return client, resp.Header.Get("X-CSRF-Token")
+}
+
+// do sends a request with the CSRF token and returns the response status
+// and X-CSRF-Token header.
+func do(t *testing.T, client *http.Client, method, url, csrf string) (int, string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(method, url, bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("X-CSRF-Token", csrf)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, resp.Header.Get("X-CSRF-Token")
+}
+
+func TestServer_ExpireSessionAfter(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, csrf := loggedInClient(t, server)
+	url := server.URL() + "/proxy/network/api/s/default/stat/device"
+
+	server.ExpireSessionAfter(1)
+	for i, want := range []int{http.StatusOK, http.StatusUnauthorized, http.StatusUnauthorized} {
+		if status, _ := do(t, client, "GET", url, csrf); status != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, status, want)
+		}
+	}
+
+	client, csrf = loggedInClient(t, server)
+	if status, _ := do(t, client, "GET", url, csrf); status != http.StatusOK {
+		t.Errorf("after login again: status = %d, want 200", status)
+	}
+}
+
+func TestServer_InvalidateCSRF(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	url := server.URL() + "/proxy/network/api/s/default/rest/networkconf"
+
+	t.Run("refresh", func(t *testing.T) {
+		client, csrf := loggedInClient(t, server)
+		server.InvalidateCSRF(true)
+
+		status, updated := do(t, client, "POST", url, csrf)
+		if status != http.StatusForbidden || updated == "" || updated == csrf {
+			t.Fatalf("stale token: status %d, new token %q, want 403 with a new token", status, updated)
+		}
+		if status, again := do(t, client, "POST", url, updated); status == http.StatusForbidden || again != "" {
+			t.Errorf("new token: status %d, token %q, want accepted without another token", status, again)
+		}
+	})
+
+	t.Run("no refresh", func(t *testing.T) {
+		client, csrf := loggedInClient(t, server)
+		server.InvalidateCSRF(false)
+
+		if status, updated := do(t, client, "POST", url, csrf); status != http.StatusForbidden || updated != "" {
+			t.Errorf("stale token: status %d, new token %q, want 403 without a token", status, updated)
+		}
+	})
+}
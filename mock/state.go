@@ -1,7 +1,10 @@
 package mock
 
 import (
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/unifi-go/gofi/types"
 )
@@ -20,21 +23,38 @@ type State struct {
 	networks     map[string]*types.Network
 	wlans        map[string]*types.WLAN
 	wlanGroups   map[string]*types.WLANGroup
+	apGroups     map[string]*types.APGroup
 	firewallRules map[string]*types.FirewallRule
 	firewallGroups map[string]*types.FirewallGroup
 	trafficRules map[string]*types.TrafficRule
 	clients      map[string]*types.Client
+	guests       map[string]*types.Guest
 	users        map[string]*types.User
 	userGroups   map[string]*types.UserGroup
 	routes         map[string]*types.Route
 	portForwards   map[string]*types.PortForward
 	portProfiles   map[string]*types.PortProfile
 	settings         map[string]*types.Setting
+	settingFields    map[string]map[string]interface{}
+	superSettings    map[string]map[string]interface{}
 	radiusProfiles   map[string]*types.RADIUSProfile
 	dynamicDNS       *types.DynamicDNS
 	backups          []*types.Backup
 	admins           []*types.AdminUser
 	speedTestStatus  *types.SpeedTestStatus
+	wakeOnLAN        []string
+	ipsEvents        []types.IPSEvent
+	events           []types.Event
+	alarms           map[string]*types.Alarm
+	anomalies        []types.Anomaly
+	siteMaps         map[string]*types.SiteMap
+	mapImages        map[string][]byte
+	vouchers         map[string]*types.IntegrationVoucher
+	firewallZones    map[string]*types.FirewallZone
+	firewallPolicies map[string]*types.FirewallPolicy
+	dnsRecords       map[string]*types.DNSRecord
+	hotspotVouchers  map[string]*types.Voucher
+	hotspotOperators map[string]*types.HotspotOperator
 }
 
 // Session represents a mock authentication session.
@@ -53,16 +73,29 @@ func NewState() *State {
 		networks:           make(map[string]*types.Network),
 		wlans:              make(map[string]*types.WLAN),
 		wlanGroups:         make(map[string]*types.WLANGroup),
+		apGroups:           make(map[string]*types.APGroup),
+		siteMaps:           make(map[string]*types.SiteMap),
+		mapImages:          make(map[string][]byte),
+		vouchers:           make(map[string]*types.IntegrationVoucher),
+		firewallZones:      defaultFirewallZones(),
+		firewallPolicies:   make(map[string]*types.FirewallPolicy),
+		dnsRecords:         make(map[string]*types.DNSRecord),
+		alarms:             make(map[string]*types.Alarm),
+		hotspotVouchers:    make(map[string]*types.Voucher),
+		hotspotOperators:   make(map[string]*types.HotspotOperator),
 		firewallRules:      make(map[string]*types.FirewallRule),
 		firewallGroups:     make(map[string]*types.FirewallGroup),
 		trafficRules:       make(map[string]*types.TrafficRule),
 		clients:            make(map[string]*types.Client),
+		guests:             make(map[string]*types.Guest),
 		users:              make(map[string]*types.User),
 		userGroups:         make(map[string]*types.UserGroup),
 		routes:             make(map[string]*types.Route),
 		portForwards:       make(map[string]*types.PortForward),
 		portProfiles:       make(map[string]*types.PortProfile),
 		settings:           make(map[string]*types.Setting),
+		settingFields:      make(map[string]map[string]interface{}),
+		superSettings:      make(map[string]map[string]interface{}),
 		radiusProfiles:     make(map[string]*types.RADIUSProfile),
 		backups:            make([]*types.Backup, 0),
 		admins:             make([]*types.AdminUser, 0),
@@ -92,21 +125,38 @@ func (s *State) Reset() {
 	s.networks = make(map[string]*types.Network)
 	s.wlans = make(map[string]*types.WLAN)
 	s.wlanGroups = make(map[string]*types.WLANGroup)
+	s.apGroups = make(map[string]*types.APGroup)
 	s.firewallRules = make(map[string]*types.FirewallRule)
 	s.firewallGroups = make(map[string]*types.FirewallGroup)
 	s.trafficRules = make(map[string]*types.TrafficRule)
 	s.clients = make(map[string]*types.Client)
+	s.guests = make(map[string]*types.Guest)
 	s.users = make(map[string]*types.User)
 	s.userGroups = make(map[string]*types.UserGroup)
 	s.routes = make(map[string]*types.Route)
 	s.portForwards = make(map[string]*types.PortForward)
 	s.portProfiles = make(map[string]*types.PortProfile)
 	s.settings = make(map[string]*types.Setting)
+	s.settingFields = make(map[string]map[string]interface{})
+	s.superSettings = make(map[string]map[string]interface{})
 	s.radiusProfiles = make(map[string]*types.RADIUSProfile)
 	s.dynamicDNS = nil
 	s.backups = make([]*types.Backup, 0)
 	s.admins = make([]*types.AdminUser, 0)
 	s.speedTestStatus = nil
+	s.wakeOnLAN = nil
+	s.ipsEvents = nil
+	s.events = nil
+	s.alarms = make(map[string]*types.Alarm)
+	s.anomalies = nil
+	s.siteMaps = make(map[string]*types.SiteMap)
+	s.mapImages = make(map[string][]byte)
+	s.vouchers = make(map[string]*types.IntegrationVoucher)
+	s.firewallZones = defaultFirewallZones()
+	s.firewallPolicies = make(map[string]*types.FirewallPolicy)
+	s.dnsRecords = make(map[string]*types.DNSRecord)
+	s.hotspotVouchers = make(map[string]*types.Voucher)
+	s.hotspotOperators = make(map[string]*types.HotspotOperator)
 
 	// Re-add default site
 	s.sites["default"] = &types.Site{
@@ -131,6 +181,19 @@ func (s *State) ValidateCredentials(username, password string) bool {
 	return exists && storedPassword == password
 }
 
+// ChangePassword sets a user's password and ends the user's sessions, as
+// a console does.
+func (s *State) ChangePassword(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authenticatedUsers[username] = password
+	for token, session := range s.sessions {
+		if session.Username == username {
+			delete(s.sessions, token)
+		}
+	}
+}
+
 // CreateSession creates a new session.
 func (s *State) CreateSession(token string, session *Session) {
 	s.mu.Lock()
@@ -146,6 +209,17 @@ func (s *State) GetSession(token string) (*Session, bool) {
 	return session, exists
 }
 
+// SessionTokens returns the tokens of all sessions.
+func (s *State) SessionTokens() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tokens := make([]string, 0, len(s.sessions))
+	for token := range s.sessions {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
 // DeleteSession removes a session.
 func (s *State) DeleteSession(token string) {
 	s.mu.Lock()
@@ -243,6 +317,27 @@ func (s *State) DeleteNetwork(id string) {
 	delete(s.networks, id)
 }
 
+// NetworkReferences returns the WLANs and fixed-IP clients that use the
+// network, as "wlanconf <id>" and "user <id>" entries. The controller
+// refuses to delete a network while any remain.
+func (s *State) NetworkReferences(id string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var refs []string
+	for _, wlan := range s.wlans {
+		if wlan.NetworkConfID == id {
+			refs = append(refs, "wlanconf "+wlan.ID)
+		}
+	}
+	for _, user := range s.users {
+		if user.UseFixedIP && user.NetworkID == id {
+			refs = append(refs, "user "+user.ID)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
 // WLAN accessors
 func (s *State) GetWLAN(id string) *types.WLAN {
 	s.mu.RLock()
@@ -313,6 +408,41 @@ func (s *State) DeleteWLANGroup(id string) {
 	delete(s.wlanGroups, id)
 }
 
+// AP Group accessors
+func (s *State) GetAPGroup(id string) *types.APGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.apGroups[id]
+}
+
+func (s *State) ListAPGroups() []*types.APGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	groups := make([]*types.APGroup, 0, len(s.apGroups))
+	for _, group := range s.apGroups {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func (s *State) AddAPGroup(group *types.APGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apGroups[group.ID] = group
+}
+
+func (s *State) UpdateAPGroup(group *types.APGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apGroups[group.ID] = group
+}
+
+func (s *State) DeleteAPGroup(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.apGroups, id)
+}
+
 // Firewall Rule accessors
 func (s *State) GetFirewallRule(id string) *types.FirewallRule {
 	s.mu.RLock()
@@ -435,6 +565,31 @@ func (s *State) ListClients() []*types.Client {
 	return clients
 }
 
+// ListGuests returns guest authorizations.
+func (s *State) ListGuests() []*types.Guest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	guests := make([]*types.Guest, 0, len(s.guests))
+	for _, guest := range s.guests {
+		guests = append(guests, guest)
+	}
+	return guests
+}
+
+// AddGuest adds or replaces the guest authorization for guest.MAC.
+func (s *State) AddGuest(guest *types.Guest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guests[strings.ToLower(guest.MAC)] = guest
+}
+
+// GetGuest returns the guest authorization for a MAC address, or nil.
+func (s *State) GetGuest(mac string) *types.Guest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guests[strings.ToLower(mac)]
+}
+
 func (s *State) AddClient(client *types.Client) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -453,6 +608,302 @@ func (s *State) DeleteClient(mac string) {
 	delete(s.clients, mac)
 }
 
+// RecordWakeOnLAN records a Wake-on-LAN request for mac.
+func (s *State) RecordWakeOnLAN(mac string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wakeOnLAN = append(s.wakeOnLAN, mac)
+}
+
+// WakeOnLANSent returns the MACs Wake-on-LAN was requested for, in order.
+func (s *State) WakeOnLANSent() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.wakeOnLAN...)
+}
+
+// AddAnomaly records a client anomaly for stat/anomalies.
+func (s *State) AddAnomaly(anomaly types.Anomaly) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.anomalies = append(s.anomalies, anomaly)
+}
+
+// ListAnomalies returns the anomalies in the order they were added.
+func (s *State) ListAnomalies() []types.Anomaly {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]types.Anomaly(nil), s.anomalies...)
+}
+
+// Firewall zone accessors
+func (s *State) GetFirewallZone(id string) *types.FirewallZone {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.firewallZones[id]
+}
+
+func (s *State) ListFirewallZones() []*types.FirewallZone {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	zones := make([]*types.FirewallZone, 0, len(s.firewallZones))
+	for _, zone := range s.firewallZones {
+		zones = append(zones, zone)
+	}
+	return zones
+}
+
+func (s *State) AddFirewallZone(zone *types.FirewallZone) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.firewallZones[zone.ID] = zone
+}
+
+func (s *State) DeleteFirewallZone(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.firewallZones, id)
+}
+
+// Firewall policy accessors
+func (s *State) GetFirewallPolicy(id string) *types.FirewallPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.firewallPolicies[id]
+}
+
+func (s *State) ListFirewallPolicies() []*types.FirewallPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	policies := make([]*types.FirewallPolicy, 0, len(s.firewallPolicies))
+	for _, policy := range s.firewallPolicies {
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+func (s *State) AddFirewallPolicy(policy *types.FirewallPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.firewallPolicies[policy.ID] = policy
+}
+
+func (s *State) DeleteFirewallPolicy(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.firewallPolicies, id)
+}
+
+// DNS record accessors
+func (s *State) GetDNSRecord(id string) *types.DNSRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dnsRecords[id]
+}
+
+func (s *State) ListDNSRecords() []*types.DNSRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]*types.DNSRecord, 0, len(s.dnsRecords))
+	for _, record := range s.dnsRecords {
+		records = append(records, record)
+	}
+	return records
+}
+
+func (s *State) AddDNSRecord(record *types.DNSRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dnsRecords[record.ID] = record
+}
+
+func (s *State) DeleteDNSRecord(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.dnsRecords, id)
+}
+
+// Hotspot voucher accessors
+func (s *State) GetHotspotVoucher(id string) *types.Voucher {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hotspotVouchers[id]
+}
+
+func (s *State) ListHotspotVouchers() []*types.Voucher {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	vouchers := make([]*types.Voucher, 0, len(s.hotspotVouchers))
+	for _, v := range s.hotspotVouchers {
+		vouchers = append(vouchers, v)
+	}
+	return vouchers
+}
+
+func (s *State) AddHotspotVoucher(voucher *types.Voucher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hotspotVouchers[voucher.ID] = voucher
+}
+
+func (s *State) DeleteHotspotVoucher(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hotspotVouchers, id)
+}
+
+// Hotspot operator accessors
+func (s *State) GetHotspotOperator(id string) *types.HotspotOperator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hotspotOperators[id]
+}
+
+func (s *State) ListHotspotOperators() []*types.HotspotOperator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	operators := make([]*types.HotspotOperator, 0, len(s.hotspotOperators))
+	for _, op := range s.hotspotOperators {
+		operators = append(operators, op)
+	}
+	return operators
+}
+
+func (s *State) AddHotspotOperator(operator *types.HotspotOperator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hotspotOperators[operator.ID] = operator
+}
+
+func (s *State) DeleteHotspotOperator(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hotspotOperators, id)
+}
+
+// Voucher accessors
+func (s *State) GetVoucher(id string) *types.IntegrationVoucher {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.vouchers[id]
+}
+
+func (s *State) ListVouchers() []*types.IntegrationVoucher {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	vouchers := make([]*types.IntegrationVoucher, 0, len(s.vouchers))
+	for _, v := range s.vouchers {
+		vouchers = append(vouchers, v)
+	}
+	return vouchers
+}
+
+func (s *State) AddVoucher(voucher *types.IntegrationVoucher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vouchers[voucher.ID] = voucher
+}
+
+func (s *State) DeleteVoucher(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.vouchers, id)
+}
+
+// Site map accessors
+func (s *State) GetSiteMap(id string) *types.SiteMap {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.siteMaps[id]
+}
+
+func (s *State) ListSiteMaps() []*types.SiteMap {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	maps := make([]*types.SiteMap, 0, len(s.siteMaps))
+	for _, m := range s.siteMaps {
+		maps = append(maps, m)
+	}
+	return maps
+}
+
+func (s *State) AddSiteMap(m *types.SiteMap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.siteMaps[m.ID] = m
+}
+
+func (s *State) DeleteSiteMap(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.siteMaps, id)
+}
+
+// AddMapImage stores an uploaded map image under name.
+func (s *State) AddMapImage(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mapImages[name] = data
+}
+
+// GetMapImage returns an uploaded map image, or nil.
+func (s *State) GetMapImage(name string) []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mapImages[name]
+}
+
+// AddIPSEvent records an IPS event, such as a honeypot trigger.
+func (s *State) AddIPSEvent(event types.IPSEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ipsEvents = append(s.ipsEvents, event)
+}
+
+// ListIPSEvents returns the IPS events in the order they were added.
+func (s *State) ListIPSEvents() []types.IPSEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]types.IPSEvent(nil), s.ipsEvents...)
+}
+
+// AddEvent records an event for stat/event.
+func (s *State) AddEvent(event types.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+// ListEvents returns the events in the order they were added.
+func (s *State) ListEvents() []types.Event {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]types.Event(nil), s.events...)
+}
+
+// Alarm accessors
+func (s *State) GetAlarm(id string) *types.Alarm {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.alarms[id]
+}
+
+func (s *State) ListAlarms() []*types.Alarm {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	alarms := make([]*types.Alarm, 0, len(s.alarms))
+	for _, alarm := range s.alarms {
+		alarms = append(alarms, alarm)
+	}
+	return alarms
+}
+
+func (s *State) AddAlarm(alarm *types.Alarm) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alarms[alarm.ID] = alarm
+}
+
 // User accessors (known clients, not auth users)
 func (s *State) GetKnownClient(id string) *types.User {
 	s.mu.RLock()
@@ -668,6 +1119,72 @@ func (s *State) UpdateSetting(setting *types.Setting) {
 	s.settings[setting.Key] = setting
 }
 
+// GetSettingFields returns a copy of the fields stored for a site setting
+// beyond its key and ID, or nil if none were written.
+func (s *State) GetSettingFields(key string) map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.settingFields[key]
+	if !ok {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(stored))
+	for k, v := range stored {
+		fields[k] = v
+	}
+	return fields
+}
+
+// SetSettingFields merges fields into a site setting's stored fields.
+func (s *State) SetSettingFields(key string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.settingFields[key]
+	if !ok {
+		stored = make(map[string]interface{})
+		s.settingFields[key] = stored
+	}
+	for k, v := range fields {
+		switch k {
+		case "_id", "key", "site_id":
+		default:
+			stored[k] = v
+		}
+	}
+}
+
+// GetSuperSetting returns a copy of a controller-wide setting's fields, or
+// nil if it is not set.
+func (s *State) GetSuperSetting(key string) map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.superSettings[key]
+	if !ok {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(stored))
+	for k, v := range stored {
+		fields[k] = v
+	}
+	return fields
+}
+
+// SetSuperSetting merges fields into a controller-wide setting.
+func (s *State) SetSuperSetting(key string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.superSettings[key]
+	if !ok {
+		stored = map[string]interface{}{"_id": generateID(), "key": key}
+		s.superSettings[key] = stored
+	}
+	for k, v := range fields {
+		if k != "_id" {
+			stored[k] = v
+		}
+	}
+}
+
 func (s *State) DeleteSetting(key string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -786,6 +1303,7 @@ func (s *State) SimulateSpeedTest() {
 		Runtime:        0,
 		ServerName:     "Mock Speed Test Server",
 		ServerCountry:  "US",
+		LastRun:        time.Now().UnixMilli(),
 	}
 	// Set upload/download speeds
 	s.speedTestStatus.XputDownload.Val = 500.0
@@ -180,6 +180,22 @@ func TestState_Networks(t *testing.T) {
 	}
 }
 
+func TestState_NetworkReferences(t *testing.T) {
+	state := NewState()
+	state.AddNetwork(&types.Network{ID: "net1"})
+	state.AddWLAN(&types.WLAN{ID: "wlan1", NetworkConfID: "net1"})
+	state.AddKnownClient(&types.User{ID: "user1", UseFixedIP: true, NetworkID: "net1"})
+	state.AddKnownClient(&types.User{ID: "user2", NetworkID: "net1"}) // no fixed IP
+
+	refs := state.NetworkReferences("net1")
+	if len(refs) != 2 || refs[0] != "user user1" || refs[1] != "wlanconf wlan1" {
+		t.Errorf("NetworkReferences() = %v, want user1 and wlan1", refs)
+	}
+	if refs := state.NetworkReferences("net2"); len(refs) != 0 {
+		t.Errorf("NetworkReferences(unused) = %v, want none", refs)
+	}
+}
+
 func TestState_Reset(t *testing.T) {
 	state := NewState()
 
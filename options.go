@@ -2,6 +2,7 @@ package gofi
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
 )
 
@@ -29,6 +30,17 @@ func WithRetry(maxRetries int, initialBackoff time.Duration) Option {
 	}
 }
 
+// WithRateLimit limits the client to requestsPerSecond requests per
+// second, allowing bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Config) {
+		c.RateLimit = &RateLimitConfig{
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             burst,
+		}
+	}
+}
+
 // WithLogger sets a custom logger.
 func WithLogger(logger Logger) Option {
 	return func(c *Config) {
@@ -51,9 +63,80 @@ func WithInsecureSkipVerify() Option {
 	}
 }
 
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request. It can be given
+// several times, also for the same key.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Add(key, value)
+	}
+}
+
 // WithSite sets the default site.
 func WithSite(site string) Option {
 	return func(c *Config) {
 		c.Site = site
 	}
 }
+
+// WithListCache caches Networks().List, Users().List and Devices().List
+// for the given TTLs.
+func WithListCache(cache ListCache) Option {
+	return func(c *Config) {
+		c.ListCache = &cache
+	}
+}
+
+// WithSerializedWrites queues writes (requests other than a GET or a stat/
+// or list/ query) per site and sends them one at a time, so that goroutines
+// updating devices or WLANs concurrently do not set off a provisioning
+// storm. Reads and other sites are not held up. Queue depth is reported in
+// Diagnostics.
+func WithSerializedWrites() Option {
+	return func(c *Config) {
+		c.SerializeWrites = true
+	}
+}
+
+// WithGracefulDisconnect makes Disconnect drain the client before logging
+// out: requests started after Disconnect is called fail with
+// ErrClientClosing, and Disconnect waits for those already in flight
+// until they finish or its context is done.
+func WithGracefulDisconnect() Option {
+	return func(c *Config) {
+		c.GracefulDisconnect = true
+	}
+}
+
+// WithConsoleType skips API path detection on Connect and uses the paths
+// of the given console type.
+func WithConsoleType(t ConsoleType) Option {
+	return func(c *Config) {
+		c.ConsoleType = t
+	}
+}
+
+// WithMaintenancePolicy restricts device restarts, upgrades, provisioning
+// and PoE power cycles to the policy's maintenance windows.
+func WithMaintenancePolicy(policy *MaintenancePolicy) Option {
+	return func(c *Config) {
+		c.MaintenancePolicy = policy
+	}
+}
+
+// WithIntegrationAPI serves sites, devices and clients from the official
+// Integrations API only; see Config.UseIntegrationAPI.
+func WithIntegrationAPI() Option {
+	return func(c *Config) {
+		c.UseIntegrationAPI = true
+	}
+}
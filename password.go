@@ -0,0 +1,40 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChangePassword changes the password of the configured admin on the
+// controller and logs in again with it, since the controller ends the
+// admin's sessions. Later logins, those of clones included, use the new
+// password. Only UniFi OS consoles support it.
+//
+// If the change succeeds but the new login fails, the error says so and
+// the client is left disconnected; Connect retries with the new password.
+// Clients authenticating with Config.APIKey have no password to change.
+func (c *client) ChangePassword(ctx context.Context, newPassword string) error {
+	if !c.connected.Load() {
+		return ErrNotConnected
+	}
+	if c.config.APIKey != "" {
+		return fmt.Errorf("cannot change the password of an API key client")
+	}
+
+	if err := c.System().ChangePassword(ctx, c.config.Password, newPassword); err != nil {
+		return err
+	}
+	c.auth.SetPassword(newPassword)
+	c.config.Password = newPassword
+
+	if err := c.auth.Login(ctx); err != nil {
+		c.connected.Store(false)
+		return fmt.Errorf("password changed, but login with the new password failed: %w", err)
+	}
+
+	if c.logger != nil {
+		c.logger.Info("Changed admin password", "username", c.config.Username)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+)
+
+func TestClient_ChangePassword(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	if err := c.ChangePassword(ctx, "n3w-secret"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+
+	if !server.State().ValidateCredentials("admin", "n3w-secret") {
+		t.Error("new password not accepted by controller")
+	}
+	if server.State().ValidateCredentials("admin", "admin") {
+		t.Error("old password still accepted by controller")
+	}
+
+	// The old session ended; the client logged in again.
+	if !c.IsConnected() {
+		t.Error("IsConnected() = false after ChangePassword")
+	}
+	if _, err := c.Sites().List(ctx); err != nil {
+		t.Errorf("Sites().List() after ChangePassword error = %v", err)
+	}
+
+	// Later logins use the new password.
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	if err := c.Connect(ctx); err != nil {
+		t.Errorf("Connect() with new password error = %v", err)
+	}
+}
+
+func TestClient_ChangePassword_WrongCurrent(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	// Someone else changed the password meanwhile.
+	server.State().AddUser("admin", "rotated")
+
+	if err := c.ChangePassword(ctx, "n3w-secret"); err == nil {
+		t.Fatal("ChangePassword() with stale current password succeeded")
+	}
+	if !server.State().ValidateCredentials("admin", "rotated") {
+		t.Error("password changed despite the error")
+	}
+	if !c.IsConnected() {
+		t.Error("IsConnected() = false after failed ChangePassword")
+	}
+}
+
+func TestClient_ChangePassword_NotConnected(t *testing.T) {
+	c, err := New(&Config{Host: "localhost", Username: "admin", Password: "admin"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := c.ChangePassword(context.Background(), "n3w-secret"); err != ErrNotConnected {
+		t.Errorf("ChangePassword() error = %v, want ErrNotConnected", err)
+	}
+}
@@ -0,0 +1,143 @@
+package gofi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// ConsoleType identifies the kind of controller behind the configured host.
+type ConsoleType string
+
+// Console types reported by Ping.
+const (
+	// ConsoleUnknown means the console type could not be determined.
+	ConsoleUnknown ConsoleType = "unknown"
+
+	// ConsoleUniFiOS is a UniFi OS console (UDM, UDM Pro, UCG, Cloud Key Gen2+).
+	ConsoleUniFiOS ConsoleType = "unifi-os"
+
+	// ConsoleClassic is a standalone (self-hosted) Network Application.
+	ConsoleClassic ConsoleType = "classic"
+)
+
+// PingResult describes controller reachability and identity.
+type PingResult struct {
+	// Reachable is true if the controller answered the status probe.
+	Reachable bool
+
+	// Authenticated is true if the client holds a session the controller still accepts.
+	Authenticated bool
+
+	// Version is the Network Application version reported by the controller.
+	Version string
+
+	// ConsoleType is the detected controller type.
+	ConsoleType ConsoleType
+
+	// Latency is the round-trip time of the status probe.
+	Latency time.Duration
+}
+
+// Ping probes the controller without side effects.
+//
+// It never logs in: Authenticated only reflects an existing session, which is
+// verified with a read-only request. An error is returned only when the
+// controller cannot be reached at all.
+func (c *client) Ping(ctx context.Context) (*PingResult, error) {
+	result := &PingResult{ConsoleType: ConsoleUnknown}
+
+	start := time.Now()
+	status, consoleType, err := c.probeStatus(ctx)
+	result.Latency = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+
+	result.Reachable = true
+	result.ConsoleType = consoleType
+	result.Version = status.Version
+	if status.ServerVersion != "" {
+		result.Version = status.ServerVersion
+	}
+
+	if c.auth.IsAuthenticated() {
+		resp, err := c.transport.Do(ctx, transport.NewRequest("GET", "/api/self"))
+		result.Authenticated = err == nil && resp.IsSuccess()
+	}
+
+	return result, nil
+}
+
+// probeStatus queries the unauthenticated status endpoints, trying the
+// UniFi OS location first and falling back to the classic controller one.
+func (c *client) probeStatus(ctx context.Context) (*types.Status, ConsoleType, error) {
+	probes := []struct {
+		path        string
+		consoleType ConsoleType
+	}{
+		{"/api/status", ConsoleUniFiOS},
+		{"/status", ConsoleClassic},
+	}
+
+	var lastErr error
+	for _, p := range probes {
+		resp, err := c.transport.Do(ctx, transport.NewRequest("GET", p.path))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !resp.IsSuccess() {
+			lastErr = types.NewResponseError("status probe "+p.path, resp.StatusCode, resp.Body)
+			continue
+		}
+
+		status, err := parseStatus(resp.Body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return status, p.consoleType, nil
+	}
+
+	return nil, ConsoleUnknown, fmt.Errorf("controller unreachable: %w", lastErr)
+}
+
+// detectConsoleType tells a UniFi OS console from a classic controller by
+// its start page: UniFi OS serves it, while a classic controller redirects
+// to /manage. It returns ConsoleUnknown if the answer is neither, in which
+// case the client keeps its current paths and the login reports any error.
+func (c *client) detectConsoleType(ctx context.Context) ConsoleType {
+	resp, err := c.transport.Do(ctx, transport.NewRequest("GET", "/"))
+	switch {
+	case err != nil:
+		return ConsoleUnknown
+	case resp.IsSuccess():
+		return ConsoleUniFiOS
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return ConsoleClassic
+	default:
+		return ConsoleUnknown
+	}
+}
+
+// parseStatus accepts both the bare status object and the classic
+// controller's {"meta": {...}} form, where the version lives in meta.
+func parseStatus(body []byte) (*types.Status, error) {
+	var wrapped struct {
+		types.Status
+		Meta *types.Status `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to parse status: %w", err)
+	}
+
+	if wrapped.Meta != nil {
+		return wrapped.Meta, nil
+	}
+	return &wrapped.Status, nil
+}
@@ -0,0 +1,116 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+)
+
+func TestClient_Ping(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Before connecting: reachable but not authenticated, and no login performed
+	result, err := c.Ping(ctx)
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if !result.Reachable {
+		t.Error("Reachable = false, want true")
+	}
+	if result.Authenticated {
+		t.Error("Authenticated = true before Connect()")
+	}
+	if result.Version != "7.5.174" {
+		t.Errorf("Version = %s, want 7.5.174", result.Version)
+	}
+	if result.ConsoleType != ConsoleUniFiOS {
+		t.Errorf("ConsoleType = %s, want %s", result.ConsoleType, ConsoleUniFiOS)
+	}
+	if c.IsConnected() {
+		t.Error("Ping() should not connect the client")
+	}
+
+	// After connecting: authenticated
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	result, err = c.Ping(ctx)
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if !result.Authenticated {
+		t.Error("Authenticated = false after Connect()")
+	}
+}
+
+func TestClient_Ping_Unreachable(t *testing.T) {
+	server := mock.NewServer()
+	host, port := server.Host(), server.Port()
+	server.Close()
+
+	c, err := New(&Config{
+		Host:          host,
+		Port:          port,
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := c.Ping(context.Background())
+	if err == nil {
+		t.Fatal("Ping() should return error for unreachable controller")
+	}
+	if result == nil || result.Reachable {
+		t.Error("Reachable should be false")
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantVersion string
+	}{
+		{"unifi os", `{"up":true,"version":"8.0.24"}`, "8.0.24"},
+		{"classic meta", `{"meta":{"rc":"ok","up":true,"server_version":"7.4.162"},"data":[]}`, "7.4.162"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := parseStatus([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("parseStatus() error = %v", err)
+			}
+			got := status.Version
+			if status.ServerVersion != "" {
+				got = status.ServerVersion
+			}
+			if got != tt.wantVersion {
+				t.Errorf("version = %s, want %s", got, tt.wantVersion)
+			}
+			if !status.Up {
+				t.Error("Up = false, want true")
+			}
+		})
+	}
+}
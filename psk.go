@@ -0,0 +1,245 @@
+package gofi
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// DefaultPSKAlphabet omits characters that are easily confused when a
+// passphrase is read aloud or copied from paper (0/O, 1/l/I).
+const DefaultPSKAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// PSKPolicy describes how new WLAN passphrases are generated: a fixed
+// prefix followed by a random suffix.
+type PSKPolicy struct {
+	// Prefix is prepended to every passphrase, e.g. "guest-".
+	Prefix string
+
+	// Length is the number of random characters. Defaults to 12.
+	Length int
+
+	// Alphabet is the set of characters the suffix is drawn from.
+	// Defaults to DefaultPSKAlphabet.
+	Alphabet string
+}
+
+// Validate checks that the policy produces WPA passphrases of 8 to 63
+// characters with enough randomness to be worth rotating.
+func (p PSKPolicy) Validate() error {
+	length, alphabet := p.params()
+	if length < 8 {
+		return fmt.Errorf("random suffix must be at least 8 characters, got %d", length)
+	}
+	if n := len(p.Prefix) + length; n > 63 {
+		return fmt.Errorf("passphrase would be %d characters, maximum is 63", n)
+	}
+	if len(alphabet) < 2 {
+		return fmt.Errorf("alphabet must have at least 2 characters")
+	}
+	for _, r := range p.Prefix + alphabet {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("passphrase characters must be printable ASCII, got %q", r)
+		}
+	}
+	return nil
+}
+
+// params returns the policy's length and alphabet with defaults applied.
+func (p PSKPolicy) params() (int, string) {
+	length := p.Length
+	if length == 0 {
+		length = 12
+	}
+	alphabet := p.Alphabet
+	if alphabet == "" {
+		alphabet = DefaultPSKAlphabet
+	}
+	return length, alphabet
+}
+
+// Generate returns a new passphrase using crypto/rand.
+func (p PSKPolicy) Generate() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	length, alphabet := p.params()
+
+	var b strings.Builder
+	b.WriteString(p.Prefix)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate passphrase: %w", err)
+		}
+		b.WriteByte(alphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// PSKRotationOptions controls RotatePSKs.
+type PSKRotationOptions struct {
+	// Sites are the sites to rotate. Defaults to the client's default site.
+	Sites []string
+
+	// SSIDs selects WLANs by name. Empty selects every WPA-PSK and WPA3
+	// WLAN on each site.
+	SSIDs []string
+
+	// Policy generates the new passphrases.
+	Policy PSKPolicy
+
+	// Stagger is the pause between WLAN updates. Every update reprovisions
+	// the access points broadcasting the SSID, so spacing them out avoids
+	// dropping all wireless clients at once.
+	Stagger time.Duration
+
+	// DryRun generates passphrases without updating the controller.
+	DryRun bool
+}
+
+// PSKRotation is the result of rotating one WLAN's passphrase.
+type PSKRotation struct {
+	Site   string
+	WLANID string
+	SSID   string
+
+	// Passphrase is the new passphrase. It is set even if applying it
+	// failed, so a partial rotation can be retried or reported.
+	Passphrase string
+
+	// Applied is true once the controller accepted the new passphrase.
+	Applied bool
+
+	// Err is why the rotation failed, if it did.
+	Err error
+
+	hidden bool
+}
+
+// WiFiURI returns the credentials in the "WIFI:" format understood by
+// phone cameras when encoded as a QR code.
+func (r PSKRotation) WiFiURI() string {
+	uri := fmt.Sprintf("WIFI:T:WPA;S:%s;P:%s;", escapeWiFi(r.SSID), escapeWiFi(r.Passphrase))
+	if r.hidden {
+		uri += "H:true;"
+	}
+	return uri + ";"
+}
+
+var wifiEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+
+// escapeWiFi escapes the characters that are special in WIFI: URIs.
+func escapeWiFi(s string) string {
+	return wifiEscaper.Replace(s)
+}
+
+// RotatePSKs assigns new passphrases to the selected WLANs. WLANs are
+// updated one at a time, pausing opts.Stagger between them. Every selected
+// WLAN is attempted; failures are recorded in the result's Err. An error is
+// returned only if the WLANs could not be listed or an SSID was not found,
+// in which case nothing has been changed.
+func RotatePSKs(ctx context.Context, c Client, opts PSKRotationOptions) ([]PSKRotation, error) {
+	if err := opts.Policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid passphrase policy: %w", err)
+	}
+
+	sites := opts.Sites
+	if len(sites) == 0 {
+		sites = []string{c.Site("").Name()}
+	}
+
+	// Select everything up front so an unknown SSID fails before any change.
+	var rotations []PSKRotation
+	var selected []*types.WLAN
+	for _, site := range sites {
+		wlans, err := c.WLANs().List(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list WLANs on site %s: %w", site, err)
+		}
+
+		matched, err := selectPSKWLANs(wlans, opts.SSIDs)
+		if err != nil {
+			return nil, fmt.Errorf("site %s: %w", site, err)
+		}
+		for _, w := range matched {
+			rotations = append(rotations, PSKRotation{Site: site, WLANID: w.ID, SSID: w.Name, hidden: w.HideSSID})
+			selected = append(selected, w)
+		}
+	}
+
+	for i := range rotations {
+		r := &rotations[i]
+
+		pass, err := opts.Policy.Generate()
+		if err != nil {
+			return rotations, err
+		}
+		r.Passphrase = pass
+
+		if opts.DryRun {
+			continue
+		}
+
+		if i > 0 && opts.Stagger > 0 {
+			select {
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+				continue
+			case <-time.After(opts.Stagger):
+			}
+		}
+
+		w := selected[i].Clone()
+		w.Passphrase = pass
+		if _, err := c.WLANs().Update(ctx, r.Site, w); err != nil {
+			r.Err = fmt.Errorf("failed to update WLAN: %w", err)
+			continue
+		}
+		r.Applied = true
+	}
+
+	return rotations, nil
+}
+
+// selectPSKWLANs returns the passphrase-protected WLANs named in ssids, or
+// all of them if ssids is empty.
+func selectPSKWLANs(wlans []types.WLAN, ssids []string) ([]*types.WLAN, error) {
+	isPSK := func(w *types.WLAN) bool {
+		return w.Security == types.SecurityTypeWPAPSK || w.Security == types.SecurityTypeWPA3
+	}
+
+	var selected []*types.WLAN
+	if len(ssids) == 0 {
+		for i := range wlans {
+			if isPSK(&wlans[i]) {
+				selected = append(selected, &wlans[i])
+			}
+		}
+		return selected, nil
+	}
+
+	for _, ssid := range ssids {
+		var found *types.WLAN
+		for i := range wlans {
+			if wlans[i].Name == ssid {
+				found = &wlans[i]
+				break
+			}
+		}
+		switch {
+		case found == nil:
+			return nil, notFoundf("SSID %q not found", ssid)
+		case !isPSK(found):
+			return nil, fmt.Errorf("SSID %q uses %s security, not a passphrase", ssid, found.Security)
+		}
+		selected = append(selected, found)
+	}
+	return selected, nil
+}
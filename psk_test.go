@@ -0,0 +1,141 @@
+package gofi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestPSKPolicy_Generate(t *testing.T) {
+	p := PSKPolicy{Prefix: "guest-", Length: 10}
+	a, err := p.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	b, _ := p.Generate()
+
+	if !strings.HasPrefix(a, "guest-") || len(a) != 16 {
+		t.Errorf("Generate() = %q, want guest- plus 10 characters", a)
+	}
+	if a == b {
+		t.Errorf("Generate() returned %q twice", a)
+	}
+	for _, r := range strings.TrimPrefix(a, "guest-") {
+		if !strings.ContainsRune(DefaultPSKAlphabet, r) {
+			t.Errorf("Generate() = %q contains %q outside the alphabet", a, r)
+		}
+	}
+}
+
+func TestPSKPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PSKPolicy
+		wantErr bool
+	}{
+		{"defaults", PSKPolicy{}, false},
+		{"short suffix", PSKPolicy{Length: 6}, true},
+		{"too long", PSKPolicy{Prefix: strings.Repeat("x", 50), Length: 20}, true},
+		{"tiny alphabet", PSKPolicy{Alphabet: "a"}, true},
+		{"non-ascii prefix", PSKPolicy{Prefix: "café-"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newPSKServer() *mock.Server {
+	server := mock.NewServer()
+	server.State().AddWLAN(&types.WLAN{ID: "w1", Name: "Home", Security: types.SecurityTypeWPAPSK, Passphrase: "old-home-pass", Enabled: true})
+	server.State().AddWLAN(&types.WLAN{ID: "w2", Name: "Guest", Security: types.SecurityTypeWPAPSK, Passphrase: "old-guest-pass", Enabled: true})
+	server.State().AddWLAN(&types.WLAN{ID: "w3", Name: "Open", Security: types.SecurityTypeOpen, Enabled: true})
+	return server
+}
+
+func TestRotatePSKs(t *testing.T) {
+	server := newPSKServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	rotations, err := RotatePSKs(context.Background(), c, PSKRotationOptions{Policy: PSKPolicy{Prefix: "p-"}})
+	if err != nil {
+		t.Fatalf("RotatePSKs() error = %v", err)
+	}
+	if len(rotations) != 2 {
+		t.Fatalf("RotatePSKs() rotated %d WLANs, want 2", len(rotations))
+	}
+
+	for _, r := range rotations {
+		if !r.Applied || r.Err != nil {
+			t.Errorf("%s: Applied = %v, Err = %v", r.SSID, r.Applied, r.Err)
+		}
+		w, err := c.WLANs().Get(context.Background(), "default", r.WLANID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if w.Passphrase != r.Passphrase || !strings.HasPrefix(w.Passphrase, "p-") {
+			t.Errorf("%s passphrase = %q, want %q", r.SSID, w.Passphrase, r.Passphrase)
+		}
+	}
+}
+
+func TestRotatePSKs_SelectedDryRun(t *testing.T) {
+	server := newPSKServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	rotations, err := RotatePSKs(context.Background(), c, PSKRotationOptions{SSIDs: []string{"Guest"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("RotatePSKs() error = %v", err)
+	}
+	if len(rotations) != 1 || rotations[0].SSID != "Guest" || rotations[0].Applied || rotations[0].Passphrase == "" {
+		t.Fatalf("RotatePSKs() = %+v, want one unapplied Guest rotation", rotations)
+	}
+
+	w, _ := c.WLANs().Get(context.Background(), "default", "w2")
+	if w.Passphrase != "old-guest-pass" {
+		t.Errorf("dry run changed passphrase to %q", w.Passphrase)
+	}
+}
+
+func TestRotatePSKs_BadSSID(t *testing.T) {
+	server := newPSKServer()
+	defer server.Close()
+	c := connectMock(t, server)
+
+	tests := []struct {
+		ssids    []string
+		notFound bool
+	}{
+		{[]string{"Home", "Missing"}, true},
+		{[]string{"Open"}, false},
+	}
+	for _, tt := range tests {
+		_, err := RotatePSKs(context.Background(), c, PSKRotationOptions{SSIDs: tt.ssids})
+		if err == nil {
+			t.Errorf("RotatePSKs(%v) expected error", tt.ssids)
+		} else if IsNotFound(err) != tt.notFound {
+			t.Errorf("RotatePSKs(%v) IsNotFound(%v) = %v, want %v", tt.ssids, err, !tt.notFound, tt.notFound)
+		}
+	}
+
+	w, _ := c.WLANs().Get(context.Background(), "default", "w1")
+	if w.Passphrase != "old-home-pass" {
+		t.Errorf("failed selection changed passphrase to %q", w.Passphrase)
+	}
+}
+
+func TestPSKRotation_WiFiURI(t *testing.T) {
+	r := PSKRotation{SSID: `Cafe;Wifi`, Passphrase: `a:b\c`}
+	want := `WIFI:T:WPA;S:Cafe\;Wifi;P:a\:b\\c;;`
+	if got := r.WiFiURI(); got != want {
+		t.Errorf("WiFiURI() = %s, want %s", got, want)
+	}
+}
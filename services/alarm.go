@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// alarmService implements AlarmService.
+type alarmService struct {
+	transport transport.Transport
+}
+
+// NewAlarmService creates a new alarm service.
+func NewAlarmService(transport transport.Transport) AlarmService {
+	return &alarmService{
+		transport: transport,
+	}
+}
+
+// List returns the alarms that have not been archived.
+func (s *alarmService) List(ctx context.Context, site string) ([]types.Alarm, error) {
+	return s.list(ctx, site, map[string]interface{}{"archived": false})
+}
+
+// ListAll returns every alarm, archived or not.
+func (s *alarmService) ListAll(ctx context.Context, site string) ([]types.Alarm, error) {
+	return s.list(ctx, site, nil)
+}
+
+// list returns the alarms matching filter, or all of them if it is nil.
+func (s *alarmService) list(ctx context.Context, site string, filter map[string]interface{}) ([]types.Alarm, error) {
+	path := internal.BuildAPIPath(site, "list/alarm")
+	req := transport.NewRequest("GET", path)
+	if filter != nil {
+		req = transport.NewRequest("POST", path).WithBody(filter)
+	}
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alarms: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list alarms", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.Alarm](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// Archive archives an alarm. Archiving an alarm that does not exist or is
+// already archived is not an error.
+func (s *alarmService) Archive(ctx context.Context, site, id string) error {
+	return s.command(ctx, site, "archive-alarm", map[string]interface{}{"_id": id})
+}
+
+// ArchiveAll archives every alarm of the site.
+func (s *alarmService) ArchiveAll(ctx context.Context, site string) error {
+	return s.command(ctx, site, "archive-all-alarms", nil)
+}
+
+// ArchiveMatching archives the active alarms for which match returns true
+// and returns how many it archived. It stops at the first failure.
+func (s *alarmService) ArchiveMatching(ctx context.Context, site string, match func(*types.Alarm) bool) (int, error) {
+	alarms, err := s.List(ctx, site)
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for i := range alarms {
+		if !match(&alarms[i]) {
+			continue
+		}
+		if err := s.Archive(ctx, site, alarms[i].ID); err != nil {
+			return archived, err
+		}
+		archived++
+	}
+
+	return archived, nil
+}
+
+// command sends an event manager command.
+func (s *alarmService) command(ctx context.Context, site, cmd string, extra map[string]interface{}) error {
+	payload := map[string]interface{}{
+		"cmd": cmd,
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+
+	path := internal.BuildAPIPath(site, "cmd/evtmgr")
+	req := transport.NewRequest("POST", path).WithBody(payload)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to execute alarm command %s: %w", cmd, err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return types.NewResponseError("alarm command "+cmd, resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
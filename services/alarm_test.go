@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestAlarmService(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.SimulateAlarm("default", &types.Alarm{ID: "a1", Time: types.NewFlexTime(3), Key: types.EventAPDisconnected, AP: "aa:00:00:00:00:01"})
+	server.SimulateAlarm("default", &types.Alarm{ID: "a2", Time: types.NewFlexTime(2), Key: types.EventAPDisconnected, AP: "aa:00:00:00:00:02"})
+	server.SimulateAlarm("default", &types.Alarm{ID: "a3", Time: types.NewFlexTime(1), Key: types.EventIPSAlert})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewAlarmService(trans)
+	ctx := context.Background()
+
+	alarms, err := svc.List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(alarms) != 3 || alarms[0].ID != "a1" {
+		t.Fatalf("List = %+v, want 3 alarms newest first", alarms)
+	}
+
+	// Acknowledge the disconnect of the AP that was under maintenance.
+	n, err := svc.ArchiveMatching(ctx, "default", func(a *types.Alarm) bool {
+		return a.Key == types.EventAPDisconnected && a.DeviceMAC() == "aa:00:00:00:00:02"
+	})
+	if err != nil {
+		t.Fatalf("ArchiveMatching failed: %v", err)
+	}
+	if n != 1 || !server.State().GetAlarm("a2").Archived {
+		t.Errorf("ArchiveMatching archived %d, a2 archived = %v", n, server.State().GetAlarm("a2").Archived)
+	}
+
+	if err := svc.Archive(ctx, "default", "a3"); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if err := svc.Archive(ctx, "default", "missing"); err != nil {
+		t.Errorf("Archive of a missing alarm should succeed, got %v", err)
+	}
+
+	alarms, err = svc.List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(alarms) != 1 || alarms[0].ID != "a1" {
+		t.Errorf("List after archiving = %+v, want only a1", alarms)
+	}
+
+	if err := svc.ArchiveAll(ctx, "default"); err != nil {
+		t.Fatalf("ArchiveAll failed: %v", err)
+	}
+	if alarms, _ := svc.List(ctx, "default"); len(alarms) != 0 {
+		t.Errorf("List after ArchiveAll = %+v, want none", alarms)
+	}
+
+	all, err := svc.ListAll(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListAll failed: %v", err)
+	}
+	if len(all) != 3 || !all[0].Handled {
+		t.Errorf("ListAll = %+v, want 3 handled alarms", all)
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// Anomalies returns the client anomalies that occurred between start and
+// end. The controller aggregates them hourly; occurrences outside the
+// period are dropped, as are anomalies left without any.
+func (s *statsService) Anomalies(ctx context.Context, site string, start, end time.Time) ([]types.Anomaly, error) {
+	query := url.Values{"scale": {"hourly"}}
+	if !start.IsZero() {
+		query.Set("start", strconv.FormatInt(start.UnixMilli(), 10))
+	}
+	if !end.IsZero() {
+		query.Set("end", strconv.FormatInt(end.UnixMilli(), 10))
+	}
+	path := internal.BuildAPIPath(site, "stat/anomalies") + "?" + query.Encode()
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anomalies: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list anomalies", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.Anomaly](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := []types.Anomaly{}
+	for i := range apiResp.Data {
+		if a := apiResp.Data[i].Between(start, end); len(a.Timestamps) > 0 {
+			anomalies = append(anomalies, a)
+		}
+	}
+	return anomalies, nil
+}
+
+// AnomalySummary returns the anomalies between start and end counted by
+// kind and category with types.NewAnomalySummary.
+func (s *statsService) AnomalySummary(ctx context.Context, site string, start, end time.Time) (*types.AnomalySummary, error) {
+	anomalies, err := s.Anomalies(ctx, site, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewAnomalySummary(anomalies, start, end), nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// apGroupPath builds the v2 API path for AP groups.
+func apGroupPath(site, id string) string {
+	endpoint := fmt.Sprintf("site/%s/apgroups", site)
+	if id != "" {
+		endpoint += "/" + id
+	}
+	return internal.BuildV2APIPath(site, endpoint)
+}
+
+// ListAPGroups returns all AP groups for a site.
+func (s *wlanService) ListAPGroups(ctx context.Context, site string) ([]types.APGroup, error) {
+	req := transport.NewRequest("GET", apGroupPath(site, ""))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AP groups: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list AP groups", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.APGroup](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// CreateAPGroup creates a new AP group.
+func (s *wlanService) CreateAPGroup(ctx context.Context, site string, group *types.APGroup) (*types.APGroup, error) {
+	req := transport.NewRequest("POST", apGroupPath(site, "")).WithBody(group)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AP group: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create AP group", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.APGroup](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create AP group returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// UpdateAPGroup updates an existing AP group.
+func (s *wlanService) UpdateAPGroup(ctx context.Context, site string, group *types.APGroup) (*types.APGroup, error) {
+	if group.ID == "" {
+		return nil, fmt.Errorf("AP group ID is required for update")
+	}
+
+	req := transport.NewRequest("PUT", apGroupPath(site, group.ID)).WithBody(group)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update AP group: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("update AP group", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.APGroup](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("update AP group returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// DeleteAPGroup deletes an AP group.
+func (s *wlanService) DeleteAPGroup(ctx context.Context, site, id string) error {
+	req := transport.NewRequest("DELETE", apGroupPath(site, id))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete AP group: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("AP group", resp)
+	}
+
+	return nil
+}
+
+// EnableOnAPs limits a WLAN to the given access points. It reuses an AP
+// group with exactly those APs, or else creates or updates the group
+// managed for the WLAN, and binds the WLAN to it.
+func (s *wlanService) EnableOnAPs(ctx context.Context, site, wlanID string, apMACs []string) (*types.WLAN, error) {
+	macs := normalizeMACs(apMACs)
+	if len(macs) == 0 {
+		return nil, fmt.Errorf("at least one AP MAC is required")
+	}
+
+	wlan, err := s.Get(ctx, site, wlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.ListAPGroups(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	name := apGroupName(wlan)
+	var group, managed *types.APGroup
+	for i := range groups {
+		if equalStrings(normalizeMACs(groups[i].DeviceMACs), macs) {
+			group = &groups[i]
+			break
+		}
+		if groups[i].Name == name {
+			managed = &groups[i]
+		}
+	}
+
+	switch {
+	case group != nil:
+	case managed != nil:
+		managed.DeviceMACs = macs
+		if group, err = s.UpdateAPGroup(ctx, site, managed); err != nil {
+			return nil, err
+		}
+	default:
+		if group, err = s.CreateAPGroup(ctx, site, &types.APGroup{Name: name, DeviceMACs: macs}); err != nil {
+			return nil, err
+		}
+	}
+
+	wlan.APGroupIDs = []string{group.ID}
+	wlan.APGroupMode = types.APGroupModeGroups
+	return s.Update(WithoutValidation(ctx), site, wlan)
+}
+
+// apGroupName is the name of the AP group EnableOnAPs manages for a WLAN.
+func apGroupName(wlan *types.WLAN) string {
+	return "gofi: " + wlan.Name
+}
+
+// normalizeMACs lowercases, sorts and deduplicates MAC addresses.
+func normalizeMACs(macs []string) []string {
+	seen := make(map[string]bool, len(macs))
+	out := make([]string, 0, len(macs))
+	for _, mac := range macs {
+		mac = strings.ToLower(strings.TrimSpace(mac))
+		if mac == "" || seen[mac] {
+			continue
+		}
+		seen[mac] = true
+		out = append(out, mac)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// equalStrings reports whether a and b hold the same strings in order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
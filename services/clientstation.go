@@ -33,7 +33,7 @@ func (s *clientStationService) ListActive(ctx context.Context, site string) ([]t
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list active clients failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list active clients", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Client](resp.Body)
@@ -66,7 +66,7 @@ func (s *clientStationService) ListAll(ctx context.Context, site string, opts ..
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list all clients failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list all clients", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Client](resp.Body)
@@ -77,6 +77,39 @@ func (s *clientStationService) ListAll(ctx context.Context, site string, opts ..
 	return apiResp.Data, nil
 }
 
+// ListGuests returns guest authorizations from stat/guest.
+func (s *clientStationService) ListGuests(ctx context.Context, site string, opts ...ClientListOption) ([]types.Guest, error) {
+	options := &clientListOptions{
+		withinHours: 24,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	path := internal.BuildAPIPath(site, "stat/guest")
+	if options.withinHours > 0 {
+		path += "?within=" + strconv.Itoa(options.withinHours)
+	}
+
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guests: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list guests", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.Guest](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
 // Get returns a client by MAC address.
 func (s *clientStationService) Get(ctx context.Context, site, mac string) (*types.Client, error) {
 	// Get all active clients and find the one with matching MAC
@@ -91,7 +124,7 @@ func (s *clientStationService) Get(ctx context.Context, site, mac string) (*type
 		}
 	}
 
-	return nil, fmt.Errorf("client not found: %s", mac)
+	return nil, notFoundf("client not found: %s", mac)
 }
 
 // Block blocks a client from the network.
@@ -109,6 +142,12 @@ func (s *clientStationService) Kick(ctx context.Context, site, mac string) error
 	return s.executeCommand(ctx, site, "kick-sta", mac, nil)
 }
 
+// WakeOnLAN asks the controller to send a Wake-on-LAN magic packet to a
+// client. The client does not need to be connected.
+func (s *clientStationService) WakeOnLAN(ctx context.Context, site, mac string) error {
+	return s.executeCommand(ctx, site, "wol", mac, nil)
+}
+
 // AuthorizeGuest authorizes a guest client.
 func (s *clientStationService) AuthorizeGuest(ctx context.Context, site, mac string, opts ...GuestAuthOption) error {
 	options := &guestAuthOptions{}
@@ -139,6 +178,7 @@ func (s *clientStationService) AuthorizeGuest(ctx context.Context, site, mac str
 
 	path := internal.BuildAPIPath(site, "cmd/stamgr")
 	req := transport.NewRequest("POST", path).WithBody(payload)
+	req = withIdempotencyKey(ctx, req)
 
 	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
@@ -146,7 +186,7 @@ func (s *clientStationService) AuthorizeGuest(ctx context.Context, site, mac str
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("authorize guest failed with status %d", resp.StatusCode)
+		return types.NewResponseError("authorize guest", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -184,6 +224,7 @@ func (s *clientStationService) executeCommand(ctx context.Context, site, cmd, ma
 
 	path := internal.BuildAPIPath(site, "cmd/stamgr")
 	req := transport.NewRequest("POST", path).WithBody(payload)
+	req = withIdempotencyKey(ctx, req)
 
 	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
@@ -191,7 +232,7 @@ func (s *clientStationService) executeCommand(ctx context.Context, site, cmd, ma
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("client command %s failed with status %d", cmd, resp.StatusCode)
+		return types.NewResponseError("client command "+cmd, resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -28,12 +28,12 @@ func TestClientService_ListActive(t *testing.T) {
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f1",
 		Hostname: "active-device",
-		LastSeen: now - 60, // Active: 1 minute ago
+		LastSeen: types.NewFlexTime(now - 60), // Active: 1 minute ago
 	})
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f2",
 		Hostname: "inactive-device",
-		LastSeen: now - 600, // Inactive: 10 minutes ago
+		LastSeen: types.NewFlexTime(now - 600), // Inactive: 10 minutes ago
 	})
 
 	// Create service
@@ -65,12 +65,12 @@ func TestClientService_ListAll(t *testing.T) {
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f1",
 		Hostname: "recent-device",
-		LastSeen: now - 3600, // 1 hour ago
+		LastSeen: types.NewFlexTime(now - 3600), // 1 hour ago
 	})
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:f2",
 		Hostname: "old-device",
-		LastSeen: now - 86400*30, // 30 days ago
+		LastSeen: types.NewFlexTime(now - 86400*30), // 30 days ago
 	})
 
 	// Create service
@@ -103,7 +103,7 @@ func TestClientService_Get(t *testing.T) {
 		MAC:      "aa:bb:cc:dd:ee:ff",
 		Hostname: "test-device",
 		IP:       "192.168.1.100",
-		LastSeen: now - 60,
+		LastSeen: types.NewFlexTime(now - 60),
 	})
 
 	// Create service
@@ -148,7 +148,7 @@ func TestClientService_Block(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
-		LastSeen: now,
+		LastSeen: types.NewFlexTime(now),
 	})
 
 	// Create service
@@ -180,7 +180,7 @@ func TestClientService_Unblock(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
-		LastSeen: now,
+		LastSeen: types.NewFlexTime(now),
 		Blocked:  true,
 	})
 
@@ -213,7 +213,7 @@ func TestClientService_Kick(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
-		LastSeen: now,
+		LastSeen: types.NewFlexTime(now),
 	})
 
 	// Create service
@@ -237,6 +237,25 @@ func TestClientService_Kick(t *testing.T) {
 	}
 }
 
+func TestClientService_WakeOnLAN(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestClientTransport(server.URL())
+	svc := NewClientService(trans)
+
+	// The client is asleep, so the controller does not list it.
+	err := svc.WakeOnLAN(context.Background(), "default", "aa:bb:cc:dd:ee:ff")
+	if err != nil {
+		t.Fatalf("WakeOnLAN failed: %v", err)
+	}
+
+	sent := server.State().WakeOnLANSent()
+	if len(sent) != 1 || sent[0] != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("WakeOnLANSent() = %v, want [aa:bb:cc:dd:ee:ff]", sent)
+	}
+}
+
 func TestClientService_Forget(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -245,7 +264,7 @@ func TestClientService_Forget(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
-		LastSeen: now,
+		LastSeen: types.NewFlexTime(now),
 	})
 
 	// Create service
@@ -306,8 +325,8 @@ func TestClientService_UnauthorizeGuest(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:             "aa:bb:cc:dd:ee:f1",
-		LastSeen:        now,
-		IsGuest:         true,
+		LastSeen:        types.NewFlexTime(now),
+		IsGuest:         types.FlexBool{Val: true},
 		GuestAuthorized: true,
 		Authorized:      true,
 	})
@@ -333,6 +352,49 @@ func TestClientService_UnauthorizeGuest(t *testing.T) {
 	}
 }
 
+func TestClientService_ListGuests(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestClientTransport(server.URL())
+	svc := NewClientService(trans)
+	ctx := context.Background()
+
+	if err := svc.AuthorizeGuest(ctx, "default", "aa:bb:cc:dd:ee:f1", WithDuration(60), WithDataLimit(100)); err != nil {
+		t.Fatalf("AuthorizeGuest failed: %v", err)
+	}
+
+	guests, err := svc.ListGuests(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListGuests failed: %v", err)
+	}
+	if len(guests) != 1 {
+		t.Fatalf("Expected 1 guest, got %d", len(guests))
+	}
+
+	g := guests[0]
+	if g.AuthorizedBy != "api" || g.Duration != 60 {
+		t.Errorf("Guest = %+v, want api authorization for 60 minutes", g)
+	}
+	if !g.Active(time.Now()) {
+		t.Error("Expected guest authorization to be active")
+	}
+	if g.QuotaBytes() != 100*1024*1024 {
+		t.Errorf("QuotaBytes() = %d, want 100 MB", g.QuotaBytes())
+	}
+	if end := g.EndTime().Sub(g.StartTime()); end != time.Hour {
+		t.Errorf("EndTime() - StartTime() = %s, want 1h", end)
+	}
+
+	if err := svc.UnauthorizeGuest(ctx, "default", "aa:bb:cc:dd:ee:f1"); err != nil {
+		t.Fatalf("UnauthorizeGuest failed: %v", err)
+	}
+	guests, _ = svc.ListGuests(ctx, "default")
+	if len(guests) != 1 || guests[0].Active(time.Now()) {
+		t.Errorf("Expected expired guest authorization, got %+v", guests)
+	}
+}
+
 func TestClientService_SetFingerprint(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -341,7 +403,7 @@ func TestClientService_SetFingerprint(t *testing.T) {
 	now := time.Now().Unix()
 	server.State().AddClient(&types.Client{
 		MAC:      "aa:bb:cc:dd:ee:ff",
-		LastSeen: now,
+		LastSeen: types.NewFlexTime(now),
 	})
 
 	// Create service
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// CountActive returns the number of connected clients, wired and
+// wireless, users and guests. It reads the controller's stat/health
+// summary instead of listing the clients.
+func (s *clientStationService) CountActive(ctx context.Context, site string) (int, error) {
+	path := internal.BuildAPIPath(site, "stat/health")
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active clients: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return 0, types.NewResponseError("count active clients", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.HealthData](resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, h := range apiResp.Data {
+		if h.Subsystem == "lan" || h.Subsystem == "wlan" {
+			count += h.NumUser + h.NumGuest
+		}
+	}
+	return count, nil
+}
+
+// CountByState returns the number of devices in each state. Only the MAC
+// and state of each device are fetched.
+func (s *deviceService) CountByState(ctx context.Context, site string) (map[types.DeviceState]int, error) {
+	devices, err := s.ListBasic(ctx, site, WithFields("state"))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[types.DeviceState]int)
+	for _, d := range devices {
+		counts[d.State]++
+	}
+	return counts, nil
+}
+
+// CountByType returns the number of devices of each type, such as "uap"
+// and "usw". Only the MAC and type of each device are fetched.
+func (s *deviceService) CountByType(ctx context.Context, site string) (map[string]int, error) {
+	devices, err := s.ListBasic(ctx, site, WithFields("type"))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, d := range devices {
+		counts[d.Type]++
+	}
+	return counts, nil
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestClientService_CountActive(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	now := time.Now().Unix()
+	server.State().AddClient(&types.Client{MAC: "aa:00:00:00:00:01", LastSeen: types.NewFlexTime(now - 60)})
+	server.State().AddClient(&types.Client{MAC: "aa:00:00:00:00:02", LastSeen: types.NewFlexTime(now), IsWired: types.FlexBool{Val: true}})
+	server.State().AddClient(&types.Client{MAC: "aa:00:00:00:00:03", LastSeen: types.NewFlexTime(now), IsGuest: types.FlexBool{Val: true}})
+	server.State().AddClient(&types.Client{MAC: "aa:00:00:00:00:04", LastSeen: types.NewFlexTime(now - 600)})
+
+	trans, _ := newTestClientTransport(server.URL())
+	svc := NewClientService(trans)
+
+	count, err := svc.CountActive(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("CountActive failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("CountActive() = %d, want 3", count)
+	}
+}
+
+func TestDeviceService_CountByState(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:00:00:00:00:01", Type: "uap", State: types.DeviceStateConnected})
+	server.State().AddDevice(&types.Device{ID: "d2", MAC: "aa:00:00:00:00:02", Type: "uap", State: types.DeviceStateConnected})
+	server.State().AddDevice(&types.Device{ID: "d3", MAC: "aa:00:00:00:00:03", Type: "usw", State: types.DeviceStateDisconnected})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	byState, err := svc.CountByState(ctx, "default")
+	if err != nil {
+		t.Fatalf("CountByState failed: %v", err)
+	}
+	if byState[types.DeviceStateConnected] != 2 || byState[types.DeviceStateDisconnected] != 1 || len(byState) != 2 {
+		t.Errorf("CountByState() = %v, want 2 connected and 1 disconnected", byState)
+	}
+
+	byType, err := svc.CountByType(ctx, "default")
+	if err != nil {
+		t.Fatalf("CountByType failed: %v", err)
+	}
+	if byType["uap"] != 2 || byType["usw"] != 1 || len(byType) != 2 {
+		t.Errorf("CountByType() = %v, want 2 uap and 1 usw", byType)
+	}
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// alreadyDeleted reports whether a failed DELETE response means the object
+// does not exist. Deletes treat this as success so that retrying a delete,
+// or deleting something removed out of band, is not an error.
+func alreadyDeleted(resp *transport.Response) bool {
+	if resp.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return bytes.Contains(resp.Body, []byte("api.err.NotFound"))
+}
+
+// deleteFailed returns the error for a failed delete of what. It keeps the
+// controller's message, such as api.err.ObjectReferredBy for an object
+// that is still in use, so callers can tell why with gofi.IsConflict.
+func deleteFailed(what string, resp *transport.Response) error {
+	return types.NewResponseError("delete "+what, resp.StatusCode, resp.Body)
+}
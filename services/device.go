@@ -2,29 +2,67 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
 )
 
+// unlocateTimeout bounds the unset-locate command sent by LocateFor.
+const unlocateTimeout = 30 * time.Second
+
 // deviceService implements DeviceService.
 type deviceService struct {
 	transport transport.Transport
+
+	// Pending LocateFor timers, keyed by site and MAC.
+	locateMu sync.Mutex
+	locates  map[string]*pendingLocate
+
+	// onUnlocateError is called when a LocateFor timer fails to turn the
+	// LED off.
+	onUnlocateError func(site, mac string, err error)
+}
+
+// pendingLocate is a LocateFor timer that has yet to turn an LED off.
+type pendingLocate struct {
+	timer     *time.Timer
+	site, mac string
+}
+
+// DeviceServiceOption configures NewDeviceService.
+type DeviceServiceOption func(*deviceService)
+
+// WithUnlocateErrorHandler sets the function called when the timer of a
+// LocateFor call fails to turn the LED off. Without it, such errors are
+// dropped.
+func WithUnlocateErrorHandler(fn func(site, mac string, err error)) DeviceServiceOption {
+	return func(s *deviceService) {
+		s.onUnlocateError = fn
+	}
 }
 
 // NewDeviceService creates a new device service.
-func NewDeviceService(transport transport.Transport) DeviceService {
-	return &deviceService{
+func NewDeviceService(transport transport.Transport, opts ...DeviceServiceOption) DeviceService {
+	s := &deviceService{
 		transport: transport,
+		locates:   make(map[string]*pendingLocate),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // List returns all devices for a site.
-func (s *deviceService) List(ctx context.Context, site string) ([]types.Device, error) {
-	path := internal.BuildAPIPath(site, "stat/device")
+func (s *deviceService) List(ctx context.Context, site string, opts ...DeviceListOption) ([]types.Device, error) {
+	path := deviceListPath(site, "stat/device", opts)
 	req := transport.NewRequest("GET", path)
 
 	resp, err := s.transport.Do(ctx, req)
@@ -33,7 +71,7 @@ func (s *deviceService) List(ctx context.Context, site string) ([]types.Device,
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list devices failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list devices", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Device](resp.Body)
@@ -45,8 +83,8 @@ func (s *deviceService) List(ctx context.Context, site string) ([]types.Device,
 }
 
 // ListBasic returns basic device information for faster queries.
-func (s *deviceService) ListBasic(ctx context.Context, site string) ([]types.DeviceBasic, error) {
-	path := internal.BuildAPIPath(site, "basicstat/device")
+func (s *deviceService) ListBasic(ctx context.Context, site string, opts ...DeviceListOption) ([]types.DeviceBasic, error) {
+	path := deviceListPath(site, "basicstat/device", opts)
 	req := transport.NewRequest("GET", path)
 
 	resp, err := s.transport.Do(ctx, req)
@@ -55,7 +93,7 @@ func (s *deviceService) ListBasic(ctx context.Context, site string) ([]types.Dev
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list basic devices failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list basic devices", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.DeviceBasic](resp.Body)
@@ -66,6 +104,28 @@ func (s *deviceService) ListBasic(ctx context.Context, site string) ([]types.Dev
 	return apiResp.Data, nil
 }
 
+// deviceListPath builds the path of a device list endpoint, with the
+// fields selected by opts as the attrs query parameter.
+func deviceListPath(site, endpoint string, opts []DeviceListOption) string {
+	var options deviceListOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	path := internal.BuildAPIPath(site, endpoint)
+	if len(options.fields) == 0 {
+		return path
+	}
+
+	fields := []string{"mac"}
+	for _, f := range options.fields {
+		if f = strings.TrimSpace(f); f != "" && f != "mac" {
+			fields = append(fields, f)
+		}
+	}
+	return path + "?" + url.Values{"attrs": {strings.Join(fields, ",")}}.Encode()
+}
+
 // Get returns a specific device by ID.
 func (s *deviceService) Get(ctx context.Context, site, id string) (*types.Device, error) {
 	devices, err := s.List(ctx, site)
@@ -79,7 +139,7 @@ func (s *deviceService) Get(ctx context.Context, site, id string) (*types.Device
 		}
 	}
 
-	return nil, fmt.Errorf("device not found: %s", id)
+	return nil, notFoundf("device not found: %s", id)
 }
 
 // GetByMAC returns a specific device by MAC address.
@@ -89,17 +149,10 @@ func (s *deviceService) GetByMAC(ctx context.Context, site, mac string) (*types.
 		return nil, err
 	}
 
-	// Normalize MAC for comparison
-	normalizedMAC := strings.ToLower(strings.ReplaceAll(mac, ":", ""))
-
-	for _, device := range devices {
-		deviceMAC := strings.ToLower(strings.ReplaceAll(device.MAC, ":", ""))
-		if deviceMAC == normalizedMAC {
-			return &device, nil
-		}
+	if device := findDeviceByMAC(devices, mac); device != nil {
+		return device, nil
 	}
-
-	return nil, fmt.Errorf("device not found with MAC: %s", mac)
+	return nil, notFoundf("device not found with MAC: %s", mac)
 }
 
 // Update updates a device's configuration.
@@ -113,7 +166,7 @@ func (s *deviceService) Update(ctx context.Context, site string, device *types.D
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update device failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update device", resp.StatusCode, resp.Body)
 	}
 
 	updated, err := internal.ParseSingleResult[types.Device](resp.Body)
@@ -129,6 +182,35 @@ func (s *deviceService) Adopt(ctx context.Context, site, mac string) error {
 	return s.sendCommand(ctx, site, "adopt", mac, nil)
 }
 
+// AdoptAdvanced adopts a device on a network the controller cannot reach
+// with a plain adopt, such as a remote L3 site. The controller logs in to
+// the device over SSH with username and password and points it at the
+// inform URL url (e.g. "http://unifi.example.com:8080/inform"). The device
+// must already be listed, since its IP address is taken from the device
+// list.
+func (s *deviceService) AdoptAdvanced(ctx context.Context, site, mac, url, username, password string) error {
+	if url == "" || username == "" || password == "" {
+		return fmt.Errorf("inform URL, username and password are required for advanced adoption")
+	}
+
+	device, err := s.GetByMAC(ctx, site, mac)
+	if err != nil {
+		return err
+	}
+	if device.IP == "" {
+		return fmt.Errorf("device %s has no known IP address", mac)
+	}
+
+	return s.sendCommand(ctx, site, "adv-adopt", device.MAC, map[string]interface{}{
+		"ip":           device.IP,
+		"url":          url,
+		"username":     username,
+		"password":     password,
+		"port":         "22",
+		"sshKeyVerify": false,
+	})
+}
+
 // Forget removes a device from the controller.
 func (s *deviceService) Forget(ctx context.Context, site, mac string) error {
 	return s.sendCommand(ctx, site, "forget", mac, nil)
@@ -161,11 +243,94 @@ func (s *deviceService) Locate(ctx context.Context, site, mac string) error {
 	return s.sendCommand(ctx, site, "set-locate", mac, nil)
 }
 
-// Unlocate disables the locate LED on a device.
+// Unlocate disables the locate LED on a device and cancels any pending
+// LocateFor timer for it.
 func (s *deviceService) Unlocate(ctx context.Context, site, mac string) error {
+	s.stopLocateTimer(site, mac)
 	return s.sendCommand(ctx, site, "unset-locate", mac, nil)
 }
 
+// LocateFor enables the locate LED on a device and turns it off again
+// after d. The off command is sent from a timer that does not depend on
+// ctx, so it is sent even if the caller returns or ctx is canceled; it
+// cannot survive the process exiting, and UnlocatePending sends it early.
+// Calling LocateFor again for the same device restarts the timer, and
+// Unlocate cancels it.
+func (s *deviceService) LocateFor(ctx context.Context, site, mac string, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("locate duration must be positive, got %s", d)
+	}
+	if err := s.Locate(ctx, site, mac); err != nil {
+		return err
+	}
+
+	key := locateKey(site, mac)
+	s.locateMu.Lock()
+	defer s.locateMu.Unlock()
+
+	if p := s.locates[key]; p != nil {
+		p.timer.Stop()
+	}
+	pending := &pendingLocate{site: site, mac: mac}
+	pending.timer = time.AfterFunc(d, func() {
+		s.locateMu.Lock()
+		if s.locates[key] != pending {
+			// Replaced by a later LocateFor or taken by Unlocate or
+			// UnlocatePending.
+			s.locateMu.Unlock()
+			return
+		}
+		delete(s.locates, key)
+		s.locateMu.Unlock()
+
+		offCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlocateTimeout)
+		defer cancel()
+		if err := s.sendCommand(offCtx, site, "unset-locate", mac, nil); err != nil && s.onUnlocateError != nil {
+			s.onUnlocateError(site, mac, err)
+		}
+	})
+	s.locates[key] = pending
+	return nil
+}
+
+// UnlocatePending turns off the LED of every device with a pending
+// LocateFor timer now, canceling the timers. It returns the errors of
+// the devices it could not reach.
+func (s *deviceService) UnlocatePending(ctx context.Context) error {
+	s.locateMu.Lock()
+	pending := make([]*pendingLocate, 0, len(s.locates))
+	for key, p := range s.locates {
+		p.timer.Stop()
+		delete(s.locates, key)
+		pending = append(pending, p)
+	}
+	s.locateMu.Unlock()
+
+	var errs []error
+	for _, p := range pending {
+		if err := s.sendCommand(ctx, p.site, "unset-locate", p.mac, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to turn off locate LED of %s: %w", p.mac, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stopLocateTimer cancels a pending LocateFor timer.
+func (s *deviceService) stopLocateTimer(site, mac string) {
+	key := locateKey(site, mac)
+	s.locateMu.Lock()
+	defer s.locateMu.Unlock()
+	if p := s.locates[key]; p != nil {
+		p.timer.Stop()
+		delete(s.locates, key)
+	}
+}
+
+// locateKey identifies a device for LocateFor timers.
+func locateKey(site, mac string) string {
+	return site + "/" + strings.ToLower(mac)
+}
+
 // PowerCyclePort power cycles a specific port on a switch.
 func (s *deviceService) PowerCyclePort(ctx context.Context, site, switchMAC string, portIdx int) error {
 	return s.sendCommand(ctx, site, "power-cycle", switchMAC, map[string]interface{}{
@@ -192,7 +357,9 @@ func (s *deviceService) sendCommand(ctx context.Context, site, cmd, mac string,
 	// Build command request
 	cmdReq := map[string]interface{}{
 		"cmd": cmd,
-		"mac": mac,
+	}
+	if mac != "" {
+		cmdReq["mac"] = mac
 	}
 
 	// Add additional parameters
@@ -201,6 +368,7 @@ func (s *deviceService) sendCommand(ctx context.Context, site, cmd, mac string,
 	}
 
 	req := transport.NewRequest("POST", path).WithBody(cmdReq)
+	req = withIdempotencyKey(ctx, req)
 
 	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
@@ -208,7 +376,7 @@ func (s *deviceService) sendCommand(ctx context.Context, site, cmd, mac string,
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("command %s failed with status %d", cmd, resp.StatusCode)
+		return types.NewResponseError("command "+cmd, resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -3,7 +3,10 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/unifi-go/gofi/mock"
 	"github.com/unifi-go/gofi/transport"
@@ -90,6 +93,48 @@ func TestDeviceService_ListBasic(t *testing.T) {
 	}
 }
 
+func TestDeviceService_ListWithFields(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:      "device1",
+		MAC:     "aa:bb:cc:dd:ee:f1",
+		Model:   "UAP-AC-PRO",
+		Type:    "uap",
+		Name:    "AP 1",
+		Version: "6.5.28",
+		State:   types.DeviceStateConnected,
+	})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	devices, err := svc.List(ctx, "default", WithFields("name", "state"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("Expected 1 device, got %d", len(devices))
+	}
+	d := devices[0]
+	if d.MAC != "aa:bb:cc:dd:ee:f1" || d.Name != "AP 1" || d.State != types.DeviceStateConnected {
+		t.Errorf("selected fields = %q %q %v, want MAC, name and state", d.MAC, d.Name, d.State)
+	}
+	if d.Model != "" || d.Version != "" || d.ID != "" {
+		t.Errorf("unselected fields returned: model %q, version %q, id %q", d.Model, d.Version, d.ID)
+	}
+
+	basics, err := svc.ListBasic(ctx, "default", WithFields("state"))
+	if err != nil {
+		t.Fatalf("ListBasic failed: %v", err)
+	}
+	if len(basics) != 1 || basics[0].MAC != "aa:bb:cc:dd:ee:f1" || basics[0].State != types.DeviceStateConnected || basics[0].Model != "" {
+		t.Errorf("ListBasic() = %+v, want only MAC and state", basics)
+	}
+}
+
 func TestDeviceService_Get(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -122,6 +167,9 @@ func TestDeviceService_Get(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for nonexistent device")
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
 }
 
 func TestDeviceService_GetByMAC(t *testing.T) {
@@ -244,6 +292,40 @@ func TestDeviceService_Adopt(t *testing.T) {
 	}
 }
 
+func TestDeviceService_AdoptAdvanced(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:    "device1",
+		MAC:   "aa:bb:cc:dd:ee:f1",
+		IP:    "10.20.0.5",
+		Type:  "usw",
+		State: types.DeviceStatePending,
+	})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+	informURL := "http://unifi.example.com:8080/inform"
+
+	if err := svc.AdoptAdvanced(ctx, "default", "aa:bb:cc:dd:ee:f1", informURL, "ubnt", ""); err == nil {
+		t.Error("AdoptAdvanced() without password succeeded, want error")
+	}
+	if err := svc.AdoptAdvanced(ctx, "default", "aa:bb:cc:dd:ee:99", informURL, "ubnt", "ubnt"); err == nil {
+		t.Error("AdoptAdvanced() of unknown device succeeded, want error")
+	}
+
+	if err := svc.AdoptAdvanced(ctx, "default", "AA:BB:CC:DD:EE:F1", informURL, "ubnt", "ubnt"); err != nil {
+		t.Fatalf("AdoptAdvanced() error = %v", err)
+	}
+
+	device, _ := server.State().GetDevice("device1")
+	if !device.Adopted || device.InformURL != informURL {
+		t.Errorf("device adopted = %v, inform URL = %q, want adopted with %q", device.Adopted, device.InformURL, informURL)
+	}
+}
+
 func TestDeviceService_Restart(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -462,3 +544,336 @@ func TestDeviceService_SpectrumScan(t *testing.T) {
 		t.Fatalf("SpectrumScan failed: %v", err)
 	}
 }
+
+func TestDeviceService_LocateFor(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device1", MAC: "aa:bb:cc:dd:ee:f1", Type: "uap"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := svc.LocateFor(ctx, "default", "aa:bb:cc:dd:ee:f1", 30*time.Millisecond); err != nil {
+		t.Fatalf("LocateFor() error = %v", err)
+	}
+	// The LED must still go off after the caller's context is gone.
+	cancel()
+
+	device, _ := server.State().GetDevice("device1")
+	if device.LEDOverride != "on" {
+		t.Fatalf("LEDOverride = %q, want on", device.LEDOverride)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		device, _ = server.State().GetDevice("device1")
+		if device.LEDOverride == "default" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("LEDOverride = %q after duration, want default", device.LEDOverride)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := svc.LocateFor(context.Background(), "default", "aa:bb:cc:dd:ee:f1", 0); err == nil {
+		t.Error("LocateFor() with zero duration succeeded, want error")
+	}
+}
+
+func TestDeviceService_UnlocatePending(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device1", MAC: "aa:bb:cc:dd:ee:f1", Type: "uap"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	if err := svc.LocateFor(ctx, "default", "aa:bb:cc:dd:ee:f1", time.Hour); err != nil {
+		t.Fatalf("LocateFor() error = %v", err)
+	}
+	if err := svc.UnlocatePending(ctx); err != nil {
+		t.Fatalf("UnlocatePending() error = %v", err)
+	}
+	if device, _ := server.State().GetDevice("device1"); device.LEDOverride != "default" {
+		t.Errorf("LEDOverride = %q after UnlocatePending(), want default", device.LEDOverride)
+	}
+
+	// The timers are gone, so there is nothing left to turn off.
+	server.Close()
+	if err := svc.UnlocatePending(ctx); err != nil {
+		t.Errorf("second UnlocatePending() error = %v", err)
+	}
+}
+
+func TestDeviceService_LocateForError(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device1", MAC: "aa:bb:cc:dd:ee:f1", Type: "uap"})
+
+	failed := make(chan string, 1)
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans, WithUnlocateErrorHandler(func(site, mac string, err error) {
+		failed <- mac
+	}))
+
+	if err := svc.LocateFor(context.Background(), "default", "aa:bb:cc:dd:ee:f1", 30*time.Millisecond); err != nil {
+		t.Fatalf("LocateFor() error = %v", err)
+	}
+	server.Close()
+
+	select {
+	case mac := <-failed:
+		if mac != "aa:bb:cc:dd:ee:f1" {
+			t.Errorf("handler mac = %s, want aa:bb:cc:dd:ee:f1", mac)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("unlocate error handler not called")
+	}
+}
+
+func TestDeviceService_RestartMany(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	macs := []string{"aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02", "aa:bb:cc:dd:ee:03"}
+	for i, mac := range macs {
+		server.State().AddDevice(&types.Device{
+			ID:     fmt.Sprintf("device%d", i),
+			MAC:    mac,
+			Type:   "uap",
+			State:  types.DeviceStateConnected,
+			Uptime: types.FlexInt{Val: 86400},
+		})
+	}
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	report, err := svc.RestartMany(ctx, "default", macs, WithRolling(2), WithPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("RestartMany() error = %v", err)
+	}
+	if report.Succeeded != 3 || report.Failed != 0 || report.Skipped != 0 {
+		t.Errorf("report = %+v, want 3 succeeded", report)
+	}
+	for _, res := range report.Results {
+		if res.Downtime <= 0 {
+			t.Errorf("%s: Downtime = %s, want measured downtime", res.MAC, res.Downtime)
+		}
+	}
+
+	// A failed batch stops the rollout.
+	report, err = svc.RestartMany(ctx, "default", []string{"aa:bb:cc:dd:ee:99", macs[0], macs[1]},
+		WithRolling(1), WithPollInterval(10*time.Millisecond))
+	if err == nil {
+		t.Fatal("RestartMany() with unknown device succeeded, want error")
+	}
+	if report.Failed != 1 || report.Skipped != 2 || !report.Results[1].Skipped {
+		t.Errorf("report = %+v, want 1 failed and 2 skipped", report)
+	}
+
+	// Without waiting, every device is restarted in parallel.
+	report, err = svc.RestartMany(ctx, "default", append([]string{"aa:bb:cc:dd:ee:99"}, macs...))
+	if err == nil || report.Failed != 1 || report.Succeeded != 3 {
+		t.Errorf("parallel RestartMany() = %+v, %v, want 3 succeeded and 1 failed", report, err)
+	}
+}
+
+func TestDeviceService_RestartMany_CommandDelays(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(),
+		mock.WithCommandDelays(mock.CommandDelays{Restart: 50 * time.Millisecond}))
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device0", MAC: "aa:bb:cc:dd:ee:01", Type: "uap",
+		State: types.DeviceStateConnected, Uptime: types.FlexInt{Val: 86400}})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+
+	report, err := svc.RestartMany(context.Background(), "default", []string{"aa:bb:cc:dd:ee:01"},
+		WithWaitForReturn(time.Second), WithPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("RestartMany() error = %v", err)
+	}
+	if d := report.Results[0].Downtime; d < 50*time.Millisecond {
+		t.Errorf("Downtime = %s, want at least the simulated 50ms", d)
+	}
+}
+
+func TestDeviceService_RestartMany_Options(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device0", MAC: "aa:bb:cc:dd:ee:01", Type: "uap",
+		State: types.DeviceStateConnected, Uptime: types.FlexInt{Val: 86400}})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+
+	// Non-positive values keep the defaults rather than panicking or
+	// timing out at once, and the MAC may use any notation.
+	report, err := svc.RestartMany(context.Background(), "default", []string{"AA-BB-CC-DD-EE-01"},
+		WithWaitForReturn(0), WithPollInterval(0))
+	if err != nil {
+		t.Fatalf("RestartMany() error = %v", err)
+	}
+	if report.Succeeded != 1 {
+		t.Errorf("report = %+v, want 1 succeeded", report)
+	}
+}
+
+func TestFindDeviceByMAC(t *testing.T) {
+	devices := []types.Device{{MAC: "aa:bb:cc:dd:ee:01"}, {MAC: "aa:bb:cc:dd:ee:02"}}
+	for _, mac := range []string{"aa:bb:cc:dd:ee:02", "AA:BB:CC:DD:EE:02", "aa-bb-cc-dd-ee-02", "aabbccddee02"} {
+		if d := findDeviceByMAC(devices, mac); d == nil || d.MAC != "aa:bb:cc:dd:ee:02" {
+			t.Errorf("findDeviceByMAC(%q) = %v, want the second device", mac, d)
+		}
+	}
+	if d := findDeviceByMAC(devices, "aa:bb:cc:dd:ee:03"); d != nil {
+		t.Errorf("findDeviceByMAC() of an unknown MAC = %v, want nil", d)
+	}
+}
+
+func TestDeviceService_WaitForProvision(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(),
+		mock.WithCommandDelays(mock.CommandDelays{Provision: 50 * time.Millisecond}))
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "device0", MAC: "aa:bb:cc:dd:ee:01", Type: "uap",
+		State: types.DeviceStateConnected, ConfigVersion: "0000000000000001"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	before, _ := svc.GetByMAC(ctx, "default", "aa:bb:cc:dd:ee:01")
+	before.Name = "lobby-ap"
+	if _, err := svc.Update(ctx, "default", before); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	d, err := svc.WaitForProvision(ctx, "default", "aa:bb:cc:dd:ee:01", before.ConfigVersion,
+		WithProvisionPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("WaitForProvision() error = %v", err)
+	}
+	if d.ConfigVersion == before.ConfigVersion || d.State != types.DeviceStateConnected || d.Name != "lobby-ap" {
+		t.Errorf("WaitForProvision() = %+v, want connected lobby-ap with a new cfgversion", d)
+	}
+
+	// Nothing changes without a new provisioning run.
+	_, err = svc.WaitForProvision(ctx, "default", "aa:bb:cc:dd:ee:01", d.ConfigVersion,
+		WithProvisionTimeout(50*time.Millisecond), WithProvisionPollInterval(10*time.Millisecond))
+	if err == nil {
+		t.Error("WaitForProvision() without a change succeeded, want timeout")
+	}
+}
+
+func TestDeviceService_Topology(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "gw", MAC: "aa:00:00:00:00:01", Name: "gateway", Type: "udm"})
+	server.State().AddDevice(&types.Device{ID: "sw", MAC: "aa:00:00:00:00:02", Name: "core", Type: "usw",
+		Uplink:    &types.DeviceUplink{UplinkMAC: "aa:00:00:00:00:01", UplinkRemotePort: 4, Speed: 10000},
+		PortTable: []types.PortTable{{PortIdx: 3, Speed: 1000, PoeEnable: true, PoeGood: true, PoePower: types.FlexInt{Val: 6.5}}}})
+	server.State().AddDevice(&types.Device{ID: "ap", MAC: "aa:00:00:00:00:03", Name: "office-ap", Type: "uap",
+		Uplink: &types.DeviceUplink{UplinkMAC: "aa:00:00:00:00:02", UplinkRemotePort: 3}})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:01", Hostname: "laptop",
+		APMA: "aa:00:00:00:00:03", LastSeen: types.NewFlexTime(time.Now().Unix())})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+
+	graph, err := svc.Topology(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("Topology() error = %v", err)
+	}
+	if len(graph.Nodes) != 4 || len(graph.Edges) != 3 {
+		t.Fatalf("Topology() = %d nodes, %d edges, want 4 and 3", len(graph.Nodes), len(graph.Edges))
+	}
+
+	ap := graph.Uplink("aa:00:00:00:00:03")
+	if ap == nil || ap.From != "aa:00:00:00:00:02" || ap.Port != 3 || ap.Speed != 1000 || !ap.PoE || ap.PoEPower != 6.5 {
+		t.Errorf("AP uplink = %+v, want PoE on core port 3 at 1000 Mbps", ap)
+	}
+	if laptop := graph.Uplink("cc:00:00:00:00:01"); laptop == nil || !laptop.Wireless {
+		t.Errorf("laptop uplink = %+v, want wireless via the AP", laptop)
+	}
+	if graph.Node("aa:00:00:00:00:01").Kind != types.TopologyGateway {
+		t.Errorf("gateway kind = %s", graph.Node("aa:00:00:00:00:01").Kind)
+	}
+}
+
+func TestDeviceService_PoEBudget(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:            "switch1",
+		MAC:           "aa:bb:cc:dd:ee:f2",
+		Type:          "usw",
+		TotalMaxPower: 95,
+		PortTable: []types.PortTable{
+			{PortIdx: 1, PortPoe: true, PoeEnable: true, PoeGood: true, PoeClass: "Class 4", PoePower: types.FlexInt{Val: 8}},
+			{PortIdx: 2},
+		},
+	})
+	server.State().AddDevice(&types.Device{ID: "ap1", MAC: "aa:bb:cc:dd:ee:f1", Type: "uap"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+
+	budget, err := svc.PoEBudget(context.Background(), "default", "AA:BB:CC:DD:EE:F2")
+	if err != nil {
+		t.Fatalf("PoEBudget failed: %v", err)
+	}
+	if budget.TotalWatts != 95 || budget.AllocatedWatts != 30 || budget.DrawnWatts != 8 || len(budget.Ports) != 1 {
+		t.Errorf("budget = %+v", budget)
+	}
+
+	if _, err := svc.PoEBudget(context.Background(), "default", "aa:bb:cc:dd:ee:f1"); err == nil {
+		t.Error("Expected error for device without PoE ports")
+	}
+}
+
+func TestDeviceService_Placements(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{ID: "ap1", MAC: "aa:bb:cc:dd:ee:01", Type: "uap", Name: "Lobby"})
+	server.State().AddDevice(&types.Device{ID: "ap2", MAC: "aa:bb:cc:dd:ee:02", Type: "uap", MapID: "floor2", X: 1, Y: 2})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	err := svc.SetPlacement(ctx, "default", "aa:bb:cc:dd:ee:01", types.APPlacement{MapID: "floor1", X: 12.5, Y: 40, HeightInMeters: 2.7})
+	if err != nil {
+		t.Fatalf("SetPlacement failed: %v", err)
+	}
+
+	placements, err := svc.Placements(ctx, "default", "floor1")
+	if err != nil {
+		t.Fatalf("Placements failed: %v", err)
+	}
+	want := types.APPlacement{MAC: "aa:bb:cc:dd:ee:01", Name: "Lobby", MapID: "floor1", X: 12.5, Y: 40, HeightInMeters: 2.7}
+	if len(placements) != 1 || placements[0] != want {
+		t.Errorf("Placements(floor1) = %+v, want %+v", placements, want)
+	}
+
+	if all, _ := svc.Placements(ctx, "default", ""); len(all) != 2 {
+		t.Errorf("Placements() = %d devices, want 2", len(all))
+	}
+
+	if err := svc.SetPlacement(ctx, "default", "aa:bb:cc:dd:ee:01", types.APPlacement{X: 1}); err == nil {
+		t.Error("Expected error without map ID")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/unifi-go/gofi/internal"
+)
+
+// DeviceCommand is the name of a devmgr command.
+type DeviceCommand string
+
+// Device commands accepted by Command. Most have a dedicated method as
+// well; the rest are only reachable through Command.
+const (
+	DeviceCmdAdopt               DeviceCommand = "adopt"
+	DeviceCmdRestart             DeviceCommand = "restart"
+	DeviceCmdForceProvision      DeviceCommand = "force-provision"
+	DeviceCmdUpgrade             DeviceCommand = "upgrade"
+	DeviceCmdUpgradeExternal     DeviceCommand = "upgrade-external"
+	DeviceCmdSetLocate           DeviceCommand = "set-locate"
+	DeviceCmdUnsetLocate         DeviceCommand = "unset-locate"
+	DeviceCmdPowerCycle          DeviceCommand = "power-cycle"
+	DeviceCmdSpectrumScan        DeviceCommand = "spectrum-scan"
+	DeviceCmdMigrate             DeviceCommand = "migrate"
+	DeviceCmdCancelMigrate       DeviceCommand = "cancel-migrate"
+	DeviceCmdSpeedTest           DeviceCommand = "speedtest"
+	DeviceCmdSpeedTestStatus     DeviceCommand = "speedtest-status"
+	DeviceCmdSetRollUpgrade      DeviceCommand = "set-rollupgrade"
+	DeviceCmdUnsetRollUpgrade    DeviceCommand = "unset-rollupgrade"
+	DeviceCmdCheckFirmwareUpdate DeviceCommand = "check-firmware-update"
+)
+
+// ErrUnknownDeviceCommand is returned by Command for a command that is not
+// on its allowlist.
+var ErrUnknownDeviceCommand = errors.New("unknown device command")
+
+// deviceCommands is the allowlist for Command: the parameters each command
+// requires.
+var deviceCommands = map[DeviceCommand][]string{
+	DeviceCmdAdopt:               {"mac"},
+	DeviceCmdRestart:             {"mac"},
+	DeviceCmdForceProvision:      {"mac"},
+	DeviceCmdUpgrade:             {"mac"},
+	DeviceCmdUpgradeExternal:     {"mac", "url"},
+	DeviceCmdSetLocate:           {"mac"},
+	DeviceCmdUnsetLocate:         {"mac"},
+	DeviceCmdPowerCycle:          {"mac", "port_idx"},
+	DeviceCmdSpectrumScan:        {"mac"},
+	DeviceCmdMigrate:             {"mac", "inform_url"},
+	DeviceCmdCancelMigrate:       {"mac"},
+	DeviceCmdSpeedTest:           nil,
+	DeviceCmdSpeedTestStatus:     nil,
+	DeviceCmdSetRollUpgrade:      nil,
+	DeviceCmdUnsetRollUpgrade:    nil,
+	DeviceCmdCheckFirmwareUpdate: nil,
+}
+
+// DeviceCommands returns the commands accepted by Command, sorted.
+func DeviceCommands() []DeviceCommand {
+	cmds := make([]DeviceCommand, 0, len(deviceCommands))
+	for cmd := range deviceCommands {
+		cmds = append(cmds, cmd)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i] < cmds[j] })
+	return cmds
+}
+
+// Command sends a devmgr command with params as its payload, for commands
+// that have no dedicated method. The command must be on the allowlist
+// (see DeviceCommands) and params must hold the fields it requires; a
+// "mac" field must be a valid MAC address. Nothing is sent if validation
+// fails.
+func (s *deviceService) Command(ctx context.Context, site string, cmd DeviceCommand, params map[string]interface{}) error {
+	required, ok := deviceCommands[cmd]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownDeviceCommand, cmd)
+	}
+	if _, ok := params["cmd"]; ok {
+		return fmt.Errorf("command %s: params must not set cmd", cmd)
+	}
+	for _, key := range required {
+		if v, ok := params[key]; !ok || v == nil || v == "" {
+			return fmt.Errorf("command %s requires %s", cmd, key)
+		}
+	}
+
+	var mac string
+	if v, ok := params["mac"]; ok {
+		mac, ok = v.(string)
+		if !ok || !internal.ValidateMAC(mac) {
+			return fmt.Errorf("command %s: invalid MAC address %v", cmd, v)
+		}
+	}
+
+	extra := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if k != "mac" {
+			extra[k] = v
+		}
+	}
+	return s.sendCommand(ctx, site, string(cmd), mac, extra)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestDeviceService_Command(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:    "device1",
+		MAC:   "aa:bb:cc:dd:ee:f1",
+		Model: "UAP-AC-PRO",
+		Type:  "uap",
+	})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	err := svc.Command(ctx, "default", DeviceCmdMigrate, map[string]interface{}{
+		"mac":        "aa:bb:cc:dd:ee:f1",
+		"inform_url": "http://unifi.example.com:8080/inform",
+	})
+	if err != nil {
+		t.Fatalf("Command(migrate) failed: %v", err)
+	}
+
+	if err := svc.Command(ctx, "default", DeviceCmdSpeedTest, nil); err != nil {
+		t.Fatalf("Command(speedtest) failed: %v", err)
+	}
+
+	// A device the controller does not know is still rejected by it.
+	if err := svc.Command(ctx, "default", DeviceCmdCancelMigrate, map[string]interface{}{"mac": "aa:bb:cc:dd:ee:99"}); err == nil {
+		t.Error("Command(cancel-migrate) for unknown device succeeded")
+	}
+}
+
+func TestDeviceService_CommandValidation(t *testing.T) {
+	// Nothing is sent, so no server is needed.
+	trans, _ := newTestTransport("http://127.0.0.1:1")
+	svc := NewDeviceService(trans)
+	ctx := context.Background()
+
+	err := svc.Command(ctx, "default", "factory-reset-everything", nil)
+	if !errors.Is(err, ErrUnknownDeviceCommand) {
+		t.Errorf("Command(unknown) error = %v, want ErrUnknownDeviceCommand", err)
+	}
+
+	tests := map[string]map[string]interface{}{
+		"missing mac":    {"url": "http://example.com/fw.bin"},
+		"missing url":    {"mac": "aa:bb:cc:dd:ee:f1"},
+		"invalid mac":    {"mac": "not-a-mac", "url": "http://example.com/fw.bin"},
+		"non-string mac": {"mac": 42, "url": "http://example.com/fw.bin"},
+		"cmd override":   {"cmd": "restart", "mac": "aa:bb:cc:dd:ee:f1", "url": "http://example.com/fw.bin"},
+	}
+	for name, params := range tests {
+		if err := svc.Command(ctx, "default", DeviceCmdUpgradeExternal, params); err == nil {
+			t.Errorf("%s: Command(upgrade-external) succeeded, want validation error", name)
+		}
+	}
+
+	if got := DeviceCommands(); len(got) != len(deviceCommands) || got[0] != DeviceCmdAdopt {
+		t.Errorf("DeviceCommands() = %v", got)
+	}
+}
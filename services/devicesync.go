@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+	"github.com/unifi-go/gofi/websocket"
+)
+
+// SubscribeDeviceUpdates opens a WebSocket of its own to a site and
+// delivers the device state it pushes. The channels are closed when the
+// connection ends, ctx is done or the service is closed.
+func (e *eventService) SubscribeDeviceUpdates(ctx context.Context, site string) (<-chan types.Device, <-chan error, error) {
+	client, err := e.dial(ctx, internal.BuildWebSocketPath(site))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := e.track(client); err != nil {
+		return nil, nil, err
+	}
+
+	deviceCh := make(chan types.Device, 100)
+	errorCh := make(chan error, 10)
+	go e.deviceLoop(ctx, client, deviceCh, errorCh)
+
+	return deviceCh, errorCh, nil
+}
+
+// deviceLoop reads device:sync and device:update messages until the
+// connection fails, ctx is done or the service is closed. Unlike events,
+// device updates are never dropped; a full channel holds up the stream.
+func (e *eventService) deviceLoop(ctx context.Context, client *websocket.Client, deviceCh chan<- types.Device, errorCh chan<- error) {
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer func() {
+		stop()
+		e.untrack(client)
+		close(deviceCh)
+		close(errorCh)
+	}()
+
+	report := func(err error) {
+		select {
+		case errorCh <- err:
+		default:
+		}
+	}
+
+	for {
+		message, err := client.ReadMessage()
+		if err != nil {
+			if ctx.Err() == nil && !e.closed() {
+				report(fmt.Errorf("read error: %w", err))
+			}
+			return
+		}
+
+		devices, err := parseDeviceUpdates(message)
+		if err != nil {
+			report(fmt.Errorf("parse error: %w", err))
+			continue
+		}
+
+		for _, device := range devices {
+			select {
+			case deviceCh <- device:
+			case <-ctx.Done():
+				return
+			case <-e.closeCh:
+				return
+			}
+		}
+	}
+}
+
+// closed reports whether Close has been called.
+func (e *eventService) closed() bool {
+	select {
+	case <-e.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseDeviceUpdates returns the devices of a device:sync or device:update
+// message, and none for other messages.
+func parseDeviceUpdates(message []byte) ([]types.Device, error) {
+	var msg types.WebSocketMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return nil, err
+	}
+
+	switch msg.Meta.Message {
+	case types.WebSocketMessageDeviceSync, types.WebSocketMessageDeviceUpdate:
+	default:
+		return nil, nil
+	}
+
+	var devices []types.Device
+	if err := json.Unmarshal(msg.Data, &devices); err != nil {
+		return nil, err
+	}
+
+	// Updates without a MAC address cannot be matched to a device.
+	return slices.DeleteFunc(devices, func(d types.Device) bool { return d.MAC == "" }), nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestEventService_SubscribeDeviceUpdates(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	svc := NewEventService(server.URL(), &tls.Config{InsecureSkipVerify: true})
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	devices, errs, err := svc.SubscribeDeviceUpdates(ctx, "default")
+	if err != nil {
+		t.Fatalf("SubscribeDeviceUpdates() error = %v", err)
+	}
+
+	// The mock registers connections asynchronously, so keep sending the
+	// full state until it arrives.
+	sw := &types.Device{MAC: "00:11:22:33:44:55", Type: "usw", State: types.DeviceStateConnected}
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	var first types.Device
+	for first.MAC == "" {
+		select {
+		case first = <-devices:
+		case <-tick.C:
+			server.SimulateDeviceUpdate("default", sw)
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for device:sync")
+		}
+	}
+	if first.Type != "usw" || first.State != types.DeviceStateConnected {
+		t.Errorf("device:sync = %+v", first)
+	}
+
+	server.BroadcastMessage("default", types.WebSocketMessageDeviceUpdate, map[string]interface{}{
+		"mac":        sw.MAC,
+		"port_table": []map[string]interface{}{{"port_idx": 3, "poe_enable": true, "poe_good": true}},
+	})
+	for {
+		select {
+		case update := <-devices:
+			if update.Type != "" {
+				continue // a sync sent before the update
+			}
+			if update.MAC != sw.MAC || len(update.PortTable) != 1 || !update.PortTable[0].PoeGood {
+				t.Errorf("device:update = %+v", update)
+			}
+			cancel()
+			for range devices {
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for device:update")
+		}
+	}
+}
+
+func TestParseNetworkEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		keys    []string
+	}{
+		{"bare event", `{"key":"EVT_WU_Connected"}`, []string{"EVT_WU_Connected"}},
+		{"events", `{"meta":{"rc":"ok","message":"events"},"data":[{"key":"EVT_AP_Connected"},{"key":"EVT_AD_Login"}]}`, []string{"EVT_AP_Connected", "EVT_AD_Login"}},
+		{"device sync", `{"meta":{"rc":"ok","message":"device:sync"},"data":[{"mac":"00:11:22:33:44:55"}]}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := parseNetworkEvent([]byte(tt.message))
+			if err != nil {
+				t.Fatalf("parseNetworkEvent() error = %v", err)
+			}
+			var keys []string
+			for _, e := range events {
+				keys = append(keys, e.Key)
+				if e.Source != types.EventSourceNetwork {
+					t.Errorf("Source = %q, want %q", e.Source, types.EventSourceNetwork)
+				}
+			}
+			if !reflect.DeepEqual(keys, tt.keys) {
+				t.Errorf("keys = %v, want %v", keys, tt.keys)
+			}
+		})
+	}
+}
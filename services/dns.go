@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
 )
@@ -21,12 +22,13 @@ func NewDNSService(transport transport.Transport) DNSService {
 	}
 }
 
-// buildDNSPath builds the v2 API path for DNS records.
+// buildDNSPath builds the v2 API path for static DNS records.
 func buildDNSPath(site, id string) string {
+	endpoint := fmt.Sprintf("site/%s/static-dns", site)
 	if id != "" {
-		return fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns/%s", site, id)
+		endpoint += "/" + id
 	}
-	return fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns", site)
+	return internal.BuildV2APIPath(site, endpoint)
 }
 
 // List returns all local DNS records.
@@ -40,23 +42,16 @@ func (s *dnsService) List(ctx context.Context, site string) ([]types.DNSRecord,
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list DNS records failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		return nil, types.NewResponseError("list DNS records", resp.StatusCode, resp.Body)
 	}
 
 	// v2 API returns array directly, not wrapped in data field
-	var records []types.DNSRecord
-	if err := json.Unmarshal(resp.Body, &records); err != nil {
-		// Try parsing as wrapped response
-		var wrapped struct {
-			Data []types.DNSRecord `json:"data"`
-		}
-		if err2 := json.Unmarshal(resp.Body, &wrapped); err2 != nil {
-			return nil, fmt.Errorf("failed to parse DNS records response: %w", err)
-		}
-		records = wrapped.Data
+	apiResp, err := internal.ParseAPIResponse[types.DNSRecord](resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNS records response: %w", err)
 	}
 
-	return records, nil
+	return apiResp.Data, nil
 }
 
 // Get returns a DNS record by ID.
@@ -70,11 +65,11 @@ func (s *dnsService) Get(ctx context.Context, site, id string) (*types.DNSRecord
 	}
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("DNS record not found: %s", id)
+		return nil, notFoundf("DNS record not found: %s", id)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get DNS record failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		return nil, types.NewResponseError("get DNS record", resp.StatusCode, resp.Body)
 	}
 
 	var record types.DNSRecord
@@ -85,7 +80,8 @@ func (s *dnsService) Get(ctx context.Context, site, id string) (*types.DNSRecord
 	return &record, nil
 }
 
-// GetByName returns a DNS record by hostname/key.
+// GetByName returns a DNS record by hostname/key, ignoring case and a
+// trailing dot.
 func (s *dnsService) GetByName(ctx context.Context, site, name string) (*types.DNSRecord, error) {
 	records, err := s.List(ctx, site)
 	if err != nil {
@@ -93,15 +89,16 @@ func (s *dnsService) GetByName(ctx context.Context, site, name string) (*types.D
 	}
 
 	for _, r := range records {
-		if r.Key == name {
+		if r.MatchesName(name) {
 			return &r, nil
 		}
 	}
 
-	return nil, fmt.Errorf("DNS record not found for name: %s", name)
+	return nil, notFoundf("DNS record not found for name: %s", name)
 }
 
-// GetByIP returns DNS records pointing to a specific IP.
+// GetByIP returns DNS records pointing to a specific IP. Addresses are
+// compared by value, so any IPv6 notation matches.
 func (s *dnsService) GetByIP(ctx context.Context, site, ip string) ([]types.DNSRecord, error) {
 	records, err := s.List(ctx, site)
 	if err != nil {
@@ -110,7 +107,7 @@ func (s *dnsService) GetByIP(ctx context.Context, site, ip string) ([]types.DNSR
 
 	var matches []types.DNSRecord
 	for _, r := range records {
-		if r.Value == ip {
+		if r.PointsTo(ip) {
 			matches = append(matches, r)
 		}
 	}
@@ -120,6 +117,10 @@ func (s *dnsService) GetByIP(ctx context.Context, site, ip string) ([]types.DNSR
 
 // Create creates a new DNS record.
 func (s *dnsService) Create(ctx context.Context, site string, record *types.DNSRecord) (*types.DNSRecord, error) {
+	if err := validate(ctx, "DNS record", record); err != nil {
+		return nil, err
+	}
+
 	path := buildDNSPath(site, "")
 	req := transport.NewRequest("POST", path).WithBody(record)
 
@@ -129,7 +130,7 @@ func (s *dnsService) Create(ctx context.Context, site string, record *types.DNSR
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create DNS record failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		return nil, types.NewResponseError("create DNS record", resp.StatusCode, resp.Body)
 	}
 
 	var created types.DNSRecord
@@ -147,6 +148,10 @@ func (s *dnsService) Update(ctx context.Context, site string, record *types.DNSR
 		return nil, fmt.Errorf("DNS record ID is required for update")
 	}
 
+	if err := validate(ctx, "DNS record", record); err != nil {
+		return nil, err
+	}
+
 	path := buildDNSPath(site, record.ID)
 	req := transport.NewRequest("PUT", path).WithBody(record)
 
@@ -156,7 +161,7 @@ func (s *dnsService) Update(ctx context.Context, site string, record *types.DNSR
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update DNS record failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		return nil, types.NewResponseError("update DNS record", resp.StatusCode, resp.Body)
 	}
 
 	var updated types.DNSRecord
@@ -177,19 +182,26 @@ func (s *dnsService) Delete(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete DNS record: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete DNS record failed with status %d: %s", resp.StatusCode, string(resp.Body))
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return types.NewResponseError("delete DNS record", resp.StatusCode, resp.Body)
 	}
 
 	return nil
 }
 
-// DeleteByName deletes a DNS record by hostname/key.
+// DeleteByName deletes a DNS record by hostname/key, matched like
+// GetByName. Deleting a name that has no record is not an error.
 func (s *dnsService) DeleteByName(ctx context.Context, site, name string) error {
-	record, err := s.GetByName(ctx, site, name)
+	records, err := s.List(ctx, site)
 	if err != nil {
 		return err
 	}
 
-	return s.Delete(ctx, site, record.ID)
+	for _, r := range records {
+		if r.MatchesName(name) {
+			return s.Delete(ctx, site, r.ID)
+		}
+	}
+
+	return nil
 }
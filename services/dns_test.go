@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestDNSService_CRUD(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDNSService(trans)
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "default", &types.DNSRecord{Key: "nas.lan", RecordType: types.DNSRecordTypeA, Value: "192.168.1.10", Enabled: true})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("Create returned a record without an ID")
+	}
+	if _, err := svc.Create(ctx, "default", &types.DNSRecord{Key: "v6.lan", RecordType: types.DNSRecordTypeAAAA, Value: "2001:db8::10", Enabled: true}); err != nil {
+		t.Fatalf("Create AAAA failed: %v", err)
+	}
+
+	records, err := svc.List(ctx, "default")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records, got %d", len(records))
+	}
+
+	got, err := svc.Get(ctx, "default", created.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Key != "nas.lan" {
+		t.Errorf("Get Key = %q, want nas.lan", got.Key)
+	}
+
+	got.Enabled = false
+	updated, err := svc.Update(ctx, "default", got)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Enabled {
+		t.Error("Update should disable the record")
+	}
+
+	if err := svc.Delete(ctx, "default", created.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := svc.Get(ctx, "default", created.ID); err == nil {
+		t.Error("Get after delete should fail")
+	}
+	if err := svc.Delete(ctx, "default", created.ID); err != nil {
+		t.Errorf("second Delete should succeed, got %v", err)
+	}
+
+	var verrs types.ValidationErrors
+	if _, err := svc.Create(ctx, "default", &types.DNSRecord{Key: "bad.lan", RecordType: types.DNSRecordTypeA, Value: "not-an-ip"}); !errors.As(err, &verrs) {
+		t.Errorf("Create with a bad address error = %v, want ValidationErrors", err)
+	}
+}
+
+func TestDNSService_Lookups(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewDNSService(trans)
+	ctx := context.Background()
+
+	for _, r := range []types.DNSRecord{
+		{Key: "NAS.lan", RecordType: types.DNSRecordTypeA, Value: "192.168.1.10"},
+		{Key: "files.lan", RecordType: types.DNSRecordTypeA, Value: "192.168.1.10"},
+		{Key: "v6.lan", RecordType: types.DNSRecordTypeAAAA, Value: "2001:db8::10"},
+	} {
+		if _, err := svc.Create(ctx, "default", &r); err != nil {
+			t.Fatalf("Create %s failed: %v", r.Key, err)
+		}
+	}
+
+	record, err := svc.GetByName(ctx, "default", "nas.lan.")
+	if err != nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if record.Key != "NAS.lan" {
+		t.Errorf("GetByName Key = %q, want NAS.lan", record.Key)
+	}
+
+	matches, err := svc.GetByIP(ctx, "default", "192.168.1.10")
+	if err != nil {
+		t.Fatalf("GetByIP failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("GetByIP returned %d records, want 2", len(matches))
+	}
+	matches, err = svc.GetByIP(ctx, "default", "2001:0db8:0:0::10")
+	if err != nil {
+		t.Fatalf("GetByIP failed: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("GetByIP of an expanded IPv6 address returned %d records, want 1", len(matches))
+	}
+
+	if err := svc.DeleteByName(ctx, "default", "nas.lan"); err != nil {
+		t.Fatalf("DeleteByName failed: %v", err)
+	}
+	if _, err := svc.GetByName(ctx, "default", "nas.lan"); err == nil {
+		t.Error("GetByName after DeleteByName should fail")
+	}
+	if err := svc.DeleteByName(ctx, "default", "missing.lan"); err != nil {
+		t.Errorf("DeleteByName of a missing name should succeed, got %v", err)
+	}
+}
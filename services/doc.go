@@ -10,5 +10,14 @@
 //   - UserService: Known client/user management
 //   - RoutingService: Static routes
 //   - SettingService: System settings
+//   - DNSService: Static DNS records, with lookups by hostname and IP
+//   - HotspotService: Guest vouchers and hotspot operators
+//   - AlarmService: Alarm listing and archiving
 //   - SystemService: System-level operations
+//   - StatsService: Statistics derived from polling and historical reports
+//   - IntegrationService: The official Integrations API
+//
+// Deletes are idempotent: deleting an object that does not exist (a 404 or
+// api.err.NotFound from the controller) succeeds, so a delete can be retried
+// after a timeout without checking whether the first attempt went through.
 package services
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// EventServiceOption configures NewEventService.
+type EventServiceOption func(*eventService)
+
+// WithEventTransport sets the transport History queries the controller
+// with. Without it, History fails.
+func WithEventTransport(t transport.Transport) EventServiceOption {
+	return func(e *eventService) {
+		e.transport = t
+	}
+}
+
+// WithEventHeaders sets headers sent when connecting the WebSockets, such
+// as an API key or the credentials of a proxy.
+func WithEventHeaders(headers http.Header) EventServiceOption {
+	return func(e *eventService) {
+		e.headers = headers.Clone()
+	}
+}
+
+// WithEventCookieJar sets the jar whose cookies, such as the session of
+// a username and password login, are sent when connecting the WebSockets.
+func WithEventCookieJar(jar http.CookieJar) EventServiceOption {
+	return func(e *eventService) {
+		e.jar = jar
+	}
+}
+
+// WithEventPaths maps the WebSocket paths, which are those of UniFi OS,
+// before each connection, for example to reach a classic controller.
+func WithEventPaths(mapPath func(string) string) EventServiceOption {
+	return func(e *eventService) {
+		e.mapPath = mapPath
+	}
+}
+
+// EventHistoryOption configures EventService.History.
+type EventHistoryOption func(*eventHistoryOptions)
+
+// eventHistoryOptions holds options for EventService.History.
+type eventHistoryOptions struct {
+	start, end time.Time
+	categories []string
+	offset     int
+	limit      int
+}
+
+// defaultEventHistory is the period History covers without
+// WithEventTimeRange.
+const defaultEventHistory = 30 * 24 * time.Hour
+
+// WithEventTimeRange limits History to events between start and end. A
+// zero end is now. Without it, History covers the last 30 days.
+func WithEventTimeRange(start, end time.Time) EventHistoryOption {
+	return func(opts *eventHistoryOptions) {
+		opts.start = start
+		opts.end = end
+	}
+}
+
+// WithEventCategories limits History to events of these categories, such
+// as types.EventCategoryAP.
+func WithEventCategories(categories ...string) EventHistoryOption {
+	return func(opts *eventHistoryOptions) {
+		opts.categories = append(opts.categories, categories...)
+	}
+}
+
+// WithEventPage pages through the events of the period newest first,
+// skipping offset events and reading up to limit (default: the first
+// 3000). The controller pages from now, before the end of the period and
+// the categories are applied, so a page may hold fewer events than its
+// limit.
+func WithEventPage(offset, limit int) EventHistoryOption {
+	return func(opts *eventHistoryOptions) {
+		opts.offset = max(offset, 0)
+		opts.limit = limit
+	}
+}
+
+// eventHistoryQuery is the body of a stat/event request. Within is the
+// number of hours before now to cover.
+type eventHistoryQuery struct {
+	Sort   string `json:"_sort"`
+	Within int    `json:"within"`
+	Start  int    `json:"_start"`
+	Limit  int    `json:"_limit"`
+}
+
+// History returns past events of a site from stat/event, newest first.
+func (e *eventService) History(ctx context.Context, site string, opts ...EventHistoryOption) ([]types.Event, error) {
+	if e.transport == nil {
+		return nil, errors.New("event history needs a transport; see WithEventTransport")
+	}
+
+	options := &eventHistoryOptions{limit: 3000}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	now := time.Now()
+	if options.start.IsZero() {
+		options.start = now.Add(-defaultEventHistory)
+	}
+	if options.limit < 1 {
+		return nil, fmt.Errorf("event page limit must be positive, got %d", options.limit)
+	}
+	if !options.end.IsZero() && !options.start.Before(options.end) {
+		return nil, fmt.Errorf("event history start %s is not before end %s", options.start.Format(time.RFC3339), options.end.Format(time.RFC3339))
+	}
+
+	// The controller counts the period back from now in whole hours, so
+	// start, end and the categories are applied to what it returns.
+	query := eventHistoryQuery{
+		Sort:   "-time",
+		Within: int(math.Ceil(now.Sub(options.start).Hours())),
+		Start:  options.offset,
+		Limit:  options.limit,
+	}
+
+	path := internal.BuildAPIPath(site, "stat/event")
+	req := transport.NewRequest("POST", path).WithBody(query)
+
+	resp, err := e.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list events", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.Event](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]types.Event, 0, len(apiResp.Data))
+	for _, event := range apiResp.Data {
+		at := event.Timestamp()
+		if at.Before(options.start) || (!options.end.IsZero() && at.After(options.end)) {
+			continue
+		}
+		if len(options.categories) > 0 && !slices.Contains(options.categories, event.Category()) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
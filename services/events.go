@@ -4,10 +4,17 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
 	"github.com/unifi-go/gofi/websocket"
 )
@@ -15,112 +22,291 @@ import (
 // eventService implements EventService.
 type eventService struct {
 	baseURL   string
-	wsClient  *websocket.Client
-	eventCh   chan types.Event
-	errorCh   chan error
 	closeCh   chan struct{}
+	closeOnce sync.Once
 	tlsConfig *tls.Config
+	headers   http.Header
+	jar       http.CookieJar
+	mapPath   func(string) string
+	transport transport.Transport
+
+	// streams are the open WebSocket connections, one per subscription.
+	mu      sync.Mutex
+	streams []*websocket.Client
+
+	received atomic.Uint64
+	dropped  atomic.Uint64
+
+	// loops counts the goroutines reading streams, for Shutdown.
+	loops sync.WaitGroup
+}
+
+// EventStats describes the state of an event subscription.
+type EventStats struct {
+	// Connected is true while any of the WebSockets is open.
+	Connected bool `json:"connected"`
+
+	// Received is the number of events read from the WebSocket.
+	Received uint64 `json:"received"`
+
+	// Dropped is the number of events discarded because the event
+	// channel was full.
+	Dropped uint64 `json:"dropped"`
 }
 
 // NewEventService creates a new event service.
-func NewEventService(baseURL string, tlsConfig *tls.Config) EventService {
-	return &eventService{
+func NewEventService(baseURL string, tlsConfig *tls.Config, opts ...EventServiceOption) EventService {
+	e := &eventService{
 		baseURL:   baseURL,
 		tlsConfig: tlsConfig,
-		eventCh:   make(chan types.Event, 100),
-		errorCh:   make(chan error, 10),
 		closeCh:   make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
-// Subscribe subscribes to events for a site.
+// Subscribe subscribes to events for a site on a WebSocket of its own.
+// Events are tagged with types.EventSourceNetwork. The channels are closed
+// when the connection ends, ctx is done or the service is closed.
 func (e *eventService) Subscribe(ctx context.Context, site string) (<-chan types.Event, <-chan error, error) {
-	// Build WebSocket URL
-	wsPath := internal.BuildWebSocketPath(site)
+	return e.subscribe(ctx, internal.BuildWebSocketPath(site), parseNetworkEvent)
+}
+
+// SubscribeSystem subscribes to the UniFi OS event bus like Subscribe.
+// Events are tagged with types.EventSourceSystem.
+func (e *eventService) SubscribeSystem(ctx context.Context) (<-chan types.Event, <-chan error, error) {
+	return e.subscribe(ctx, internal.BuildSystemWebSocketPath(), parseSystemEvent)
+}
+
+// subscribe connects to a WebSocket and starts reading its events.
+func (e *eventService) subscribe(ctx context.Context, wsPath string, parse func([]byte) ([]types.Event, error)) (<-chan types.Event, <-chan error, error) {
+	client, err := e.dial(ctx, wsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := e.track(client); err != nil {
+		return nil, nil, err
+	}
+
+	eventCh := make(chan types.Event, 100)
+	errorCh := make(chan error, 10)
+	go e.readLoop(ctx, client, parse, eventCh, errorCh)
+
+	return eventCh, errorCh, nil
+}
 
-	// Convert https:// to wss://
-	wsURL := "wss" + e.baseURL[5:] + wsPath // Strip "https" and add "wss"
+// track registers a connection for Close and Shutdown, or closes it if
+// the service already is.
+func (e *eventService) track(client *websocket.Client) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed() {
+		client.Close()
+		return fmt.Errorf("event stream is closed")
+	}
+	e.streams = append(e.streams, client)
+	e.loops.Add(1)
+	return nil
+}
+
+// untrack closes a connection and forgets it. Its loop must call it on
+// exit.
+func (e *eventService) untrack(client *websocket.Client) {
+	client.Close()
+
+	e.mu.Lock()
+	e.streams = slices.DeleteFunc(e.streams, func(c *websocket.Client) bool { return c == client })
+	e.mu.Unlock()
+	e.loops.Done()
+}
+
+// dial connects to a WebSocket of the controller.
+func (e *eventService) dial(ctx context.Context, wsPath string) (*websocket.Client, error) {
+	if e.mapPath != nil {
+		wsPath = e.mapPath(wsPath)
+	}
+
+	// Convert https:// to wss:// and http:// to ws://
+	u, err := url.Parse(e.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	default:
+		return nil, fmt.Errorf("invalid base URL scheme: %s (must be http or https)", u.Scheme)
+	}
+	u = u.JoinPath(wsPath)
 
 	// Create WebSocket client
 	var opts []websocket.Option
 	if e.tlsConfig != nil {
 		opts = append(opts, websocket.WithTLSConfig(e.tlsConfig))
 	}
+	if e.headers != nil {
+		opts = append(opts, websocket.WithHeaders(e.headers))
+	}
+	if e.jar != nil {
+		opts = append(opts, websocket.WithCookieJar(e.jar))
+	}
 
-	client, err := websocket.New(wsURL, opts...)
+	client, err := websocket.New(u.String(), opts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create WebSocket client: %w", err)
+		return nil, fmt.Errorf("failed to create WebSocket client: %w", err)
 	}
 
-	e.wsClient = client
-
 	// Connect
 	if err := client.Connect(ctx); err != nil {
-		return nil, nil, fmt.Errorf("failed to connect: %w", err)
+		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
-	// Start reading events
-	go e.readLoop()
+	return client, nil
+}
+
+// parseNetworkEvent parses a Network Application message: either a single
+// event or an envelope holding several. Envelopes of other kinds, such as
+// device:sync, hold no events.
+func parseNetworkEvent(message []byte) ([]types.Event, error) {
+	var msg types.WebSocketMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return nil, err
+	}
 
-	return e.eventCh, e.errorCh, nil
+	var events []types.Event
+	switch msg.Meta.Message {
+	case "":
+		var event types.Event
+		if err := json.Unmarshal(message, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	case types.WebSocketMessageEvents:
+		if err := json.Unmarshal(msg.Data, &events); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range events {
+		events[i].Source = types.EventSourceNetwork
+	}
+	return events, nil
 }
 
-// readLoop reads events from the WebSocket.
-func (e *eventService) readLoop() {
+// parseSystemEvent parses a UniFi OS event bus message.
+func parseSystemEvent(message []byte) ([]types.Event, error) {
+	var msg types.SystemMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return nil, err
+	}
+	return []types.Event{msg.Event()}, nil
+}
+
+// readLoop reads events from a WebSocket until it fails, ctx is done or
+// the service is closed, then closes the subscription's channels. Events
+// that cannot be delivered within a second are dropped.
+func (e *eventService) readLoop(ctx context.Context, client *websocket.Client, parse func([]byte) ([]types.Event, error), eventCh chan<- types.Event, errorCh chan<- error) {
+	stop := context.AfterFunc(ctx, func() { client.Close() })
 	defer func() {
-		close(e.eventCh)
-		close(e.errorCh)
+		stop()
+		e.untrack(client)
+		close(eventCh)
+		close(errorCh)
 	}()
 
-	for {
+	report := func(err error) {
 		select {
-		case <-e.closeCh:
-			return
+		case errorCh <- err:
 		default:
-			if e.wsClient == nil {
-				return
-			}
+		}
+	}
 
-			message, err := e.wsClient.ReadMessage()
-			if err != nil {
-				select {
-				case e.errorCh <- fmt.Errorf("read error: %w", err):
-				case <-e.closeCh:
-				default:
-				}
-				return
+	for {
+		message, err := client.ReadMessage()
+		if err != nil {
+			if ctx.Err() == nil && !e.closed() {
+				report(fmt.Errorf("read error: %w", err))
 			}
+			return
+		}
 
-			// Parse event
-			var event types.Event
-			if err := json.Unmarshal(message, &event); err != nil {
-				select {
-				case e.errorCh <- fmt.Errorf("parse error: %w", err):
-				case <-e.closeCh:
-				default:
-				}
-				continue
-			}
+		// Parse events
+		events, err := parse(message)
+		if err != nil {
+			report(fmt.Errorf("parse error: %w", err))
+			continue
+		}
 
-			// Send event
+		// Send events
+		for _, event := range events {
+			e.received.Add(1)
 			select {
-			case e.eventCh <- event:
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
 			case <-e.closeCh:
 				return
 			case <-time.After(1 * time.Second):
 				// Drop event if channel is full
+				e.dropped.Add(1)
 			}
 		}
 	}
 }
 
-// Close closes the event stream.
+// Close closes the event stream. Closing it again does nothing.
 func (e *eventService) Close() error {
-	close(e.closeCh)
+	e.closeOnce.Do(func() { close(e.closeCh) })
 
-	if e.wsClient != nil {
-		return e.wsClient.Close()
+	e.mu.Lock()
+	streams := e.streams
+	e.mu.Unlock()
+
+	var errs []error
+	for _, client := range streams {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// Shutdown closes the event stream like Close, then waits until every
+// stream has stopped and closed its channels, or ctx is done.
+func (e *eventService) Shutdown(ctx context.Context) error {
+	err := e.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		e.loops.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return err
+	case <-ctx.Done():
+		return errors.Join(err, ctx.Err())
+	}
+}
+
+// Stats returns the subscription's state and counters.
+func (e *eventService) Stats() EventStats {
+	e.mu.Lock()
+	connected := false
+	for _, client := range e.streams {
+		connected = connected || client.IsConnected()
+	}
+	e.mu.Unlock()
+
+	return EventStats{
+		Connected: connected,
+		Received:  e.received.Load(),
+		Dropped:   e.dropped.Load(),
+	}
 }
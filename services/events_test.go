@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+	"github.com/unifi-go/gofi/websocket"
+)
+
+func TestEventService_SubscribeSystem(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	svc := NewEventService(server.URL(), &tls.Config{InsecureSkipVerify: true})
+	defer svc.Close()
+
+	ctx := context.Background()
+	events, _, err := svc.Subscribe(ctx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	systemEvents, _, err := svc.SubscribeSystem(ctx)
+	if err != nil {
+		t.Fatalf("SubscribeSystem() error = %v", err)
+	}
+
+	// The mock registers connections asynchronously, so keep
+	// broadcasting until both events have arrived.
+	got := make(map[types.EventSource]types.Event)
+	timeout := time.After(5 * time.Second)
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			got[event.Source] = event
+		case event := <-systemEvents:
+			got[event.Source] = event
+		case <-tick.C:
+			if _, ok := got[types.EventSourceNetwork]; !ok {
+				server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+			}
+			if _, ok := got[types.EventSourceSystem]; !ok {
+				server.SimulateApplicationStateChange("network", "running")
+			}
+		case <-timeout:
+			t.Fatalf("timed out, got %+v", got)
+		}
+	}
+
+	if key := got[types.EventSourceNetwork].Key; key != "EVT_WU_Disconnected" {
+		t.Errorf("network event key = %q, want EVT_WU_Disconnected", key)
+	}
+	system := got[types.EventSourceSystem]
+	if system.Key != "APPLICATION_STATE_CHANGED" || system.Subsystem != "network" {
+		t.Errorf("system event = %+v, want APPLICATION_STATE_CHANGED for network", system)
+	}
+}
+
+func TestEventService_SeparateSubscriptions(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	svc := NewEventService(server.URL(), &tls.Config{InsecureSkipVerify: true})
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	first, _, err := svc.Subscribe(ctx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	subCtx, subCancel := context.WithCancel(ctx)
+	second, secondErrs, err := svc.Subscribe(subCtx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// Both subscribers see the same event.
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for gotFirst, gotSecond := false, false; !gotFirst || !gotSecond; {
+		select {
+		case <-first:
+			gotFirst = true
+		case <-second:
+			gotSecond = true
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatalf("timed out, first = %v, second = %v", gotFirst, gotSecond)
+		}
+	}
+
+	// Ending the second's ctx closes its channels only.
+	subCancel()
+	for range second {
+	}
+	for range secondErrs {
+	}
+	for {
+		select {
+		case _, ok := <-first:
+			if !ok {
+				t.Fatal("first subscription closed with the second")
+			}
+			return
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the first subscription")
+		}
+	}
+}
+
+func TestEventService_SessionCookie(t *testing.T) {
+	server := mock.NewServer(mock.WithoutCSRF())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+
+	// The controller refuses upgrades without a session.
+	if _, _, err := NewEventService(server.URL(), tlsConfig).Subscribe(ctx, "default"); err == nil {
+		t.Fatal("Subscribe() without a session should fail")
+	}
+
+	trans, _ := newTestTransport(server.URL())
+	req := transport.NewRequest("POST", "/api/auth/login").WithBody(map[string]string{"username": "admin", "password": "admin"})
+	if resp, err := trans.Do(ctx, req); err != nil || !resp.IsSuccess() {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	svc := NewEventService(server.URL(), tlsConfig, WithEventCookieJar(transport.GetCookieJar(trans)))
+	defer svc.Close()
+	if _, _, err := svc.Subscribe(ctx, "default"); err != nil {
+		t.Errorf("Subscribe() with the session cookie error = %v", err)
+	}
+
+	if _, _, err := NewEventService("ftp://"+server.Host(), tlsConfig).Subscribe(ctx, "default"); err == nil {
+		t.Error("Subscribe() with an ftp base URL should fail")
+	}
+}
+
+func TestEventService_History(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	now := time.Now()
+	for i, key := range []string{types.EventAPConnected, types.EventWUConnected, types.EventAPDisconnected, types.EventADLogin} {
+		server.State().AddEvent(types.Event{ID: key, Key: key, Time: types.NewFlexTime(now.Add(-time.Duration(i+1) * time.Hour).UnixMilli())})
+	}
+	server.State().AddEvent(types.Event{ID: "old", Key: types.EventAPRestarted, Time: types.NewFlexTime(now.Add(-60 * 24 * time.Hour).UnixMilli())})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewEventService(server.URL(), &tls.Config{InsecureSkipVerify: true}, WithEventTransport(trans))
+	defer svc.Close()
+	ctx := context.Background()
+
+	keys := func(events []types.Event) []string {
+		var keys []string
+		for _, e := range events {
+			keys = append(keys, e.Key)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name string
+		opts []EventHistoryOption
+		want []string
+	}{
+		{"last 30 days", nil, []string{types.EventAPConnected, types.EventWUConnected, types.EventAPDisconnected, types.EventADLogin}},
+		{"time range", []EventHistoryOption{WithEventTimeRange(now.Add(-150*time.Minute), now.Add(-90*time.Minute))}, []string{types.EventWUConnected}},
+		{"category", []EventHistoryOption{WithEventCategories(types.EventCategoryAP)}, []string{types.EventAPConnected, types.EventAPDisconnected}},
+		{"page", []EventHistoryOption{WithEventPage(1, 2)}, []string{types.EventWUConnected, types.EventAPDisconnected}},
+		{"long range", []EventHistoryOption{WithEventTimeRange(now.Add(-90*24*time.Hour), time.Time{}), WithEventCategories(types.EventCategoryAP)}, []string{types.EventAPConnected, types.EventAPDisconnected, types.EventAPRestarted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := svc.History(ctx, "default", tt.opts...)
+			if err != nil {
+				t.Fatalf("History() error = %v", err)
+			}
+			if got := keys(events); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("History() keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewEventService(server.URL(), nil).History(ctx, "default"); err == nil {
+		t.Error("History() without a transport should fail")
+	}
+}
+
+func TestEventService_Dispatcher(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	svc := NewEventService(server.URL(), &tls.Config{InsecureSkipVerify: true})
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, _, err := svc.Subscribe(ctx, "default")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	connected := make(chan types.ClientEvent, 10)
+	d := websocket.NewDispatcher()
+	d.OnClientConnected(func(e types.ClientEvent) { connected <- e })
+	go d.Run(ctx, events)
+
+	client := &types.Client{MAC: "aa:bb:cc:dd:ee:01", Hostname: "laptop", IsWired: types.FlexBool{Val: true}}
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case e := <-connected:
+			if e.MAC != client.MAC || e.Hostname != "laptop" || !e.Wired {
+				t.Errorf("OnClientConnected got %+v", e)
+			}
+			return
+		case <-tick.C:
+			server.SimulateClientConnect("default", client)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the client event")
+		}
+	}
+}
@@ -32,7 +32,7 @@ func (s *firewallService) ListRules(ctx context.Context, site string) ([]types.F
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list firewall rules failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list firewall rules", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallRule](resp.Body)
@@ -55,9 +55,9 @@ func (s *firewallService) GetRule(ctx context.Context, site, id string) (*types.
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("firewall rule not found: %s", id)
+			return nil, notFoundf("firewall rule not found: %s", id)
 		}
-		return nil, fmt.Errorf("get firewall rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get firewall rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallRule](resp.Body)
@@ -66,7 +66,7 @@ func (s *firewallService) GetRule(ctx context.Context, site, id string) (*types.
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("firewall rule not found: %s", id)
+		return nil, notFoundf("firewall rule not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -74,6 +74,10 @@ func (s *firewallService) GetRule(ctx context.Context, site, id string) (*types.
 
 // CreateRule creates a new firewall rule.
 func (s *firewallService) CreateRule(ctx context.Context, site string, rule *types.FirewallRule) (*types.FirewallRule, error) {
+	if err := validate(ctx, "firewall rule", rule); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "firewallrule", "")
 	req := transport.NewRequest("POST", path).WithBody(rule)
 
@@ -83,7 +87,7 @@ func (s *firewallService) CreateRule(ctx context.Context, site string, rule *typ
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create firewall rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create firewall rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallRule](resp.Body)
@@ -100,6 +104,10 @@ func (s *firewallService) CreateRule(ctx context.Context, site string, rule *typ
 
 // UpdateRule updates an existing firewall rule.
 func (s *firewallService) UpdateRule(ctx context.Context, site string, rule *types.FirewallRule) (*types.FirewallRule, error) {
+	if err := validate(ctx, "firewall rule", rule); err != nil {
+		return nil, err
+	}
+
 	if rule.ID == "" {
 		return nil, fmt.Errorf("firewall rule ID is required for update")
 	}
@@ -113,7 +121,7 @@ func (s *firewallService) UpdateRule(ctx context.Context, site string, rule *typ
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update firewall rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update firewall rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallRule](resp.Body)
@@ -138,8 +146,8 @@ func (s *firewallService) DeleteRule(ctx context.Context, site, id string) error
 		return fmt.Errorf("failed to delete firewall rule: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete firewall rule failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("firewall rule", resp)
 	}
 
 	return nil
@@ -153,7 +161,7 @@ func (s *firewallService) EnableRule(ctx context.Context, site, id string) error
 	}
 
 	rule.Enabled = true
-	_, err = s.UpdateRule(ctx, site, rule)
+	_, err = s.UpdateRule(WithoutValidation(ctx), site, rule)
 	return err
 }
 
@@ -165,7 +173,7 @@ func (s *firewallService) DisableRule(ctx context.Context, site, id string) erro
 	}
 
 	rule.Enabled = false
-	_, err = s.UpdateRule(ctx, site, rule)
+	_, err = s.UpdateRule(WithoutValidation(ctx), site, rule)
 	return err
 }
 
@@ -180,7 +188,7 @@ func (s *firewallService) ReorderRules(ctx context.Context, site, ruleset string
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("reorder firewall rules failed with status %d", resp.StatusCode)
+		return types.NewResponseError("reorder firewall rules", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -197,7 +205,7 @@ func (s *firewallService) ListGroups(ctx context.Context, site string) ([]types.
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list firewall groups failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list firewall groups", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallGroup](resp.Body)
@@ -220,9 +228,9 @@ func (s *firewallService) GetGroup(ctx context.Context, site, id string) (*types
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("firewall group not found: %s", id)
+			return nil, notFoundf("firewall group not found: %s", id)
 		}
-		return nil, fmt.Errorf("get firewall group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get firewall group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallGroup](resp.Body)
@@ -231,7 +239,7 @@ func (s *firewallService) GetGroup(ctx context.Context, site, id string) (*types
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("firewall group not found: %s", id)
+		return nil, notFoundf("firewall group not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -239,6 +247,10 @@ func (s *firewallService) GetGroup(ctx context.Context, site, id string) (*types
 
 // CreateGroup creates a new firewall group.
 func (s *firewallService) CreateGroup(ctx context.Context, site string, group *types.FirewallGroup) (*types.FirewallGroup, error) {
+	if err := validate(ctx, "firewall group", group); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "firewallgroup", "")
 	req := transport.NewRequest("POST", path).WithBody(group)
 
@@ -248,7 +260,7 @@ func (s *firewallService) CreateGroup(ctx context.Context, site string, group *t
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create firewall group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create firewall group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallGroup](resp.Body)
@@ -265,6 +277,10 @@ func (s *firewallService) CreateGroup(ctx context.Context, site string, group *t
 
 // UpdateGroup updates an existing firewall group.
 func (s *firewallService) UpdateGroup(ctx context.Context, site string, group *types.FirewallGroup) (*types.FirewallGroup, error) {
+	if err := validate(ctx, "firewall group", group); err != nil {
+		return nil, err
+	}
+
 	if group.ID == "" {
 		return nil, fmt.Errorf("firewall group ID is required for update")
 	}
@@ -278,7 +294,7 @@ func (s *firewallService) UpdateGroup(ctx context.Context, site string, group *t
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update firewall group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update firewall group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.FirewallGroup](resp.Body)
@@ -303,8 +319,8 @@ func (s *firewallService) DeleteGroup(ctx context.Context, site, id string) erro
 		return fmt.Errorf("failed to delete firewall group: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete firewall group failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("firewall group", resp)
 	}
 
 	return nil
@@ -321,7 +337,7 @@ func (s *firewallService) ListTrafficRules(ctx context.Context, site string) ([]
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list traffic rules failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list traffic rules", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.TrafficRule](resp.Body)
@@ -344,9 +360,9 @@ func (s *firewallService) GetTrafficRule(ctx context.Context, site, id string) (
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("traffic rule not found: %s", id)
+			return nil, notFoundf("traffic rule not found: %s", id)
 		}
-		return nil, fmt.Errorf("get traffic rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get traffic rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.TrafficRule](resp.Body)
@@ -355,7 +371,7 @@ func (s *firewallService) GetTrafficRule(ctx context.Context, site, id string) (
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("traffic rule not found: %s", id)
+		return nil, notFoundf("traffic rule not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -372,7 +388,7 @@ func (s *firewallService) CreateTrafficRule(ctx context.Context, site string, ru
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create traffic rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create traffic rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.TrafficRule](resp.Body)
@@ -403,7 +419,7 @@ func (s *firewallService) UpdateTrafficRule(ctx context.Context, site string, ru
 
 	// Note: v2 API returns 201 for PUT operations
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("update traffic rule failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update traffic rule", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.TrafficRule](resp.Body)
@@ -428,8 +444,8 @@ func (s *firewallService) DeleteTrafficRule(ctx context.Context, site, id string
 		return fmt.Errorf("failed to delete traffic rule: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete traffic rule failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("traffic rule", resp)
 	}
 
 	return nil
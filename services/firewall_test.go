@@ -158,3 +158,35 @@ func TestFirewallService_CreateTrafficRule(t *testing.T) {
 		t.Errorf("Expected name 'Block Social Media', got %s", created.Name)
 	}
 }
+
+func TestFirewallService_CreateIPv6Rule(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewFirewallService(trans)
+
+	created, err := svc.CreateRule(context.Background(), "default", &types.FirewallRule{
+		Name:           "Allow ICMPv6",
+		Enabled:        true,
+		Ruleset:        types.RulesetWANv6In,
+		Action:         types.FirewallActionAccept,
+		ProtocolV6:     types.ProtocolIPv6ICMP,
+		ICMPv6Typename: "echo-request",
+	})
+	if err != nil {
+		t.Fatalf("CreateRule failed: %v", err)
+	}
+	if created.Ruleset != types.RulesetWANv6In || created.ProtocolV6 != types.ProtocolIPv6ICMP {
+		t.Errorf("created rule = %s/%s", created.Ruleset, created.ProtocolV6)
+	}
+
+	_, err = svc.CreateGroup(context.Background(), "default", &types.FirewallGroup{
+		Name:         "Bad v6 group",
+		GroupType:    types.GroupTypeIPv6Address,
+		GroupMembers: []string{"192.168.1.0/24"},
+	})
+	if err == nil {
+		t.Error("CreateGroup should reject IPv4 members in an IPv6 address group")
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// ErrReorderNotApplied is returned by ReorderRulesVerified when the rule
+// indexes read back still differ from the requested ones after the last
+// attempt.
+var ErrReorderNotApplied = errors.New("firewall rule order not applied")
+
+// ReorderOption configures ReorderRulesVerified.
+type ReorderOption func(*reorderOptions)
+
+// reorderOptions holds options for ReorderRulesVerified.
+type reorderOptions struct {
+	attempts int
+	delay    time.Duration
+	rollback bool
+}
+
+// WithReorderAttempts sets how many times the new order is sent before
+// giving up (default: 3).
+func WithReorderAttempts(n int) ReorderOption {
+	return func(opts *reorderOptions) {
+		opts.attempts = max(n, 1)
+	}
+}
+
+// WithReorderDelay sets how long to wait after sending the order before
+// reading the rules back (default: 500ms).
+func WithReorderDelay(d time.Duration) ReorderOption {
+	return func(opts *reorderOptions) {
+		opts.delay = d
+	}
+}
+
+// WithReorderRollback restores the original indexes of the reordered rules
+// if the new order cannot be verified.
+func WithReorderRollback() ReorderOption {
+	return func(opts *reorderOptions) {
+		opts.rollback = true
+	}
+}
+
+// RulePosition is where a rule ended up after ReorderRulesVerified.
+type RulePosition struct {
+	ID   string
+	Name string
+
+	// RuleIndex is the rule's index as read back from the controller.
+	RuleIndex int
+
+	// Position is the rule's place in the ruleset ordered by index,
+	// starting at 0.
+	Position int
+
+	// Reordered is true for rules that were part of the reorder, and
+	// Wanted is their requested index.
+	Reordered bool
+	Wanted    int
+}
+
+// Applied reports whether the rule has its requested index. Rules that
+// were not part of the reorder always count as applied.
+func (p RulePosition) Applied() bool {
+	return !p.Reordered || p.RuleIndex == p.Wanted
+}
+
+// ReorderReport describes the outcome of ReorderRulesVerified.
+type ReorderReport struct {
+	Ruleset string
+
+	// Attempts is how many times the order was sent.
+	Attempts int
+
+	// Rules are the rules of the ruleset in their final order.
+	Rules []RulePosition
+
+	// Mismatched lists the IDs of reordered rules whose index differs
+	// from the requested one after the last attempt.
+	Mismatched []string
+
+	// RolledBack is true if the original indexes were restored.
+	RolledBack bool
+}
+
+// ReorderRulesVerified reorders firewall rules like ReorderRules, then
+// reads the rules back and checks that every rule in updates belongs to
+// ruleset and has its requested index. On a mismatch it sends the order
+// again, up to the configured number of attempts. The report is returned
+// even on error; if the order was never verified the error wraps
+// ErrReorderNotApplied.
+func (s *firewallService) ReorderRulesVerified(ctx context.Context, site, ruleset string, updates []types.FirewallRuleIndexUpdate, opts ...ReorderOption) (*ReorderReport, error) {
+	options := &reorderOptions{
+		attempts: 3,
+		delay:    500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	wanted := make(map[string]int, len(updates))
+	for _, u := range updates {
+		if _, ok := wanted[u.ID]; ok {
+			return nil, fmt.Errorf("duplicate rule in reorder: %s", u.ID)
+		}
+		wanted[u.ID] = u.RuleIndex
+	}
+
+	rules, err := s.ListRules(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	original := make([]types.FirewallRuleIndexUpdate, 0, len(updates))
+	for _, r := range rules {
+		if _, ok := wanted[r.ID]; !ok {
+			continue
+		}
+		if r.Ruleset != ruleset {
+			return nil, fmt.Errorf("rule %s is in ruleset %s, not %s", r.ID, r.Ruleset, ruleset)
+		}
+		original = append(original, types.FirewallRuleIndexUpdate{ID: r.ID, RuleIndex: r.RuleIndex})
+	}
+	if len(original) != len(wanted) {
+		return nil, fmt.Errorf("reorder references rules not found in site %s", site)
+	}
+
+	report := &ReorderReport{Ruleset: ruleset}
+	for report.Attempts < options.attempts {
+		report.Attempts++
+		if err := s.ReorderRules(ctx, site, ruleset, updates); err != nil {
+			return report, err
+		}
+		// Give the controller time to apply the order before reading.
+		if err := sleepContext(ctx, options.delay); err != nil {
+			return report, err
+		}
+
+		rules, err := s.ListRules(ctx, site)
+		if err != nil {
+			return report, err
+		}
+		report.Rules, report.Mismatched = rulePositions(rules, ruleset, wanted)
+		if len(report.Mismatched) == 0 {
+			return report, nil
+		}
+	}
+
+	err = fmt.Errorf("%w: %d of %d rules after %d attempts", ErrReorderNotApplied, len(report.Mismatched), len(updates), report.Attempts)
+	if options.rollback {
+		if rbErr := s.ReorderRules(ctx, site, ruleset, original); rbErr != nil {
+			return report, errors.Join(err, fmt.Errorf("failed to roll back: %w", rbErr))
+		}
+		report.RolledBack = true
+	}
+	return report, err
+}
+
+// rulePositions orders the rules of ruleset by index and lists the IDs of
+// rules in wanted whose index differs.
+func rulePositions(rules []types.FirewallRule, ruleset string, wanted map[string]int) ([]RulePosition, []string) {
+	var positions []RulePosition
+	seen := make(map[string]bool, len(wanted))
+	for _, r := range rules {
+		if r.Ruleset != ruleset {
+			continue
+		}
+		seen[r.ID] = true
+		want, ok := wanted[r.ID]
+		positions = append(positions, RulePosition{ID: r.ID, Name: r.Name, RuleIndex: r.RuleIndex, Reordered: ok, Wanted: want})
+	}
+	sort.SliceStable(positions, func(i, j int) bool { return positions[i].RuleIndex < positions[j].RuleIndex })
+
+	var mismatched []string
+	for i := range positions {
+		positions[i].Position = i
+		if !positions[i].Applied() {
+			mismatched = append(mismatched, positions[i].ID)
+		}
+	}
+	for id := range wanted {
+		if !seen[id] {
+			// Deleted or moved to another ruleset meanwhile.
+			mismatched = append(mismatched, id)
+		}
+	}
+	sort.Strings(mismatched)
+	return positions, mismatched
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// droppingTransport acknowledges the first drop reorder requests without
+// sending them, like a controller that loses an update.
+type droppingTransport struct {
+	transport.Transport
+	drop int
+}
+
+func (t *droppingTransport) Do(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	if req.Method == "POST" && strings.HasSuffix(req.Path, "/reorder") && t.drop > 0 {
+		t.drop--
+		return &transport.Response{StatusCode: 200, Body: []byte(`{"meta":{"rc":"ok"},"data":[]}`)}, nil
+	}
+	return t.Transport.Do(ctx, req)
+}
+
+func newReorderTestServer() *mock.Server {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	for i, id := range []string{"a", "b", "c"} {
+		server.State().AddFirewallRule(&types.FirewallRule{
+			ID:        id,
+			Name:      "rule " + id,
+			Ruleset:   types.RulesetLANIn,
+			RuleIndex: 2000 + i,
+		})
+	}
+	server.State().AddFirewallRule(&types.FirewallRule{ID: "wan", Ruleset: types.RulesetWANIn, RuleIndex: 2000})
+	return server
+}
+
+func TestFirewallService_ReorderRulesVerified(t *testing.T) {
+	server := newReorderTestServer()
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewFirewallService(&droppingTransport{Transport: trans, drop: 1})
+
+	updates := []types.FirewallRuleIndexUpdate{
+		{ID: "c", RuleIndex: 2000},
+		{ID: "a", RuleIndex: 2001},
+		{ID: "b", RuleIndex: 2002},
+	}
+	report, err := svc.ReorderRulesVerified(context.Background(), "default", types.RulesetLANIn, updates, WithReorderDelay(0))
+	if err != nil {
+		t.Fatalf("ReorderRulesVerified failed: %v", err)
+	}
+
+	if report.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2 after a lost update", report.Attempts)
+	}
+	var order []string
+	for _, p := range report.Rules {
+		order = append(order, p.ID)
+		if !p.Reordered || !p.Applied() {
+			t.Errorf("rule %s: %+v, want reordered and applied", p.ID, p)
+		}
+	}
+	if got := strings.Join(order, ","); got != "c,a,b" {
+		t.Errorf("final order = %s, want c,a,b", got)
+	}
+}
+
+func TestFirewallService_ReorderRulesVerified_NotApplied(t *testing.T) {
+	server := newReorderTestServer()
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	dropping := &droppingTransport{Transport: trans, drop: 2}
+	svc := NewFirewallService(dropping)
+
+	updates := []types.FirewallRuleIndexUpdate{{ID: "c", RuleIndex: 1999}}
+	report, err := svc.ReorderRulesVerified(context.Background(), "default", types.RulesetLANIn, updates,
+		WithReorderAttempts(2), WithReorderDelay(0), WithReorderRollback())
+	if !errors.Is(err, ErrReorderNotApplied) {
+		t.Fatalf("error = %v, want ErrReorderNotApplied", err)
+	}
+	if report.Attempts != 2 || !report.RolledBack || len(report.Mismatched) != 1 || report.Mismatched[0] != "c" {
+		t.Errorf("report = %+v, want 2 attempts, rolled back, c mismatched", report)
+	}
+	if rule := server.State().GetFirewallRule("c"); rule.RuleIndex != 2002 {
+		t.Errorf("rule c index = %d, want original 2002", rule.RuleIndex)
+	}
+
+	// Rules of another ruleset are refused before anything is sent.
+	_, err = svc.ReorderRulesVerified(context.Background(), "default", types.RulesetLANIn,
+		[]types.FirewallRuleIndexUpdate{{ID: "wan", RuleIndex: 2005}})
+	if err == nil {
+		t.Error("reordering a WAN_IN rule in LAN_IN succeeded")
+	}
+}
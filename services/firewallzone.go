@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// firewallZonePath builds the v2 API path for firewall zones.
+func firewallZonePath(site, id string) string {
+	endpoint := fmt.Sprintf("site/%s/firewall/zone", site)
+	if id != "" {
+		endpoint += "/" + id
+	}
+	return internal.BuildV2APIPath(site, endpoint)
+}
+
+// firewallPolicyPath builds the v2 API path for firewall policies.
+func firewallPolicyPath(site, id string) string {
+	endpoint := fmt.Sprintf("site/%s/firewall-policies", site)
+	if id != "" {
+		endpoint += "/" + id
+	}
+	return internal.BuildV2APIPath(site, endpoint)
+}
+
+// ListZones returns all firewall zones for a site.
+func (s *firewallService) ListZones(ctx context.Context, site string) ([]types.FirewallZone, error) {
+	req := transport.NewRequest("GET", firewallZonePath(site, ""))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall zones: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list firewall zones", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallZone](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// CreateZone creates a custom firewall zone.
+func (s *firewallService) CreateZone(ctx context.Context, site string, zone *types.FirewallZone) (*types.FirewallZone, error) {
+	if err := validate(ctx, "firewall zone", zone); err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("POST", firewallZonePath(site, "")).WithBody(zone)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall zone: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create firewall zone", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallZone](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create firewall zone returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// UpdateZone updates a firewall zone, such as the networks it contains.
+func (s *firewallService) UpdateZone(ctx context.Context, site string, zone *types.FirewallZone) (*types.FirewallZone, error) {
+	if zone.ID == "" {
+		return nil, fmt.Errorf("firewall zone ID is required for update")
+	}
+
+	if err := validate(ctx, "firewall zone", zone); err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("PUT", firewallZonePath(site, zone.ID)).WithBody(zone)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update firewall zone: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("update firewall zone", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallZone](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("update firewall zone returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// DeleteZone deletes a custom firewall zone.
+func (s *firewallService) DeleteZone(ctx context.Context, site, id string) error {
+	req := transport.NewRequest("DELETE", firewallZonePath(site, id))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall zone: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("firewall zone", resp)
+	}
+
+	return nil
+}
+
+// GetZoneMatrix returns the default action and policy count for every
+// pair of zones.
+func (s *firewallService) GetZoneMatrix(ctx context.Context, site string) (types.FirewallZoneMatrix, error) {
+	path := internal.BuildV2APIPath(site, fmt.Sprintf("site/%s/firewall/zone-matrix", site))
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone matrix: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("get zone matrix", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallZoneMatrixRow](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// ListPolicies returns all firewall policies for a site, including the
+// predefined ones.
+func (s *firewallService) ListPolicies(ctx context.Context, site string) ([]types.FirewallPolicy, error) {
+	req := transport.NewRequest("GET", firewallPolicyPath(site, ""))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall policies: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list firewall policies", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallPolicy](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// GetPolicy returns a firewall policy by ID.
+func (s *firewallService) GetPolicy(ctx context.Context, site, id string) (*types.FirewallPolicy, error) {
+	policies, err := s.ListPolicies(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range policies {
+		if policies[i].ID == id {
+			return &policies[i], nil
+		}
+	}
+
+	return nil, notFoundf("firewall policy not found: %s", id)
+}
+
+// CreatePolicy creates a firewall policy. The controller appends it to the
+// policies between its zones unless Index is set.
+func (s *firewallService) CreatePolicy(ctx context.Context, site string, policy *types.FirewallPolicy) (*types.FirewallPolicy, error) {
+	if err := validate(ctx, "firewall policy", policy); err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("POST", firewallPolicyPath(site, "")).WithBody(policy)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall policy: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create firewall policy", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallPolicy](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create firewall policy returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// UpdatePolicy updates a user-defined firewall policy.
+func (s *firewallService) UpdatePolicy(ctx context.Context, site string, policy *types.FirewallPolicy) (*types.FirewallPolicy, error) {
+	if policy.ID == "" {
+		return nil, fmt.Errorf("firewall policy ID is required for update")
+	}
+
+	if policy.Predefined {
+		return nil, fmt.Errorf("predefined firewall policy %s cannot be updated", policy.ID)
+	}
+
+	if err := validate(ctx, "firewall policy", policy); err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("PUT", firewallPolicyPath(site, policy.ID)).WithBody(policy)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update firewall policy: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("update firewall policy", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.FirewallPolicy](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("update firewall policy returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// DeletePolicy deletes a user-defined firewall policy. The controller
+// deletes policies in batches; this sends a batch of one.
+func (s *firewallService) DeletePolicy(ctx context.Context, site, id string) error {
+	req := transport.NewRequest("POST", firewallPolicyPath(site, "batch-delete")).WithBody([]string{id})
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall policy: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("firewall policy", resp)
+	}
+
+	return nil
+}
+
+// ReorderPolicies sets the order of the user-defined policies between two
+// zones.
+func (s *firewallService) ReorderPolicies(ctx context.Context, site string, order *types.FirewallPolicyOrder) error {
+	if order.SourceZoneID == "" || order.DestinationZoneID == "" {
+		return fmt.Errorf("source and destination zone IDs are required for reorder")
+	}
+
+	// The controller rejects null lists
+	body := *order
+	if body.BeforePredefinedIDs == nil {
+		body.BeforePredefinedIDs = []string{}
+	}
+	if body.AfterPredefinedIDs == nil {
+		body.AfterPredefinedIDs = []string{}
+	}
+
+	req := transport.NewRequest("PUT", firewallPolicyPath(site, "batch-reorder")).WithBody(&body)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to reorder firewall policies: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError("reorder firewall policies", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
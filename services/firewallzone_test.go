@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestFirewallService_Zones(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewFirewallService(trans)
+	ctx := context.Background()
+
+	zones, err := svc.ListZones(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListZones failed: %v", err)
+	}
+	if len(zones) != 6 {
+		t.Errorf("Expected 6 built-in zones, got %d", len(zones))
+	}
+
+	zone, err := svc.CreateZone(ctx, "default", &types.FirewallZone{Name: "IoT", NetworkIDs: []string{"net1"}})
+	if err != nil {
+		t.Fatalf("CreateZone failed: %v", err)
+	}
+	if zone.ID == "" || zone.DefaultZone {
+		t.Errorf("CreateZone returned %+v", zone)
+	}
+
+	zone.NetworkIDs = append(zone.NetworkIDs, "net2")
+	updated, err := svc.UpdateZone(ctx, "default", zone)
+	if err != nil {
+		t.Fatalf("UpdateZone failed: %v", err)
+	}
+	if len(updated.NetworkIDs) != 2 {
+		t.Errorf("UpdateZone NetworkIDs = %v", updated.NetworkIDs)
+	}
+
+	if err := svc.DeleteZone(ctx, "default", zone.ID); err != nil {
+		t.Fatalf("DeleteZone failed: %v", err)
+	}
+	if err := svc.DeleteZone(ctx, "default", "zone-internal"); err == nil {
+		t.Error("DeleteZone of a built-in zone should fail")
+	}
+
+	var verrs types.ValidationErrors
+	if _, err := svc.CreateZone(ctx, "default", &types.FirewallZone{}); !errors.As(err, &verrs) {
+		t.Errorf("CreateZone without a name error = %v, want ValidationErrors", err)
+	}
+}
+
+func TestFirewallService_Policies(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewFirewallService(trans)
+	ctx := context.Background()
+
+	newPolicy := func(name string) *types.FirewallPolicy {
+		return &types.FirewallPolicy{
+			Name:        name,
+			Enabled:     true,
+			Action:      types.PolicyActionAllow,
+			Protocol:    types.ProtocolTCP,
+			Source:      types.FirewallPolicyEndpoint{ZoneID: "zone-external", MatchingTarget: types.PolicyTargetAny},
+			Destination: types.FirewallPolicyEndpoint{ZoneID: "zone-internal", MatchingTarget: types.PolicyTargetIP, IPs: []string{"192.168.1.10"}, PortMatchingType: "SPECIFIC", Port: "443"},
+		}
+	}
+
+	first, err := svc.CreatePolicy(ctx, "default", newPolicy("HTTPS to server"))
+	if err != nil {
+		t.Fatalf("CreatePolicy failed: %v", err)
+	}
+	second, err := svc.CreatePolicy(ctx, "default", newPolicy("Second"))
+	if err != nil {
+		t.Fatalf("CreatePolicy failed: %v", err)
+	}
+	if second.Index <= first.Index {
+		t.Errorf("second policy index %d should follow %d", second.Index, first.Index)
+	}
+
+	matrix, err := svc.GetZoneMatrix(ctx, "default")
+	if err != nil {
+		t.Fatalf("GetZoneMatrix failed: %v", err)
+	}
+	cell := matrix.Cell("zone-external", "zone-internal")
+	if cell == nil || cell.Action != types.PolicyActionBlock || cell.PolicyCount != 2 {
+		t.Errorf("external to internal cell = %+v, want BLOCK with 2 policies", cell)
+	}
+
+	err = svc.ReorderPolicies(ctx, "default", &types.FirewallPolicyOrder{
+		SourceZoneID:        "zone-external",
+		DestinationZoneID:   "zone-internal",
+		BeforePredefinedIDs: []string{second.ID, first.ID},
+	})
+	if err != nil {
+		t.Fatalf("ReorderPolicies failed: %v", err)
+	}
+	policies, err := svc.ListPolicies(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListPolicies failed: %v", err)
+	}
+	if len(policies) != 2 || policies[0].ID != second.ID {
+		t.Errorf("ListPolicies after reorder = %+v, want %s first", policies, second.ID)
+	}
+
+	got, err := svc.GetPolicy(ctx, "default", first.ID)
+	if err != nil {
+		t.Fatalf("GetPolicy failed: %v", err)
+	}
+	got.Enabled = false
+	if _, err := svc.UpdatePolicy(ctx, "default", got); err != nil {
+		t.Fatalf("UpdatePolicy failed: %v", err)
+	}
+
+	if err := svc.DeletePolicy(ctx, "default", first.ID); err != nil {
+		t.Fatalf("DeletePolicy failed: %v", err)
+	}
+	if _, err := svc.GetPolicy(ctx, "default", first.ID); err == nil {
+		t.Error("GetPolicy after delete should fail")
+	}
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// hotspotService implements HotspotService.
+type hotspotService struct {
+	transport transport.Transport
+}
+
+// NewHotspotService creates a new hotspot service.
+func NewHotspotService(transport transport.Transport) HotspotService {
+	return &hotspotService{
+		transport: transport,
+	}
+}
+
+// VoucherOption configures CreateVouchers.
+type VoucherOption func(*voucherOptions)
+
+// voucherOptions holds options for CreateVouchers.
+type voucherOptions struct {
+	quota    int
+	up, down int
+	mbytes   int64
+	note     string
+}
+
+// WithVoucherQuota sets how many guests each voucher admits; 0 is
+// unlimited. Vouchers are single-use without it.
+func WithVoucherQuota(n int) VoucherOption {
+	return func(opts *voucherOptions) {
+		opts.quota = max(n, 0)
+	}
+}
+
+// WithVoucherRateLimit limits the upload and download rate of guests
+// using the vouchers, in Kbps. Zero leaves a direction unlimited.
+func WithVoucherRateLimit(upKbps, downKbps int) VoucherOption {
+	return func(opts *voucherOptions) {
+		opts.up = upKbps
+		opts.down = downKbps
+	}
+}
+
+// WithVoucherDataLimit limits the data each guest can transfer, in MB.
+func WithVoucherDataLimit(mbytes int64) VoucherOption {
+	return func(opts *voucherOptions) {
+		opts.mbytes = mbytes
+	}
+}
+
+// WithVoucherNote sets the note printed with the vouchers.
+func WithVoucherNote(note string) VoucherOption {
+	return func(opts *voucherOptions) {
+		opts.note = note
+	}
+}
+
+// ListVouchers returns the vouchers of a site.
+func (s *hotspotService) ListVouchers(ctx context.Context, site string) ([]types.Voucher, error) {
+	return s.listVouchers(ctx, site, nil)
+}
+
+// listVouchers returns the vouchers matching filter, or all of them if it
+// is nil.
+func (s *hotspotService) listVouchers(ctx context.Context, site string, filter map[string]interface{}) ([]types.Voucher, error) {
+	path := internal.BuildAPIPath(site, "stat/voucher")
+	req := transport.NewRequest("GET", path)
+	if filter != nil {
+		req = transport.NewRequest("POST", path).WithBody(filter)
+	}
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vouchers: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list vouchers", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.Voucher](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// CreateVouchers creates count vouchers that each authorize a guest for
+// minutes and returns them. The controller only reports when the batch
+// was created, so the vouchers are read back by their creation time.
+func (s *hotspotService) CreateVouchers(ctx context.Context, site string, count, minutes int, opts ...VoucherOption) ([]types.Voucher, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("voucher count must be positive, got %d", count)
+	}
+	if minutes < 1 {
+		return nil, fmt.Errorf("voucher duration must be positive, got %d minutes", minutes)
+	}
+
+	options := &voucherOptions{quota: 1}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	payload := map[string]interface{}{
+		"cmd":    "create-voucher",
+		"n":      count,
+		"expire": minutes,
+		"quota":  options.quota,
+	}
+
+	if options.up > 0 {
+		payload["up"] = options.up
+	}
+	if options.down > 0 {
+		payload["down"] = options.down
+	}
+	if options.mbytes > 0 {
+		payload["bytes"] = options.mbytes
+	}
+	if options.note != "" {
+		payload["note"] = options.note
+	}
+
+	path := internal.BuildAPIPath(site, "cmd/hotspot")
+	req := transport.NewRequest("POST", path).WithBody(payload)
+	req = withIdempotencyKey(ctx, req)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vouchers: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create vouchers", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[struct {
+		CreateTime int64 `json:"create_time"`
+	}](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create vouchers returned no data")
+	}
+
+	return s.listVouchers(ctx, site, map[string]interface{}{"create_time": apiResp.Data[0].CreateTime})
+}
+
+// RevokeVoucher deletes a voucher so it can no longer be used. Guests it
+// already authorized stay authorized. Revoking a voucher that does not
+// exist is not an error.
+func (s *hotspotService) RevokeVoucher(ctx context.Context, site, id string) error {
+	payload := map[string]interface{}{
+		"cmd": "delete-voucher",
+		"_id": id,
+	}
+
+	path := internal.BuildAPIPath(site, "cmd/hotspot")
+	req := transport.NewRequest("POST", path).WithBody(payload)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to revoke voucher: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("voucher", resp)
+	}
+
+	return nil
+}
+
+// ListOperators returns the hotspot operator accounts.
+func (s *hotspotService) ListOperators(ctx context.Context, site string) ([]types.HotspotOperator, error) {
+	path := internal.BuildRESTPath(site, "hotspotop", "")
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hotspot operators: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list hotspot operators", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.HotspotOperator](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// CreateOperator creates a hotspot operator account.
+func (s *hotspotService) CreateOperator(ctx context.Context, site string, operator *types.HotspotOperator) (*types.HotspotOperator, error) {
+	if err := validate(ctx, "hotspot operator", operator); err != nil {
+		return nil, err
+	}
+
+	path := internal.BuildRESTPath(site, "hotspotop", "")
+	req := transport.NewRequest("POST", path).WithBody(operator)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hotspot operator: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create hotspot operator", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.HotspotOperator](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create hotspot operator returned no data")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// UpdateOperator updates a hotspot operator account.
+func (s *hotspotService) UpdateOperator(ctx context.Context, site string, operator *types.HotspotOperator) (*types.HotspotOperator, error) {
+	if operator.ID == "" {
+		return nil, fmt.Errorf("hotspot operator ID is required for update")
+	}
+
+	if err := validate(ctx, "hotspot operator", operator); err != nil {
+		return nil, err
+	}
+
+	path := internal.BuildRESTPath(site, "hotspotop", operator.ID)
+	req := transport.NewRequest("PUT", path).WithBody(operator)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update hotspot operator: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		if resp.StatusCode == 404 {
+			return nil, notFoundf("hotspot operator not found: %s", operator.ID)
+		}
+		return nil, types.NewResponseError("update hotspot operator", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.HotspotOperator](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("update hotspot operator returned no data")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// DeleteOperator deletes a hotspot operator account.
+func (s *hotspotService) DeleteOperator(ctx context.Context, site, id string) error {
+	path := internal.BuildRESTPath(site, "hotspotop", id)
+	req := transport.NewRequest("DELETE", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete hotspot operator: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("hotspot operator", resp)
+	}
+
+	return nil
+}
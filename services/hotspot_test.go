@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestHotspotService_Vouchers(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewHotspotService(trans)
+	ctx := context.Background()
+
+	single, err := svc.CreateVouchers(ctx, "default", 3, 60)
+	if err != nil {
+		t.Fatalf("CreateVouchers failed: %v", err)
+	}
+	if len(single) != 3 {
+		t.Fatalf("Expected 3 vouchers, got %d", len(single))
+	}
+	if single[0].Duration != 60 || single[0].MultiUse() || single[0].Remaining() != 1 {
+		t.Errorf("single-use voucher = %+v", single[0])
+	}
+
+	multi, err := svc.CreateVouchers(ctx, "default", 1, 1440,
+		WithVoucherQuota(0),
+		WithVoucherRateLimit(1024, 4096),
+		WithVoucherDataLimit(500),
+		WithVoucherNote("conference"))
+	if err != nil {
+		t.Fatalf("CreateVouchers failed: %v", err)
+	}
+	if len(multi) != 1 {
+		t.Fatalf("Expected only the new voucher, got %d", len(multi))
+	}
+	v := multi[0]
+	if !v.MultiUse() || v.Remaining() != -1 || !v.QOSOverwrite || v.QOSRateMaxUp != 1024 ||
+		v.QOSRateMaxDown != 4096 || v.QOSUsageQuota != 500 || v.Note != "conference" {
+		t.Errorf("multi-use voucher = %+v", v)
+	}
+	if len(v.FormattedCode()) != 11 {
+		t.Errorf("FormattedCode() = %q, want 12345-67890 form", v.FormattedCode())
+	}
+
+	vouchers, err := svc.ListVouchers(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListVouchers failed: %v", err)
+	}
+	if len(vouchers) != 4 {
+		t.Errorf("Expected 4 vouchers, got %d", len(vouchers))
+	}
+
+	if err := svc.RevokeVoucher(ctx, "default", v.ID); err != nil {
+		t.Fatalf("RevokeVoucher failed: %v", err)
+	}
+	if server.State().GetHotspotVoucher(v.ID) != nil {
+		t.Error("voucher still exists after RevokeVoucher")
+	}
+	if err := svc.RevokeVoucher(ctx, "default", v.ID); err != nil {
+		t.Errorf("second RevokeVoucher should succeed, got %v", err)
+	}
+
+	if _, err := svc.CreateVouchers(ctx, "default", 0, 60); err == nil {
+		t.Error("CreateVouchers of 0 vouchers should fail")
+	}
+}
+
+func TestHotspotService_Operators(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewHotspotService(trans)
+	ctx := context.Background()
+
+	op, err := svc.CreateOperator(ctx, "default", &types.HotspotOperator{Name: "frontdesk", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("CreateOperator failed: %v", err)
+	}
+	if op.ID == "" {
+		t.Fatal("CreateOperator returned an operator without an ID")
+	}
+
+	op.Note = "lobby"
+	if _, err := svc.UpdateOperator(ctx, "default", op); err != nil {
+		t.Fatalf("UpdateOperator failed: %v", err)
+	}
+
+	operators, err := svc.ListOperators(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListOperators failed: %v", err)
+	}
+	if len(operators) != 1 || operators[0].Note != "lobby" {
+		t.Errorf("ListOperators = %+v", operators)
+	}
+
+	if err := svc.DeleteOperator(ctx, "default", op.ID); err != nil {
+		t.Fatalf("DeleteOperator failed: %v", err)
+	}
+
+	var verrs types.ValidationErrors
+	if _, err := svc.CreateOperator(ctx, "default", &types.HotspotOperator{Name: "frontdesk"}); !errors.As(err, &verrs) {
+		t.Errorf("CreateOperator without a password error = %v, want ValidationErrors", err)
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"github.com/unifi-go/gofi/transport"
+)
+
+// idempotencyKeyKey is the context key set by WithIdempotencyKey.
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey returns a context that attaches key to the devmgr and
+// stamgr commands sent with it. The client runs a command with a given key
+// at most once: repeating it after a success returns the first result,
+// and repeating it after an ambiguous failure, such as a timeout, fails
+// with transport.ErrOutcomeUnknown instead of risking a second power-cycle
+// or restart. Use transport.NewIdempotencyKey for a fresh key, and a new
+// key for each command that is meant to run.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// withIdempotencyKey sets the idempotency key of ctx, if any, on req.
+func withIdempotencyKey(ctx context.Context, req *transport.Request) *transport.Request {
+	if key, _ := ctx.Value(idempotencyKeyKey{}).(string); key != "" {
+		req.WithHeader(transport.IdempotencyKeyHeader, key)
+	}
+	return req
+}
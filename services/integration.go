@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// integrationPageLimit is the largest page the Integrations API returns.
+const integrationPageLimit = 200
+
+// integrationService implements IntegrationService.
+type integrationService struct {
+	transport transport.Transport
+
+	// siteIDs maps legacy site names to Integrations API site IDs.
+	mu      sync.Mutex
+	siteIDs map[string]string
+}
+
+// NewIntegrationService creates a new Integrations API service.
+func NewIntegrationService(transport transport.Transport) IntegrationService {
+	return &integrationService{
+		transport: transport,
+		siteIDs:   make(map[string]string),
+	}
+}
+
+// Info returns the version of the application serving the API. It fails
+// on controllers without the Integrations API.
+func (s *integrationService) Info(ctx context.Context) (*types.IntegrationInfo, error) {
+	req := transport.NewRequest("GET", internal.BuildIntegrationPath("info"))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration info: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("get integration info", resp.StatusCode, resp.Body)
+	}
+
+	var info types.IntegrationInfo
+	if err := json.Unmarshal(resp.Body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse integration info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// ListSites returns all sites.
+func (s *integrationService) ListSites(ctx context.Context) ([]types.IntegrationSite, error) {
+	sites, err := listIntegration[types.IntegrationSite](ctx, s.transport, internal.BuildIntegrationPath("sites"), "integration sites")
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	for _, site := range sites {
+		s.siteIDs[site.InternalReference] = site.ID
+	}
+	s.mu.Unlock()
+
+	return sites, nil
+}
+
+// SiteID returns the Integrations API ID of a site given by legacy name or
+// by ID.
+func (s *integrationService) SiteID(ctx context.Context, site string) (string, error) {
+	if site == "" {
+		site = "default"
+	}
+
+	s.mu.Lock()
+	id, ok := s.siteIDs[site]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	sites, err := s.ListSites(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, st := range sites {
+		if st.InternalReference == site || st.ID == site {
+			return st.ID, nil
+		}
+	}
+
+	return "", notFoundf("site not found: %s", site)
+}
+
+// ListDevices returns the adopted devices of a site.
+func (s *integrationService) ListDevices(ctx context.Context, site string) ([]types.IntegrationDevice, error) {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	return listIntegration[types.IntegrationDevice](ctx, s.transport, internal.BuildIntegrationPath("sites", siteID, "devices"), "integration devices")
+}
+
+// GetDevice returns a device by Integrations API ID.
+func (s *integrationService) GetDevice(ctx context.Context, site, id string) (*types.IntegrationDevice, error) {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("GET", internal.BuildIntegrationPath("sites", siteID, "devices", id))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration device: %w", err)
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, notFoundf("device not found: %s", id)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("get integration device", resp.StatusCode, resp.Body)
+	}
+
+	var device types.IntegrationDevice
+	if err := json.Unmarshal(resp.Body, &device); err != nil {
+		return nil, fmt.Errorf("failed to parse integration device: %w", err)
+	}
+
+	return &device, nil
+}
+
+// RestartDevice restarts a device given by Integrations API ID.
+func (s *integrationService) RestartDevice(ctx context.Context, site, id string) error {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	path := internal.BuildIntegrationPath("sites", siteID, "devices", id, "actions")
+	req := transport.NewRequest("POST", path).WithBody(map[string]string{"action": "RESTART"})
+
+	resp, err := s.transport.Do(ctx, withIdempotencyKey(ctx, req))
+	if err != nil {
+		return fmt.Errorf("failed to restart device: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError("restart device", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+// ListClients returns the connected clients of a site.
+func (s *integrationService) ListClients(ctx context.Context, site string) ([]types.IntegrationClient, error) {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	return listIntegration[types.IntegrationClient](ctx, s.transport, internal.BuildIntegrationPath("sites", siteID, "clients"), "integration clients")
+}
+
+// ListVouchers returns the hotspot vouchers of a site.
+func (s *integrationService) ListVouchers(ctx context.Context, site string) ([]types.IntegrationVoucher, error) {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	return listIntegration[types.IntegrationVoucher](ctx, s.transport, internal.BuildIntegrationPath("sites", siteID, "hotspot", "vouchers"), "vouchers")
+}
+
+// CreateVouchers creates vouchers and returns them.
+func (s *integrationService) CreateVouchers(ctx context.Context, site string, voucherReq *types.IntegrationVoucherRequest) ([]types.IntegrationVoucher, error) {
+	if voucherReq.Count < 1 {
+		return nil, fmt.Errorf("voucher count must be at least 1")
+	}
+
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	req := transport.NewRequest("POST", internal.BuildIntegrationPath("sites", siteID, "hotspot", "vouchers")).WithBody(voucherReq)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vouchers: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create vouchers", resp.StatusCode, resp.Body)
+	}
+
+	var created struct {
+		Vouchers []types.IntegrationVoucher `json:"vouchers"`
+	}
+	if err := json.Unmarshal(resp.Body, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse vouchers: %w", err)
+	}
+
+	return created.Vouchers, nil
+}
+
+// DeleteVoucher deletes a voucher by ID.
+func (s *integrationService) DeleteVoucher(ctx context.Context, site, id string) error {
+	siteID, err := s.SiteID(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	req := transport.NewRequest("DELETE", internal.BuildIntegrationPath("sites", siteID, "hotspot", "vouchers", id))
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete voucher: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("voucher", resp)
+	}
+
+	return nil
+}
+
+// listIntegration fetches every page of an Integrations API list.
+func listIntegration[T any](ctx context.Context, t transport.Transport, path, what string) ([]T, error) {
+	items := []T{}
+	for {
+		query := url.Values{}
+		query.Set("offset", strconv.Itoa(len(items)))
+		query.Set("limit", strconv.Itoa(integrationPageLimit))
+
+		resp, err := t.Do(ctx, transport.NewRequest("GET", path+"?"+query.Encode()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", what, err)
+		}
+
+		if !resp.IsSuccess() {
+			return nil, types.NewResponseError("list "+what, resp.StatusCode, resp.Body)
+		}
+
+		var page types.IntegrationPage[T]
+		if err := json.Unmarshal(resp.Body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", what, err)
+		}
+
+		items = append(items, page.Data...)
+		if len(page.Data) == 0 || len(items) >= page.TotalCount {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestIntegrationService_SitesAndDevices(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(), mock.WithIntegrationAPI())
+	defer server.Close()
+
+	// More devices than fit on one page
+	for i := 0; i < 205; i++ {
+		server.State().AddDevice(&types.Device{
+			ID:    fmt.Sprintf("device%03d", i),
+			MAC:   fmt.Sprintf("AA:BB:CC:DD:%02X:%02X", i/256, i%256),
+			Type:  "usw",
+			Model: "USW-24-POE",
+			State: types.DeviceStateConnected,
+		})
+	}
+
+	trans, err := newTestTransport(server.URL())
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	svc := NewIntegrationService(trans)
+	ctx := context.Background()
+
+	info, err := svc.Info(ctx)
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.ApplicationVersion == "" {
+		t.Error("Info() returned no application version")
+	}
+
+	sites, err := svc.ListSites(ctx)
+	if err != nil {
+		t.Fatalf("ListSites() error = %v", err)
+	}
+	if len(sites) != 1 || sites[0].InternalReference != "default" {
+		t.Fatalf("ListSites() = %+v, want the default site", sites)
+	}
+	if site := sites[0].Site(); site.Name != "default" || site.Desc != "Default Site" {
+		t.Errorf("Site() = %+v", site)
+	}
+
+	devices, err := svc.ListDevices(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListDevices() error = %v", err)
+	}
+	if len(devices) != 205 {
+		t.Fatalf("ListDevices() returned %d devices, want 205", len(devices))
+	}
+	basic := devices[0].DeviceBasic()
+	if basic.Type != "usw" || basic.State != types.DeviceStateConnected || basic.MAC != "aa:bb:cc:dd:00:00" {
+		t.Errorf("DeviceBasic() = %+v", basic)
+	}
+
+	device, err := svc.GetDevice(ctx, sites[0].ID, "device007")
+	if err != nil {
+		t.Fatalf("GetDevice() error = %v", err)
+	}
+	if device.ID != "device007" {
+		t.Errorf("GetDevice() ID = %q", device.ID)
+	}
+	if _, err := svc.GetDevice(ctx, "default", "missing"); err == nil {
+		t.Error("GetDevice() of a missing device should fail")
+	}
+
+	if err := svc.RestartDevice(ctx, "default", "device007"); err != nil {
+		t.Errorf("RestartDevice() error = %v", err)
+	}
+
+	if _, err := svc.ListDevices(ctx, "nosuchsite"); err == nil {
+		t.Error("ListDevices() of an unknown site should fail")
+	}
+}
+
+func TestIntegrationService_Clients(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(), mock.WithIntegrationAPI())
+	defer server.Close()
+
+	now := time.Now().Unix()
+	server.State().AddClient(&types.Client{ID: "c1", MAC: "aa:bb:cc:00:00:01", Name: "Laptop", LastSeen: types.NewFlexTime(now), Uptime: types.FlexInt{Val: 600}, IsWired: types.FlexBool{Val: true}})
+	server.State().AddClient(&types.Client{ID: "c2", MAC: "aa:bb:cc:00:00:02", LastSeen: types.NewFlexTime(now - 3600)})
+
+	trans, err := newTestTransport(server.URL())
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	svc := NewIntegrationService(trans)
+
+	clients, err := svc.ListClients(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListClients() error = %v", err)
+	}
+	if len(clients) != 1 {
+		t.Fatalf("ListClients() returned %d clients, want the connected one", len(clients))
+	}
+	client := clients[0].Client()
+	if client.MAC != "aa:bb:cc:00:00:01" || !client.IsWired.Val || client.Uptime.Int64() < 600 {
+		t.Errorf("Client() = %+v", client)
+	}
+}
+
+func TestIntegrationService_Vouchers(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(), mock.WithIntegrationAPI())
+	defer server.Close()
+
+	trans, err := newTestTransport(server.URL())
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	svc := NewIntegrationService(trans)
+	ctx := context.Background()
+
+	created, err := svc.CreateVouchers(ctx, "default", &types.IntegrationVoucherRequest{Count: 3, Name: "Lobby", TimeLimitMinutes: 60})
+	if err != nil {
+		t.Fatalf("CreateVouchers() error = %v", err)
+	}
+	if len(created) != 3 || created[0].Code == "" || created[0].TimeLimitMinutes != 60 {
+		t.Fatalf("CreateVouchers() = %+v", created)
+	}
+
+	if err := svc.DeleteVoucher(ctx, "default", created[0].ID); err != nil {
+		t.Fatalf("DeleteVoucher() error = %v", err)
+	}
+	if err := svc.DeleteVoucher(ctx, "default", created[0].ID); err != nil {
+		t.Errorf("DeleteVoucher() of a deleted voucher error = %v", err)
+	}
+
+	vouchers, err := svc.ListVouchers(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListVouchers() error = %v", err)
+	}
+	if len(vouchers) != 2 {
+		t.Errorf("ListVouchers() returned %d vouchers, want 2", len(vouchers))
+	}
+
+	if _, err := svc.CreateVouchers(ctx, "default", &types.IntegrationVoucherRequest{Name: "None"}); err == nil {
+		t.Error("CreateVouchers() with a zero count should fail")
+	}
+}
+
+func TestIntegrationService_Unavailable(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, err := newTestTransport(server.URL())
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	svc := NewIntegrationService(trans)
+
+	if _, err := svc.Info(context.Background()); err == nil {
+		t.Error("Info() should fail without the Integrations API")
+	}
+	if _, err := svc.ListDevices(context.Background(), "default"); err == nil {
+		t.Error("ListDevices() should fail without the Integrations API")
+	}
+}
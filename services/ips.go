@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// ipsEventQuery is the body of a stat/ips/event request. Times are epoch
+// milliseconds.
+type ipsEventQuery struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// IPSEvents returns the IPS events between start and end, oldest first as
+// the controller returns them.
+func (s *statsService) IPSEvents(ctx context.Context, site string, start, end time.Time) ([]types.IPSEvent, error) {
+	path := internal.BuildAPIPath(site, "stat/ips/event")
+	req := transport.NewRequest("POST", path).WithBody(ipsEventQuery{Start: start.UnixMilli(), End: end.UnixMilli()})
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IPS events: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list IPS events", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.IPSEvent](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// HoneypotEvents reads the site's honeypots and returns the IPS events
+// between start and end that they triggered.
+func (s *statsService) HoneypotEvents(ctx context.Context, site string, start, end time.Time) ([]types.IPSEvent, error) {
+	setting, err := NewSettingService(s.transport).GetIPS(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.IPSEvents(ctx, site, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggered []types.IPSEvent
+	for i := range events {
+		if events[i].IsHoneypot(setting.Honeypots) {
+			triggered = append(triggered, events[i])
+		}
+	}
+	return triggered, nil
+}
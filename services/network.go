@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
@@ -32,7 +33,7 @@ func (s *networkService) List(ctx context.Context, site string) ([]types.Network
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list networks failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list networks", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Network](resp.Body)
@@ -54,7 +55,7 @@ func (s *networkService) Get(ctx context.Context, site, id string) (*types.Netwo
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get network failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get network", resp.StatusCode, resp.Body)
 	}
 
 	network, err := internal.ParseSingleResult[types.Network](resp.Body)
@@ -67,6 +68,10 @@ func (s *networkService) Get(ctx context.Context, site, id string) (*types.Netwo
 
 // Create creates a new network.
 func (s *networkService) Create(ctx context.Context, site string, network *types.Network) (*types.Network, error) {
+	if err := validate(ctx, "network", network); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "networkconf", "")
 	req := transport.NewRequest("POST", path).WithBody(network)
 
@@ -76,7 +81,7 @@ func (s *networkService) Create(ctx context.Context, site string, network *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create network failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create network", resp.StatusCode, resp.Body)
 	}
 
 	created, err := internal.ParseSingleResult[types.Network](resp.Body)
@@ -89,6 +94,10 @@ func (s *networkService) Create(ctx context.Context, site string, network *types
 
 // Update updates a network.
 func (s *networkService) Update(ctx context.Context, site string, network *types.Network) (*types.Network, error) {
+	if err := validate(ctx, "network", network); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "networkconf", network.ID)
 	req := transport.NewRequest("PUT", path).WithBody(network)
 
@@ -98,7 +107,7 @@ func (s *networkService) Update(ctx context.Context, site string, network *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update network failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update network", resp.StatusCode, resp.Body)
 	}
 
 	updated, err := internal.ParseSingleResult[types.Network](resp.Body)
@@ -119,9 +128,66 @@ func (s *networkService) Delete(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete network: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete network failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("network", resp)
 	}
 
 	return nil
 }
+
+// ListWithUsage joins the network list with the active clients. A client
+// belongs to the network named by its network ID, or failing that, to the
+// network whose subnet contains its IP address.
+func (s *networkService) ListWithUsage(ctx context.Context, site string) ([]types.NetworkUsage, error) {
+	networks, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := NewClientService(s.transport).ListActive(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]types.NetworkUsage, len(networks))
+	byID := make(map[string]*types.NetworkUsage, len(networks))
+	subnets := make([]*net.IPNet, len(networks))
+	for i := range networks {
+		usage[i] = types.NetworkUsage{
+			Network:      networks[i],
+			DHCPPoolSize: networks[i].DHCPPoolSize(),
+		}
+		byID[networks[i].ID] = &usage[i]
+		if _, subnet, err := net.ParseCIDR(networks[i].IPSubnet); err == nil {
+			subnets[i] = subnet
+		}
+	}
+
+	for _, c := range clients {
+		u := byID[c.NetworkID]
+		if u == nil {
+			ip := net.ParseIP(c.IP)
+			for i, subnet := range subnets {
+				if ip != nil && subnet != nil && subnet.Contains(ip) {
+					u = &usage[i]
+					break
+				}
+			}
+		}
+		if u == nil {
+			continue
+		}
+
+		u.ActiveClients++
+		if c.IsWired.Val {
+			u.WiredClients++
+		} else {
+			u.WirelessClients++
+		}
+		if !c.UseFixedIP && u.Network.InDHCPPool(c.IP) {
+			u.DHCPLeases++
+		}
+	}
+
+	return usage, nil
+}
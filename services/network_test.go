@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestNetworkService_ListWithUsage(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddNetwork(&types.Network{
+		ID:           "lan",
+		Name:         "LAN",
+		IPSubnet:     "192.168.1.1/24",
+		DHCPDEnabled: true,
+		DHCPDStart:   "192.168.1.100",
+		DHCPDStop:    "192.168.1.199",
+	})
+	server.State().AddNetwork(&types.Network{ID: "iot", Name: "IoT", IPSubnet: "10.0.20.1/24"})
+
+	now := time.Now().Unix()
+	for _, c := range []*types.Client{
+		{MAC: "aa:00:00:00:00:01", IP: "192.168.1.100", NetworkID: "lan", IsWired: types.FlexBool{Val: true}},
+		{MAC: "aa:00:00:00:00:02", IP: "192.168.1.150"}, // matched by subnet
+		{MAC: "aa:00:00:00:00:03", IP: "192.168.1.10", UseFixedIP: true, NetworkID: "lan"},
+		{MAC: "aa:00:00:00:00:04", IP: "10.0.20.5", NetworkID: "iot"},
+	} {
+		c.LastSeen = types.NewFlexTime(now)
+		server.State().AddClient(c)
+	}
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewNetworkService(trans)
+
+	usage, err := svc.ListWithUsage(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListWithUsage() error = %v", err)
+	}
+
+	byName := make(map[string]types.NetworkUsage)
+	for _, u := range usage {
+		byName[u.Network.Name] = u
+	}
+
+	lan := byName["LAN"]
+	if lan.ActiveClients != 3 || lan.WiredClients != 1 || lan.WirelessClients != 2 {
+		t.Errorf("LAN clients = %d (%d wired, %d wireless), want 3 (1, 2)", lan.ActiveClients, lan.WiredClients, lan.WirelessClients)
+	}
+	if lan.DHCPLeases != 2 || lan.DHCPPoolSize != 100 || lan.DHCPUtilization() != 0.02 {
+		t.Errorf("LAN DHCP = %d/%d (%v), want 2/100", lan.DHCPLeases, lan.DHCPPoolSize, lan.DHCPUtilization())
+	}
+
+	iot := byName["IoT"]
+	if iot.ActiveClients != 1 || iot.DHCPPoolSize != 0 || iot.DHCPUtilization() != 0 {
+		t.Errorf("IoT = %+v, want 1 client and no DHCP pool", iot)
+	}
+}
+
+func TestNetworkService_Delete_Referenced(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddNetwork(&types.Network{ID: "iot", Name: "IoT"})
+	server.State().AddWLAN(&types.WLAN{ID: "wlan1", Name: "IoT WiFi", NetworkConfID: "iot"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewNetworkService(trans)
+	ctx := context.Background()
+
+	err := svc.Delete(ctx, "default", "iot")
+	if err == nil || !strings.Contains(err.Error(), "api.err.ObjectReferredBy") {
+		t.Fatalf("Delete() of referenced network error = %v, want api.err.ObjectReferredBy", err)
+	}
+	if _, ok := server.State().GetNetwork("iot"); !ok {
+		t.Error("referenced network was deleted")
+	}
+
+	// Once the WLAN moves away the delete goes through
+	server.State().AddWLAN(&types.WLAN{ID: "wlan1", Name: "IoT WiFi"})
+	if err := svc.Delete(ctx, "default", "iot"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is wrapped by the errors of lookups that find no matching
+// object, such as Get with an unknown ID.
+var ErrNotFound = errors.New("resource not found")
+
+// notFoundError keeps the message of a failed lookup while wrapping
+// ErrNotFound.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string { return e.msg }
+
+func (e *notFoundError) Unwrap() error { return ErrNotFound }
+
+// notFoundf formats a lookup error that wraps ErrNotFound.
+func notFoundf(format string, args ...any) error {
+	return &notFoundError{msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// PoEBudget looks up a switch by MAC and summarizes its PoE power with
+// types.NewPoEBudget. It fails if the device has no PoE ports.
+func (s *deviceService) PoEBudget(ctx context.Context, site, mac string) (*types.PoEBudget, error) {
+	device, err := s.GetByMAC(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := types.NewPoEBudget(device)
+	if len(budget.Ports) == 0 {
+		return nil, fmt.Errorf("device %s has no PoE ports", mac)
+	}
+
+	return budget, nil
+}
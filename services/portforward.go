@@ -2,13 +2,40 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
 )
 
+// ErrPortForwardConflict is matched by the errors ValidateNoConflict
+// returns when a forward overlaps existing ones.
+var ErrPortForwardConflict = errors.New("port forward conflict")
+
+// PortForwardConflictError lists the existing forwards a port forward
+// overlaps. It matches ErrPortForwardConflict with errors.Is.
+type PortForwardConflictError struct {
+	Forward   *types.PortForward
+	Conflicts []types.PortForward
+}
+
+// Error implements error.
+func (e *PortForwardConflictError) Error() string {
+	names := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		names[i] = fmt.Sprintf("%q (%s %s)", c.Name, c.Protocol, c.DstPort)
+	}
+	return fmt.Sprintf("port forward %q (%s %s) overlaps %s", e.Forward.Name, e.Forward.Protocol, e.Forward.DstPort, strings.Join(names, ", "))
+}
+
+// Is reports whether target is ErrPortForwardConflict.
+func (e *PortForwardConflictError) Is(target error) bool {
+	return target == ErrPortForwardConflict
+}
+
 // portForwardService implements PortForwardService.
 type portForwardService struct {
 	transport transport.Transport
@@ -32,7 +59,7 @@ func (s *portForwardService) List(ctx context.Context, site string) ([]types.Por
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list port forwards failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list port forwards", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortForward](resp.Body)
@@ -55,9 +82,9 @@ func (s *portForwardService) Get(ctx context.Context, site, id string) (*types.P
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("port forward not found: %s", id)
+			return nil, notFoundf("port forward not found: %s", id)
 		}
-		return nil, fmt.Errorf("get port forward failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get port forward", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortForward](resp.Body)
@@ -66,7 +93,7 @@ func (s *portForwardService) Get(ctx context.Context, site, id string) (*types.P
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("port forward not found: %s", id)
+		return nil, notFoundf("port forward not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -74,6 +101,10 @@ func (s *portForwardService) Get(ctx context.Context, site, id string) (*types.P
 
 // Create creates a new port forward.
 func (s *portForwardService) Create(ctx context.Context, site string, forward *types.PortForward) (*types.PortForward, error) {
+	if err := validate(ctx, "port forward", forward); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "portforward", "")
 	req := transport.NewRequest("POST", path).WithBody(forward)
 
@@ -83,7 +114,7 @@ func (s *portForwardService) Create(ctx context.Context, site string, forward *t
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create port forward failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create port forward", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortForward](resp.Body)
@@ -104,6 +135,10 @@ func (s *portForwardService) Update(ctx context.Context, site string, forward *t
 		return nil, fmt.Errorf("port forward ID is required for update")
 	}
 
+	if err := validate(ctx, "port forward", forward); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "portforward", forward.ID)
 	req := transport.NewRequest("PUT", path).WithBody(forward)
 
@@ -114,9 +149,9 @@ func (s *portForwardService) Update(ctx context.Context, site string, forward *t
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("port forward not found: %s", forward.ID)
+			return nil, notFoundf("port forward not found: %s", forward.ID)
 		}
-		return nil, fmt.Errorf("update port forward failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update port forward", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortForward](resp.Body)
@@ -141,11 +176,8 @@ func (s *portForwardService) Delete(ctx context.Context, site, id string) error
 		return fmt.Errorf("failed to delete port forward: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		if resp.StatusCode == 404 {
-			return fmt.Errorf("port forward not found: %s", id)
-		}
-		return fmt.Errorf("delete port forward failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("port forward", resp)
 	}
 
 	return nil
@@ -159,7 +191,7 @@ func (s *portForwardService) Enable(ctx context.Context, site, id string) error
 	}
 
 	forward.Enabled = true
-	_, err = s.Update(ctx, site, forward)
+	_, err = s.Update(WithoutValidation(ctx), site, forward)
 	return err
 }
 
@@ -171,6 +203,33 @@ func (s *portForwardService) Disable(ctx context.Context, site, id string) error
 	}
 
 	forward.Enabled = false
-	_, err = s.Update(ctx, site, forward)
+	_, err = s.Update(WithoutValidation(ctx), site, forward)
 	return err
 }
+
+// ValidateNoConflict returns a *PortForwardConflictError if forward
+// overlaps an enabled forward of the site, as decided by
+// types.PortForward.Overlaps. The forward with the same ID, if any, is
+// skipped so an update can be checked against the others. Call it before
+// Create or Update; neither checks for conflicts itself.
+func (s *portForwardService) ValidateNoConflict(ctx context.Context, site string, forward *types.PortForward) error {
+	forwards, err := s.List(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []types.PortForward
+	for _, existing := range forwards {
+		if !existing.Enabled || (forward.ID != "" && existing.ID == forward.ID) {
+			continue
+		}
+		if forward.Overlaps(&existing) {
+			conflicts = append(conflicts, existing)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &PortForwardConflictError{Forward: forward, Conflicts: conflicts}
+	}
+	return nil
+}
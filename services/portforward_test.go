@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"testing"
 
 	"github.com/unifi-go/gofi/mock"
@@ -258,3 +259,55 @@ func TestPortForwardService_Disable(t *testing.T) {
 		t.Error("Expected port forward to be disabled")
 	}
 }
+
+func TestPortForwardService_ValidateNoConflict(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddPortForward(&types.PortForward{ID: "web", Name: "Web", Enabled: true, Protocol: "tcp", DstPort: "80,443", FwdIP: "192.168.1.100"})
+	server.State().AddPortForward(&types.PortForward{ID: "game", Name: "Game", Enabled: true, Protocol: "udp", DstPort: "27015-27030", FwdIP: "192.168.1.101", WANInterface: types.PortForwardWAN2})
+	server.State().AddPortForward(&types.PortForward{ID: "old", Name: "Old", Enabled: false, Protocol: "tcp_udp", DstPort: "8080", FwdIP: "192.168.1.102"})
+
+	trans, _ := newTestPortForwardTransport(server.URL())
+	svc := NewPortForwardService(trans)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		forward types.PortForward
+		want    []string
+	}{
+		{"free port", types.PortForward{Name: "SSH", Protocol: "tcp", DstPort: "22"}, nil},
+		{"single port in list", types.PortForward{Name: "TLS", Protocol: "tcp", DstPort: "443"}, []string{"web"}},
+		{"other protocol", types.PortForward{Name: "QUIC", Protocol: "udp", DstPort: "443"}, nil},
+		{"both protocols", types.PortForward{Name: "Any", Protocol: "tcp_udp", DstPort: "1-100"}, []string{"web"}},
+		{"range overlap on both WANs", types.PortForward{Name: "Game 2", Protocol: "udp", DstPort: "27000-27015", WANInterface: types.PortForwardBothWANs}, []string{"game"}},
+		{"other WAN", types.PortForward{Name: "Game 2", Protocol: "udp", DstPort: "27020", WANInterface: types.PortForwardWAN}, nil},
+		{"disabled forward ignored", types.PortForward{Name: "Proxy", Protocol: "tcp", DstPort: "8080"}, nil},
+		{"same ID skipped", types.PortForward{ID: "web", Name: "Web", Protocol: "tcp", DstPort: "80"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateNoConflict(ctx, "default", &tt.forward)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateNoConflict() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var conflict *PortForwardConflictError
+			if !errors.As(err, &conflict) || !errors.Is(err, ErrPortForwardConflict) {
+				t.Fatalf("ValidateNoConflict() error = %v, want PortForwardConflictError", err)
+			}
+			var ids []string
+			for _, c := range conflict.Conflicts {
+				ids = append(ids, c.ID)
+			}
+			if len(ids) != len(tt.want) || ids[0] != tt.want[0] {
+				t.Errorf("conflicts = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -32,7 +32,7 @@ func (s *portProfileService) List(ctx context.Context, site string) ([]types.Por
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list port profiles failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list port profiles", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortProfile](resp.Body)
@@ -55,9 +55,9 @@ func (s *portProfileService) Get(ctx context.Context, site, id string) (*types.P
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("port profile not found: %s", id)
+			return nil, notFoundf("port profile not found: %s", id)
 		}
-		return nil, fmt.Errorf("get port profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get port profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortProfile](resp.Body)
@@ -66,7 +66,7 @@ func (s *portProfileService) Get(ctx context.Context, site, id string) (*types.P
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("port profile not found: %s", id)
+		return nil, notFoundf("port profile not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -83,7 +83,7 @@ func (s *portProfileService) Create(ctx context.Context, site string, profile *t
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create port profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create port profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortProfile](resp.Body)
@@ -114,9 +114,9 @@ func (s *portProfileService) Update(ctx context.Context, site string, profile *t
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("port profile not found: %s", profile.ID)
+			return nil, notFoundf("port profile not found: %s", profile.ID)
 		}
-		return nil, fmt.Errorf("update port profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update port profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.PortProfile](resp.Body)
@@ -141,11 +141,8 @@ func (s *portProfileService) Delete(ctx context.Context, site, id string) error
 		return fmt.Errorf("failed to delete port profile: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		if resp.StatusCode == 404 {
-			return fmt.Errorf("port profile not found: %s", id)
-		}
-		return fmt.Errorf("delete port profile failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("port profile", resp)
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// ProvisionOption configures WaitForProvision.
+type ProvisionOption func(*provisionOptions)
+
+// provisionOptions holds options for WaitForProvision.
+type provisionOptions struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// WithProvisionTimeout sets how long WaitForProvision waits (default: 5m).
+func WithProvisionTimeout(d time.Duration) ProvisionOption {
+	return func(opts *provisionOptions) {
+		opts.timeout = d
+	}
+}
+
+// WithProvisionPollInterval sets how often WaitForProvision polls device
+// state (default: 2s).
+func WithProvisionPollInterval(d time.Duration) ProvisionOption {
+	return func(opts *provisionOptions) {
+		opts.pollInterval = d
+	}
+}
+
+// WaitForProvision polls a device until it has applied a new configuration
+// and returns it. Pass the device's cfgversion from before the change as
+// sinceCfgVersion: the device counts as provisioned once it is connected
+// with a different cfgversion. With an empty sinceCfgVersion it counts as
+// provisioned once it is connected with a provisioned_at no earlier than
+// the call. Errors listing devices are retried until the timeout.
+func (s *deviceService) WaitForProvision(ctx context.Context, site, mac, sinceCfgVersion string, opts ...ProvisionOption) (*types.Device, error) {
+	options := &provisionOptions{
+		timeout:      5 * time.Minute,
+		pollInterval: 2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	started := time.Now().Unix()
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(options.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		devices, err := s.List(ctx, site)
+		if err == nil {
+			d := findDeviceByMAC(devices, mac)
+			if d != nil && d.State == types.DeviceStateConnected {
+				if sinceCfgVersion != "" && d.ConfigVersion != sinceCfgVersion {
+					return d, nil
+				}
+				if sinceCfgVersion == "" && d.ProvisionedAt.Val.Unix() >= started {
+					return d, nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("device %s was not provisioned within %s", mac, options.timeout)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// RebootTokenTTL is how long a token from PrepareReboot or PreparePowerOff
+// stays valid.
+const RebootTokenTTL = time.Minute
+
+var (
+	// ErrInvalidRebootToken is returned by Reboot and PowerOff for a token
+	// that is unknown, already used, expired or issued for the other
+	// command.
+	ErrInvalidRebootToken = errors.New("invalid or expired reboot token")
+
+	// ErrRebootBlocked is returned by PrepareReboot and PreparePowerOff
+	// when the pre-flight check finds work that a restart would interrupt.
+	ErrRebootBlocked = errors.New("reboot blocked")
+)
+
+// RebootOption configures PrepareReboot and PreparePowerOff.
+type RebootOption func(*rebootOptions)
+
+// rebootOptions holds options for PrepareReboot and PreparePowerOff.
+type rebootOptions struct {
+	preflight bool
+}
+
+// WithPreflight refuses to issue a token while a device in any site is
+// upgrading or a CreateBackup call made through this same SystemService is
+// running. The controller does not report running backups, so backups
+// started from the UI, by the scheduled autobackup, by another process or
+// through a client clone are not detected; callers must rule those out.
+func WithPreflight() RebootOption {
+	return func(opts *rebootOptions) {
+		opts.preflight = true
+	}
+}
+
+// rebootToken is an outstanding confirmation for a system command.
+type rebootToken struct {
+	cmd     string
+	expires time.Time
+}
+
+// PrepareReboot returns a token for Reboot, valid for RebootTokenTTL.
+func (s *systemService) PrepareReboot(ctx context.Context, opts ...RebootOption) (string, error) {
+	return s.prepare(ctx, "reboot", opts)
+}
+
+// PreparePowerOff returns a token for PowerOff, valid for RebootTokenTTL.
+func (s *systemService) PreparePowerOff(ctx context.Context, opts ...RebootOption) (string, error) {
+	return s.prepare(ctx, "poweroff", opts)
+}
+
+// Reboot reboots the controller. token must come from PrepareReboot and
+// is consumed even if the command fails.
+func (s *systemService) Reboot(ctx context.Context, token string) error {
+	return s.systemCommand(ctx, "reboot", token)
+}
+
+// PowerOff shuts the controller down. It must be powered on again by hand.
+// token must come from PreparePowerOff and is consumed even if the
+// command fails.
+func (s *systemService) PowerOff(ctx context.Context, token string) error {
+	return s.systemCommand(ctx, "poweroff", token)
+}
+
+// prepare runs the optional pre-flight check and issues a token for cmd.
+func (s *systemService) prepare(ctx context.Context, cmd string, opts []RebootOption) (string, error) {
+	options := &rebootOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.preflight {
+		if err := s.preflight(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate %s token: %w", cmd, err)
+	}
+	token := hex.EncodeToString(b)
+
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+	now := time.Now()
+	for t, pending := range s.tokens {
+		if now.After(pending.expires) {
+			delete(s.tokens, t)
+		}
+	}
+	s.tokens[token] = rebootToken{cmd: cmd, expires: now.Add(RebootTokenTTL)}
+	return token, nil
+}
+
+// preflight returns an error wrapping ErrRebootBlocked if a device upgrade
+// or one of this service's own CreateBackup calls is in progress.
+func (s *systemService) preflight(ctx context.Context) error {
+	if n := s.backups.Load(); n > 0 {
+		return fmt.Errorf("%w: %d backup(s) started by this client in progress", ErrRebootBlocked, n)
+	}
+
+	sites, err := NewSiteService(s.transport).List(ctx)
+	if err != nil {
+		return err
+	}
+	devices := NewDeviceService(s.transport)
+	var upgrading []string
+	for _, site := range sites {
+		list, err := devices.List(ctx, site.Name)
+		if err != nil {
+			return err
+		}
+		for _, d := range list {
+			if d.State == types.DeviceStateUpgrading {
+				upgrading = append(upgrading, d.MAC)
+			}
+		}
+	}
+	if len(upgrading) > 0 {
+		return fmt.Errorf("%w: devices upgrading: %s", ErrRebootBlocked, strings.Join(upgrading, ", "))
+	}
+	return nil
+}
+
+// systemCommand consumes token and sends cmd to the controller.
+func (s *systemService) systemCommand(ctx context.Context, cmd, token string) error {
+	s.tokenMu.Lock()
+	pending, ok := s.tokens[token]
+	delete(s.tokens, token)
+	s.tokenMu.Unlock()
+	if !ok || pending.cmd != cmd || time.Now().After(pending.expires) {
+		return ErrInvalidRebootToken
+	}
+
+	path := "/proxy/network/api/cmd/system"
+	req := transport.NewRequest("POST", path).WithBody(map[string]string{
+		"cmd": cmd,
+	})
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", cmd, err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError(cmd, resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
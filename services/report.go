@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// ReportOption configures a stat/report query.
+type ReportOption func(*reportOptions)
+
+// reportOptions holds options for a stat/report query.
+type reportOptions struct {
+	metrics []string
+	macs    []string
+}
+
+// WithMetrics selects the metrics the report returns, such as
+// types.MetricWANRxBytes. Without it each report type returns a default
+// set of traffic and client counts.
+func WithMetrics(metrics ...string) ReportOption {
+	return func(opts *reportOptions) {
+		opts.metrics = append(opts.metrics, metrics...)
+	}
+}
+
+// WithReportMACs limits an AP, user or gateway report to the devices or
+// clients with these MAC addresses.
+func WithReportMACs(macs ...string) ReportOption {
+	return func(opts *reportOptions) {
+		for _, mac := range macs {
+			opts.macs = append(opts.macs, internal.FormatMAC(mac))
+		}
+	}
+}
+
+// defaultReportMetrics are the metrics of each report type without
+// WithMetrics.
+var defaultReportMetrics = map[types.ReportType][]string{
+	types.ReportSite: {
+		types.MetricBytes, types.MetricWANRxBytes, types.MetricWANTxBytes, types.MetricWLANBytes,
+		types.MetricNumSta, types.MetricLANNumSta, types.MetricWLANNumSta,
+	},
+	types.ReportAP:      {types.MetricBytes, types.MetricNumSta},
+	types.ReportUser:    {types.MetricRxBytes, types.MetricTxBytes},
+	types.ReportGateway: {types.MetricWANRxBytes, types.MetricWANTxBytes, types.MetricLANRxBytes, types.MetricLANTxBytes, types.MetricCPU, types.MetricMem},
+}
+
+// defaultReportSpans are the periods reported when start is zero.
+var defaultReportSpans = map[types.ReportInterval]time.Duration{
+	types.ReportFiveMinutes: 12 * time.Hour,
+	types.ReportHourly:      7 * 24 * time.Hour,
+	types.ReportDaily:       52 * 7 * 24 * time.Hour,
+	types.ReportMonthly:     52 * 7 * 24 * time.Hour,
+}
+
+// reportQuery is the body of a stat/report request. Times are epoch
+// milliseconds.
+type reportQuery struct {
+	Attrs []string `json:"attrs"`
+	Start int64    `json:"start"`
+	End   int64    `json:"end"`
+	MACs  []string `json:"macs,omitempty"`
+}
+
+// Report returns the historical statistics of kind aggregated by interval
+// between start and end, oldest first. A zero end is now; a zero start
+// reports the last 12 hours of 5-minute data, 7 days of hourly data or 52
+// weeks of daily or monthly data.
+func (s *statsService) Report(ctx context.Context, site string, kind types.ReportType, interval types.ReportInterval, start, end time.Time, opts ...ReportOption) ([]types.ReportRow, error) {
+	span, ok := defaultReportSpans[interval]
+	if !ok {
+		return nil, fmt.Errorf("unknown report interval %q", interval)
+	}
+	defaults, ok := defaultReportMetrics[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown report type %q", kind)
+	}
+
+	var options reportOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-span)
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("report start %s is not before end %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	query := reportQuery{
+		Attrs: append([]string{"time"}, defaults...),
+		Start: start.UnixMilli(),
+		End:   end.UnixMilli(),
+		MACs:  options.macs,
+	}
+	if len(options.metrics) > 0 {
+		query.Attrs = append([]string{"time"}, options.metrics...)
+	}
+
+	path := internal.BuildAPIPath(site, fmt.Sprintf("stat/report/%s.%s", interval, kind))
+	req := transport.NewRequest("POST", path).WithBody(query)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s report: %w", kind, err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError(fmt.Sprintf("get %s report", kind), resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.ReportRow](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
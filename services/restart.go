@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+)
+
+// RestartOption configures RestartMany.
+type RestartOption func(*restartOptions)
+
+// restartOptions holds options for RestartMany.
+type restartOptions struct {
+	batchSize         int
+	parallelism       int
+	wait              bool
+	timeout           time.Duration
+	pollInterval      time.Duration
+	continueOnFailure bool
+}
+
+// WithRolling restarts batchSize devices at a time and waits for each
+// batch to come back before starting the next. A failed batch stops the
+// rollout unless WithContinueOnFailure is given.
+func WithRolling(batchSize int) RestartOption {
+	return func(opts *restartOptions) {
+		opts.batchSize = max(batchSize, 1)
+		opts.wait = true
+	}
+}
+
+// WithParallelism limits how many restart commands are in flight at once
+// when not rolling. The default sends them all at once.
+func WithParallelism(n int) RestartOption {
+	return func(opts *restartOptions) {
+		opts.parallelism = n
+	}
+}
+
+// WithWaitForReturn waits up to timeout for each device to reboot and
+// reconnect to the controller before reporting it as restarted. A timeout
+// that is not positive keeps the default of 10 minutes.
+func WithWaitForReturn(timeout time.Duration) RestartOption {
+	return func(opts *restartOptions) {
+		opts.wait = true
+		if timeout > 0 {
+			opts.timeout = timeout
+		}
+	}
+}
+
+// WithPollInterval sets how often device state is polled while waiting.
+// An interval that is not positive keeps the default of 5 seconds.
+func WithPollInterval(d time.Duration) RestartOption {
+	return func(opts *restartOptions) {
+		if d > 0 {
+			opts.pollInterval = d
+		}
+	}
+}
+
+// WithContinueOnFailure keeps a rolling restart going after a batch fails.
+func WithContinueOnFailure() RestartOption {
+	return func(opts *restartOptions) {
+		opts.continueOnFailure = true
+	}
+}
+
+// RestartResult is the outcome of restarting one device.
+type RestartResult struct {
+	MAC string
+
+	// Err is set if the command failed or the device did not return.
+	Err error
+
+	// Skipped is true if the device was not restarted because an earlier
+	// rolling batch failed.
+	Skipped bool
+
+	// Downtime is the time from the restart command until the device was
+	// connected again. It is only measured when waiting for return.
+	Downtime time.Duration
+}
+
+// RestartReport summarizes RestartMany, with results in the order of the
+// MAC addresses passed in.
+type RestartReport struct {
+	Results   []RestartResult
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Err joins the errors of the failed devices.
+func (r *RestartReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.MAC, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RestartMany restarts several devices, all at once by default or in
+// rolling batches with WithRolling. The report covers every device; the
+// returned error is the report's Err.
+func (s *deviceService) RestartMany(ctx context.Context, site string, macs []string, opts ...RestartOption) (*RestartReport, error) {
+	options := &restartOptions{
+		timeout:      10 * time.Minute,
+		pollInterval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	report := &RestartReport{Results: make([]RestartResult, len(macs))}
+	for i, mac := range macs {
+		report.Results[i].MAC = mac
+	}
+
+	batchSize, limit := len(macs), options.parallelism
+	if options.batchSize > 0 {
+		batchSize, limit = options.batchSize, options.batchSize
+	}
+	if limit <= 0 {
+		limit = len(macs)
+	}
+
+	failed := false
+	for start := 0; start < len(macs); start += batchSize {
+		batch := report.Results[start:min(start+batchSize, len(macs))]
+		if (failed && !options.continueOnFailure) || ctx.Err() != nil {
+			for i := range batch {
+				batch[i].Skipped = true
+			}
+			continue
+		}
+
+		s.restartBatch(ctx, site, batch, limit, options)
+		for _, res := range batch {
+			if res.Err != nil {
+				failed = true
+			}
+		}
+	}
+
+	for _, res := range report.Results {
+		switch {
+		case res.Skipped:
+			report.Skipped++
+		case res.Err != nil:
+			report.Failed++
+		default:
+			report.Succeeded++
+		}
+	}
+	return report, report.Err()
+}
+
+// restartBatch restarts the devices of one batch, at most limit at a time,
+// and fills in their results.
+func (s *deviceService) restartBatch(ctx context.Context, site string, batch []RestartResult, limit int, options *restartOptions) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for i := range batch {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res *RestartResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res.Downtime, res.Err = s.restartAndWait(ctx, site, res.MAC, options)
+		}(&batch[i])
+	}
+	wg.Wait()
+}
+
+// restartAndWait restarts a device and, if requested, waits for it to
+// come back. mac may be in any notation.
+func (s *deviceService) restartAndWait(ctx context.Context, site, mac string, options *restartOptions) (time.Duration, error) {
+	mac = internal.FormatMAC(mac)
+	var before *types.Device
+	if options.wait {
+		var err error
+		if before, err = s.GetByMAC(ctx, site, mac); err != nil {
+			return 0, err
+		}
+	}
+
+	started := time.Now()
+	if err := s.Restart(ctx, site, mac); err != nil {
+		return 0, err
+	}
+	if !options.wait {
+		return 0, nil
+	}
+
+	if err := s.waitForReboot(ctx, site, mac, before.Uptime.Int64(), options); err != nil {
+		return 0, err
+	}
+	return time.Since(started), nil
+}
+
+// waitForReboot polls until the device is connected again after going
+// offline or resetting its uptime.
+func (s *deviceService) waitForReboot(ctx context.Context, site, mac string, uptime int64, options *restartOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(options.pollInterval)
+	defer ticker.Stop()
+
+	wentDown := false
+	for {
+		devices, err := s.List(ctx, site)
+		if err == nil {
+			d := findDeviceByMAC(devices, mac)
+			switch {
+			case d == nil || !d.State.IsOnline():
+				wentDown = true
+			case d.State == types.DeviceStateConnected && (wentDown || d.Uptime.Int64() < uptime):
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("device did not return within %s", options.timeout)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// findDeviceByMAC returns the device with the given MAC address, in any
+// notation, or nil.
+func findDeviceByMAC(devices []types.Device, mac string) *types.Device {
+	normalized := internal.NormalizeMAC(mac)
+	for i := range devices {
+		if internal.NormalizeMAC(devices[i].MAC) == normalized {
+			return &devices[i]
+		}
+	}
+	return nil
+}
@@ -32,7 +32,7 @@ func (s *routingService) List(ctx context.Context, site string) ([]types.Route,
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list routes failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list routes", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Route](resp.Body)
@@ -55,9 +55,9 @@ func (s *routingService) Get(ctx context.Context, site, id string) (*types.Route
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("route not found: %s", id)
+			return nil, notFoundf("route not found: %s", id)
 		}
-		return nil, fmt.Errorf("get route failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get route", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Route](resp.Body)
@@ -66,7 +66,7 @@ func (s *routingService) Get(ctx context.Context, site, id string) (*types.Route
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("route not found: %s", id)
+		return nil, notFoundf("route not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -74,6 +74,10 @@ func (s *routingService) Get(ctx context.Context, site, id string) (*types.Route
 
 // Create creates a new route.
 func (s *routingService) Create(ctx context.Context, site string, route *types.Route) (*types.Route, error) {
+	if err := validate(ctx, "route", route); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "routing", "")
 	req := transport.NewRequest("POST", path).WithBody(route)
 
@@ -83,7 +87,7 @@ func (s *routingService) Create(ctx context.Context, site string, route *types.R
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create route failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create route", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Route](resp.Body)
@@ -104,6 +108,10 @@ func (s *routingService) Update(ctx context.Context, site string, route *types.R
 		return nil, fmt.Errorf("route ID is required for update")
 	}
 
+	if err := validate(ctx, "route", route); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "routing", route.ID)
 	req := transport.NewRequest("PUT", path).WithBody(route)
 
@@ -114,9 +122,9 @@ func (s *routingService) Update(ctx context.Context, site string, route *types.R
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("route not found: %s", route.ID)
+			return nil, notFoundf("route not found: %s", route.ID)
 		}
-		return nil, fmt.Errorf("update route failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update route", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Route](resp.Body)
@@ -141,11 +149,8 @@ func (s *routingService) Delete(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete route: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		if resp.StatusCode == 404 {
-			return fmt.Errorf("route not found: %s", id)
-		}
-		return fmt.Errorf("delete route failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("route", resp)
 	}
 
 	return nil
@@ -159,7 +164,7 @@ func (s *routingService) Enable(ctx context.Context, site, id string) error {
 	}
 
 	route.Enabled = true
-	_, err = s.Update(ctx, site, route)
+	_, err = s.Update(WithoutValidation(ctx), site, route)
 	return err
 }
 
@@ -171,6 +176,6 @@ func (s *routingService) Disable(ctx context.Context, site, id string) error {
 	}
 
 	route.Enabled = false
-	_, err = s.Update(ctx, site, route)
+	_, err = s.Update(WithoutValidation(ctx), site, route)
 	return err
 }
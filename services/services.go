@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/unifi-go/gofi/types"
 )
@@ -17,26 +19,70 @@ type SiteService interface {
 	Delete(ctx context.Context, id string) error
 	Health(ctx context.Context, site string) ([]types.HealthData, error)
 	SysInfo(ctx context.Context, site string) (*types.SysInfo, error)
+
+	// ForEach calls fn for every site, running at most
+	// MaxSiteConcurrency calls at a time.
+	ForEach(ctx context.Context, fn func(site types.Site) error) error
+
+	// Site maps (floor plans)
+	ListMaps(ctx context.Context, site string) ([]types.SiteMap, error)
+	CreateMap(ctx context.Context, site string, m *types.SiteMap) (*types.SiteMap, error)
+	UpdateMap(ctx context.Context, site string, m *types.SiteMap) (*types.SiteMap, error)
+	DeleteMap(ctx context.Context, site, id string) error
+	UploadMapImage(ctx context.Context, site, filename string, image io.Reader) (string, error)
 }
 
 // DeviceService provides device control and configuration.
 type DeviceService interface {
-	List(ctx context.Context, site string) ([]types.Device, error)
-	ListBasic(ctx context.Context, site string) ([]types.DeviceBasic, error)
+	List(ctx context.Context, site string, opts ...DeviceListOption) ([]types.Device, error)
+	ListBasic(ctx context.Context, site string, opts ...DeviceListOption) ([]types.DeviceBasic, error)
 	Get(ctx context.Context, site, id string) (*types.Device, error)
 	GetByMAC(ctx context.Context, site, mac string) (*types.Device, error)
 	Update(ctx context.Context, site string, device *types.Device) (*types.Device, error)
 	Adopt(ctx context.Context, site, mac string) error
+	AdoptAdvanced(ctx context.Context, site, mac, url, username, password string) error
 	Forget(ctx context.Context, site, mac string) error
 	Restart(ctx context.Context, site, mac string) error
+	RestartMany(ctx context.Context, site string, macs []string, opts ...RestartOption) (*RestartReport, error)
 	ForceProvision(ctx context.Context, site, mac string) error
 	Upgrade(ctx context.Context, site, mac string) error
 	UpgradeExternal(ctx context.Context, site, mac, url string) error
 	Locate(ctx context.Context, site, mac string) error
 	Unlocate(ctx context.Context, site, mac string) error
+	LocateFor(ctx context.Context, site, mac string, d time.Duration) error
+
+	// UnlocatePending turns off the LEDs that pending LocateFor calls
+	// would turn off later, canceling their timers.
+	UnlocatePending(ctx context.Context) error
 	PowerCyclePort(ctx context.Context, site, switchMAC string, portIdx int) error
 	SetLEDOverride(ctx context.Context, site, mac, mode string) error
 	SpectrumScan(ctx context.Context, site, mac string) error
+
+	// WaitForProvision waits until a device has applied a configuration
+	// newer than sinceCfgVersion.
+	WaitForProvision(ctx context.Context, site, mac, sinceCfgVersion string, opts ...ProvisionOption) (*types.Device, error)
+
+	// Command sends an allowlisted devmgr command that has no dedicated
+	// method.
+	Command(ctx context.Context, site string, cmd DeviceCommand, params map[string]interface{}) error
+
+	// Topology returns the site's uplink graph of devices and connected
+	// clients.
+	Topology(ctx context.Context, site string) (*types.TopologyGraph, error)
+
+	// PoEBudget returns a switch's PoE budget with allocated and drawn
+	// power, overall and per port.
+	PoEBudget(ctx context.Context, site, mac string) (*types.PoEBudget, error)
+
+	// CountByState and CountByType count devices by state and by type
+	// ("uap", "usw", ...), fetching only the attributes they need.
+	CountByState(ctx context.Context, site string) (map[types.DeviceState]int, error)
+	CountByType(ctx context.Context, site string) (map[string]int, error)
+
+	// Placements returns the positions of devices on a site map, or on
+	// every map if mapID is empty.
+	Placements(ctx context.Context, site, mapID string) ([]types.APPlacement, error)
+	SetPlacement(ctx context.Context, site, mac string, placement types.APPlacement) error
 }
 
 // NetworkService provides network and VLAN management.
@@ -46,6 +92,10 @@ type NetworkService interface {
 	Create(ctx context.Context, site string, network *types.Network) (*types.Network, error)
 	Update(ctx context.Context, site string, network *types.Network) (*types.Network, error)
 	Delete(ctx context.Context, site, id string) error
+
+	// ListWithUsage returns every network with its active client count and
+	// DHCP pool utilization.
+	ListWithUsage(ctx context.Context, site string) ([]types.NetworkUsage, error)
 }
 
 // WLANService provides wireless network configuration.
@@ -60,12 +110,25 @@ type WLANService interface {
 	Disable(ctx context.Context, site, id string) error
 	SetMACFilter(ctx context.Context, site, id, policy string, macs []string) error
 
+	// ConnectedClients returns the active clients associated with a WLAN.
+	ConnectedClients(ctx context.Context, site, id string) ([]types.Client, error)
+
 	// WLAN Group methods
 	ListGroups(ctx context.Context, site string) ([]types.WLANGroup, error)
 	GetGroup(ctx context.Context, site, id string) (*types.WLANGroup, error)
 	CreateGroup(ctx context.Context, site string, group *types.WLANGroup) (*types.WLANGroup, error)
 	UpdateGroup(ctx context.Context, site string, group *types.WLANGroup) (*types.WLANGroup, error)
 	DeleteGroup(ctx context.Context, site, id string) error
+
+	// AP Group methods (v2 API)
+	ListAPGroups(ctx context.Context, site string) ([]types.APGroup, error)
+	CreateAPGroup(ctx context.Context, site string, group *types.APGroup) (*types.APGroup, error)
+	UpdateAPGroup(ctx context.Context, site string, group *types.APGroup) (*types.APGroup, error)
+	DeleteAPGroup(ctx context.Context, site, id string) error
+
+	// EnableOnAPs limits a WLAN to the given access points, creating or
+	// updating an AP group for them as needed, and returns the updated WLAN.
+	EnableOnAPs(ctx context.Context, site, wlanID string, apMACs []string) (*types.WLAN, error)
 }
 
 // FirewallService provides firewall rules and groups management.
@@ -80,6 +143,10 @@ type FirewallService interface {
 	DisableRule(ctx context.Context, site, id string) error
 	ReorderRules(ctx context.Context, site, ruleset string, updates []types.FirewallRuleIndexUpdate) error
 
+	// ReorderRulesVerified reorders firewall rules, reads the indexes back
+	// and resends the order until it is applied or the attempts run out.
+	ReorderRulesVerified(ctx context.Context, site, ruleset string, updates []types.FirewallRuleIndexUpdate, opts ...ReorderOption) (*ReorderReport, error)
+
 	// Firewall Group methods
 	ListGroups(ctx context.Context, site string) ([]types.FirewallGroup, error)
 	GetGroup(ctx context.Context, site, id string) (*types.FirewallGroup, error)
@@ -93,6 +160,22 @@ type FirewallService interface {
 	CreateTrafficRule(ctx context.Context, site string, rule *types.TrafficRule) (*types.TrafficRule, error)
 	UpdateTrafficRule(ctx context.Context, site string, rule *types.TrafficRule) (*types.TrafficRule, error)
 	DeleteTrafficRule(ctx context.Context, site, id string) error
+
+	// Zone-based firewall methods (v2 API, Network 9.0 and later)
+	ListZones(ctx context.Context, site string) ([]types.FirewallZone, error)
+	CreateZone(ctx context.Context, site string, zone *types.FirewallZone) (*types.FirewallZone, error)
+	UpdateZone(ctx context.Context, site string, zone *types.FirewallZone) (*types.FirewallZone, error)
+	DeleteZone(ctx context.Context, site, id string) error
+	GetZoneMatrix(ctx context.Context, site string) (types.FirewallZoneMatrix, error)
+	ListPolicies(ctx context.Context, site string) ([]types.FirewallPolicy, error)
+	GetPolicy(ctx context.Context, site, id string) (*types.FirewallPolicy, error)
+	CreatePolicy(ctx context.Context, site string, policy *types.FirewallPolicy) (*types.FirewallPolicy, error)
+	UpdatePolicy(ctx context.Context, site string, policy *types.FirewallPolicy) (*types.FirewallPolicy, error)
+	DeletePolicy(ctx context.Context, site, id string) error
+
+	// ReorderPolicies sets the order of the user-defined policies between
+	// two zones.
+	ReorderPolicies(ctx context.Context, site string, order *types.FirewallPolicyOrder) error
 }
 
 // ClientService provides connected client/station operations.
@@ -103,6 +186,10 @@ type ClientService interface {
 	// ListAll returns all known clients (including historical).
 	ListAll(ctx context.Context, site string, opts ...ClientListOption) ([]types.Client, error)
 
+	// CountActive returns the number of connected clients from the site's
+	// health summary, without listing them.
+	CountActive(ctx context.Context, site string) (int, error)
+
 	// Get returns a client by MAC address.
 	Get(ctx context.Context, site, mac string) (*types.Client, error)
 
@@ -115,12 +202,20 @@ type ClientService interface {
 	// Kick disconnects a client from the network.
 	Kick(ctx context.Context, site, mac string) error
 
+	// WakeOnLAN asks the controller to send a Wake-on-LAN magic packet to
+	// a client.
+	WakeOnLAN(ctx context.Context, site, mac string) error
+
 	// AuthorizeGuest authorizes a guest client.
 	AuthorizeGuest(ctx context.Context, site, mac string, opts ...GuestAuthOption) error
 
 	// UnauthorizeGuest revokes guest authorization.
 	UnauthorizeGuest(ctx context.Context, site, mac string) error
 
+	// ListGuests returns guest authorizations, including expired ones
+	// within the WithinHours window (default 24 hours).
+	ListGuests(ctx context.Context, site string, opts ...ClientListOption) ([]types.Guest, error)
+
 	// Forget removes a client from the known clients list.
 	Forget(ctx context.Context, site, mac string) error
 
@@ -128,6 +223,25 @@ type ClientService interface {
 	SetFingerprint(ctx context.Context, site, mac string, devID int) error
 }
 
+// DeviceListOption configures device list queries.
+type DeviceListOption func(*deviceListOptions)
+
+// deviceListOptions holds options for listing devices.
+type deviceListOptions struct {
+	fields []string
+}
+
+// WithFields asks the controller for only the given device attributes,
+// such as "mac", "state" and "name", to keep responses small on sites
+// with hundreds of devices. "mac" is always included. Other fields of the
+// returned devices are left at their zero values; a controller that does
+// not filter attributes returns them all.
+func WithFields(fields ...string) DeviceListOption {
+	return func(opts *deviceListOptions) {
+		opts.fields = append(opts.fields, fields...)
+	}
+}
+
 // ClientListOption configures client list queries.
 type ClientListOption func(*clientListOptions)
 
@@ -143,6 +257,49 @@ func WithinHours(hours int) ClientListOption {
 	}
 }
 
+// UserListOption configures user list queries. Multiple options must all
+// match.
+type UserListOption func(*userListOptions)
+
+// userListOptions holds options for listing users.
+type userListOptions struct {
+	name      string
+	hostname  string
+	note      string
+	macPrefix string
+}
+
+// WithUserName matches users whose name contains name, ignoring case.
+func WithUserName(name string) UserListOption {
+	return func(opts *userListOptions) {
+		opts.name = name
+	}
+}
+
+// WithHostname matches users whose hostname contains hostname, ignoring
+// case.
+func WithHostname(hostname string) UserListOption {
+	return func(opts *userListOptions) {
+		opts.hostname = hostname
+	}
+}
+
+// WithNote matches users whose note contains note, ignoring case.
+func WithNote(note string) UserListOption {
+	return func(opts *userListOptions) {
+		opts.note = note
+	}
+}
+
+// WithMACPrefix matches users whose MAC address starts with prefix, such
+// as an OUI, in any notation. A complete MAC address is looked up by the
+// controller.
+func WithMACPrefix(prefix string) UserListOption {
+	return func(opts *userListOptions) {
+		opts.macPrefix = prefix
+	}
+}
+
 // GuestAuthOption configures guest authorization.
 type GuestAuthOption func(*guestAuthOptions)
 
@@ -193,8 +350,11 @@ func WithAPMAC(mac string) GuestAuthOption {
 // UserService provides known client/user management.
 type UserService interface {
 	// User operations
-	List(ctx context.Context, site string) ([]types.User, error)
+	// List returns the site's users, or with options only those matching.
+	List(ctx context.Context, site string, opts ...UserListOption) ([]types.User, error)
 	Get(ctx context.Context, site, id string) (*types.User, error)
+	// GetByMAC looks up a user by MAC address, in any case and with or
+	// without separators.
 	GetByMAC(ctx context.Context, site, mac string) (*types.User, error)
 	Create(ctx context.Context, site string, user *types.User) (*types.User, error)
 	Update(ctx context.Context, site string, user *types.User) (*types.User, error)
@@ -203,12 +363,28 @@ type UserService interface {
 	SetFixedIP(ctx context.Context, site, mac, ip, networkID string) error
 	ClearFixedIP(ctx context.Context, site, mac string) error
 
+	// FindDuplicates returns MAC addresses with more than one user entry.
+	FindDuplicates(ctx context.Context, site string) ([]DuplicateUsers, error)
+
+	// Merge folds the entries dropIDs into keepID and deletes them.
+	Merge(ctx context.Context, site, keepID string, dropIDs []string) (*types.User, error)
+
+	// BulkUpsert creates or updates users matched by MAC address, several
+	// at a time, and reports the outcome of each.
+	BulkUpsert(ctx context.Context, site string, users []types.User, opts ...UpsertOption) (*UpsertReport, error)
+
 	// User group operations
 	ListGroups(ctx context.Context, site string) ([]types.UserGroup, error)
 	GetGroup(ctx context.Context, site, id string) (*types.UserGroup, error)
 	CreateGroup(ctx context.Context, site string, group *types.UserGroup) (*types.UserGroup, error)
 	UpdateGroup(ctx context.Context, site string, group *types.UserGroup) (*types.UserGroup, error)
 	DeleteGroup(ctx context.Context, site, id string) error
+
+	// SetGroupLimits sets a user group's download and upload limits.
+	SetGroupLimits(ctx context.Context, site, groupID string, down, up types.RateLimit) (*types.UserGroup, error)
+
+	// AssignGroup moves clients into a user group by MAC.
+	AssignGroup(ctx context.Context, site, groupID string, macs []string) (*AssignGroupReport, error)
 }
 
 // RoutingService provides static route management.
@@ -231,6 +407,11 @@ type PortForwardService interface {
 	Delete(ctx context.Context, site, id string) error
 	Enable(ctx context.Context, site, id string) error
 	Disable(ctx context.Context, site, id string) error
+
+	// ValidateNoConflict returns a *PortForwardConflictError if forward
+	// overlaps an enabled forward of the site on protocol, WAN and
+	// external ports.
+	ValidateNoConflict(ctx context.Context, site string, forward *types.PortForward) error
 }
 
 // PortProfileService provides port profile management.
@@ -257,28 +438,128 @@ type SettingService interface {
 	// Dynamic DNS
 	GetDynamicDNS(ctx context.Context, site string) (*types.DynamicDNS, error)
 	UpdateDynamicDNS(ctx context.Context, site string, ddns *types.DynamicDNS) error
+
+	// Touchscreen (LCM) settings of consoles such as the UDM Pro
+	GetLCM(ctx context.Context, site string) (*types.SettingLCM, error)
+	UpdateLCM(ctx context.Context, site string, setting *types.SettingLCM) error
+
+	// Intrusion prevention, including the internal honeypots
+	GetIPS(ctx context.Context, site string) (*types.SettingIPS, error)
+	UpdateIPS(ctx context.Context, site string, setting *types.SettingIPS) error
+	SetHoneypots(ctx context.Context, site string, honeypots []types.Honeypot) error
+
+	// Controller-wide settings. They apply to every site, so they are not
+	// reachable through Get and Update and have no site argument.
+	GetSuperMgmt(ctx context.Context) (*types.SettingSuperMgmt, error)
+	UpdateSuperMgmt(ctx context.Context, setting *types.SettingSuperMgmt) error
+	GetSuperSMTP(ctx context.Context) (*types.SettingSuperSMTP, error)
+	UpdateSuperSMTP(ctx context.Context, setting *types.SettingSuperSMTP) error
+	GetSuperIdentity(ctx context.Context) (*types.SettingSuperIdentity, error)
+	UpdateSuperIdentity(ctx context.Context, setting *types.SettingSuperIdentity) error
 }
 
 // SystemService provides system-level operations.
 type SystemService interface {
 	Status(ctx context.Context) (*types.Status, error)
 	Self(ctx context.Context) (*types.AdminUser, error)
-	Reboot(ctx context.Context) error
+
+	// ChangePassword changes the logged-in admin's password, ending its
+	// sessions. See Client.ChangePassword to log in again automatically.
+	ChangePassword(ctx context.Context, currentPassword, newPassword string) error
+
+	// PrepareReboot and PreparePowerOff return a single-use token that
+	// Reboot or PowerOff, respectively, require. WithPreflight only sees
+	// backups started through this service's CreateBackup.
+	PrepareReboot(ctx context.Context, opts ...RebootOption) (string, error)
+	PreparePowerOff(ctx context.Context, opts ...RebootOption) (string, error)
+	Reboot(ctx context.Context, token string) error
+	PowerOff(ctx context.Context, token string) error
+
 	SpeedTest(ctx context.Context, site string) error
 	SpeedTestStatus(ctx context.Context, site string) (*types.SpeedTestStatus, error)
+
+	// RunSpeedTest starts a speed test and waits for its result.
+	RunSpeedTest(ctx context.Context, site string, opts ...SpeedTestOption) (*types.SpeedTestResult, error)
+
 	ListBackups(ctx context.Context) ([]types.Backup, error)
 	CreateBackup(ctx context.Context) error
 	DeleteBackup(ctx context.Context, filename string) error
+	DownloadBackup(ctx context.Context, filename string) (io.ReadCloser, error)
 	ListAdmins(ctx context.Context) ([]types.AdminUser, error)
 }
 
 // EventService provides real-time event streaming.
 type EventService interface {
+	// Subscribe streams a site's events. Each subscription has a
+	// connection and channels of its own, closed when ctx is done, the
+	// connection ends or the service is closed.
 	Subscribe(ctx context.Context, site string) (<-chan types.Event, <-chan error, error)
+
+	// SubscribeSystem subscribes to the UniFi OS event bus (console
+	// updates, application state changes) like Subscribe. Event.Source
+	// tells its events from a site's.
+	SubscribeSystem(ctx context.Context) (<-chan types.Event, <-chan error, error)
+
+	// History returns past events of a site from stat/event, newest
+	// first. It does not need a subscription.
+	History(ctx context.Context, site string, opts ...EventHistoryOption) ([]types.Event, error)
+
+	// SubscribeDeviceUpdates delivers the device state a site pushes on
+	// its WebSocket: full devices from device:sync messages and, from
+	// device:update messages, devices with only MAC and the fields that
+	// changed set. It uses a connection of its own, closed with ctx.
+	SubscribeDeviceUpdates(ctx context.Context, site string) (<-chan types.Device, <-chan error, error)
+
 	Close() error
+
+	// Shutdown closes the service and waits, until ctx is done, for its
+	// streams to stop and close their channels.
+	Shutdown(ctx context.Context) error
+}
+
+// AlarmService provides alarm listing and acknowledgement. Archiving an
+// alarm is how it is acknowledged in the UI.
+type AlarmService interface {
+	// List returns the alarms that have not been archived.
+	List(ctx context.Context, site string) ([]types.Alarm, error)
+
+	// ListAll returns every alarm, archived or not.
+	ListAll(ctx context.Context, site string) ([]types.Alarm, error)
+
+	// Archive archives an alarm.
+	Archive(ctx context.Context, site, id string) error
+
+	// ArchiveAll archives every alarm of the site.
+	ArchiveAll(ctx context.Context, site string) error
+
+	// ArchiveMatching archives the active alarms for which match returns
+	// true and returns how many it archived.
+	ArchiveMatching(ctx context.Context, site string, match func(*types.Alarm) bool) (int, error)
 }
 
-// DNSService provides local DNS record management.
+// HotspotService provides guest hotspot vouchers and operator accounts
+// through the legacy API.
+type HotspotService interface {
+	// ListVouchers returns the vouchers of a site.
+	ListVouchers(ctx context.Context, site string) ([]types.Voucher, error)
+
+	// CreateVouchers creates count vouchers that each authorize a guest
+	// for minutes and returns them. Vouchers are single-use unless
+	// WithVoucherQuota says otherwise.
+	CreateVouchers(ctx context.Context, site string, count, minutes int, opts ...VoucherOption) ([]types.Voucher, error)
+
+	// RevokeVoucher deletes a voucher so it can no longer be used.
+	RevokeVoucher(ctx context.Context, site, id string) error
+
+	// Operator accounts, which can only create and print vouchers.
+	ListOperators(ctx context.Context, site string) ([]types.HotspotOperator, error)
+	CreateOperator(ctx context.Context, site string, operator *types.HotspotOperator) (*types.HotspotOperator, error)
+	UpdateOperator(ctx context.Context, site string, operator *types.HotspotOperator) (*types.HotspotOperator, error)
+	DeleteOperator(ctx context.Context, site, id string) error
+}
+
+// DNSService provides local DNS record management through the static-dns
+// v2 API. Create and Update validate records before sending them.
 type DNSService interface {
 	// List returns all local DNS records.
 	List(ctx context.Context, site string) ([]types.DNSRecord, error)
@@ -286,10 +567,12 @@ type DNSService interface {
 	// Get returns a DNS record by ID.
 	Get(ctx context.Context, site, id string) (*types.DNSRecord, error)
 
-	// GetByName returns a DNS record by hostname/key.
+	// GetByName returns a DNS record by hostname/key, ignoring case and a
+	// trailing dot.
 	GetByName(ctx context.Context, site, name string) (*types.DNSRecord, error)
 
-	// GetByIP returns DNS records pointing to a specific IP.
+	// GetByIP returns DNS records pointing to a specific IP, comparing
+	// addresses by value.
 	GetByIP(ctx context.Context, site, ip string) ([]types.DNSRecord, error)
 
 	// Create creates a new DNS record.
@@ -304,3 +587,66 @@ type DNSService interface {
 	// DeleteByName deletes a DNS record by hostname/key.
 	DeleteByName(ctx context.Context, site, name string) error
 }
+
+// StatsService derives statistics from periodic controller polls.
+type StatsService interface {
+	// WANThroughput polls the site's gateway every interval and sends one
+	// sample per WAN interface. The channel is closed when ctx is done.
+	WANThroughput(ctx context.Context, site string, interval time.Duration) (<-chan types.WANSample, error)
+
+	// IPSEvents returns the threats detected between start and end.
+	IPSEvents(ctx context.Context, site string, start, end time.Time) ([]types.IPSEvent, error)
+
+	// HoneypotEvents returns the IPS events triggered by the site's
+	// internal honeypots between start and end.
+	HoneypotEvents(ctx context.Context, site string, start, end time.Time) ([]types.IPSEvent, error)
+
+	// Anomalies returns the client anomalies, such as roaming issues,
+	// DHCP failures and high retries, between start and end.
+	Anomalies(ctx context.Context, site string, start, end time.Time) ([]types.Anomaly, error)
+
+	// AnomalySummary counts the anomalies between start and end by kind
+	// and category, like the Insights view.
+	AnomalySummary(ctx context.Context, site string, start, end time.Time) (*types.AnomalySummary, error)
+
+	// Report returns historical statistics from stat/report, such as
+	// hourly site bandwidth or daily traffic per access point or client.
+	Report(ctx context.Context, site string, kind types.ReportType, interval types.ReportInterval, start, end time.Time, opts ...ReportOption) ([]types.ReportRow, error)
+}
+
+// IntegrationService uses the official Integrations API
+// (/proxy/network/integration/v1). Sites may be given by legacy name, such
+// as "default", or by Integrations API ID; device, client and voucher IDs
+// are Integrations API IDs.
+type IntegrationService interface {
+	// Info returns the application version. It fails on controllers
+	// without the Integrations API.
+	Info(ctx context.Context) (*types.IntegrationInfo, error)
+
+	// ListSites returns all sites.
+	ListSites(ctx context.Context) ([]types.IntegrationSite, error)
+
+	// SiteID returns the Integrations API ID of a site.
+	SiteID(ctx context.Context, site string) (string, error)
+
+	// ListDevices returns the adopted devices of a site.
+	ListDevices(ctx context.Context, site string) ([]types.IntegrationDevice, error)
+
+	// GetDevice returns a device by ID.
+	GetDevice(ctx context.Context, site, id string) (*types.IntegrationDevice, error)
+
+	// RestartDevice restarts a device by ID.
+	RestartDevice(ctx context.Context, site, id string) error
+
+	// ListClients returns the connected clients of a site.
+	ListClients(ctx context.Context, site string) ([]types.IntegrationClient, error)
+
+	// ListVouchers returns the hotspot vouchers of a site.
+	ListVouchers(ctx context.Context, site string) ([]types.IntegrationVoucher, error)
+
+	// CreateVouchers creates req.Count vouchers and returns them.
+	CreateVouchers(ctx context.Context, site string, req *types.IntegrationVoucherRequest) ([]types.IntegrationVoucher, error)
+
+	// DeleteVoucher deletes a voucher by ID.
+	DeleteVoucher(ctx context.Context, site, id string) error
+}
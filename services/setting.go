@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
@@ -23,6 +24,10 @@ func NewSettingService(transport transport.Transport) SettingService {
 
 // Get returns a setting by key.
 func (s *settingService) Get(ctx context.Context, site, key string) (interface{}, error) {
+	if isSuperSettingKey(key) {
+		return nil, fmt.Errorf("setting %s is controller-wide; use the GetSuper methods", key)
+	}
+
 	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/setting/%s", site, key)
 	req := transport.NewRequest("GET", path)
 
@@ -33,9 +38,9 @@ func (s *settingService) Get(ctx context.Context, site, key string) (interface{}
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("setting not found: %s", key)
+			return nil, notFoundf("setting not found: %s", key)
 		}
-		return nil, fmt.Errorf("get setting failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get setting", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Setting](resp.Body)
@@ -44,7 +49,7 @@ func (s *settingService) Get(ctx context.Context, site, key string) (interface{}
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("setting not found: %s", key)
+		return nil, notFoundf("setting not found: %s", key)
 	}
 
 	return &apiResp.Data[0], nil
@@ -60,6 +65,9 @@ func (s *settingService) Update(ctx context.Context, site string, setting interf
 		// Try to get key via reflection or type assertion for typed settings
 		return fmt.Errorf("invalid setting type")
 	}
+	if isSuperSettingKey(key) {
+		return fmt.Errorf("setting %s is controller-wide; use the UpdateSuper methods", key)
+	}
 
 	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/setting/%s", site, key)
 	req := transport.NewRequest("PUT", path).WithBody(setting)
@@ -70,7 +78,7 @@ func (s *settingService) Update(ctx context.Context, site string, setting interf
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("update setting failed with status %d", resp.StatusCode)
+		return types.NewResponseError("update setting", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -87,7 +95,7 @@ func (s *settingService) ListRadiusProfiles(ctx context.Context, site string) ([
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list RADIUS profiles failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list RADIUS profiles", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.RADIUSProfile](resp.Body)
@@ -110,9 +118,9 @@ func (s *settingService) GetRadiusProfile(ctx context.Context, site, id string)
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("RADIUS profile not found: %s", id)
+			return nil, notFoundf("RADIUS profile not found: %s", id)
 		}
-		return nil, fmt.Errorf("get RADIUS profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get RADIUS profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.RADIUSProfile](resp.Body)
@@ -121,7 +129,7 @@ func (s *settingService) GetRadiusProfile(ctx context.Context, site, id string)
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("RADIUS profile not found: %s", id)
+		return nil, notFoundf("RADIUS profile not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -129,6 +137,10 @@ func (s *settingService) GetRadiusProfile(ctx context.Context, site, id string)
 
 // CreateRadiusProfile creates a new RADIUS profile.
 func (s *settingService) CreateRadiusProfile(ctx context.Context, site string, profile *types.RADIUSProfile) (*types.RADIUSProfile, error) {
+	if err := validate(ctx, "RADIUS profile", profile); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "radiusprofile", "")
 	req := transport.NewRequest("POST", path).WithBody(profile)
 
@@ -138,7 +150,7 @@ func (s *settingService) CreateRadiusProfile(ctx context.Context, site string, p
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create RADIUS profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create RADIUS profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.RADIUSProfile](resp.Body)
@@ -159,6 +171,10 @@ func (s *settingService) UpdateRadiusProfile(ctx context.Context, site string, p
 		return nil, fmt.Errorf("RADIUS profile ID is required for update")
 	}
 
+	if err := validate(ctx, "RADIUS profile", profile); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "radiusprofile", profile.ID)
 	req := transport.NewRequest("PUT", path).WithBody(profile)
 
@@ -169,9 +185,9 @@ func (s *settingService) UpdateRadiusProfile(ctx context.Context, site string, p
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("RADIUS profile not found: %s", profile.ID)
+			return nil, notFoundf("RADIUS profile not found: %s", profile.ID)
 		}
-		return nil, fmt.Errorf("update RADIUS profile failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update RADIUS profile", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.RADIUSProfile](resp.Body)
@@ -196,11 +212,8 @@ func (s *settingService) DeleteRadiusProfile(ctx context.Context, site, id strin
 		return fmt.Errorf("failed to delete RADIUS profile: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		if resp.StatusCode == 404 {
-			return fmt.Errorf("RADIUS profile not found: %s", id)
-		}
-		return fmt.Errorf("delete RADIUS profile failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("RADIUS profile", resp)
 	}
 
 	return nil
@@ -217,7 +230,7 @@ func (s *settingService) GetDynamicDNS(ctx context.Context, site string) (*types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get Dynamic DNS failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get Dynamic DNS", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.DynamicDNS](resp.Body)
@@ -244,7 +257,190 @@ func (s *settingService) UpdateDynamicDNS(ctx context.Context, site string, ddns
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("update Dynamic DNS failed with status %d", resp.StatusCode)
+		return types.NewResponseError("update Dynamic DNS", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+// GetLCM returns the site's touchscreen settings.
+func (s *settingService) GetLCM(ctx context.Context, site string) (*types.SettingLCM, error) {
+	return getSiteSetting[types.SettingLCM](ctx, s, site, types.SettingKeyLCM)
+}
+
+// UpdateLCM validates and updates the site's touchscreen settings.
+func (s *settingService) UpdateLCM(ctx context.Context, site string, setting *types.SettingLCM) error {
+	if err := validate(ctx, "LCM setting", setting); err != nil {
+		return err
+	}
+	setting.Key = types.SettingKeyLCM
+	return s.updateSiteSetting(ctx, site, setting.Key, setting)
+}
+
+// GetIPS returns the site's intrusion prevention settings, including its
+// internal honeypots.
+func (s *settingService) GetIPS(ctx context.Context, site string) (*types.SettingIPS, error) {
+	return getSiteSetting[types.SettingIPS](ctx, s, site, types.SettingKeyIPS)
+}
+
+// UpdateIPS validates and updates the site's intrusion prevention settings.
+func (s *settingService) UpdateIPS(ctx context.Context, site string, setting *types.SettingIPS) error {
+	if err := validate(ctx, "IPS setting", setting); err != nil {
+		return err
+	}
+	setting.Key = types.SettingKeyIPS
+	return s.updateSiteSetting(ctx, site, setting.Key, setting)
+}
+
+// SetHoneypots replaces the site's internal honeypots, enabling the
+// feature if any are given and disabling it otherwise. Other IPS settings
+// are kept.
+func (s *settingService) SetHoneypots(ctx context.Context, site string, honeypots []types.Honeypot) error {
+	setting, err := s.GetIPS(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	setting.Honeypots = make([]types.Honeypot, len(honeypots))
+	for i, h := range honeypots {
+		if h.Version == "" {
+			h.Version = "v4"
+		}
+		setting.Honeypots[i] = h
+	}
+	setting.HoneypotEnabled = len(honeypots) > 0
+	return s.UpdateIPS(ctx, site, setting)
+}
+
+// getSiteSetting reads a site setting into its typed form.
+func getSiteSetting[T any](ctx context.Context, s *settingService, site, key string) (*T, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/setting/%s", site, key)
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+
+	if !resp.IsSuccess() {
+		if resp.StatusCode == 404 {
+			return nil, notFoundf("setting not found: %s", key)
+		}
+		return nil, types.NewResponseError("get setting "+key, resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[T](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, notFoundf("setting not found: %s", key)
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// updateSiteSetting writes a typed site setting.
+func (s *settingService) updateSiteSetting(ctx context.Context, site, key string, setting interface{}) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/setting/%s", site, key)
+	req := transport.NewRequest("PUT", path).WithBody(setting)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update setting %s: %w", key, err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError("update setting "+key, resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+// superSettingSite is the site controller-wide settings are read and
+// written through.
+const superSettingSite = "default"
+
+// GetSuperMgmt returns the controller-wide management settings.
+func (s *settingService) GetSuperMgmt(ctx context.Context) (*types.SettingSuperMgmt, error) {
+	return getSuperSetting[types.SettingSuperMgmt](ctx, s, types.SettingKeySuperMgmt)
+}
+
+// UpdateSuperMgmt updates the controller-wide management settings. The
+// change applies to every site.
+func (s *settingService) UpdateSuperMgmt(ctx context.Context, setting *types.SettingSuperMgmt) error {
+	setting.Key = types.SettingKeySuperMgmt
+	return s.updateSuperSetting(ctx, setting.Key, setting)
+}
+
+// GetSuperSMTP returns the controller's mail server settings.
+func (s *settingService) GetSuperSMTP(ctx context.Context) (*types.SettingSuperSMTP, error) {
+	return getSuperSetting[types.SettingSuperSMTP](ctx, s, types.SettingKeySuperSMTP)
+}
+
+// UpdateSuperSMTP updates the controller's mail server settings. The
+// change applies to every site.
+func (s *settingService) UpdateSuperSMTP(ctx context.Context, setting *types.SettingSuperSMTP) error {
+	setting.Key = types.SettingKeySuperSMTP
+	return s.updateSuperSetting(ctx, setting.Key, setting)
+}
+
+// GetSuperIdentity returns the controller's name and hostname.
+func (s *settingService) GetSuperIdentity(ctx context.Context) (*types.SettingSuperIdentity, error) {
+	return getSuperSetting[types.SettingSuperIdentity](ctx, s, types.SettingKeySuperIdentity)
+}
+
+// UpdateSuperIdentity updates the controller's name and hostname. The
+// change applies to every site.
+func (s *settingService) UpdateSuperIdentity(ctx context.Context, setting *types.SettingSuperIdentity) error {
+	setting.Key = types.SettingKeySuperIdentity
+	return s.updateSuperSetting(ctx, setting.Key, setting)
+}
+
+// isSuperSettingKey reports whether key names a controller-wide setting.
+func isSuperSettingKey(key string) bool {
+	return strings.HasPrefix(key, "super_")
+}
+
+// getSuperSetting reads a controller-wide setting.
+func getSuperSetting[T any](ctx context.Context, s *settingService, key string) (*T, error) {
+	path := internal.BuildAPIPath(superSettingSite, "get/setting/"+key)
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("get setting "+key, resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[T](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, notFoundf("setting not found: %s", key)
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// updateSuperSetting writes a controller-wide setting.
+func (s *settingService) updateSuperSetting(ctx context.Context, key string, setting interface{}) error {
+	path := internal.BuildAPIPath(superSettingSite, "set/setting/"+key)
+	req := transport.NewRequest("POST", path).WithBody(setting)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update setting %s: %w", key, err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError("update setting "+key, resp.StatusCode, resp.Body)
 	}
 
 	return nil
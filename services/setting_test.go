@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"testing"
 
 	"github.com/unifi-go/gofi/mock"
@@ -69,6 +70,83 @@ func TestSettingService_Update(t *testing.T) {
 	}
 }
 
+func TestSettingService_SuperSettings(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestSettingTransport(server.URL())
+	svc := NewSettingService(trans)
+	ctx := context.Background()
+
+	if _, err := svc.GetSuperSMTP(ctx); err == nil {
+		t.Error("Expected error for unset super_smtp")
+	}
+
+	smtp := &types.SettingSuperSMTP{Enabled: true, Host: "smtp.example.com", Port: 587, UseAuth: true, Username: "unifi"}
+	if err := svc.UpdateSuperSMTP(ctx, smtp); err != nil {
+		t.Fatalf("UpdateSuperSMTP failed: %v", err)
+	}
+	got, err := svc.GetSuperSMTP(ctx)
+	if err != nil {
+		t.Fatalf("GetSuperSMTP failed: %v", err)
+	}
+	if got.Key != types.SettingKeySuperSMTP || got.Host != "smtp.example.com" || got.Port != 587 || !got.UseAuth {
+		t.Errorf("GetSuperSMTP() = %+v, want stored settings", got)
+	}
+
+	if err := svc.UpdateSuperIdentity(ctx, &types.SettingSuperIdentity{Name: "HQ Controller"}); err != nil {
+		t.Fatalf("UpdateSuperIdentity failed: %v", err)
+	}
+	if identity, err := svc.GetSuperIdentity(ctx); err != nil || identity.Name != "HQ Controller" {
+		t.Errorf("GetSuperIdentity() = %+v, %v, want HQ Controller", identity, err)
+	}
+
+	if err := svc.UpdateSuperMgmt(ctx, &types.SettingSuperMgmt{AutobackupEnabled: true, AutobackupMaxFiles: 7}); err != nil {
+		t.Fatalf("UpdateSuperMgmt failed: %v", err)
+	}
+	if mgmt, err := svc.GetSuperMgmt(ctx); err != nil || mgmt.AutobackupMaxFiles != 7 {
+		t.Errorf("GetSuperMgmt() = %+v, %v, want 7 backup files", mgmt, err)
+	}
+
+	// The site-scoped methods refuse controller-wide keys
+	if _, err := svc.Get(ctx, "default", types.SettingKeySuperMgmt); err == nil {
+		t.Error("Get() of super_mgmt succeeded, want error")
+	}
+	if err := svc.Update(ctx, "default", &types.Setting{Key: types.SettingKeySuperSMTP}); err == nil {
+		t.Error("Update() of super_smtp succeeded, want error")
+	}
+}
+
+func TestSettingService_LCM(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddSetting(&types.Setting{ID: "lcm1", Key: types.SettingKeyLCM, SiteID: "default"})
+
+	trans, _ := newTestSettingTransport(server.URL())
+	svc := NewSettingService(trans)
+	ctx := context.Background()
+
+	lcm := &types.SettingLCM{Enabled: true, Brightness: 40, IdleTimeout: 60, Sync: true, TouchEvent: true}
+	if err := svc.UpdateLCM(ctx, "default", lcm); err != nil {
+		t.Fatalf("UpdateLCM failed: %v", err)
+	}
+
+	got, err := svc.GetLCM(ctx, "default")
+	if err != nil {
+		t.Fatalf("GetLCM failed: %v", err)
+	}
+	if got.ID != "lcm1" || got.Key != types.SettingKeyLCM || !got.Enabled || got.Brightness != 40 || got.IdleTimeout != 60 || !got.Sync {
+		t.Errorf("GetLCM() = %+v, want stored settings", got)
+	}
+
+	err = svc.UpdateLCM(ctx, "default", &types.SettingLCM{Brightness: 0})
+	var verrs types.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Errorf("UpdateLCM() with brightness 0 error = %v, want ValidationErrors", err)
+	}
+}
+
 func TestSettingService_ListRadiusProfiles(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -150,6 +228,29 @@ func TestSettingService_CreateRadiusProfile(t *testing.T) {
 	}
 }
 
+func TestSettingService_CreateRadiusProfile_Invalid(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestSettingTransport(server.URL())
+	svc := NewSettingService(trans)
+
+	_, err := svc.CreateRadiusProfile(context.Background(), "default", &types.RADIUSProfile{
+		Name:              "Corp",
+		AuthServers:       []types.RADIUSServer{{IP: "10.0.0.5", Port: 1812}},
+		AccountingEnabled: true,
+	})
+	var verrs types.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	profiles, _ := svc.ListRadiusProfiles(context.Background(), "default")
+	if len(profiles) != 0 {
+		t.Errorf("Expected invalid profile not to be created, got %d profiles", len(profiles))
+	}
+}
+
 func TestSettingService_UpdateRadiusProfile(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -266,3 +367,46 @@ func TestSettingService_UpdateDynamicDNS(t *testing.T) {
 		t.Errorf("Expected hostname 'new.dyndns.org', got %s", ddns.Hostname)
 	}
 }
+
+func TestSettingService_SetHoneypots(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddSetting(&types.Setting{ID: "ips1", Key: types.SettingKeyIPS, SiteID: "default"})
+
+	trans, _ := newTestSettingTransport(server.URL())
+	svc := NewSettingService(trans)
+	ctx := context.Background()
+
+	if err := svc.UpdateIPS(ctx, "default", &types.SettingIPS{Enabled: true, RuleCategories: []string{"emerging-malware"}}); err != nil {
+		t.Fatalf("UpdateIPS failed: %v", err)
+	}
+	honeypots := []types.Honeypot{{IPAddress: "192.168.1.2", NetworkID: "lan"}}
+	if err := svc.SetHoneypots(ctx, "default", honeypots); err != nil {
+		t.Fatalf("SetHoneypots failed: %v", err)
+	}
+
+	got, err := svc.GetIPS(ctx, "default")
+	if err != nil {
+		t.Fatalf("GetIPS failed: %v", err)
+	}
+	if !got.HoneypotEnabled || len(got.Honeypots) != 1 || got.Honeypots[0].Version != "v4" || got.Honeypots[0].IPAddress != "192.168.1.2" {
+		t.Errorf("GetIPS() honeypots = %v %+v", got.HoneypotEnabled, got.Honeypots)
+	}
+	if !got.Enabled || len(got.RuleCategories) != 1 {
+		t.Errorf("GetIPS() = %+v, want other IPS settings kept", got)
+	}
+
+	if err := svc.SetHoneypots(ctx, "default", nil); err != nil {
+		t.Fatalf("SetHoneypots(nil) failed: %v", err)
+	}
+	if got, _ := svc.GetIPS(ctx, "default"); got.HoneypotEnabled || len(got.Honeypots) != 0 {
+		t.Errorf("GetIPS() after clearing = %+v", got)
+	}
+
+	err = svc.SetHoneypots(ctx, "default", []types.Honeypot{{IPAddress: "not-an-ip", NetworkID: "lan"}})
+	var verrs types.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Errorf("SetHoneypots() with invalid IP error = %v, want ValidationErrors", err)
+	}
+}
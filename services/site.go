@@ -2,13 +2,19 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
 )
 
+// MaxSiteConcurrency bounds how many sites ForEach works on at once, so
+// that tooling spanning hundreds of sites does not flood the controller.
+const MaxSiteConcurrency = 4
+
 // siteService implements SiteService.
 type siteService struct {
 	transport transport.Transport
@@ -31,7 +37,7 @@ func (s *siteService) List(ctx context.Context) ([]types.Site, error) {
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list sites failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list sites", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Site](resp.Body)
@@ -56,7 +62,7 @@ func (s *siteService) Get(ctx context.Context, id string) (*types.Site, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("site not found: %s", id)
+	return nil, notFoundf("site not found: %s", id)
 }
 
 // Create creates a new site.
@@ -75,7 +81,7 @@ func (s *siteService) Create(ctx context.Context, desc, name string) (*types.Sit
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create site failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create site", resp.StatusCode, resp.Body)
 	}
 
 	site, err := internal.ParseSingleResult[types.Site](resp.Body)
@@ -103,7 +109,7 @@ func (s *siteService) Update(ctx context.Context, site *types.Site) (*types.Site
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update site failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update site", resp.StatusCode, resp.Body)
 	}
 
 	result, err := internal.ParseSingleResult[types.Site](resp.Body)
@@ -124,8 +130,8 @@ func (s *siteService) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete site: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete site failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("site", resp)
 	}
 
 	return nil
@@ -142,7 +148,7 @@ func (s *siteService) Health(ctx context.Context, site string) ([]types.HealthDa
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get health failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get health", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.HealthData](resp.Body)
@@ -164,7 +170,7 @@ func (s *siteService) SysInfo(ctx context.Context, site string) (*types.SysInfo,
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get sysinfo failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get sysinfo", resp.StatusCode, resp.Body)
 	}
 
 	sysInfo, err := internal.ParseSingleResult[types.SysInfo](resp.Body)
@@ -174,3 +180,45 @@ func (s *siteService) SysInfo(ctx context.Context, site string) (*types.SysInfo,
 
 	return sysInfo, nil
 }
+
+// ForEach lists the sites and calls fn for each, at most
+// MaxSiteConcurrency at a time, so fn must be safe for concurrent use. A
+// failing site does not stop the others; the returned error joins the
+// failures, each prefixed with the site name. Sites not yet started when
+// ctx is done are skipped and ctx's error is included.
+func (s *siteService) ForEach(ctx context.Context, fn func(site types.Site) error) error {
+	sites, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, MaxSiteConcurrency)
+	)
+	for _, site := range sites {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		wg.Add(1)
+		go func(site types.Site) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(site); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("site %s: %w", site.Name, err))
+				mu.Unlock()
+			}
+		}(site)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
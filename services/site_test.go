@@ -3,10 +3,17 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/unifi-go/gofi/mock"
 	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
 )
 
 func TestSiteService_List(t *testing.T) {
@@ -171,3 +178,94 @@ func TestSiteService_SysInfo(t *testing.T) {
 		t.Errorf("Hostname = %s, want UDM-Pro", sysInfo.Hostname)
 	}
 }
+
+func TestSiteService_ForEach(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+	for i := 1; i <= 9; i++ {
+		name := fmt.Sprintf("branch%d", i)
+		server.State().AddSite(&types.Site{ID: name, Name: name})
+	}
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewSiteService(trans)
+
+	var (
+		mu            sync.Mutex
+		seen          []string
+		active, peak  atomic.Int32
+		errBranchFail = errors.New("boom")
+	)
+	err := svc.ForEach(context.Background(), func(site types.Site) error {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		seen = append(seen, site.Name)
+		mu.Unlock()
+		if site.Name == "branch3" {
+			return errBranchFail
+		}
+		return nil
+	})
+
+	if len(seen) != 10 {
+		t.Errorf("fn called for %d sites, want 10", len(seen))
+	}
+	if p := peak.Load(); p > MaxSiteConcurrency {
+		t.Errorf("peak concurrency = %d, want at most %d", p, MaxSiteConcurrency)
+	}
+	if !errors.Is(err, errBranchFail) || !strings.Contains(err.Error(), "site branch3") {
+		t.Errorf("ForEach() error = %v, want branch3 failure", err)
+	}
+}
+
+func TestSiteService_Maps(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewSiteService(trans)
+	ctx := context.Background()
+
+	name, err := svc.UploadMapImage(ctx, "default", "floor1.png", strings.NewReader("\x89PNG image"))
+	if err != nil {
+		t.Fatalf("UploadMapImage() error = %v", err)
+	}
+	if !strings.HasSuffix(name, ".png") || string(server.State().GetMapImage(name)) != "\x89PNG image" {
+		t.Errorf("UploadMapImage() = %q, image not stored", name)
+	}
+
+	m, err := svc.CreateMap(ctx, "default", &types.SiteMap{
+		Name: "Floor 1", Type: types.SiteMapImage, Upload: name, Unit: "m", UnitsPerPixel: 0.05,
+	})
+	if err != nil {
+		t.Fatalf("CreateMap() error = %v", err)
+	}
+
+	m.Name = "Ground floor"
+	if _, err := svc.UpdateMap(ctx, "default", m); err != nil {
+		t.Fatalf("UpdateMap() error = %v", err)
+	}
+	maps, err := svc.ListMaps(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListMaps() error = %v", err)
+	}
+	if len(maps) != 1 || maps[0].Name != "Ground floor" || maps[0].Upload != name || maps[0].UnitsPerPixel != 0.05 {
+		t.Errorf("ListMaps() = %+v", maps)
+	}
+
+	if err := svc.DeleteMap(ctx, "default", m.ID); err != nil {
+		t.Fatalf("DeleteMap() error = %v", err)
+	}
+	if server.State().GetSiteMap(m.ID) != nil {
+		t.Error("map not deleted")
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// ListMaps returns the site's maps.
+func (s *siteService) ListMaps(ctx context.Context, site string) ([]types.SiteMap, error) {
+	path := internal.BuildRESTPath(site, "map", "")
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maps: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("list maps", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.SiteMap](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// CreateMap creates a map. For an image map, upload the image first with
+// UploadMapImage and set Upload to the returned name.
+func (s *siteService) CreateMap(ctx context.Context, site string, m *types.SiteMap) (*types.SiteMap, error) {
+	path := internal.BuildRESTPath(site, "map", "")
+	req := transport.NewRequest("POST", path).WithBody(m)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create map: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("create map", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.SiteMap](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("create map returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// UpdateMap updates an existing map.
+func (s *siteService) UpdateMap(ctx context.Context, site string, m *types.SiteMap) (*types.SiteMap, error) {
+	if m.ID == "" {
+		return nil, fmt.Errorf("map ID is required for update")
+	}
+
+	path := internal.BuildRESTPath(site, "map", m.ID)
+	req := transport.NewRequest("PUT", path).WithBody(m)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update map: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, types.NewResponseError("update map", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[types.SiteMap](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("update map returned empty response")
+	}
+
+	return &apiResp.Data[0], nil
+}
+
+// DeleteMap deletes a map.
+func (s *siteService) DeleteMap(ctx context.Context, site, id string) error {
+	path := internal.BuildRESTPath(site, "map", id)
+	req := transport.NewRequest("DELETE", path)
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete map: %w", err)
+	}
+
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("map", resp)
+	}
+
+	return nil
+}
+
+// mapUpload is the controller's reply to a map image upload.
+type mapUpload struct {
+	Filename string `json:"filename"`
+}
+
+// UploadMapImage uploads a map image and returns the name the controller
+// stored it under, for SiteMap.Upload.
+func (s *siteService) UploadMapImage(ctx context.Context, site, filename string, image io.Reader) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload map image: %w", err)
+	}
+	if _, err := io.Copy(part, image); err != nil {
+		return "", fmt.Errorf("failed to read map image: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return "", fmt.Errorf("failed to upload map image: %w", err)
+	}
+
+	path := internal.BuildAPIPath(site, "upload/map")
+	req := transport.NewRequest("POST", path).
+		WithBody(transport.RawBody{ContentType: form.FormDataContentType(), Data: body.Bytes()})
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload map image: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return "", types.NewResponseError("upload map image", resp.StatusCode, resp.Body)
+	}
+
+	apiResp, err := internal.ParseAPIResponse[mapUpload](resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if len(apiResp.Data) == 0 || apiResp.Data[0].Filename == "" {
+		return "", fmt.Errorf("upload map image returned empty response")
+	}
+
+	return apiResp.Data[0].Filename, nil
+}
+
+// Placements returns the positions of the devices placed on a map, or on
+// any map if mapID is empty.
+func (s *deviceService) Placements(ctx context.Context, site, mapID string) ([]types.APPlacement, error) {
+	devices, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	placements := []types.APPlacement{}
+	for i := range devices {
+		if p := devices[i].Placement(); p != nil && (mapID == "" || p.MapID == mapID) {
+			placements = append(placements, *p)
+		}
+	}
+	return placements, nil
+}
+
+// devicePlacement is the body of a device update that moves it on a map.
+type devicePlacement struct {
+	MapID          string  `json:"map_id"`
+	X              float64 `json:"x"`
+	Y              float64 `json:"y"`
+	HeightInMeters float64 `json:"heightInMeters,omitempty"`
+}
+
+// SetPlacement places a device on a map. The MAC of the placement is
+// ignored in favor of mac.
+func (s *deviceService) SetPlacement(ctx context.Context, site, mac string, placement types.APPlacement) error {
+	if placement.MapID == "" {
+		return fmt.Errorf("map ID is required for placement")
+	}
+
+	device, err := s.GetByMAC(ctx, site, mac)
+	if err != nil {
+		return err
+	}
+
+	path := internal.BuildRESTPath(site, "device", device.ID)
+	req := transport.NewRequest("PUT", path).WithBody(devicePlacement{
+		MapID:          placement.MapID,
+		X:              placement.X,
+		Y:              placement.Y,
+		HeightInMeters: placement.HeightInMeters,
+	})
+
+	resp, err := s.transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to place device: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return types.NewResponseError("place device", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// SpeedTestOption configures RunSpeedTest.
+type SpeedTestOption func(*speedTestOptions)
+
+// speedTestOptions holds options for RunSpeedTest.
+type speedTestOptions struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// WithSpeedTestTimeout sets how long RunSpeedTest waits for the test to
+// finish (default 3 minutes).
+func WithSpeedTestTimeout(d time.Duration) SpeedTestOption {
+	return func(opts *speedTestOptions) {
+		opts.timeout = d
+	}
+}
+
+// WithSpeedTestPollInterval sets how often RunSpeedTest polls the test
+// status (default 5 seconds).
+func WithSpeedTestPollInterval(d time.Duration) SpeedTestOption {
+	return func(opts *speedTestOptions) {
+		opts.pollInterval = d
+	}
+}
+
+// RunSpeedTest starts a speed test on the site's gateway and waits for it
+// to finish. The test counts as finished once the status is no longer
+// running and reports a newer last run than before the test was started.
+func (s *systemService) RunSpeedTest(ctx context.Context, site string, opts ...SpeedTestOption) (*types.SpeedTestResult, error) {
+	options := &speedTestOptions{
+		timeout:      3 * time.Minute,
+		pollInterval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	before, err := s.SpeedTestStatus(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	var lastRun int64
+	if before != nil {
+		lastRun = before.LastRun
+	}
+
+	if err := s.SpeedTest(ctx, site); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(options.pollInterval)
+	defer ticker.Stop()
+
+	sawRunning := false
+	for {
+		status, err := s.SpeedTestStatus(ctx, site)
+		if err == nil && status != nil {
+			if status.Running {
+				sawRunning = true
+			} else if sawRunning || status.LastRun > lastRun {
+				result := status.Result()
+				return &result, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("speed test did not finish within %s", options.timeout)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
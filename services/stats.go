@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/transport"
+	"github.com/unifi-go/gofi/types"
+)
+
+// statsService implements StatsService.
+type statsService struct {
+	transport transport.Transport
+	devices   DeviceService
+}
+
+// NewStatsService creates a new stats service.
+func NewStatsService(transport transport.Transport) StatsService {
+	return &statsService{
+		transport: transport,
+		devices:   NewDeviceService(transport),
+	}
+}
+
+// WANThroughput polls the site's gateway and converts its WAN byte counters
+// into rates. The first poll of each interface only records a baseline, so
+// the first samples arrive one interval after the call.
+//
+// The device list is fetched on every poll, so a replaced gateway is picked
+// up without restarting the stream. Counters that wrap at 32 bits are
+// corrected; any other decrease, a drop in uptime or a change of gateway is
+// treated as a reset and starts a new baseline. A failed poll sends a
+// sample with Err set.
+func (s *statsService) WANThroughput(ctx context.Context, site string, interval time.Duration) (<-chan types.WANSample, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	ch := make(chan types.WANSample, 2)
+	go func() {
+		defer close(ch)
+
+		meter := newWANMeter()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			for _, sample := range s.pollWAN(ctx, site, meter) {
+				select {
+				case ch <- sample:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// pollWAN lists the site's devices and feeds the gateway's WAN counters to
+// the meter.
+func (s *statsService) pollWAN(ctx context.Context, site string, meter *wanMeter) []types.WANSample {
+	devices, err := s.devices.List(ctx, site)
+	if ctx.Err() != nil {
+		return nil
+	}
+	now := time.Now()
+	if err != nil {
+		return []types.WANSample{{Time: now, Err: err}}
+	}
+
+	gw := findGateway(devices)
+	if gw == nil {
+		meter.reset()
+		return []types.WANSample{{Time: now, Err: fmt.Errorf("no gateway found in site %s", site)}}
+	}
+
+	var samples []types.WANSample
+	for _, wan := range []struct {
+		name  string
+		stats *types.WAN
+	}{{"wan1", gw.Wan1}, {"wan2", gw.Wan2}} {
+		if wan.stats == nil {
+			continue
+		}
+		if sample, ok := meter.update(now, strings.ToLower(gw.MAC), wan.name, wan.stats); ok {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// findGateway returns the first gateway in devices, or nil.
+func findGateway(devices []types.Device) *types.Device {
+	for i := range devices {
+		switch devices[i].Type {
+		case "udm", "ugw", "uxg":
+			return &devices[i]
+		}
+	}
+	return nil
+}
+
+// wanCounters is the last reading of one WAN interface.
+type wanCounters struct {
+	time   time.Time
+	mac    string
+	uptime int64
+	rx, tx uint64
+}
+
+// wanMeter turns successive WAN counter readings into rates.
+type wanMeter struct {
+	last map[string]wanCounters
+}
+
+func newWANMeter() *wanMeter {
+	return &wanMeter{last: make(map[string]wanCounters)}
+}
+
+// reset discards all baselines.
+func (m *wanMeter) reset() {
+	m.last = make(map[string]wanCounters)
+}
+
+// update records a reading and returns a sample if a rate could be
+// computed from the previous one.
+func (m *wanMeter) update(now time.Time, mac, iface string, wan *types.WAN) (types.WANSample, bool) {
+	cur := wanCounters{
+		time:   now,
+		mac:    mac,
+		uptime: wan.Uptime.Int64(),
+		rx:     uint64(wan.RXBytes.Int64()),
+		tx:     uint64(wan.TXBytes.Int64()),
+	}
+	prev, ok := m.last[iface]
+	m.last[iface] = cur
+
+	if !ok || prev.mac != cur.mac || cur.uptime < prev.uptime {
+		return types.WANSample{}, false
+	}
+	elapsed := cur.time.Sub(prev.time).Seconds()
+	if elapsed <= 0 {
+		return types.WANSample{}, false
+	}
+	rx, rxOK := counterDelta(prev.rx, cur.rx)
+	tx, txOK := counterDelta(prev.tx, cur.tx)
+	if !rxOK || !txOK {
+		return types.WANSample{}, false
+	}
+
+	return types.WANSample{
+		Time:       now,
+		Interface:  iface,
+		GatewayMAC: mac,
+		Up:         wan.Up,
+		RXBps:      float64(rx) * 8 / elapsed,
+		TXBps:      float64(tx) * 8 / elapsed,
+		Latency:    time.Duration(wan.Latency) * time.Millisecond,
+		Uptime:     time.Duration(cur.uptime) * time.Second,
+	}, true
+}
+
+// counterDelta returns the increase of a byte counter. A 32-bit counter
+// that went backwards is assumed to have wrapped once; any other decrease
+// is a reset and reports false.
+func counterDelta(prev, cur uint64) (uint64, bool) {
+	if cur >= prev {
+		return cur - prev, true
+	}
+	if prev < 1<<32 {
+		return cur + 1<<32 - prev, true
+	}
+	return 0, false
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func wanReading(rx, tx float64, uptime float64) *types.WAN {
+	return &types.WAN{
+		Up:      true,
+		Latency: 12,
+		RXBytes: types.FlexInt{Val: rx},
+		TXBytes: types.FlexInt{Val: tx},
+		Uptime:  types.FlexInt{Val: uptime},
+	}
+}
+
+func TestWANMeter(t *testing.T) {
+	m := newWANMeter()
+	start := time.Unix(1700000000, 0)
+	mac := "aa:bb:cc:dd:ee:01"
+
+	if _, ok := m.update(start, mac, "wan1", wanReading(1000, 500, 100)); ok {
+		t.Fatal("first reading produced a sample, want baseline only")
+	}
+
+	s, ok := m.update(start.Add(10*time.Second), mac, "wan1", wanReading(11000, 1500, 110))
+	if !ok {
+		t.Fatal("second reading produced no sample")
+	}
+	if s.RXBps != 8000 || s.TXBps != 800 {
+		t.Errorf("rates = %v/%v bps, want 8000/800", s.RXBps, s.TXBps)
+	}
+	if s.Latency != 12*time.Millisecond || s.Uptime != 110*time.Second {
+		t.Errorf("latency = %s, uptime = %s, want 12ms, 1m50s", s.Latency, s.Uptime)
+	}
+
+	// 32-bit wrap: 4294967000 -> 704 is 1000 bytes.
+	m.update(start.Add(20*time.Second), mac, "wan1", wanReading(4294967000, 1500, 120))
+	s, ok = m.update(start.Add(30*time.Second), mac, "wan1", wanReading(704, 1500, 130))
+	if !ok || s.RXBps != 800 {
+		t.Errorf("after wrap = %v bps, %v, want 800 bps", s.RXBps, ok)
+	}
+
+	// Uptime going backwards means the gateway rebooted.
+	if _, ok := m.update(start.Add(40*time.Second), mac, "wan1", wanReading(100, 100, 5)); ok {
+		t.Error("reading after reboot produced a sample, want new baseline")
+	}
+
+	// A different gateway starts a new baseline too.
+	if _, ok := m.update(start.Add(50*time.Second), "aa:bb:cc:dd:ee:02", "wan1", wanReading(200, 200, 15)); ok {
+		t.Error("reading from new gateway produced a sample, want new baseline")
+	}
+}
+
+func TestCounterDelta(t *testing.T) {
+	tests := []struct {
+		prev, cur uint64
+		want      uint64
+		ok        bool
+	}{
+		{100, 300, 200, true},
+		{1<<32 - 100, 50, 150, true},
+		{1 << 40, 10, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := counterDelta(tt.prev, tt.cur)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("counterDelta(%d, %d) = %d, %v, want %d, %v", tt.prev, tt.cur, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStatsService_WANThroughput(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddDevice(&types.Device{
+		ID:   "gw",
+		MAC:  "AA:BB:CC:DD:EE:01",
+		Type: "udm",
+		Wan1: wanReading(1000, 1000, 100),
+	})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewStatsService(trans)
+
+	if _, err := svc.WANThroughput(context.Background(), "default", 0); err == nil {
+		t.Error("WANThroughput() with zero interval succeeded, want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	samples, err := svc.WANThroughput(ctx, "default", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WANThroughput() error = %v", err)
+	}
+
+	select {
+	case s := <-samples:
+		if s.Err != nil {
+			t.Fatalf("sample error = %v", s.Err)
+		}
+		if s.Interface != "wan1" || s.GatewayMAC != "aa:bb:cc:dd:ee:01" || !s.Up {
+			t.Errorf("sample = %+v, want wan1 of aa:bb:cc:dd:ee:01", s)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no sample received")
+	}
+
+	cancel()
+	for range samples {
+	}
+}
+
+func TestStatsService_HoneypotEvents(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddSetting(&types.Setting{ID: "ips1", Key: types.SettingKeyIPS, SiteID: "default"})
+	server.State().SetSettingFields(types.SettingKeyIPS, map[string]interface{}{
+		"honeypot_enabled": true,
+		"honeypot":         []interface{}{map[string]interface{}{"ip_address": "192.168.1.2", "network_id": "lan"}},
+	})
+
+	now := time.Now()
+	server.State().AddIPSEvent(types.IPSEvent{ID: "old", Time: now.Add(-48 * time.Hour).UnixMilli(), DestIP: "192.168.1.2"})
+	server.State().AddIPSEvent(types.IPSEvent{ID: "hit", Time: now.Add(-time.Hour).UnixMilli(), SrcIP: "192.168.1.50", DestIP: "192.168.1.2", DestPort: 22})
+	server.State().AddIPSEvent(types.IPSEvent{ID: "other", Time: now.Add(-time.Hour).UnixMilli(), DestIP: "203.0.113.9", Category: "emerging-malware"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewStatsService(trans)
+	ctx := context.Background()
+
+	all, err := svc.IPSEvents(ctx, "default", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("IPSEvents failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("IPSEvents() = %d events, want 2 in the last day", len(all))
+	}
+
+	hits, err := svc.HoneypotEvents(ctx, "default", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("HoneypotEvents failed: %v", err)
+	}
+	if len(hits) != 1 || hits[0].ID != "hit" || hits[0].SrcIP != "192.168.1.50" {
+		t.Errorf("HoneypotEvents() = %+v, want the honeypot hit", hits)
+	}
+}
+
+func TestStatsService_Anomalies(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	now := time.Now()
+	ms := func(d time.Duration) types.FlexInt { return types.FlexInt{Val: float64(now.Add(-d).UnixMilli())} }
+	server.State().AddAnomaly(types.Anomaly{Kind: "USER_HIGH_WIFI_RETRIES", MAC: "aa:00:00:00:00:01",
+		Timestamps: []types.FlexInt{ms(time.Hour), ms(72 * time.Hour)}})
+	server.State().AddAnomaly(types.Anomaly{Kind: "USER_DHCP_TIMEOUT", MAC: "aa:00:00:00:00:02",
+		Timestamps: []types.FlexInt{ms(2 * time.Hour)}})
+	server.State().AddAnomaly(types.Anomaly{Kind: "USER_SLOW_ROAMING", MAC: "aa:00:00:00:00:03",
+		Timestamps: []types.FlexInt{ms(96 * time.Hour)}})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewStatsService(trans)
+	ctx := context.Background()
+
+	anomalies, err := svc.Anomalies(ctx, "default", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("Anomalies failed: %v", err)
+	}
+	if len(anomalies) != 2 || len(anomalies[0].Timestamps) != 1 {
+		t.Errorf("Anomalies() = %+v, want 2 anomalies within the last day", anomalies)
+	}
+
+	summary, err := svc.AnomalySummary(ctx, "default", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("AnomalySummary failed: %v", err)
+	}
+	if summary.Categories[types.AnomalyRetries] != 1 || summary.Categories[types.AnomalyDHCP] != 1 || summary.Categories[types.AnomalyRoaming] != 0 {
+		t.Errorf("Categories = %v", summary.Categories)
+	}
+}
+
+func TestStatsService_Report(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewStatsService(trans)
+	ctx := context.Background()
+
+	end := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rows, err := svc.Report(ctx, "default", types.ReportSite, types.ReportHourly, end.Add(-6*time.Hour), end)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("Report() returned %d rows, want 6 hourly rows", len(rows))
+	}
+	if !rows[0].StartTime().Equal(end.Add(-6*time.Hour)) || rows[5].Value(types.MetricWANRxBytes) != 6000 {
+		t.Errorf("Report() rows = %+v", rows)
+	}
+
+	midnight := end.Truncate(24 * time.Hour)
+	rows, err = svc.Report(ctx, "default", types.ReportAP, types.ReportDaily, midnight.Add(-48*time.Hour), midnight,
+		WithMetrics(types.MetricBytes), WithReportMACs("AA-BB-CC-00-00-01", "aa:bb:cc:00:00:02"))
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(rows) != 4 || rows[0].AP != "aa:bb:cc:00:00:01" {
+		t.Fatalf("Report() = %+v, want 2 days for 2 APs", rows)
+	}
+	if len(rows[0].Metrics) != 1 || rows[0].Value(types.MetricBytes) != 1000 {
+		t.Errorf("Metrics = %v, want only bytes", rows[0].Metrics)
+	}
+
+	if _, err := svc.Report(ctx, "default", types.ReportSite, "weekly", time.Time{}, time.Time{}); err == nil {
+		t.Error("Report() with an unknown interval should fail")
+	}
+	if _, err := svc.Report(ctx, "default", types.ReportSite, types.ReportHourly, end, end.Add(-time.Hour)); err == nil {
+		t.Error("Report() with start after end should fail")
+	}
+}
@@ -3,6 +3,10 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
@@ -12,12 +16,20 @@ import (
 // systemService implements SystemService.
 type systemService struct {
 	transport transport.Transport
+
+	// Outstanding reboot and power-off tokens, keyed by token.
+	tokenMu sync.Mutex
+	tokens  map[string]rebootToken
+
+	// Number of CreateBackup calls in flight.
+	backups atomic.Int32
 }
 
 // NewSystemService creates a new system service.
 func NewSystemService(transport transport.Transport) SystemService {
 	return &systemService{
 		transport: transport,
+		tokens:    make(map[string]rebootToken),
 	}
 }
 
@@ -32,7 +44,7 @@ func (s *systemService) Status(ctx context.Context) (*types.Status, error) {
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get status failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get status", resp.StatusCode, resp.Body)
 	}
 
 	var status types.Status
@@ -54,7 +66,7 @@ func (s *systemService) Self(ctx context.Context) (*types.AdminUser, error) {
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get self failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get self", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.AdminUser](resp.Body)
@@ -69,20 +81,27 @@ func (s *systemService) Self(ctx context.Context) (*types.AdminUser, error) {
 	return &apiResp.Data[0], nil
 }
 
-// Reboot reboots the controller.
-func (s *systemService) Reboot(ctx context.Context) error {
-	path := "/proxy/network/api/cmd/system"
-	req := transport.NewRequest("POST", path).WithBody(map[string]string{
-		"cmd": "reboot",
-	})
+// ChangePassword changes the password of the logged-in admin. The console
+// ends the admin's sessions, this one included, so the caller must log in
+// again with the new password. Only UniFi OS consoles serve it.
+func (s *systemService) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	if newPassword == "" {
+		return fmt.Errorf("new password is required")
+	}
+
+	body := map[string]string{
+		"oldPassword": currentPassword,
+		"password":    newPassword,
+	}
+	req := transport.NewRequest("PUT", "/api/users/self").WithBody(body)
 
 	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to reboot: %w", err)
+		return fmt.Errorf("failed to change password: %w", err)
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("reboot failed with status %d", resp.StatusCode)
+		return types.NewResponseError("change password", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -99,7 +118,7 @@ func (s *systemService) SpeedTest(ctx context.Context, site string) error {
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("speed test failed with status %d", resp.StatusCode)
+		return types.NewResponseError("speed test", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -116,7 +135,7 @@ func (s *systemService) SpeedTestStatus(ctx context.Context, site string) (*type
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("get speed test status failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get speed test status", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.SpeedTestStatus](resp.Body)
@@ -143,7 +162,7 @@ func (s *systemService) ListBackups(ctx context.Context) ([]types.Backup, error)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list backups failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list backups", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.Backup](resp.Body)
@@ -156,6 +175,9 @@ func (s *systemService) ListBackups(ctx context.Context) ([]types.Backup, error)
 
 // CreateBackup creates a new backup.
 func (s *systemService) CreateBackup(ctx context.Context) error {
+	s.backups.Add(1)
+	defer s.backups.Add(-1)
+
 	path := "/proxy/network/api/cmd/backup"
 	req := transport.NewRequest("POST", path)
 
@@ -165,7 +187,7 @@ func (s *systemService) CreateBackup(ctx context.Context) error {
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("create backup failed with status %d", resp.StatusCode)
+		return types.NewResponseError("create backup", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -181,13 +203,33 @@ func (s *systemService) DeleteBackup(ctx context.Context, filename string) error
 		return fmt.Errorf("failed to delete backup: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete backup failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("backup", resp)
 	}
 
 	return nil
 }
 
+// DownloadBackup opens a backup file for reading. The body is streamed
+// from the controller; the caller must close it.
+func (s *systemService) DownloadBackup(ctx context.Context, filename string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/proxy/network/dl/backup/%s", url.PathEscape(filename))
+	req := transport.NewRequest("GET", path).WithHeader("Accept", "application/octet-stream")
+
+	resp, err := transport.Stream(ctx, s.transport, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		return nil, types.NewResponseError("download backup", resp.StatusCode, body)
+	}
+
+	return resp.Body, nil
+}
+
 // ListAdmins returns all admin users.
 func (s *systemService) ListAdmins(ctx context.Context) ([]types.AdminUser, error) {
 	path := "/proxy/network/api/stat/admin"
@@ -199,7 +241,7 @@ func (s *systemService) ListAdmins(ctx context.Context) ([]types.AdminUser, erro
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list admins failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list admins", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.AdminUser](resp.Body)
@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/unifi-go/gofi/mock"
 	"github.com/unifi-go/gofi/transport"
@@ -65,12 +69,61 @@ func TestSystemService_Reboot(t *testing.T) {
 	// Create service
 	trans, _ := newTestSystemTransport(server.URL())
 	svc := NewSystemService(trans)
+	ctx := context.Background()
 
-	// Test Reboot
-	err := svc.Reboot(context.Background())
+	// Reboot without a token is refused
+	if err := svc.Reboot(ctx, ""); !errors.Is(err, ErrInvalidRebootToken) {
+		t.Fatalf("Reboot() without token error = %v, want ErrInvalidRebootToken", err)
+	}
+
+	token, err := svc.PrepareReboot(ctx)
 	if err != nil {
+		t.Fatalf("PrepareReboot failed: %v", err)
+	}
+
+	// A reboot token does not power off
+	if err := svc.PowerOff(ctx, token); !errors.Is(err, ErrInvalidRebootToken) {
+		t.Errorf("PowerOff() with reboot token error = %v, want ErrInvalidRebootToken", err)
+	}
+
+	// Consumed by the failed PowerOff
+	if err := svc.Reboot(ctx, token); !errors.Is(err, ErrInvalidRebootToken) {
+		t.Errorf("Reboot() with used token error = %v, want ErrInvalidRebootToken", err)
+	}
+
+	token, _ = svc.PrepareReboot(ctx)
+	if err := svc.Reboot(ctx, token); err != nil {
 		t.Fatalf("Reboot failed: %v", err)
 	}
+
+	token, _ = svc.PreparePowerOff(ctx)
+	if err := svc.PowerOff(ctx, token); err != nil {
+		t.Fatalf("PowerOff failed: %v", err)
+	}
+}
+
+func TestSystemService_PrepareReboot_Preflight(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestSystemTransport(server.URL())
+	svc := NewSystemService(trans)
+	ctx := context.Background()
+
+	if _, err := svc.PrepareReboot(ctx, WithPreflight()); err != nil {
+		t.Fatalf("PrepareReboot() on idle controller error = %v", err)
+	}
+
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:bb:cc:dd:ee:01", State: types.DeviceStateUpgrading})
+	_, err := svc.PrepareReboot(ctx, WithPreflight())
+	if !errors.Is(err, ErrRebootBlocked) || !strings.Contains(err.Error(), "aa:bb:cc:dd:ee:01") {
+		t.Errorf("PrepareReboot() during upgrade error = %v, want ErrRebootBlocked naming the device", err)
+	}
+
+	// Without the pre-flight check the token is issued anyway
+	if _, err := svc.PrepareReboot(ctx); err != nil {
+		t.Errorf("PrepareReboot() without preflight error = %v", err)
+	}
 }
 
 func TestSystemService_SpeedTest(t *testing.T) {
@@ -110,12 +163,12 @@ func TestSystemService_ListBackups(t *testing.T) {
 	server.State().AddBackup(&types.Backup{
 		Filename: "backup1.unf",
 		Size:     1024,
-		Time:     1234567890,
+		Time:     types.NewFlexTime(1234567890),
 	})
 	server.State().AddBackup(&types.Backup{
 		Filename: "backup2.unf",
 		Size:     2048,
-		Time:     1234567891,
+		Time:     types.NewFlexTime(1234567891),
 	})
 
 	// Create service
@@ -162,7 +215,7 @@ func TestSystemService_DeleteBackup(t *testing.T) {
 	server.State().AddBackup(&types.Backup{
 		Filename: "backup1.unf",
 		Size:     1024,
-		Time:     1234567890,
+		Time:     types.NewFlexTime(1234567890),
 	})
 
 	// Create service
@@ -182,6 +235,37 @@ func TestSystemService_DeleteBackup(t *testing.T) {
 	}
 }
 
+func TestSystemService_DownloadBackup(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddBackup(&types.Backup{
+		Filename: "backup1.unf",
+		Size:     4096,
+		Time:     types.NewFlexTime(1234567890),
+	})
+
+	trans, _ := newTestSystemTransport(server.URL())
+	svc := NewSystemService(trans)
+
+	body, err := svc.DownloadBackup(context.Background(), "backup1.unf")
+	if err != nil {
+		t.Fatalf("DownloadBackup failed: %v", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatalf("Reading backup failed: %v", err)
+	}
+	if len(data) != 4096 {
+		t.Errorf("Expected 4096 bytes, got %d", len(data))
+	}
+
+	if _, err := svc.DownloadBackup(context.Background(), "missing.unf"); err == nil {
+		t.Error("Expected error for missing backup")
+	}
+}
+
 func TestSystemService_ListAdmins(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -207,3 +291,21 @@ func TestSystemService_ListAdmins(t *testing.T) {
 		t.Errorf("Expected 1 admin, got %d", len(admins))
 	}
 }
+
+func TestSystemService_RunSpeedTest(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestSystemTransport(server.URL())
+	svc := NewSystemService(trans)
+	ctx := context.Background()
+
+	result, err := svc.RunSpeedTest(ctx, "default", WithSpeedTestPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("RunSpeedTest failed: %v", err)
+	}
+	if result.DownloadMbps != 500 || result.UploadMbps != 50 || result.Latency != 15*time.Millisecond {
+		t.Errorf("RunSpeedTest() = %+v, want 500/50 Mbps and 15ms", result)
+	}
+
+}
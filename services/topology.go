@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// Topology lists the site's devices and active clients and assembles them
+// into an uplink graph with types.NewTopologyGraph.
+func (s *deviceService) Topology(ctx context.Context, site string) (*types.TopologyGraph, error) {
+	devices, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := NewClientService(s.transport).ListActive(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewTopologyGraph(devices, clients), nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -21,9 +22,18 @@ func NewUserService(transport transport.Transport) UserService {
 	}
 }
 
-// List returns all known clients/users.
-func (s *userService) List(ctx context.Context, site string) ([]types.User, error) {
+// List returns all known clients/users, or with options only those
+// matching.
+func (s *userService) List(ctx context.Context, site string, opts ...UserListOption) ([]types.User, error) {
+	options := &userListOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	path := internal.BuildRESTPath(site, "user", "")
+	if query := options.query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
 	req := transport.NewRequest("GET", path)
 
 	resp, err := s.transport.Do(ctx, req)
@@ -32,7 +42,7 @@ func (s *userService) List(ctx context.Context, site string) ([]types.User, erro
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list users failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list users", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.User](resp.Body)
@@ -40,7 +50,10 @@ func (s *userService) List(ctx context.Context, site string) ([]types.User, erro
 		return nil, err
 	}
 
-	return apiResp.Data, nil
+	if len(opts) == 0 {
+		return apiResp.Data, nil
+	}
+	return options.filter(apiResp.Data), nil
 }
 
 // Get returns a user by ID.
@@ -55,9 +68,9 @@ func (s *userService) Get(ctx context.Context, site, id string) (*types.User, er
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("user not found: %s", id)
+			return nil, notFoundf("user not found: %s", id)
 		}
-		return nil, fmt.Errorf("get user failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get user", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.User](resp.Body)
@@ -66,27 +79,53 @@ func (s *userService) Get(ctx context.Context, site, id string) (*types.User, er
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("user not found: %s", id)
+		return nil, notFoundf("user not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
 }
 
-// GetByMAC returns a user by MAC address.
+// GetByMAC returns a user by MAC address, in any case and with or without
+// separators. It asks the controller for that user alone, and falls back
+// to a filtered List on controllers without the direct lookup.
 func (s *userService) GetByMAC(ctx context.Context, site, mac string) (*types.User, error) {
-	// List all users and find by MAC
-	users, err := s.List(ctx, site)
+	if !internal.ValidateMAC(mac) {
+		return nil, fmt.Errorf("invalid MAC address: %s", mac)
+	}
+	normalized := internal.NormalizeMAC(mac)
+
+	path := internal.BuildAPIPath(site, "stat/user/"+internal.FormatMAC(normalized))
+	req := transport.NewRequest("GET", path)
+
+	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	for _, user := range users {
-		if user.MAC == mac {
-			return &user, nil
+	var users []types.User
+	switch {
+	case resp.IsSuccess():
+		apiResp, err := internal.ParseAPIResponse[types.User](resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		users = apiResp.Data
+	case bytes.Contains(resp.Body, []byte("api.err.UnknownUser")):
+		// The lookup worked; the controller has never seen the MAC
+	default:
+		users, err = s.List(ctx, site, WithMACPrefix(normalized))
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return nil, fmt.Errorf("user not found with MAC: %s", mac)
+	for i := range users {
+		if internal.NormalizeMAC(users[i].MAC) == normalized {
+			return &users[i], nil
+		}
+	}
+
+	return nil, notFoundf("user not found with MAC: %s", mac)
 }
 
 // Create creates a new user entry.
@@ -100,7 +139,7 @@ func (s *userService) Create(ctx context.Context, site string, user *types.User)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create user failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create user", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.User](resp.Body)
@@ -130,7 +169,7 @@ func (s *userService) Update(ctx context.Context, site string, user *types.User)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update user failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update user", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.User](resp.Body)
@@ -155,22 +194,28 @@ func (s *userService) Delete(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete user failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("user", resp)
 	}
 
 	return nil
 }
 
-// DeleteByMAC deletes a user by MAC address.
+// DeleteByMAC deletes a user by MAC address. Deleting a MAC with no user
+// entry is not an error.
 func (s *userService) DeleteByMAC(ctx context.Context, site, mac string) error {
-	// Find user by MAC first
-	user, err := s.GetByMAC(ctx, site, mac)
+	users, err := s.List(ctx, site)
 	if err != nil {
 		return err
 	}
 
-	return s.Delete(ctx, site, user.ID)
+	for _, user := range users {
+		if user.MAC == mac {
+			return s.Delete(ctx, site, user.ID)
+		}
+	}
+
+	return nil
 }
 
 // SetFixedIP assigns a fixed IP to a user.
@@ -222,7 +267,7 @@ func (s *userService) ClearFixedIP(ctx context.Context, site, mac string) error
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("clear fixed IP failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		return types.NewResponseError("clear fixed IP", resp.StatusCode, resp.Body)
 	}
 
 	return nil
@@ -239,7 +284,7 @@ func (s *userService) ListGroups(ctx context.Context, site string) ([]types.User
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list user groups failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list user groups", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.UserGroup](resp.Body)
@@ -262,9 +307,9 @@ func (s *userService) GetGroup(ctx context.Context, site, id string) (*types.Use
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("user group not found: %s", id)
+			return nil, notFoundf("user group not found: %s", id)
 		}
-		return nil, fmt.Errorf("get user group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get user group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.UserGroup](resp.Body)
@@ -273,7 +318,7 @@ func (s *userService) GetGroup(ctx context.Context, site, id string) (*types.Use
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("user group not found: %s", id)
+		return nil, notFoundf("user group not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -281,6 +326,10 @@ func (s *userService) GetGroup(ctx context.Context, site, id string) (*types.Use
 
 // CreateGroup creates a new user group.
 func (s *userService) CreateGroup(ctx context.Context, site string, group *types.UserGroup) (*types.UserGroup, error) {
+	if err := validate(ctx, "user group", group); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "usergroup", "")
 	req := transport.NewRequest("POST", path).WithBody(group)
 
@@ -290,7 +339,7 @@ func (s *userService) CreateGroup(ctx context.Context, site string, group *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create user group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create user group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.UserGroup](resp.Body)
@@ -307,6 +356,10 @@ func (s *userService) CreateGroup(ctx context.Context, site string, group *types
 
 // UpdateGroup updates an existing user group.
 func (s *userService) UpdateGroup(ctx context.Context, site string, group *types.UserGroup) (*types.UserGroup, error) {
+	if err := validate(ctx, "user group", group); err != nil {
+		return nil, err
+	}
+
 	if group.ID == "" {
 		return nil, fmt.Errorf("user group ID is required for update")
 	}
@@ -320,7 +373,7 @@ func (s *userService) UpdateGroup(ctx context.Context, site string, group *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update user group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update user group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.UserGroup](resp.Body)
@@ -345,8 +398,8 @@ func (s *userService) DeleteGroup(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete user group: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete user group failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("user group", resp)
 	}
 
 	return nil
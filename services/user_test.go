@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"crypto/tls"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/mock"
 	"github.com/unifi-go/gofi/transport"
 	"github.com/unifi-go/gofi/types"
@@ -49,6 +52,50 @@ func TestUserService_List(t *testing.T) {
 	}
 }
 
+func TestUserService_ListSearch(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddKnownClient(&types.User{ID: "user1", MAC: "aa:bb:cc:00:00:01", Name: "Kitchen Speaker", Hostname: "sonos-1"})
+	server.State().AddKnownClient(&types.User{ID: "user2", MAC: "aa:bb:cc:00:00:02", Name: "Office Printer", Note: "Toner ordered"})
+	server.State().AddKnownClient(&types.User{ID: "user3", MAC: "dd:ee:ff:00:00:03", Hostname: "SONOS-2"})
+
+	trans, _ := newTestUserTransport(server.URL())
+	svc := NewUserService(trans)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts []UserListOption
+		want []string
+	}{
+		{"name", []UserListOption{WithUserName("speaker")}, []string{"user1"}},
+		{"hostname", []UserListOption{WithHostname("sonos")}, []string{"user1", "user3"}},
+		{"note", []UserListOption{WithNote("TONER")}, []string{"user2"}},
+		{"oui", []UserListOption{WithMACPrefix("AA-BB-CC")}, []string{"user1", "user2"}},
+		{"full mac", []UserListOption{WithMACPrefix("aabbcc000002")}, []string{"user2"}},
+		{"combined", []UserListOption{WithMACPrefix("aa:bb:cc"), WithHostname("sonos")}, []string{"user1"}},
+		{"no match", []UserListOption{WithUserName("nobody")}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := svc.List(ctx, "default", tt.opts...)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			got := []string{}
+			for _, u := range users {
+				got = append(got, u.ID)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserService_GetByMAC(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -75,6 +122,59 @@ func TestUserService_GetByMAC(t *testing.T) {
 	}
 }
 
+func TestUserService_GetByMAC_Lookup(t *testing.T) {
+	listPath := internal.BuildRESTPath("default", "user", "")
+	lookupPath := internal.BuildAPIPath("default", "stat/user/aa:bb:cc:dd:ee:ff")
+
+	tests := []struct {
+		name     string
+		scenario *mock.ErrorScenario
+	}{
+		// Failing the list shows the direct lookup is used
+		{"direct lookup", &mock.ErrorScenario{Path: listPath, StatusCode: 500, RC: "error", Message: "api.err.Internal"}},
+		{"falls back to list", &mock.ErrorScenario{Path: lookupPath, StatusCode: 404, RC: "error", Message: "api.err.NotFound"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF(), mock.WithScenario(tt.scenario))
+			defer server.Close()
+			server.State().AddKnownClient(&types.User{ID: "user1", MAC: "aa:bb:cc:dd:ee:ff", Name: "Test User"})
+			server.State().AddKnownClient(&types.User{ID: "user2", MAC: "aa:bb:cc:dd:ee:01", Name: "Other"})
+
+			trans, _ := newTestUserTransport(server.URL())
+			svc := NewUserService(trans)
+
+			for _, mac := range []string{"aa:bb:cc:dd:ee:ff", "AA-BB-CC-DD-EE-FF", "aabbccddeeff"} {
+				user, err := svc.GetByMAC(context.Background(), "default", mac)
+				if err != nil {
+					t.Fatalf("GetByMAC(%q) error = %v", mac, err)
+				}
+				if user.ID != "user1" {
+					t.Errorf("GetByMAC(%q) = %s, want user1", mac, user.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestUserService_GetByMAC_NotFound(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestUserTransport(server.URL())
+	svc := NewUserService(trans)
+
+	if _, err := svc.GetByMAC(context.Background(), "default", "aa:bb:cc:dd:ee:ff"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("GetByMAC() of an unknown MAC error = %v, want not found", err)
+	}
+	for _, mac := range []string{"aa:bb", "zzzzzzzzzzzz"} {
+		if _, err := svc.GetByMAC(context.Background(), "default", mac); err == nil || !strings.Contains(err.Error(), "invalid MAC") {
+			t.Errorf("GetByMAC(%q) error = %v, want invalid MAC", mac, err)
+		}
+	}
+}
+
 func TestUserService_Create(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -196,3 +296,110 @@ func TestUserService_CreateGroup(t *testing.T) {
 		t.Error("Expected ID to be generated")
 	}
 }
+
+func TestUserService_FindDuplicatesAndMerge(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddKnownClient(&types.User{ID: "u1", MAC: "aa:bb:cc:dd:ee:01", FirstSeen: types.NewFlexTime(200), Note: "desk"})
+	server.State().AddKnownClient(&types.User{ID: "u2", MAC: "AA-BB-CC-DD-EE-01", FirstSeen: types.NewFlexTime(100), Name: "printer",
+		UseFixedIP: true, FixedIP: "192.168.1.50", NetworkID: "net1", Note: "floor 2"})
+	server.State().AddKnownClient(&types.User{ID: "u3", MAC: "aa:bb:cc:dd:ee:02", Name: "laptop"})
+	server.State().AddKnownClient(&types.User{ID: "u4", MAC: "aa:bb:cc:dd:ee:02", UseFixedIP: true, FixedIP: "192.168.1.60", NetworkID: "net1"})
+	server.State().AddKnownClient(&types.User{ID: "u5", MAC: "aa:bb:cc:dd:ee:02", UseFixedIP: true, FixedIP: "192.168.1.61", NetworkID: "net1"})
+
+	trans, _ := newTestUserTransport(server.URL())
+	svc := NewUserService(trans)
+	ctx := context.Background()
+
+	dups, err := svc.FindDuplicates(ctx, "default")
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(dups) != 2 || dups[0].MAC != "aa:bb:cc:dd:ee:01" || len(dups[0].Users) != 2 || dups[0].Users[0].ID != "u2" {
+		t.Fatalf("FindDuplicates() = %+v, want 2 sets with the oldest entry first", dups)
+	}
+
+	merged, err := svc.Merge(ctx, "default", "u1", []string{"u2"})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merged.Name != "printer" || !merged.UseFixedIP || merged.FixedIP != "192.168.1.50" ||
+		merged.FirstSeen.Unix() != 100 || merged.Note != "desk\nfloor 2" {
+		t.Errorf("Merge() = %+v, want name, fixed IP, first_seen and notes consolidated", merged)
+	}
+	if server.State().GetKnownClient("u2") != nil {
+		t.Error("dropped entry u2 still exists")
+	}
+
+	// Conflicting fixed IPs leave everything untouched.
+	if _, err := svc.Merge(ctx, "default", "u4", []string{"u5"}); err == nil {
+		t.Error("Merge() with conflicting fixed IPs succeeded, want error")
+	}
+	if server.State().GetKnownClient("u5") == nil {
+		t.Error("entry u5 deleted despite failed merge")
+	}
+
+	// Entries must share the kept entry's MAC.
+	if _, err := svc.Merge(ctx, "default", "u1", []string{"u3"}); err == nil {
+		t.Error("Merge() of a different MAC succeeded, want error")
+	}
+}
+
+func TestUserService_SetGroupLimits(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddUserGroup(&types.UserGroup{ID: "gold", Name: "Gold"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewUserService(trans)
+
+	group, err := svc.SetGroupLimits(context.Background(), "default", "gold", types.RateMbps(100), types.RateUnlimited)
+	if err != nil {
+		t.Fatalf("SetGroupLimits failed: %v", err)
+	}
+	if group.QOSRateMaxDown != 100000 || group.QOSRateMaxUp != types.RateUnlimited {
+		t.Errorf("limits = %v/%v, want 100 Mbps/unlimited", group.QOSRateMaxDown, group.QOSRateMaxUp)
+	}
+
+	if _, err := svc.SetGroupLimits(context.Background(), "default", "gold", -10, 0); err == nil {
+		t.Error("Expected validation error for negative limit")
+	}
+}
+
+func TestUserService_AssignGroup(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddUserGroup(&types.UserGroup{ID: "gold", Name: "Gold"})
+	server.State().AddKnownClient(&types.User{ID: "u1", MAC: "aa:bb:cc:dd:ee:01", Name: "laptop"})
+	server.State().AddKnownClient(&types.User{ID: "u2", MAC: "aa:bb:cc:dd:ee:02", UsergroupID: "gold"})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewUserService(trans)
+	ctx := context.Background()
+
+	report, err := svc.AssignGroup(ctx, "default", "gold", []string{"AA:BB:CC:DD:EE:01", "aa:bb:cc:dd:ee:02", "aa:bb:cc:dd:ee:03"})
+	if err != nil {
+		t.Fatalf("AssignGroup failed: %v", err)
+	}
+	if report.Assigned != 3 || report.Failed != 0 {
+		t.Errorf("report = %+v, want 3 assigned", report)
+	}
+	if r := report.Results; r[0].Created || r[0].Unchanged || !r[1].Unchanged || !r[2].Created {
+		t.Errorf("results = %+v", r)
+	}
+
+	if u := server.State().GetKnownClient("u1"); u.UsergroupID != "gold" || u.Name != "laptop" {
+		t.Errorf("u1 = %+v, want name kept and group gold", u)
+	}
+	created, err := svc.GetByMAC(ctx, "default", "aa:bb:cc:dd:ee:03")
+	if err != nil || created.UsergroupID != "gold" {
+		t.Errorf("created user = %+v, %v", created, err)
+	}
+
+	if _, err := svc.AssignGroup(ctx, "default", "missing", []string{"aa:bb:cc:dd:ee:01"}); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
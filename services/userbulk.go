@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+)
+
+// UpsertOption configures BulkUpsert.
+type UpsertOption func(*upsertOptions)
+
+// upsertOptions holds options for BulkUpsert.
+type upsertOptions struct {
+	parallelism int
+}
+
+// WithUpsertParallelism limits how many creates and updates are in flight
+// at once. The default is 4.
+func WithUpsertParallelism(n int) UpsertOption {
+	return func(opts *upsertOptions) {
+		opts.parallelism = n
+	}
+}
+
+// UpsertResult is the outcome of upserting one user.
+type UpsertResult struct {
+	MAC string
+
+	// Created is true if the controller had no user with the MAC and one
+	// was created.
+	Created bool
+
+	// Unchanged is true if the existing user already had every field set,
+	// so nothing was sent.
+	Unchanged bool
+
+	// User is the user as the controller returned it, or the existing one
+	// if it was unchanged.
+	User *types.User
+
+	Err error
+}
+
+// UpsertReport summarizes BulkUpsert, with results in the order of the
+// users passed in.
+type UpsertReport struct {
+	Results   []UpsertResult
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    int
+}
+
+// Err joins the errors of the failed upserts.
+func (r *UpsertReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.MAC, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BulkUpsert creates or updates users, matched by MAC address. A user the
+// controller does not know is created; a known one is updated with the
+// fields set in users, while fields left empty keep their values, and is
+// not sent at all if nothing would change. The site's users are listed
+// once up front. Invalid and repeated MAC addresses fail without a
+// request. The report covers every user; the returned error is the
+// report's Err.
+func (s *userService) BulkUpsert(ctx context.Context, site string, users []types.User, opts ...UpsertOption) (*UpsertReport, error) {
+	options := &upsertOptions{
+		parallelism: 4,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	existing, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	index := types.NewUserIndex(existing)
+
+	report := &UpsertReport{Results: make([]UpsertResult, len(users))}
+	seen := make(map[string]bool, len(users))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(options.parallelism, 1))
+	for i := range users {
+		res := &report.Results[i]
+		res.MAC = users[i].MAC
+
+		mac := internal.NormalizeMAC(users[i].MAC)
+		switch {
+		case !internal.ValidateMAC(users[i].MAC):
+			res.Err = fmt.Errorf("invalid MAC address: %s", users[i].MAC)
+			continue
+		case seen[mac]:
+			res.Err = fmt.Errorf("duplicate MAC address: %s", users[i].MAC)
+			continue
+		}
+		seen[mac] = true
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res *UpsertResult, desired, current *types.User) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res.User, res.Created, res.Unchanged, res.Err = s.upsert(ctx, site, desired, current)
+		}(res, &users[i], index.ByMAC(mac))
+	}
+	wg.Wait()
+
+	for _, res := range report.Results {
+		switch {
+		case res.Err != nil:
+			report.Failed++
+		case res.Created:
+			report.Created++
+		case res.Unchanged:
+			report.Unchanged++
+		default:
+			report.Updated++
+		}
+	}
+	return report, report.Err()
+}
+
+// upsert creates desired if current is nil, and otherwise updates current
+// with the fields desired sets.
+func (s *userService) upsert(ctx context.Context, site string, desired, current *types.User) (user *types.User, created, unchanged bool, err error) {
+	if current == nil {
+		fresh := desired.Clone()
+		fresh.ID = ""
+		fresh.MAC = internal.FormatMAC(desired.MAC)
+		user, err = s.Create(ctx, site, fresh)
+		return user, true, false, err
+	}
+
+	merged, err := mergeUser(current, desired)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if len(types.Diff(current, merged)) == 0 {
+		return current.Clone(), false, true, nil
+	}
+
+	user, err = s.Update(ctx, site, merged)
+	return user, false, false, err
+}
+
+// mergeUser returns a copy of current with the fields desired sets. Like
+// the JSON desired encodes to, it leaves out empty fields, so they keep
+// their current values.
+func mergeUser(current, desired *types.User) (*types.User, error) {
+	patch := desired.Clone()
+	patch.ID = current.ID
+	patch.MAC = current.MAC
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	merged := current.Clone()
+	if err := json.Unmarshal(body, merged); err != nil {
+		return nil, fmt.Errorf("failed to merge user: %w", err)
+	}
+	return merged, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestUserService_BulkUpsert(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+	server.State().AddKnownClient(&types.User{ID: "user1", MAC: "aa:bb:cc:dd:ee:01", Name: "printer", Note: "2nd floor"})
+	server.State().AddKnownClient(&types.User{ID: "user2", MAC: "aa:bb:cc:dd:ee:02", Name: "nas"})
+
+	trans, _ := newTestUserTransport(server.URL())
+	svc := NewUserService(trans)
+	ctx := context.Background()
+
+	report, err := svc.BulkUpsert(ctx, "default", []types.User{
+		{MAC: "AA-BB-CC-DD-EE-01", Name: "printer", UseFixedIP: true, FixedIP: "192.168.1.50"},
+		{MAC: "aa:bb:cc:dd:ee:02", Name: "nas"},
+		{MAC: "aa:bb:cc:dd:ee:03", Name: "camera"},
+		{MAC: "not-a-mac"},
+		{MAC: "aa:bb:cc:dd:ee:03", Name: "camera again"},
+	}, WithUpsertParallelism(2))
+	if err == nil {
+		t.Error("BulkUpsert() error = nil, want the invalid and duplicate MACs")
+	}
+
+	want := []struct {
+		created, unchanged, failed bool
+	}{
+		{false, false, false},
+		{false, true, false},
+		{true, false, false},
+		{false, false, true},
+		{false, false, true},
+	}
+	for i, w := range want {
+		res := report.Results[i]
+		if res.Created != w.created || res.Unchanged != w.unchanged || (res.Err != nil) != w.failed {
+			t.Errorf("Results[%d] = %+v, want created %v, unchanged %v, failed %v", i, res, w.created, w.unchanged, w.failed)
+		}
+	}
+	if report.Created != 1 || report.Updated != 1 || report.Unchanged != 1 || report.Failed != 2 {
+		t.Errorf("report = %d created, %d updated, %d unchanged, %d failed, want 1, 1, 1, 2",
+			report.Created, report.Updated, report.Unchanged, report.Failed)
+	}
+
+	// The update keeps the fields it did not set
+	printer := server.State().GetKnownClient("user1")
+	if printer.FixedIP != "192.168.1.50" || printer.Note != "2nd floor" {
+		t.Errorf("updated user = %+v, want fixed IP set and note kept", printer)
+	}
+
+	camera, err := svc.GetByMAC(ctx, "default", "aa:bb:cc:dd:ee:03")
+	if err != nil || camera.Name != "camera" {
+		t.Errorf("created user = %+v, %v", camera, err)
+	}
+}
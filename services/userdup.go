@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+)
+
+// DuplicateUsers is a set of user entries that share one MAC address.
+type DuplicateUsers struct {
+	// MAC is the shared address, normalized to lowercase with colons.
+	MAC string
+
+	// Users are the entries, oldest (by first_seen) first.
+	Users []types.User
+}
+
+// FindDuplicates returns the MAC addresses that have more than one user
+// entry, sorted by MAC. MACs are compared after normalization, so entries
+// differing only in case or separators count as duplicates.
+func (s *userService) FindDuplicates(ctx context.Context, site string) ([]DuplicateUsers, error) {
+	users, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	byMAC := make(map[string][]types.User)
+	for _, u := range users {
+		mac := internal.FormatMAC(u.MAC)
+		byMAC[mac] = append(byMAC[mac], u)
+	}
+
+	var dups []DuplicateUsers
+	for mac, list := range byMAC {
+		if len(list) < 2 {
+			continue
+		}
+		sort.SliceStable(list, func(i, j int) bool { return list[i].FirstSeen.Val.Before(list[j].FirstSeen.Val) })
+		dups = append(dups, DuplicateUsers{MAC: mac, Users: list})
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].MAC < dups[j].MAC })
+	return dups, nil
+}
+
+// Merge folds the user entries dropIDs into keepID and deletes them. All
+// entries must exist and share keepID's MAC address. The kept entry takes
+// the first name, user group and fixed IP of a dropped entry where it has
+// none, the earliest first_seen, and every distinct note. Nothing is
+// changed if dropped entries have fixed IPs that conflict with each other
+// or with the kept entry. The kept entry is updated before the others are
+// deleted, so a failed delete loses no data and can be retried.
+func (s *userService) Merge(ctx context.Context, site, keepID string, dropIDs []string) (*types.User, error) {
+	users, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]types.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	keep, ok := byID[keepID]
+	if !ok {
+		return nil, notFoundf("user not found: %s", keepID)
+	}
+	mac := internal.FormatMAC(keep.MAC)
+
+	var drops []types.User
+	for _, id := range dropIDs {
+		drop, ok := byID[id]
+		switch {
+		case id == keepID:
+			return nil, fmt.Errorf("cannot merge user %s into itself", id)
+		case !ok:
+			return nil, notFoundf("user not found: %s", id)
+		case internal.FormatMAC(drop.MAC) != mac:
+			return nil, fmt.Errorf("user %s has MAC %s, not %s", id, drop.MAC, keep.MAC)
+		}
+		drops = append(drops, drop)
+	}
+
+	merged := keep
+	notes := []string{}
+	if keep.Note != "" {
+		notes = append(notes, keep.Note)
+	}
+	for _, d := range drops {
+		if merged.Name == "" {
+			merged.Name = d.Name
+		}
+		if merged.UsergroupID == "" {
+			merged.UsergroupID = d.UsergroupID
+		}
+		if !d.FirstSeen.IsZero() && (merged.FirstSeen.IsZero() || d.FirstSeen.Val.Before(merged.FirstSeen.Val)) {
+			merged.FirstSeen = d.FirstSeen
+		}
+		if d.Note != "" && !slices.Contains(notes, d.Note) {
+			notes = append(notes, d.Note)
+		}
+
+		if !d.UseFixedIP {
+			continue
+		}
+		if !merged.UseFixedIP {
+			merged.UseFixedIP, merged.FixedIP, merged.NetworkID = true, d.FixedIP, d.NetworkID
+		} else if merged.FixedIP != d.FixedIP || merged.NetworkID != d.NetworkID {
+			return nil, fmt.Errorf("conflicting fixed IPs for %s: %s and %s (user %s)", mac, merged.FixedIP, d.FixedIP, d.ID)
+		}
+	}
+	merged.Note = strings.Join(notes, "\n")
+	merged.Noted = merged.Note != ""
+
+	updated, err := s.Update(ctx, site, &merged)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range drops {
+		if err := s.Delete(ctx, site, d.ID); err != nil {
+			return updated, fmt.Errorf("merged into %s but failed to delete %s: %w", keepID, d.ID, err)
+		}
+	}
+	return updated, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// SetGroupLimits sets a user group's download and upload limits. Pass
+// types.RateUnlimited to remove a limit.
+func (s *userService) SetGroupLimits(ctx context.Context, site, groupID string, down, up types.RateLimit) (*types.UserGroup, error) {
+	group, err := s.GetGroup(ctx, site, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group.QOSRateMaxDown = down
+	group.QOSRateMaxUp = up
+	return s.UpdateGroup(ctx, site, group)
+}
+
+// GroupAssignment is the outcome of assigning one client to a user group.
+type GroupAssignment struct {
+	MAC string
+
+	// Created is true if the client had no user entry and one was created.
+	Created bool
+
+	// Unchanged is true if the client was already in the group.
+	Unchanged bool
+
+	Err error
+}
+
+// AssignGroupReport summarizes AssignGroup, with results in the order of
+// the MAC addresses passed in.
+type AssignGroupReport struct {
+	Results  []GroupAssignment
+	Assigned int
+	Failed   int
+}
+
+// Err joins the errors of the failed assignments.
+func (r *AssignGroupReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.MAC, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AssignGroup moves clients into a user group, creating user entries for
+// clients the controller does not know yet. Pass an empty groupID to
+// return the clients to the default group. The report covers every
+// client; the returned error is the report's Err.
+func (s *userService) AssignGroup(ctx context.Context, site, groupID string, macs []string) (*AssignGroupReport, error) {
+	if groupID != "" {
+		if _, err := s.GetGroup(ctx, site, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := s.List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	byMAC := make(map[string]*types.User, len(users))
+	for i := range users {
+		byMAC[strings.ToLower(users[i].MAC)] = &users[i]
+	}
+
+	report := &AssignGroupReport{Results: make([]GroupAssignment, len(macs))}
+	for i, mac := range macs {
+		res := &report.Results[i]
+		res.MAC = strings.ToLower(mac)
+
+		switch user := byMAC[res.MAC]; {
+		case user == nil:
+			res.Created = true
+			byMAC[res.MAC], res.Err = s.Create(ctx, site, &types.User{MAC: res.MAC, UsergroupID: groupID})
+		case user.UsergroupID == groupID:
+			res.Unchanged = true
+		default:
+			user.UsergroupID = groupID
+			_, res.Err = s.Update(ctx, site, user)
+		}
+
+		if res.Err != nil {
+			report.Failed++
+		} else {
+			report.Assigned++
+		}
+	}
+
+	return report, report.Err()
+}
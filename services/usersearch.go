@@ -0,0 +1,45 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/types"
+)
+
+// query returns the filters the controller applies itself. Its REST
+// endpoints only filter on exact field values, so only a complete MAC
+// address is sent; everything else is matched by filter.
+func (o *userListOptions) query() url.Values {
+	query := url.Values{}
+	if len(internal.NormalizeMAC(o.macPrefix)) == 12 {
+		query.Set("mac", internal.FormatMAC(o.macPrefix))
+	}
+	return query
+}
+
+// filter returns the users matching the options. A MAC prefix is looked
+// up in a types.UserIndex; the other options are checked on what remains.
+// The controller may have filtered already or ignored the query, so users
+// are always checked.
+func (o *userListOptions) filter(users []types.User) []types.User {
+	if o.macPrefix != "" {
+		users = types.NewUserIndex(users).WithMACPrefix(o.macPrefix)
+	}
+
+	matched := []types.User{}
+	for i := range users {
+		u := &users[i]
+		if containsFold(u.Name, o.name) && containsFold(u.Hostname, o.hostname) && containsFold(u.Note, o.note) {
+			matched = append(matched, *u)
+		}
+	}
+	return matched
+}
+
+// containsFold reports whether substr is within s, ignoring case. An
+// empty substr matches anything.
+func containsFold(s, substr string) bool {
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
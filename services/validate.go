@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// skipValidationKey is the context key set by WithoutValidation.
+type skipValidationKey struct{}
+
+// WithoutValidation returns a context that disables the local Validate()
+// check services run before create and update requests. Use it when the
+// controller accepts a value the library does not know about yet. The
+// Enable and Disable helpers use it too, as they only toggle a stored object.
+func WithoutValidation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipValidationKey{}, true)
+}
+
+// validationSkipped reports whether ctx was derived from WithoutValidation.
+func validationSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipValidationKey{}).(bool)
+	return skip
+}
+
+// validate runs v.Validate unless disabled for ctx. The returned error wraps
+// the types.ValidationErrors so callers can inspect individual fields.
+func validate(ctx context.Context, what string, v types.Validator) error {
+	if validationSkipped(ctx) {
+		return nil
+	}
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("invalid %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestValidate_RejectsBeforeRequest(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewNetworkService(trans)
+
+	_, err := svc.Create(context.Background(), "default", &types.Network{Name: "Bad", VLANEnabled: true, VLAN: 5000})
+	if err == nil {
+		t.Fatal("Create should reject an invalid network")
+	}
+
+	var verr *types.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "vlan" {
+		t.Errorf("error = %v, want validation error for vlan", err)
+	}
+
+	if networks := server.State().ListNetworks(); len(networks) != 0 {
+		t.Errorf("invalid network reached the controller: %d networks", len(networks))
+	}
+}
+
+func TestWithoutValidation(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewPortForwardService(trans)
+
+	forward := &types.PortForward{Name: "Raw", Protocol: "tcp", DstPort: "443"}
+
+	if _, err := svc.Create(context.Background(), "default", forward); err == nil {
+		t.Fatal("Create should reject a port forward without a target")
+	}
+
+	created, err := svc.Create(WithoutValidation(context.Background()), "default", forward)
+	if err != nil {
+		t.Fatalf("Create with WithoutValidation failed: %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected ID to be generated")
+	}
+}
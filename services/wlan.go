@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/unifi-go/gofi/internal"
 	"github.com/unifi-go/gofi/transport"
@@ -32,7 +33,7 @@ func (s *wlanService) List(ctx context.Context, site string) ([]types.WLAN, erro
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list WLANs failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list WLANs", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLAN](resp.Body)
@@ -55,9 +56,9 @@ func (s *wlanService) Get(ctx context.Context, site, id string) (*types.WLAN, er
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("WLAN not found: %s", id)
+			return nil, notFoundf("WLAN not found: %s", id)
 		}
-		return nil, fmt.Errorf("get WLAN failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get WLAN", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLAN](resp.Body)
@@ -66,7 +67,7 @@ func (s *wlanService) Get(ctx context.Context, site, id string) (*types.WLAN, er
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("WLAN not found: %s", id)
+		return nil, notFoundf("WLAN not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -74,6 +75,10 @@ func (s *wlanService) Get(ctx context.Context, site, id string) (*types.WLAN, er
 
 // Create creates a new WLAN.
 func (s *wlanService) Create(ctx context.Context, site string, wlan *types.WLAN) (*types.WLAN, error) {
+	if err := validate(ctx, "WLAN", wlan); err != nil {
+		return nil, err
+	}
+
 	path := internal.BuildRESTPath(site, "wlanconf", "")
 	req := transport.NewRequest("POST", path).WithBody(wlan)
 
@@ -83,7 +88,7 @@ func (s *wlanService) Create(ctx context.Context, site string, wlan *types.WLAN)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create WLAN failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create WLAN", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLAN](resp.Body)
@@ -100,6 +105,10 @@ func (s *wlanService) Create(ctx context.Context, site string, wlan *types.WLAN)
 
 // Update updates an existing WLAN.
 func (s *wlanService) Update(ctx context.Context, site string, wlan *types.WLAN) (*types.WLAN, error) {
+	if err := validate(ctx, "WLAN", wlan); err != nil {
+		return nil, err
+	}
+
 	if wlan.ID == "" {
 		return nil, fmt.Errorf("WLAN ID is required for update")
 	}
@@ -113,7 +122,7 @@ func (s *wlanService) Update(ctx context.Context, site string, wlan *types.WLAN)
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update WLAN failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update WLAN", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLAN](resp.Body)
@@ -138,8 +147,8 @@ func (s *wlanService) Delete(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete WLAN: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete WLAN failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("WLAN", resp)
 	}
 
 	return nil
@@ -153,7 +162,7 @@ func (s *wlanService) Enable(ctx context.Context, site, id string) error {
 	}
 
 	wlan.Enabled = true
-	_, err = s.Update(ctx, site, wlan)
+	_, err = s.Update(WithoutValidation(ctx), site, wlan)
 	return err
 }
 
@@ -165,7 +174,7 @@ func (s *wlanService) Disable(ctx context.Context, site, id string) error {
 	}
 
 	wlan.Enabled = false
-	_, err = s.Update(ctx, site, wlan)
+	_, err = s.Update(WithoutValidation(ctx), site, wlan)
 	return err
 }
 
@@ -184,6 +193,47 @@ func (s *wlanService) SetMACFilter(ctx context.Context, site, id, policy string,
 	return err
 }
 
+// ConnectedClients returns the active wireless clients on a WLAN. Clients
+// are matched by ESSID. When the access points report which BSSIDs
+// broadcast the WLAN, a client's BSSID must also be one of them, which
+// separates WLANs that share an SSID.
+func (s *wlanService) ConnectedClients(ctx context.Context, site, id string) ([]types.Client, error) {
+	wlan, err := s.Get(ctx, site, id)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := NewDeviceService(s.transport).List(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	bssids := make(map[string]bool)
+	for _, d := range devices {
+		for _, vap := range d.VAPTable {
+			if vap.BSSID != "" && vap.WlanconfID == wlan.ID {
+				bssids[strings.ToLower(vap.BSSID)] = true
+			}
+		}
+	}
+
+	clients, err := NewClientService(s.transport).ListActive(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	var connected []types.Client
+	for _, c := range clients {
+		if c.IsWired.Val || c.ESSID != wlan.Name {
+			continue
+		}
+		if len(bssids) > 0 && c.BSSID != "" && !bssids[strings.ToLower(c.BSSID)] {
+			continue
+		}
+		connected = append(connected, c)
+	}
+	return connected, nil
+}
+
 // ListGroups returns all WLAN groups for a site.
 func (s *wlanService) ListGroups(ctx context.Context, site string) ([]types.WLANGroup, error) {
 	path := internal.BuildRESTPath(site, "wlangroup", "")
@@ -195,7 +245,7 @@ func (s *wlanService) ListGroups(ctx context.Context, site string) ([]types.WLAN
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("list WLAN groups failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("list WLAN groups", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLANGroup](resp.Body)
@@ -218,9 +268,9 @@ func (s *wlanService) GetGroup(ctx context.Context, site, id string) (*types.WLA
 
 	if !resp.IsSuccess() {
 		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("WLAN group not found: %s", id)
+			return nil, notFoundf("WLAN group not found: %s", id)
 		}
-		return nil, fmt.Errorf("get WLAN group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("get WLAN group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLANGroup](resp.Body)
@@ -229,7 +279,7 @@ func (s *wlanService) GetGroup(ctx context.Context, site, id string) (*types.WLA
 	}
 
 	if len(apiResp.Data) == 0 {
-		return nil, fmt.Errorf("WLAN group not found: %s", id)
+		return nil, notFoundf("WLAN group not found: %s", id)
 	}
 
 	return &apiResp.Data[0], nil
@@ -246,7 +296,7 @@ func (s *wlanService) CreateGroup(ctx context.Context, site string, group *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("create WLAN group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("create WLAN group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLANGroup](resp.Body)
@@ -276,7 +326,7 @@ func (s *wlanService) UpdateGroup(ctx context.Context, site string, group *types
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("update WLAN group failed with status %d", resp.StatusCode)
+		return nil, types.NewResponseError("update WLAN group", resp.StatusCode, resp.Body)
 	}
 
 	apiResp, err := internal.ParseAPIResponse[types.WLANGroup](resp.Body)
@@ -301,8 +351,8 @@ func (s *wlanService) DeleteGroup(ctx context.Context, site, id string) error {
 		return fmt.Errorf("failed to delete WLAN group: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("delete WLAN group failed with status %d", resp.StatusCode)
+	if !resp.IsSuccess() && !alreadyDeleted(resp) {
+		return deleteFailed("WLAN group", resp)
 	}
 
 	return nil
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/unifi-go/gofi/mock"
 	"github.com/unifi-go/gofi/types"
@@ -291,6 +294,49 @@ func TestWLANService_SetMACFilter(t *testing.T) {
 	}
 }
 
+func TestWLANService_ConnectedClients(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	// Two WLANs broadcast the same SSID from different APs
+	server.State().AddWLAN(&types.WLAN{ID: "wlan1", Name: "Office", Enabled: true})
+	server.State().AddWLAN(&types.WLAN{ID: "wlan2", Name: "Office", Enabled: true})
+	server.State().AddDevice(&types.Device{ID: "ap1", MAC: "aa:00:00:00:00:01", Type: "uap",
+		VAPTable: []types.VAPTable{
+			{BSSID: "AA:00:00:00:10:01", Essid: "Office", WlanconfID: "wlan1"},
+			{BSSID: "aa:00:00:00:10:02", Essid: "Office", WlanconfID: "wlan2"},
+		}})
+
+	now := time.Now().Unix()
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:01", ESSID: "Office", BSSID: "aa:00:00:00:10:01", LastSeen: types.NewFlexTime(now)})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:02", ESSID: "Office", BSSID: "aa:00:00:00:10:02", LastSeen: types.NewFlexTime(now)})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:03", ESSID: "Office", LastSeen: types.NewFlexTime(now)})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:04", ESSID: "Guest", LastSeen: types.NewFlexTime(now)})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:05", IsWired: types.FlexBool{Val: true}, LastSeen: types.NewFlexTime(now)})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewWLANService(trans)
+
+	clients, err := svc.ConnectedClients(context.Background(), "default", "wlan1")
+	if err != nil {
+		t.Fatalf("ConnectedClients failed: %v", err)
+	}
+
+	// The client on wlan2's BSSID is excluded; the one without a BSSID is kept
+	var macs []string
+	for _, c := range clients {
+		macs = append(macs, c.MAC)
+	}
+	sort.Strings(macs)
+	if want := []string{"cc:00:00:00:00:01", "cc:00:00:00:00:03"}; !reflect.DeepEqual(macs, want) {
+		t.Errorf("ConnectedClients() = %v, want %v", macs, want)
+	}
+
+	if _, err := svc.ConnectedClients(context.Background(), "default", "missing"); err == nil {
+		t.Error("Expected error for unknown WLAN")
+	}
+}
+
 func TestWLANService_ListGroups(t *testing.T) {
 	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
 	defer server.Close()
@@ -442,3 +488,62 @@ func TestWLANService_DeleteGroup(t *testing.T) {
 		t.Error("Expected WLAN group to be deleted")
 	}
 }
+
+func TestWLANService_EnableOnAPs(t *testing.T) {
+	server := mock.NewServer(mock.WithoutAuth(), mock.WithoutCSRF())
+	defer server.Close()
+
+	server.State().AddWLAN(&types.WLAN{
+		ID:       "wlan1",
+		Name:     "Lobby",
+		Enabled:  true,
+		Security: types.SecurityTypeOpen,
+	})
+	server.State().AddAPGroup(&types.APGroup{
+		ID:         "existing",
+		Name:       "Upstairs",
+		DeviceMACs: []string{"aa:bb:cc:00:00:02", "aa:bb:cc:00:00:03"},
+	})
+
+	trans, _ := newTestTransport(server.URL())
+	svc := NewWLANService(trans)
+	ctx := context.Background()
+
+	// A new set of APs gets a managed group.
+	wlan, err := svc.EnableOnAPs(ctx, "default", "wlan1", []string{"AA:BB:CC:00:00:01", "aa:bb:cc:00:00:01"})
+	if err != nil {
+		t.Fatalf("EnableOnAPs failed: %v", err)
+	}
+	if wlan.APGroupMode != types.APGroupModeGroups || len(wlan.APGroupIDs) != 1 {
+		t.Fatalf("WLAN groups = %q %v, want one group", wlan.APGroupMode, wlan.APGroupIDs)
+	}
+	managed := server.State().GetAPGroup(wlan.APGroupIDs[0])
+	if managed == nil || managed.Name != "gofi: Lobby" || !reflect.DeepEqual(managed.DeviceMACs, []string{"aa:bb:cc:00:00:01"}) {
+		t.Fatalf("managed group = %+v", managed)
+	}
+
+	// Changing the APs updates the managed group in place.
+	wlan, err = svc.EnableOnAPs(ctx, "default", "wlan1", []string{"aa:bb:cc:00:00:04", "aa:bb:cc:00:00:01"})
+	if err != nil {
+		t.Fatalf("EnableOnAPs failed: %v", err)
+	}
+	if wlan.APGroupIDs[0] != managed.ID || len(server.State().ListAPGroups()) != 2 {
+		t.Errorf("managed group not reused: %v", wlan.APGroupIDs)
+	}
+	if got := server.State().GetAPGroup(managed.ID).DeviceMACs; !reflect.DeepEqual(got, []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:04"}) {
+		t.Errorf("managed group MACs = %v", got)
+	}
+
+	// A group with exactly the requested APs is bound as is.
+	wlan, err = svc.EnableOnAPs(ctx, "default", "wlan1", []string{"aa:bb:cc:00:00:03", "aa:bb:cc:00:00:02"})
+	if err != nil {
+		t.Fatalf("EnableOnAPs failed: %v", err)
+	}
+	if !reflect.DeepEqual(server.State().GetWLAN("wlan1").APGroupIDs, []string{"existing"}) {
+		t.Errorf("APGroupIDs = %v, want [existing]", wlan.APGroupIDs)
+	}
+
+	if _, err := svc.EnableOnAPs(ctx, "default", "wlan1", nil); err == nil {
+		t.Error("Expected error without AP MACs")
+	}
+}
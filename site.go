@@ -0,0 +1,1110 @@
+package gofi
+
+import (
+	"context"
+	"time"
+
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
+)
+
+// SiteClient provides service accessors bound to a single site.
+//
+// Every method mirrors its counterpart on the Client services with the site
+// parameter removed, so multi-tenant code cannot accidentally mix sites:
+//
+//	devices, err := client.Site("branch").Devices().List(ctx)
+type SiteClient interface {
+	// Name returns the site ID this client is bound to.
+	Name() string
+
+	// Site-level information
+	Health(ctx context.Context) ([]types.HealthData, error)
+	SysInfo(ctx context.Context) (*types.SysInfo, error)
+
+	// Service accessors
+	Devices() SiteDeviceService
+	Networks() SiteNetworkService
+	WLANs() SiteWLANService
+	Firewall() SiteFirewallService
+	Clients() SiteClientService
+	Users() SiteUserService
+	Routing() SiteRoutingService
+	PortForwards() SitePortForwardService
+	PortProfiles() SitePortProfileService
+	Settings() SiteSettingService
+	DNS() SiteDNSService
+	Hotspot() SiteHotspotService
+	Alarms() SiteAlarmService
+}
+
+// SiteDeviceService is a DeviceService bound to a single site.
+type SiteDeviceService interface {
+	List(ctx context.Context, opts ...services.DeviceListOption) ([]types.Device, error)
+	ListBasic(ctx context.Context, opts ...services.DeviceListOption) ([]types.DeviceBasic, error)
+	Get(ctx context.Context, id string) (*types.Device, error)
+	GetByMAC(ctx context.Context, mac string) (*types.Device, error)
+	Update(ctx context.Context, device *types.Device) (*types.Device, error)
+	Adopt(ctx context.Context, mac string) error
+	AdoptAdvanced(ctx context.Context, mac, url, username, password string) error
+	Forget(ctx context.Context, mac string) error
+	Restart(ctx context.Context, mac string) error
+	RestartMany(ctx context.Context, macs []string, opts ...services.RestartOption) (*services.RestartReport, error)
+	ForceProvision(ctx context.Context, mac string) error
+	Upgrade(ctx context.Context, mac string) error
+	UpgradeExternal(ctx context.Context, mac, url string) error
+	Locate(ctx context.Context, mac string) error
+	Unlocate(ctx context.Context, mac string) error
+	LocateFor(ctx context.Context, mac string, d time.Duration) error
+	PowerCyclePort(ctx context.Context, switchMAC string, portIdx int) error
+	SetLEDOverride(ctx context.Context, mac, mode string) error
+	SpectrumScan(ctx context.Context, mac string) error
+	WaitForProvision(ctx context.Context, mac, sinceCfgVersion string, opts ...services.ProvisionOption) (*types.Device, error)
+	Command(ctx context.Context, cmd services.DeviceCommand, params map[string]interface{}) error
+	Topology(ctx context.Context) (*types.TopologyGraph, error)
+	PoEBudget(ctx context.Context, mac string) (*types.PoEBudget, error)
+	CountByState(ctx context.Context) (map[types.DeviceState]int, error)
+	CountByType(ctx context.Context) (map[string]int, error)
+	Placements(ctx context.Context, mapID string) ([]types.APPlacement, error)
+	SetPlacement(ctx context.Context, mac string, placement types.APPlacement) error
+}
+
+// SiteNetworkService is a NetworkService bound to a single site.
+type SiteNetworkService interface {
+	List(ctx context.Context) ([]types.Network, error)
+	Get(ctx context.Context, id string) (*types.Network, error)
+	Create(ctx context.Context, network *types.Network) (*types.Network, error)
+	Update(ctx context.Context, network *types.Network) (*types.Network, error)
+	Delete(ctx context.Context, id string) error
+	ListWithUsage(ctx context.Context) ([]types.NetworkUsage, error)
+}
+
+// SiteWLANService is a WLANService bound to a single site.
+type SiteWLANService interface {
+	List(ctx context.Context) ([]types.WLAN, error)
+	Get(ctx context.Context, id string) (*types.WLAN, error)
+	Create(ctx context.Context, wlan *types.WLAN) (*types.WLAN, error)
+	Update(ctx context.Context, wlan *types.WLAN) (*types.WLAN, error)
+	Delete(ctx context.Context, id string) error
+	Enable(ctx context.Context, id string) error
+	Disable(ctx context.Context, id string) error
+	SetMACFilter(ctx context.Context, id, policy string, macs []string) error
+	ConnectedClients(ctx context.Context, id string) ([]types.Client, error)
+
+	ListGroups(ctx context.Context) ([]types.WLANGroup, error)
+	GetGroup(ctx context.Context, id string) (*types.WLANGroup, error)
+	CreateGroup(ctx context.Context, group *types.WLANGroup) (*types.WLANGroup, error)
+	UpdateGroup(ctx context.Context, group *types.WLANGroup) (*types.WLANGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+
+	ListAPGroups(ctx context.Context) ([]types.APGroup, error)
+	CreateAPGroup(ctx context.Context, group *types.APGroup) (*types.APGroup, error)
+	UpdateAPGroup(ctx context.Context, group *types.APGroup) (*types.APGroup, error)
+	DeleteAPGroup(ctx context.Context, id string) error
+	EnableOnAPs(ctx context.Context, wlanID string, apMACs []string) (*types.WLAN, error)
+}
+
+// SiteFirewallService is a FirewallService bound to a single site.
+type SiteFirewallService interface {
+	ListRules(ctx context.Context) ([]types.FirewallRule, error)
+	GetRule(ctx context.Context, id string) (*types.FirewallRule, error)
+	CreateRule(ctx context.Context, rule *types.FirewallRule) (*types.FirewallRule, error)
+	UpdateRule(ctx context.Context, rule *types.FirewallRule) (*types.FirewallRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	EnableRule(ctx context.Context, id string) error
+	DisableRule(ctx context.Context, id string) error
+	ReorderRules(ctx context.Context, ruleset string, updates []types.FirewallRuleIndexUpdate) error
+	ReorderRulesVerified(ctx context.Context, ruleset string, updates []types.FirewallRuleIndexUpdate, opts ...services.ReorderOption) (*services.ReorderReport, error)
+
+	ListGroups(ctx context.Context) ([]types.FirewallGroup, error)
+	GetGroup(ctx context.Context, id string) (*types.FirewallGroup, error)
+	CreateGroup(ctx context.Context, group *types.FirewallGroup) (*types.FirewallGroup, error)
+	UpdateGroup(ctx context.Context, group *types.FirewallGroup) (*types.FirewallGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+
+	ListTrafficRules(ctx context.Context) ([]types.TrafficRule, error)
+	GetTrafficRule(ctx context.Context, id string) (*types.TrafficRule, error)
+	CreateTrafficRule(ctx context.Context, rule *types.TrafficRule) (*types.TrafficRule, error)
+	UpdateTrafficRule(ctx context.Context, rule *types.TrafficRule) (*types.TrafficRule, error)
+	DeleteTrafficRule(ctx context.Context, id string) error
+
+	ListZones(ctx context.Context) ([]types.FirewallZone, error)
+	CreateZone(ctx context.Context, zone *types.FirewallZone) (*types.FirewallZone, error)
+	UpdateZone(ctx context.Context, zone *types.FirewallZone) (*types.FirewallZone, error)
+	DeleteZone(ctx context.Context, id string) error
+	GetZoneMatrix(ctx context.Context) (types.FirewallZoneMatrix, error)
+	ListPolicies(ctx context.Context) ([]types.FirewallPolicy, error)
+	GetPolicy(ctx context.Context, id string) (*types.FirewallPolicy, error)
+	CreatePolicy(ctx context.Context, policy *types.FirewallPolicy) (*types.FirewallPolicy, error)
+	UpdatePolicy(ctx context.Context, policy *types.FirewallPolicy) (*types.FirewallPolicy, error)
+	DeletePolicy(ctx context.Context, id string) error
+	ReorderPolicies(ctx context.Context, order *types.FirewallPolicyOrder) error
+}
+
+// SiteClientService is a ClientService bound to a single site.
+type SiteClientService interface {
+	ListActive(ctx context.Context) ([]types.Client, error)
+	ListAll(ctx context.Context, opts ...services.ClientListOption) ([]types.Client, error)
+	CountActive(ctx context.Context) (int, error)
+	Get(ctx context.Context, mac string) (*types.Client, error)
+	Block(ctx context.Context, mac string) error
+	Unblock(ctx context.Context, mac string) error
+	Kick(ctx context.Context, mac string) error
+	WakeOnLAN(ctx context.Context, mac string) error
+	AuthorizeGuest(ctx context.Context, mac string, opts ...services.GuestAuthOption) error
+	UnauthorizeGuest(ctx context.Context, mac string) error
+	ListGuests(ctx context.Context, opts ...services.ClientListOption) ([]types.Guest, error)
+	Forget(ctx context.Context, mac string) error
+	SetFingerprint(ctx context.Context, mac string, devID int) error
+}
+
+// SiteUserService is a UserService bound to a single site.
+type SiteUserService interface {
+	List(ctx context.Context, opts ...services.UserListOption) ([]types.User, error)
+	Get(ctx context.Context, id string) (*types.User, error)
+	GetByMAC(ctx context.Context, mac string) (*types.User, error)
+	Create(ctx context.Context, user *types.User) (*types.User, error)
+	Update(ctx context.Context, user *types.User) (*types.User, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByMAC(ctx context.Context, mac string) error
+	SetFixedIP(ctx context.Context, mac, ip, networkID string) error
+	ClearFixedIP(ctx context.Context, mac string) error
+	FindDuplicates(ctx context.Context) ([]services.DuplicateUsers, error)
+	Merge(ctx context.Context, keepID string, dropIDs []string) (*types.User, error)
+	BulkUpsert(ctx context.Context, users []types.User, opts ...services.UpsertOption) (*services.UpsertReport, error)
+
+	ListGroups(ctx context.Context) ([]types.UserGroup, error)
+	GetGroup(ctx context.Context, id string) (*types.UserGroup, error)
+	CreateGroup(ctx context.Context, group *types.UserGroup) (*types.UserGroup, error)
+	UpdateGroup(ctx context.Context, group *types.UserGroup) (*types.UserGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+	SetGroupLimits(ctx context.Context, groupID string, down, up types.RateLimit) (*types.UserGroup, error)
+	AssignGroup(ctx context.Context, groupID string, macs []string) (*services.AssignGroupReport, error)
+}
+
+// SiteRoutingService is a RoutingService bound to a single site.
+type SiteRoutingService interface {
+	List(ctx context.Context) ([]types.Route, error)
+	Get(ctx context.Context, id string) (*types.Route, error)
+	Create(ctx context.Context, route *types.Route) (*types.Route, error)
+	Update(ctx context.Context, route *types.Route) (*types.Route, error)
+	Delete(ctx context.Context, id string) error
+	Enable(ctx context.Context, id string) error
+	Disable(ctx context.Context, id string) error
+}
+
+// SitePortForwardService is a PortForwardService bound to a single site.
+type SitePortForwardService interface {
+	List(ctx context.Context) ([]types.PortForward, error)
+	Get(ctx context.Context, id string) (*types.PortForward, error)
+	Create(ctx context.Context, forward *types.PortForward) (*types.PortForward, error)
+	Update(ctx context.Context, forward *types.PortForward) (*types.PortForward, error)
+	Delete(ctx context.Context, id string) error
+	Enable(ctx context.Context, id string) error
+	Disable(ctx context.Context, id string) error
+	ValidateNoConflict(ctx context.Context, forward *types.PortForward) error
+}
+
+// SitePortProfileService is a PortProfileService bound to a single site.
+type SitePortProfileService interface {
+	List(ctx context.Context) ([]types.PortProfile, error)
+	Get(ctx context.Context, id string) (*types.PortProfile, error)
+	Create(ctx context.Context, profile *types.PortProfile) (*types.PortProfile, error)
+	Update(ctx context.Context, profile *types.PortProfile) (*types.PortProfile, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// SiteSettingService is a SettingService bound to a single site.
+type SiteSettingService interface {
+	Get(ctx context.Context, key string) (interface{}, error)
+	Update(ctx context.Context, setting interface{}) error
+
+	ListRadiusProfiles(ctx context.Context) ([]types.RADIUSProfile, error)
+	GetRadiusProfile(ctx context.Context, id string) (*types.RADIUSProfile, error)
+	CreateRadiusProfile(ctx context.Context, profile *types.RADIUSProfile) (*types.RADIUSProfile, error)
+	UpdateRadiusProfile(ctx context.Context, profile *types.RADIUSProfile) (*types.RADIUSProfile, error)
+	DeleteRadiusProfile(ctx context.Context, id string) error
+
+	GetDynamicDNS(ctx context.Context) (*types.DynamicDNS, error)
+	UpdateDynamicDNS(ctx context.Context, ddns *types.DynamicDNS) error
+	GetLCM(ctx context.Context) (*types.SettingLCM, error)
+	UpdateLCM(ctx context.Context, setting *types.SettingLCM) error
+	GetIPS(ctx context.Context) (*types.SettingIPS, error)
+	UpdateIPS(ctx context.Context, setting *types.SettingIPS) error
+	SetHoneypots(ctx context.Context, honeypots []types.Honeypot) error
+}
+
+// SiteDNSService is a DNSService bound to a single site.
+type SiteDNSService interface {
+	List(ctx context.Context) ([]types.DNSRecord, error)
+	Get(ctx context.Context, id string) (*types.DNSRecord, error)
+	GetByName(ctx context.Context, name string) (*types.DNSRecord, error)
+	GetByIP(ctx context.Context, ip string) ([]types.DNSRecord, error)
+	Create(ctx context.Context, record *types.DNSRecord) (*types.DNSRecord, error)
+	Update(ctx context.Context, record *types.DNSRecord) (*types.DNSRecord, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByName(ctx context.Context, name string) error
+}
+
+// SiteHotspotService is a HotspotService bound to a single site.
+type SiteHotspotService interface {
+	ListVouchers(ctx context.Context) ([]types.Voucher, error)
+	CreateVouchers(ctx context.Context, count, minutes int, opts ...services.VoucherOption) ([]types.Voucher, error)
+	RevokeVoucher(ctx context.Context, id string) error
+	ListOperators(ctx context.Context) ([]types.HotspotOperator, error)
+	CreateOperator(ctx context.Context, operator *types.HotspotOperator) (*types.HotspotOperator, error)
+	UpdateOperator(ctx context.Context, operator *types.HotspotOperator) (*types.HotspotOperator, error)
+	DeleteOperator(ctx context.Context, id string) error
+}
+
+// SiteAlarmService is an AlarmService bound to a single site.
+type SiteAlarmService interface {
+	List(ctx context.Context) ([]types.Alarm, error)
+	ListAll(ctx context.Context) ([]types.Alarm, error)
+	Archive(ctx context.Context, id string) error
+	ArchiveAll(ctx context.Context) error
+	ArchiveMatching(ctx context.Context, match func(*types.Alarm) bool) (int, error)
+}
+
+// siteClient implements SiteClient on top of a Client.
+type siteClient struct {
+	client Client
+	site   string
+}
+
+// Site returns service accessors bound to the given site.
+// An empty site selects the client's configured default site.
+func (c *client) Site(site string) SiteClient {
+	if site == "" {
+		site = c.config.Site
+	}
+	return &siteClient{client: c, site: site}
+}
+
+// Name returns the site ID.
+func (s *siteClient) Name() string { return s.site }
+
+// Health returns health data for the site.
+func (s *siteClient) Health(ctx context.Context) ([]types.HealthData, error) {
+	return s.client.Sites().Health(ctx, s.site)
+}
+
+// SysInfo returns system information for the site.
+func (s *siteClient) SysInfo(ctx context.Context) (*types.SysInfo, error) {
+	return s.client.Sites().SysInfo(ctx, s.site)
+}
+
+// Devices returns the site-scoped device service.
+func (s *siteClient) Devices() SiteDeviceService {
+	return &siteDevices{svc: s.client.Devices(), site: s.site}
+}
+
+// Networks returns the site-scoped network service.
+func (s *siteClient) Networks() SiteNetworkService {
+	return &siteNetworks{svc: s.client.Networks(), site: s.site}
+}
+
+// WLANs returns the site-scoped WLAN service.
+func (s *siteClient) WLANs() SiteWLANService {
+	return &siteWLANs{svc: s.client.WLANs(), site: s.site}
+}
+
+// Firewall returns the site-scoped firewall service.
+func (s *siteClient) Firewall() SiteFirewallService {
+	return &siteFirewall{svc: s.client.Firewall(), site: s.site}
+}
+
+// Clients returns the site-scoped client service.
+func (s *siteClient) Clients() SiteClientService {
+	return &siteClients{svc: s.client.Clients(), site: s.site}
+}
+
+// Users returns the site-scoped user service.
+func (s *siteClient) Users() SiteUserService {
+	return &siteUsers{svc: s.client.Users(), site: s.site}
+}
+
+// Routing returns the site-scoped routing service.
+func (s *siteClient) Routing() SiteRoutingService {
+	return &siteRouting{svc: s.client.Routing(), site: s.site}
+}
+
+// PortForwards returns the site-scoped port forward service.
+func (s *siteClient) PortForwards() SitePortForwardService {
+	return &sitePortForwards{svc: s.client.PortForwards(), site: s.site}
+}
+
+// PortProfiles returns the site-scoped port profile service.
+func (s *siteClient) PortProfiles() SitePortProfileService {
+	return &sitePortProfiles{svc: s.client.PortProfiles(), site: s.site}
+}
+
+// Settings returns the site-scoped settings service.
+func (s *siteClient) Settings() SiteSettingService {
+	return &siteSettings{svc: s.client.Settings(), site: s.site}
+}
+
+// DNS returns the site-scoped DNS service.
+func (s *siteClient) DNS() SiteDNSService {
+	return &siteDNS{svc: s.client.DNS(), site: s.site}
+}
+
+// Hotspot returns the site-scoped hotspot service.
+func (s *siteClient) Hotspot() SiteHotspotService {
+	return &siteHotspot{svc: s.client.Hotspot(), site: s.site}
+}
+
+// Alarms returns the site-scoped alarm service.
+func (s *siteClient) Alarms() SiteAlarmService {
+	return &siteAlarms{svc: s.client.Alarms(), site: s.site}
+}
+
+// siteDevices binds a DeviceService to a site.
+type siteDevices struct {
+	svc  services.DeviceService
+	site string
+}
+
+func (s *siteDevices) List(ctx context.Context, opts ...services.DeviceListOption) ([]types.Device, error) {
+	return s.svc.List(ctx, s.site, opts...)
+}
+
+func (s *siteDevices) ListBasic(ctx context.Context, opts ...services.DeviceListOption) ([]types.DeviceBasic, error) {
+	return s.svc.ListBasic(ctx, s.site, opts...)
+}
+
+func (s *siteDevices) Get(ctx context.Context, id string) (*types.Device, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteDevices) GetByMAC(ctx context.Context, mac string) (*types.Device, error) {
+	return s.svc.GetByMAC(ctx, s.site, mac)
+}
+
+func (s *siteDevices) Update(ctx context.Context, device *types.Device) (*types.Device, error) {
+	return s.svc.Update(ctx, s.site, device)
+}
+
+func (s *siteDevices) Adopt(ctx context.Context, mac string) error {
+	return s.svc.Adopt(ctx, s.site, mac)
+}
+
+func (s *siteDevices) AdoptAdvanced(ctx context.Context, mac, url, username, password string) error {
+	return s.svc.AdoptAdvanced(ctx, s.site, mac, url, username, password)
+}
+
+func (s *siteDevices) Forget(ctx context.Context, mac string) error {
+	return s.svc.Forget(ctx, s.site, mac)
+}
+
+func (s *siteDevices) Restart(ctx context.Context, mac string) error {
+	return s.svc.Restart(ctx, s.site, mac)
+}
+
+func (s *siteDevices) RestartMany(ctx context.Context, macs []string, opts ...services.RestartOption) (*services.RestartReport, error) {
+	return s.svc.RestartMany(ctx, s.site, macs, opts...)
+}
+
+func (s *siteDevices) ForceProvision(ctx context.Context, mac string) error {
+	return s.svc.ForceProvision(ctx, s.site, mac)
+}
+
+func (s *siteDevices) Upgrade(ctx context.Context, mac string) error {
+	return s.svc.Upgrade(ctx, s.site, mac)
+}
+
+func (s *siteDevices) UpgradeExternal(ctx context.Context, mac, url string) error {
+	return s.svc.UpgradeExternal(ctx, s.site, mac, url)
+}
+
+func (s *siteDevices) Locate(ctx context.Context, mac string) error {
+	return s.svc.Locate(ctx, s.site, mac)
+}
+
+func (s *siteDevices) Unlocate(ctx context.Context, mac string) error {
+	return s.svc.Unlocate(ctx, s.site, mac)
+}
+
+func (s *siteDevices) LocateFor(ctx context.Context, mac string, d time.Duration) error {
+	return s.svc.LocateFor(ctx, s.site, mac, d)
+}
+
+func (s *siteDevices) PowerCyclePort(ctx context.Context, switchMAC string, portIdx int) error {
+	return s.svc.PowerCyclePort(ctx, s.site, switchMAC, portIdx)
+}
+
+func (s *siteDevices) SetLEDOverride(ctx context.Context, mac, mode string) error {
+	return s.svc.SetLEDOverride(ctx, s.site, mac, mode)
+}
+
+func (s *siteDevices) SpectrumScan(ctx context.Context, mac string) error {
+	return s.svc.SpectrumScan(ctx, s.site, mac)
+}
+
+func (s *siteDevices) WaitForProvision(ctx context.Context, mac, sinceCfgVersion string, opts ...services.ProvisionOption) (*types.Device, error) {
+	return s.svc.WaitForProvision(ctx, s.site, mac, sinceCfgVersion, opts...)
+}
+
+func (s *siteDevices) Command(ctx context.Context, cmd services.DeviceCommand, params map[string]interface{}) error {
+	return s.svc.Command(ctx, s.site, cmd, params)
+}
+
+func (s *siteDevices) Topology(ctx context.Context) (*types.TopologyGraph, error) {
+	return s.svc.Topology(ctx, s.site)
+}
+
+func (s *siteDevices) PoEBudget(ctx context.Context, mac string) (*types.PoEBudget, error) {
+	return s.svc.PoEBudget(ctx, s.site, mac)
+}
+
+func (s *siteDevices) CountByState(ctx context.Context) (map[types.DeviceState]int, error) {
+	return s.svc.CountByState(ctx, s.site)
+}
+
+func (s *siteDevices) CountByType(ctx context.Context) (map[string]int, error) {
+	return s.svc.CountByType(ctx, s.site)
+}
+
+func (s *siteDevices) Placements(ctx context.Context, mapID string) ([]types.APPlacement, error) {
+	return s.svc.Placements(ctx, s.site, mapID)
+}
+
+func (s *siteDevices) SetPlacement(ctx context.Context, mac string, placement types.APPlacement) error {
+	return s.svc.SetPlacement(ctx, s.site, mac, placement)
+}
+
+// siteNetworks binds a NetworkService to a site.
+type siteNetworks struct {
+	svc  services.NetworkService
+	site string
+}
+
+func (s *siteNetworks) List(ctx context.Context) ([]types.Network, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *siteNetworks) Get(ctx context.Context, id string) (*types.Network, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteNetworks) Create(ctx context.Context, network *types.Network) (*types.Network, error) {
+	return s.svc.Create(ctx, s.site, network)
+}
+
+func (s *siteNetworks) Update(ctx context.Context, network *types.Network) (*types.Network, error) {
+	return s.svc.Update(ctx, s.site, network)
+}
+
+func (s *siteNetworks) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *siteNetworks) ListWithUsage(ctx context.Context) ([]types.NetworkUsage, error) {
+	return s.svc.ListWithUsage(ctx, s.site)
+}
+
+// siteWLANs binds a WLANService to a site.
+type siteWLANs struct {
+	svc  services.WLANService
+	site string
+}
+
+func (s *siteWLANs) List(ctx context.Context) ([]types.WLAN, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *siteWLANs) Get(ctx context.Context, id string) (*types.WLAN, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteWLANs) Create(ctx context.Context, wlan *types.WLAN) (*types.WLAN, error) {
+	return s.svc.Create(ctx, s.site, wlan)
+}
+
+func (s *siteWLANs) Update(ctx context.Context, wlan *types.WLAN) (*types.WLAN, error) {
+	return s.svc.Update(ctx, s.site, wlan)
+}
+
+func (s *siteWLANs) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *siteWLANs) Enable(ctx context.Context, id string) error {
+	return s.svc.Enable(ctx, s.site, id)
+}
+
+func (s *siteWLANs) Disable(ctx context.Context, id string) error {
+	return s.svc.Disable(ctx, s.site, id)
+}
+
+func (s *siteWLANs) SetMACFilter(ctx context.Context, id, policy string, macs []string) error {
+	return s.svc.SetMACFilter(ctx, s.site, id, policy, macs)
+}
+
+func (s *siteWLANs) ConnectedClients(ctx context.Context, id string) ([]types.Client, error) {
+	return s.svc.ConnectedClients(ctx, s.site, id)
+}
+
+func (s *siteWLANs) ListGroups(ctx context.Context) ([]types.WLANGroup, error) {
+	return s.svc.ListGroups(ctx, s.site)
+}
+
+func (s *siteWLANs) GetGroup(ctx context.Context, id string) (*types.WLANGroup, error) {
+	return s.svc.GetGroup(ctx, s.site, id)
+}
+
+func (s *siteWLANs) CreateGroup(ctx context.Context, group *types.WLANGroup) (*types.WLANGroup, error) {
+	return s.svc.CreateGroup(ctx, s.site, group)
+}
+
+func (s *siteWLANs) UpdateGroup(ctx context.Context, group *types.WLANGroup) (*types.WLANGroup, error) {
+	return s.svc.UpdateGroup(ctx, s.site, group)
+}
+
+func (s *siteWLANs) DeleteGroup(ctx context.Context, id string) error {
+	return s.svc.DeleteGroup(ctx, s.site, id)
+}
+
+func (s *siteWLANs) ListAPGroups(ctx context.Context) ([]types.APGroup, error) {
+	return s.svc.ListAPGroups(ctx, s.site)
+}
+
+func (s *siteWLANs) CreateAPGroup(ctx context.Context, group *types.APGroup) (*types.APGroup, error) {
+	return s.svc.CreateAPGroup(ctx, s.site, group)
+}
+
+func (s *siteWLANs) UpdateAPGroup(ctx context.Context, group *types.APGroup) (*types.APGroup, error) {
+	return s.svc.UpdateAPGroup(ctx, s.site, group)
+}
+
+func (s *siteWLANs) DeleteAPGroup(ctx context.Context, id string) error {
+	return s.svc.DeleteAPGroup(ctx, s.site, id)
+}
+
+func (s *siteWLANs) EnableOnAPs(ctx context.Context, wlanID string, apMACs []string) (*types.WLAN, error) {
+	return s.svc.EnableOnAPs(ctx, s.site, wlanID, apMACs)
+}
+
+// siteFirewall binds a FirewallService to a site.
+type siteFirewall struct {
+	svc  services.FirewallService
+	site string
+}
+
+func (s *siteFirewall) ListRules(ctx context.Context) ([]types.FirewallRule, error) {
+	return s.svc.ListRules(ctx, s.site)
+}
+
+func (s *siteFirewall) GetRule(ctx context.Context, id string) (*types.FirewallRule, error) {
+	return s.svc.GetRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) CreateRule(ctx context.Context, rule *types.FirewallRule) (*types.FirewallRule, error) {
+	return s.svc.CreateRule(ctx, s.site, rule)
+}
+
+func (s *siteFirewall) UpdateRule(ctx context.Context, rule *types.FirewallRule) (*types.FirewallRule, error) {
+	return s.svc.UpdateRule(ctx, s.site, rule)
+}
+
+func (s *siteFirewall) DeleteRule(ctx context.Context, id string) error {
+	return s.svc.DeleteRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) EnableRule(ctx context.Context, id string) error {
+	return s.svc.EnableRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) DisableRule(ctx context.Context, id string) error {
+	return s.svc.DisableRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) ReorderRules(ctx context.Context, ruleset string, updates []types.FirewallRuleIndexUpdate) error {
+	return s.svc.ReorderRules(ctx, s.site, ruleset, updates)
+}
+
+func (s *siteFirewall) ReorderRulesVerified(ctx context.Context, ruleset string, updates []types.FirewallRuleIndexUpdate, opts ...services.ReorderOption) (*services.ReorderReport, error) {
+	return s.svc.ReorderRulesVerified(ctx, s.site, ruleset, updates, opts...)
+}
+
+func (s *siteFirewall) ListGroups(ctx context.Context) ([]types.FirewallGroup, error) {
+	return s.svc.ListGroups(ctx, s.site)
+}
+
+func (s *siteFirewall) GetGroup(ctx context.Context, id string) (*types.FirewallGroup, error) {
+	return s.svc.GetGroup(ctx, s.site, id)
+}
+
+func (s *siteFirewall) CreateGroup(ctx context.Context, group *types.FirewallGroup) (*types.FirewallGroup, error) {
+	return s.svc.CreateGroup(ctx, s.site, group)
+}
+
+func (s *siteFirewall) UpdateGroup(ctx context.Context, group *types.FirewallGroup) (*types.FirewallGroup, error) {
+	return s.svc.UpdateGroup(ctx, s.site, group)
+}
+
+func (s *siteFirewall) DeleteGroup(ctx context.Context, id string) error {
+	return s.svc.DeleteGroup(ctx, s.site, id)
+}
+
+func (s *siteFirewall) ListTrafficRules(ctx context.Context) ([]types.TrafficRule, error) {
+	return s.svc.ListTrafficRules(ctx, s.site)
+}
+
+func (s *siteFirewall) GetTrafficRule(ctx context.Context, id string) (*types.TrafficRule, error) {
+	return s.svc.GetTrafficRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) CreateTrafficRule(ctx context.Context, rule *types.TrafficRule) (*types.TrafficRule, error) {
+	return s.svc.CreateTrafficRule(ctx, s.site, rule)
+}
+
+func (s *siteFirewall) UpdateTrafficRule(ctx context.Context, rule *types.TrafficRule) (*types.TrafficRule, error) {
+	return s.svc.UpdateTrafficRule(ctx, s.site, rule)
+}
+
+func (s *siteFirewall) DeleteTrafficRule(ctx context.Context, id string) error {
+	return s.svc.DeleteTrafficRule(ctx, s.site, id)
+}
+
+func (s *siteFirewall) ListZones(ctx context.Context) ([]types.FirewallZone, error) {
+	return s.svc.ListZones(ctx, s.site)
+}
+
+func (s *siteFirewall) CreateZone(ctx context.Context, zone *types.FirewallZone) (*types.FirewallZone, error) {
+	return s.svc.CreateZone(ctx, s.site, zone)
+}
+
+func (s *siteFirewall) UpdateZone(ctx context.Context, zone *types.FirewallZone) (*types.FirewallZone, error) {
+	return s.svc.UpdateZone(ctx, s.site, zone)
+}
+
+func (s *siteFirewall) DeleteZone(ctx context.Context, id string) error {
+	return s.svc.DeleteZone(ctx, s.site, id)
+}
+
+func (s *siteFirewall) GetZoneMatrix(ctx context.Context) (types.FirewallZoneMatrix, error) {
+	return s.svc.GetZoneMatrix(ctx, s.site)
+}
+
+func (s *siteFirewall) ListPolicies(ctx context.Context) ([]types.FirewallPolicy, error) {
+	return s.svc.ListPolicies(ctx, s.site)
+}
+
+func (s *siteFirewall) GetPolicy(ctx context.Context, id string) (*types.FirewallPolicy, error) {
+	return s.svc.GetPolicy(ctx, s.site, id)
+}
+
+func (s *siteFirewall) CreatePolicy(ctx context.Context, policy *types.FirewallPolicy) (*types.FirewallPolicy, error) {
+	return s.svc.CreatePolicy(ctx, s.site, policy)
+}
+
+func (s *siteFirewall) UpdatePolicy(ctx context.Context, policy *types.FirewallPolicy) (*types.FirewallPolicy, error) {
+	return s.svc.UpdatePolicy(ctx, s.site, policy)
+}
+
+func (s *siteFirewall) DeletePolicy(ctx context.Context, id string) error {
+	return s.svc.DeletePolicy(ctx, s.site, id)
+}
+
+func (s *siteFirewall) ReorderPolicies(ctx context.Context, order *types.FirewallPolicyOrder) error {
+	return s.svc.ReorderPolicies(ctx, s.site, order)
+}
+
+// siteClients binds a ClientService to a site.
+type siteClients struct {
+	svc  services.ClientService
+	site string
+}
+
+func (s *siteClients) ListActive(ctx context.Context) ([]types.Client, error) {
+	return s.svc.ListActive(ctx, s.site)
+}
+
+func (s *siteClients) ListAll(ctx context.Context, opts ...services.ClientListOption) ([]types.Client, error) {
+	return s.svc.ListAll(ctx, s.site, opts...)
+}
+
+func (s *siteClients) CountActive(ctx context.Context) (int, error) {
+	return s.svc.CountActive(ctx, s.site)
+}
+
+func (s *siteClients) Get(ctx context.Context, mac string) (*types.Client, error) {
+	return s.svc.Get(ctx, s.site, mac)
+}
+
+func (s *siteClients) Block(ctx context.Context, mac string) error {
+	return s.svc.Block(ctx, s.site, mac)
+}
+
+func (s *siteClients) Unblock(ctx context.Context, mac string) error {
+	return s.svc.Unblock(ctx, s.site, mac)
+}
+
+func (s *siteClients) Kick(ctx context.Context, mac string) error {
+	return s.svc.Kick(ctx, s.site, mac)
+}
+
+func (s *siteClients) WakeOnLAN(ctx context.Context, mac string) error {
+	return s.svc.WakeOnLAN(ctx, s.site, mac)
+}
+
+func (s *siteClients) AuthorizeGuest(ctx context.Context, mac string, opts ...services.GuestAuthOption) error {
+	return s.svc.AuthorizeGuest(ctx, s.site, mac, opts...)
+}
+
+func (s *siteClients) UnauthorizeGuest(ctx context.Context, mac string) error {
+	return s.svc.UnauthorizeGuest(ctx, s.site, mac)
+}
+
+func (s *siteClients) ListGuests(ctx context.Context, opts ...services.ClientListOption) ([]types.Guest, error) {
+	return s.svc.ListGuests(ctx, s.site, opts...)
+}
+
+func (s *siteClients) Forget(ctx context.Context, mac string) error {
+	return s.svc.Forget(ctx, s.site, mac)
+}
+
+func (s *siteClients) SetFingerprint(ctx context.Context, mac string, devID int) error {
+	return s.svc.SetFingerprint(ctx, s.site, mac, devID)
+}
+
+// siteUsers binds a UserService to a site.
+type siteUsers struct {
+	svc  services.UserService
+	site string
+}
+
+func (s *siteUsers) List(ctx context.Context, opts ...services.UserListOption) ([]types.User, error) {
+	return s.svc.List(ctx, s.site, opts...)
+}
+
+func (s *siteUsers) Get(ctx context.Context, id string) (*types.User, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteUsers) GetByMAC(ctx context.Context, mac string) (*types.User, error) {
+	return s.svc.GetByMAC(ctx, s.site, mac)
+}
+
+func (s *siteUsers) Create(ctx context.Context, user *types.User) (*types.User, error) {
+	return s.svc.Create(ctx, s.site, user)
+}
+
+func (s *siteUsers) Update(ctx context.Context, user *types.User) (*types.User, error) {
+	return s.svc.Update(ctx, s.site, user)
+}
+
+func (s *siteUsers) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *siteUsers) DeleteByMAC(ctx context.Context, mac string) error {
+	return s.svc.DeleteByMAC(ctx, s.site, mac)
+}
+
+func (s *siteUsers) SetFixedIP(ctx context.Context, mac, ip, networkID string) error {
+	return s.svc.SetFixedIP(ctx, s.site, mac, ip, networkID)
+}
+
+func (s *siteUsers) ClearFixedIP(ctx context.Context, mac string) error {
+	return s.svc.ClearFixedIP(ctx, s.site, mac)
+}
+
+func (s *siteUsers) FindDuplicates(ctx context.Context) ([]services.DuplicateUsers, error) {
+	return s.svc.FindDuplicates(ctx, s.site)
+}
+
+func (s *siteUsers) Merge(ctx context.Context, keepID string, dropIDs []string) (*types.User, error) {
+	return s.svc.Merge(ctx, s.site, keepID, dropIDs)
+}
+
+func (s *siteUsers) BulkUpsert(ctx context.Context, users []types.User, opts ...services.UpsertOption) (*services.UpsertReport, error) {
+	return s.svc.BulkUpsert(ctx, s.site, users, opts...)
+}
+
+func (s *siteUsers) ListGroups(ctx context.Context) ([]types.UserGroup, error) {
+	return s.svc.ListGroups(ctx, s.site)
+}
+
+func (s *siteUsers) GetGroup(ctx context.Context, id string) (*types.UserGroup, error) {
+	return s.svc.GetGroup(ctx, s.site, id)
+}
+
+func (s *siteUsers) CreateGroup(ctx context.Context, group *types.UserGroup) (*types.UserGroup, error) {
+	return s.svc.CreateGroup(ctx, s.site, group)
+}
+
+func (s *siteUsers) UpdateGroup(ctx context.Context, group *types.UserGroup) (*types.UserGroup, error) {
+	return s.svc.UpdateGroup(ctx, s.site, group)
+}
+
+func (s *siteUsers) DeleteGroup(ctx context.Context, id string) error {
+	return s.svc.DeleteGroup(ctx, s.site, id)
+}
+
+func (s *siteUsers) SetGroupLimits(ctx context.Context, groupID string, down, up types.RateLimit) (*types.UserGroup, error) {
+	return s.svc.SetGroupLimits(ctx, s.site, groupID, down, up)
+}
+
+func (s *siteUsers) AssignGroup(ctx context.Context, groupID string, macs []string) (*services.AssignGroupReport, error) {
+	return s.svc.AssignGroup(ctx, s.site, groupID, macs)
+}
+
+// siteRouting binds a RoutingService to a site.
+type siteRouting struct {
+	svc  services.RoutingService
+	site string
+}
+
+func (s *siteRouting) List(ctx context.Context) ([]types.Route, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *siteRouting) Get(ctx context.Context, id string) (*types.Route, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteRouting) Create(ctx context.Context, route *types.Route) (*types.Route, error) {
+	return s.svc.Create(ctx, s.site, route)
+}
+
+func (s *siteRouting) Update(ctx context.Context, route *types.Route) (*types.Route, error) {
+	return s.svc.Update(ctx, s.site, route)
+}
+
+func (s *siteRouting) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *siteRouting) Enable(ctx context.Context, id string) error {
+	return s.svc.Enable(ctx, s.site, id)
+}
+
+func (s *siteRouting) Disable(ctx context.Context, id string) error {
+	return s.svc.Disable(ctx, s.site, id)
+}
+
+// sitePortForwards binds a PortForwardService to a site.
+type sitePortForwards struct {
+	svc  services.PortForwardService
+	site string
+}
+
+func (s *sitePortForwards) List(ctx context.Context) ([]types.PortForward, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *sitePortForwards) Get(ctx context.Context, id string) (*types.PortForward, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *sitePortForwards) Create(ctx context.Context, forward *types.PortForward) (*types.PortForward, error) {
+	return s.svc.Create(ctx, s.site, forward)
+}
+
+func (s *sitePortForwards) Update(ctx context.Context, forward *types.PortForward) (*types.PortForward, error) {
+	return s.svc.Update(ctx, s.site, forward)
+}
+
+func (s *sitePortForwards) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *sitePortForwards) Enable(ctx context.Context, id string) error {
+	return s.svc.Enable(ctx, s.site, id)
+}
+
+func (s *sitePortForwards) Disable(ctx context.Context, id string) error {
+	return s.svc.Disable(ctx, s.site, id)
+}
+
+func (s *sitePortForwards) ValidateNoConflict(ctx context.Context, forward *types.PortForward) error {
+	return s.svc.ValidateNoConflict(ctx, s.site, forward)
+}
+
+// sitePortProfiles binds a PortProfileService to a site.
+type sitePortProfiles struct {
+	svc  services.PortProfileService
+	site string
+}
+
+func (s *sitePortProfiles) List(ctx context.Context) ([]types.PortProfile, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *sitePortProfiles) Get(ctx context.Context, id string) (*types.PortProfile, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *sitePortProfiles) Create(ctx context.Context, profile *types.PortProfile) (*types.PortProfile, error) {
+	return s.svc.Create(ctx, s.site, profile)
+}
+
+func (s *sitePortProfiles) Update(ctx context.Context, profile *types.PortProfile) (*types.PortProfile, error) {
+	return s.svc.Update(ctx, s.site, profile)
+}
+
+func (s *sitePortProfiles) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+// siteSettings binds a SettingService to a site.
+type siteSettings struct {
+	svc  services.SettingService
+	site string
+}
+
+func (s *siteSettings) Get(ctx context.Context, key string) (interface{}, error) {
+	return s.svc.Get(ctx, s.site, key)
+}
+
+func (s *siteSettings) Update(ctx context.Context, setting interface{}) error {
+	return s.svc.Update(ctx, s.site, setting)
+}
+
+func (s *siteSettings) ListRadiusProfiles(ctx context.Context) ([]types.RADIUSProfile, error) {
+	return s.svc.ListRadiusProfiles(ctx, s.site)
+}
+
+func (s *siteSettings) GetRadiusProfile(ctx context.Context, id string) (*types.RADIUSProfile, error) {
+	return s.svc.GetRadiusProfile(ctx, s.site, id)
+}
+
+func (s *siteSettings) CreateRadiusProfile(ctx context.Context, profile *types.RADIUSProfile) (*types.RADIUSProfile, error) {
+	return s.svc.CreateRadiusProfile(ctx, s.site, profile)
+}
+
+func (s *siteSettings) UpdateRadiusProfile(ctx context.Context, profile *types.RADIUSProfile) (*types.RADIUSProfile, error) {
+	return s.svc.UpdateRadiusProfile(ctx, s.site, profile)
+}
+
+func (s *siteSettings) DeleteRadiusProfile(ctx context.Context, id string) error {
+	return s.svc.DeleteRadiusProfile(ctx, s.site, id)
+}
+
+func (s *siteSettings) GetDynamicDNS(ctx context.Context) (*types.DynamicDNS, error) {
+	return s.svc.GetDynamicDNS(ctx, s.site)
+}
+
+func (s *siteSettings) UpdateDynamicDNS(ctx context.Context, ddns *types.DynamicDNS) error {
+	return s.svc.UpdateDynamicDNS(ctx, s.site, ddns)
+}
+
+func (s *siteSettings) GetLCM(ctx context.Context) (*types.SettingLCM, error) {
+	return s.svc.GetLCM(ctx, s.site)
+}
+
+func (s *siteSettings) UpdateLCM(ctx context.Context, setting *types.SettingLCM) error {
+	return s.svc.UpdateLCM(ctx, s.site, setting)
+}
+
+func (s *siteSettings) GetIPS(ctx context.Context) (*types.SettingIPS, error) {
+	return s.svc.GetIPS(ctx, s.site)
+}
+
+func (s *siteSettings) UpdateIPS(ctx context.Context, setting *types.SettingIPS) error {
+	return s.svc.UpdateIPS(ctx, s.site, setting)
+}
+
+func (s *siteSettings) SetHoneypots(ctx context.Context, honeypots []types.Honeypot) error {
+	return s.svc.SetHoneypots(ctx, s.site, honeypots)
+}
+
+// siteDNS binds a DNSService to a site.
+type siteDNS struct {
+	svc  services.DNSService
+	site string
+}
+
+func (s *siteDNS) List(ctx context.Context) ([]types.DNSRecord, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *siteDNS) Get(ctx context.Context, id string) (*types.DNSRecord, error) {
+	return s.svc.Get(ctx, s.site, id)
+}
+
+func (s *siteDNS) GetByName(ctx context.Context, name string) (*types.DNSRecord, error) {
+	return s.svc.GetByName(ctx, s.site, name)
+}
+
+func (s *siteDNS) GetByIP(ctx context.Context, ip string) ([]types.DNSRecord, error) {
+	return s.svc.GetByIP(ctx, s.site, ip)
+}
+
+func (s *siteDNS) Create(ctx context.Context, record *types.DNSRecord) (*types.DNSRecord, error) {
+	return s.svc.Create(ctx, s.site, record)
+}
+
+func (s *siteDNS) Update(ctx context.Context, record *types.DNSRecord) (*types.DNSRecord, error) {
+	return s.svc.Update(ctx, s.site, record)
+}
+
+func (s *siteDNS) Delete(ctx context.Context, id string) error {
+	return s.svc.Delete(ctx, s.site, id)
+}
+
+func (s *siteDNS) DeleteByName(ctx context.Context, name string) error {
+	return s.svc.DeleteByName(ctx, s.site, name)
+}
+
+// siteHotspot binds a HotspotService to a site.
+type siteHotspot struct {
+	svc  services.HotspotService
+	site string
+}
+
+func (s *siteHotspot) ListVouchers(ctx context.Context) ([]types.Voucher, error) {
+	return s.svc.ListVouchers(ctx, s.site)
+}
+
+func (s *siteHotspot) CreateVouchers(ctx context.Context, count, minutes int, opts ...services.VoucherOption) ([]types.Voucher, error) {
+	return s.svc.CreateVouchers(ctx, s.site, count, minutes, opts...)
+}
+
+func (s *siteHotspot) RevokeVoucher(ctx context.Context, id string) error {
+	return s.svc.RevokeVoucher(ctx, s.site, id)
+}
+
+func (s *siteHotspot) ListOperators(ctx context.Context) ([]types.HotspotOperator, error) {
+	return s.svc.ListOperators(ctx, s.site)
+}
+
+func (s *siteHotspot) CreateOperator(ctx context.Context, operator *types.HotspotOperator) (*types.HotspotOperator, error) {
+	return s.svc.CreateOperator(ctx, s.site, operator)
+}
+
+func (s *siteHotspot) UpdateOperator(ctx context.Context, operator *types.HotspotOperator) (*types.HotspotOperator, error) {
+	return s.svc.UpdateOperator(ctx, s.site, operator)
+}
+
+func (s *siteHotspot) DeleteOperator(ctx context.Context, id string) error {
+	return s.svc.DeleteOperator(ctx, s.site, id)
+}
+
+// siteAlarms binds an AlarmService to a site.
+type siteAlarms struct {
+	svc  services.AlarmService
+	site string
+}
+
+func (s *siteAlarms) List(ctx context.Context) ([]types.Alarm, error) {
+	return s.svc.List(ctx, s.site)
+}
+
+func (s *siteAlarms) ListAll(ctx context.Context) ([]types.Alarm, error) {
+	return s.svc.ListAll(ctx, s.site)
+}
+
+func (s *siteAlarms) Archive(ctx context.Context, id string) error {
+	return s.svc.Archive(ctx, s.site, id)
+}
+
+func (s *siteAlarms) ArchiveAll(ctx context.Context) error {
+	return s.svc.ArchiveAll(ctx, s.site)
+}
+
+func (s *siteAlarms) ArchiveMatching(ctx context.Context, match func(*types.Alarm) bool) (int, error) {
+	return s.svc.ArchiveMatching(ctx, s.site, match)
+}
@@ -0,0 +1,114 @@
+package gofi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/transport"
+)
+
+// recordingTransport records request paths and returns an empty API response.
+type recordingTransport struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (r *recordingTransport) Do(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	r.mu.Lock()
+	r.paths = append(r.paths, req.Path)
+	r.mu.Unlock()
+	return &transport.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"meta":{"rc":"ok"},"data":[]}`),
+	}, nil
+}
+
+func (r *recordingTransport) SetCSRFToken(token string) {}
+func (r *recordingTransport) GetCSRFToken() string      { return "" }
+func (r *recordingTransport) Close()                    {}
+
+func (r *recordingTransport) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.paths) == 0 {
+		return ""
+	}
+	return r.paths[len(r.paths)-1]
+}
+
+func TestClient_Site_BindsSite(t *testing.T) {
+	rt := &recordingTransport{}
+	c := &client{config: &Config{Site: "default"}, transport: rt, devices: &deviceHub{}}
+	ctx := context.Background()
+
+	branch := c.Site("branch")
+	if branch.Name() != "branch" {
+		t.Errorf("Name() = %s, want branch", branch.Name())
+	}
+
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"Devices.List", func() error { _, err := branch.Devices().List(ctx); return err }},
+		{"Networks.List", func() error { _, err := branch.Networks().List(ctx); return err }},
+		{"WLANs.List", func() error { _, err := branch.WLANs().List(ctx); return err }},
+		{"Firewall.ListRules", func() error { _, err := branch.Firewall().ListRules(ctx); return err }},
+		{"Clients.ListActive", func() error { _, err := branch.Clients().ListActive(ctx); return err }},
+		{"Users.List", func() error { _, err := branch.Users().List(ctx); return err }},
+		{"Routing.List", func() error { _, err := branch.Routing().List(ctx); return err }},
+		{"PortForwards.List", func() error { _, err := branch.PortForwards().List(ctx); return err }},
+		{"PortProfiles.List", func() error { _, err := branch.PortProfiles().List(ctx); return err }},
+		{"Settings.ListRadiusProfiles", func() error { _, err := branch.Settings().ListRadiusProfiles(ctx); return err }},
+		{"DNS.List", func() error { _, err := branch.DNS().List(ctx); return err }},
+		{"Health", func() error { _, err := branch.Health(ctx); return err }},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("%s error = %v", tt.name, err)
+			}
+			if path := rt.last(); !strings.Contains(path, "/branch/") {
+				t.Errorf("path = %s, want site branch", path)
+			}
+		})
+	}
+}
+
+func TestClient_Site_DefaultSite(t *testing.T) {
+	c := &client{config: &Config{Site: "main"}, transport: &recordingTransport{}, devices: &deviceHub{}}
+
+	if name := c.Site("").Name(); name != "main" {
+		t.Errorf("Name() = %s, want main", name)
+	}
+}
+
+func TestClient_Site_Integration(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := New(&Config{
+		Host:          server.Host(),
+		Port:          server.Port(),
+		Username:      "admin",
+		Password:      "admin",
+		SkipTLSVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	if _, err := c.Site("default").Devices().List(ctx); err != nil {
+		t.Fatalf("Site().Devices().List() error = %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package gofi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// AllDevices lists the devices of every site, keyed by site name. Sites
+// are queried concurrently with Sites().ForEach. If some sites fail, the
+// devices of the others are returned along with the joined error.
+func AllDevices(ctx context.Context, c Client) (map[string][]types.Device, error) {
+	return collectSites(ctx, c, func(ctx context.Context, site string) ([]types.Device, error) {
+		return c.Devices().List(ctx, site)
+	})
+}
+
+// AllClients lists the active clients of every site, keyed by site name,
+// in the same way as AllDevices.
+func AllClients(ctx context.Context, c Client) (map[string][]types.Client, error) {
+	return collectSites(ctx, c, c.Clients().ListActive)
+}
+
+// collectSites calls list for every site and gathers the results.
+func collectSites[T any](ctx context.Context, c Client, list func(ctx context.Context, site string) ([]T, error)) (map[string][]T, error) {
+	var mu sync.Mutex
+	out := make(map[string][]T)
+	err := c.Sites().ForEach(ctx, func(site types.Site) error {
+		items, err := list(ctx, site.Name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		out[site.Name] = items
+		mu.Unlock()
+		return nil
+	})
+	return out, err
+}
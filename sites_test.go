@@ -0,0 +1,39 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestAllDevices(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddSite(&types.Site{ID: "branch", Name: "branch"})
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:bb:cc:dd:ee:01", Type: "uap", Name: "AP"})
+	server.State().AddClient(&types.Client{MAC: "aa:bb:cc:dd:ee:10", Hostname: "laptop", LastSeen: types.NewFlexTime(time.Now().Unix())})
+
+	c := connectMock(t, server)
+	ctx := context.Background()
+
+	devices, err := AllDevices(ctx, c)
+	if err != nil {
+		t.Fatalf("AllDevices() error = %v", err)
+	}
+	for _, site := range []string{"default", "branch"} {
+		if len(devices[site]) != 1 {
+			t.Errorf("AllDevices()[%s] has %d devices, want 1", site, len(devices[site]))
+		}
+	}
+
+	clients, err := AllClients(ctx, c)
+	if err != nil {
+		t.Fatalf("AllClients() error = %v", err)
+	}
+	if len(clients) != 2 || len(clients["branch"]) != 1 {
+		t.Errorf("AllClients() = %v, want one client in each of 2 sites", clients)
+	}
+}
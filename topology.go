@@ -0,0 +1,228 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// TopologyNodeKind classifies a node in the uplink tree.
+type TopologyNodeKind = types.TopologyNodeKind
+
+// Topology node kinds.
+const (
+	TopologyGateway = types.TopologyGateway
+	TopologySwitch  = types.TopologySwitch
+	TopologyAP      = types.TopologyAP
+	TopologyDevice  = types.TopologyDevice
+	TopologyClient  = types.TopologyClient
+)
+
+// TopologyNode is a device or client in the uplink tree.
+type TopologyNode struct {
+	Kind  TopologyNodeKind `json:"kind"`
+	MAC   string           `json:"mac"`
+	Name  string           `json:"name,omitempty"`
+	Model string           `json:"model,omitempty"`
+	IP    string           `json:"ip,omitempty"`
+
+	// UplinkPort is the port on the parent this node is connected to,
+	// or 0 if unknown or wireless.
+	UplinkPort int `json:"uplink_port,omitempty"`
+
+	// Wireless is true for nodes connected over Wi-Fi, including
+	// mesh access points.
+	Wireless bool `json:"wireless,omitempty"`
+
+	// Speed is the uplink speed in Mbps, if known.
+	Speed int `json:"speed,omitempty"`
+
+	Children []*TopologyNode `json:"children,omitempty"`
+}
+
+// Topology is the site's uplink tree. Roots are normally the gateway;
+// devices and clients whose uplink cannot be resolved are added as
+// additional roots rather than dropped.
+type Topology struct {
+	Roots []*TopologyNode `json:"roots"`
+}
+
+// TopologyOptions controls BuildTopology.
+type TopologyOptions struct {
+	// IncludeClients adds connected clients as leaves under the device
+	// they are attached to.
+	IncludeClients bool
+}
+
+// BuildTopology fetches devices (and optionally clients) for a site and
+// arranges them into an uplink tree with NewTopology.
+func BuildTopology(ctx context.Context, c Client, site string, opts TopologyOptions) (*Topology, error) {
+	devices, err := c.Devices().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	var clients []types.Client
+	if opts.IncludeClients {
+		clients, err = c.Clients().ListActive(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clients: %w", err)
+		}
+	}
+
+	return NewTopology(devices, clients), nil
+}
+
+// NewTopology arranges devices and clients into an uplink tree. Parents
+// are resolved as described for types.NewTopologyGraph.
+func NewTopology(devices []types.Device, clients []types.Client) *Topology {
+	g := types.NewTopologyGraph(devices, clients)
+	nodes := make(map[string]*TopologyNode, len(g.Nodes))
+	parents := make(map[string]string, len(g.Edges))
+	for _, n := range g.Nodes {
+		nodes[n.MAC] = &TopologyNode{Kind: n.Kind, MAC: n.MAC, Name: n.Name, Model: n.Model, IP: n.IP}
+	}
+	for _, e := range g.Edges {
+		node := nodes[e.To]
+		node.UplinkPort = e.Port
+		node.Speed = e.Speed
+		node.Wireless = e.Wireless
+		parents[e.To] = e.From
+	}
+
+	t := &Topology{}
+	for _, n := range g.Nodes {
+		node := nodes[n.MAC]
+		parent := nodes[parents[n.MAC]]
+		if parent == nil || createsCycle(n.MAC, parents) {
+			t.Roots = append(t.Roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortTopology(t.Roots)
+	return t
+}
+
+// createsCycle reports whether following parents from mac leads back to
+// mac. Broken uplink data would otherwise detach a loop of devices from
+// the tree entirely.
+func createsCycle(mac string, parents map[string]string) bool {
+	seen := map[string]bool{mac: true}
+	for p := parents[mac]; p != ""; p = parents[p] {
+		if seen[p] {
+			return p == mac
+		}
+		seen[p] = true
+	}
+	return false
+}
+
+// kindOrder sorts infrastructure before clients.
+var kindOrder = map[TopologyNodeKind]int{
+	TopologyGateway: 0,
+	TopologySwitch:  1,
+	TopologyAP:      2,
+	TopologyDevice:  3,
+	TopologyClient:  4,
+}
+
+// sortTopology orders nodes by kind, uplink port and name, recursively.
+func sortTopology(nodes []*TopologyNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if kindOrder[a.Kind] != kindOrder[b.Kind] {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		if a.UplinkPort != b.UplinkPort {
+			return a.UplinkPort < b.UplinkPort
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.MAC < b.MAC
+	})
+	for _, n := range nodes {
+		sortTopology(n.Children)
+	}
+}
+
+// Find returns the node with the given MAC address, or nil.
+func (t *Topology) Find(mac string) *TopologyNode {
+	mac = strings.ToLower(mac)
+	var find func([]*TopologyNode) *TopologyNode
+	find = func(nodes []*TopologyNode) *TopologyNode {
+		for _, n := range nodes {
+			if n.MAC == mac {
+				return n
+			}
+			if found := find(n.Children); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(t.Roots)
+}
+
+// String returns a one-line description of the node, e.g.
+// "core-switch (UniFi Switch 24 PoE, aa:bb:cc:dd:ee:ff) port 3".
+func (n *TopologyNode) String() string {
+	var b strings.Builder
+	if n.Name != "" {
+		b.WriteString(n.Name)
+	} else {
+		b.WriteString(n.MAC)
+	}
+
+	var details []string
+	if n.Model != "" {
+		details = append(details, n.Model)
+	}
+	if n.Name != "" {
+		details = append(details, n.MAC)
+	}
+	if n.IP != "" {
+		details = append(details, n.IP)
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	switch {
+	case n.Wireless:
+		b.WriteString(" wireless")
+	case n.UplinkPort > 0:
+		fmt.Fprintf(&b, " port %d", n.UplinkPort)
+	}
+	return b.String()
+}
+
+// WriteTree writes the topology as an indented ASCII tree.
+func (t *Topology) WriteTree(w io.Writer) error {
+	var b strings.Builder
+	var walk func(nodes []*TopologyNode, indent string)
+	walk = func(nodes []*TopologyNode, indent string) {
+		for i, n := range nodes {
+			branch, next := "├── ", "│   "
+			if i == len(nodes)-1 {
+				branch, next = "└── ", "    "
+			}
+			fmt.Fprintf(&b, "%s%s[%s] %s\n", indent, branch, n.Kind, n)
+			walk(n.Children, indent+next)
+		}
+	}
+
+	for _, root := range t.Roots {
+		fmt.Fprintf(&b, "[%s] %s\n", root.Kind, root)
+		walk(root.Children, "")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,129 @@
+package gofi
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func topologyFixture() ([]types.Device, []types.Client) {
+	devices := []types.Device{
+		{MAC: "aa:00:00:00:00:03", Name: "office-ap", Type: "uap",
+			Uplink: &types.DeviceUplink{UplinkMAC: "AA:00:00:00:00:02", UplinkRemotePort: 5, Speed: 1000}},
+		{MAC: "aa:00:00:00:00:01", Name: "gateway", Type: "udm", Model: "UDMPRO"},
+		{MAC: "aa:00:00:00:00:02", Name: "core", Type: "usw",
+			UplinkTable: []types.DeviceUplink{{UplinkMAC: "aa:00:00:00:00:01", UplinkRemotePort: 9}}},
+		// Uplink only known from LLDP on the switch's uplink port.
+		{MAC: "aa:00:00:00:00:04", Name: "edge", Type: "usw",
+			PortTable: []types.PortTable{{PortIdx: 1}, {PortIdx: 8, IsUplink: types.FlexBool{Val: true}}},
+			LLDPTable: []types.LLDPEntry{{ChassisID: "aa:00:00:00:00:99", LocalPortIdx: 1}, {ChassisID: "aa:00:00:00:00:02", LocalPortIdx: 8}}},
+		{MAC: "aa:00:00:00:00:05", Name: "orphan", Type: "uap"},
+	}
+	clients := []types.Client{
+		{MAC: "cc:00:00:00:00:01", Hostname: "laptop", APMA: "aa:00:00:00:00:03"},
+		{MAC: "cc:00:00:00:00:02", Name: "nas", IsWired: types.FlexBool{Val: true}, SWMAC: "aa:00:00:00:00:02", SWPORT: 2},
+		{MAC: "cc:00:00:00:00:03", Name: "tv", IsWired: types.FlexBool{Val: true}, GWMAC: "aa:00:00:00:00:01"},
+	}
+	return devices, clients
+}
+
+func TestNewTopology(t *testing.T) {
+	devices, clients := topologyFixture()
+	topo := NewTopology(devices, clients)
+
+	if len(topo.Roots) != 2 || topo.Roots[0].Name != "gateway" || topo.Roots[1].Name != "orphan" {
+		t.Fatalf("Roots = %v, want gateway and orphan", topo.Roots)
+	}
+
+	tests := []struct {
+		mac, parent string
+		port        int
+	}{
+		{"aa:00:00:00:00:02", "aa:00:00:00:00:01", 9},
+		{"aa:00:00:00:00:03", "aa:00:00:00:00:02", 5},
+		{"aa:00:00:00:00:04", "aa:00:00:00:00:02", 0},
+		{"cc:00:00:00:00:01", "aa:00:00:00:00:03", 0},
+		{"cc:00:00:00:00:02", "aa:00:00:00:00:02", 2},
+		{"cc:00:00:00:00:03", "aa:00:00:00:00:01", 0},
+	}
+	for _, tt := range tests {
+		parent := topo.Find(tt.parent)
+		var found *TopologyNode
+		for _, c := range parent.Children {
+			if c.MAC == tt.mac {
+				found = c
+			}
+		}
+		if found == nil {
+			t.Errorf("%s is not a child of %s", tt.mac, tt.parent)
+			continue
+		}
+		if found.UplinkPort != tt.port {
+			t.Errorf("%s UplinkPort = %d, want %d", tt.mac, found.UplinkPort, tt.port)
+		}
+	}
+
+	// Infrastructure sorts before clients.
+	core := topo.Find("aa:00:00:00:00:02")
+	if core.Children[len(core.Children)-1].Kind != TopologyClient {
+		t.Errorf("core children = %v, want clients last", core.Children)
+	}
+	if !topo.Find("cc:00:00:00:00:01").Wireless {
+		t.Error("laptop should be wireless")
+	}
+}
+
+func TestNewTopology_Cycle(t *testing.T) {
+	devices := []types.Device{
+		{MAC: "aa:00:00:00:00:01", Type: "usw", Uplink: &types.DeviceUplink{UplinkMAC: "aa:00:00:00:00:02"}},
+		{MAC: "aa:00:00:00:00:02", Type: "usw", Uplink: &types.DeviceUplink{UplinkMAC: "aa:00:00:00:00:01"}},
+	}
+	topo := NewTopology(devices, nil)
+
+	if topo.Find("aa:00:00:00:00:01") == nil || topo.Find("aa:00:00:00:00:02") == nil {
+		t.Errorf("devices in an uplink cycle were dropped: %+v", topo.Roots)
+	}
+}
+
+func TestTopology_WriteTree(t *testing.T) {
+	devices, clients := topologyFixture()
+	var buf bytes.Buffer
+	if err := NewTopology(devices, clients).WriteTree(&buf); err != nil {
+		t.Fatalf("WriteTree() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"[gateway] gateway (UniFi Dream Machine Pro, aa:00:00:00:00:01)\n",
+		"├── [switch] core (aa:00:00:00:00:02) port 9\n",
+		"│   ├── [ap] office-ap (aa:00:00:00:00:03) port 5\n",
+		"│   │   └── [client] laptop (cc:00:00:00:00:01) wireless\n",
+		"└── [client] tv (cc:00:00:00:00:03)\n",
+		"[ap] orphan (aa:00:00:00:00:05)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteTree() missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestBuildTopology(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "d1", MAC: "aa:00:00:00:00:01", Name: "gateway", Type: "udm"})
+	server.State().AddDevice(&types.Device{ID: "d2", MAC: "aa:00:00:00:00:02", Name: "core", Type: "usw",
+		Uplink: &types.DeviceUplink{UplinkMAC: "aa:00:00:00:00:01"}})
+	c := connectMock(t, server)
+
+	topo, err := BuildTopology(context.Background(), c, "default", TopologyOptions{})
+	if err != nil {
+		t.Fatalf("BuildTopology() error = %v", err)
+	}
+	if len(topo.Roots) != 1 || len(topo.Roots[0].Children) != 1 {
+		t.Errorf("BuildTopology() roots = %+v, want gateway with one child", topo.Roots)
+	}
+}
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
 )
 
@@ -27,6 +28,10 @@ type Config struct {
 
 	// UserAgent is the User-Agent header value.
 	UserAgent string
+
+	// Headers are added to every request. Headers set on a Request take
+	// precedence.
+	Headers http.Header
 }
 
 // Option is a functional option for configuring the transport.
@@ -60,6 +65,13 @@ func WithUserAgent(ua string) Option {
 	}
 }
 
+// WithHeaders sets headers added to every request.
+func WithHeaders(headers http.Header) Option {
+	return func(c *Config) {
+		c.Headers = headers
+	}
+}
+
 // DefaultConfig returns a Config with default values.
 func DefaultConfig(baseURL string) *Config {
 	return &Config{
@@ -4,5 +4,6 @@
 //   - HTTP request/response operations
 //   - Connection pooling
 //   - Automatic retries with exponential backoff
+//   - Client-side rate limiting
 //   - CSRF token injection
 package transport
@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header carrying an idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL is how long an IdempotencyTransport remembers a key.
+const DefaultIdempotencyTTL = 10 * time.Minute
+
+// ErrOutcomeUnknown is returned for a request whose idempotency key was
+// already used by a request that may or may not have been executed, for
+// example because the connection failed before the response arrived.
+var ErrOutcomeUnknown = errors.New("outcome of request with idempotency key unknown")
+
+// NewIdempotencyKey returns a random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// idempotentResult is what an IdempotencyTransport knows about a key.
+type idempotentResult struct {
+	// resp is the successful response, or nil while the request is in
+	// flight or if its outcome is unknown.
+	resp    *Response
+	err     error
+	expires time.Time
+}
+
+// IdempotencyTransport wraps a Transport and sends each request carrying
+// an IdempotencyKeyHeader at most once. The controller does not
+// deduplicate requests itself, so the keys are tracked locally:
+//
+//   - a key whose request succeeded returns the same response again
+//     without sending anything;
+//   - a key whose request was rejected (a definite error status) may be
+//     sent again;
+//   - a key whose request failed ambiguously (no response, or a 500, 502
+//     or 504 status) fails with ErrOutcomeUnknown, as does a key whose
+//     request is still in flight.
+//
+// Requests without a key pass through unchanged.
+type IdempotencyTransport struct {
+	transport Transport
+	ttl       time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*idempotentResult
+}
+
+// NewIdempotencyTransport creates an IdempotencyTransport that remembers
+// keys for ttl, or DefaultIdempotencyTTL if ttl is not positive.
+func NewIdempotencyTransport(transport Transport, ttl time.Duration) *IdempotencyTransport {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &IdempotencyTransport{
+		transport: transport,
+		ttl:       ttl,
+		keys:      make(map[string]*idempotentResult),
+	}
+}
+
+// Do executes a request on the underlying transport unless its
+// idempotency key was already used.
+func (t *IdempotencyTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	key := req.Headers[IdempotencyKeyHeader]
+	if key == "" {
+		return t.transport.Do(ctx, req)
+	}
+
+	now := time.Now()
+	t.mu.Lock()
+	for k, r := range t.keys {
+		if now.After(r.expires) {
+			delete(t.keys, k)
+		}
+	}
+	if r, ok := t.keys[key]; ok {
+		t.mu.Unlock()
+		if r.resp != nil {
+			return r.resp, nil
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("%w: key %s: %v", ErrOutcomeUnknown, key, r.err)
+		}
+		return nil, fmt.Errorf("%w: key %s is already in use", ErrOutcomeUnknown, key)
+	}
+	r := &idempotentResult{expires: now.Add(t.ttl)}
+	t.keys[key] = r
+	t.mu.Unlock()
+
+	resp, err := t.transport.Do(ctx, req)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case err != nil:
+		r.err = err
+	case resp.IsSuccess():
+		r.resp = resp
+	case ambiguousStatus(resp.StatusCode):
+		r.err = fmt.Errorf("status %d", resp.StatusCode)
+	default:
+		delete(t.keys, key)
+	}
+
+	return resp, err
+}
+
+// ambiguousStatus reports whether a response with the status code leaves
+// open whether the controller executed the request.
+func ambiguousStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Stream executes a request on the underlying transport without
+// idempotency tracking; a streamed response cannot be replayed.
+func (t *IdempotencyTransport) Stream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	return Stream(ctx, t.transport, req)
+}
+
+// Stats returns the underlying transport's counters.
+func (t *IdempotencyTransport) Stats() Stats {
+	return GetStats(t.transport)
+}
+
+// SetCSRFToken sets the CSRF token on the underlying transport.
+func (t *IdempotencyTransport) SetCSRFToken(token string) {
+	t.transport.SetCSRFToken(token)
+}
+
+// GetCSRFToken returns the CSRF token from the underlying transport.
+func (t *IdempotencyTransport) GetCSRFToken() string {
+	return t.transport.GetCSRFToken()
+}
+
+// Close closes the underlying transport.
+func (t *IdempotencyTransport) Close() {
+	t.transport.Close()
+}
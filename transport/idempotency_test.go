@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// scriptedTransport returns its responses in order and counts the calls.
+type scriptedTransport struct {
+	responses []*Response
+	errs      []error
+	calls     int
+	lastKey   string
+}
+
+func (s *scriptedTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	i := s.calls
+	s.calls++
+	s.lastKey = req.Headers[IdempotencyKeyHeader]
+	if i >= len(s.responses) {
+		i = len(s.responses) - 1
+	}
+	return s.responses[i], s.errs[i]
+}
+
+func (s *scriptedTransport) SetCSRFToken(string)  {}
+func (s *scriptedTransport) GetCSRFToken() string { return "" }
+func (s *scriptedTransport) Close()               {}
+
+func TestIdempotencyTransport(t *testing.T) {
+	ok := &Response{StatusCode: 200, Body: []byte(`{"meta":{"rc":"ok"}}`)}
+	tests := []struct {
+		name      string
+		responses []*Response
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{"success is replayed", []*Response{ok}, []error{nil}, 1, nil},
+		{"rejection can be resent", []*Response{{StatusCode: 400}, ok}, []error{nil, nil}, 2, nil},
+		{"ambiguous status is not resent", []*Response{{StatusCode: 502}}, []error{nil}, 1, ErrOutcomeUnknown},
+		{"network failure is not resent", []*Response{nil}, []error{errors.New("connection reset")}, 1, ErrOutcomeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &scriptedTransport{responses: tt.responses, errs: tt.errs}
+			tr := NewIdempotencyTransport(inner, time.Minute)
+
+			req := NewRequest("POST", "/cmd/devmgr").WithHeader(IdempotencyKeyHeader, "k1")
+			_, _ = tr.Do(context.Background(), req)
+			resp, err := tr.Do(context.Background(), req)
+
+			if inner.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", inner.calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("second Do() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || resp != ok {
+				t.Errorf("second Do() = %v, %v, want the successful response", resp, err)
+			}
+		})
+	}
+}
+
+func TestIdempotencyTransport_WithoutKey(t *testing.T) {
+	inner := &scriptedTransport{responses: []*Response{nil}, errs: []error{errors.New("timeout")}}
+	tr := NewIdempotencyTransport(inner, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.Do(context.Background(), NewRequest("POST", "/cmd/devmgr")); errors.Is(err, ErrOutcomeUnknown) {
+			t.Fatalf("Do() without key error = %v", err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Errorf("calls = %d, want 2", inner.calls)
+	}
+}
+
+func TestIdempotencyTransport_Expiry(t *testing.T) {
+	inner := &scriptedTransport{responses: []*Response{{StatusCode: 200}}, errs: []error{nil}}
+	tr := NewIdempotencyTransport(inner, time.Millisecond)
+
+	req := NewRequest("POST", "/cmd/devmgr").WithHeader(IdempotencyKeyHeader, "k1")
+	_, _ = tr.Do(context.Background(), req)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = tr.Do(context.Background(), req)
+
+	if inner.calls != 2 {
+		t.Errorf("calls = %d, want 2 after the key expired", inner.calls)
+	}
+}
+
+func TestRetryTransport_StopsOnUnknownOutcome(t *testing.T) {
+	inner := &scriptedTransport{responses: []*Response{nil}, errs: []error{errors.New("connection reset")}}
+	config := DefaultRetryConfig()
+	config.InitialBackoff = time.Millisecond
+	tr := NewRetryTransport(NewIdempotencyTransport(inner, 0), config)
+
+	key := NewIdempotencyKey()
+	_, err := tr.Do(context.Background(), NewRequest("POST", "/cmd/devmgr").WithHeader(IdempotencyKeyHeader, key))
+	if !errors.Is(err, ErrOutcomeUnknown) {
+		t.Errorf("Do() error = %v, want ErrOutcomeUnknown", err)
+	}
+	if inner.calls != 1 || inner.lastKey != key {
+		t.Errorf("calls = %d with key %q, want 1 with %q", inner.calls, inner.lastKey, key)
+	}
+}
@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+)
+
+// unifiOSPrefix is where UniFi OS consoles proxy the Network Application.
+const unifiOSPrefix = "/proxy/network"
+
+// ClassicPath maps a UniFi OS request path to the path a classic
+// (self-hosted) Network Application serves it at: the /proxy/network prefix
+// is dropped and the login and logout endpoints lose their /auth segment.
+// Other paths are returned unchanged.
+func ClassicPath(path string) string {
+	switch path {
+	case "/api/auth/login":
+		return "/api/login"
+	case "/api/auth/logout":
+		return "/api/logout"
+	}
+	if rest, ok := strings.CutPrefix(path, unifiOSPrefix); ok && (rest == "" || rest[0] == '/') {
+		if rest == "" {
+			return "/"
+		}
+		return rest
+	}
+	return path
+}
+
+// PathTransport wraps a Transport and, once classic mode is switched on,
+// rewrites request paths with ClassicPath. The library builds UniFi OS
+// paths throughout; this lets the same requests reach a classic controller.
+type PathTransport struct {
+	transport Transport
+	classic   atomic.Bool
+}
+
+// NewPathTransport creates a PathTransport in UniFi OS mode.
+func NewPathTransport(transport Transport) *PathTransport {
+	return &PathTransport{transport: transport}
+}
+
+// SetClassic switches classic path rewriting on or off.
+func (p *PathTransport) SetClassic(classic bool) {
+	p.classic.Store(classic)
+}
+
+// Classic reports whether paths are rewritten for a classic controller.
+func (p *PathTransport) Classic() bool {
+	return p.classic.Load()
+}
+
+// Path returns path mapped with ClassicPath in classic mode, and path
+// itself otherwise. It is for connections made outside the transport, such
+// as WebSockets.
+func (p *PathTransport) Path(path string) string {
+	if !p.classic.Load() {
+		return path
+	}
+	return ClassicPath(path)
+}
+
+// rewrite returns req, or a copy with its path mapped in classic mode.
+func (p *PathTransport) rewrite(req *Request) *Request {
+	path := p.Path(req.Path)
+	if path == req.Path {
+		return req
+	}
+	r := *req
+	r.Path = path
+	return &r
+}
+
+// Do executes a request on the underlying transport.
+func (p *PathTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	return p.transport.Do(ctx, p.rewrite(req))
+}
+
+// Stream executes a request on the underlying transport without buffering
+// the response body.
+func (p *PathTransport) Stream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	return Stream(ctx, p.transport, p.rewrite(req))
+}
+
+// Stats returns the underlying transport's counters.
+func (p *PathTransport) Stats() Stats {
+	return GetStats(p.transport)
+}
+
+// SetCSRFToken sets the CSRF token on the underlying transport.
+func (p *PathTransport) SetCSRFToken(token string) {
+	p.transport.SetCSRFToken(token)
+}
+
+// GetCSRFToken returns the CSRF token from the underlying transport.
+func (p *PathTransport) GetCSRFToken() string {
+	return p.transport.GetCSRFToken()
+}
+
+// Close closes the underlying transport.
+func (p *PathTransport) Close() {
+	p.transport.Close()
+}
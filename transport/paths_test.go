@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClassicPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/proxy/network/api/s/default/stat/device", "/api/s/default/stat/device"},
+		{"/proxy/network/v2/api/site/default/trafficrules", "/v2/api/site/default/trafficrules"},
+		{"/proxy/network/dl/backup/x.unf", "/dl/backup/x.unf"},
+		{"/proxy/network", "/"},
+		{"/proxy/networkx/api", "/proxy/networkx/api"},
+		{"/api/auth/login", "/api/login"},
+		{"/api/auth/logout", "/api/logout"},
+		{"/api/self", "/api/self"},
+	}
+	for _, tt := range tests {
+		if got := ClassicPath(tt.path); got != tt.want {
+			t.Errorf("ClassicPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPathTransport(t *testing.T) {
+	var got string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	base, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pt := NewPathTransport(base)
+	defer pt.Close()
+
+	ctx := context.Background()
+	req := NewRequest("GET", "/proxy/network/api/s/default/stat/device")
+	if _, err := pt.Do(ctx, req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got != req.Path {
+		t.Errorf("UniFi OS mode requested %q, want %q", got, req.Path)
+	}
+
+	pt.SetClassic(true)
+	if _, err := pt.Do(ctx, req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got != "/api/s/default/stat/device" {
+		t.Errorf("classic mode requested %q, want /api/s/default/stat/device", got)
+	}
+	if req.Path != "/proxy/network/api/s/default/stat/device" {
+		t.Errorf("Do() modified the request path to %q", req.Path)
+	}
+}
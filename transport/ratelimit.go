@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitTransport wraps a Transport and spaces out its requests with a
+// token bucket: up to burst requests are sent at once, after which they
+// are sent at most perSecond per second. A request that has to wait does
+// so until its turn or until its context ends, whichever is first.
+type RateLimitTransport struct {
+	transport Transport
+	perSecond float64
+	burst     float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	throttled atomic.Uint64
+}
+
+// NewRateLimitTransport creates a RateLimitTransport. A burst below 1 is
+// 1; perSecond must be positive.
+func NewRateLimitTransport(transport Transport, perSecond float64, burst int) *RateLimitTransport {
+	b := float64(max(burst, 1))
+	return &RateLimitTransport{
+		transport: transport,
+		perSecond: perSecond,
+		burst:     b,
+		tokens:    b,
+		last:      time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (r *RateLimitTransport) reserve() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.tokens = min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.perSecond)
+	r.last = now
+
+	r.tokens--
+	if r.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-r.tokens / r.perSecond * float64(time.Second))
+}
+
+// cancel returns a token taken by a request that was not sent.
+func (r *RateLimitTransport) cancel() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = min(r.burst, r.tokens+1)
+}
+
+// wait blocks until the request may be sent. It fails at once if ctx
+// would end before then.
+func (r *RateLimitTransport) wait(ctx context.Context) error {
+	delay := r.reserve()
+	if delay == 0 {
+		return nil
+	}
+	r.throttled.Add(1)
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		r.cancel()
+		return fmt.Errorf("rate limit: request would wait %s, past the context deadline: %w", delay.Round(time.Millisecond), context.DeadlineExceeded)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		return ctx.Err()
+	}
+}
+
+// Do waits for the request's turn and executes it.
+func (r *RateLimitTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.transport.Do(ctx, req)
+}
+
+// Stream waits for the request's turn and executes it without buffering
+// the response body.
+func (r *RateLimitTransport) Stream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return Stream(ctx, r.transport, req)
+}
+
+// Stats returns the underlying transport's counters plus the number of
+// requests that had to wait.
+func (r *RateLimitTransport) Stats() Stats {
+	s := GetStats(r.transport)
+	s.Throttled += r.throttled.Load()
+	return s
+}
+
+// SetCSRFToken sets the CSRF token on the underlying transport.
+func (r *RateLimitTransport) SetCSRFToken(token string) {
+	r.transport.SetCSRFToken(token)
+}
+
+// GetCSRFToken returns the CSRF token from the underlying transport.
+func (r *RateLimitTransport) GetCSRFToken() string {
+	return r.transport.GetCSRFToken()
+}
+
+// Close closes the underlying transport.
+func (r *RateLimitTransport) Close() {
+	r.transport.Close()
+}
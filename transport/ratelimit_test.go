@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	inner := &scriptedTransport{responses: []*Response{{StatusCode: 200}}, errs: []error{nil}}
+	limited := NewRateLimitTransport(inner, 20, 2)
+	ctx := context.Background()
+
+	// The burst goes out at once, the next request waits for a token
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := limited.Do(ctx, NewRequest("GET", "/api/test")); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 requests at 20/s with a burst of 2 took %v, want at least 50ms", elapsed)
+	}
+	if inner.calls != 3 {
+		t.Errorf("calls = %d, want 3", inner.calls)
+	}
+	if s := limited.Stats(); s.Throttled != 1 {
+		t.Errorf("Throttled = %d, want 1", s.Throttled)
+	}
+}
+
+func TestRateLimitTransport_Context(t *testing.T) {
+	inner := &scriptedTransport{responses: []*Response{{StatusCode: 200}}, errs: []error{nil}}
+	limited := NewRateLimitTransport(inner, 1, 1)
+
+	if _, err := limited.Do(context.Background(), NewRequest("GET", "/api/test")); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// A deadline the wait cannot meet fails without waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := limited.Do(ctx, NewRequest("GET", "/api/test"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() past the deadline error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Do() past the deadline took %v, want no wait", elapsed)
+	}
+
+	// Cancellation ends the wait
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := limited.Do(ctx, NewRequest("GET", "/api/test")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() cancelled error = %v, want Canceled", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("calls = %d, want only the first request", inner.calls)
+	}
+}
@@ -17,7 +17,15 @@ func NewRequest(method, path string) *Request {
 	}
 }
 
-// WithBody sets the request body.
+// RawBody is a request body sent as is rather than encoded as JSON, such
+// as a multipart file upload.
+type RawBody struct {
+	ContentType string
+	Data        []byte
+}
+
+// WithBody sets the request body. Bodies other than RawBody are encoded
+// as JSON.
 func (r *Request) WithBody(body interface{}) *Request {
 	r.Body = body
 	return r
@@ -2,8 +2,10 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,6 +25,32 @@ type RetryConfig struct {
 
 	// RetryableStatusCodes are HTTP status codes that should trigger a retry.
 	RetryableStatusCodes []int
+
+	// OnRetry, if set, is called for each failed attempt that will be
+	// retried, before waiting.
+	OnRetry func(RetryEvent)
+
+	// OnGiveUp, if set, is called when the last attempt has failed. It is
+	// not called when the context ends first.
+	OnGiveUp func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt of a RetryTransport.
+type RetryEvent struct {
+	Method string
+	Path   string
+
+	// Attempt is the number of the failed attempt, from 1.
+	Attempt int
+
+	// Wait is the backoff before the next attempt, or 0 when giving up.
+	Wait time.Duration
+
+	// StatusCode is the attempt's HTTP status, or 0 if there was no
+	// response. Err is the attempt's error, or nil if it failed with a
+	// retryable status.
+	StatusCode int
+	Err        error
 }
 
 // DefaultRetryConfig returns a RetryConfig with sensible defaults.
@@ -46,6 +74,7 @@ func DefaultRetryConfig() *RetryConfig {
 type RetryTransport struct {
 	transport Transport
 	config    *RetryConfig
+	retries   atomic.Uint64
 }
 
 // NewRetryTransport creates a new RetryTransport.
@@ -76,23 +105,40 @@ func (r *RetryTransport) Do(ctx context.Context, req *Request) (*Response, error
 		// Execute request
 		resp, lastErr = r.transport.Do(ctx, req)
 
+		// A request that may already have run must not be sent again
+		if errors.Is(lastErr, ErrOutcomeUnknown) {
+			return nil, lastErr
+		}
+
 		// If no error and successful response, return immediately
 		if lastErr == nil && !r.shouldRetry(resp) {
 			return resp, nil
 		}
 
+		event := RetryEvent{Method: req.Method, Path: req.Path, Attempt: attempt + 1, Err: lastErr}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+		}
+
 		// Don't retry on last attempt
 		if attempt == r.config.MaxRetries {
+			if r.config.OnGiveUp != nil {
+				r.config.OnGiveUp(event)
+			}
 			break
 		}
 
 		// Calculate backoff
 		backoff := r.calculateBackoff(attempt)
+		if r.config.OnRetry != nil {
+			event.Wait = backoff
+			r.config.OnRetry(event)
+		}
 
 		// Wait before retry
 		select {
 		case <-time.After(backoff):
-			// Continue to next attempt
+			r.retries.Add(1)
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
@@ -106,6 +152,12 @@ func (r *RetryTransport) Do(ctx context.Context, req *Request) (*Response, error
 	return resp, nil
 }
 
+// Stream executes a request on the underlying transport without retries;
+// a partially read body cannot be replayed.
+func (r *RetryTransport) Stream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	return Stream(ctx, r.transport, req)
+}
+
 // shouldRetry determines if a response should trigger a retry.
 func (r *RetryTransport) shouldRetry(resp *Response) bool {
 	if resp == nil {
@@ -132,6 +184,14 @@ func (r *RetryTransport) calculateBackoff(attempt int) time.Duration {
 	return time.Duration(backoff)
 }
 
+// Stats returns the underlying transport's counters plus the number of
+// retries made.
+func (r *RetryTransport) Stats() Stats {
+	s := GetStats(r.transport)
+	s.Retries += r.retries.Load()
+	return s
+}
+
 // SetCSRFToken sets the CSRF token on the underlying transport.
 func (r *RetryTransport) SetCSRFToken(token string) {
 	r.transport.SetCSRFToken(token)
@@ -164,6 +164,58 @@ func TestRetryTransport_ExhaustedRetries(t *testing.T) {
 	}
 }
 
+func TestRetryTransport_Hooks(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	baseTransport, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer baseTransport.Close()
+
+	var retries, gaveUp []RetryEvent
+	retryConfig := DefaultRetryConfig()
+	retryConfig.MaxRetries = 2
+	retryConfig.InitialBackoff = 5 * time.Millisecond
+	retryConfig.OnRetry = func(e RetryEvent) { retries = append(retries, e) }
+	retryConfig.OnGiveUp = func(e RetryEvent) { gaveUp = append(gaveUp, e) }
+
+	if _, err := NewRetryTransport(baseTransport, retryConfig).Do(context.Background(), NewRequest("POST", "/api/test")); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if len(retries) != 2 {
+		t.Fatalf("OnRetry called %d times, want 2", len(retries))
+	}
+	for i, e := range retries {
+		wantWait := 5 * time.Millisecond << i
+		if e.Attempt != i+1 || e.Wait != wantWait || e.StatusCode != http.StatusBadGateway || e.Method != "POST" || e.Path != "/api/test" || e.Err != nil {
+			t.Errorf("retry %d = %+v, want attempt %d, wait %v, status 502", i, e, i+1, wantWait)
+		}
+	}
+	if len(gaveUp) != 1 || gaveUp[0].Attempt != 3 || gaveUp[0].Wait != 0 || gaveUp[0].StatusCode != http.StatusBadGateway {
+		t.Errorf("OnGiveUp events = %+v, want one for attempt 3", gaveUp)
+	}
+
+	// A request that succeeds after a retry does not give up.
+	retries, gaveUp = nil, nil
+	flaky := &scriptedTransport{
+		responses: []*Response{nil, {StatusCode: http.StatusOK}},
+		errs:      []error{context.DeadlineExceeded, nil},
+	}
+	if _, err := NewRetryTransport(flaky, retryConfig).Do(context.Background(), NewRequest("GET", "/api/test")); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if len(retries) != 1 || retries[0].Err != context.DeadlineExceeded || retries[0].StatusCode != 0 || len(gaveUp) != 0 {
+		t.Errorf("events = %+v, %+v, want one retry for the error and no give-up", retries, gaveUp)
+	}
+}
+
 func TestRetryTransport_NoRetryOn4xx(t *testing.T) {
 	var attempts int32
 
@@ -0,0 +1,33 @@
+package transport
+
+// Stats counts the requests made through a transport.
+type Stats struct {
+	// Requests is the number of HTTP requests sent, including retries.
+	Requests uint64 `json:"requests"`
+
+	// Errors is the number of requests that got no response at all,
+	// e.g. connection failures and timeouts.
+	Errors uint64 `json:"errors"`
+
+	// StatusErrors is the number of responses with a non-2xx status.
+	StatusErrors uint64 `json:"status_errors"`
+
+	// Retries is the number of requests repeated by a RetryTransport.
+	Retries uint64 `json:"retries"`
+
+	// Throttled is the number of requests a RateLimitTransport held back.
+	Throttled uint64 `json:"throttled"`
+}
+
+// StatsReporter is implemented by transports that count their requests.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// GetStats returns the counters of t, or zero Stats if t does not keep any.
+func GetStats(t Transport) Stats {
+	if r, ok := t.(StatsReporter); ok {
+		return r.Stats()
+	}
+	return Stats{}
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_Stats(t *testing.T) {
+	var attempts int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	base, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer base.Close()
+
+	retryConfig := DefaultRetryConfig()
+	retryConfig.InitialBackoff = time.Millisecond
+	rt := NewRetryTransport(base, retryConfig)
+
+	if _, err := rt.Do(context.Background(), NewRequest("GET", "/test")); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	want := Stats{Requests: 3, StatusErrors: 2, Retries: 2}
+	if got := GetStats(rt); got != want {
+		t.Errorf("GetStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetStats_NoCounters(t *testing.T) {
+	if got := GetStats(nil); got != (Stats{}) {
+		t.Errorf("GetStats(nil) = %+v, want zero", got)
+	}
+}
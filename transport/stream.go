@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// Streamer is implemented by transports that can return a response body
+// without reading it into memory first. It is used for large downloads
+// such as backup files.
+type Streamer interface {
+	Stream(ctx context.Context, req *Request) (*StreamResponse, error)
+}
+
+// StreamResponse is an HTTP response with an unread body.
+// The caller must close Body.
+type StreamResponse struct {
+	StatusCode int
+	Headers    http.Header
+
+	// ContentLength is the declared body length, or -1 if unknown.
+	ContentLength int64
+
+	Body io.ReadCloser
+}
+
+// IsSuccess returns true if the response indicates success (2xx status code).
+func (r *StreamResponse) IsSuccess() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Stream executes req with t's Stream method if it has one. Otherwise the
+// response is buffered with Do and wrapped as a StreamResponse.
+func Stream(ctx context.Context, t Transport, req *Request) (*StreamResponse, error) {
+	if s, ok := t.(Streamer); ok {
+		return s.Stream(ctx, req)
+	}
+
+	resp, err := t.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamResponse{
+		StatusCode:    resp.StatusCode,
+		Headers:       resp.Headers,
+		ContentLength: int64(len(resp.Body)),
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+	}, nil
+}
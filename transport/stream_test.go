@@ -0,0 +1,71 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransport_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-CSRF-Token", "stream-token")
+		w.Write([]byte("backup-bytes"))
+	}))
+	defer server.Close()
+
+	trans, err := New(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer trans.Close()
+
+	resp, err := Stream(context.Background(), trans, NewRequest("GET", "/dl/backup/file.unf"))
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !resp.IsSuccess() {
+		t.Fatalf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(body) != "backup-bytes" {
+		t.Errorf("Body = %q, want backup-bytes", body)
+	}
+	if trans.GetCSRFToken() != "stream-token" {
+		t.Errorf("CSRF token = %q, want stream-token", trans.GetCSRFToken())
+	}
+}
+
+func TestStream_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("buffered"))
+	}))
+	defer server.Close()
+
+	base, err := New(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer base.Close()
+
+	// Hide the Streamer implementation to exercise the Do fallback.
+	trans := struct{ Transport }{base}
+
+	resp, err := Stream(context.Background(), trans, NewRequest("GET", "/"))
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "buffered" || resp.ContentLength != int64(len("buffered")) {
+		t.Errorf("Body = %q (length %d), want buffered", body, resp.ContentLength)
+	}
+}
@@ -34,6 +34,11 @@ type httpTransport struct {
 	baseURL   *url.URL
 	csrfToken atomic.Value // stores string
 	userAgent string
+	headers   http.Header
+
+	requests     atomic.Uint64
+	errors       atomic.Uint64
+	statusErrors atomic.Uint64
 }
 
 // New creates a new HTTP transport.
@@ -87,6 +92,7 @@ func New(config *Config, opts ...Option) (Transport, error) {
 		client:    client,
 		baseURL:   baseURL,
 		userAgent: config.UserAgent,
+		headers:   config.Headers.Clone(),
 	}
 
 	// Initialize CSRF token as empty string
@@ -97,6 +103,46 @@ func New(config *Config, opts ...Option) (Transport, error) {
 
 // Do executes an HTTP request.
 func (t *httpTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	httpResp, err := t.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Create response
+	resp := &Response{
+		StatusCode: httpResp.StatusCode,
+		Body:       body,
+		Headers:    httpResp.Header,
+	}
+
+	return resp, nil
+}
+
+// Stream executes an HTTP request without buffering the response body.
+func (t *httpTransport) Stream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	httpResp, err := t.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamResponse{
+		StatusCode:    httpResp.StatusCode,
+		Headers:       httpResp.Header,
+		ContentLength: httpResp.ContentLength,
+		Body:          httpResp.Body,
+	}, nil
+}
+
+// send builds and executes an HTTP request. The caller must close the
+// response body.
+func (t *httpTransport) send(ctx context.Context, req *Request) (*http.Response, error) {
 	// Build full URL
 	fullURL, err := t.baseURL.Parse(req.Path)
 	if err != nil {
@@ -105,7 +151,11 @@ func (t *httpTransport) Do(ctx context.Context, req *Request) (*Response, error)
 
 	// Serialize body if present
 	var bodyReader io.Reader
-	if req.Body != nil {
+	contentType := "application/json"
+	if raw, ok := req.Body.(RawBody); ok {
+		bodyReader = bytes.NewReader(raw.Data)
+		contentType = raw.ContentType
+	} else if req.Body != nil {
 		bodyBytes, err := json.Marshal(req.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
@@ -122,12 +172,20 @@ func (t *httpTransport) Do(ctx context.Context, req *Request) (*Response, error)
 	// Set default headers
 	httpReq.Header.Set("Accept", "application/json")
 	if req.Body != nil {
-		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Content-Type", contentType)
 	}
 	if t.userAgent != "" {
 		httpReq.Header.Set("User-Agent", t.userAgent)
 	}
 
+	// Add static headers
+	for k, vs := range t.headers {
+		httpReq.Header.Del(k)
+		for _, v := range vs {
+			httpReq.Header.Add(k, v)
+		}
+	}
+
 	// Add CSRF token if available
 	if token := t.GetCSRFToken(); token != "" {
 		httpReq.Header.Set("X-CSRF-Token", token)
@@ -139,16 +197,14 @@ func (t *httpTransport) Do(ctx context.Context, req *Request) (*Response, error)
 	}
 
 	// Execute request
+	t.requests.Add(1)
 	httpResp, err := t.client.Do(httpReq)
 	if err != nil {
+		t.errors.Add(1)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer httpResp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		t.statusErrors.Add(1)
 	}
 
 	// Check for CSRF token in response headers
@@ -156,14 +212,7 @@ func (t *httpTransport) Do(ctx context.Context, req *Request) (*Response, error)
 		t.SetCSRFToken(csrfToken)
 	}
 
-	// Create response
-	resp := &Response{
-		StatusCode: httpResp.StatusCode,
-		Body:       body,
-		Headers:    httpResp.Header,
-	}
-
-	return resp, nil
+	return httpResp, nil
 }
 
 // SetCSRFToken sets the CSRF token.
@@ -181,7 +230,36 @@ func (t *httpTransport) GetCSRFToken() string {
 	return ""
 }
 
+// Stats returns the transport's request counters.
+func (t *httpTransport) Stats() Stats {
+	return Stats{
+		Requests:     t.requests.Load(),
+		Errors:       t.errors.Load(),
+		StatusErrors: t.statusErrors.Load(),
+	}
+}
+
 // Close closes any idle connections.
 func (t *httpTransport) Close() {
 	t.client.CloseIdleConnections()
 }
+
+// CookieJar returns the jar holding the session cookies.
+func (t *httpTransport) CookieJar() http.CookieJar {
+	return t.client.Jar
+}
+
+// CookieJarProvider is implemented by transports that keep the session
+// cookies in a jar, so that connections made outside the transport, such
+// as WebSockets, can send them too.
+type CookieJarProvider interface {
+	CookieJar() http.CookieJar
+}
+
+// GetCookieJar returns the cookie jar of t, or nil if t has none.
+func GetCookieJar(t Transport) http.CookieJar {
+	if p, ok := t.(CookieJarProvider); ok {
+		return p.CookieJar()
+	}
+	return nil
+}
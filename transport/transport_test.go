@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -158,6 +159,34 @@ func TestTransport_Do_POST(t *testing.T) {
 	}
 }
 
+func TestTransport_Do_RawBody(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "text/plain" {
+			t.Errorf("Content-Type = %s, want text/plain", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "raw data" {
+			t.Errorf("body = %q, want raw data", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	transport, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer transport.Close()
+
+	req := NewRequest("POST", "/api/upload").
+		WithBody(RawBody{ContentType: "text/plain", Data: []byte("raw data")})
+	if _, err := transport.Do(context.Background(), req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+}
+
 func TestTransport_CSRFToken(t *testing.T) {
 	// Create test server
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -270,6 +299,43 @@ func TestTransport_CustomHeaders(t *testing.T) {
 	}
 }
 
+func TestTransport_StaticHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	transport, err := New(config,
+		WithUserAgent("backup-job/2.1"),
+		WithHeaders(http.Header{
+			"Proxy-Authorization": {"Bearer proxy-token"},
+			"X-Team":              {"netops", "oncall"},
+		}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer transport.Close()
+
+	req := NewRequest("GET", "/api/test").WithHeader("X-Team", "override")
+	if _, err := transport.Do(context.Background(), req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if ua := got.Get("User-Agent"); ua != "backup-job/2.1" {
+		t.Errorf("User-Agent = %q, want backup-job/2.1", ua)
+	}
+	if auth := got.Get("Proxy-Authorization"); auth != "Bearer proxy-token" {
+		t.Errorf("Proxy-Authorization = %q, want the static header", auth)
+	}
+	if team := got.Values("X-Team"); len(team) != 1 || team[0] != "override" {
+		t.Errorf("X-Team = %v, want the request header to take precedence", team)
+	}
+}
+
 func TestTransport_ContextCancellation(t *testing.T) {
 	// Create test server with delay
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// AnomalyCategory groups anomaly kinds the way the Insights view does.
+type AnomalyCategory string
+
+// Anomaly categories.
+const (
+	AnomalyRoaming AnomalyCategory = "roaming"
+	AnomalyDHCP    AnomalyCategory = "dhcp"
+	AnomalyDNS     AnomalyCategory = "dns"
+	AnomalyRetries AnomalyCategory = "retries"
+	AnomalyLatency AnomalyCategory = "latency"
+	AnomalyOther   AnomalyCategory = "other"
+)
+
+// Anomaly is a kind of problem the controller detected for one client,
+// with the times it occurred.
+type Anomaly struct {
+	// Kind is the controller's name for the anomaly, such as
+	// "USER_HIGH_WIFI_RETRIES" or "USER_DHCP_TIMEOUT".
+	Kind       string    `json:"anomaly"`
+	MAC        string    `json:"mac"`
+	Timestamps []FlexInt `json:"timestamps"`
+}
+
+// Category classifies the anomaly by its kind.
+func (a *Anomaly) Category() AnomalyCategory {
+	kind := strings.ToUpper(a.Kind)
+	switch {
+	case strings.Contains(kind, "ROAM"):
+		return AnomalyRoaming
+	case strings.Contains(kind, "DHCP"):
+		return AnomalyDHCP
+	case strings.Contains(kind, "DNS"):
+		return AnomalyDNS
+	case strings.Contains(kind, "RETR"):
+		return AnomalyRetries
+	case strings.Contains(kind, "LATENCY"):
+		return AnomalyLatency
+	default:
+		return AnomalyOther
+	}
+}
+
+// Times returns the occurrences in ascending order.
+func (a *Anomaly) Times() []time.Time {
+	times := make([]time.Time, len(a.Timestamps))
+	for i, ts := range a.Timestamps {
+		times[i] = EpochTime(ts.Int64())
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// Between returns a copy with only the occurrences from start to end,
+// inclusive. A zero start or end leaves that side open.
+func (a *Anomaly) Between(start, end time.Time) Anomaly {
+	out := Anomaly{Kind: a.Kind, MAC: a.MAC}
+	for _, ts := range a.Timestamps {
+		t := EpochTime(ts.Int64())
+		if (start.IsZero() || !t.Before(start)) && (end.IsZero() || !t.After(end)) {
+			out.Timestamps = append(out.Timestamps, ts)
+		}
+	}
+	return out
+}
+
+// AnomalyCount summarizes one kind of anomaly across clients.
+type AnomalyCount struct {
+	Kind     string          `json:"kind"`
+	Category AnomalyCategory `json:"category"`
+
+	// Occurrences is the number of times it occurred, and Clients the
+	// lowercase MAC addresses of the clients affected, sorted.
+	Occurrences int      `json:"occurrences"`
+	Clients     []string `json:"clients"`
+
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// AnomalySummary is the Insights view of a site's anomalies over a period.
+type AnomalySummary struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Kinds are sorted by occurrences, most frequent first.
+	Kinds []AnomalyCount `json:"kinds"`
+
+	// Categories counts occurrences by category.
+	Categories map[AnomalyCategory]int `json:"categories"`
+}
+
+// NewAnomalySummary counts the anomalies by kind and category.
+func NewAnomalySummary(anomalies []Anomaly, start, end time.Time) *AnomalySummary {
+	summary := &AnomalySummary{Start: start, End: end, Categories: make(map[AnomalyCategory]int)}
+
+	byKind := make(map[string]*AnomalyCount)
+	clients := make(map[string]map[string]bool)
+	for i := range anomalies {
+		a := &anomalies[i]
+		times := a.Times()
+		if len(times) == 0 {
+			continue
+		}
+
+		count := byKind[a.Kind]
+		if count == nil {
+			count = &AnomalyCount{Kind: a.Kind, Category: a.Category(), First: times[0]}
+			byKind[a.Kind] = count
+			clients[a.Kind] = make(map[string]bool)
+		}
+		count.Occurrences += len(times)
+		if times[0].Before(count.First) {
+			count.First = times[0]
+		}
+		if last := times[len(times)-1]; last.After(count.Last) {
+			count.Last = last
+		}
+		clients[a.Kind][strings.ToLower(a.MAC)] = true
+		summary.Categories[count.Category] += len(times)
+	}
+
+	for kind, count := range byKind {
+		for mac := range clients[kind] {
+			count.Clients = append(count.Clients, mac)
+		}
+		sort.Strings(count.Clients)
+		summary.Kinds = append(summary.Kinds, *count)
+	}
+	sort.Slice(summary.Kinds, func(i, j int) bool {
+		if summary.Kinds[i].Occurrences != summary.Kinds[j].Occurrences {
+			return summary.Kinds[i].Occurrences > summary.Kinds[j].Occurrences
+		}
+		return summary.Kinds[i].Kind < summary.Kinds[j].Kind
+	})
+	return summary
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAnomalySummary(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(hours ...int) []FlexInt {
+		var ts []FlexInt
+		for _, h := range hours {
+			ts = append(ts, FlexInt{Val: float64(base.Add(time.Duration(h) * time.Hour).UnixMilli())})
+		}
+		return ts
+	}
+	anomalies := []Anomaly{
+		{Kind: "USER_HIGH_WIFI_RETRIES", MAC: "AA:00:00:00:00:01", Timestamps: at(3, 1)},
+		{Kind: "USER_HIGH_WIFI_RETRIES", MAC: "aa:00:00:00:00:02", Timestamps: at(2)},
+		{Kind: "USER_DHCP_TIMEOUT", MAC: "aa:00:00:00:00:01", Timestamps: at(0)},
+		{Kind: "USER_SLOW_ROAMING", MAC: "aa:00:00:00:00:03", Timestamps: nil},
+	}
+
+	s := NewAnomalySummary(anomalies, base, base.Add(24*time.Hour))
+	if len(s.Kinds) != 2 {
+		t.Fatalf("Kinds = %+v, want 2 kinds with occurrences", s.Kinds)
+	}
+	retries := s.Kinds[0]
+	if retries.Kind != "USER_HIGH_WIFI_RETRIES" || retries.Category != AnomalyRetries || retries.Occurrences != 3 ||
+		!reflect.DeepEqual(retries.Clients, []string{"aa:00:00:00:00:01", "aa:00:00:00:00:02"}) {
+		t.Errorf("Kinds[0] = %+v", retries)
+	}
+	if !retries.First.Equal(base.Add(time.Hour)) || !retries.Last.Equal(base.Add(3*time.Hour)) {
+		t.Errorf("retries first %v last %v, want hours 1 and 3", retries.First, retries.Last)
+	}
+	want := map[AnomalyCategory]int{AnomalyRetries: 3, AnomalyDHCP: 1}
+	if !reflect.DeepEqual(s.Categories, want) {
+		t.Errorf("Categories = %v, want %v", s.Categories, want)
+	}
+
+	between := anomalies[0].Between(base.Add(2*time.Hour), time.Time{})
+	if len(between.Timestamps) != 1 || between.Kind != anomalies[0].Kind {
+		t.Errorf("Between() = %+v, want the occurrence at hour 3", between)
+	}
+}
+
+func TestAnomaly_Category(t *testing.T) {
+	tests := map[string]AnomalyCategory{
+		"USER_SLOW_ROAMING":      AnomalyRoaming,
+		"USER_DHCP_TIMEOUT":      AnomalyDHCP,
+		"USER_DNS_TIMEOUT":       AnomalyDNS,
+		"USER_HIGH_WIFI_RETRIES": AnomalyRetries,
+		"USER_HIGH_TCP_LATENCY":  AnomalyLatency,
+		"USER_SLEEPY_CLIENT":     AnomalyOther,
+	}
+	for kind, want := range tests {
+		a := Anomaly{Kind: kind}
+		if got := a.Category(); got != want {
+			t.Errorf("Category(%s) = %s, want %s", kind, got, want)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Client represents a connected client/station.
 type Client struct {
 	ID              string  `json:"_id,omitempty"`
@@ -9,10 +11,10 @@ type Client struct {
 	Name            string  `json:"name,omitempty"`
 	OUI             string  `json:"oui,omitempty"`
 	IP              string  `json:"ip,omitempty"`
-	IsGuest         bool    `json:"is_guest,omitempty"`
-	IsWired         bool    `json:"is_wired,omitempty"`
-	FirstSeen       int64   `json:"first_seen,omitempty"`
-	LastSeen        int64   `json:"last_seen,omitempty"`
+	IsGuest         FlexBool `json:"is_guest,omitzero"`
+	IsWired         FlexBool `json:"is_wired,omitzero"`
+	FirstSeen       FlexTime `json:"first_seen,omitzero"`
+	LastSeen        FlexTime `json:"last_seen,omitzero"`
 	Uptime          FlexInt `json:"uptime,omitempty"`
 
 	// Connection info
@@ -45,7 +47,7 @@ type Client struct {
 	Authorized      bool    `json:"authorized,omitempty"`
 	Blocked         bool    `json:"blocked,omitempty"`
 	Note            string  `json:"note,omitempty"`
-	Noted           bool    `json:"noted,omitempty"`
+	Noted           FlexBool `json:"noted,omitzero"`
 	UseFixedIP      bool    `json:"use_fixedip,omitempty"`
 	FixedIP         string  `json:"fixed_ip,omitempty"`
 	UsergroupID     string  `json:"usergroup_id,omitempty"`
@@ -70,3 +72,72 @@ type Client struct {
 	IdleTime        FlexInt `json:"idletime,omitempty"`
 	Anomalies       int     `json:"anomalies,omitempty"`
 }
+
+// FirstSeenTime returns FirstSeen as time.Time.
+func (c *Client) FirstSeenTime() time.Time {
+	return c.FirstSeen.Val
+}
+
+// LastSeenTime returns LastSeen as time.Time.
+func (c *Client) LastSeenTime() time.Time {
+	return c.LastSeen.Val
+}
+
+// Guest is a guest authorization as reported by stat/guest.
+type Guest struct {
+	ID       string `json:"_id"`
+	MAC      string `json:"mac"`
+	APMAC    string `json:"ap_mac,omitempty"`
+	SiteID   string `json:"site_id,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	Name     string `json:"name,omitempty"`
+
+	// AuthorizedBy is how the guest was authorized: "api", "voucher",
+	// "password", "radius", "none" (click-through) or a payment provider.
+	AuthorizedBy string `json:"authorized_by,omitempty"`
+	VoucherID    string `json:"voucher_id,omitempty"`
+	VoucherCode  string `json:"voucher_code,omitempty"`
+
+	Start    int64 `json:"start"`
+	End      int64 `json:"end"`
+	Duration int   `json:"duration,omitempty"` // minutes
+	Expired  bool  `json:"expired"`
+
+	// Limits; zero means unlimited.
+	QOSRateMaxUp   int `json:"qos_rate_max_up,omitempty"`   // Kbps
+	QOSRateMaxDown int `json:"qos_rate_max_down,omitempty"` // Kbps
+	QOSUsageQuota  int `json:"qos_usage_quota,omitempty"`   // MB
+
+	RXBytes FlexInt `json:"rx_bytes,omitempty"`
+	TXBytes FlexInt `json:"tx_bytes,omitempty"`
+	Bytes   FlexInt `json:"bytes,omitempty"`
+}
+
+// StartTime returns Start as time.Time.
+func (g *Guest) StartTime() time.Time {
+	return EpochTime(g.Start)
+}
+
+// EndTime returns the time the authorization expires (or expired).
+func (g *Guest) EndTime() time.Time {
+	return EpochTime(g.End)
+}
+
+// Active reports whether the authorization is in effect at t.
+func (g *Guest) Active(t time.Time) bool {
+	return !g.Expired && t.Before(g.EndTime())
+}
+
+// UsedBytes returns the data transferred under the authorization.
+func (g *Guest) UsedBytes() int64 {
+	if used := g.RXBytes.Int64() + g.TXBytes.Int64(); used > 0 {
+		return used
+	}
+	return g.Bytes.Int64()
+}
+
+// QuotaBytes returns the data quota in bytes, or 0 if unlimited.
+func (g *Guest) QuotaBytes() int64 {
+	return int64(g.QOSUsageQuota) * 1024 * 1024
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestClient_UnmarshalJSON(t *testing.T) {
@@ -48,3 +49,61 @@ func TestClient_UnmarshalJSON(t *testing.T) {
 		t.Errorf("Signal = %v, want -45", client.Signal.Int())
 	}
 }
+
+func TestClient_UnmarshalJSON_FlexBools(t *testing.T) {
+	// Older USG firmware encodes these flags as strings and numbers.
+	jsonData := `{
+		"mac": "aa:bb:cc:dd:ee:ff",
+		"is_guest": 0,
+		"is_wired": "true",
+		"noted": "1"
+	}`
+
+	var client Client
+	if err := json.Unmarshal([]byte(jsonData), &client); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if client.IsGuest.Bool() {
+		t.Error("IsGuest should be false")
+	}
+	if !client.IsWired.Bool() {
+		t.Error("IsWired should be true")
+	}
+	if !client.Noted.Bool() {
+		t.Error("Noted should be true")
+	}
+}
+
+func TestClient_UnmarshalJSON_FlexTimes(t *testing.T) {
+	// Some firmware sends timestamps as strings or in milliseconds.
+	jsonData := `{
+		"mac": "aa:bb:cc:dd:ee:ff",
+		"first_seen": "1642567890",
+		"last_seen": 1642654290123
+	}`
+
+	var client Client
+	if err := json.Unmarshal([]byte(jsonData), &client); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got := client.FirstSeenTime(); !got.Equal(time.Unix(1642567890, 0)) {
+		t.Errorf("FirstSeenTime() = %v", got)
+	}
+	if got := client.LastSeenTime(); !got.Equal(time.UnixMilli(1642654290123)) {
+		t.Errorf("LastSeenTime() = %v", got)
+	}
+
+	data, err := json.Marshal(client)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if fields["first_seen"] != float64(1642567890) || fields["last_seen"] != float64(1642654290123) {
+		t.Errorf("Marshal() times = %v, %v, want original units", fields["first_seen"], fields["last_seen"])
+	}
+}
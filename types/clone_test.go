@@ -0,0 +1,228 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDevice_Clone(t *testing.T) {
+	orig := &Device{
+		ID:            "device1",
+		PortOverrides: []PortOverride{{PortIdx: 1, PoeMode: "auto"}},
+		PortTable:     []PortTable{{PortIdx: 1, PortDelta: &PortDelta{TimeMS: 100}}},
+		Uplink:        &DeviceUplink{Name: "eth0"},
+	}
+
+	clone := orig.Clone()
+
+	clone.ID = "device2"
+	clone.PortOverrides[0].PoeMode = "off"
+	clone.PortOverrides = append(clone.PortOverrides, PortOverride{PortIdx: 2})
+	clone.PortTable[0].PortDelta.TimeMS = 200
+	clone.Uplink.Name = "eth1"
+
+	if orig.ID != "device1" {
+		t.Errorf("orig ID = %s, want device1", orig.ID)
+	}
+	if orig.PortOverrides[0].PoeMode != "auto" || len(orig.PortOverrides) != 1 {
+		t.Errorf("orig PortOverrides modified: %+v", orig.PortOverrides)
+	}
+	if orig.PortTable[0].PortDelta.TimeMS != 100 {
+		t.Errorf("orig PortDelta modified: %d", orig.PortTable[0].PortDelta.TimeMS)
+	}
+	if orig.Uplink.Name != "eth0" {
+		t.Errorf("orig Uplink modified: %s", orig.Uplink.Name)
+	}
+}
+
+func TestWLAN_Clone(t *testing.T) {
+	orig := &WLAN{
+		Name:                 "Home",
+		MACFilterList:        []string{"aa:bb:cc:dd:ee:ff"},
+		ScheduleWithDuration: []WLANSchedule{{}},
+	}
+
+	clone := orig.Clone()
+	clone.MACFilterList[0] = "11:22:33:44:55:66"
+
+	if orig.MACFilterList[0] != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("orig MACFilterList modified: %v", orig.MACFilterList)
+	}
+	if len(clone.ScheduleWithDuration) != 1 {
+		t.Errorf("clone ScheduleWithDuration = %v", clone.ScheduleWithDuration)
+	}
+}
+
+func TestFirewallRule_Clone(t *testing.T) {
+	orig := &FirewallRule{SrcFirewallGroupIDs: []string{"g1"}}
+
+	clone := orig.Clone()
+	clone.SrcFirewallGroupIDs[0] = "g2"
+
+	if orig.SrcFirewallGroupIDs[0] != "g1" {
+		t.Errorf("orig SrcFirewallGroupIDs modified: %v", orig.SrcFirewallGroupIDs)
+	}
+}
+
+func TestClone_Nil(t *testing.T) {
+	var d *Device
+	if d.Clone() != nil {
+		t.Error("nil Device.Clone() should return nil")
+	}
+
+	var n *Network
+	if n.Clone() != nil {
+		t.Error("nil Network.Clone() should return nil")
+	}
+}
+
+// TestClone_SharesNothing fills every reference in each type with Clone
+// and checks the copy shares none of them, which also catches a
+// clone_gen.go that is stale after a field was added.
+func TestClone_SharesNothing(t *testing.T) {
+	clones := []any{
+		(*Device).Clone, (*DeviceBasic).Clone, (*PortOverride).Clone,
+		(*Network).Clone, (*WLAN).Clone, (*WLANGroup).Clone,
+		(*FirewallRule).Clone, (*FirewallGroup).Clone, (*TrafficRule).Clone,
+		(*PortForward).Clone, (*PortProfile).Clone, (*Client).Clone,
+		(*User).Clone, (*UserGroup).Clone, (*Route).Clone,
+		(*DNSRecord).Clone, (*RADIUSProfile).Clone, (*DynamicDNS).Clone,
+		(*Site).Clone,
+	}
+
+	for _, fn := range clones {
+		clone := reflect.ValueOf(fn)
+		typ := clone.Type().In(0).Elem()
+		t.Run(typ.Name(), func(t *testing.T) {
+			orig := reflect.New(typ)
+			fill(orig.Elem(), 3)
+
+			copied := clone.Call([]reflect.Value{orig})[0]
+			if !reflect.DeepEqual(orig.Interface(), copied.Interface()) {
+				t.Fatal("clone differs from the original")
+			}
+			if path := shared(orig.Elem(), copied.Elem(), typ.Name()); path != "" {
+				t.Errorf("%s is shared with the original", path)
+			}
+		})
+	}
+}
+
+// fill sets every nil pointer, slice, map and interface in v, down to
+// depth levels of nesting.
+func fill(v reflect.Value, depth int) {
+	if depth == 0 || !v.CanSet() && v.Kind() != reflect.Struct {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem(), depth-1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0), depth-1)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fill(elem, depth-1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf([]any{"value"}))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fill(v.Field(i), depth)
+		}
+	}
+}
+
+// shared returns the path of the first reference a and b have in common,
+// or "" if there is none.
+func shared(a, b reflect.Value, path string) string {
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		return shared(a.Elem(), b.Elem(), path)
+	case reflect.Slice:
+		if a.Len() == 0 || b.Len() == 0 {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		for i := 0; i < a.Len(); i++ {
+			if p := shared(a.Index(i), b.Index(i), path+"[]"); p != "" {
+				return p
+			}
+		}
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return ""
+		}
+		if a.Pointer() == b.Pointer() {
+			return path
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			if p := shared(iter.Value(), b.MapIndex(iter.Key()), path+"[k]"); p != "" {
+				return p
+			}
+		}
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return ""
+		}
+		return shared(a.Elem(), b.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).IsExported() {
+				if p := shared(a.Field(i), b.Field(i), path+"."+a.Type().Field(i).Name); p != "" {
+					return p
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func TestDeepCopy_MapsAndInterfaces(t *testing.T) {
+	orig := AdminUser{
+		Permissions: map[string]interface{}{
+			"network": []interface{}{"admin"},
+			"nested":  map[string]interface{}{"k": "v"},
+		},
+	}
+
+	clone := DeepCopy(orig)
+	clone.Permissions["network"].([]interface{})[0] = "readonly"
+	clone.Permissions["nested"].(map[string]interface{})["k"] = "changed"
+	clone.Permissions["new"] = true
+
+	if orig.Permissions["network"].([]interface{})[0] != "admin" {
+		t.Error("orig nested slice modified")
+	}
+	if orig.Permissions["nested"].(map[string]interface{})["k"] != "v" {
+		t.Error("orig nested map modified")
+	}
+	if _, ok := orig.Permissions["new"]; ok {
+		t.Error("orig map gained a key")
+	}
+}
+
+func TestDeepCopy_UnexportedFields(t *testing.T) {
+	now := time.Now()
+	orig := FlexTime{Val: now}
+
+	clone := DeepCopy(orig)
+	if !clone.Val.Equal(now) {
+		t.Errorf("clone Val = %v, want %v", clone.Val, now)
+	}
+}
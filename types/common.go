@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -8,9 +9,73 @@ import (
 )
 
 // APIResponse is a generic wrapper for UniFi API responses.
+//
+// Besides the classic {"meta": ..., "data": [...]} envelope it decodes the
+// forms used by the v2 API: a plain array, a {"data": [...]} object with
+// paging fields, and a bare object, which becomes the only element of Data.
 type APIResponse[T any] struct {
 	Meta ResponseMeta `json:"meta"`
 	Data []T          `json:"data"`
+
+	// TotalCount is the number of items across all pages, if the v2 API
+	// reports it (totalCount).
+	TotalCount int `json:"totalCount,omitempty"`
+
+	// Offset and Limit describe the page, if the v2 API reports them.
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+}
+
+// UnmarshalJSON decodes any of the envelopes described on APIResponse.
+func (r *APIResponse[T]) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		*r = APIResponse[T]{}
+		return nil
+	case trimmed[0] == '[':
+		*r = APIResponse[T]{}
+		return json.Unmarshal(trimmed, &r.Data)
+	case trimmed[0] != '{':
+		return fmt.Errorf("unexpected API response: %.20s", trimmed)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return err
+	}
+	_, hasMeta := fields["meta"]
+	_, hasData := fields["data"]
+	if !hasMeta && !hasData {
+		var item T
+		if err := json.Unmarshal(trimmed, &item); err != nil {
+			return err
+		}
+		*r = APIResponse[T]{Data: []T{item}}
+		return nil
+	}
+
+	// Decode the envelope without this method.
+	type envelope APIResponse[T]
+	var env envelope
+	if err := json.Unmarshal(trimmed, &env); err != nil {
+		return err
+	}
+	*r = APIResponse[T](env)
+	return nil
+}
+
+// Total returns the number of items across all pages: TotalCount if
+// reported, else Meta.Count, else the length of Data.
+func (r *APIResponse[T]) Total() int {
+	switch {
+	case r.TotalCount > 0:
+		return r.TotalCount
+	case r.Meta.Count > 0:
+		return r.Meta.Count
+	default:
+		return len(r.Data)
+	}
 }
 
 // ResponseMeta contains metadata about the API response.
@@ -20,6 +85,69 @@ type ResponseMeta struct {
 	Count   int    `json:"count,omitempty"`
 }
 
+// ResponseError is the error for a request the controller refused: a
+// non-2xx status, or an error rc in the meta of a 2xx response.
+type ResponseError struct {
+	// Op describes the failed operation, e.g. "list devices". It is empty
+	// for an error rc in a successful response.
+	Op string
+
+	// StatusCode is the HTTP status code, or 0 if only the body was seen.
+	StatusCode int
+
+	// RC is the meta rc, e.g. "error", if the body had one.
+	RC string
+
+	// Message is the meta msg, or the message of a v2 error body.
+	Message string
+
+	// Code is the UniFi api.err code, e.g. "api.err.IdInvalid", if the
+	// body had one.
+	Code string
+}
+
+// Error implements the error interface.
+func (e *ResponseError) Error() string {
+	switch {
+	case e.Op == "":
+		return fmt.Sprintf("API error: %s (rc=%s)", e.Message, e.RC)
+	case e.Message != "":
+		return fmt.Sprintf("%s failed with status %d: %s", e.Op, e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("%s failed with status %d", e.Op, e.StatusCode)
+	}
+}
+
+// NewResponseError returns the error for a failed request of op, taking
+// the rc, message and api.err code from the response body: the classic
+// {"meta": {"rc": ..., "msg": ...}} envelope or a v2 {"code": ...,
+// "message": ...} object.
+func NewResponseError(op string, statusCode int, body []byte) *ResponseError {
+	e := &ResponseError{Op: op, StatusCode: statusCode}
+
+	var parsed struct {
+		Meta    ResponseMeta `json:"meta"`
+		Code    string       `json:"code"`
+		Message string       `json:"message"`
+	}
+	if json.Unmarshal(body, &parsed) != nil {
+		return e
+	}
+
+	e.RC = parsed.Meta.RC
+	e.Message = parsed.Meta.Message
+	if e.Message == "" {
+		e.Message = parsed.Message
+	}
+	for _, code := range []string{parsed.Code, parsed.Meta.Message} {
+		if strings.HasPrefix(code, "api.err.") {
+			e.Code = code
+			break
+		}
+	}
+	return e
+}
+
 // CommandRequest is a generic command request structure.
 type CommandRequest struct {
 	Cmd string `json:"cmd"`
@@ -28,9 +156,14 @@ type CommandRequest struct {
 	MAC      string `json:"mac,omitempty"`
 	Duration int    `json:"duration,omitempty"`
 
-	// For upgrades
+	// For upgrades, and the inform URL for advanced adoption
 	URL string `json:"url,omitempty"`
 
+	// For advanced adoption over SSH
+	IP       string `json:"ip,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
 	// For LED override
 	Mode string `json:"mode,omitempty"`
 
@@ -91,6 +224,18 @@ const (
 	DeviceStateDeleting     DeviceState = 8
 	DeviceStateInformed     DeviceState = 9
 	DeviceStateUpgrading    DeviceState = 10
+	DeviceStateIsolated     DeviceState = 11
+)
+
+// Descriptive aliases for states whose short names hide their meaning.
+const (
+	// DeviceStateFirmwareMismatch means the device firmware is incompatible
+	// with the controller and needs an upgrade before it can be managed.
+	DeviceStateFirmwareMismatch = DeviceStateFirmware
+
+	// DeviceStateHeartbeatMissed means the device stopped reporting in but
+	// has not yet been declared offline.
+	DeviceStateHeartbeatMissed = DeviceStateHeartbeat
 )
 
 // String returns a string representation of the device state.
@@ -118,11 +263,37 @@ func (s DeviceState) String() string {
 		return "informed"
 	case DeviceStateUpgrading:
 		return "upgrading"
+	case DeviceStateIsolated:
+		return "isolated"
 	default:
 		return fmt.Sprintf("unknown(%d)", s)
 	}
 }
 
+// IsOnline returns true if the controller currently has a working connection
+// to the device, including while it provisions or upgrades.
+func (s DeviceState) IsOnline() bool {
+	switch s {
+	case DeviceStateConnected, DeviceStateFirmware, DeviceStateProvisioning, DeviceStateUpgrading:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransitional returns true for states the device leaves on its own, such
+// as provisioning or adopting. Callers waiting for a device to settle should
+// keep polling while this is true.
+func (s DeviceState) IsTransitional() bool {
+	switch s {
+	case DeviceStateProvisioning, DeviceStateUpgrading, DeviceStateAdopting,
+		DeviceStateDeleting, DeviceStateHeartbeat:
+		return true
+	default:
+		return false
+	}
+}
+
 // UnmarshalJSON implements json.Unmarshaler for DeviceState.
 func (s *DeviceState) UnmarshalJSON(data []byte) error {
 	var val int
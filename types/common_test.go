@@ -44,6 +44,97 @@ func TestAPIResponse_Unmarshal(t *testing.T) {
 	}
 }
 
+func TestAPIResponse_UnmarshalV2(t *testing.T) {
+	type TestData struct {
+		ID string `json:"_id"`
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantIDs   int
+		wantTotal int
+		wantMsg   string
+	}{
+		{"plain array", `[{"_id":"1"},{"_id":"2"}]`, 2, 2, ""},
+		{"paged data", `{"data":[{"_id":"1"}],"totalCount":40,"offset":0,"limit":1}`, 1, 40, ""},
+		{"data and meta", `{"meta":{"rc":"ok","msg":"done","count":7},"data":[{"_id":"1"}]}`, 1, 7, "done"},
+		{"bare object", `{"_id":"1"}`, 1, 1, ""},
+		{"null", `null`, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp APIResponse[TestData]
+			if err := json.Unmarshal([]byte(tt.input), &resp); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if len(resp.Data) != tt.wantIDs {
+				t.Errorf("len(Data) = %d, want %d", len(resp.Data), tt.wantIDs)
+			}
+			if tt.wantIDs > 0 && resp.Data[0].ID != "1" {
+				t.Errorf("Data[0].ID = %q, want 1", resp.Data[0].ID)
+			}
+			if got := resp.Total(); got != tt.wantTotal {
+				t.Errorf("Total() = %d, want %d", got, tt.wantTotal)
+			}
+			if resp.Meta.Message != tt.wantMsg {
+				t.Errorf("Meta.Message = %q, want %q", resp.Meta.Message, tt.wantMsg)
+			}
+		})
+	}
+
+	var resp APIResponse[TestData]
+	if err := json.Unmarshal([]byte(`"text"`), &resp); err == nil {
+		t.Error("Unmarshal of a string succeeded, want error")
+	}
+}
+
+func TestNewResponseError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ResponseError
+		want ResponseError
+		msg  string
+	}{
+		{
+			"meta",
+			NewResponseError("delete network", 400, []byte(`{"meta":{"rc":"error","msg":"api.err.ObjectReferredBy"},"data":[]}`)),
+			ResponseError{Op: "delete network", StatusCode: 400, RC: "error", Message: "api.err.ObjectReferredBy", Code: "api.err.ObjectReferredBy"},
+			"delete network failed with status 400: api.err.ObjectReferredBy",
+		},
+		{
+			"v2",
+			NewResponseError("create DNS record", 400, []byte(`{"code":"api.err.InvalidPayload","message":"Invalid payload"}`)),
+			ResponseError{Op: "create DNS record", StatusCode: 400, Message: "Invalid payload", Code: "api.err.InvalidPayload"},
+			"create DNS record failed with status 400: Invalid payload",
+		},
+		{
+			"not json",
+			NewResponseError("list devices", 502, []byte("<html>Bad Gateway</html>")),
+			ResponseError{Op: "list devices", StatusCode: 502},
+			"list devices failed with status 502",
+		},
+		{
+			"rc only",
+			NewResponseError("", 0, []byte(`{"meta":{"rc":"error","msg":"api.err.IdInvalid"}}`)),
+			ResponseError{RC: "error", Message: "api.err.IdInvalid", Code: "api.err.IdInvalid"},
+			"API error: api.err.IdInvalid (rc=error)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if *tt.err != tt.want {
+				t.Errorf("NewResponseError() = %+v, want %+v", *tt.err, tt.want)
+			}
+			if got := tt.err.Error(); got != tt.msg {
+				t.Errorf("Error() = %q, want %q", got, tt.msg)
+			}
+		})
+	}
+}
+
 func TestMAC_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -86,6 +177,7 @@ func TestDeviceState_String(t *testing.T) {
 		{DeviceStateDeleting, "deleting"},
 		{DeviceStateInformed, "informed"},
 		{DeviceStateUpgrading, "upgrading"},
+		{DeviceStateIsolated, "isolated"},
 		{DeviceState(99), "unknown(99)"},
 	}
 
@@ -99,6 +191,39 @@ func TestDeviceState_String(t *testing.T) {
 	}
 }
 
+func TestDeviceState_Predicates(t *testing.T) {
+	tests := []struct {
+		state            DeviceState
+		wantOnline       bool
+		wantTransitional bool
+	}{
+		{DeviceStateOffline, false, false},
+		{DeviceStateConnected, true, false},
+		{DeviceStatePending, false, false},
+		{DeviceStateDisconnected, false, false},
+		{DeviceStateFirmwareMismatch, true, false},
+		{DeviceStateProvisioning, true, true},
+		{DeviceStateHeartbeatMissed, false, true},
+		{DeviceStateAdopting, false, true},
+		{DeviceStateDeleting, false, true},
+		{DeviceStateInformed, false, false},
+		{DeviceStateUpgrading, true, true},
+		{DeviceStateIsolated, false, false},
+		{DeviceState(99), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state.String(), func(t *testing.T) {
+			if got := tt.state.IsOnline(); got != tt.wantOnline {
+				t.Errorf("IsOnline() = %v, want %v", got, tt.wantOnline)
+			}
+			if got := tt.state.IsTransitional(); got != tt.wantTransitional {
+				t.Errorf("IsTransitional() = %v, want %v", got, tt.wantTransitional)
+			}
+		})
+	}
+}
+
 func TestDeviceState_JSON(t *testing.T) {
 	tests := []struct {
 		name  string
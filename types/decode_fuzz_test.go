@@ -0,0 +1,70 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+// FuzzDecode decodes random controller-shaped payloads of every type with
+// a published schema, and checks that they decode, copy, compare and
+// encode without errors or panics.
+func FuzzDecode(f *testing.F) {
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed)
+	}
+
+	names := make([]string, 0, len(types.SchemaTypes))
+	for name := range types.SchemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+		for _, name := range names {
+			typ := reflect.TypeOf(types.SchemaTypes[name])
+			payload := mock.RandomPayload(r, types.SchemaTypes[name])
+
+			v := reflect.New(typ)
+			if err := json.Unmarshal(payload, v.Interface()); err != nil {
+				t.Fatalf("%s: Unmarshal(%s): %v", name, payload, err)
+			}
+			copied := types.DeepCopy(v.Elem().Interface())
+			if changes := types.Diff(v.Elem().Interface(), copied); len(changes) > 0 {
+				t.Fatalf("%s: copy differs: %v", name, changes)
+			}
+			if _, err := json.Marshal(v.Interface()); err != nil {
+				t.Fatalf("%s: Marshal: %v", name, err)
+			}
+			_ = fmt.Sprintf("%v %+v %#v", v.Interface(), v.Interface(), v.Interface())
+		}
+	})
+}
+
+// FuzzUnmarshalDevice decodes arbitrary bytes as a device, the largest and
+// least consistent payload, and checks that whatever decodes also encodes.
+func FuzzUnmarshalDevice(f *testing.F) {
+	f.Add([]byte(`{"mac":"00:11:22:33:44:55","state":1,"uptime":"123","port_table":[{"port_idx":1,"poe_power":"2.5"}]}`))
+	f.Add([]byte(`{"adopted":"true","last_seen":1642567890123,"sys_stats":{"loadavg_1":"0.5"}}`))
+	f.Add(mock.RandomPayload(rand.New(rand.NewSource(1)), types.Device{}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d types.Device
+		if err := json.Unmarshal(data, &d); err != nil {
+			return
+		}
+		if _, err := json.Marshal(d); err != nil {
+			t.Fatalf("Marshal of device decoded from %q: %v", data, err)
+		}
+		_ = d.ModelName()
+		_ = d.State.String()
+		_, _ = d.ModelInfo()
+	})
+}
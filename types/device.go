@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Device represents a UniFi network device (AP, Switch, Gateway, etc.).
 type Device struct {
 	ID              string `json:"_id"`
@@ -16,19 +18,26 @@ type Device struct {
 	State           DeviceState `json:"state"`
 	InformURL       string `json:"inform_url,omitempty"`
 	InformIP        string `json:"inform_ip,omitempty"`
-	LastSeen        int64  `json:"last_seen"`
+	LastSeen        FlexTime `json:"last_seen"`
 	Uptime          FlexInt `json:"uptime"`
 	Upgradable      bool   `json:"upgradable"`
+	UpgradeToFirmware string `json:"upgrade_to_firmware,omitempty"`
 	ConfigVersion   string `json:"cfgversion,omitempty"`
 	LicenseState    string `json:"license_state,omitempty"`
-	ConnectedAt     int64  `json:"connected_at,omitempty"`
-	ProvisionedAt   int64  `json:"provisioned_at,omitempty"`
+	ConnectedAt     FlexTime `json:"connected_at,omitzero"`
+	ProvisionedAt   FlexTime `json:"provisioned_at,omitzero"`
 	LEDOverride     string `json:"led_override,omitempty"`
 	LEDOverrideColor string `json:"led_override_color,omitempty"`
 	LEDOverrideColorBrightness int `json:"led_override_color_brightness,omitempty"`
 	Internet        bool   `json:"internet,omitempty"`
 	IP              string `json:"ip,omitempty"`
 
+	// Map placement, in map units from the top left of the map image
+	MapID           string  `json:"map_id,omitempty"`
+	X               float64 `json:"x,omitempty"`
+	Y               float64 `json:"y,omitempty"`
+	HeightInMeters  float64 `json:"heightInMeters,omitempty"`
+
 	// Statistics
 	SystemStats     *SystemStats `json:"system-stats,omitempty"`
 	SysStats        *SysStats    `json:"sys_stats,omitempty"`
@@ -40,6 +49,7 @@ type Device struct {
 	// Uplink information
 	Uplink          *DeviceUplink `json:"uplink,omitempty"`
 	UplinkTable     []DeviceUplink `json:"uplink_table,omitempty"`
+	LLDPTable       []LLDPEntry `json:"lldp_table,omitempty"`
 
 	// Network configuration
 	ConfigNetwork   *DeviceConfigNetwork `json:"config_network,omitempty"`
@@ -82,6 +92,21 @@ type Device struct {
 	HashID          string `json:"hash_id,omitempty"`
 }
 
+// LastSeenTime returns LastSeen as time.Time.
+func (d *Device) LastSeenTime() time.Time {
+	return d.LastSeen.Val
+}
+
+// ConnectedAtTime returns ConnectedAt as time.Time.
+func (d *Device) ConnectedAtTime() time.Time {
+	return d.ConnectedAt.Val
+}
+
+// ProvisionedAtTime returns ProvisionedAt as time.Time.
+func (d *Device) ProvisionedAtTime() time.Time {
+	return d.ProvisionedAt.Val
+}
+
 // DeviceBasic represents minimal device information for faster queries.
 type DeviceBasic struct {
 	MAC   string `json:"mac"`
@@ -115,6 +140,14 @@ type DeviceUplink struct {
 	PortIdx          int    `json:"port_idx,omitempty"`
 }
 
+// LLDPEntry is a neighbor a device has discovered with LLDP.
+type LLDPEntry struct {
+	ChassisID    string `json:"chassis_id"`
+	PortID       string `json:"port_id,omitempty"`
+	LocalPortIdx int    `json:"local_port_idx"`
+	IsWired      bool   `json:"is_wired,omitempty"`
+}
+
 // DeviceConfigNetwork represents network configuration for a device.
 type DeviceConfigNetwork struct {
 	Type           string `json:"type"`
@@ -238,18 +271,18 @@ type PortTable struct {
 	PoePower             FlexInt `json:"poe_power,omitempty"`
 	PoeVoltage           FlexInt `json:"poe_voltage,omitempty"`
 	PortconfID           string  `json:"portconf_id,omitempty"`
-	AggregatedBy         bool    `json:"aggregated_by,omitempty"`
-	Autoneg              bool    `json:"autoneg,omitempty"`
+	AggregatedBy         FlexInt `json:"aggregated_by,omitempty"` // index of the aggregating port
+	Autoneg              FlexBool `json:"autoneg,omitzero"`
 	BytesR               FlexInt `json:"bytes-r,omitempty"`
 	Dot1xMode            string  `json:"dot1x_mode,omitempty"`
 	Dot1xStatus          string  `json:"dot1x_status,omitempty"`
 	Enable               bool    `json:"enable"`
 	Flowctrl             bool    `json:"flowctrl_rx,omitempty"`
 	FullDuplex           bool    `json:"full_duplex"`
-	IsUplink             bool    `json:"is_uplink,omitempty"`
-	Jumbo                bool    `json:"jumbo,omitempty"`
+	IsUplink             FlexBool `json:"is_uplink,omitzero"`
+	Jumbo                FlexBool `json:"jumbo,omitzero"`
 	MAC                  string  `json:"mac,omitempty"`
-	Masked               bool    `json:"masked,omitempty"`
+	Masked               FlexBool `json:"masked,omitzero"`
 	Name                 string  `json:"name,omitempty"`
 	NetworkName          string  `json:"network_name,omitempty"`
 	OpMode               string  `json:"op_mode,omitempty"`
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestDevice_UnmarshalJSON(t *testing.T) {
@@ -194,6 +195,47 @@ func TestDevice_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDevice_TimeHelpers(t *testing.T) {
+	d := Device{
+		LastSeen:    NewFlexTime(1642567890),
+		ConnectedAt: NewFlexTime(1642567890123),
+	}
+
+	if got := d.LastSeenTime(); !got.Equal(time.Unix(1642567890, 0)) {
+		t.Errorf("LastSeenTime() = %v", got)
+	}
+	if got := d.ConnectedAtTime(); !got.Equal(time.UnixMilli(1642567890123)) {
+		t.Errorf("ConnectedAtTime() = %v", got)
+	}
+	if !d.ProvisionedAtTime().IsZero() {
+		t.Error("ProvisionedAtTime() should be zero when unset")
+	}
+}
+
+func TestDevice_UnmarshalJSON_FlexTimes(t *testing.T) {
+	jsonData := `{
+		"mac": "aa:bb:cc:dd:ee:ff",
+		"last_seen": "1642567890",
+		"connected_at": 1642567890123,
+		"provisioned_at": null
+	}`
+
+	var d Device
+	if err := json.Unmarshal([]byte(jsonData), &d); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got := d.LastSeenTime(); !got.Equal(time.Unix(1642567890, 0)) {
+		t.Errorf("LastSeenTime() = %v", got)
+	}
+	if got := d.ConnectedAtTime(); !got.Equal(time.UnixMilli(1642567890123)) {
+		t.Errorf("ConnectedAtTime() = %v", got)
+	}
+	if !d.ProvisionedAtTime().IsZero() {
+		t.Error("ProvisionedAtTime() should be zero for null")
+	}
+}
+
 func TestDevice_MarshalJSON(t *testing.T) {
 	d := Device{
 		ID:       "test123",
@@ -204,7 +246,7 @@ func TestDevice_MarshalJSON(t *testing.T) {
 		State:    DeviceStateConnected,
 		Adopted:  true,
 		SiteID:   "default",
-		LastSeen: 1642567890,
+		LastSeen: NewFlexTime(1642567890),
 	}
 
 	data, err := json.Marshal(d)
@@ -369,6 +411,40 @@ func TestPortTable_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestPortTable_UnmarshalJSON_FlexBools(t *testing.T) {
+	// Aggregated ports report the aggregating port as a number; some switch
+	// firmware reports the remaining flags as strings or integers.
+	jsonData := `{
+		"port_idx": 2,
+		"aggregated_by": 1,
+		"autoneg": "true",
+		"is_uplink": 1,
+		"jumbo": "false",
+		"masked": false
+	}`
+
+	var port PortTable
+	if err := json.Unmarshal([]byte(jsonData), &port); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if port.AggregatedBy.Int() != 1 || !port.IsAggregated() {
+		t.Errorf("AggregatedBy = %v, want port 1", port.AggregatedBy.Int())
+	}
+	if !port.Autoneg.Bool() {
+		t.Error("Autoneg should be true")
+	}
+	if !port.IsUplink.Bool() {
+		t.Error("IsUplink should be true")
+	}
+	if port.Jumbo.Bool() {
+		t.Error("Jumbo should be false")
+	}
+	if port.Masked.Bool() {
+		t.Error("Masked should be false")
+	}
+}
+
 func TestVAPTable_UnmarshalJSON(t *testing.T) {
 	jsonData := `{
 		"bssid": "00:11:22:33:44:55",
@@ -472,3 +548,14 @@ func TestWAN_UnmarshalJSON(t *testing.T) {
 		t.Errorf("RXBytes = %v, want 9876543210", wan.RXBytes.Int64())
 	}
 }
+
+func TestPortTable_AggregatedBy_False(t *testing.T) {
+	// Ports that are not aggregated report false instead of an index.
+	var port PortTable
+	if err := json.Unmarshal([]byte(`{"port_idx": 1, "aggregated_by": false}`), &port); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if port.IsAggregated() {
+		t.Errorf("IsAggregated() = true, AggregatedBy = %v", port.AggregatedBy.Int())
+	}
+}
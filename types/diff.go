@@ -0,0 +1,175 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change describes a single field-level difference between two values.
+type Change struct {
+	// Path identifies the field using JSON names, e.g. "port_overrides[0].poe_mode".
+	Path string
+
+	// Old is the value in the first object, or nil if the field was added.
+	Old interface{}
+
+	// New is the value in the second object, or nil if the field was removed.
+	New interface{}
+}
+
+// String returns the change in "path: old -> new" form.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// Diff returns the field-level changes needed to turn a into b.
+//
+// a and b must be of the same type (values or pointers). Struct fields are
+// named by their JSON tags and fields tagged "-" are ignored. Slices are
+// compared element by element and maps key by key. Types with custom JSON
+// encoding (FlexInt, FlexBool, ...) are compared by their encoded form, so
+// representational differences such as 1 vs "1" are not reported.
+func Diff(a, b interface{}) []Change {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []Change{{Old: a, New: b}}
+	}
+
+	var changes []Change
+	diffValue(&changes, "", va, vb)
+	return changes
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// diffValue appends the differences between a and b to changes.
+func diffValue(changes *[]Change, path string, a, b reflect.Value) {
+	if a.Type().Implements(jsonMarshalerType) {
+		ja, errA := json.Marshal(a.Interface())
+		jb, errB := json.Marshal(b.Interface())
+		if errA != nil || errB != nil || string(ja) != string(jb) {
+			*changes = append(*changes, Change{Path: path, Old: a.Interface(), New: b.Interface()})
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*changes = append(*changes, Change{Path: path, Old: valueOrNil(a), New: valueOrNil(b)})
+			}
+			return
+		}
+		ea, eb := a.Elem(), b.Elem()
+		if ea.Type() != eb.Type() {
+			*changes = append(*changes, Change{Path: path, Old: ea.Interface(), New: eb.Interface()})
+			return
+		}
+		diffValue(changes, path, ea, eb)
+
+	case reflect.Struct:
+		diffStruct(changes, path, a, b)
+
+	case reflect.Slice, reflect.Array:
+		n := a.Len()
+		if b.Len() > n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, Change{Path: p, New: b.Index(i).Interface()})
+			case i >= b.Len():
+				*changes = append(*changes, Change{Path: p, Old: a.Index(i).Interface()})
+			default:
+				diffValue(changes, p, a.Index(i), b.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, k := range a.MapKeys() {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		for _, k := range b.MapKeys() {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			k := keys[name]
+			p := joinPath(path, name)
+			va, vb := a.MapIndex(k), b.MapIndex(k)
+			switch {
+			case !va.IsValid():
+				*changes = append(*changes, Change{Path: p, New: vb.Interface()})
+			case !vb.IsValid():
+				*changes = append(*changes, Change{Path: p, Old: va.Interface()})
+			default:
+				diffValue(changes, p, va, vb)
+			}
+		}
+
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, Change{Path: path, Old: a.Interface(), New: b.Interface()})
+		}
+	}
+}
+
+// diffStruct compares exported struct fields, flattening embedded structs.
+func diffStruct(changes *[]Change, path string, a, b reflect.Value) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			diffStruct(changes, path, a.Field(i), b.Field(i))
+			continue
+		}
+
+		diffValue(changes, joinPath(path, name), a.Field(i), b.Field(i))
+	}
+}
+
+// valueOrNil returns the underlying value of a pointer or interface, or nil.
+func valueOrNil(v reflect.Value) interface{} {
+	if v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// joinPath appends a field name to a path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff_Network(t *testing.T) {
+	a := &Network{ID: "n1", Name: "LAN", VLAN: 10, DHCPDEnabled: true}
+	b := a.Clone()
+	b.Name = "Office"
+	b.VLAN = 20
+
+	changes := Diff(a, b)
+
+	want := []Change{
+		{Path: "name", Old: "LAN", New: "Office"},
+		{Path: "vlan", Old: 10, New: 20},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %v, want %v", changes, want)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	a := &WLAN{Name: "Home", MACFilterList: []string{"aa:bb:cc:dd:ee:ff"}}
+	if changes := Diff(a, a.Clone()); len(changes) != 0 {
+		t.Errorf("Diff() = %v, want no changes", changes)
+	}
+}
+
+func TestDiff_Slices(t *testing.T) {
+	a := &Device{PortOverrides: []PortOverride{{PortIdx: 1, PoeMode: "auto"}}}
+	b := a.Clone()
+	b.PortOverrides[0].PoeMode = "off"
+	b.PortOverrides = append(b.PortOverrides, PortOverride{PortIdx: 2})
+
+	changes := Diff(a, b)
+	if len(changes) != 2 {
+		t.Fatalf("Diff() returned %d changes, want 2: %v", len(changes), changes)
+	}
+
+	if changes[0].Path != "port_overrides[0].poe_mode" || changes[0].Old != "auto" || changes[0].New != "off" {
+		t.Errorf("changes[0] = %v", changes[0])
+	}
+	if changes[1].Path != "port_overrides[1]" || changes[1].Old != nil {
+		t.Errorf("changes[1] = %v", changes[1])
+	}
+}
+
+func TestDiff_FlexTypesCompareEncoded(t *testing.T) {
+	a := Client{Uptime: FlexInt{Val: 100, Txt: "100"}, IsWired: FlexBool{Val: true, Txt: "1"}}
+	b := Client{Uptime: FlexInt{Val: 100}, IsWired: FlexBool{Val: true, Txt: "true"}}
+
+	if changes := Diff(a, b); len(changes) != 0 {
+		t.Errorf("Diff() = %v, want no changes", changes)
+	}
+
+	b.Uptime = FlexInt{Val: 200}
+	changes := Diff(a, b)
+	if len(changes) != 1 || changes[0].Path != "uptime" {
+		t.Errorf("Diff() = %v, want uptime change", changes)
+	}
+}
+
+func TestDiff_Maps(t *testing.T) {
+	a := AdminUser{Permissions: map[string]interface{}{"a": 1.0, "b": "x"}}
+	b := AdminUser{Permissions: map[string]interface{}{"a": 2.0, "c": true}}
+
+	changes := Diff(a, b)
+	want := []Change{
+		{Path: "permissions.a", Old: 1.0, New: 2.0},
+		{Path: "permissions.b", Old: "x"},
+		{Path: "permissions.c", New: true},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %v, want %v", changes, want)
+	}
+}
+
+func TestDiff_EmbeddedStruct(t *testing.T) {
+	a := SettingMgmt{Setting: Setting{Key: "mgmt"}, LEDEnabled: true}
+	b := SettingMgmt{Setting: Setting{Key: "mgmt", ID: "x"}, LEDEnabled: false}
+
+	changes := Diff(a, b)
+	if len(changes) != 2 || changes[0].Path != "_id" || changes[1].Path != "led_enabled" {
+		t.Errorf("Diff() = %v", changes)
+	}
+}
+
+func TestDiff_NilPointers(t *testing.T) {
+	a := &Device{}
+	b := &Device{Uplink: &DeviceUplink{Name: "eth0"}}
+
+	changes := Diff(a, b)
+	if len(changes) != 1 || changes[0].Path != "uplink" || changes[0].Old != nil {
+		t.Errorf("Diff() = %v", changes)
+	}
+}
+
+func TestDiff_MismatchedTypes(t *testing.T) {
+	changes := Diff(&Network{}, &WLAN{})
+	if len(changes) != 1 || changes[0].Path != "" {
+		t.Errorf("Diff() = %v, want a single root change", changes)
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	c := Change{Path: "name", Old: "a", New: "b"}
+	if got := c.String(); got != "name: a -> b" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"net"
+	"strings"
+)
+
 // DNSRecord represents a local DNS record (static DNS entry).
 type DNSRecord struct {
 	ID         string `json:"_id,omitempty"`
@@ -10,7 +15,7 @@ type DNSRecord struct {
 	Port       int    `json:"port,omitempty"`       // For SRV records
 	Priority   int    `json:"priority,omitempty"`   // For MX/SRV records
 	Weight     int    `json:"weight,omitempty"`     // For SRV records
-	Enabled    bool   `json:"enabled,omitempty"`
+	Enabled    bool   `json:"enabled"`
 }
 
 // DNSRecordType constants for DNS record types.
@@ -22,3 +27,19 @@ const (
 	DNSRecordTypeTXT   = "TXT"
 	DNSRecordTypeSRV   = "SRV"
 )
+
+// MatchesName reports whether the record is for name. DNS names are
+// compared case-insensitively and without a trailing dot.
+func (r *DNSRecord) MatchesName(name string) bool {
+	return strings.EqualFold(strings.TrimSuffix(r.Key, "."), strings.TrimSuffix(name, "."))
+}
+
+// PointsTo reports whether the record's value is ip. Addresses are
+// compared by value, so "2001:db8::1" matches "2001:DB8:0::1".
+func (r *DNSRecord) PointsTo(ip string) bool {
+	a, b := net.ParseIP(r.Value), net.ParseIP(ip)
+	if a == nil || b == nil {
+		return r.Value == ip
+	}
+	return a.Equal(b)
+}
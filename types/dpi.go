@@ -0,0 +1,59 @@
+package types
+
+//go:generate go run gen_dpi.go
+
+// DPICategory is a deep packet inspection application category.
+type DPICategory struct {
+	ID   int
+	Name string
+}
+
+// DPIApp is an application recognized by deep packet inspection.
+type DPIApp struct {
+	// ID is the application ID used by traffic rules (category<<16 | app).
+	ID int
+
+	// CategoryID is the ID of the application's category.
+	CategoryID int
+
+	// Name is the application name, e.g. "Netflix".
+	Name string
+}
+
+// DPIAppID combines a category and per-category application number into
+// the application ID used by traffic rules.
+func DPIAppID(category, app int) int {
+	return category<<16 | app
+}
+
+// LookupDPIApp finds an application by name, ignoring case and punctuation.
+func LookupDPIApp(name string) (DPIApp, bool) {
+	key := normalizeName(name)
+	for _, app := range dpiApps {
+		if normalizeName(app.Name) == key {
+			return app, true
+		}
+	}
+	return DPIApp{}, false
+}
+
+// LookupDPICategory finds a category by name, ignoring case and punctuation.
+func LookupDPICategory(name string) (DPICategory, bool) {
+	key := normalizeName(name)
+	for _, cat := range dpiCategories {
+		if normalizeName(cat.Name) == key {
+			return cat, true
+		}
+	}
+	return DPICategory{}, false
+}
+
+// DPIApps returns a copy of the application catalog.
+func DPIApps() []DPIApp {
+	return DeepCopy(dpiApps)
+}
+
+// DPICategories returns a copy of the category catalog.
+func DPICategories() []DPICategory {
+	return DeepCopy(dpiCategories)
+}
@@ -1,15 +1,23 @@
 package types
 
+import (
+	"strings"
+	"time"
+)
+
 // Event represents a UniFi event (device connect/disconnect, client activity, etc.).
 type Event struct {
 	ID          string `json:"_id"`
-	Time        int64  `json:"time"`
+	Time        FlexTime `json:"time"`
 	Datetime    string `json:"datetime"`
 	Key         string `json:"key"` // Event type key like "EVT_AP_Connected"
 	Message     string `json:"msg"`
 	SiteID      string `json:"site_id"`
 	Subsystem   string `json:"subsystem"` // "wlan", "lan", "wan", etc.
 
+	// Source is the stream the event was received on; see EventSource.
+	Source      EventSource `json:"source,omitempty"`
+
 	// Device info
 	AP          string `json:"ap,omitempty"`
 	APMAC       string `json:"ap_mac,omitempty"`
@@ -24,6 +32,7 @@ type Event struct {
 	// Client info
 	Client      string `json:"client,omitempty"`
 	User        string `json:"user,omitempty"`
+	Guest       string `json:"guest,omitempty"`
 	Hostname    string `json:"hostname,omitempty"`
 	SSID        string `json:"ssid,omitempty"`
 
@@ -40,13 +49,35 @@ type Event struct {
 	Bytes       FlexInt `json:"bytes,omitempty"`
 	Channel     int     `json:"channel,omitempty"`
 	Radio       string  `json:"radio,omitempty"`
+	APFrom      string  `json:"ap_from,omitempty"` // roaming
+	APTo        string  `json:"ap_to,omitempty"`
 	InnerID     int     `json:"inner_id,omitempty"`
 }
 
+// Timestamp returns Time as time.Time.
+func (e *Event) Timestamp() time.Time {
+	return e.Time.Val
+}
+
+// Category returns the part of the key naming what the event is about,
+// such as EventCategoryAP for "EVT_AP_Connected", or "" for keys of
+// another form.
+func (e *Event) Category() string {
+	rest, ok := strings.CutPrefix(e.Key, "EVT_")
+	if !ok {
+		return ""
+	}
+	category, _, ok := strings.Cut(rest, "_")
+	if !ok {
+		return ""
+	}
+	return category
+}
+
 // Alarm represents a UniFi alarm/alert.
 type Alarm struct {
 	ID             string  `json:"_id"`
-	Time           int64   `json:"time"`
+	Time           FlexTime `json:"time"`
 	Datetime       string  `json:"datetime"`
 	Key            string  `json:"key"` // Alarm type key
 	Message        string  `json:"msg"`
@@ -55,7 +86,7 @@ type Alarm struct {
 	Archived       bool    `json:"archived"`
 	Handled        bool    `json:"handled"`
 	HandledBy      string  `json:"handled_by,omitempty"`
-	HandledTime    int64   `json:"handled_time,omitempty"`
+	HandledTime    FlexTime `json:"handled_time,omitzero"`
 
 	// Device info
 	AP             string  `json:"ap,omitempty"`
@@ -78,6 +109,22 @@ type Alarm struct {
 	InnerAlertID   int     `json:"inner_alert_id,omitempty"`
 }
 
+// Timestamp returns Time as time.Time.
+func (a *Alarm) Timestamp() time.Time {
+	return a.Time.Val
+}
+
+// HandledAt returns HandledTime as time.Time.
+func (a *Alarm) HandledAt() time.Time {
+	return a.HandledTime.Val
+}
+
+// DeviceMAC returns the MAC address of the access point, switch or
+// gateway the alarm is about, or "" if it is not about a device.
+func (a *Alarm) DeviceMAC() string {
+	return firstNonEmpty(a.AP, a.SW, a.GW)
+}
+
 // Common event keys.
 const (
 	EventAPConnected       = "EVT_AP_Connected"
@@ -96,3 +143,16 @@ const (
 	EventIPSAlert          = "EVT_IPS_Alert"
 	EventADLogin           = "EVT_AD_Login"
 )
+
+// Event categories, as returned by Event.Category.
+const (
+	EventCategoryAP            = "AP"  // access points
+	EventCategorySwitch        = "SW"  // switches
+	EventCategoryGateway       = "GW"  // gateways
+	EventCategoryWirelessUser  = "WU"  // wireless clients
+	EventCategoryWiredUser     = "LU"  // wired clients
+	EventCategoryWirelessGuest = "WG"  // wireless guests
+	EventCategoryWiredGuest    = "LG"  // wired guests
+	EventCategoryAdmin         = "AD"  // administrators
+	EventCategoryIPS           = "IPS" // intrusion prevention
+)
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestEvent_UnmarshalJSON(t *testing.T) {
@@ -57,3 +58,76 @@ func TestAlarm_UnmarshalJSON(t *testing.T) {
 		t.Error("Archived should be false")
 	}
 }
+
+func TestEvent_Category(t *testing.T) {
+	tests := map[string]string{
+		EventAPConnected:          EventCategoryAP,
+		EventWURoam:               EventCategoryWirelessUser,
+		EventIPSAlert:             EventCategoryIPS,
+		"EVT_GW_WANTransition":    EventCategoryGateway,
+		"EVT_Unknown":             "",
+		"system.update.available": "",
+	}
+	for key, want := range tests {
+		e := Event{Key: key}
+		if got := e.Category(); got != want {
+			t.Errorf("Category() of %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEvent_ClientEvent(t *testing.T) {
+	event := Event{
+		Key:      "EVT_WG_Disconnected",
+		Time:     NewFlexTime(1642567890000),
+		Guest:    "aa:bb:cc:dd:ee:01",
+		Hostname: "phone",
+		SSID:     "Guests",
+		AP:       "00:11:22:33:44:55",
+		Duration: FlexInt{Val: 600},
+		Bytes:    FlexInt{Val: 2048},
+	}
+
+	ce, ok := event.ClientEvent()
+	if !ok {
+		t.Fatal("ClientEvent() = false, want true")
+	}
+	if ce.Kind != ClientEventDisconnected || ce.MAC != "aa:bb:cc:dd:ee:01" || !ce.Guest || ce.Wired {
+		t.Errorf("ClientEvent() = %+v", ce)
+	}
+	if ce.Duration != 10*time.Minute || ce.Bytes != 2048 {
+		t.Errorf("session = %v, %d bytes, want 10m0s, 2048 bytes", ce.Duration, ce.Bytes)
+	}
+
+	for _, key := range []string{EventAPConnected, "EVT_WU_Unknown", EventADLogin} {
+		if _, ok := (&Event{Key: key}).ClientEvent(); ok {
+			t.Errorf("ClientEvent() of %s = true, want false", key)
+		}
+	}
+}
+
+func TestEvent_DeviceEvent(t *testing.T) {
+	tests := []struct {
+		event Event
+		kind  DeviceEventKind
+		typ   string
+		mac   string
+	}{
+		{Event{Key: EventAPConnected, AP: "00:11:22:33:44:55"}, DeviceEventConnected, "uap", "00:11:22:33:44:55"},
+		{Event{Key: "EVT_AP_Lost_Contact", APMAC: "00:11:22:33:44:56"}, DeviceEventDisconnected, "uap", "00:11:22:33:44:56"},
+		{Event{Key: "EVT_SW_RestartedUnknown", SW: "00:11:22:33:44:57"}, DeviceEventRestarted, "usw", "00:11:22:33:44:57"},
+		{Event{Key: "EVT_GW_Upgraded", GW: "00:11:22:33:44:58"}, DeviceEventUpgraded, "ugw", "00:11:22:33:44:58"},
+	}
+	for _, tt := range tests {
+		de, ok := tt.event.DeviceEvent()
+		if !ok || de.Kind != tt.kind || de.Type != tt.typ || de.MAC != tt.mac {
+			t.Errorf("DeviceEvent() of %s = %+v, %v", tt.event.Key, de, ok)
+		}
+	}
+
+	for _, key := range []string{EventGWWANTransition, EventWUConnected} {
+		if _, ok := (&Event{Key: key}).DeviceEvent(); ok {
+			t.Errorf("DeviceEvent() of %s = true, want false", key)
+		}
+	}
+}
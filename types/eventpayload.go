@@ -0,0 +1,157 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// ClientEventKind is what happened to the client of a ClientEvent.
+type ClientEventKind string
+
+// Client event kinds.
+const (
+	ClientEventConnected    ClientEventKind = "connected"
+	ClientEventDisconnected ClientEventKind = "disconnected"
+	ClientEventRoamed       ClientEventKind = "roamed"
+)
+
+// ClientEvent is a client connecting, disconnecting or roaming, decoded
+// from the EVT_WU_*, EVT_LU_*, EVT_WG_* and EVT_LG_* events.
+type ClientEvent struct {
+	Kind     ClientEventKind
+	MAC      string
+	Hostname string
+	Wired    bool
+	Guest    bool
+
+	// SSID and AP are the wireless network and the access point the
+	// client is on, or was on when it disconnected. For a roam,
+	// PreviousAP is the access point it left.
+	SSID       string
+	AP         string
+	PreviousAP string
+
+	// Duration and Bytes are the length and traffic of the session that
+	// ended, for disconnects.
+	Duration time.Duration
+	Bytes    int64
+
+	Time  time.Time
+	Event Event
+}
+
+// ClientEvent decodes a client event. It returns false for events that
+// are not about a client connecting, disconnecting or roaming.
+func (e *Event) ClientEvent() (ClientEvent, bool) {
+	category := e.Category()
+	switch category {
+	case EventCategoryWirelessUser, EventCategoryWiredUser, EventCategoryWirelessGuest, EventCategoryWiredGuest:
+	default:
+		return ClientEvent{}, false
+	}
+
+	var kind ClientEventKind
+	switch strings.TrimPrefix(e.Key, "EVT_"+category+"_") {
+	case "Connected":
+		kind = ClientEventConnected
+	case "Disconnected":
+		kind = ClientEventDisconnected
+	case "Roam", "RoamRadio":
+		kind = ClientEventRoamed
+	default:
+		return ClientEvent{}, false
+	}
+
+	ce := ClientEvent{
+		Kind:     kind,
+		MAC:      firstNonEmpty(e.User, e.Guest, e.Client),
+		Hostname: e.Hostname,
+		Wired:    category == EventCategoryWiredUser || category == EventCategoryWiredGuest,
+		Guest:    category == EventCategoryWirelessGuest || category == EventCategoryWiredGuest,
+		SSID:     e.SSID,
+		AP:       firstNonEmpty(e.APTo, e.AP),
+		Time:     e.Timestamp(),
+		Event:    *e,
+	}
+	if kind == ClientEventRoamed {
+		ce.PreviousAP = e.APFrom
+	}
+	if kind == ClientEventDisconnected {
+		ce.Duration = time.Duration(e.Duration.Int64()) * time.Second
+		ce.Bytes = e.Bytes.Int64()
+	}
+	return ce, true
+}
+
+// DeviceEventKind is what happened to the device of a DeviceEvent.
+type DeviceEventKind string
+
+// Device event kinds.
+const (
+	DeviceEventConnected    DeviceEventKind = "connected"
+	DeviceEventDisconnected DeviceEventKind = "disconnected"
+	DeviceEventRestarted    DeviceEventKind = "restarted"
+	DeviceEventUpgraded     DeviceEventKind = "upgraded"
+	DeviceEventAdopted      DeviceEventKind = "adopted"
+)
+
+// DeviceEvent is an access point, switch or gateway changing state,
+// decoded from the EVT_AP_*, EVT_SW_* and EVT_GW_* events.
+type DeviceEvent struct {
+	Kind DeviceEventKind
+
+	// Type is the device type: "uap", "usw" or "ugw".
+	Type string
+	MAC  string
+	Name string
+
+	Time  time.Time
+	Event Event
+}
+
+// deviceEventKinds maps the part of a device event key after the category
+// to the kind of change.
+var deviceEventKinds = map[string]DeviceEventKind{
+	"Connected":        DeviceEventConnected,
+	"Disconnected":     DeviceEventDisconnected,
+	"Lost_Contact":     DeviceEventDisconnected,
+	"Restarted":        DeviceEventRestarted,
+	"RestartedUnknown": DeviceEventRestarted,
+	"Upgraded":         DeviceEventUpgraded,
+	"Adopted":          DeviceEventAdopted,
+	"AutoReadopted":    DeviceEventAdopted,
+}
+
+// DeviceEvent decodes a device state change. It returns false for events
+// that are not about an access point, switch or gateway changing state.
+func (e *Event) DeviceEvent() (DeviceEvent, bool) {
+	de := DeviceEvent{Time: e.Timestamp(), Event: *e}
+	category := e.Category()
+	switch category {
+	case EventCategoryAP:
+		de.Type, de.MAC, de.Name = "uap", firstNonEmpty(e.AP, e.APMAC), e.APName
+	case EventCategorySwitch:
+		de.Type, de.MAC, de.Name = "usw", firstNonEmpty(e.SW, e.SWMAC), e.SWName
+	case EventCategoryGateway:
+		de.Type, de.MAC, de.Name = "ugw", firstNonEmpty(e.GW, e.GWMAC), e.GWName
+	default:
+		return DeviceEvent{}, false
+	}
+
+	kind, ok := deviceEventKinds[strings.TrimPrefix(e.Key, "EVT_"+category+"_")]
+	if !ok {
+		return DeviceEvent{}, false
+	}
+	de.Kind = kind
+	return de, true
+}
+
+// firstNonEmpty returns the first of values that is not "".
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
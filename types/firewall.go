@@ -35,6 +35,10 @@ type FirewallRule struct {
 	// ICMP
 	ICMPTypename string   `json:"icmp_typename,omitempty"`
 
+	// IPv6 rulesets use these instead of Protocol and ICMPTypename
+	ProtocolV6     string `json:"protocol_v6,omitempty"`
+	ICMPv6Typename string `json:"icmpv6_typename,omitempty"`
+
 	// IPSec
 	IPSecMatchIPSec      string   `json:"ipsec_match_ipsec,omitempty"`
 }
@@ -67,6 +71,48 @@ const (
 	RulesetGuestLocal = "GUEST_LOCAL"
 )
 
+// IPv6 ruleset constants.
+const (
+	RulesetWANv6In      = "WANv6_IN"
+	RulesetWANv6Out     = "WANv6_OUT"
+	RulesetWANv6Local   = "WANv6_LOCAL"
+	RulesetLANv6In      = "LANv6_IN"
+	RulesetLANv6Out     = "LANv6_OUT"
+	RulesetLANv6Local   = "LANv6_LOCAL"
+	RulesetGuestv6In    = "GUESTv6_IN"
+	RulesetGuestv6Out   = "GUESTv6_OUT"
+	RulesetGuestv6Local = "GUESTv6_LOCAL"
+)
+
+// Rulesets lists the IPv4 rulesets.
+var Rulesets = []string{
+	RulesetWANIn, RulesetWANOut, RulesetWANLocal,
+	RulesetLANIn, RulesetLANOut, RulesetLANLocal,
+	RulesetGuestIn, RulesetGuestOut, RulesetGuestLocal,
+}
+
+// RulesetsV6 lists the IPv6 rulesets.
+var RulesetsV6 = []string{
+	RulesetWANv6In, RulesetWANv6Out, RulesetWANv6Local,
+	RulesetLANv6In, RulesetLANv6Out, RulesetLANv6Local,
+	RulesetGuestv6In, RulesetGuestv6Out, RulesetGuestv6Local,
+}
+
+// IsIPv6Ruleset returns true if ruleset is one of the IPv6 rulesets.
+func IsIPv6Ruleset(ruleset string) bool {
+	for _, r := range RulesetsV6 {
+		if r == ruleset {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIPv6 returns true if the rule belongs to an IPv6 ruleset.
+func (r *FirewallRule) IsIPv6() bool {
+	return IsIPv6Ruleset(r.Ruleset)
+}
+
 // Action constants.
 const (
 	FirewallActionAccept = "accept"
@@ -116,3 +116,21 @@ func TestFirewallConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestIsIPv6Ruleset(t *testing.T) {
+	for _, r := range RulesetsV6 {
+		if !IsIPv6Ruleset(r) {
+			t.Errorf("IsIPv6Ruleset(%s) = false", r)
+		}
+	}
+	for _, r := range Rulesets {
+		if IsIPv6Ruleset(r) {
+			t.Errorf("IsIPv6Ruleset(%s) = true", r)
+		}
+	}
+
+	rule := &FirewallRule{Ruleset: RulesetLANv6Local}
+	if !rule.IsIPv6() {
+		t.Error("IsIPv6() should be true for LANv6_LOCAL")
+	}
+}
@@ -0,0 +1,147 @@
+package types
+
+// FirewallZone is a zone of the zone-based firewall (Network 9.0 and
+// later). Built-in zones have a ZoneKey such as "internal" or "external"
+// and cannot be deleted; custom zones group the networks in NetworkIDs.
+type FirewallZone struct {
+	ID          string   `json:"_id,omitempty"`
+	Name        string   `json:"name"`
+	ZoneKey     string   `json:"zone_key,omitempty"`
+	NetworkIDs  []string `json:"network_ids"`
+	DefaultZone bool     `json:"default_zone,omitempty"`
+	AttrNoEdit  bool     `json:"attr_no_edit,omitempty"`
+}
+
+// Built-in firewall zone keys.
+const (
+	ZoneKeyInternal = "internal"
+	ZoneKeyExternal = "external"
+	ZoneKeyGateway  = "gateway"
+	ZoneKeyVPN      = "vpn"
+	ZoneKeyHotspot  = "hotspot"
+	ZoneKeyDMZ      = "dmz"
+)
+
+// Firewall policy actions.
+const (
+	PolicyActionAllow  = "ALLOW"
+	PolicyActionBlock  = "BLOCK"
+	PolicyActionReject = "REJECT"
+)
+
+// Firewall policy IP versions.
+const (
+	PolicyIPVersionBoth = "BOTH"
+	PolicyIPVersionIPv4 = "IPV4"
+	PolicyIPVersionIPv6 = "IPV6"
+)
+
+// Firewall policy matching targets.
+const (
+	PolicyTargetAny     = "ANY"
+	PolicyTargetIP      = "IP"
+	PolicyTargetNetwork = "NETWORK"
+	PolicyTargetClient  = "CLIENT"
+	PolicyTargetRegion  = "REGION"
+)
+
+// FirewallPolicy is a rule of the zone-based firewall. It applies to
+// traffic from the source zone to the destination zone; policies between
+// the same pair of zones are matched in Index order.
+type FirewallPolicy struct {
+	ID          string `json:"_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Action      string `json:"action"`               // ALLOW, BLOCK or REJECT
+	Protocol    string `json:"protocol,omitempty"`   // all, tcp, udp, tcp_udp, icmp
+	IPVersion   string `json:"ip_version,omitempty"` // BOTH, IPV4 or IPV6
+	Index       int    `json:"index,omitempty"`
+	Logging     bool   `json:"logging"`
+
+	// Predefined policies are created by the controller and are read-only.
+	Predefined bool `json:"predefined,omitempty"`
+
+	Source      FirewallPolicyEndpoint `json:"source"`
+	Destination FirewallPolicyEndpoint `json:"destination"`
+
+	// ConnectionStateType is ALL, RESPOND_ONLY or CUSTOM; CUSTOM matches
+	// ConnectionStates such as "NEW" and "ESTABLISHED".
+	ConnectionStateType string   `json:"connection_state_type,omitempty"`
+	ConnectionStates    []string `json:"connection_states,omitempty"`
+
+	MatchIPSec bool                    `json:"match_ipsec,omitempty"`
+	Schedule   *FirewallPolicySchedule `json:"schedule,omitempty"`
+}
+
+// FirewallPolicyEndpoint is the source or destination of a FirewallPolicy.
+// MatchingTarget selects which of the lists applies; ANY matches the whole
+// zone.
+type FirewallPolicyEndpoint struct {
+	ZoneID         string   `json:"zone_id"`
+	MatchingTarget string   `json:"matching_target,omitempty"`
+	IPs            []string `json:"ips,omitempty"`
+	NetworkIDs     []string `json:"network_ids,omitempty"`
+	ClientMACs     []string `json:"client_macs,omitempty"`
+	Regions        []string `json:"regions,omitempty"`
+
+	// PortMatchingType is ANY or SPECIFIC; SPECIFIC matches Port, a port
+	// or port range such as "80" or "8000-8080".
+	PortMatchingType string `json:"port_matching_type,omitempty"`
+	Port             string `json:"port,omitempty"`
+}
+
+// FirewallPolicySchedule limits when a FirewallPolicy applies. Mode is
+// ALWAYS, EVERY_DAY, EVERY_WEEK or ONE_TIME_ONLY.
+type FirewallPolicySchedule struct {
+	Mode           string   `json:"mode"`
+	TimeAllDay     bool     `json:"time_all_day,omitempty"`
+	TimeRangeStart string   `json:"time_range_start,omitempty"`
+	TimeRangeEnd   string   `json:"time_range_end,omitempty"`
+	RepeatOnDays   []string `json:"repeat_on_days,omitempty"`
+}
+
+// FirewallPolicyOrder reorders the user-defined policies from one zone to
+// another. The controller places the predefined policies itself.
+type FirewallPolicyOrder struct {
+	SourceZoneID        string   `json:"source_zone_id"`
+	DestinationZoneID   string   `json:"destination_zone_id"`
+	BeforePredefinedIDs []string `json:"before_predefined_ids"`
+	AfterPredefinedIDs  []string `json:"after_predefined_ids"`
+}
+
+// FirewallZoneMatrix is the zone matrix: for each source zone, the default
+// action towards every destination zone and the number of policies
+// between them.
+type FirewallZoneMatrix []FirewallZoneMatrixRow
+
+// FirewallZoneMatrixRow is the traffic from one source zone.
+type FirewallZoneMatrixRow struct {
+	ZoneID  string                   `json:"_id"`
+	Name    string                   `json:"name"`
+	ZoneKey string                   `json:"zone_key,omitempty"`
+	Data    []FirewallZoneMatrixCell `json:"data"`
+}
+
+// FirewallZoneMatrixCell is the traffic from a row's zone to ZoneID.
+type FirewallZoneMatrixCell struct {
+	ZoneID      string `json:"zone_id"`
+	Action      string `json:"action"`
+	PolicyCount int    `json:"policy_count"`
+}
+
+// Cell returns the cell for traffic from source to destination, or nil if
+// the matrix has no such pair.
+func (m FirewallZoneMatrix) Cell(source, destination string) *FirewallZoneMatrixCell {
+	for i := range m {
+		if m[i].ZoneID != source {
+			continue
+		}
+		for j := range m[i].Data {
+			if m[i].Data[j].ZoneID == destination {
+				return &m[i].Data[j]
+			}
+		}
+	}
+	return nil
+}
@@ -3,11 +3,16 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // FlexInt handles JSON fields that may be either numbers or strings.
-// UniFi API sometimes returns numbers as strings (e.g., "123" instead of 123).
+// UniFi API sometimes returns numbers as strings (e.g., "123" instead of 123),
+// and false for numbers that are not set (e.g., aggregated_by of a port
+// that is not aggregated), which decodes as zero.
 type FlexInt struct {
 	Val float64
 	Txt string
@@ -15,6 +20,11 @@ type FlexInt struct {
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (f *FlexInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" {
+		*f = FlexInt{}
+		return nil
+	}
+
 	// Try to unmarshal as number first
 	var num float64
 	if err := json.Unmarshal(data, &num); err == nil {
@@ -31,8 +41,9 @@ func (f *FlexInt) UnmarshalJSON(data []byte) error {
 
 	f.Txt = str
 	if str != "" {
-		// Try to parse string as number
-		if num, err := strconv.ParseFloat(str, 64); err == nil {
+		// Try to parse string as number. "NaN" and "Inf" parse, but have
+		// no JSON encoding, so they are kept as text only.
+		if num, err := strconv.ParseFloat(str, 64); err == nil && !math.IsNaN(num) && !math.IsInf(num, 0) {
 			f.Val = num
 		}
 	}
@@ -75,8 +86,9 @@ func (f FlexInt) String() string {
 // FlexBool handles JSON fields that may be booleans, strings, or numbers.
 // UniFi API sometimes returns:
 //   - true/false
-//   - "true"/"false"
+//   - "true"/"false" (any case), "yes"/"no", "on"/"off"
 //   - 1/0
+//   - null
 type FlexBool struct {
 	Val bool
 	Txt string
@@ -84,6 +96,12 @@ type FlexBool struct {
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (f *FlexBool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		f.Val = false
+		f.Txt = ""
+		return nil
+	}
+
 	// Try boolean first
 	var b bool
 	if err := json.Unmarshal(data, &b); err == nil {
@@ -96,12 +114,17 @@ func (f *FlexBool) UnmarshalJSON(data []byte) error {
 	var str string
 	if err := json.Unmarshal(data, &str); err == nil {
 		f.Txt = str
-		f.Val = str == "true" || str == "1"
+		switch strings.ToLower(strings.TrimSpace(str)) {
+		case "true", "1", "yes", "on", "enabled":
+			f.Val = true
+		default:
+			f.Val = false
+		}
 		return nil
 	}
 
 	// Try number
-	var num int
+	var num float64
 	if err := json.Unmarshal(data, &num); err == nil {
 		f.Val = num != 0
 		f.Txt = strconv.FormatBool(f.Val)
@@ -116,6 +139,14 @@ func (f FlexBool) MarshalJSON() ([]byte, error) {
 	return json.Marshal(f.Val)
 }
 
+// IsZero reports whether f holds no value, as a field left out of the
+// decoded JSON does, so that fields tagged omitzero leave it out. A decoded
+// false keeps its text and is sent back; FlexBool{Txt: "false"} sends an
+// explicit false.
+func (f FlexBool) IsZero() bool {
+	return !f.Val && f.Txt == ""
+}
+
 // Bool returns the boolean value.
 func (f FlexBool) Bool() bool {
 	return f.Val
@@ -123,6 +154,9 @@ func (f FlexBool) Bool() bool {
 
 // String returns the string representation.
 func (f FlexBool) String() string {
+	if f.Txt == "" {
+		return strconv.FormatBool(f.Val)
+	}
 	return f.Txt
 }
 
@@ -175,3 +209,81 @@ func (f FlexString) String() string {
 func (f FlexString) Strings() []string {
 	return f.Arr
 }
+
+// msThreshold separates epoch seconds from epoch milliseconds. Second-based
+// values stay below it until the year 33658; millisecond values pass it in 2001.
+const msThreshold = 1e12
+
+// EpochTime converts a UniFi epoch timestamp to time.Time, detecting whether
+// it is in seconds or milliseconds. Zero returns the zero time.
+func EpochTime(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	if v >= msThreshold || v <= -msThreshold {
+		return time.UnixMilli(v)
+	}
+	return time.Unix(v, 0)
+}
+
+// FlexTime handles epoch timestamps that may be seconds or milliseconds,
+// encoded as numbers or strings. UniFi API returns:
+//   - 1642567890 (seconds)
+//   - 1642567890123 (milliseconds)
+//   - "1642567890"
+//   - 0 or null when unset
+type FlexTime struct {
+	Val    time.Time
+	Millis bool
+}
+
+// NewFlexTime returns the FlexTime of an epoch timestamp in seconds or
+// milliseconds, keeping its unit.
+func NewFlexTime(v int64) FlexTime {
+	return FlexTime{Val: EpochTime(v), Millis: v >= msThreshold || v <= -msThreshold}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *FlexTime) UnmarshalJSON(data []byte) error {
+	var n FlexInt
+	if err := n.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("cannot unmarshal %s as FlexTime", string(data))
+	}
+
+	*f = NewFlexTime(n.Int64())
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, preserving the original unit.
+func (f FlexTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Unix())
+}
+
+// Time returns the timestamp as time.Time.
+func (f FlexTime) Time() time.Time {
+	return f.Val
+}
+
+// Unix returns the timestamp in its original unit (seconds or milliseconds).
+func (f FlexTime) Unix() int64 {
+	if f.Val.IsZero() {
+		return 0
+	}
+	if f.Millis {
+		return f.Val.UnixMilli()
+	}
+	return f.Val.Unix()
+}
+
+// IsZero returns true if the timestamp is unset.
+func (f FlexTime) IsZero() bool {
+	return f.Val.IsZero()
+}
+
+// String returns the timestamp in RFC 3339 format, or "" if unset.
+func (f FlexTime) String() string {
+	if f.Val.IsZero() {
+		return ""
+	}
+	return f.Val.Format(time.RFC3339)
+}
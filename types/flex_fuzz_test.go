@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// flexSeeds are JSON variants seen from real controllers, plus edge cases.
+var flexSeeds = []string{
+	`0`, `1`, `-1`, `123`, `12.5`, `1e3`, `1642567890`, `1642567890123`,
+	`"0"`, `"123"`, `"12.5"`, `""`, `" 1"`, `"abc"`, `"NaN"`, `"Inf"`, `"-0"`, `"1e400"`,
+	`true`, `false`, `"true"`, `"FALSE"`, `"yes"`, `"on"`, `"enabled"`,
+	`null`, `[]`, `["a"]`, `["a","b"]`, `[null]`, `{}`, `[1]`,
+}
+
+// fuzzRoundTrip checks that a value decoded from data encodes, and that
+// the encoding decodes to the same value according to same.
+func fuzzRoundTrip[T any](t *testing.T, data []byte, same func(a, b T) bool) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal(%+v) decoded from %q: %v", v, data, err)
+	}
+	var again T
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("Unmarshal(%s) of own encoding: %v", encoded, err)
+	}
+	if !same(v, again) {
+		t.Fatalf("round trip of %q: %+v became %+v via %s", data, v, again, encoded)
+	}
+}
+
+func FuzzFlexInt(f *testing.F) {
+	for _, s := range flexSeeds {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, func(a, b FlexInt) bool {
+			_ = a.String()
+			return a.Val == b.Val
+		})
+	})
+}
+
+func FuzzFlexBool(f *testing.F) {
+	for _, s := range flexSeeds {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, func(a, b FlexBool) bool {
+			_ = a.String()
+			return a.Val == b.Val
+		})
+	})
+}
+
+func FuzzFlexString(f *testing.F) {
+	for _, s := range flexSeeds {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, func(a, b FlexString) bool {
+			return a.Val == b.Val
+		})
+	})
+}
+
+func FuzzFlexTime(f *testing.F) {
+	for _, s := range flexSeeds {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, data, func(a, b FlexTime) bool {
+			_ = a.String()
+			return a.Unix() == b.Unix()
+		})
+	})
+}
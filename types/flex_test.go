@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestFlexInt_UnmarshalJSON_Numeric(t *testing.T) {
@@ -126,6 +127,9 @@ func TestFlexBool_UnmarshalJSON_String(t *testing.T) {
 		{"one string", `"1"`, true},
 		{"zero string", `"0"`, false},
 		{"other string", `"other"`, false},
+		{"capitalized", `"True"`, true},
+		{"yes", `"yes"`, true},
+		{"off", `"off"`, false},
 	}
 
 	for _, tt := range tests {
@@ -150,6 +154,8 @@ func TestFlexBool_UnmarshalJSON_Numeric(t *testing.T) {
 		{"one", `1`, true},
 		{"zero", `0`, false},
 		{"positive", `42`, true},
+		{"float", `1.0`, true},
+		{"float zero", `0.0`, false},
 	}
 
 	for _, tt := range tests {
@@ -165,6 +171,26 @@ func TestFlexBool_UnmarshalJSON_Numeric(t *testing.T) {
 	}
 }
 
+func TestFlexBool_UnmarshalJSON_Null(t *testing.T) {
+	f := FlexBool{Val: true, Txt: "true"}
+	if err := json.Unmarshal([]byte(`null`), &f); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if f.Bool() {
+		t.Error("Bool() = true, want false for null")
+	}
+	if f.String() != "false" {
+		t.Errorf("String() = %q, want false", f.String())
+	}
+}
+
+func TestFlexBool_UnmarshalJSON_Invalid(t *testing.T) {
+	var f FlexBool
+	if err := json.Unmarshal([]byte(`[true]`), &f); err == nil {
+		t.Error("UnmarshalJSON() should fail for an array")
+	}
+}
+
 func TestFlexBool_MarshalJSON(t *testing.T) {
 	tests := []struct {
 		name string
@@ -189,6 +215,43 @@ func TestFlexBool_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestFlexBool_OmitZeroRoundTrip(t *testing.T) {
+	// Unset fields stay out of the JSON, so an update does not
+	// overwrite them with false.
+	data, err := json.Marshal(Client{MAC: "aa:bb:cc:dd:ee:ff"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var fields map[string]any
+	json.Unmarshal(data, &fields)
+	for _, key := range []string{"is_guest", "is_wired", "noted"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("unset %s marshaled: %s", key, data)
+		}
+	}
+
+	// Decoded values, false included, are sent back.
+	var port PortTable
+	if err := json.Unmarshal([]byte(`{"port_idx":1,"autoneg":false,"is_uplink":"true","jumbo":0}`), &port); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	data, err = json.Marshal(port)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	fields = nil
+	json.Unmarshal(data, &fields)
+	want := map[string]any{"autoneg": false, "is_uplink": true, "jumbo": false}
+	for key, v := range want {
+		if fields[key] != v {
+			t.Errorf("%s = %v, want %v in %s", key, fields[key], v, data)
+		}
+	}
+	if _, ok := fields["masked"]; ok {
+		t.Errorf("unset masked marshaled: %s", data)
+	}
+}
+
 func TestFlexString_UnmarshalJSON_String(t *testing.T) {
 	var f FlexString
 	input := `"hello"`
@@ -240,3 +303,88 @@ func TestFlexString_MarshalJSON_Multiple(t *testing.T) {
 		t.Errorf("MarshalJSON() = %s, want %s", string(got), want)
 	}
 }
+
+func TestEpochTime(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int64
+		want time.Time
+	}{
+		{"zero", 0, time.Time{}},
+		{"seconds", 1642567890, time.Unix(1642567890, 0)},
+		{"milliseconds", 1642567890123, time.UnixMilli(1642567890123)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EpochTime(tt.in); !got.Equal(tt.want) {
+				t.Errorf("EpochTime(%d) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       time.Time
+		wantMillis bool
+	}{
+		{"seconds", `1642567890`, time.Unix(1642567890, 0), false},
+		{"milliseconds", `1642567890123`, time.UnixMilli(1642567890123), true},
+		{"string seconds", `"1642567890"`, time.Unix(1642567890, 0), false},
+		{"zero", `0`, time.Time{}, false},
+		{"null", `null`, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f FlexTime
+			if err := json.Unmarshal([]byte(tt.input), &f); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+			if !f.Time().Equal(tt.want) {
+				t.Errorf("Time() = %v, want %v", f.Time(), tt.want)
+			}
+			if f.Millis != tt.wantMillis {
+				t.Errorf("Millis = %v, want %v", f.Millis, tt.wantMillis)
+			}
+		})
+	}
+}
+
+func TestFlexTime_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"seconds round trip", `1642567890`, `1642567890`},
+		{"milliseconds round trip", `1642567890123`, `1642567890123`},
+		{"zero", `0`, `0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f FlexTime
+			if err := json.Unmarshal([]byte(tt.input), &f); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+			got, err := json.Marshal(f)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexTime_Invalid(t *testing.T) {
+	var f FlexTime
+	if err := json.Unmarshal([]byte(`true`), &f); err == nil {
+		t.Error("UnmarshalJSON() should fail for a boolean")
+	}
+}
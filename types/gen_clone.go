@@ -0,0 +1,233 @@
+//go:build ignore
+
+// gen_clone writes clone_gen.go: a Clone method for each type in
+// cloneTypes that copies slices, maps and pointers field by field.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// cloneTypes are the types that get a Clone method, with the noun its doc
+// comment uses.
+var cloneTypes = []struct {
+	value any
+	noun  string
+}{
+	{types.Device{}, "device"},
+	{types.DeviceBasic{}, "device summary"},
+	{types.PortOverride{}, "port override"},
+	{types.Network{}, "network"},
+	{types.WLAN{}, "WLAN"},
+	{types.WLANGroup{}, "WLAN group"},
+	{types.FirewallRule{}, "firewall rule"},
+	{types.FirewallGroup{}, "firewall group"},
+	{types.TrafficRule{}, "traffic rule"},
+	{types.PortForward{}, "port forward"},
+	{types.PortProfile{}, "port profile"},
+	{types.Client{}, "client"},
+	{types.User{}, "user"},
+	{types.UserGroup{}, "user group"},
+	{types.Route{}, "route"},
+	{types.DNSRecord{}, "DNS record"},
+	{types.RADIUSProfile{}, "RADIUS profile"},
+	{types.DynamicDNS{}, "dynamic DNS configuration"},
+	{types.Site{}, "site"},
+}
+
+var pkgPath = reflect.TypeOf(types.Device{}).PkgPath()
+
+type generator struct {
+	imports map[string]bool
+
+	// refs caches whether a type holds slices, maps, pointers or
+	// interfaces that a copy by value would share.
+	refs map[reflect.Type]bool
+
+	// pending are the struct types that need a copyRefs method.
+	pending []reflect.Type
+	queued  map[reflect.Type]bool
+}
+
+func main() {
+	g := &generator{
+		imports: make(map[string]bool),
+		refs:    make(map[reflect.Type]bool),
+		queued:  make(map[reflect.Type]bool),
+	}
+
+	var body bytes.Buffer
+	for _, ct := range cloneTypes {
+		t := reflect.TypeOf(ct.value)
+		fmt.Fprintf(&body, "\n// Clone returns a deep copy of the %s.\n", ct.noun)
+		fmt.Fprintf(&body, "func (x *%s) Clone() *%s {\n", t.Name(), t.Name())
+		body.WriteString("if x == nil {\nreturn nil\n}\nc := *x\n")
+		if g.hasRefs(t) {
+			g.queue(t)
+			body.WriteString("c.copyRefs()\n")
+		}
+		body.WriteString("return &c\n}\n")
+	}
+
+	// Emitting a method can queue more struct types.
+	for i := 0; i < len(g.pending); i++ {
+		t := g.pending[i]
+		body.WriteString("\n// copyRefs replaces the slices, maps and pointers of x with copies.\n")
+		fmt.Fprintf(&body, "func (x *%s) copyRefs() {\n", t.Name())
+		for j := 0; j < t.NumField(); j++ {
+			f := t.Field(j)
+			if f.IsExported() && g.hasRefs(f.Type) {
+				g.copy(&body, "x."+f.Name, f.Type, 1)
+			}
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_clone.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package types\n")
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		buf.WriteString("\nimport (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+		buf.WriteString(")\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("clone_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// queue schedules a copyRefs method for the struct type t.
+func (g *generator) queue(t reflect.Type) {
+	if !g.queued[t] {
+		g.queued[t] = true
+		g.pending = append(g.pending, t)
+	}
+}
+
+// hasRefs returns true if a copy of a t value by value would share
+// mutable state with the original.
+func (g *generator) hasRefs(t reflect.Type) bool {
+	if refs, ok := g.refs[t]; ok {
+		return refs
+	}
+	// A type that refers back to itself does so through a reference.
+	g.refs[t] = true
+
+	var refs bool
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		refs = true
+	case reflect.Array:
+		refs = g.hasRefs(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.IsExported() && g.hasRefs(f.Type) {
+				refs = true
+			}
+		}
+		if refs && t.PkgPath() != pkgPath {
+			log.Fatalf("%s: cannot copy the fields of a struct from another package", t)
+		}
+	}
+	g.refs[t] = refs
+	return refs
+}
+
+// copy writes statements that replace the value at dst, an addressable
+// expression of type t, with a deep copy of itself. depth keeps the names
+// of nested variables apart.
+func (g *generator) copy(w *bytes.Buffer, dst string, t reflect.Type, depth int) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		v := fmt.Sprintf("v%d", depth)
+		fmt.Fprintf(w, "if %s != nil {\n%s := *%s\n", dst, v, dst)
+		if g.hasRefs(t.Elem()) {
+			g.copy(w, v, t.Elem(), depth+1)
+		}
+		fmt.Fprintf(w, "%s = &%s\n}\n", dst, v)
+
+	case reflect.Slice:
+		s, i := fmt.Sprintf("s%d", depth), fmt.Sprintf("i%d", depth)
+		fmt.Fprintf(w, "if %s != nil {\n%s := make(%s, len(%s))\ncopy(%s, %s)\n", dst, s, g.typeName(t), dst, s, dst)
+		if g.hasRefs(t.Elem()) {
+			fmt.Fprintf(w, "for %s := range %s {\n", i, s)
+			g.copy(w, fmt.Sprintf("%s[%s]", s, i), t.Elem(), depth+1)
+			w.WriteString("}\n")
+		}
+		fmt.Fprintf(w, "%s = %s\n}\n", dst, s)
+
+	case reflect.Array:
+		i := fmt.Sprintf("i%d", depth)
+		fmt.Fprintf(w, "for %s := range %s {\n", i, dst)
+		g.copy(w, fmt.Sprintf("%s[%s]", dst, i), t.Elem(), depth+1)
+		w.WriteString("}\n")
+
+	case reflect.Map:
+		m, k, v := fmt.Sprintf("m%d", depth), fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		fmt.Fprintf(w, "if %s != nil {\n%s := make(%s, len(%s))\n", dst, m, g.typeName(t), dst)
+		fmt.Fprintf(w, "for %s, %s := range %s {\n", k, v, dst)
+		if g.hasRefs(t.Elem()) {
+			g.copy(w, v, t.Elem(), depth+1)
+		}
+		fmt.Fprintf(w, "%s[%s] = %s\n}\n%s = %s\n}\n", m, k, v, dst, m)
+
+	case reflect.Interface:
+		// The dynamic type is only known at run time.
+		fmt.Fprintf(w, "if %s != nil {\n%s = DeepCopy(%s)\n}\n", dst, dst, dst)
+
+	case reflect.Struct:
+		g.queue(t)
+		fmt.Fprintf(w, "%s.copyRefs()\n", dst)
+	}
+}
+
+// typeName returns t as written in package types.
+func (g *generator) typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		switch t.PkgPath() {
+		case "", pkgPath:
+			return t.Name()
+		}
+		g.imports[t.PkgPath()] = true
+		return t.String()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + g.typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeName(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", g.typeName(t.Key()), g.typeName(t.Elem()))
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any"
+		}
+	}
+	log.Fatalf("%s: unsupported type", t)
+	return ""
+}
@@ -0,0 +1,82 @@
+//go:build ignore
+
+// gen_dpi reads dpi.csv and writes dpi_gen.go.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+)
+
+func main() {
+	f, err := os.Open("dpi.csv")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var categories, apps bytes.Buffer
+	known := make(map[int]bool)
+	seen := make(map[int]bool)
+	for i, rec := range records[1:] {
+		if len(rec) != 4 {
+			log.Fatalf("dpi.csv record %d: want 4 fields, got %d", i+1, len(rec))
+		}
+		cat := atoi(rec[1], i)
+		switch rec[0] {
+		case "category":
+			known[cat] = true
+			fmt.Fprintf(&categories, "\t{ID: %d, Name: %q},\n", cat, rec[3])
+		case "app":
+			if !known[cat] {
+				log.Fatalf("dpi.csv record %d: unknown category %d", i+1, cat)
+			}
+			id := cat<<16 | atoi(rec[2], i)
+			if seen[id] {
+				log.Fatalf("dpi.csv record %d: duplicate app %d/%s", i+1, cat, rec[2])
+			}
+			seen[id] = true
+			fmt.Fprintf(&apps, "\t{ID: %d, CategoryID: %d, Name: %q},\n", id, cat, rec[3])
+		default:
+			log.Fatalf("dpi.csv record %d: unknown kind %q", i+1, rec[0])
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_dpi.go from dpi.csv; DO NOT EDIT.\n\n")
+	buf.WriteString("package types\n\n")
+	buf.WriteString("var dpiCategories = []DPICategory{\n")
+	buf.Write(categories.Bytes())
+	buf.WriteString("}\n\n")
+	buf.WriteString("var dpiApps = []DPIApp{\n")
+	buf.Write(apps.Bytes())
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("dpi_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func atoi(s string, record int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		log.Fatalf("dpi.csv record %d: %v", record+1, err)
+	}
+	return n
+}
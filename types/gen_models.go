@@ -0,0 +1,78 @@
+//go:build ignore
+
+// gen_models reads models.csv and writes models_gen.go.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	f, err := os.Open("models.csv")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_models.go from models.csv; DO NOT EDIT.\n\n")
+	buf.WriteString("package types\n\n")
+	buf.WriteString("var modelCatalog = []ModelInfo{\n")
+
+	seen := make(map[string]bool)
+	for i, rec := range records[1:] {
+		if len(rec) != 8 {
+			log.Fatalf("models.csv record %d: want 8 fields, got %d", i+1, len(rec))
+		}
+		code := rec[0]
+		if seen[code] {
+			log.Fatalf("models.csv record %d: duplicate code %s", i+1, code)
+		}
+		seen[code] = true
+
+		ports := atoi(rec[4], i)
+		poePorts := atoi(rec[5], i)
+		budget, err := strconv.ParseFloat(rec[6], 64)
+		if err != nil {
+			log.Fatalf("models.csv record %d: poe_budget: %v", i+1, err)
+		}
+
+		fmt.Fprintf(&buf, "\t{Code: %q, SKU: %q, Name: %q, Type: %q, Ports: %d, PoEPorts: %d, PoEBudget: %v",
+			code, rec[1], rec[2], rec[3], ports, poePorts, budget)
+		if radios := strings.Fields(rec[7]); len(radios) > 0 {
+			fmt.Fprintf(&buf, ", Radios: %#v", radios)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("models_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func atoi(s string, record int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		log.Fatalf("models.csv record %d: %v", record+1, err)
+	}
+	return n
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+
+// gen_schemas writes the JSON Schemas of types.SchemaTypes to schemas/.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func main() {
+	if err := os.MkdirAll("schemas", 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for name, schema := range types.Schemas() {
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		path := filepath.Join("schemas", name+".schema.json")
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
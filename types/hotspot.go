@@ -0,0 +1,71 @@
+package types
+
+import "time"
+
+// Voucher is a hotspot voucher from stat/voucher. Limits of zero are
+// unlimited.
+type Voucher struct {
+	ID         string `json:"_id,omitempty"`
+	SiteID     string `json:"site_id,omitempty"`
+	Code       string `json:"code"`
+	CreateTime int64  `json:"create_time"`
+	Note       string `json:"note,omitempty"`
+	AdminName  string `json:"admin_name,omitempty"`
+	ForHotspot bool   `json:"for_hotspot,omitempty"`
+
+	// Duration is how long a guest stays authorized, in minutes.
+	Duration int `json:"duration"`
+
+	// Quota is how many times the voucher can be used: 1 for single-use,
+	// 0 for unlimited.
+	Quota int `json:"quota"`
+	Used  int `json:"used"`
+
+	// Rate and data limits, applied when QOSOverwrite is set.
+	QOSOverwrite   bool  `json:"qos_overwrite,omitempty"`
+	QOSRateMaxUp   int   `json:"qos_rate_max_up,omitempty"`   // Kbps
+	QOSRateMaxDown int   `json:"qos_rate_max_down,omitempty"` // Kbps
+	QOSUsageQuota  int64 `json:"qos_usage_quota,omitempty"`   // MB
+
+	// Status is "VALID_ONE", "VALID_MULTI" or "USED_MULTIPLE".
+	Status        string `json:"status,omitempty"`
+	StatusExpires int64  `json:"status_expires,omitempty"`
+}
+
+// Created returns when the voucher was created.
+func (v *Voucher) Created() time.Time {
+	return time.Unix(v.CreateTime, 0)
+}
+
+// FormattedCode returns the code the way the controller prints it, such
+// as "12345-67890".
+func (v *Voucher) FormattedCode() string {
+	if len(v.Code) != 10 {
+		return v.Code
+	}
+	return v.Code[:5] + "-" + v.Code[5:]
+}
+
+// MultiUse reports whether more than one guest can use the voucher.
+func (v *Voucher) MultiUse() bool {
+	return v.Quota != 1
+}
+
+// Remaining returns how many more times the voucher can be used, or -1 if
+// it is unlimited.
+func (v *Voucher) Remaining() int {
+	if v.Quota == 0 {
+		return -1
+	}
+	return max(v.Quota-v.Used, 0)
+}
+
+// HotspotOperator is an account that can only create and print vouchers
+// in the hotspot manager.
+type HotspotOperator struct {
+	ID       string `json:"_id,omitempty"`
+	SiteID   string `json:"site_id,omitempty"`
+	Name     string `json:"name"`
+	Password string `json:"x_password,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
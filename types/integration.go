@@ -0,0 +1,188 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// Types of the official Integrations API (/proxy/network/integration/v1),
+// which uses camelCase JSON, UUIDs and its own enums. Its IDs are not the
+// legacy _id values; the conversions to legacy types leave ID empty.
+
+// IntegrationPage is one page of an Integrations API list.
+type IntegrationPage[T any] struct {
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	Count      int `json:"count"`
+	TotalCount int `json:"totalCount"`
+	Data       []T `json:"data"`
+}
+
+// IntegrationInfo describes the application serving the Integrations API.
+type IntegrationInfo struct {
+	ApplicationVersion string `json:"applicationVersion"`
+}
+
+// IntegrationSite is a site in the Integrations API. InternalReference is
+// the legacy site name, such as "default".
+type IntegrationSite struct {
+	ID                string `json:"id"`
+	InternalReference string `json:"internalReference"`
+	Name              string `json:"name"`
+}
+
+// Site converts the site to the legacy type.
+func (s *IntegrationSite) Site() Site {
+	return Site{Name: s.InternalReference, Desc: s.Name}
+}
+
+// Integrations API device states.
+const (
+	IntegrationDeviceOnline                = "ONLINE"
+	IntegrationDeviceOffline               = "OFFLINE"
+	IntegrationDevicePendingAdoption       = "PENDING_ADOPTION"
+	IntegrationDeviceUpdating              = "UPDATING"
+	IntegrationDeviceGettingReady          = "GETTING_READY"
+	IntegrationDeviceAdopting              = "ADOPTING"
+	IntegrationDeviceDeleting              = "DELETING"
+	IntegrationDeviceConnectionInterrupted = "CONNECTION_INTERRUPTED"
+	IntegrationDeviceIsolated              = "ISOLATED"
+)
+
+// IntegrationDevice is an adopted device in the Integrations API.
+type IntegrationDevice struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Model             string `json:"model"`
+	Supported         bool   `json:"supported"`
+	MACAddress        string `json:"macAddress"`
+	IPAddress         string `json:"ipAddress"`
+	State             string `json:"state"`
+	FirmwareVersion   string `json:"firmwareVersion"`
+	FirmwareUpdatable bool   `json:"firmwareUpdatable"`
+
+	// Features are capabilities such as "switching" and "accessPoint".
+	Features []string `json:"features,omitempty"`
+}
+
+// HasFeature reports whether the device has the feature.
+func (d *IntegrationDevice) HasFeature(feature string) bool {
+	for _, f := range d.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Device converts the device to the legacy type. Type is "uap" for access
+// points and "usw" for switches, and empty if the features do not tell.
+func (d *IntegrationDevice) Device() Device {
+	dev := Device{
+		MAC:        strings.ToLower(d.MACAddress),
+		Name:       d.Name,
+		Model:      d.Model,
+		IP:         d.IPAddress,
+		Version:    d.FirmwareVersion,
+		Upgradable: d.FirmwareUpdatable,
+		Adopted:    d.State != IntegrationDevicePendingAdoption,
+		State:      integrationDeviceState(d.State),
+	}
+	switch {
+	case d.HasFeature("accessPoint"):
+		dev.Type = "uap"
+	case d.HasFeature("switching"):
+		dev.Type = "usw"
+	}
+	return dev
+}
+
+// DeviceBasic converts the device to the legacy summary type.
+func (d *IntegrationDevice) DeviceBasic() DeviceBasic {
+	dev := d.Device()
+	return DeviceBasic{MAC: dev.MAC, Type: dev.Type, Model: dev.Model, Name: dev.Name, State: dev.State}
+}
+
+// integrationDeviceState maps an Integrations API state to a DeviceState.
+func integrationDeviceState(state string) DeviceState {
+	switch state {
+	case IntegrationDeviceOnline:
+		return DeviceStateConnected
+	case IntegrationDevicePendingAdoption:
+		return DeviceStatePending
+	case IntegrationDeviceUpdating:
+		return DeviceStateUpgrading
+	case IntegrationDeviceGettingReady:
+		return DeviceStateProvisioning
+	case IntegrationDeviceAdopting:
+		return DeviceStateAdopting
+	case IntegrationDeviceConnectionInterrupted:
+		return DeviceStateHeartbeat
+	case IntegrationDeviceDeleting:
+		return DeviceStateDeleting
+	case IntegrationDeviceIsolated:
+		return DeviceStateIsolated
+	default:
+		return DeviceStateOffline
+	}
+}
+
+// IntegrationClient is a connected client in the Integrations API. Type is
+// "WIRED", "WIRELESS", "VPN" or "TELEPORT".
+type IntegrationClient struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Name           string    `json:"name"`
+	ConnectedAt    time.Time `json:"connectedAt"`
+	IPAddress      string    `json:"ipAddress"`
+	MACAddress     string    `json:"macAddress"`
+	UplinkDeviceID string    `json:"uplinkDeviceId,omitempty"`
+}
+
+// Client converts the client to the legacy type, with Uptime counted from
+// ConnectedAt to now.
+func (c *IntegrationClient) Client() Client {
+	client := Client{
+		MAC:     strings.ToLower(c.MACAddress),
+		Name:    c.Name,
+		IP:      c.IPAddress,
+		IsWired: FlexBool{Val: c.Type == "WIRED"},
+	}
+	if !c.ConnectedAt.IsZero() {
+		client.FirstSeen = FlexTime{Val: c.ConnectedAt.Truncate(time.Second)}
+		client.LastSeen = FlexTime{Val: time.Now().Truncate(time.Second)}
+		client.Uptime = FlexInt{Val: float64(client.LastSeen.Unix() - client.FirstSeen.Unix())}
+	}
+	return client
+}
+
+// IntegrationVoucher is a hotspot voucher in the Integrations API. Limits
+// of zero are unlimited.
+type IntegrationVoucher struct {
+	ID                   string     `json:"id"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	Name                 string     `json:"name"`
+	Code                 string     `json:"code"`
+	AuthorizedGuestLimit int        `json:"authorizedGuestLimit,omitempty"`
+	AuthorizedGuestCount int        `json:"authorizedGuestCount"`
+	ActivatedAt          *time.Time `json:"activatedAt,omitempty"`
+	ExpiresAt            *time.Time `json:"expiresAt,omitempty"`
+	Expired              bool       `json:"expired"`
+	TimeLimitMinutes     int        `json:"timeLimitMinutes"`
+	DataUsageLimitMBytes int64      `json:"dataUsageLimitMBytes,omitempty"`
+	RxRateLimitKbps      int        `json:"rxRateLimitKbps,omitempty"`
+	TxRateLimitKbps      int        `json:"txRateLimitKbps,omitempty"`
+}
+
+// IntegrationVoucherRequest creates Count vouchers with the same limits.
+// AuthorizedGuestLimit is the number of guests each voucher admits; zero
+// is unlimited.
+type IntegrationVoucherRequest struct {
+	Count                int    `json:"count"`
+	Name                 string `json:"name"`
+	AuthorizedGuestLimit int    `json:"authorizedGuestLimit,omitempty"`
+	TimeLimitMinutes     int    `json:"timeLimitMinutes"`
+	DataUsageLimitMBytes int64  `json:"dataUsageLimitMBytes,omitempty"`
+	RxRateLimitKbps      int    `json:"rxRateLimitKbps,omitempty"`
+	TxRateLimitKbps      int    `json:"txRateLimitKbps,omitempty"`
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// IPSEvent is a threat detected by the gateway's intrusion detection and
+// prevention, including connections to internal honeypots.
+type IPSEvent struct {
+	ID        string `json:"_id"`
+	Time      int64  `json:"time"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Datetime  string `json:"datetime,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Message   string `json:"msg,omitempty"`
+	SiteID    string `json:"site_id,omitempty"`
+	Subsystem string `json:"subsystem,omitempty"`
+	Archived  bool   `json:"archived,omitempty"`
+
+	// Flow
+	SrcIP    string `json:"src_ip,omitempty"`
+	SrcMAC   string `json:"src_mac,omitempty"`
+	SrcPort  int    `json:"src_port,omitempty"`
+	DestIP   string `json:"dest_ip,omitempty"`
+	DestMAC  string `json:"dst_mac,omitempty"`
+	DestPort int    `json:"dest_port,omitempty"`
+	Proto    string `json:"proto,omitempty"`
+	AppProto string `json:"app_proto,omitempty"`
+	InIface  string `json:"in_iface,omitempty"`
+
+	// Signature
+	Category    string `json:"catname,omitempty"`
+	Action      string `json:"inner_alert_action,omitempty"` // "allowed", "blocked"
+	Signature   string `json:"inner_alert_signature,omitempty"`
+	SignatureID int    `json:"inner_alert_signature_id,omitempty"`
+	Severity    int    `json:"inner_alert_severity,omitempty"`
+}
+
+// OccurredAt returns Time as time.Time.
+func (e *IPSEvent) OccurredAt() time.Time {
+	return EpochTime(e.Time)
+}
+
+// IsHoneypot reports whether the event is a connection to one of the
+// honeypots, or is categorized as a honeypot trigger by the gateway.
+func (e *IPSEvent) IsHoneypot(honeypots []Honeypot) bool {
+	for _, h := range honeypots {
+		if e.DestIP != "" && e.DestIP == h.IPAddress {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(e.Category), "honeypot")
+}
@@ -0,0 +1,98 @@
+package types
+
+import "strings"
+
+//go:generate go run gen_models.go
+
+// ModelInfo describes a device model from the built-in catalog.
+type ModelInfo struct {
+	// Code is the controller's model code, e.g. "UDMPRO".
+	Code string
+
+	// SKU is the marketing name printed on the box, e.g. "UDM-Pro".
+	SKU string
+
+	// Name is the display name, e.g. "UniFi Dream Machine Pro".
+	Name string
+
+	// Type is the device type ("uap", "usw", "udm", "ugw", "uxg").
+	Type string
+
+	// Ports is the number of wired ports, including SFP/SFP+ cages.
+	Ports int
+
+	// PoEPorts is the number of ports able to supply PoE.
+	PoEPorts int
+
+	// PoEBudget is the total PoE budget in watts, or 0 if unpublished.
+	PoEBudget float64
+
+	// Radios lists the supported Wi-Fi bands ("2g", "5g", "6g").
+	Radios []string
+}
+
+// HasRadio returns true if the model supports the given band.
+func (m ModelInfo) HasRadio(band string) bool {
+	for _, r := range m.Radios {
+		if r == band {
+			return true
+		}
+	}
+	return false
+}
+
+// modelIndex maps normalized codes and SKUs to catalog entries.
+var modelIndex = func() map[string]int {
+	index := make(map[string]int, 2*len(modelCatalog))
+	for i, m := range modelCatalog {
+		index[normalizeName(m.Code)] = i
+		index[normalizeName(m.SKU)] = i
+	}
+	return index
+}()
+
+// normalizeName uppercases s and drops everything but letters and digits,
+// so "USW-24-POE", "usw 24 poe" and "USW24POE" match.
+func normalizeName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// LookupModel finds a model by controller code or SKU, ignoring case and
+// punctuation.
+func LookupModel(model string) (ModelInfo, bool) {
+	i, ok := modelIndex[normalizeName(model)]
+	if !ok {
+		return ModelInfo{}, false
+	}
+	return modelCatalog[i], true
+}
+
+// Models returns a copy of the model catalog.
+func Models() []ModelInfo {
+	return DeepCopy(modelCatalog)
+}
+
+// ModelInfo returns catalog information for the device's model.
+func (d *Device) ModelInfo() (ModelInfo, bool) {
+	return LookupModel(d.Model)
+}
+
+// ModelName returns the display name of the device's model, falling back to
+// the raw model code for models missing from the catalog.
+func (d *Device) ModelName() string {
+	if m, ok := d.ModelInfo(); ok {
+		return m.Name
+	}
+	return d.Model
+}
+
+// ModelInfo returns catalog information for the device's model.
+func (d *DeviceBasic) ModelInfo() (ModelInfo, bool) {
+	return LookupModel(d.Model)
+}
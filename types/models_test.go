@@ -0,0 +1,75 @@
+package types
+
+import "testing"
+
+func TestLookupModel(t *testing.T) {
+	tests := []struct {
+		model    string
+		wantCode string
+		wantName string
+	}{
+		{"UDMPRO", "UDMPRO", "UniFi Dream Machine Pro"},
+		{"U6LR", "UALR6", "UniFi 6 Long-Range"},
+		{"USW-24-POE", "USL24P", "UniFi Switch 24 PoE"},
+		{"uap-ac-pro", "U7PG2", "UniFi AP AC Pro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			m, ok := LookupModel(tt.model)
+			if !ok {
+				t.Fatalf("LookupModel(%q) not found", tt.model)
+			}
+			if m.Code != tt.wantCode || m.Name != tt.wantName {
+				t.Errorf("LookupModel(%q) = %s/%s, want %s/%s", tt.model, m.Code, m.Name, tt.wantCode, tt.wantName)
+			}
+		})
+	}
+
+	if _, ok := LookupModel("NOPE1"); ok {
+		t.Error("LookupModel should not find an unknown model")
+	}
+}
+
+func TestModels_UniqueKeys(t *testing.T) {
+	seen := make(map[string]string)
+	for _, m := range Models() {
+		for _, key := range []string{m.Code, m.SKU} {
+			n := normalizeName(key)
+			if other, ok := seen[n]; ok && other != m.Code {
+				t.Errorf("%s and %s both match %q", other, m.Code, key)
+			}
+			seen[n] = m.Code
+		}
+		if m.PoEPorts > m.Ports {
+			t.Errorf("%s: PoEPorts %d > Ports %d", m.Code, m.PoEPorts, m.Ports)
+		}
+	}
+}
+
+func TestDevice_ModelInfo(t *testing.T) {
+	d := &Device{Model: "USL24P"}
+	m, ok := d.ModelInfo()
+	if !ok {
+		t.Fatal("ModelInfo() not found")
+	}
+	if m.PoEPorts != 16 || m.PoEBudget != 95 {
+		t.Errorf("PoE = %d ports / %vW, want 16 / 95W", m.PoEPorts, m.PoEBudget)
+	}
+	if d.ModelName() != "UniFi Switch 24 PoE" {
+		t.Errorf("ModelName() = %s", d.ModelName())
+	}
+
+	ap := &Device{Model: "UAE6"}
+	if m, _ := ap.ModelInfo(); !m.HasRadio(WLANBand6G) {
+		t.Error("U6 Enterprise should have a 6 GHz radio")
+	}
+
+	unknown := &Device{Model: "X1"}
+	if _, ok := unknown.ModelInfo(); ok {
+		t.Error("ModelInfo() should not find an unknown model")
+	}
+	if unknown.ModelName() != "X1" {
+		t.Errorf("ModelName() = %s, want raw model", unknown.ModelName())
+	}
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"encoding/binary"
+	"net"
+)
+
 // Network represents a UniFi network configuration (VLAN, subnet, DHCP, etc.).
 type Network struct {
 	ID              string `json:"_id,omitempty"`
@@ -97,6 +102,72 @@ type Network struct {
 	Up                  bool    `json:"up,omitempty"`
 }
 
+// DHCPPoolSize returns the number of addresses in the DHCP range, or 0 if
+// the DHCP server is disabled or the range is not a valid IPv4 range.
+func (n *Network) DHCPPoolSize() int {
+	start, stop, ok := n.dhcpRange()
+	if !ok {
+		return 0
+	}
+	return int(stop-start) + 1
+}
+
+// InDHCPPool reports whether ip lies within the DHCP range.
+func (n *Network) InDHCPPool(ip string) bool {
+	start, stop, ok := n.dhcpRange()
+	if !ok {
+		return false
+	}
+	addr, ok := ipv4Uint(ip)
+	return ok && addr >= start && addr <= stop
+}
+
+// dhcpRange returns the DHCP range as integers.
+func (n *Network) dhcpRange() (start, stop uint32, ok bool) {
+	if !n.DHCPDEnabled {
+		return 0, 0, false
+	}
+	start, okStart := ipv4Uint(n.DHCPDStart)
+	stop, okStop := ipv4Uint(n.DHCPDStop)
+	if !okStart || !okStop || start > stop {
+		return 0, 0, false
+	}
+	return start, stop, true
+}
+
+// ipv4Uint converts a dotted IPv4 address to an integer.
+func ipv4Uint(s string) (uint32, bool) {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ip), true
+}
+
+// NetworkUsage is a network joined with its active clients and DHCP pool
+// use.
+type NetworkUsage struct {
+	Network Network `json:"network"`
+
+	ActiveClients   int `json:"active_clients"`
+	WiredClients    int `json:"wired_clients"`
+	WirelessClients int `json:"wireless_clients"`
+
+	// DHCPLeases counts active clients holding an address from the DHCP
+	// range; clients with a fixed IP are not counted.
+	DHCPLeases   int `json:"dhcp_leases"`
+	DHCPPoolSize int `json:"dhcp_pool_size"`
+}
+
+// DHCPUtilization returns DHCPLeases as a fraction of DHCPPoolSize, or 0
+// if the network has no DHCP pool.
+func (u *NetworkUsage) DHCPUtilization() float64 {
+	if u.DHCPPoolSize == 0 {
+		return 0
+	}
+	return float64(u.DHCPLeases) / float64(u.DHCPPoolSize)
+}
+
 // WANProviderCaps represents WAN provider capabilities.
 type WANProviderCaps struct {
 	DownloadKilobitsPerSecond FlexInt `json:"download_kilobits_per_second,omitempty"`
@@ -355,3 +355,18 @@ func TestWANProviderCaps_UnmarshalJSON(t *testing.T) {
 		t.Errorf("UploadKilobitsPerSecond = %v, want 50000", caps.UploadKilobitsPerSecond.Int())
 	}
 }
+
+func TestNetwork_DHCPPool(t *testing.T) {
+	n := Network{DHCPDEnabled: true, DHCPDStart: "192.168.1.250", DHCPDStop: "192.168.2.9"}
+	if got := n.DHCPPoolSize(); got != 16 {
+		t.Errorf("DHCPPoolSize() = %d, want 16", got)
+	}
+	if !n.InDHCPPool("192.168.2.0") || n.InDHCPPool("192.168.2.10") || n.InDHCPPool("bogus") {
+		t.Error("InDHCPPool() gave wrong membership")
+	}
+
+	n.DHCPDEnabled = false
+	if got := n.DHCPPoolSize(); got != 0 {
+		t.Errorf("DHCPPoolSize() with DHCP disabled = %d, want 0", got)
+	}
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PoEClassWatts is the power a switch reserves at the port for each
+// 802.3af/at/bt class.
+var PoEClassWatts = map[int]float64{
+	0: 15.4,
+	1: 4.0,
+	2: 7.0,
+	3: 15.4,
+	4: 30.0,
+	5: 45.0,
+	6: 60.0,
+	7: 75.0,
+	8: 90.0,
+}
+
+// PortPoE is the PoE state of one switch port.
+type PortPoE struct {
+	PortIdx int    `json:"port_idx"`
+	Name    string `json:"name,omitempty"`
+	Mode    string `json:"mode,omitempty"` // "auto", "pasv24", "passthrough", "off"
+
+	// Class is the negotiated PoE class, or -1 if none, for example for
+	// passive PoE or an idle port.
+	Class int `json:"class"`
+
+	// Powered is true if the port is delivering power to a device.
+	Powered bool `json:"powered"`
+
+	// AllocatedWatts is the power reserved for the port: the maximum of
+	// its class, or what it draws if it has no class.
+	AllocatedWatts float64 `json:"allocated_watts"`
+
+	DrawnWatts float64 `json:"drawn_watts"`
+	Voltage    float64 `json:"voltage,omitempty"`
+	CurrentMA  float64 `json:"current_ma,omitempty"`
+}
+
+// PoEBudget summarizes the PoE power of a switch.
+type PoEBudget struct {
+	MAC  string `json:"mac"`
+	Name string `json:"name,omitempty"`
+
+	// TotalWatts is the switch's PoE budget, or 0 if it does not report one.
+	TotalWatts float64 `json:"total_watts"`
+
+	AllocatedWatts float64 `json:"allocated_watts"`
+	DrawnWatts     float64 `json:"drawn_watts"`
+
+	// Ports are the PoE-capable ports, by index.
+	Ports []PortPoE `json:"ports"`
+}
+
+// AvailableWatts is the budget not yet allocated. It is negative if the
+// switch is oversubscribed.
+func (b *PoEBudget) AvailableWatts() float64 {
+	return b.TotalWatts - b.AllocatedWatts
+}
+
+// NewPoEBudget computes the PoE budget of a switch from its port table
+// and total_max_power. Only powered ports count towards the allocated and
+// drawn totals.
+func NewPoEBudget(d *Device) *PoEBudget {
+	b := &PoEBudget{
+		MAC:        strings.ToLower(d.MAC),
+		Name:       d.Name,
+		TotalWatts: float64(d.TotalMaxPower),
+		Ports:      []PortPoE{},
+	}
+
+	for _, p := range d.PoEPorts() {
+		port := PortPoE{
+			PortIdx:    p.PortIdx,
+			Name:       p.Name,
+			Mode:       p.PoeMode,
+			Class:      poeClass(p.PoeClass),
+			DrawnWatts: p.PoePower.Float64(),
+			Voltage:    p.PoeVoltage.Float64(),
+			CurrentMA:  p.PoeCurrent.Float64(),
+		}
+		port.Powered = p.PoeEnable && (p.PoeGood || port.DrawnWatts > 0)
+		if port.Powered {
+			if watts, ok := PoEClassWatts[port.Class]; ok {
+				port.AllocatedWatts = watts
+			} else {
+				port.AllocatedWatts = port.DrawnWatts
+			}
+			b.AllocatedWatts += port.AllocatedWatts
+			b.DrawnWatts += port.DrawnWatts
+		}
+		b.Ports = append(b.Ports, port)
+	}
+	sort.Slice(b.Ports, func(i, j int) bool { return b.Ports[i].PortIdx < b.Ports[j].PortIdx })
+
+	return b
+}
+
+// poeClass parses a port's poe_class, such as "Class 4" or "4", returning
+// -1 if it has none.
+func poeClass(s string) int {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "Class"))
+	class, err := strconv.Atoi(s)
+	if err != nil || class < 0 {
+		return -1
+	}
+	return class
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPoEBudget(t *testing.T) {
+	d := &Device{
+		MAC:           "AA:00:00:00:00:01",
+		Name:          "Closet",
+		TotalMaxPower: 60,
+		PortTable: []PortTable{
+			{PortIdx: 3, PortPoe: true, PoeEnable: true, PoeGood: true, PoeMode: "auto", PoeClass: "Class 4", PoePower: FlexInt{Val: 12.5}},
+			{PortIdx: 1, PortPoe: true, PoeEnable: true, PoeGood: true, PoeMode: "auto", PoeClass: "2", PoePower: FlexInt{Val: 3.2}},
+			{PortIdx: 2, PortPoe: true, PoeEnable: true, PoeMode: "pasv24", PoePower: FlexInt{Val: 5}},
+			{PortIdx: 4, PortPoe: true, PoeEnable: true, PoeMode: "auto", PoeClass: "Unknown"},
+			{PortIdx: 5, PortPoe: true, PoeMode: "off", PoeClass: "Class 4"},
+			{PortIdx: 6},
+		},
+	}
+
+	b := NewPoEBudget(d)
+	if b.MAC != "aa:00:00:00:00:01" || b.TotalWatts != 60 {
+		t.Errorf("budget = %+v", b)
+	}
+	if len(b.Ports) != 5 || b.Ports[0].PortIdx != 1 || b.Ports[4].PortIdx != 5 {
+		t.Fatalf("Ports = %+v, want PoE ports 1-5 in order", b.Ports)
+	}
+
+	want := []struct {
+		class     int
+		powered   bool
+		allocated float64
+	}{
+		{2, true, 7},
+		{-1, true, 5},
+		{4, true, 30},
+		{-1, false, 0},
+		{4, false, 0},
+	}
+	for i, w := range want {
+		p := b.Ports[i]
+		if p.Class != w.class || p.Powered != w.powered || p.AllocatedWatts != w.allocated {
+			t.Errorf("port %d = %+v, want class %d powered %v allocated %v", p.PortIdx, p, w.class, w.powered, w.allocated)
+		}
+	}
+
+	if b.AllocatedWatts != 42 || math.Abs(b.DrawnWatts-20.7) > 1e-9 || b.AvailableWatts() != 18 {
+		t.Errorf("allocated %v drawn %v available %v, want 42, 20.7, 18", b.AllocatedWatts, b.DrawnWatts, b.AvailableWatts())
+	}
+}
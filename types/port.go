@@ -1,5 +1,10 @@
 package types
 
+import (
+	"strconv"
+	"strings"
+)
+
 // PortForward represents a port forwarding rule.
 type PortForward struct {
 	ID              string `json:"_id,omitempty"`
@@ -7,12 +12,181 @@ type PortForward struct {
 	Name            string `json:"name"`
 	Enabled         bool   `json:"enabled"`
 	Protocol        string `json:"proto"` // "tcp", "udp", "tcp_udp"
-	SrcNetworkID    string `json:"src,omitempty"` // "wan" or network ID
+	SrcNetworkID    string `json:"src,omitempty"` // "any", or the allowed source IP/CIDR when limited by IP
 	DstPort         string `json:"dst_port"`
 	FwdIP           string `json:"fwd"` // Forward to IP
 	FwdPort         string `json:"fwd_port"`
 	LogForward      bool   `json:"log,omitempty"`
 	PfRule          string `json:"pfrule,omitempty"`
+
+	// WANInterface selects the WAN the forward listens on: PortForwardWAN,
+	// PortForwardWAN2 or PortForwardBothWANs.
+	WANInterface string `json:"pfwd_interface,omitempty"`
+
+	// DestinationIP is "any" or a specific WAN address to match.
+	DestinationIP string `json:"destination_ip,omitempty"`
+
+	// Source restriction. With SrcLimitingType "ip" the allowed source is
+	// in SrcNetworkID; with "firewall_group" it is SrcFirewallGroupID.
+	SrcLimitingEnabled bool   `json:"src_limiting_enabled,omitempty"`
+	SrcLimitingType    string `json:"src_limiting_type,omitempty"`
+	SrcFirewallGroupID string `json:"src_firewall_group_id,omitempty"`
+}
+
+// WAN interface and source limiting values for PortForward.
+const (
+	PortForwardWAN      = "wan"
+	PortForwardWAN2     = "wan2"
+	PortForwardBothWANs = "both"
+
+	SrcLimitingIP            = "ip"
+	SrcLimitingFirewallGroup = "firewall_group"
+)
+
+// Overlaps reports whether p and other capture some of the same traffic:
+// they share a protocol, a WAN interface and a WAN address, and their
+// external port specifications have a port in common. An empty protocol,
+// WAN interface or WAN address counts as the controller's default
+// (ProtocolTCPUDP, PortForwardWAN and "any"). Enabled state is ignored.
+func (p *PortForward) Overlaps(other *PortForward) bool {
+	if !overlapsWithDefault(p.Protocol, other.Protocol, ProtocolTCPUDP, ProtocolTCPUDP) ||
+		!overlapsWithDefault(p.WANInterface, other.WANInterface, PortForwardWAN, PortForwardBothWANs) ||
+		!overlapsWithDefault(p.DestinationIP, other.DestinationIP, "any", "any") {
+		return false
+	}
+
+	for _, a := range portRanges(p.DstPort) {
+		for _, b := range portRanges(other.DstPort) {
+			if a[0] <= b[1] && b[0] <= a[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlapsWithDefault reports whether two values overlap, where an empty
+// value means def and wildcard overlaps everything.
+func overlapsWithDefault(a, b, def, wildcard string) bool {
+	if a == "" {
+		a = def
+	}
+	if b == "" {
+		b = def
+	}
+	return a == b || a == wildcard || b == wildcard
+}
+
+// portRanges returns the inclusive ranges of a port specification such as
+// "80,443,8000-8100", skipping malformed parts.
+func portRanges(value string) [][2]int {
+	var ranges [][2]int
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isRange {
+			hi = lo
+		}
+		a, errA := strconv.Atoi(lo)
+		b, errB := strconv.Atoi(hi)
+		if errA == nil && errB == nil && a <= b {
+			ranges = append(ranges, [2]int{a, b})
+		}
+	}
+	return ranges
+}
+
+// PortForwardBuilder assembles a PortForward, checking it with Validate
+// when built:
+//
+//	pf, err := types.NewPortForward("Web", "443", "192.168.1.10").
+//		ForwardPort("8443").
+//		Protocol(types.ProtocolTCP).
+//		OnWAN(types.PortForwardWAN2).
+//		FromSource("203.0.113.0/24").
+//		Logged().
+//		Build()
+type PortForwardBuilder struct {
+	pf PortForward
+}
+
+// NewPortForward starts an enabled TCP and UDP forward of dstPort to the
+// same port on fwdIP, accepting any source on the primary WAN.
+func NewPortForward(name, dstPort, fwdIP string) *PortForwardBuilder {
+	return &PortForwardBuilder{pf: PortForward{
+		Name:          name,
+		Enabled:       true,
+		Protocol:      ProtocolTCPUDP,
+		SrcNetworkID:  "any",
+		DstPort:       dstPort,
+		FwdIP:         fwdIP,
+		FwdPort:       dstPort,
+		WANInterface:  PortForwardWAN,
+		DestinationIP: "any",
+	}}
+}
+
+// ForwardPort sets the port or range on the target host.
+func (b *PortForwardBuilder) ForwardPort(port string) *PortForwardBuilder {
+	b.pf.FwdPort = port
+	return b
+}
+
+// Protocol sets ProtocolTCP, ProtocolUDP or ProtocolTCPUDP.
+func (b *PortForwardBuilder) Protocol(proto string) *PortForwardBuilder {
+	b.pf.Protocol = proto
+	return b
+}
+
+// OnWAN sets the WAN interface the forward listens on.
+func (b *PortForwardBuilder) OnWAN(iface string) *PortForwardBuilder {
+	b.pf.WANInterface = iface
+	return b
+}
+
+// ToWANAddress matches only traffic sent to the given WAN address.
+func (b *PortForwardBuilder) ToWANAddress(ip string) *PortForwardBuilder {
+	b.pf.DestinationIP = ip
+	return b
+}
+
+// FromSource limits the forward to a source IP address or CIDR.
+func (b *PortForwardBuilder) FromSource(cidr string) *PortForwardBuilder {
+	b.pf.SrcLimitingEnabled = true
+	b.pf.SrcLimitingType = SrcLimitingIP
+	b.pf.SrcNetworkID = cidr
+	b.pf.SrcFirewallGroupID = ""
+	return b
+}
+
+// FromFirewallGroup limits the forward to the addresses of a firewall
+// group.
+func (b *PortForwardBuilder) FromFirewallGroup(id string) *PortForwardBuilder {
+	b.pf.SrcLimitingEnabled = true
+	b.pf.SrcLimitingType = SrcLimitingFirewallGroup
+	b.pf.SrcNetworkID = "any"
+	b.pf.SrcFirewallGroupID = id
+	return b
+}
+
+// Logged enables logging of forwarded connections.
+func (b *PortForwardBuilder) Logged() *PortForwardBuilder {
+	b.pf.LogForward = true
+	return b
+}
+
+// Disabled creates the forward disabled.
+func (b *PortForwardBuilder) Disabled() *PortForwardBuilder {
+	b.pf.Enabled = false
+	return b
+}
+
+// Build validates and returns the port forward.
+func (b *PortForwardBuilder) Build() (*PortForward, error) {
+	pf := b.pf
+	if err := pf.Validate(); err != nil {
+		return nil, err
+	}
+	return &pf, nil
 }
 
 // PortProfile represents a switch port profile.
@@ -34,6 +34,33 @@ func TestPortForward_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestPortForwardBuilder(t *testing.T) {
+	pf, err := NewPortForward("Web", "443", "192.168.1.10").
+		ForwardPort("8443").
+		Protocol(ProtocolTCP).
+		OnWAN(PortForwardWAN2).
+		FromSource("203.0.113.0/24").
+		Logged().
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if pf.WANInterface != "wan2" || pf.SrcLimitingType != "ip" || pf.SrcNetworkID != "203.0.113.0/24" || !pf.LogForward || !pf.Enabled {
+		t.Errorf("Build() = %+v", pf)
+	}
+
+	data, _ := json.Marshal(pf)
+	var raw map[string]interface{}
+	json.Unmarshal(data, &raw)
+	if raw["pfwd_interface"] != "wan2" || raw["src_limiting_enabled"] != true || raw["destination_ip"] != "any" {
+		t.Errorf("JSON = %s", data)
+	}
+
+	if _, err := NewPortForward("Bad", "80-81", "192.168.1.10").ForwardPort("80").Protocol("icmp").Build(); err == nil {
+		t.Error("Build() of invalid forward succeeded, want error")
+	}
+}
+
 func TestPortProfile_UnmarshalJSON(t *testing.T) {
 	jsonData := `{
 		"_id": "profile123",
@@ -57,3 +84,39 @@ func TestPortProfile_UnmarshalJSON(t *testing.T) {
 		t.Errorf("TaggedNetworkConfIDs length = %v, want 2", len(profile.TaggedNetworkConfIDs))
 	}
 }
+
+func TestPortForward_Overlaps(t *testing.T) {
+	base := PortForward{Protocol: ProtocolTCP, DstPort: "80,8000-8100"}
+	tests := []struct {
+		name  string
+		other PortForward
+		want  bool
+	}{
+		{"same port", PortForward{Protocol: ProtocolTCP, DstPort: "80"}, true},
+		{"range edge", PortForward{Protocol: ProtocolTCP, DstPort: "8100-8200"}, true},
+		{"disjoint", PortForward{Protocol: ProtocolTCP, DstPort: "81-7999"}, false},
+		{"tcp_udp covers tcp", PortForward{Protocol: ProtocolTCPUDP, DstPort: "8050"}, true},
+		{"empty protocol is tcp_udp", PortForward{DstPort: "80"}, true},
+		{"udp only", PortForward{Protocol: ProtocolUDP, DstPort: "80"}, false},
+		{"second WAN", PortForward{Protocol: ProtocolTCP, DstPort: "80", WANInterface: PortForwardWAN2}, false},
+		{"both WANs", PortForward{Protocol: ProtocolTCP, DstPort: "80", WANInterface: PortForwardBothWANs}, true},
+		{"specific WAN address", PortForward{Protocol: ProtocolTCP, DstPort: "80", DestinationIP: "203.0.113.5"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Overlaps(&tt.other); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.Overlaps(&base); got != tt.want {
+				t.Errorf("reversed Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	a := PortForward{DstPort: "80", DestinationIP: "203.0.113.5"}
+	b := PortForward{DstPort: "80", DestinationIP: "203.0.113.6"}
+	if a.Overlaps(&b) {
+		t.Error("forwards on different WAN addresses should not overlap")
+	}
+}
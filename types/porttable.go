@@ -0,0 +1,53 @@
+package types
+
+import "strings"
+
+// FindPort returns the port with the given index, or nil.
+func (d *Device) FindPort(idx int) *PortTable {
+	for i := range d.PortTable {
+		if d.PortTable[i].PortIdx == idx {
+			return &d.PortTable[i]
+		}
+	}
+	return nil
+}
+
+// PoEPorts returns the PoE-capable ports, in port table order.
+func (d *Device) PoEPorts() []PortTable {
+	ports := []PortTable{}
+	for _, p := range d.PortTable {
+		if p.PortPoe {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
+
+// UplinkPort returns the port the device uplinks through, from its uplink
+// record or else the port flagged is_uplink. It returns nil for wireless
+// uplinks and devices without a port table.
+func (d *Device) UplinkPort() *PortTable {
+	if d.Uplink != nil && d.Uplink.PortIdx != 0 {
+		if p := d.FindPort(d.Uplink.PortIdx); p != nil {
+			return p
+		}
+	}
+	for i := range d.PortTable {
+		if d.PortTable[i].IsUplink.Val {
+			return &d.PortTable[i]
+		}
+	}
+	return nil
+}
+
+// IsSFP reports whether the port is an SFP or SFP+ cage, whether or not a
+// module is inserted.
+func (p *PortTable) IsSFP() bool {
+	return p.SFPFound || strings.HasPrefix(strings.ToUpper(p.MediaType), "SFP")
+}
+
+// IsAggregated reports whether the port is a member of a link aggregation
+// led by another port, whose index is AggregatedBy.
+func (p *PortTable) IsAggregated() bool {
+	return p.AggregatedBy.Int() > 0
+}
@@ -0,0 +1,44 @@
+package types
+
+import "testing"
+
+func TestDevice_PortHelpers(t *testing.T) {
+	d := &Device{
+		PortTable: []PortTable{
+			{PortIdx: 1, MediaType: "GE", PortPoe: true},
+			{PortIdx: 2, MediaType: "GE"},
+			{PortIdx: 3, MediaType: "GE", PortPoe: true},
+			{PortIdx: 9, MediaType: "SFP+", IsUplink: FlexBool{Val: true}},
+			{PortIdx: 10, MediaType: "GE", SFPFound: true},
+		},
+	}
+
+	if p := d.FindPort(3); p == nil || p.PortIdx != 3 {
+		t.Errorf("FindPort(3) = %+v", p)
+	}
+	if p := d.FindPort(4); p != nil {
+		t.Errorf("FindPort(4) = %+v, want nil", p)
+	}
+
+	poe := d.PoEPorts()
+	if len(poe) != 2 || poe[0].PortIdx != 1 || poe[1].PortIdx != 3 {
+		t.Errorf("PoEPorts() = %+v, want ports 1 and 3", poe)
+	}
+
+	if p := d.UplinkPort(); p == nil || p.PortIdx != 9 {
+		t.Errorf("UplinkPort() = %+v, want port 9 from is_uplink", p)
+	}
+	d.Uplink = &DeviceUplink{PortIdx: 2}
+	if p := d.UplinkPort(); p == nil || p.PortIdx != 2 {
+		t.Errorf("UplinkPort() = %+v, want port 2 from uplink record", p)
+	}
+	if p := (&Device{Uplink: &DeviceUplink{Type: "wireless"}}).UplinkPort(); p != nil {
+		t.Errorf("UplinkPort() without ports = %+v, want nil", p)
+	}
+
+	for idx, want := range map[int]bool{1: false, 9: true, 10: true} {
+		if got := d.FindPort(idx).IsSFP(); got != want {
+			t.Errorf("port %d IsSFP() = %v, want %v", idx, got, want)
+		}
+	}
+}
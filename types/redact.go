@@ -0,0 +1,157 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Redacted replaces secret values in String and GoString output.
+const Redacted = "[REDACTED]"
+
+// redact hides a non-empty secret. Empty values are kept so that
+// "no passphrase set" remains visible in logs.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// goString renders v with %#v under the exported type name.
+func goString(v interface{}, plain, name string) string {
+	return strings.Replace(fmt.Sprintf("%#v", v), "types."+plain, "types."+name, 1)
+}
+
+// Plain aliases without methods, used to format redacted copies without
+// recursing into String/GoString.
+type (
+	wlanPlain          WLAN
+	networkPlain       Network
+	radiusProfilePlain RADIUSProfile
+	radiusServerPlain  RADIUSServer
+	dynamicDNSPlain    DynamicDNS
+	mgmtPlain          SettingMgmt
+	guestAccessPlain   SettingGuestAccess
+	superMgmtPlain     SettingSuperMgmt
+	superSMTPPlain     SettingSuperSMTP
+	hotspotOpPlain     HotspotOperator
+)
+
+// String implements fmt.Stringer with the passphrase redacted.
+func (w WLAN) String() string {
+	w.Passphrase = redact(w.Passphrase)
+	return fmt.Sprintf("%+v", wlanPlain(w))
+}
+
+// GoString implements fmt.GoStringer with the passphrase redacted.
+func (w WLAN) GoString() string {
+	w.Passphrase = redact(w.Passphrase)
+	return goString(wlanPlain(w), "wlanPlain", "WLAN")
+}
+
+// String implements fmt.Stringer with the WAN password redacted.
+func (n Network) String() string {
+	n.WANPassword = redact(n.WANPassword)
+	return fmt.Sprintf("%+v", networkPlain(n))
+}
+
+// GoString implements fmt.GoStringer with the WAN password redacted.
+func (n Network) GoString() string {
+	n.WANPassword = redact(n.WANPassword)
+	return goString(networkPlain(n), "networkPlain", "Network")
+}
+
+// String implements fmt.Stringer. Server secrets are redacted by
+// RADIUSServer's own formatting methods.
+func (p RADIUSProfile) String() string {
+	return fmt.Sprintf("%+v", radiusProfilePlain(p))
+}
+
+// GoString implements fmt.GoStringer with server secrets redacted.
+func (p RADIUSProfile) GoString() string {
+	return goString(radiusProfilePlain(p), "radiusProfilePlain", "RADIUSProfile")
+}
+
+// String implements fmt.Stringer with the shared secret redacted.
+func (s RADIUSServer) String() string {
+	s.Secret = redact(s.Secret)
+	return fmt.Sprintf("%+v", radiusServerPlain(s))
+}
+
+// GoString implements fmt.GoStringer with the shared secret redacted.
+func (s RADIUSServer) GoString() string {
+	s.Secret = redact(s.Secret)
+	return goString(radiusServerPlain(s), "radiusServerPlain", "RADIUSServer")
+}
+
+// String implements fmt.Stringer with the password redacted.
+func (d DynamicDNS) String() string {
+	d.Password = redact(d.Password)
+	return fmt.Sprintf("%+v", dynamicDNSPlain(d))
+}
+
+// GoString implements fmt.GoStringer with the password redacted.
+func (d DynamicDNS) GoString() string {
+	d.Password = redact(d.Password)
+	return goString(dynamicDNSPlain(d), "dynamicDNSPlain", "DynamicDNS")
+}
+
+// String implements fmt.Stringer with the SSH password redacted.
+func (m SettingMgmt) String() string {
+	m.XSSHPassword = redact(m.XSSHPassword)
+	return fmt.Sprintf("%+v", mgmtPlain(m))
+}
+
+// GoString implements fmt.GoStringer with the SSH password redacted.
+func (m SettingMgmt) GoString() string {
+	m.XSSHPassword = redact(m.XSSHPassword)
+	return goString(mgmtPlain(m), "mgmtPlain", "SettingMgmt")
+}
+
+// String implements fmt.Stringer with the guest password redacted.
+func (g SettingGuestAccess) String() string {
+	g.Password = redact(g.Password)
+	return fmt.Sprintf("%+v", guestAccessPlain(g))
+}
+
+// GoString implements fmt.GoStringer with the guest password redacted.
+func (g SettingGuestAccess) GoString() string {
+	g.Password = redact(g.Password)
+	return goString(guestAccessPlain(g), "guestAccessPlain", "SettingGuestAccess")
+}
+
+// String implements fmt.Stringer with the SSH password redacted.
+func (m SettingSuperMgmt) String() string {
+	m.XSSHPassword = redact(m.XSSHPassword)
+	return fmt.Sprintf("%+v", superMgmtPlain(m))
+}
+
+// GoString implements fmt.GoStringer with the SSH password redacted.
+func (m SettingSuperMgmt) GoString() string {
+	m.XSSHPassword = redact(m.XSSHPassword)
+	return goString(superMgmtPlain(m), "superMgmtPlain", "SettingSuperMgmt")
+}
+
+// String implements fmt.Stringer with the SMTP password redacted.
+func (m SettingSuperSMTP) String() string {
+	m.Password = redact(m.Password)
+	return fmt.Sprintf("%+v", superSMTPPlain(m))
+}
+
+// GoString implements fmt.GoStringer with the SMTP password redacted.
+func (m SettingSuperSMTP) GoString() string {
+	m.Password = redact(m.Password)
+	return goString(superSMTPPlain(m), "superSMTPPlain", "SettingSuperSMTP")
+}
+
+// String implements fmt.Stringer with the password redacted.
+func (o HotspotOperator) String() string {
+	o.Password = redact(o.Password)
+	return fmt.Sprintf("%+v", hotspotOpPlain(o))
+}
+
+// GoString implements fmt.GoStringer with the password redacted.
+func (o HotspotOperator) GoString() string {
+	o.Password = redact(o.Password)
+	return goString(hotspotOpPlain(o), "hotspotOpPlain", "HotspotOperator")
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedaction(t *testing.T) {
+	wlan := WLAN{Name: "Home", Passphrase: "hunter22"}
+	network := Network{Name: "WAN", WANPassword: "pppoe-secret"}
+	profile := RADIUSProfile{
+		Name:        "corp",
+		AuthServers: []RADIUSServer{{IP: "10.0.0.5", Port: 1812, Secret: "radius-secret"}},
+		AcctServers: []RADIUSServer{{IP: "10.0.0.6", Port: 1813, Secret: "acct-secret"}},
+	}
+	ddns := DynamicDNS{Service: "dyndns", Password: "ddns-secret"}
+	siteMgmt := SettingMgmt{XSSHUsername: "root", XSSHPassword: "site-ssh-secret"}
+	guest := SettingGuestAccess{Auth: "simple", Password: "guest-secret"}
+	mgmt := SettingSuperMgmt{XSSHUsername: "admin", XSSHPassword: "ssh-secret"}
+	smtp := SettingSuperSMTP{Host: "smtp.example.com", Password: "smtp-secret"}
+	operator := HotspotOperator{Name: "front-desk", Password: "operator-secret"}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		secrets []string
+		visible string
+	}{
+		{"wlan", wlan, []string{"hunter22"}, "Home"},
+		{"wlan pointer", &wlan, []string{"hunter22"}, "Home"},
+		{"network", network, []string{"pppoe-secret"}, "WAN"},
+		{"radius profile", profile, []string{"radius-secret", "acct-secret"}, "10.0.0.5"},
+		{"dynamic dns", ddns, []string{"ddns-secret"}, "dyndns"},
+		{"mgmt", siteMgmt, []string{"site-ssh-secret"}, "root"},
+		{"guest access", &guest, []string{"guest-secret"}, "simple"},
+		{"super mgmt", mgmt, []string{"ssh-secret"}, "admin"},
+		{"super smtp", &smtp, []string{"smtp-secret"}, "smtp.example.com"},
+		{"hotspot operator", operator, []string{"operator-secret"}, "front-desk"},
+	}
+
+	for _, tt := range tests {
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+			t.Run(tt.name+" "+verb, func(t *testing.T) {
+				out := fmt.Sprintf(verb, tt.value)
+				for _, secret := range tt.secrets {
+					if strings.Contains(out, secret) {
+						t.Errorf("%s output leaks %q: %s", verb, secret, out)
+					}
+				}
+				if !strings.Contains(out, Redacted) {
+					t.Errorf("%s output missing %s: %s", verb, Redacted, out)
+				}
+				if !strings.Contains(out, tt.visible) {
+					t.Errorf("%s output missing %q: %s", verb, tt.visible, out)
+				}
+			})
+		}
+	}
+
+	// Formatting must not modify the original values
+	if wlan.Passphrase != "hunter22" || profile.AuthServers[0].Secret != "radius-secret" || operator.Password != "operator-secret" || guest.Password != "guest-secret" {
+		t.Error("formatting modified the original value")
+	}
+}
+
+func TestRedaction_GoStringTypeName(t *testing.T) {
+	out := fmt.Sprintf("%#v", WLAN{Name: "Home"})
+	if !strings.HasPrefix(out, "types.WLAN{") {
+		t.Errorf("GoString() = %s, want types.WLAN prefix", out)
+	}
+}
+
+func TestRedaction_EmptySecretVisible(t *testing.T) {
+	out := fmt.Sprintf("%+v", WLAN{Name: "Open"})
+	if strings.Contains(out, Redacted) {
+		t.Errorf("empty passphrase should not be shown as redacted: %s", out)
+	}
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReportInterval is the aggregation interval of a stat/report query. The
+// controller keeps 5-minute data for about a day, hourly data for about a
+// week and daily and monthly data for about a year.
+type ReportInterval string
+
+// Report intervals.
+const (
+	ReportFiveMinutes ReportInterval = "5minutes"
+	ReportHourly      ReportInterval = "hourly"
+	ReportDaily       ReportInterval = "daily"
+	ReportMonthly     ReportInterval = "monthly"
+)
+
+// Duration returns the length of one interval; a month counts as 30 days.
+func (i ReportInterval) Duration() time.Duration {
+	switch i {
+	case ReportFiveMinutes:
+		return 5 * time.Minute
+	case ReportHourly:
+		return time.Hour
+	case ReportDaily:
+		return 24 * time.Hour
+	case ReportMonthly:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// ReportType is what a stat/report query aggregates.
+type ReportType string
+
+// Report types.
+const (
+	ReportSite    ReportType = "site"
+	ReportAP      ReportType = "ap"
+	ReportUser    ReportType = "user"
+	ReportGateway ReportType = "gw"
+)
+
+// Report metrics. Not every metric applies to every report type; the
+// controller omits those that do not.
+const (
+	MetricBytes      = "bytes"
+	MetricRxBytes    = "rx_bytes"
+	MetricTxBytes    = "tx_bytes"
+	MetricWANRxBytes = "wan-rx_bytes"
+	MetricWANTxBytes = "wan-tx_bytes"
+	MetricWLANBytes  = "wlan_bytes"
+	MetricNumSta     = "num_sta"
+	MetricLANNumSta  = "lan-num_sta"
+	MetricWLANNumSta = "wlan-num_sta"
+	MetricLANRxBytes = "lan-rx_bytes"
+	MetricLANTxBytes = "lan-tx_bytes"
+	MetricCPU        = "cpu"
+	MetricMem        = "mem"
+)
+
+// ReportRow is one interval of a stat/report query. Metrics holds every
+// numeric field of the row except time, keyed by metric name.
+type ReportRow struct {
+	// Time is the start of the interval in epoch milliseconds.
+	Time int64 `json:"time"`
+
+	OID     string `json:"oid,omitempty"`
+	AP      string `json:"ap,omitempty"`
+	User    string `json:"user,omitempty"`
+	Gateway string `json:"gw,omitempty"`
+
+	Metrics map[string]float64 `json:"-"`
+}
+
+// StartTime returns the start of the interval.
+func (r *ReportRow) StartTime() time.Time {
+	return time.UnixMilli(r.Time)
+}
+
+// Value returns a metric, or 0 if the row does not have it.
+func (r *ReportRow) Value(metric string) float64 {
+	return r.Metrics[metric]
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *ReportRow) UnmarshalJSON(data []byte) error {
+	type plain ReportRow
+	var row plain
+	if err := json.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	row.Metrics = make(map[string]float64, len(fields))
+	for name, raw := range fields {
+		if name == "time" {
+			continue
+		}
+		var value float64
+		if err := json.Unmarshal(raw, &value); err == nil {
+			row.Metrics[name] = value
+		}
+	}
+
+	*r = ReportRow(row)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the metrics as fields.
+func (r ReportRow) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(r.Metrics)+5)
+	for name, value := range r.Metrics {
+		fields[name] = value
+	}
+	fields["time"] = r.Time
+	for name, value := range map[string]string{"oid": r.OID, "ap": r.AP, "user": r.User, "gw": r.Gateway} {
+		if value != "" {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestReportRow_JSON(t *testing.T) {
+	data := `{"time":1714564800000,"oid":"5f1a","wan-rx_bytes":1.5e9,"num_sta":12,"site":"default"}`
+
+	var row ReportRow
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !row.StartTime().Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) || row.OID != "5f1a" {
+		t.Errorf("row = %+v", row)
+	}
+	if row.Value(MetricWANRxBytes) != 1.5e9 || row.Value(MetricNumSta) != 12 || len(row.Metrics) != 2 {
+		t.Errorf("Metrics = %v, want wan-rx_bytes and num_sta", row.Metrics)
+	}
+
+	out, err := json.Marshal(row)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var back ReportRow
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatalf("Unmarshal() of %s error = %v", out, err)
+	}
+	if back.Time != row.Time || back.Value(MetricNumSta) != 12 {
+		t.Errorf("round trip = %+v, want %+v", back, row)
+	}
+}
@@ -0,0 +1,230 @@
+package types
+
+//go:generate go run gen_schemas.go
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaDraft is the JSON Schema dialect produced by Schema.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a JSON Schema document or subschema. Only the keywords
+// Schema produces are modeled.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 any                    `json:"type,omitempty"` // string or []string
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// schemaProvider is implemented by types whose JSON encoding is not
+// derived from their Go fields, such as the Flex types.
+type schemaProvider interface {
+	jsonSchema() *JSONSchema
+}
+
+// FlexInt also accepts false, for numbers the controller leaves unset.
+func (FlexInt) jsonSchema() *JSONSchema {
+	return &JSONSchema{Type: []string{"boolean", "number", "string"}}
+}
+
+func (FlexBool) jsonSchema() *JSONSchema {
+	return &JSONSchema{Type: []string{"boolean", "number", "string"}}
+}
+
+func (FlexString) jsonSchema() *JSONSchema {
+	return &JSONSchema{Type: []string{"string", "array"}, Items: &JSONSchema{Type: "string"}}
+}
+
+func (FlexTime) jsonSchema() *JSONSchema {
+	return &JSONSchema{Type: []string{"integer", "string"}}
+}
+
+func (DeviceState) jsonSchema() *JSONSchema {
+	return &JSONSchema{Type: "integer"}
+}
+
+// SchemaTypes are the types with published schemas, by file name (without
+// the .schema.json extension). See Schemas.
+var SchemaTypes = map[string]any{
+	"client":         Client{},
+	"device":         Device{},
+	"firewall_group": FirewallGroup{},
+	"firewall_rule":  FirewallRule{},
+	"network":        Network{},
+	"port_forward":   PortForward{},
+	"port_profile":   PortProfile{},
+	"route":          Route{},
+	"traffic_rule":   TrafficRule{},
+	"user":           User{},
+	"user_group":     UserGroup{},
+	"wlan":           WLAN{},
+	"wlan_group":     WLANGroup{},
+}
+
+// Schemas returns the schemas of SchemaTypes, keyed like it. The files in
+// types/schemas are generated from it by go generate.
+func Schemas() map[string]*JSONSchema {
+	schemas := make(map[string]*JSONSchema, len(SchemaTypes))
+	for name, v := range SchemaTypes {
+		schemas[name] = Schema(v)
+	}
+	return schemas
+}
+
+// Schema returns a JSON Schema for the JSON encoding of v's type, derived
+// from its fields and json tags. Fields without omitempty are required.
+// Nested struct types are described once under $defs and referenced.
+// Properties are not closed, since the controller adds fields between
+// releases.
+func Schema(v any) *JSONSchema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	g := &schemaGen{root: t, defs: make(map[string]*JSONSchema)}
+	var s *JSONSchema
+	if t.Kind() == reflect.Struct && !t.Implements(schemaProviderType) {
+		s = g.structSchema(t)
+	} else {
+		s = g.schema(t)
+	}
+	s.Schema = SchemaDraft
+	s.Title = t.Name()
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s
+}
+
+// schemaGen builds one schema document.
+type schemaGen struct {
+	root reflect.Type
+	defs map[string]*JSONSchema
+}
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	rawMessageType     = reflect.TypeOf(json.RawMessage{})
+	schemaProviderType = reflect.TypeOf((*schemaProvider)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schema returns the schema of t, adding named struct types to defs.
+func (g *schemaGen) schema(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Implements(schemaProviderType):
+		return reflect.Zero(t).Interface().(schemaProvider).jsonSchema()
+	case t == timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	case t == rawMessageType || t.Implements(jsonMarshalerType):
+		return &JSONSchema{}
+	case t.Implements(textMarshalerType):
+		return &JSONSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string", Format: "byte"}
+		}
+		return &JSONSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		switch {
+		case t.Name() == "":
+			return g.structSchema(t)
+		case t == g.root:
+			return &JSONSchema{Ref: "#"}
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // break cycles
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/$defs/" + t.Name()}
+	}
+	return &JSONSchema{}
+}
+
+// structSchema describes a struct's fields, flattening embedded structs
+// the way encoding/json does.
+func (g *schemaGen) structSchema(t reflect.Type) *JSONSchema {
+	s := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *schemaGen) addFields(s *JSONSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			g.addFields(s, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var prop *JSONSchema
+		if hasTagOption(opts, "string") {
+			prop = &JSONSchema{Type: "string"}
+		} else {
+			prop = g.schema(f.Type)
+		}
+		s.Properties[name] = prop
+		if !hasTagOption(opts, "omitempty") && !hasTagOption(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// hasTagOption reports whether a json tag's options include opt.
+func hasTagOption(opts, opt string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSchema(t *testing.T) {
+	type inner struct {
+		Name string `json:"name"`
+	}
+	type node struct {
+		MAC      string            `json:"mac"`
+		Count    int               `json:"count,omitempty"`
+		Ratio    float64           `json:"ratio,string"`
+		Rate     FlexInt           `json:"rate"`
+		Enabled  FlexBool          `json:"enabled,omitempty"`
+		Seen     time.Time         `json:"seen"`
+		Tags     []string          `json:"tags,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Inner    *inner            `json:"inner,omitempty"`
+		Children []node            `json:"children,omitempty"`
+		Skipped  string            `json:"-"`
+		internal string
+		inner
+	}
+
+	s := Schema(&node{})
+	if s.Schema != SchemaDraft || s.Title != "node" || s.Type != "object" {
+		t.Errorf("Schema() header = %q %q %v", s.Schema, s.Title, s.Type)
+	}
+	if want := []string{"mac", "ratio", "rate", "seen", "name"}; !slices.Equal(s.Required, want) {
+		t.Errorf("Required = %v, want %v", s.Required, want)
+	}
+
+	props := s.Properties
+	if len(props) != 11 {
+		t.Errorf("properties = %d, want 11 (skipped and unexported fields left out)", len(props))
+	}
+	checks := map[string]string{
+		"mac":      `{"type":"string"}`,
+		"ratio":    `{"type":"string"}`,
+		"rate":     `{"type":["boolean","number","string"]}`,
+		"seen":     `{"type":"string","format":"date-time"}`,
+		"tags":     `{"type":"array","items":{"type":"string"}}`,
+		"labels":   `{"type":"object","additionalProperties":{"type":"string"}}`,
+		"inner":    `{"$ref":"#/$defs/inner"}`,
+		"children": `{"type":"array","items":{"$ref":"#"}}`,
+		"name":     `{"type":"string"}`,
+	}
+	for name, want := range checks {
+		got, _ := json.Marshal(props[name])
+		if string(got) != want {
+			t.Errorf("property %s = %s, want %s", name, got, want)
+		}
+	}
+	if def := s.Defs["inner"]; def == nil || def.Properties["name"] == nil {
+		t.Errorf("$defs = %v, want inner", s.Defs)
+	}
+}
+
+// TestSchemas_Generated fails if the files in schemas/ are out of date; run
+// go generate to update them.
+func TestSchemas_Generated(t *testing.T) {
+	for name, schema := range Schemas() {
+		want, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := os.ReadFile(filepath.Join("schemas", name+".schema.json"))
+		if err != nil {
+			t.Errorf("%s: %v (run go generate)", name, err)
+			continue
+		}
+		if !bytes.Equal(got, append(want, '\n')) {
+			t.Errorf("schemas/%s.schema.json is out of date; run go generate", name)
+		}
+	}
+}
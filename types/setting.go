@@ -60,6 +60,18 @@ type SettingIPS struct {
 	Setting
 	Enabled        bool   `json:"enabled,omitempty"`
 	RuleCategories []string `json:"rule_categories,omitempty"`
+
+	// Internal honeypots, at most one per network
+	HoneypotEnabled bool       `json:"honeypot_enabled"`
+	Honeypots       []Honeypot `json:"honeypot"`
+}
+
+// Honeypot is an unused address on a network that the gateway answers
+// on. Clients that connect to it are reported as IPS events.
+type Honeypot struct {
+	IPAddress string `json:"ip_address"`
+	NetworkID string `json:"network_id"`
+	Version   string `json:"version,omitempty"` // "v4"
 }
 
 // SettingNTP represents NTP server settings.
@@ -105,8 +117,21 @@ type RADIUSProfile struct {
 	VLANWLANMode          string `json:"vlan_wlan_mode,omitempty"`
 	InterimUpdateEnabled  bool   `json:"interim_update_enabled,omitempty"`
 	InterimUpdateInterval int    `json:"interim_update_interval,omitempty"`
+
+	// AccountingEnabled sends accounting records to AcctServers, or to
+	// the gateway's built-in server with UseGatewayAcctServer.
+	AccountingEnabled    bool `json:"accounting_enabled,omitempty"`
+	UseGatewayAuthServer bool `json:"use_usg_auth_server,omitempty"`
+	UseGatewayAcctServer bool `json:"use_usg_acct_server,omitempty"`
 }
 
+// RADIUS VLAN assignment modes for wireless clients (VLANWLANMode).
+const (
+	RADIUSVLANDisabled = "disabled"
+	RADIUSVLANOptional = "optional"
+	RADIUSVLANRequired = "required"
+)
+
 // RADIUSServer represents a RADIUS server configuration.
 type RADIUSServer struct {
 	IP     string `json:"ip"`
@@ -127,6 +152,67 @@ type DynamicDNS struct {
 	Password string `json:"x_password,omitempty"`
 }
 
+// SettingSuperMgmt holds controller-wide management settings.
+type SettingSuperMgmt struct {
+	Setting
+	AutoUpgrade          bool   `json:"auto_upgrade,omitempty"`
+	AutobackupEnabled    bool   `json:"autobackup_enabled,omitempty"`
+	AutobackupCronExpr   string `json:"autobackup_cron_expr,omitempty"`
+	AutobackupDays       int    `json:"autobackup_days,omitempty"`
+	AutobackupMaxFiles   int    `json:"autobackup_max_files,omitempty"`
+	BackupToCloudEnabled bool   `json:"backup_to_cloud_enabled,omitempty"`
+	Discoverable         bool   `json:"discoverable,omitempty"`
+	AnalyticsDisapproved bool   `json:"analytics_disapproved,omitempty"`
+	LiveChat             string `json:"live_chat,omitempty"`
+	LiveUpdates          string `json:"live_updates,omitempty"`
+	OverrideInformHost   bool   `json:"override_inform_host,omitempty"`
+	XSSHUsername         string `json:"x_ssh_username,omitempty"`
+	XSSHPassword         string `json:"x_ssh_password,omitempty"`
+}
+
+// SettingSuperSMTP holds the controller's outgoing mail settings.
+type SettingSuperSMTP struct {
+	Setting
+	Enabled   bool   `json:"enabled"`
+	Host      string `json:"host,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	UseSSL    bool   `json:"use_ssl,omitempty"`
+	UseAuth   bool   `json:"use_auth,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"x_password,omitempty"`
+	UseSender bool   `json:"use_sender,omitempty"`
+	Sender    string `json:"sender,omitempty"`
+}
+
+// SettingSuperIdentity holds the controller's name and hostname.
+type SettingSuperIdentity struct {
+	Setting
+	Name     string `json:"name,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// SettingLCM holds the settings of the touchscreen (LCM) on consoles and
+// switches that have one, such as the UDM Pro. With Sync, they apply to
+// every device's screen.
+type SettingLCM struct {
+	Setting
+	Enabled bool `json:"enabled"`
+
+	// Brightness is the screen brightness in percent, 1 to 100.
+	Brightness int `json:"brightness,omitempty"`
+
+	// IdleTimeout is how long the screen stays on without activity, in
+	// seconds. Zero keeps it on.
+	IdleTimeout int `json:"idle_timeout"`
+
+	Sync       bool `json:"sync"`
+	TouchEvent bool `json:"touch_event"` // wake the screen on touch
+
+	// Pages are the pages the screen cycles through. Firmware that does
+	// not support choosing them omits the field.
+	Pages []string `json:"pages,omitempty"`
+}
+
 // Setting key constants.
 const (
 	SettingKeyMgmt         = "mgmt"
@@ -139,4 +225,12 @@ const (
 	SettingKeySNMP         = "snmp"
 	SettingKeyRsyslog      = "rsyslog"
 	SettingKeyRadius       = "radius"
+	SettingKeyLCM          = "lcm"
+)
+
+// Controller-wide setting keys. These settings are shared by all sites.
+const (
+	SettingKeySuperMgmt     = "super_mgmt"
+	SettingKeySuperSMTP     = "super_smtp"
+	SettingKeySuperIdentity = "super_identity"
 )
@@ -40,12 +40,12 @@ type HealthData struct {
 	NumSw              int       `json:"num_sw,omitempty"`
 	TxBytesR           FlexInt   `json:"tx_bytes-r,omitempty"`
 	RxBytesR           FlexInt   `json:"rx_bytes-r,omitempty"`
-	RemoteUserEnabled  FlexBool  `json:"remote_user_enabled,omitempty"`
+	RemoteUserEnabled  FlexBool  `json:"remote_user_enabled,omitzero"`
 	RemoteUserNumActive int      `json:"remote_user_num_active,omitempty"`
 	RemoteUserNumInactive int    `json:"remote_user_num_inactive,omitempty"`
 	RemoteUserRxBytes  FlexInt   `json:"remote_user_rx_bytes,omitempty"`
 	RemoteUserTxBytes  FlexInt   `json:"remote_user_tx_bytes,omitempty"`
-	SiteToSiteEnabled  FlexBool  `json:"site_to_site_enabled,omitempty"`
+	SiteToSiteEnabled  FlexBool  `json:"site_to_site_enabled,omitzero"`
 	WanIP              string    `json:"wan_ip,omitempty"`
 	Uptime             FlexInt   `json:"uptime,omitempty"`
 	Drops              int       `json:"drops,omitempty"`
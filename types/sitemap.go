@@ -0,0 +1,61 @@
+package types
+
+// SiteMap is a floor plan or map that devices are placed on.
+type SiteMap struct {
+	ID       string `json:"_id,omitempty"`
+	SiteID   string `json:"site_id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"` // "imageMap", "designerMap", "googleMap"
+	Selected bool   `json:"selected,omitempty"`
+
+	// Upload is the file name of the map image, as returned by
+	// UploadMapImage.
+	Upload string `json:"upload,omitempty"`
+
+	// Unit is the unit of the scale, "m" or "ft", and UnitsPerPixel the
+	// scale of the image.
+	Unit          string  `json:"unit,omitempty"`
+	UnitsPerPixel float64 `json:"upp,omitempty"`
+	Opacity       float64 `json:"opacity,omitempty"`
+
+	// Google maps
+	Lat       string `json:"lat,omitempty"`
+	Lng       string `json:"lng,omitempty"`
+	Zoom      int    `json:"zoom,omitempty"`
+	Tilt      int    `json:"tilt,omitempty"`
+	MapTypeID string `json:"mapTypeId,omitempty"`
+}
+
+// Site map type constants.
+const (
+	SiteMapImage    = "imageMap"
+	SiteMapDesigner = "designerMap"
+	SiteMapGoogle   = "googleMap"
+)
+
+// APPlacement is the position of a device on a site map, in the map's
+// units from the top left of its image.
+type APPlacement struct {
+	MAC            string  `json:"mac"`
+	Name           string  `json:"name,omitempty"`
+	MapID          string  `json:"map_id"`
+	X              float64 `json:"x"`
+	Y              float64 `json:"y"`
+	HeightInMeters float64 `json:"heightInMeters,omitempty"`
+}
+
+// Placement returns the device's position, or nil if it is not placed on
+// a map.
+func (d *Device) Placement() *APPlacement {
+	if d.MapID == "" {
+		return nil
+	}
+	return &APPlacement{
+		MAC:            d.MAC,
+		Name:           d.Name,
+		MapID:          d.MapID,
+		X:              d.X,
+		Y:              d.Y,
+		HeightInMeters: d.HeightInMeters,
+	}
+}
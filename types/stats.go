@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// WANSample is one WAN throughput measurement derived from gateway
+// statistics.
+type WANSample struct {
+	Time       time.Time     `json:"time"`
+	Interface  string        `json:"interface"` // "wan1" or "wan2"
+	GatewayMAC string        `json:"gateway_mac"`
+	Up         bool          `json:"up"`
+	RXBps      float64       `json:"rx_bps"`
+	TXBps      float64       `json:"tx_bps"`
+	Latency    time.Duration `json:"latency"`
+	Uptime     time.Duration `json:"uptime"`
+
+	// Err is set, and the other fields are zero, when the gateway could
+	// not be polled.
+	Err error `json:"-"`
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Status represents system status (non-authenticated endpoint).
 type Status struct {
 	Up             bool   `json:"up"`
@@ -43,10 +45,15 @@ type Role struct {
 type Backup struct {
 	Filename  string `json:"filename"`
 	Size      int64  `json:"size"`
-	Time      int64  `json:"time"`
+	Time      FlexTime `json:"time"`
 	Datetime  string `json:"datetime,omitempty"`
 }
 
+// Timestamp returns Time as time.Time.
+func (b *Backup) Timestamp() time.Time {
+	return b.Time.Val
+}
+
 // SpeedTestStatus represents the status of a speed test.
 type SpeedTestStatus struct {
 	StatusDownload     int     `json:"status_download,omitempty"`
@@ -62,3 +69,30 @@ type SpeedTestStatus struct {
 	ServerCountry      string  `json:"server_country,omitempty"`
 	LastRun            int64   `json:"lastrun,omitempty"`
 }
+
+// LastRunTime returns LastRun as time.Time.
+func (s *SpeedTestStatus) LastRunTime() time.Time {
+	return EpochTime(s.LastRun)
+}
+
+// SpeedTestResult is the outcome of a completed speed test.
+type SpeedTestResult struct {
+	DownloadMbps  float64
+	UploadMbps    float64
+	Latency       time.Duration
+	ServerName    string
+	ServerCountry string
+	Time          time.Time
+}
+
+// Result converts the status of a finished test into a SpeedTestResult.
+func (s *SpeedTestStatus) Result() SpeedTestResult {
+	return SpeedTestResult{
+		DownloadMbps:  s.XputDownload.Float64(),
+		UploadMbps:    s.XputUpload.Float64(),
+		Latency:       time.Duration(s.Latency) * time.Millisecond,
+		ServerName:    s.ServerName,
+		ServerCountry: s.ServerCountry,
+		Time:          s.LastRunTime(),
+	}
+}
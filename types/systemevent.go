@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// EventSource identifies the stream an Event was received on.
+type EventSource string
+
+// Event sources.
+const (
+	// EventSourceNetwork is the Network Application's site event stream.
+	EventSourceNetwork EventSource = "network"
+
+	// EventSourceSystem is the UniFi OS event bus, which carries console
+	// updates and application state changes.
+	EventSourceSystem EventSource = "system"
+)
+
+// SystemMessage is a message on the UniFi OS event bus.
+type SystemMessage struct {
+	// Type is the kind of message, such as "APPLICATION_STATE_CHANGED".
+	Type string `json:"type"`
+
+	// App is the UniFi OS application the message concerns, if any.
+	App string `json:"app,omitempty"`
+
+	Message string   `json:"message,omitempty"`
+	Time    FlexTime `json:"time,omitzero"`
+
+	// Data holds the rest of the message, which depends on Type.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Event converts the message to an Event tagged with EventSourceSystem.
+// Key is the message type and Subsystem the application.
+func (m *SystemMessage) Event() Event {
+	return Event{
+		Key:       strings.ToUpper(m.Type),
+		Message:   m.Message,
+		Time:      m.Time,
+		Subsystem: m.App,
+		Source:    EventSourceSystem,
+	}
+}
@@ -0,0 +1,220 @@
+package types
+
+import "strings"
+
+// TopologyNodeKind classifies a node in the uplink graph.
+type TopologyNodeKind string
+
+// Topology node kinds.
+const (
+	TopologyGateway TopologyNodeKind = "gateway"
+	TopologySwitch  TopologyNodeKind = "switch"
+	TopologyAP      TopologyNodeKind = "ap"
+	TopologyDevice  TopologyNodeKind = "device"
+	TopologyClient  TopologyNodeKind = "client"
+)
+
+// TopologyKind classifies the device by its controller type.
+func (d *Device) TopologyKind() TopologyNodeKind {
+	switch d.Type {
+	case "udm", "ugw", "uxg":
+		return TopologyGateway
+	case "usw":
+		return TopologySwitch
+	case "uap":
+		return TopologyAP
+	default:
+		return TopologyDevice
+	}
+}
+
+// GraphNode is a device or client in a TopologyGraph. MAC addresses are
+// lowercase.
+type GraphNode struct {
+	Kind  TopologyNodeKind `json:"kind"`
+	MAC   string           `json:"mac"`
+	Name  string           `json:"name,omitempty"`
+	Model string           `json:"model,omitempty"`
+	IP    string           `json:"ip,omitempty"`
+}
+
+// GraphEdge is the uplink of node To through node From.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Port is the port on From that To is connected to, or 0 if unknown
+	// or wireless.
+	Port int `json:"port,omitempty"`
+
+	// Speed is the link speed in Mbps, if known.
+	Speed int `json:"speed,omitempty"`
+
+	// Wireless is true for Wi-Fi links, including mesh uplinks.
+	Wireless bool `json:"wireless,omitempty"`
+
+	// PoE is true if From powers To over Port, and PoEPower is the power
+	// drawn in watts.
+	PoE      bool    `json:"poe,omitempty"`
+	PoEPower float64 `json:"poe_power,omitempty"`
+}
+
+// TopologyGraph is a site's devices and clients and the uplinks between
+// them. Nodes are in input order, devices first. Each node has at most one
+// edge to it; nodes whose uplink cannot be resolved to another node have
+// none.
+type TopologyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// NewTopologyGraph assembles the uplink graph of devices and clients.
+//
+// A device's uplink is taken from uplink_mac, falling back to
+// uplink_table, then to the LLDP neighbor seen on its uplink port.
+// Wireless clients are attached to their access point (ap_mac), wired
+// clients to their switch (sw_mac) or gateway (gw_mac). PoE is read from
+// the upstream device's port table.
+func NewTopologyGraph(devices []Device, clients []Client) *TopologyGraph {
+	g := &TopologyGraph{}
+	byMAC := make(map[string]*Device, len(devices))
+	seen := make(map[string]bool, len(devices)+len(clients))
+	var edges []GraphEdge
+
+	for i := range devices {
+		d := &devices[i]
+		mac := strings.ToLower(d.MAC)
+		if mac == "" || seen[mac] {
+			continue
+		}
+		seen[mac] = true
+		byMAC[mac] = d
+		g.Nodes = append(g.Nodes, GraphNode{
+			Kind:  d.TopologyKind(),
+			MAC:   mac,
+			Name:  d.Name,
+			Model: d.ModelName(),
+			IP:    d.IP,
+		})
+
+		edge := GraphEdge{To: mac}
+		var uplink *DeviceUplink
+		edge.From, uplink = deviceUplink(d)
+		if uplink != nil {
+			edge.Port = uplink.UplinkRemotePort
+			edge.Speed = uplink.Speed
+			edge.Wireless = uplink.Type == "wireless"
+		}
+		edges = append(edges, edge)
+	}
+
+	for i := range clients {
+		cl := &clients[i]
+		mac := strings.ToLower(cl.MAC)
+		if mac == "" || seen[mac] {
+			continue
+		}
+		seen[mac] = true
+		name := cl.Name
+		if name == "" {
+			name = cl.Hostname
+		}
+		g.Nodes = append(g.Nodes, GraphNode{Kind: TopologyClient, MAC: mac, Name: name, IP: cl.IP})
+
+		edge := GraphEdge{To: mac}
+		switch {
+		case !cl.IsWired.Val && cl.APMA != "":
+			edge.From = strings.ToLower(cl.APMA)
+			edge.Wireless = true
+		case cl.SWMAC != "":
+			edge.From = strings.ToLower(cl.SWMAC)
+			edge.Port = cl.SWPORT
+		default:
+			edge.From = strings.ToLower(cl.GWMAC)
+		}
+		edges = append(edges, edge)
+	}
+
+	for _, edge := range edges {
+		parent := byMAC[edge.From]
+		if parent == nil || edge.From == edge.To {
+			continue
+		}
+		if port := parent.FindPort(edge.Port); port != nil && edge.Port != 0 && !edge.Wireless {
+			if edge.Speed == 0 {
+				edge.Speed = port.Speed
+			}
+			edge.PoE = port.PoeEnable && (port.PoeGood || port.PoePower.Float64() > 0)
+			if edge.PoE {
+				edge.PoEPower = port.PoePower.Float64()
+			}
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+	return g
+}
+
+// deviceUplink returns the MAC of the device's parent and the uplink
+// record it came from (nil when found via LLDP).
+func deviceUplink(d *Device) (string, *DeviceUplink) {
+	if d.Uplink != nil && d.Uplink.UplinkMAC != "" {
+		return strings.ToLower(d.Uplink.UplinkMAC), d.Uplink
+	}
+	for i := range d.UplinkTable {
+		if d.UplinkTable[i].UplinkMAC != "" {
+			return strings.ToLower(d.UplinkTable[i].UplinkMAC), &d.UplinkTable[i]
+		}
+	}
+
+	// Fall back to the LLDP neighbor on the uplink port.
+	port := 0
+	if d.Uplink != nil {
+		port = d.Uplink.PortIdx
+	}
+	if p := d.UplinkPort(); port == 0 && p != nil {
+		port = p.PortIdx
+	}
+	if port != 0 {
+		for _, n := range d.LLDPTable {
+			if n.LocalPortIdx == port && n.ChassisID != "" {
+				return strings.ToLower(n.ChassisID), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// Node returns the node with the given MAC address, or nil.
+func (g *TopologyGraph) Node(mac string) *GraphNode {
+	mac = strings.ToLower(mac)
+	for i := range g.Nodes {
+		if g.Nodes[i].MAC == mac {
+			return &g.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Uplink returns the edge to the node with the given MAC address, or nil
+// if its uplink is unknown.
+func (g *TopologyGraph) Uplink(mac string) *GraphEdge {
+	mac = strings.ToLower(mac)
+	for i := range g.Edges {
+		if g.Edges[i].To == mac {
+			return &g.Edges[i]
+		}
+	}
+	return nil
+}
+
+// Downlinks returns the edges from the node with the given MAC address.
+func (g *TopologyGraph) Downlinks(mac string) []GraphEdge {
+	mac = strings.ToLower(mac)
+	var edges []GraphEdge
+	for _, e := range g.Edges {
+		if e.From == mac {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
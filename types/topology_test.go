@@ -0,0 +1,63 @@
+package types
+
+import "testing"
+
+func TestNewTopologyGraph(t *testing.T) {
+	devices := []Device{
+		{MAC: "AA:00:00:00:00:01", Type: "ugw"},
+		{MAC: "aa:00:00:00:00:02", Type: "usw",
+			UplinkTable: []DeviceUplink{{UplinkMAC: "aa:00:00:00:00:01", UplinkRemotePort: 2}},
+			PortTable: []PortTable{
+				{PortIdx: 1, PoeEnable: true, PoePower: FlexInt{Val: 4}},
+				{PortIdx: 5, Speed: 100, PoeEnable: true},
+			}},
+		// Known only through LLDP on its uplink port.
+		{MAC: "aa:00:00:00:00:03", Type: "uap",
+			PortTable: []PortTable{{PortIdx: 1, IsUplink: FlexBool{Val: true}}},
+			LLDPTable: []LLDPEntry{{ChassisID: "aa:00:00:00:00:02", LocalPortIdx: 1}}},
+		{MAC: "aa:00:00:00:00:04", Type: "uap", Uplink: &DeviceUplink{UplinkMAC: "aa:00:00:00:00:99"}},
+	}
+	clients := []Client{
+		{MAC: "cc:00:00:00:00:01", IsWired: FlexBool{Val: true}, SWMAC: "aa:00:00:00:00:02", SWPORT: 5},
+		{MAC: "cc:00:00:00:00:02", APMA: "aa:00:00:00:00:04"},
+		{MAC: "aa:00:00:00:00:02"}, // duplicate of a device
+	}
+	g := NewTopologyGraph(devices, clients)
+
+	if len(g.Nodes) != 6 {
+		t.Fatalf("len(Nodes) = %d, want 6", len(g.Nodes))
+	}
+	if n := g.Node("aa:00:00:00:00:01"); n == nil || n.Kind != TopologyGateway {
+		t.Errorf("Node(gateway) = %+v, want lowercase gateway node", n)
+	}
+
+	tests := []struct {
+		mac, from string
+		port      int
+		speed     int
+		poe       bool
+	}{
+		{"aa:00:00:00:00:02", "aa:00:00:00:00:01", 2, 0, false},
+		{"aa:00:00:00:00:03", "aa:00:00:00:00:02", 0, 0, false},
+		{"cc:00:00:00:00:01", "aa:00:00:00:00:02", 5, 100, false},
+		{"cc:00:00:00:00:02", "aa:00:00:00:00:04", 0, 0, false},
+	}
+	for _, tt := range tests {
+		e := g.Uplink(tt.mac)
+		if e == nil {
+			t.Errorf("Uplink(%s) = nil", tt.mac)
+			continue
+		}
+		if e.From != tt.from || e.Port != tt.port || e.Speed != tt.speed || e.PoE != tt.poe {
+			t.Errorf("Uplink(%s) = %+v, want from %s port %d speed %d poe %v", tt.mac, e, tt.from, tt.port, tt.speed, tt.poe)
+		}
+	}
+
+	// The uplink points outside the site.
+	if e := g.Uplink("aa:00:00:00:00:04"); e != nil {
+		t.Errorf("Uplink(unknown parent) = %+v, want nil", e)
+	}
+	if got := len(g.Downlinks("aa:00:00:00:00:02")); got != 2 {
+		t.Errorf("len(Downlinks(switch)) = %d, want 2", got)
+	}
+}
@@ -7,7 +7,7 @@ type TrafficRule struct {
 	Name              string          `json:"name"`
 	Enabled           bool            `json:"enabled"`
 	Action            string          `json:"action"` // "ACCEPT", "DROP", "LIMIT"
-	MatchingTarget    string          `json:"matching_target"` // "CLIENT", "NETWORK", "ALL"
+	MatchingTarget    string          `json:"matching_target"` // "INTERNET", "APP", "DOMAIN", ...; see SetTarget
 	TargetDevices     []TargetDevice  `json:"target_devices,omitempty"`
 	IPRange           *IPRange        `json:"ip_range,omitempty"`
 	Regions           []string        `json:"regions,omitempty"`
@@ -17,6 +17,7 @@ type TrafficRule struct {
 	Bandwidth         *Bandwidth      `json:"bandwidth,omitempty"`
 	NetworkIDs        []string        `json:"network_ids,omitempty"`
 	AppCategoryIDs    []string        `json:"app_category_ids,omitempty"`
+	AppIDs            []int           `json:"app_ids,omitempty"`
 }
 
 // TargetDevice represents a target device for a traffic rule.
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Matching target constants for what a traffic rule matches.
+const (
+	MatchingTargetInternet    = "INTERNET"
+	MatchingTargetApp         = "APP"
+	MatchingTargetAppCategory = "APP_CATEGORY"
+	MatchingTargetDomain      = "DOMAIN"
+	MatchingTargetRegion      = "REGION"
+)
+
+// TrafficTarget is a typed description of what a traffic rule matches.
+// Build one with Apps, AppCategories, Domains, Regions or Internet and apply
+// it with TrafficRule.SetTarget.
+type TrafficTarget struct {
+	// MatchingTarget is the rule's matching_target value.
+	MatchingTarget string
+
+	// AppIDs are DPI application IDs, for MatchingTargetApp.
+	AppIDs []int
+
+	// CategoryIDs are DPI category IDs, for MatchingTargetAppCategory.
+	CategoryIDs []int
+
+	// Domains are domain names, for MatchingTargetDomain.
+	Domains []string
+
+	// Regions are ISO 3166-1 alpha-2 country codes, for MatchingTargetRegion.
+	Regions []string
+
+	err error
+}
+
+// Err returns the first problem found while building the target, such as an
+// application name missing from the catalog.
+func (t TrafficTarget) Err() error {
+	return t.err
+}
+
+// Internet matches all internet traffic.
+func Internet() TrafficTarget {
+	return TrafficTarget{MatchingTarget: MatchingTargetInternet}
+}
+
+// Apps matches applications by catalog name, e.g. Apps("Netflix", "YouTube").
+func Apps(names ...string) TrafficTarget {
+	t := TrafficTarget{MatchingTarget: MatchingTargetApp}
+	for _, name := range names {
+		app, ok := LookupDPIApp(name)
+		if !ok {
+			t.err = fmt.Errorf("unknown DPI application %q", name)
+			return t
+		}
+		t.AppIDs = append(t.AppIDs, app.ID)
+	}
+	return t
+}
+
+// AppIDs matches applications by DPI application ID.
+func AppIDs(ids ...int) TrafficTarget {
+	return TrafficTarget{MatchingTarget: MatchingTargetApp, AppIDs: ids}
+}
+
+// AppCategories matches DPI categories by catalog name.
+func AppCategories(names ...string) TrafficTarget {
+	t := TrafficTarget{MatchingTarget: MatchingTargetAppCategory}
+	for _, name := range names {
+		cat, ok := LookupDPICategory(name)
+		if !ok {
+			t.err = fmt.Errorf("unknown DPI category %q", name)
+			return t
+		}
+		t.CategoryIDs = append(t.CategoryIDs, cat.ID)
+	}
+	return t
+}
+
+// Domains matches traffic to the given domain names.
+func Domains(domains ...string) TrafficTarget {
+	t := TrafficTarget{MatchingTarget: MatchingTargetDomain}
+	for _, d := range domains {
+		d = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+		if d == "" || strings.ContainsAny(d, " /:") {
+			t.err = fmt.Errorf("invalid domain %q", d)
+			return t
+		}
+		t.Domains = append(t.Domains, d)
+	}
+	return t
+}
+
+// Regions matches traffic to the given countries (ISO 3166-1 alpha-2 codes).
+func Regions(codes ...string) TrafficTarget {
+	t := TrafficTarget{MatchingTarget: MatchingTargetRegion}
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+			t.err = fmt.Errorf("invalid region code %q", code)
+			return t
+		}
+		t.Regions = append(t.Regions, code)
+	}
+	return t
+}
+
+// SetTarget sets what the rule matches, clearing any previous target.
+func (r *TrafficRule) SetTarget(t TrafficTarget) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	r.MatchingTarget = t.MatchingTarget
+	r.AppIDs = nil
+	r.AppCategoryIDs = nil
+	r.Domains = nil
+	r.Regions = nil
+
+	switch t.MatchingTarget {
+	case MatchingTargetApp:
+		r.AppIDs = append([]int(nil), t.AppIDs...)
+	case MatchingTargetAppCategory:
+		for _, id := range t.CategoryIDs {
+			r.AppCategoryIDs = append(r.AppCategoryIDs, strconv.Itoa(id))
+		}
+	case MatchingTargetDomain:
+		r.Domains = append([]string(nil), t.Domains...)
+	case MatchingTargetRegion:
+		r.Regions = append([]string(nil), t.Regions...)
+	}
+	return nil
+}
+
+// Target returns what the rule matches in typed form. Category IDs that are
+// not numeric are skipped.
+func (r *TrafficRule) Target() TrafficTarget {
+	t := TrafficTarget{
+		MatchingTarget: r.MatchingTarget,
+		AppIDs:         append([]int(nil), r.AppIDs...),
+		Domains:        append([]string(nil), r.Domains...),
+		Regions:        append([]string(nil), r.Regions...),
+	}
+	for _, s := range r.AppCategoryIDs {
+		if id, err := strconv.Atoi(s); err == nil {
+			t.CategoryIDs = append(t.CategoryIDs, id)
+		}
+	}
+	return t
+}
+
+// AppNames returns the catalog names of the rule's application IDs, or the
+// numeric ID for applications missing from the catalog.
+func (r *TrafficRule) AppNames() []string {
+	names := make([]string, 0, len(r.AppIDs))
+	for _, id := range r.AppIDs {
+		name := strconv.Itoa(id)
+		for _, app := range dpiApps {
+			if app.ID == id {
+				name = app.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupDPIApp(t *testing.T) {
+	app, ok := LookupDPIApp("netflix")
+	if !ok {
+		t.Fatal("LookupDPIApp(netflix) not found")
+	}
+	if app.Name != "Netflix" || app.ID != DPIAppID(app.CategoryID, app.ID&0xffff) {
+		t.Errorf("LookupDPIApp(netflix) = %+v", app)
+	}
+
+	cat, ok := LookupDPICategory("Media Streaming Services")
+	if !ok || cat.ID != app.CategoryID {
+		t.Errorf("LookupDPICategory() = %+v, %v; want category %d", cat, ok, app.CategoryID)
+	}
+
+	if _, ok := LookupDPIApp("Not An App"); ok {
+		t.Error("LookupDPIApp should not find an unknown app")
+	}
+}
+
+func TestDPIApps_KnownCategories(t *testing.T) {
+	cats := make(map[int]bool)
+	for _, c := range DPICategories() {
+		cats[c.ID] = true
+	}
+	for _, app := range DPIApps() {
+		if !cats[app.CategoryID] {
+			t.Errorf("%s has unknown category %d", app.Name, app.CategoryID)
+		}
+		if app.ID>>16 != app.CategoryID {
+			t.Errorf("%s: ID %d does not encode category %d", app.Name, app.ID, app.CategoryID)
+		}
+	}
+}
+
+func TestTrafficRule_SetTarget(t *testing.T) {
+	netflix, _ := LookupDPIApp("Netflix")
+	youtube, _ := LookupDPIApp("YouTube")
+
+	rule := &TrafficRule{Name: "No streaming", Domains: []string{"old.example"}}
+	if err := rule.SetTarget(Apps("Netflix", "YouTube")); err != nil {
+		t.Fatalf("SetTarget() error = %v", err)
+	}
+
+	if rule.MatchingTarget != MatchingTargetApp {
+		t.Errorf("MatchingTarget = %s, want APP", rule.MatchingTarget)
+	}
+	if want := []int{netflix.ID, youtube.ID}; !reflect.DeepEqual(rule.AppIDs, want) {
+		t.Errorf("AppIDs = %v, want %v", rule.AppIDs, want)
+	}
+	if rule.Domains != nil {
+		t.Errorf("Domains = %v, want previous target cleared", rule.Domains)
+	}
+	if got := rule.AppNames(); !reflect.DeepEqual(got, []string{"Netflix", "YouTube"}) {
+		t.Errorf("AppNames() = %v", got)
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"matching_target":"APP"`) || !strings.Contains(string(data), `"app_ids":[`) {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestTrafficRule_SetTargetKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		target TrafficTarget
+		check  func(r *TrafficRule) bool
+	}{
+		{"internet", Internet(), func(r *TrafficRule) bool { return r.MatchingTarget == MatchingTargetInternet }},
+		{"domains", Domains("Example.COM."), func(r *TrafficRule) bool {
+			return reflect.DeepEqual(r.Domains, []string{"example.com"})
+		}},
+		{"regions", Regions("us", "DE"), func(r *TrafficRule) bool {
+			return reflect.DeepEqual(r.Regions, []string{"US", "DE"})
+		}},
+		{"categories", AppCategories("Games"), func(r *TrafficRule) bool {
+			return r.MatchingTarget == MatchingTargetAppCategory && len(r.AppCategoryIDs) == 1
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &TrafficRule{}
+			if err := rule.SetTarget(tt.target); err != nil {
+				t.Fatalf("SetTarget() error = %v", err)
+			}
+			if !tt.check(rule) {
+				t.Errorf("unexpected rule %+v", rule)
+			}
+			if got := rule.Target(); !reflect.DeepEqual(got.MatchingTarget, tt.target.MatchingTarget) {
+				t.Errorf("Target().MatchingTarget = %s", got.MatchingTarget)
+			}
+		})
+	}
+}
+
+func TestTrafficRule_SetTargetErrors(t *testing.T) {
+	for _, target := range []TrafficTarget{
+		Apps("Netflix", "Nonexistent"),
+		AppCategories("Nope"),
+		Domains("bad domain"),
+		Regions("USA"),
+	} {
+		rule := &TrafficRule{}
+		if err := rule.SetTarget(target); err == nil {
+			t.Errorf("SetTarget(%+v) should fail", target)
+		}
+		if rule.MatchingTarget != "" {
+			t.Errorf("failed SetTarget should leave the rule unchanged, got %s", rule.MatchingTarget)
+		}
+	}
+}
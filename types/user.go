@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"time"
+)
+
 // User represents a known client (saved in the user database).
 type User struct {
 	ID              string  `json:"_id,omitempty"`
@@ -10,8 +15,8 @@ type User struct {
 	Note            string  `json:"note,omitempty"`
 	Noted           bool    `json:"noted,omitempty"`
 	OUI             string  `json:"oui,omitempty"`
-	FirstSeen       int64   `json:"first_seen,omitempty"`
-	LastSeen        int64   `json:"last_seen,omitempty"`
+	FirstSeen       FlexTime `json:"first_seen,omitzero"`
+	LastSeen        FlexTime `json:"last_seen,omitzero"`
 
 	// Fixed IP
 	UseFixedIP      bool    `json:"use_fixedip,omitempty"`
@@ -34,13 +39,60 @@ type User struct {
 	TXBytes         FlexInt `json:"tx_bytes,omitempty"`
 }
 
+// FirstSeenTime returns FirstSeen as time.Time.
+func (u *User) FirstSeenTime() time.Time {
+	return u.FirstSeen.Val
+}
+
+// LastSeenTime returns LastSeen as time.Time.
+func (u *User) LastSeenTime() time.Time {
+	return u.LastSeen.Val
+}
+
 // UserGroup represents a user group for grouping clients.
 type UserGroup struct {
 	ID              string  `json:"_id,omitempty"`
 	SiteID          string  `json:"site_id,omitempty"`
 	Name            string  `json:"name"`
-	QOSRateMaxDown  int     `json:"qos_rate_max_down,omitempty"` // kbps
-	QOSRateMaxUp    int     `json:"qos_rate_max_up,omitempty"`   // kbps
+	QOSRateMaxDown  RateLimit `json:"qos_rate_max_down,omitempty"`
+	QOSRateMaxUp    RateLimit `json:"qos_rate_max_up,omitempty"`
 	AttrNoDelete    bool    `json:"attr_no_delete,omitempty"`
 	AttrHiddenID    string  `json:"attr_hidden_id,omitempty"`
 }
+
+// RateLimit is a user group bandwidth limit in kbps. The controller uses
+// RateUnlimited for no limit; zero leaves the limit unset.
+type RateLimit int
+
+// RateUnlimited removes a user group's limit.
+const RateUnlimited RateLimit = -1
+
+// RateMbps returns a limit of n Mbps.
+func RateMbps(n int) RateLimit {
+	return RateLimit(n * 1000)
+}
+
+// Unlimited reports whether the limit is RateUnlimited or unset.
+func (r RateLimit) Unlimited() bool {
+	return r <= 0
+}
+
+// Kbps returns the limit in kbps, or 0 if unlimited.
+func (r RateLimit) Kbps() int {
+	if r.Unlimited() {
+		return 0
+	}
+	return int(r)
+}
+
+// String formats the limit as "unlimited", "N Mbps" or "N kbps".
+func (r RateLimit) String() string {
+	switch {
+	case r.Unlimited():
+		return "unlimited"
+	case r%1000 == 0:
+		return fmt.Sprintf("%d Mbps", r/1000)
+	default:
+		return fmt.Sprintf("%d kbps", int(r))
+	}
+}
@@ -55,3 +55,23 @@ func TestUserGroup_UnmarshalJSON(t *testing.T) {
 		t.Errorf("QOSRateMaxDown = %v, want 10000", group.QOSRateMaxDown)
 	}
 }
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		limit     RateLimit
+		unlimited bool
+		kbps      int
+		str       string
+	}{
+		{RateUnlimited, true, 0, "unlimited"},
+		{0, true, 0, "unlimited"},
+		{RateMbps(50), false, 50000, "50 Mbps"},
+		{1536, false, 1536, "1536 kbps"},
+	}
+	for _, tt := range tests {
+		if tt.limit.Unlimited() != tt.unlimited || tt.limit.Kbps() != tt.kbps || tt.limit.String() != tt.str {
+			t.Errorf("RateLimit(%d) = %v %d %q, want %v %d %q", int(tt.limit),
+				tt.limit.Unlimited(), tt.limit.Kbps(), tt.limit.String(), tt.unlimited, tt.kbps, tt.str)
+		}
+	}
+}
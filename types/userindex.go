@@ -0,0 +1,63 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// UserIndex indexes users by MAC address for repeated lookups on sites
+// with many known clients. MACs match in any notation and case.
+type UserIndex struct {
+	// users are sorted by normalized MAC, and keys are those MACs.
+	users []User
+	keys  []string
+}
+
+// NewUserIndex indexes a copy of users.
+func NewUserIndex(users []User) *UserIndex {
+	x := &UserIndex{users: make([]User, len(users)), keys: make([]string, len(users))}
+	copy(x.users, users)
+	sort.SliceStable(x.users, func(i, j int) bool {
+		return indexMAC(x.users[i].MAC) < indexMAC(x.users[j].MAC)
+	})
+	for i := range x.users {
+		x.keys[i] = indexMAC(x.users[i].MAC)
+	}
+	return x
+}
+
+// Len returns the number of users indexed.
+func (x *UserIndex) Len() int {
+	return len(x.users)
+}
+
+// ByMAC returns the user with the MAC address, or nil. If the controller
+// has duplicate entries for the MAC, the first is returned.
+func (x *UserIndex) ByMAC(mac string) *User {
+	key := indexMAC(mac)
+	i := sort.SearchStrings(x.keys, key)
+	if i < len(x.keys) && x.keys[i] == key {
+		return &x.users[i]
+	}
+	return nil
+}
+
+// WithMACPrefix returns the users whose MAC address starts with prefix,
+// such as an OUI, sorted by MAC.
+func (x *UserIndex) WithMACPrefix(prefix string) []User {
+	key := indexMAC(prefix)
+	start := sort.SearchStrings(x.keys, key)
+	end := start
+	for end < len(x.keys) && strings.HasPrefix(x.keys[end], key) {
+		end++
+	}
+	return x.users[start:end:end]
+}
+
+// macSeparators are removed from MAC addresses by indexMAC.
+var macSeparators = strings.NewReplacer(":", "", "-", "", ".", "")
+
+// indexMAC normalizes a MAC address or prefix to lowercase hex digits.
+func indexMAC(mac string) string {
+	return macSeparators.Replace(strings.ToLower(mac))
+}
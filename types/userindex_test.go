@@ -0,0 +1,32 @@
+package types
+
+import "testing"
+
+func TestUserIndex(t *testing.T) {
+	x := NewUserIndex([]User{
+		{ID: "3", MAC: "dd:ee:ff:00:00:03"},
+		{ID: "1", MAC: "AA:BB:CC:00:00:01"},
+		{ID: "2", MAC: "aa-bb-cc-00-00-02"},
+	})
+
+	if x.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", x.Len())
+	}
+	if u := x.ByMAC("aabbcc000002"); u == nil || u.ID != "2" {
+		t.Errorf("ByMAC() = %+v, want user 2", u)
+	}
+	if u := x.ByMAC("aa:bb:cc:00:00:09"); u != nil {
+		t.Errorf("ByMAC(unknown) = %+v, want nil", u)
+	}
+
+	users := x.WithMACPrefix("aa:bb:cc")
+	if len(users) != 2 || users[0].ID != "1" || users[1].ID != "2" {
+		t.Errorf("WithMACPrefix(aa:bb:cc) = %+v, want users 1 and 2", users)
+	}
+	if users := x.WithMACPrefix("11"); len(users) != 0 {
+		t.Errorf("WithMACPrefix(11) = %+v, want none", users)
+	}
+	if users := x.WithMACPrefix(""); len(users) != 3 {
+		t.Errorf("WithMACPrefix(\"\") returned %d users, want 3", len(users))
+	}
+}
@@ -0,0 +1,704 @@
+package types
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Validator is implemented by types that can check themselves against the
+// constraints the controller enforces, before a request is sent.
+// Violations are reported as ValidationErrors keyed by JSON field name.
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks required fields and controller-enforced constraints.
+// A passphrase is only required for new WPA-PSK/WPA3 WLANs (empty ID), as
+// updates may leave the stored one unchanged. Enum fields are not checked
+// against a fixed list, as controllers return values beyond the constants
+// defined here (e.g. wep or osen security, auto WPA mode, gcmp ciphers).
+func (w *WLAN) Validate() error {
+	var verrs ValidationErrors
+
+	switch {
+	case strings.TrimSpace(w.Name) == "":
+		verrs.Add("name", "required")
+	case len(w.Name) > 32:
+		verrs.Addf("name", "SSID must be at most 32 bytes, got %d", len(w.Name))
+	}
+
+	if w.Security == SecurityTypeWPAPSK || w.Security == SecurityTypeWPA3 {
+		switch {
+		case w.Passphrase == "":
+			if w.ID == "" {
+				verrs.Addf("x_passphrase", "required for %s security", w.Security)
+			}
+		case len(w.Passphrase) == 64:
+			if !isHex(w.Passphrase) {
+				verrs.Add("x_passphrase", "a 64 character passphrase must be a hex PSK")
+			}
+		case len(w.Passphrase) < 8 || len(w.Passphrase) > 63:
+			verrs.Addf("x_passphrase", "must be 8 to 63 characters, got %d", len(w.Passphrase))
+		}
+	}
+
+	wpa3Only := (w.Security == SecurityTypeWPA3 || w.WPAMode == WPAModeWPA3) && !w.WPA3Transition
+	if wpa3Only && w.PMFMode == PMFModeDisabled {
+		verrs.Add("pmf_mode", "WPA3 requires protected management frames")
+	}
+
+	for _, mac := range w.MACFilterList {
+		if _, err := net.ParseMAC(mac); err != nil {
+			verrs.Addf("mac_filter_list", "invalid MAC address %q", mac)
+		}
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks required fields and controller-enforced constraints.
+// Purpose, network group and WAN type are not checked against a fixed
+// list, as controllers use more (e.g. site-vpn, vpn-client, WAN2).
+func (n *Network) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(n.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	if n.VLANEnabled || n.VLAN != 0 {
+		checkVLAN(&verrs, "vlan", n.VLAN)
+	}
+
+	var subnet *net.IPNet
+	if n.IPSubnet != "" {
+		ip, ipnet, err := net.ParseCIDR(n.IPSubnet)
+		switch {
+		case err != nil:
+			verrs.Addf("ip_subnet", "must be a gateway address in CIDR form, got %q", n.IPSubnet)
+		case !isHostAddress(ip, ipnet):
+			verrs.Addf("ip_subnet", "%s is not a usable gateway address", ip)
+		default:
+			subnet = ipnet
+		}
+	}
+
+	if n.DHCPDEnabled {
+		start := checkIP(&verrs, "dhcpd_start", n.DHCPDStart)
+		stop := checkIP(&verrs, "dhcpd_stop", n.DHCPDStop)
+		if subnet != nil {
+			if start != nil && !subnet.Contains(start) {
+				verrs.Addf("dhcpd_start", "%s is outside %s", start, subnet)
+			}
+			if stop != nil && !subnet.Contains(stop) {
+				verrs.Addf("dhcpd_stop", "%s is outside %s", stop, subnet)
+			}
+		}
+		if start != nil && stop != nil && compareIP(start, stop) > 0 {
+			verrs.Add("dhcpd_stop", "must not be before dhcpd_start")
+		}
+	}
+
+	for _, dns := range []struct{ field, value string }{
+		{"dhcpd_dns_1", n.DHCPDDNS1},
+		{"dhcpd_dns_2", n.DHCPDDNS2},
+		{"dhcpd_dns_3", n.DHCPDDNS3},
+		{"dhcpd_dns_4", n.DHCPDDNS4},
+	} {
+		checkIP(&verrs, dns.field, dns.value)
+	}
+
+	switch n.WANType {
+	case WANTypeStatic:
+		requireIP(&verrs, "wan_ip", n.WANIPAddress)
+		requireIP(&verrs, "wan_netmask", n.WANNetmask)
+		requireIP(&verrs, "wan_gateway", n.WANGateway)
+	case WANTypePPPoE:
+		if n.WANUsername == "" {
+			verrs.Add("wan_username", "required for PPPoE")
+		}
+	}
+	if n.WANVLANEnabled {
+		checkVLAN(&verrs, "wan_vlan", n.WANVLAN)
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks required fields and controller-enforced constraints.
+// A zero RuleIndex lets the controller assign one. Rules in IPv6 rulesets
+// take their protocol from ProtocolV6 and must use IPv6 addresses. Ruleset,
+// action and protocol names are not checked against a fixed list, as
+// controllers accept more than the constants defined here.
+func (r *FirewallRule) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(r.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	if r.Ruleset == "" {
+		verrs.Add("ruleset", "required")
+	}
+	if r.Action == "" {
+		verrs.Add("action", "required")
+	}
+
+	v6 := r.IsIPv6()
+	protoField, proto := "protocol", r.Protocol
+	if v6 {
+		protoField, proto = "protocol_v6", r.ProtocolV6
+		if r.Protocol != "" && r.Protocol != ProtocolAll && r.ProtocolV6 == "" {
+			verrs.Add("protocol", "IPv6 rulesets use protocol_v6")
+		}
+		if r.ICMPTypename != "" {
+			verrs.Add("icmp_typename", "IPv6 rulesets use icmpv6_typename")
+		}
+		if proto == ProtocolICMP {
+			verrs.Add(protoField, "use ipv6-icmp in IPv6 rulesets")
+		}
+	} else {
+		if r.ProtocolV6 != "" {
+			verrs.Add("protocol_v6", "only valid in IPv6 rulesets")
+		}
+		if proto == ProtocolIPv6ICMP {
+			verrs.Add(protoField, "ipv6-icmp is only valid in IPv6 rulesets")
+		}
+	}
+
+	// User-defined rules live in 2000-2999 (before the predefined rules)
+	// or 4000-4999 (after them).
+	if r.RuleIndex != 0 && !(r.RuleIndex >= 2000 && r.RuleIndex <= 2999) && !(r.RuleIndex >= 4000 && r.RuleIndex <= 4999) {
+		verrs.Addf("rule_index", "must be in 2000-2999 or 4000-4999, got %d", r.RuleIndex)
+	}
+
+	if r.SrcPort != "" || r.DstPort != "" {
+		if proto != ProtocolTCP && proto != ProtocolUDP && proto != ProtocolTCPUDP {
+			verrs.Add(protoField, "ports require tcp, udp or tcp_udp")
+		}
+		checkPortSpec(&verrs, "src_port", r.SrcPort)
+		checkPortSpec(&verrs, "dst_port", r.DstPort)
+	}
+
+	checkFamilyAddress(&verrs, "src_address", r.SrcAddress, v6)
+	checkFamilyAddress(&verrs, "dst_address", r.DstAddress, v6)
+	if r.SrcMACAddress != "" {
+		if _, err := net.ParseMAC(r.SrcMACAddress); err != nil {
+			verrs.Addf("src_mac_address", "invalid MAC address %q", r.SrcMACAddress)
+		}
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks the group type and that every member suits it: IPv4
+// addresses, blocks or ranges for address groups, IPv6 ones for IPv6
+// address groups and ports or port ranges for port groups.
+func (g *FirewallGroup) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(g.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	if g.GroupType == "" {
+		verrs.Add("group_type", "required")
+	} else {
+		checkOneOf(&verrs, "group_type", g.GroupType, GroupTypeAddress, GroupTypePort, GroupTypeIPv6Address)
+	}
+
+	for _, m := range g.GroupMembers {
+		switch g.GroupType {
+		case GroupTypeAddress:
+			checkGroupAddress(&verrs, "group_members", m, false)
+		case GroupTypeIPv6Address:
+			checkGroupAddress(&verrs, "group_members", m, true)
+		case GroupTypePort:
+			checkPortSpec(&verrs, "group_members", m)
+		}
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks the record type and that the value suits it: an IPv4
+// address for A records, an IPv6 address for AAAA records and a port for
+// SRV records.
+func (r *DNSRecord) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(r.Key) == "" {
+		verrs.Add("key", "required")
+	}
+
+	if r.RecordType == "" {
+		verrs.Add("record_type", "required")
+	} else {
+		checkOneOf(&verrs, "record_type", r.RecordType, DNSRecordTypeA, DNSRecordTypeAAAA,
+			DNSRecordTypeCNAME, DNSRecordTypeMX, DNSRecordTypeTXT, DNSRecordTypeSRV)
+	}
+
+	if strings.TrimSpace(r.Value) == "" {
+		verrs.Add("value", "required")
+	} else {
+		switch r.RecordType {
+		case DNSRecordTypeA:
+			if ip := net.ParseIP(r.Value); ip == nil || ip.To4() == nil {
+				verrs.Addf("value", "A records need an IPv4 address, got %q", r.Value)
+			}
+		case DNSRecordTypeAAAA:
+			if ip := net.ParseIP(r.Value); ip == nil || ip.To4() != nil {
+				verrs.Addf("value", "AAAA records need an IPv6 address, got %q", r.Value)
+			}
+		}
+	}
+
+	if r.RecordType == DNSRecordTypeSRV && (r.Port < 1 || r.Port > 65535) {
+		verrs.Addf("port", "SRV records need a port in 1-65535, got %d", r.Port)
+	}
+
+	if r.TTL < 0 {
+		verrs.Addf("ttl", "must not be negative, got %d", r.TTL)
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks that the zone is named.
+func (z *FirewallZone) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(z.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks required fields, the enums and that specific ports are
+// only matched for TCP or UDP.
+func (p *FirewallPolicy) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(p.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	if p.Action == "" {
+		verrs.Add("action", "required")
+	} else {
+		checkOneOf(&verrs, "action", p.Action, PolicyActionAllow, PolicyActionBlock, PolicyActionReject)
+	}
+
+	checkOneOf(&verrs, "ip_version", p.IPVersion, PolicyIPVersionBoth, PolicyIPVersionIPv4, PolicyIPVersionIPv6)
+	checkOneOf(&verrs, "protocol", p.Protocol, ProtocolAll, ProtocolTCP, ProtocolUDP, ProtocolTCPUDP, ProtocolICMP, ProtocolIPv6ICMP)
+
+	ports := false
+	for _, end := range []struct {
+		field    string
+		endpoint *FirewallPolicyEndpoint
+	}{{"source", &p.Source}, {"destination", &p.Destination}} {
+		if end.endpoint.ZoneID == "" {
+			verrs.Add(end.field+".zone_id", "required")
+		}
+		checkOneOf(&verrs, end.field+".matching_target", end.endpoint.MatchingTarget,
+			PolicyTargetAny, PolicyTargetIP, PolicyTargetNetwork, PolicyTargetClient, PolicyTargetRegion)
+		checkOneOf(&verrs, end.field+".port_matching_type", end.endpoint.PortMatchingType, "ANY", "SPECIFIC")
+
+		if end.endpoint.PortMatchingType == "SPECIFIC" {
+			if end.endpoint.Port == "" {
+				verrs.Add(end.field+".port", "required for SPECIFIC port matching")
+			}
+			checkPortSpec(&verrs, end.field+".port", end.endpoint.Port)
+			ports = true
+		}
+	}
+	if ports && p.Protocol != ProtocolTCP && p.Protocol != ProtocolUDP && p.Protocol != ProtocolTCPUDP {
+		verrs.Add("protocol", "ports require tcp, udp or tcp_udp")
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks required fields and controller-enforced constraints.
+func (p *PortForward) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(p.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	checkOneOf(&verrs, "proto", p.Protocol, ProtocolTCP, ProtocolUDP, ProtocolTCPUDP)
+
+	if p.DstPort == "" {
+		verrs.Add("dst_port", "required")
+	} else {
+		checkPortSpec(&verrs, "dst_port", p.DstPort)
+	}
+	checkPortSpec(&verrs, "fwd_port", p.FwdPort)
+	if p.FwdPort != "" {
+		dst, fwd := portCount(p.DstPort), portCount(p.FwdPort)
+		if dst > 0 && fwd > 0 && dst != fwd {
+			verrs.Addf("fwd_port", "must cover as many ports as dst_port (%d), got %d", dst, fwd)
+		}
+	}
+
+	if ip := requireIP(&verrs, "fwd", p.FwdIP); ip != nil && ip.To4() == nil {
+		verrs.Add("fwd", "must be an IPv4 address")
+	}
+
+	checkOneOf(&verrs, "pfwd_interface", p.WANInterface, PortForwardWAN, PortForwardWAN2, PortForwardBothWANs)
+	if p.DestinationIP != "any" {
+		checkIP(&verrs, "destination_ip", p.DestinationIP)
+	}
+
+	if p.SrcLimitingEnabled {
+		switch p.SrcLimitingType {
+		case SrcLimitingIP:
+			if p.SrcNetworkID == "" || p.SrcNetworkID == "any" {
+				verrs.Add("src", "required when limiting by IP")
+			} else {
+				checkFamilyAddress(&verrs, "src", p.SrcNetworkID, false)
+			}
+		case SrcLimitingFirewallGroup:
+			if p.SrcFirewallGroupID == "" {
+				verrs.Add("src_firewall_group_id", "required when limiting by firewall group")
+			}
+		default:
+			verrs.Addf("src_limiting_type", "must be one of %s, %s, got %q", SrcLimitingIP, SrcLimitingFirewallGroup, p.SrcLimitingType)
+		}
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks required fields and controller-enforced constraints.
+// Every listed server needs an address, a port and a secret. Accounting
+// needs a server unless the gateway's own is used.
+func (p *RADIUSProfile) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(p.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	checkRADIUSServers(&verrs, "auth_servers", p.AuthServers)
+	checkRADIUSServers(&verrs, "acct_servers", p.AcctServers)
+
+	if p.AccountingEnabled && !p.UseGatewayAcctServer && len(p.AcctServers) == 0 {
+		verrs.Add("acct_servers", "required when accounting is enabled")
+	}
+
+	if p.InterimUpdateEnabled && (p.InterimUpdateInterval < 60 || p.InterimUpdateInterval > 86400) {
+		verrs.Addf("interim_update_interval", "must be between 60 and 86400 seconds, got %d", p.InterimUpdateInterval)
+	}
+
+	checkOneOf(&verrs, "vlan_wlan_mode", p.VLANWLANMode, RADIUSVLANDisabled, RADIUSVLANOptional, RADIUSVLANRequired)
+
+	return verrs.Err()
+}
+
+// checkRADIUSServers records violations for servers missing an address,
+// port or secret. Fields are reported as e.g. "auth_servers[0].port".
+func checkRADIUSServers(verrs *ValidationErrors, field string, servers []RADIUSServer) {
+	for i, srv := range servers {
+		prefix := field + "[" + strconv.Itoa(i) + "]"
+		requireIP(verrs, prefix+".ip", srv.IP)
+		if srv.Port < 1 || srv.Port > 65535 {
+			verrs.Addf(prefix+".port", "must be between 1 and 65535, got %d", srv.Port)
+		}
+		if srv.Secret == "" {
+			verrs.Add(prefix+".x_secret", "required")
+		}
+	}
+}
+
+// Validate checks required fields and controller-enforced constraints.
+// The route type is not checked: controllers also return "static",
+// "static-route" and "interface-route".
+func (r *Route) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(r.Name) == "" {
+		verrs.Add("name", "required")
+	}
+
+	if r.StaticRouteNetwork == "" {
+		verrs.Add("static-route_network", "required")
+	} else if _, _, err := net.ParseCIDR(r.StaticRouteNetwork); err != nil {
+		verrs.Addf("static-route_network", "must be in CIDR form, got %q", r.StaticRouteNetwork)
+	}
+
+	if r.Type == RouteTypeNexthop || r.StaticRouteType == RouteTypeNexthop {
+		requireIP(&verrs, "static-route_nexthop", r.StaticRouteNexthop)
+	} else {
+		checkIP(&verrs, "static-route_nexthop", r.StaticRouteNexthop)
+	}
+
+	// Zero is left out of the JSON, so the controller applies its default.
+	if r.StaticRouteDistance < 0 || r.StaticRouteDistance > 255 {
+		verrs.Addf("static-route_distance", "must be between 1 and 255, or 0 for the default, got %d", r.StaticRouteDistance)
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks the honeypots: each needs a valid IP address and a
+// network, and a network can have only one.
+func (s *SettingIPS) Validate() error {
+	var verrs ValidationErrors
+
+	seen := make(map[string]bool, len(s.Honeypots))
+	for i, h := range s.Honeypots {
+		field := "honeypot[" + strconv.Itoa(i) + "]"
+		requireIP(&verrs, field+".ip_address", h.IPAddress)
+		switch {
+		case h.NetworkID == "":
+			verrs.Add(field+".network_id", "required")
+		case seen[h.NetworkID]:
+			verrs.Addf(field+".network_id", "network %s already has a honeypot", h.NetworkID)
+		}
+		seen[h.NetworkID] = true
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks that the group has a name and that its limits are
+// positive or RateUnlimited.
+func (g *UserGroup) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(g.Name) == "" {
+		verrs.Add("name", "required")
+	}
+	if g.QOSRateMaxDown < RateUnlimited {
+		verrs.Addf("qos_rate_max_down", "must be positive or -1 for unlimited, got %d", g.QOSRateMaxDown)
+	}
+	if g.QOSRateMaxUp < RateUnlimited {
+		verrs.Addf("qos_rate_max_up", "must be positive or -1 for unlimited, got %d", g.QOSRateMaxUp)
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks that the operator has a name and a password.
+func (o *HotspotOperator) Validate() error {
+	var verrs ValidationErrors
+
+	if strings.TrimSpace(o.Name) == "" {
+		verrs.Add("name", "required")
+	}
+	if o.Password == "" {
+		verrs.Add("x_password", "required")
+	}
+
+	return verrs.Err()
+}
+
+// Validate checks the ranges the controller enforces.
+func (s *SettingLCM) Validate() error {
+	var verrs ValidationErrors
+
+	if s.Brightness < 1 || s.Brightness > 100 {
+		verrs.Addf("brightness", "must be between 1 and 100, got %d", s.Brightness)
+	}
+	if s.IdleTimeout < 0 {
+		verrs.Addf("idle_timeout", "must not be negative, got %d", s.IdleTimeout)
+	}
+	for i, page := range s.Pages {
+		if strings.TrimSpace(page) == "" {
+			verrs.Add("pages["+strconv.Itoa(i)+"]", "required")
+		}
+	}
+
+	return verrs.Err()
+}
+
+// checkOneOf records a violation if value is set but not one of allowed.
+func checkOneOf(verrs *ValidationErrors, field, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	verrs.Addf(field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// checkVLAN records a violation if vlan is outside 1-4094.
+func checkVLAN(verrs *ValidationErrors, field string, vlan int) {
+	if vlan < 1 || vlan > 4094 {
+		verrs.Addf(field, "must be between 1 and 4094, got %d", vlan)
+	}
+}
+
+// checkIP parses an optional IP address, recording a violation if it is invalid.
+func checkIP(verrs *ValidationErrors, field, value string) net.IP {
+	if value == "" {
+		return nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		verrs.Addf(field, "invalid IP address %q", value)
+	}
+	return ip
+}
+
+// requireIP is like checkIP but also records a violation if value is empty.
+func requireIP(verrs *ValidationErrors, field, value string) net.IP {
+	if value == "" {
+		verrs.Add(field, "required")
+		return nil
+	}
+	return checkIP(verrs, field, value)
+}
+
+// checkFamilyAddress accepts an optional IP address or CIDR block of the
+// given family.
+func checkFamilyAddress(verrs *ValidationErrors, field, value string, v6 bool) {
+	if value == "" {
+		return
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		var err error
+		if ip, _, err = net.ParseCIDR(value); err != nil {
+			verrs.Addf(field, "must be an IP address or CIDR block, got %q", value)
+			return
+		}
+	}
+	if isV4 := ip.To4() != nil; isV4 == v6 {
+		family := "IPv4"
+		if v6 {
+			family = "IPv6"
+		}
+		verrs.Addf(field, "%s must be an %s address", value, family)
+	}
+}
+
+// checkGroupAddress accepts an address group member: an IP address or
+// CIDR block of the group's family, or a range of addresses of that family
+// ("10.0.0.1-10.0.0.10").
+func checkGroupAddress(verrs *ValidationErrors, field, value string, v6 bool) {
+	lo, hi, isRange := strings.Cut(value, "-")
+	if !isRange {
+		checkFamilyAddress(verrs, field, value, v6)
+		return
+	}
+
+	first, last := net.ParseIP(lo), net.ParseIP(hi)
+	if first == nil || last == nil {
+		verrs.Addf(field, "must be an IP address, CIDR block or range, got %q", value)
+		return
+	}
+	if (first.To4() != nil) == v6 || (last.To4() != nil) == v6 {
+		family := "IPv4"
+		if v6 {
+			family = "IPv6"
+		}
+		verrs.Addf(field, "%s must be a range of %s addresses", value, family)
+		return
+	}
+	if bytes.Compare(first.To16(), last.To16()) > 0 {
+		verrs.Addf(field, "range %s ends before it starts", value)
+	}
+}
+
+// checkPortSpec accepts the controller's port syntax: a port, a range
+// ("8000-8100") or a comma separated list of either.
+func checkPortSpec(verrs *ValidationErrors, field, value string) {
+	if value == "" {
+		return
+	}
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isPort(lo) || (isRange && !isPort(hi)) {
+			verrs.Addf(field, "invalid port specification %q", value)
+			return
+		}
+		if isRange {
+			a, _ := strconv.Atoi(lo)
+			b, _ := strconv.Atoi(hi)
+			if a > b {
+				verrs.Addf(field, "invalid port range %q", part)
+				return
+			}
+		}
+	}
+}
+
+// portCount returns the number of ports in a valid port specification,
+// or 0 if it is empty or invalid.
+func portCount(value string) int {
+	n := 0
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isRange {
+			hi = lo
+		}
+		a, errA := strconv.Atoi(lo)
+		b, errB := strconv.Atoi(hi)
+		if errA != nil || errB != nil || a > b {
+			return 0
+		}
+		n += b - a + 1
+	}
+	return n
+}
+
+// isPort reports whether s is a port number in 1-65535.
+func isPort(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 1 && n <= 65535
+}
+
+// isHostAddress reports whether ip is neither the network nor, for IPv4,
+// the broadcast address of ipnet. /31, /32 and IPv6 prefixes have no such
+// reserved addresses worth rejecting.
+func isHostAddress(ip net.IP, ipnet *net.IPNet) bool {
+	ones, bits := ipnet.Mask.Size()
+	if bits != 32 || ones >= 31 {
+		return true
+	}
+	ip = ip.To4()
+	if ip.Equal(ipnet.IP) {
+		return false
+	}
+	for i := range ip {
+		if ip[i]|ipnet.Mask[i] != 0xff {
+			return true
+		}
+	}
+	return false
+}
+
+// compareIP compares two addresses of the same family byte by byte.
+func compareIP(a, b net.IP) int {
+	if a4, b4 := a.To4(), b.To4(); a4 != nil && b4 != nil {
+		a, b = a4, b4
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// isHex reports whether s consists only of hexadecimal digits.
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,366 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fields returns the invalid field names reported by err.
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("error = %T, want ValidationErrors", err)
+	}
+	return verrs.Fields()
+}
+
+func TestWLAN_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		wlan WLAN
+		want []string
+	}{
+		{"valid", WLAN{Name: "Home", Security: SecurityTypeWPAPSK, Passphrase: "password123"}, nil},
+		{"open", WLAN{Name: "Guest", Security: SecurityTypeOpen}, nil},
+		{"existing keeps passphrase", WLAN{ID: "w1", Name: "Home", Security: SecurityTypeWPAPSK}, nil},
+		{"hex psk", WLAN{Name: "Home", Security: SecurityTypeWPAPSK, Passphrase: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}, nil},
+		{"missing name", WLAN{Security: SecurityTypeOpen}, []string{"name"}},
+		{"long ssid", WLAN{Name: "this SSID is far too long to be accepted", Security: SecurityTypeOpen}, []string{"name"}},
+		{"missing passphrase", WLAN{Name: "Home", Security: SecurityTypeWPAPSK}, []string{"x_passphrase"}},
+		{"short passphrase", WLAN{Name: "Home", Security: SecurityTypeWPAPSK, Passphrase: "short"}, []string{"x_passphrase"}},
+		{"controller enums", WLAN{Name: "Home", Security: "wep", WPAMode: "auto", WPAEnc: "gcmp"}, nil},
+		{"wpa3 without pmf", WLAN{Name: "Home", Security: SecurityTypeWPA3, Passphrase: "password123", PMFMode: PMFModeDisabled}, []string{"pmf_mode"}},
+		{"bad mac", WLAN{Name: "Home", Security: SecurityTypeOpen, MACFilterList: []string{"nope"}}, []string{"mac_filter_list"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.wlan.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_Validate(t *testing.T) {
+	valid := Network{
+		Name:         "IoT",
+		Purpose:      NetworkPurposeCorporate,
+		VLANEnabled:  true,
+		VLAN:         20,
+		IPSubnet:     "192.168.20.1/24",
+		DHCPDEnabled: true,
+		DHCPDStart:   "192.168.20.10",
+		DHCPDStop:    "192.168.20.250",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(n *Network)
+		want   []string
+	}{
+		{"valid", func(n *Network) {}, nil},
+		{"missing name", func(n *Network) { n.Name = "" }, []string{"name"}},
+		{"vlan out of range", func(n *Network) { n.VLAN = 4095 }, []string{"vlan"}},
+		{"vlan enabled without id", func(n *Network) { n.VLAN = 0 }, []string{"vlan"}},
+		{"bad subnet", func(n *Network) { n.IPSubnet = "192.168.20.1" }, []string{"ip_subnet"}},
+		{"network address as gateway", func(n *Network) { n.IPSubnet = "192.168.20.0/24" }, []string{"ip_subnet"}},
+		{"dhcp outside subnet", func(n *Network) { n.DHCPDStop = "192.168.21.250" }, []string{"dhcpd_stop"}},
+		{"dhcp range reversed", func(n *Network) { n.DHCPDStart, n.DHCPDStop = n.DHCPDStop, n.DHCPDStart }, []string{"dhcpd_stop"}},
+		{"bad dns", func(n *Network) { n.DHCPDDNS1 = "dns.example" }, []string{"dhcpd_dns_1"}},
+		{"site vpn", func(n *Network) { n.Purpose = "site-vpn" }, nil},
+		{"second wan", func(n *Network) { n.Purpose, n.NetworkGroup = NetworkPurposeWAN, "WAN2" }, nil},
+		{"static wan", func(n *Network) {
+			n.Purpose, n.WANType, n.WANIPAddress = NetworkPurposeWAN, WANTypeStatic, "203.0.113.2"
+		}, []string{"wan_netmask", "wan_gateway"}},
+		{"pppoe wan", func(n *Network) { n.Purpose, n.WANType = NetworkPurposeWAN, WANTypePPPoE }, []string{"wan_username"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := valid
+			tt.modify(&n)
+			if got := fields(t, n.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirewallRule_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule FirewallRule
+		want []string
+	}{
+		{"valid", FirewallRule{Name: "Block", Ruleset: RulesetWANIn, Action: FirewallActionDrop, Protocol: ProtocolICMP}, nil},
+		{"ports", FirewallRule{Name: "Web", Ruleset: RulesetLANIn, Action: FirewallActionAccept, Protocol: ProtocolTCP, DstPort: "80,443,8000-8100", RuleIndex: 2000}, nil},
+		{"protocol number", FirewallRule{Name: "GRE", Ruleset: RulesetWANIn, Action: FirewallActionAccept, Protocol: "47", SrcAddress: "10.0.0.0/8"}, nil},
+		{"missing required", FirewallRule{}, []string{"name", "ruleset", "action"}},
+		{"other protocol", FirewallRule{Name: "x", Ruleset: RulesetWANIn, Action: FirewallActionAccept, Protocol: "gre"}, nil},
+		{"rule index", FirewallRule{Name: "x", Ruleset: RulesetWANIn, Action: FirewallActionDrop, RuleIndex: 3000}, []string{"rule_index"}},
+		{"ports need tcp or udp", FirewallRule{Name: "x", Ruleset: RulesetWANIn, Action: FirewallActionDrop, Protocol: ProtocolAll, DstPort: "22"}, []string{"protocol"}},
+		{"bad port", FirewallRule{Name: "x", Ruleset: RulesetWANIn, Action: FirewallActionDrop, Protocol: ProtocolTCP, SrcPort: "70000", DstPort: "90-80"}, []string{"src_port", "dst_port"}},
+		{"bad addresses", FirewallRule{Name: "x", Ruleset: RulesetWANIn, Action: FirewallActionDrop, DstAddress: "lan", SrcMACAddress: "zz"}, []string{"dst_address", "src_mac_address"}},
+		{"ipv6", FirewallRule{Name: "v6", Ruleset: RulesetWANv6In, Action: FirewallActionAccept, ProtocolV6: ProtocolIPv6ICMP, ICMPv6Typename: "echo-request", DstAddress: "2001:db8::/64"}, nil},
+		{"ipv6 ports", FirewallRule{Name: "v6", Ruleset: RulesetLANv6In, Action: FirewallActionAccept, ProtocolV6: ProtocolTCP, DstPort: "443"}, nil},
+		{"ipv6 wrong fields", FirewallRule{Name: "v6", Ruleset: RulesetWANv6In, Action: FirewallActionDrop, Protocol: ProtocolTCP, ICMPTypename: "echo-request"}, []string{"protocol", "icmp_typename"}},
+		{"ipv6 icmp", FirewallRule{Name: "v6", Ruleset: RulesetWANv6In, Action: FirewallActionDrop, ProtocolV6: ProtocolICMP}, []string{"protocol_v6"}},
+		{"ipv6 with ipv4 address", FirewallRule{Name: "v6", Ruleset: RulesetWANv6Local, Action: FirewallActionDrop, SrcAddress: "10.0.0.1"}, []string{"src_address"}},
+		{"ipv4 with ipv6 fields", FirewallRule{Name: "v4", Ruleset: RulesetWANIn, Action: FirewallActionDrop, Protocol: ProtocolIPv6ICMP, ProtocolV6: ProtocolTCP, DstAddress: "2001:db8::1"}, []string{"protocol_v6", "protocol", "dst_address"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.rule.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirewallGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		group FirewallGroup
+		want  []string
+	}{
+		{"address", FirewallGroup{Name: "LAN", GroupType: GroupTypeAddress, GroupMembers: []string{"192.168.1.0/24", "10.0.0.1"}}, nil},
+		{"ipv6 address", FirewallGroup{Name: "LANv6", GroupType: GroupTypeIPv6Address, GroupMembers: []string{"2001:db8::/48"}}, nil},
+		{"address range", FirewallGroup{Name: "DHCP", GroupType: GroupTypeAddress, GroupMembers: []string{"10.0.0.1-10.0.0.10"}}, nil},
+		{"ipv6 address range", FirewallGroup{Name: "DHCPv6", GroupType: GroupTypeIPv6Address, GroupMembers: []string{"2001:db8::1-2001:db8::ff"}}, nil},
+		{"range family mismatch", FirewallGroup{Name: "Mixed", GroupType: GroupTypeAddress, GroupMembers: []string{"10.0.0.1-2001:db8::1"}}, []string{"group_members"}},
+		{"reversed range", FirewallGroup{Name: "DHCP", GroupType: GroupTypeAddress, GroupMembers: []string{"10.0.0.10-10.0.0.1"}}, []string{"group_members"}},
+		{"port", FirewallGroup{Name: "Web", GroupType: GroupTypePort, GroupMembers: []string{"80", "8000-8100"}}, nil},
+		{"missing required", FirewallGroup{}, []string{"name", "group_type"}},
+		{"family mismatch", FirewallGroup{Name: "Mixed", GroupType: GroupTypeIPv6Address, GroupMembers: []string{"10.0.0.0/8"}}, []string{"group_members"}},
+		{"bad port", FirewallGroup{Name: "Web", GroupType: GroupTypePort, GroupMembers: []string{"http"}}, []string{"group_members"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.group.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDNSRecord_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		record DNSRecord
+		want   []string
+	}{
+		{"valid A", DNSRecord{Key: "nas.lan", RecordType: DNSRecordTypeA, Value: "192.168.1.10"}, nil},
+		{"valid AAAA", DNSRecord{Key: "nas.lan", RecordType: DNSRecordTypeAAAA, Value: "2001:db8::10"}, nil},
+		{"missing required", DNSRecord{}, []string{"key", "record_type", "value"}},
+		{"unknown type", DNSRecord{Key: "nas.lan", RecordType: "PTR", Value: "nas.lan"}, []string{"record_type"}},
+		{"A with IPv6", DNSRecord{Key: "nas.lan", RecordType: DNSRecordTypeA, Value: "2001:db8::10"}, []string{"value"}},
+		{"AAAA with IPv4", DNSRecord{Key: "nas.lan", RecordType: DNSRecordTypeAAAA, Value: "192.168.1.10"}, []string{"value"}},
+		{"SRV without port", DNSRecord{Key: "_sip._tcp.lan", RecordType: DNSRecordTypeSRV, Value: "pbx.lan"}, []string{"port"}},
+		{"negative ttl", DNSRecord{Key: "www.lan", RecordType: DNSRecordTypeCNAME, Value: "nas.lan", TTL: -1}, []string{"ttl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.record.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirewallPolicy_Validate(t *testing.T) {
+	internal := FirewallPolicyEndpoint{ZoneID: "zone-internal"}
+	https := FirewallPolicyEndpoint{ZoneID: "zone-dmz", PortMatchingType: "SPECIFIC", Port: "443"}
+	tests := []struct {
+		name   string
+		policy FirewallPolicy
+		want   []string
+	}{
+		{"valid", FirewallPolicy{Name: "Web", Action: PolicyActionAllow, Protocol: ProtocolTCP, Source: internal, Destination: https}, nil},
+		{"missing required", FirewallPolicy{}, []string{"name", "action", "source.zone_id", "destination.zone_id"}},
+		{"unknown enums", FirewallPolicy{Name: "Web", Action: "DROP", IPVersion: "V4", Source: internal, Destination: internal}, []string{"action", "ip_version"}},
+		{"ports without tcp", FirewallPolicy{Name: "Web", Action: PolicyActionAllow, Protocol: ProtocolICMP, Source: internal, Destination: https}, []string{"protocol"}},
+		{"missing port", FirewallPolicy{Name: "Web", Action: PolicyActionAllow, Protocol: ProtocolTCP, Source: internal, Destination: FirewallPolicyEndpoint{ZoneID: "zone-dmz", PortMatchingType: "SPECIFIC"}}, []string{"destination.port"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.policy.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortForward_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		forward PortForward
+		want    []string
+	}{
+		{"valid", PortForward{Name: "Web", Protocol: ProtocolTCP, DstPort: "443", FwdIP: "192.168.1.100", FwdPort: "8443"}, nil},
+		{"missing required", PortForward{}, []string{"name", "dst_port", "fwd"}},
+		{"bad protocol", PortForward{Name: "Web", Protocol: "icmp", DstPort: "443", FwdIP: "192.168.1.100"}, []string{"proto"}},
+		{"bad ports", PortForward{Name: "Web", DstPort: "http", FwdIP: "192.168.1.100", FwdPort: "0"}, []string{"dst_port", "fwd_port"}},
+		{"ipv6 target", PortForward{Name: "Web", DstPort: "443", FwdIP: "2001:db8::1"}, []string{"fwd"}},
+		{"range size mismatch", PortForward{Name: "Game", DstPort: "27015-27020", FwdIP: "192.168.1.100", FwdPort: "27015-27016"}, []string{"fwd_port"}},
+		{"bad WAN", PortForward{Name: "Web", DstPort: "443", FwdIP: "192.168.1.100", WANInterface: "wan3", DestinationIP: "wan"}, []string{"pfwd_interface", "destination_ip"}},
+		{"source by IP", PortForward{Name: "Web", DstPort: "443", FwdIP: "192.168.1.100", SrcLimitingEnabled: true, SrcLimitingType: SrcLimitingIP, SrcNetworkID: "203.0.113.0/24"}, nil},
+		{"source missing", PortForward{Name: "Web", DstPort: "443", FwdIP: "192.168.1.100", SrcLimitingEnabled: true, SrcLimitingType: SrcLimitingIP, SrcNetworkID: "any"}, []string{"src"}},
+		{"source group missing", PortForward{Name: "Web", DstPort: "443", FwdIP: "192.168.1.100", SrcLimitingEnabled: true, SrcLimitingType: SrcLimitingFirewallGroup}, []string{"src_firewall_group_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.forward.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRADIUSProfile_Validate(t *testing.T) {
+	server := RADIUSServer{IP: "10.0.0.5", Port: 1812, Secret: "s3cret"}
+	acct := RADIUSServer{IP: "10.0.0.5", Port: 1813, Secret: "s3cret"}
+
+	tests := []struct {
+		name    string
+		profile RADIUSProfile
+		want    []string
+	}{
+		{"valid", RADIUSProfile{Name: "Corp", AuthServers: []RADIUSServer{server}, AcctServers: []RADIUSServer{acct}, AccountingEnabled: true, InterimUpdateEnabled: true, InterimUpdateInterval: 3600, VLANWLANMode: RADIUSVLANOptional}, nil},
+		{"gateway accounting", RADIUSProfile{Name: "Corp", AccountingEnabled: true, UseGatewayAcctServer: true}, nil},
+		{"incomplete servers", RADIUSProfile{Name: "Corp", AuthServers: []RADIUSServer{{IP: "10.0.0.5"}}, AcctServers: []RADIUSServer{{Port: 1813, Secret: "x"}}}, []string{"auth_servers[0].port", "auth_servers[0].x_secret", "acct_servers[0].ip"}},
+		{"accounting without server", RADIUSProfile{Name: "Corp", AccountingEnabled: true}, []string{"acct_servers"}},
+		{"bad interim and VLAN mode", RADIUSProfile{Name: "Corp", InterimUpdateEnabled: true, InterimUpdateInterval: 10, VLANWLANMode: "always"}, []string{"interim_update_interval", "vlan_wlan_mode"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.profile.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoute_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+		want  []string
+	}{
+		{"valid", Route{Name: "Lab", Type: RouteTypeNexthop, StaticRouteNetwork: "10.2.0.0/24", StaticRouteNexthop: "192.168.1.1"}, nil},
+		{"blackhole", Route{Name: "Sink", Type: RouteTypeBlackhole, StaticRouteNetwork: "10.9.0.0/16"}, nil},
+		{"missing required", Route{}, []string{"name", "static-route_network"}},
+		{"bad network", Route{Name: "Lab", StaticRouteNetwork: "10.2.0.0"}, []string{"static-route_network"}},
+		{"nexthop required", Route{Name: "Lab", Type: RouteTypeNexthop, StaticRouteNetwork: "10.2.0.0/24"}, []string{"static-route_nexthop"}},
+		{"controller type", Route{Name: "Lab", Type: "interface-route", StaticRouteNetwork: "10.2.0.0/24"}, nil},
+		{"static", Route{Name: "Lab", Type: "static", StaticRouteNetwork: "10.2.0.0/24", StaticRouteNexthop: "192.168.1.1"}, nil},
+		{"bad distance", Route{Name: "Lab", Type: "interface", StaticRouteNetwork: "10.2.0.0/24", StaticRouteDistance: 300}, []string{"static-route_distance"}},
+		{"negative distance", Route{Name: "Lab", Type: RouteTypeBlackhole, StaticRouteNetwork: "10.2.0.0/24", StaticRouteDistance: -1}, []string{"static-route_distance"}},
+		{"explicit distance", Route{Name: "Lab", Type: RouteTypeBlackhole, StaticRouteNetwork: "10.2.0.0/24", StaticRouteDistance: 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.route.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHotspotOperator_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator HotspotOperator
+		want     []string
+	}{
+		{"valid", HotspotOperator{Name: "frontdesk", Password: "s3cret"}, nil},
+		{"missing required", HotspotOperator{Name: " "}, []string{"name", "x_password"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.operator.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingLCM_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting SettingLCM
+		want    []string
+	}{
+		{"valid", SettingLCM{Enabled: true, Brightness: 80, IdleTimeout: 300, Pages: []string{"overview"}}, nil},
+		{"always on", SettingLCM{Brightness: 1}, nil},
+		{"out of range", SettingLCM{Brightness: 101, IdleTimeout: -1, Pages: []string{"overview", " "}}, []string{"brightness", "idle_timeout", "pages[1]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.setting.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		group UserGroup
+		want  []string
+	}{
+		{"valid", UserGroup{Name: "Gold", QOSRateMaxDown: RateMbps(100), QOSRateMaxUp: RateUnlimited}, nil},
+		{"unset limits", UserGroup{Name: "Default"}, nil},
+		{"invalid", UserGroup{QOSRateMaxDown: -5, QOSRateMaxUp: -2}, []string{"name", "qos_rate_max_down", "qos_rate_max_up"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.group.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingIPS_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting SettingIPS
+		want    []string
+	}{
+		{"no honeypots", SettingIPS{Enabled: true}, nil},
+		{"valid", SettingIPS{HoneypotEnabled: true, Honeypots: []Honeypot{
+			{IPAddress: "192.168.1.2", NetworkID: "lan"},
+			{IPAddress: "10.0.20.2", NetworkID: "iot"},
+		}}, nil},
+		{"invalid", SettingIPS{Honeypots: []Honeypot{
+			{IPAddress: "192.168.1.2", NetworkID: "lan"},
+			{IPAddress: "192.168.1.300", NetworkID: "lan"},
+			{},
+		}}, []string{"honeypot[1].ip_address", "honeypot[1].network_id", "honeypot[2].ip_address", "honeypot[2].network_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, tt.setting.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError represents a validation error for input data.
+type ValidationError struct {
+	// Field is the name of the field that failed validation.
+	Field string
+
+	// Message is the validation error message.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("validation error: %s: %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("validation error: %s", e.Message)
+}
+
+// ValidationErrors collects every violation found while validating a value,
+// so callers can report all of them at once instead of failing on the first.
+//
+// errors.As(err, &*ValidationError) matches the first violation, and
+// errors.Is/As walk every violation.
+type ValidationErrors []*ValidationError
+
+// Add records a violation.
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, &ValidationError{Field: field, Message: message})
+}
+
+// Addf records a violation with a formatted message.
+func (e *ValidationErrors) Addf(field, format string, args ...interface{}) {
+	e.Add(field, fmt.Sprintf(format, args...))
+}
+
+// Err returns nil if no violations were recorded, otherwise the collection.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Fields returns the names of the invalid fields, in order.
+func (e ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(e))
+	for _, v := range e {
+		fields = append(fields, v.Field)
+	}
+	return fields
+}
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "validation error: no violations"
+	case 1:
+		return e[0].Error()
+	}
+
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		if v.Field != "" {
+			msgs = append(msgs, v.Field+": "+v.Message)
+		} else {
+			msgs = append(msgs, v.Message)
+		}
+	}
+	return fmt.Sprintf("validation errors (%d): %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual violations for errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
+	}
+	return errs
+}
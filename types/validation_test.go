@@ -0,0 +1,59 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{Field: "name", Message: "required"}
+	if got := err.Error(); got != "validation error: name: required" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	err = &ValidationError{Message: "invalid input"}
+	if got := err.Error(); got != "validation error: invalid input" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	var verrs ValidationErrors
+	if verrs.Err() != nil {
+		t.Fatal("Err() should be nil with no violations")
+	}
+
+	verrs.Add("name", "required")
+	if got := verrs.Err().Error(); got != "validation error: name: required" {
+		t.Errorf("single Error() = %q", got)
+	}
+
+	verrs.Addf("vlan", "must be between 1 and 4094, got %d", 5000)
+	err := verrs.Err()
+	if err == nil {
+		t.Fatal("Err() should not be nil")
+	}
+
+	want := "validation errors (2): name: required; vlan: must be between 1 and 4094, got 5000"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got := verrs.Fields(); !reflect.DeepEqual(got, []string{"name", "vlan"}) {
+		t.Errorf("Fields() = %v", got)
+	}
+
+	var single *ValidationError
+	if !errors.As(err, &single) {
+		t.Fatal("errors.As should find a *ValidationError")
+	}
+	if single.Field != "name" {
+		t.Errorf("first violation Field = %s, want name", single.Field)
+	}
+
+	var all ValidationErrors
+	if !errors.As(err, &all) || len(all) != 2 {
+		t.Errorf("errors.As(ValidationErrors) = %v", all)
+	}
+}
@@ -15,6 +15,7 @@ type WLAN struct {
 	NetworkConfID         string   `json:"networkconf_id,omitempty"`
 	UsergroupID           string   `json:"usergroup_id,omitempty"`
 	APGroupIDs            []string `json:"ap_group_ids,omitempty"`
+	APGroupMode           string   `json:"ap_group_mode,omitempty"` // "all", "groups"
 	WLANBands             []string `json:"wlan_bands,omitempty"` // ["2g", "5g", "6g"]
 	WLANBand              string   `json:"wlan_band,omitempty"` // Legacy single band
 
@@ -114,6 +115,22 @@ type WLANGroup struct {
 	AttrNoDelete    bool     `json:"attr_no_delete,omitempty"`
 }
 
+// APGroup is a named set of access points that WLANs can be limited to
+// (v2 API).
+type APGroup struct {
+	ID           string   `json:"_id,omitempty"`
+	Name         string   `json:"name"`
+	DeviceMACs   []string `json:"device_macs"`
+	AttrNoDelete bool     `json:"attr_no_delete,omitempty"`
+	AttrHiddenID string   `json:"attr_hidden_id,omitempty"`
+}
+
+// AP group mode constants for WLAN.
+const (
+	APGroupModeAll    = "all"    // Broadcast on every AP
+	APGroupModeGroups = "groups" // Broadcast on the APs of APGroupIDs
+)
+
 // Security type constants for WLAN.
 const (
 	SecurityTypeOpen   = "open"
@@ -0,0 +1,29 @@
+package types
+
+import "encoding/json"
+
+// WebSocket message kinds, as found in WebSocketMeta.Message.
+const (
+	// WebSocketMessageEvents carries events, the same as stat/event.
+	WebSocketMessageEvents = "events"
+
+	// WebSocketMessageDeviceSync carries the full state of devices.
+	WebSocketMessageDeviceSync = "device:sync"
+
+	// WebSocketMessageDeviceUpdate carries the fields of devices that
+	// changed.
+	WebSocketMessageDeviceUpdate = "device:update"
+)
+
+// WebSocketMeta describes a WebSocketMessage.
+type WebSocketMeta struct {
+	RC      string `json:"rc,omitempty"`
+	Message string `json:"message"`
+}
+
+// WebSocketMessage is a message on a site's WebSocket. Meta.Message says
+// what Data holds, always as an array.
+type WebSocketMessage struct {
+	Meta WebSocketMeta   `json:"meta"`
+	Data json.RawMessage `json:"data"`
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/types"
+)
+
+// collect polls the controller and returns the metrics for one site.
+// Devices, clients and health are all required; a failure in any of them
+// fails the scrape so partial data is never exported as complete.
+func collect(ctx context.Context, client gofi.Client, site string) (*metricSet, error) {
+	devices, err := client.Devices().List(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	clients, err := client.Clients().ListActive(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	health, err := client.Sites().Health(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health: %w", err)
+	}
+
+	m := newMetricSet()
+	siteLabel := label{"site", site}
+
+	collectDevices(m, siteLabel, devices)
+	collectClients(m, siteLabel, devices, clients)
+	collectHealth(m, siteLabel, health)
+
+	return m, nil
+}
+
+// deviceName returns the device name, falling back to its MAC.
+func deviceName(d *types.Device) string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.MAC
+}
+
+// collectDevices records per-device, per-port and WAN metrics.
+func collectDevices(m *metricSet, site label, devices []types.Device) {
+	for i := range devices {
+		d := &devices[i]
+		dev := []label{site, {"mac", strings.ToLower(d.MAC)}, {"name", deviceName(d)}, {"type", d.Type}}
+
+		m.gauge("unifi_device_info", "Device metadata; always 1.", 1,
+			append(dev, label{"model", d.ModelName()}, label{"version", d.Version})...)
+		m.gauge("unifi_device_up", "Whether the device is online (1) or not (0).", boolValue(d.State.IsOnline()), dev...)
+		m.gauge("unifi_device_state", "Raw controller device state code.", float64(d.State), dev...)
+		m.gauge("unifi_device_uptime_seconds", "Device uptime in seconds.", d.Uptime.Float64(), dev...)
+		m.counter("unifi_device_received_bytes_total", "Bytes received by the device.", d.RxBytes.Float64(), dev...)
+		m.counter("unifi_device_transmitted_bytes_total", "Bytes transmitted by the device.", d.TxBytes.Float64(), dev...)
+
+		collectPorts(m, dev, d)
+		collectWAN(m, dev, "wan1", d.Wan1)
+		collectWAN(m, dev, "wan2", d.Wan2)
+	}
+}
+
+// collectPorts records per-port PoE and link metrics for switches and
+// gateways with a port table.
+func collectPorts(m *metricSet, dev []label, d *types.Device) {
+	var used float64
+	hasPoE := false
+
+	for _, p := range d.PortTable {
+		port := append(append([]label(nil), dev...), label{"port", strconv.Itoa(p.PortIdx)}, label{"port_name", p.Name})
+
+		m.gauge("unifi_port_up", "Whether the port has link (1) or not (0).", boolValue(p.Up), port...)
+		m.gauge("unifi_port_speed_mbps", "Negotiated port speed in Mbps.", float64(p.Speed), port...)
+		m.counter("unifi_port_received_bytes_total", "Bytes received on the port.", p.RXBytes.Float64(), port...)
+		m.counter("unifi_port_transmitted_bytes_total", "Bytes transmitted on the port.", p.TXBytes.Float64(), port...)
+
+		if !p.PortPoe {
+			continue
+		}
+		hasPoE = true
+		watts := p.PoePower.Float64()
+		used += watts
+		m.gauge("unifi_port_poe_watts", "PoE power drawn on the port in watts.", watts, port...)
+		m.gauge("unifi_port_poe_enabled", "Whether PoE output is enabled on the port.", boolValue(p.PoeEnable), port...)
+	}
+
+	if !hasPoE {
+		return
+	}
+	m.gauge("unifi_device_poe_used_watts", "Total PoE power drawn from the device in watts.", used, dev...)
+	if info, ok := d.ModelInfo(); ok && info.PoEBudget > 0 {
+		m.gauge("unifi_device_poe_budget_watts", "Published PoE budget of the device model in watts.", info.PoEBudget, dev...)
+	}
+}
+
+// collectWAN records metrics for a gateway WAN interface.
+func collectWAN(m *metricSet, dev []label, name string, wan *types.WAN) {
+	if wan == nil || !wan.Enable {
+		return
+	}
+	labels := append(append([]label(nil), dev...), label{"wan", name}, label{"ifname", wan.IFNAME})
+
+	m.gauge("unifi_wan_up", "Whether the WAN interface is up (1) or not (0).", boolValue(wan.Up), labels...)
+	m.gauge("unifi_wan_latency_seconds", "WAN latency reported by the gateway in seconds.", float64(wan.Latency)/1000, labels...)
+	m.gauge("unifi_wan_receive_bytes_per_second", "Current WAN receive rate in bytes per second.", wan.RXBytesR.Float64(), labels...)
+	m.gauge("unifi_wan_transmit_bytes_per_second", "Current WAN transmit rate in bytes per second.", wan.TXBytesR.Float64(), labels...)
+	m.counter("unifi_wan_received_bytes_total", "Bytes received on the WAN interface.", wan.RXBytes.Float64(), labels...)
+	m.counter("unifi_wan_transmitted_bytes_total", "Bytes transmitted on the WAN interface.", wan.TXBytes.Float64(), labels...)
+}
+
+// collectClients records client counts per access point and per
+// connection type. Every access point is reported, including those with
+// no clients.
+func collectClients(m *metricSet, site label, devices []types.Device, clients []types.Client) {
+	perAP := make(map[string]int)
+	var wired, wireless, guests int
+	for _, c := range clients {
+		if c.IsGuest.Val {
+			guests++
+		}
+		if c.IsWired.Val {
+			wired++
+			continue
+		}
+		wireless++
+		perAP[strings.ToLower(c.APMA)]++
+	}
+
+	for i := range devices {
+		d := &devices[i]
+		if d.Type != "uap" && len(d.RadioTable) == 0 {
+			continue
+		}
+		mac := strings.ToLower(d.MAC)
+		m.gauge("unifi_ap_clients", "Wireless clients associated with the access point.", float64(perAP[mac]),
+			site, label{"mac", mac}, label{"name", deviceName(d)})
+
+		for _, r := range d.RadioTableStats {
+			m.gauge("unifi_ap_radio_clients", "Wireless clients per access point radio.", float64(r.NumSTA),
+				site, label{"mac", mac}, label{"name", deviceName(d)}, label{"radio", r.Radio})
+		}
+	}
+
+	m.gauge("unifi_clients", "Connected clients by connection type.", float64(wired), site, label{"connection", "wired"})
+	m.gauge("unifi_clients", "Connected clients by connection type.", float64(wireless), site, label{"connection", "wireless"})
+	m.gauge("unifi_guest_clients", "Connected guest clients.", float64(guests), site)
+}
+
+// collectHealth records per-subsystem health and the site's WAN latency
+// and speed test results.
+func collectHealth(m *metricSet, site label, health []types.HealthData) {
+	for _, h := range health {
+		sub := label{"subsystem", h.Subsystem}
+		m.gauge("unifi_health_ok", "Whether the subsystem reports status ok (1) or not (0).", boolValue(h.Status == "ok"), site, sub)
+
+		switch h.Subsystem {
+		case "www":
+			m.gauge("unifi_internet_latency_seconds", "Internet latency measured by the gateway in seconds.", float64(h.Latency)/1000, site)
+			m.gauge("unifi_internet_drops", "Internet connectivity drops reported by the gateway.", float64(h.Drops), site)
+			m.gauge("unifi_speedtest_download_mbps", "Last speed test download throughput in Mbps.", h.XputDown.Float64(), site)
+			m.gauge("unifi_speedtest_upload_mbps", "Last speed test upload throughput in Mbps.", h.XputUp.Float64(), site)
+			m.gauge("unifi_speedtest_ping_seconds", "Last speed test ping in seconds.", float64(h.SpeedtestPing)/1000, site)
+		case "wan":
+			m.gauge("unifi_wan_site_receive_bytes_per_second", "Site WAN receive rate in bytes per second.", h.RxBytesR.Float64(), site)
+			m.gauge("unifi_wan_site_transmit_bytes_per_second", "Site WAN transmit rate in bytes per second.", h.TxBytesR.Float64(), site)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/unifi-go/gofi"
+)
+
+func main() {
+	var (
+		host     = flag.String("host", "", "UDM Pro host address")
+		port     = flag.Int("port", 443, "UDM Pro port")
+		site     = flag.String("site", "default", "Site name")
+		insecure = flag.Bool("insecure", false, "Skip TLS certificate verification")
+		listen   = flag.String("listen", ":9130", "Address to serve metrics on")
+		interval = flag.Duration("interval", 30*time.Second, "Controller poll interval")
+	)
+
+	flag.StringVar(host, "H", "", "UDM Pro host address (shorthand)")
+	flag.IntVar(port, "p", 443, "UDM Pro port (shorthand)")
+	flag.StringVar(site, "S", "default", "Site name (shorthand)")
+	flag.BoolVar(insecure, "k", false, "Skip TLS certificate verification (shorthand)")
+	flag.StringVar(listen, "l", ":9130", "Address to serve metrics on (shorthand)")
+	flag.DurationVar(interval, "i", 30*time.Second, "Controller poll interval (shorthand)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Poll a UniFi controller and expose device, client, health and WAN\n")
+		fmt.Fprintf(os.Stderr, "statistics as Prometheus metrics on /metrics.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -l, --listen string\tAddress to serve metrics on (default \":9130\")\n")
+		fmt.Fprintf(os.Stderr, "  -i, --interval duration\tController poll interval (default 30s)\n\n")
+		fmt.Fprintf(os.Stderr, "Connection:\n")
+		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (or set %s)\n", gofi.EnvHost)
+		fmt.Fprintf(os.Stderr, "  -p, --port int\tUDM Pro port (default 443)\n")
+		fmt.Fprintf(os.Stderr, "  -S, --site string\tSite name (default \"default\")\n")
+		fmt.Fprintf(os.Stderr, "  -k, --insecure\tSkip TLS certificate verification\n\n")
+		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
+		fmt.Fprintf(os.Stderr, "  %s\tUDM host (fallback for -H; %s is also read)\n", gofi.EnvHost, gofi.EnvUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s\tUsername (required without an API key)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s\tPassword (required without an API key)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tAPI key, used instead of the username and password\n", gofi.EnvAPIKey)
+		fmt.Fprintf(os.Stderr, "  %s\tSite name (fallback for -S)\n", gofi.EnvSite)
+		fmt.Fprintf(os.Stderr, "  %s\tSkip TLS certificate verification if true\n\n", gofi.EnvInsecure)
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -l 127.0.0.1:9130 -i 1m\n", os.Args[0])
+	}
+
+	flag.Parse()
+
+	if *interval <= 0 {
+		exitError("--interval must be positive")
+	}
+
+	config := configFromEnv(*host, *port, *site, *insecure)
+	client, err := gofi.New(config)
+	if err != nil {
+		exitError("failed to create client: " + err.Error())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	e := &exporter{client: client, site: config.Site}
+	go e.run(ctx, *interval)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, "gofi-exporter: metrics are on /metrics\n")
+	})
+
+	server := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving metrics on %s/metrics, polling %s every %s", *listen, *host, *interval)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		exitError(err.Error())
+	}
+
+	client.Disconnect(context.Background())
+}
+
+// exporter polls the controller in the background and serves the most
+// recent metrics. Scrapes never hit the controller directly, so Prometheus
+// scrape intervals and timeouts are independent of controller latency.
+type exporter struct {
+	client gofi.Client
+	site   string
+
+	mu      sync.RWMutex
+	metrics []byte
+}
+
+// run polls until ctx is cancelled.
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.poll(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll collects one round of metrics. A failed poll marks the exporter
+// down and forces a fresh login on the next round, which also recovers
+// from expired sessions.
+func (e *exporter) poll(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	m, err := e.scrape(ctx)
+	if err != nil {
+		log.Printf("poll failed: %v", err)
+		e.client.Disconnect(ctx)
+		m = newMetricSet()
+	}
+
+	m.gauge("unifi_up", "Whether the last controller poll succeeded (1) or not (0).", boolValue(err == nil))
+	m.gauge("unifi_poll_duration_seconds", "Duration of the last controller poll in seconds.", time.Since(start).Seconds())
+	m.gauge("unifi_last_poll_timestamp_seconds", "Unix time of the last controller poll.", float64(start.Unix()))
+
+	var buf bytes.Buffer
+	m.write(&buf)
+
+	e.mu.Lock()
+	e.metrics = buf.Bytes()
+	e.mu.Unlock()
+}
+
+// scrape connects if needed and collects metrics.
+func (e *exporter) scrape(ctx context.Context) (*metricSet, error) {
+	if !e.client.IsConnected() {
+		if err := e.client.Connect(ctx); err != nil {
+			return nil, fmt.Errorf("failed to connect: %w", err)
+		}
+	}
+	return collect(ctx, e.client, e.site)
+}
+
+// ServeHTTP serves the most recent metrics.
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	metrics := e.metrics
+	e.mu.RUnlock()
+
+	if metrics == nil {
+		http.Error(w, "first poll has not completed", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(metrics)
+}
+
+// configFromEnv builds the config with gofi.ConfigFromEnv and overlays the
+// connection flags given on the command line.
+func configFromEnv(host string, port int, site string, insecure bool) *gofi.Config {
+	// A --host flag stands in for the host variables
+	if host != "" {
+		os.Setenv(gofi.EnvHost, host)
+	}
+
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		exitError("incomplete configuration: " + err.Error())
+	}
+	config.Port = port
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "site", "S":
+			config.Site = site
+		case "insecure", "k":
+			config.SkipTLSVerify = insecure
+		}
+	})
+	return config
+}
+
+func exitError(msg string) {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+	os.Exit(1)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric types in the Prometheus text exposition format.
+const (
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+)
+
+// label is a metric label pair.
+type label struct {
+	name  string
+	value string
+}
+
+// sample is one value of a metric family.
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a named metric with its samples.
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+// metricSet collects metric families for one scrape. Families are written
+// in name order so output is stable between polls.
+type metricSet struct {
+	families map[string]*family
+}
+
+func newMetricSet() *metricSet {
+	return &metricSet{families: make(map[string]*family)}
+}
+
+// add records a sample, creating the family on first use.
+func (m *metricSet) add(name, typ, help string, value float64, labels ...label) {
+	f, ok := m.families[name]
+	if !ok {
+		f = &family{name: name, help: help, typ: typ}
+		m.families[name] = f
+	}
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// gauge records a gauge sample.
+func (m *metricSet) gauge(name, help string, value float64, labels ...label) {
+	m.add(name, typeGauge, help, value, labels...)
+}
+
+// counter records a counter sample.
+func (m *metricSet) counter(name, help string, value float64, labels ...label) {
+	m.add(name, typeCounter, help, value, labels...)
+}
+
+// write renders the set in the Prometheus text exposition format.
+func (m *metricSet) write(w io.Writer) error {
+	names := make([]string, 0, len(m.families))
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		f := m.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.typ)
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			if len(s.labels) > 0 {
+				b.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, "%s=\"%s\"", l.name, escapeLabel(l.value))
+				}
+				b.WriteByte('}')
+			}
+			b.WriteByte(' ')
+			b.WriteString(formatValue(s.value))
+			b.WriteByte('\n')
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a label value for the text format.
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// formatValue formats a sample value for the text format.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// boolValue converts b to 1 or 0.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/unifi-go/gofi"
+)
+
+// runBackupDownload implements "gofi backup download".
+func runBackupDownload(args []string) error {
+	var (
+		conn    connFlags
+		dir     string
+		keep    int
+		timeout time.Duration
+	)
+
+	fs := flag.NewFlagSet("backup download", flag.ExitOnError)
+	conn.register(fs)
+	fs.StringVar(&dir, "dir", ".", "Directory to write the backup to")
+	fs.StringVar(&dir, "o", ".", "Directory to write the backup to (shorthand)")
+	fs.IntVar(&keep, "keep", 0, "Keep only the newest N local backups (0 keeps all)")
+	fs.DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the controller to list the new backup")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi backup download [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Create a fresh controller backup, wait for it to be listed and download it.\n")
+		fmt.Fprintf(os.Stderr, "The file is verified against the listed size and a SHA-256 checksum is\n")
+		fmt.Fprintf(os.Stderr, "written next to it as <file>.sha256.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --dir string\tDirectory to write the backup to (default \".\")\n")
+		fmt.Fprintf(os.Stderr, "      --keep int\tKeep only the newest N local backups (default 0, keep all)\n")
+		fmt.Fprintf(os.Stderr, "      --timeout duration\tWait limit for the new backup to be listed (default 5m)\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  gofi backup download -H 192.168.1.1 -k -o /var/backups/unifi\n")
+		fmt.Fprintf(os.Stderr, "  gofi backup download -H 192.168.1.1 -k -o /var/backups/unifi --keep 7\n")
+	}
+	fs.Parse(args)
+
+	if keep < 0 {
+		return errors.New("--keep must not be negative")
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	fmt.Fprintf(os.Stderr, "Creating backup...\n")
+	result, err := gofi.DownloadFreshBackup(ctx, client, gofi.BackupDownloadOptions{
+		Dir:         dir,
+		Keep:        keep,
+		WaitTimeout: timeout,
+	})
+	if result != nil {
+		fmt.Printf("%s  %s\n", result.SHA256, result.Path)
+		fmt.Fprintf(os.Stderr, "Downloaded %s (%d bytes).\n", result.Backup.Filename, result.Size)
+		for _, p := range result.Pruned {
+			fmt.Fprintf(os.Stderr, "Pruned %s\n", p)
+		}
+	}
+	return err
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/unifi-go/gofi/internal"
+	"github.com/unifi-go/gofi/services"
+	"github.com/unifi-go/gofi/types"
+)
+
+// clientFilter selects clients for "gofi clients list".
+type clientFilter struct {
+	network string
+	ssid    string
+	mac     string
+	blocked bool
+}
+
+// match reports whether c passes the filter. network matches the network
+// name or ID case-insensitively, mac is a prefix of the MAC address in
+// any notation and ssid must match exactly.
+func (f *clientFilter) match(c *types.Client) bool {
+	if f.network != "" && !strings.EqualFold(c.NetworkName, f.network) && !strings.EqualFold(c.NetworkID, f.network) {
+		return false
+	}
+	if f.ssid != "" && c.ESSID != f.ssid {
+		return false
+	}
+	if f.mac != "" && !strings.HasPrefix(internal.NormalizeMAC(c.MAC), internal.NormalizeMAC(f.mac)) {
+		return false
+	}
+	if f.blocked && !c.Blocked {
+		return false
+	}
+	return true
+}
+
+// runClientsList implements "gofi clients list".
+func runClientsList(args []string) error {
+	var (
+		conn   connFlags
+		filter clientFilter
+		all    bool
+		format string
+	)
+
+	fs := flag.NewFlagSet("clients list", flag.ExitOnError)
+	conn.register(fs)
+	fs.BoolVar(&all, "all", false, "Include known clients that are not connected")
+	fs.BoolVar(&all, "a", false, "Include known clients that are not connected (shorthand)")
+	fs.StringVar(&filter.network, "network", "", "Only clients on this network (name or ID)")
+	fs.StringVar(&filter.ssid, "ssid", "", "Only clients on this SSID")
+	fs.StringVar(&filter.mac, "mac", "", "Only clients whose MAC address starts with this")
+	fs.BoolVar(&filter.blocked, "blocked", false, "Only blocked clients (use with --all)")
+	fs.StringVar(&format, "format", "table", "Output format: table or json")
+	fs.StringVar(&format, "f", "table", "Output format (shorthand)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi clients list [options]\n\n")
+		fmt.Fprintf(os.Stderr, "List connected clients, or with --all every client the controller knows.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -a, --all\t\tInclude known clients that are not connected\n")
+		fmt.Fprintf(os.Stderr, "      --network string\tOnly clients on this network (name or ID)\n")
+		fmt.Fprintf(os.Stderr, "      --ssid string\tOnly clients on this SSID\n")
+		fmt.Fprintf(os.Stderr, "      --mac string\tOnly clients whose MAC starts with this, e.g. an OUI\n")
+		fmt.Fprintf(os.Stderr, "      --blocked\t\tOnly blocked clients (blocked clients are offline: use with --all)\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttable or json (default table)\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  gofi clients list -H 192.168.1.1 -k --ssid Guest\n")
+		fmt.Fprintf(os.Stderr, "  gofi clients list -H 192.168.1.1 -k --all --blocked -f json\n")
+	}
+	fs.Parse(args)
+
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unknown format %q (want table or json)", format)
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	var clients []types.Client
+	if all {
+		clients, err = client.Clients().ListAll(ctx, conn.site)
+	} else {
+		clients, err = client.Clients().ListActive(ctx, conn.site)
+	}
+	if err != nil {
+		return err
+	}
+
+	selected := []types.Client{}
+	for i := range clients {
+		if filter.match(&clients[i]) {
+			selected = append(selected, clients[i])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].MAC < selected[j].MAC })
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(selected)
+	} else {
+		err = writeClientTable(os.Stdout, selected)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// writeClientTable writes clients as an aligned table.
+func writeClientTable(w io.Writer, clients []types.Client) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MAC\tNAME\tIP\tNETWORK\tSSID\tLAST SEEN\tBLOCKED")
+	for i := range clients {
+		c := &clients[i]
+		name := c.Name
+		if name == "" {
+			name = c.Hostname
+		}
+		ssid := c.ESSID
+		if c.IsWired.Val {
+			ssid = "(wired)"
+		}
+		lastSeen := ""
+		if !c.LastSeen.IsZero() {
+			lastSeen = c.LastSeenTime().Local().Format(time.DateTime)
+		}
+		blocked := ""
+		if c.Blocked {
+			blocked = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.MAC, name, c.IP, c.NetworkName, ssid, lastSeen, blocked)
+	}
+	return tw.Flush()
+}
+
+// clientAction is a "gofi clients" command that acts on clients by MAC.
+type clientAction struct {
+	name  string
+	done  string
+	about string
+	run   func(svc services.ClientService, ctx context.Context, site, mac string) error
+}
+
+var (
+	clientBlock   = clientAction{"block", "Blocked", "Block clients from the network.", services.ClientService.Block}
+	clientUnblock = clientAction{"unblock", "Unblocked", "Unblock previously blocked clients.", services.ClientService.Unblock}
+	clientKick    = clientAction{"kick", "Kicked", "Disconnect clients; they may reconnect right away.", services.ClientService.Kick}
+	clientForget  = clientAction{"forget", "Forgot", "Remove clients and their history from the controller.", services.ClientService.Forget}
+)
+
+func runClientsBlock(args []string) error   { return clientBlock.runAction(args) }
+func runClientsUnblock(args []string) error { return clientUnblock.runAction(args) }
+func runClientsKick(args []string) error    { return clientKick.runAction(args) }
+func runClientsForget(args []string) error  { return clientForget.runAction(args) }
+
+// runAction implements "gofi clients <action> MAC...".
+func (a clientAction) runAction(args []string) error {
+	var (
+		conn   connFlags
+		format string
+	)
+
+	fs := flag.NewFlagSet("clients "+a.name, flag.ExitOnError)
+	conn.register(fs)
+	fs.StringVar(&format, "format", "text", "Output format: text or json")
+	fs.StringVar(&format, "f", "text", "Output format (shorthand)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi clients %s [options] MAC...\n\n", a.name)
+		fmt.Fprintf(os.Stderr, "%s\n\n", a.about)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttext or json (default text)\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExample:\n")
+		fmt.Fprintf(os.Stderr, "  gofi clients %s -H 192.168.1.1 -k aa:bb:cc:dd:ee:ff\n", a.name)
+	}
+	fs.Parse(args)
+
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (want text or json)", format)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errReported
+	}
+
+	// Check every MAC before acting on any
+	macs := make([]string, fs.NArg())
+	for i, mac := range fs.Args() {
+		if !internal.ValidateMAC(mac) {
+			return fmt.Errorf("invalid MAC address %q", mac)
+		}
+		macs[i] = internal.FormatMAC(mac)
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	results := make([]clientActionJSON, len(macs))
+	failed := 0
+	for i, mac := range macs {
+		results[i] = clientActionJSON{MAC: mac, Action: a.name, OK: true}
+		if err := a.run(client.Clients(), ctx, conn.site, mac); err != nil {
+			results[i].OK = false
+			results[i].Error = err.Error()
+			failed++
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	} else {
+		for _, r := range results {
+			if r.OK {
+				fmt.Printf("%s %s\n", a.done, r.MAC)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %s: %s\n", r.MAC, r.Error)
+			}
+		}
+	}
+	if failed > 0 {
+		return errReported
+	}
+	return nil
+}
+
+// clientActionJSON is the JSON representation of one client action.
+type clientActionJSON struct {
+	MAC    string `json:"mac"`
+	Action string `json:"action"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
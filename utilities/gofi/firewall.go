@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/types"
+)
+
+// firewallFlags are the options shared by the firewall commands.
+type firewallFlags struct {
+	conn     connFlags
+	rulesets string
+	format   string
+}
+
+// register adds the flags to fs. Rule commands also take --ruleset.
+func (f *firewallFlags) register(fs *flag.FlagSet, rules bool) {
+	f.conn.register(fs)
+	if rules {
+		fs.StringVar(&f.rulesets, "ruleset", "", "Comma-separated rulesets, e.g. WAN_IN,LAN_IN")
+		fs.StringVar(&f.rulesets, "r", "", "Comma-separated rulesets (shorthand)")
+	}
+	fs.StringVar(&f.format, "format", "text", "Output format: text or json")
+	fs.StringVar(&f.format, "f", "text", "Output format (shorthand)")
+}
+
+// usage returns a FlagSet usage function for "gofi firewall <cmd>".
+func (f *firewallFlags) usage(cmd, args, about string, rules bool, examples ...string) func() {
+	return func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi firewall %s [options]%s\n\n", cmd, args)
+		fmt.Fprintf(os.Stderr, "%s\n\n", about)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		if rules {
+			fmt.Fprintf(os.Stderr, "  -r, --ruleset string\tComma-separated rulesets, e.g. WAN_IN,LAN_IN (default: all)\n")
+		}
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttext or json (default text)\n\n")
+		f.conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		for _, ex := range examples {
+			fmt.Fprintf(os.Stderr, "  %s\n", ex)
+		}
+	}
+}
+
+// parse parses args, checks the format and, if nameArg, that exactly one
+// rule or group name is given.
+func (f *firewallFlags) parse(fs *flag.FlagSet, args []string, nameArg bool) (string, error) {
+	fs.Parse(args)
+
+	if f.format != "text" && f.format != "json" {
+		return "", fmt.Errorf("unknown format %q (want text or json)", f.format)
+	}
+	if !nameArg {
+		return "", nil
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return "", errReported
+	}
+	return fs.Arg(0), nil
+}
+
+// inRulesets reports whether ruleset is selected by the --ruleset value.
+func (f *firewallFlags) inRulesets(ruleset string) bool {
+	selected := splitList(f.rulesets)
+	if len(selected) == 0 {
+		return true
+	}
+	for _, rs := range selected {
+		if strings.EqualFold(rs, ruleset) {
+			return true
+		}
+	}
+	return false
+}
+
+// firewallData is what the firewall commands fetch from the controller.
+type firewallData struct {
+	rules    []types.FirewallRule
+	groups   []types.FirewallGroup
+	networks []types.Network
+}
+
+// fetchFirewall fetches the site's firewall rules, groups and networks.
+func fetchFirewall(ctx context.Context, client gofi.Client, site string) (*firewallData, error) {
+	var (
+		d   firewallData
+		err error
+	)
+	if d.rules, err = client.Firewall().ListRules(ctx, site); err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+	if d.groups, err = client.Firewall().ListGroups(ctx, site); err != nil {
+		return nil, fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+	if d.networks, err = client.Networks().List(ctx, site); err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	return &d, nil
+}
+
+// findRule looks a rule up by ID or, case-insensitively, by name among
+// the selected rulesets. A name used in several rulesets must be narrowed
+// down with --ruleset.
+func (f *firewallFlags) findRule(d *firewallData, ref string) (*types.FirewallRule, error) {
+	var matches []*types.FirewallRule
+	for i := range d.rules {
+		r := &d.rules[i]
+		if r.ID == ref {
+			return r, nil
+		}
+		if strings.EqualFold(r.Name, ref) && f.inRulesets(r.Ruleset) {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no firewall rule named %q", ref)
+	case 1:
+		return matches[0], nil
+	}
+	where := make([]string, len(matches))
+	for i, r := range matches {
+		where[i] = fmt.Sprintf("%s %d", r.Ruleset, r.RuleIndex)
+	}
+	return nil, fmt.Errorf("%d rules are named %q (%s); select one with --ruleset or its ID", len(matches), ref, strings.Join(where, ", "))
+}
+
+// findGroup looks a group up by ID or, case-insensitively, by name.
+func findGroup(d *firewallData, ref string) (*types.FirewallGroup, error) {
+	for i := range d.groups {
+		if d.groups[i].ID == ref || strings.EqualFold(d.groups[i].Name, ref) {
+			return &d.groups[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no firewall group named %q", ref)
+}
+
+// writeJSON writes v as indented JSON to stdout.
+func writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// runFirewallRulesList implements "gofi firewall rules list".
+func runFirewallRulesList(args []string) error {
+	var f firewallFlags
+	fs := flag.NewFlagSet("firewall rules list", flag.ExitOnError)
+	f.register(fs, true)
+	fs.Usage = f.usage("rules list", "", "List firewall rules by ruleset in evaluation order, with group and\nnetwork IDs resolved to names.", true,
+		"gofi firewall rules list -H 192.168.1.1 -k",
+		"gofi firewall rules list -H 192.168.1.1 -k -r WAN_IN,WAN_LOCAL -f json")
+	if _, err := f.parse(fs, args, false); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := f.conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	d, err := fetchFirewall(ctx, client, f.conn.site)
+	if err != nil {
+		return err
+	}
+	export := gofi.NewFirewallExport(d.rules, d.groups, d.networks)
+	export.Site = f.conn.site
+	export.Groups = nil
+
+	rulesets := export.Rulesets[:0]
+	for _, rs := range export.Rulesets {
+		if f.inRulesets(rs.Name) {
+			rulesets = append(rulesets, rs)
+		}
+	}
+	export.Rulesets = rulesets
+
+	if f.format == "json" {
+		return writeJSON(export.Rulesets)
+	}
+	if err := export.WriteText(os.Stdout); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// firewallRuleJSON is the JSON representation of "gofi firewall rules show".
+type firewallRuleJSON struct {
+	ID      string `json:"id"`
+	Ruleset string `json:"ruleset"`
+	gofi.FirewallRuleExport
+}
+
+// runFirewallRulesShow implements "gofi firewall rules show".
+func runFirewallRulesShow(args []string) error {
+	var f firewallFlags
+	fs := flag.NewFlagSet("firewall rules show", flag.ExitOnError)
+	f.register(fs, true)
+	fs.Usage = f.usage("rules show", " NAME|ID", "Show one firewall rule, looked up by name or ID.", true,
+		`gofi firewall rules show -H 192.168.1.1 -k "Block IoT to LAN"`)
+	ref, err := f.parse(fs, args, true)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := f.conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	d, err := fetchFirewall(ctx, client, f.conn.site)
+	if err != nil {
+		return err
+	}
+	rule, err := f.findRule(d, ref)
+	if err != nil {
+		return err
+	}
+	export := gofi.NewFirewallExport([]types.FirewallRule{*rule}, d.groups, d.networks)
+	out := firewallRuleJSON{ID: rule.ID, Ruleset: rule.Ruleset, FirewallRuleExport: export.Rulesets[0].Rules[0]}
+
+	if f.format == "json" {
+		return writeJSON(out)
+	}
+	writeRule(os.Stdout, &out)
+	return nil
+}
+
+// writeRule writes a rule as "field: value" lines.
+func writeRule(w io.Writer, r *firewallRuleJSON) {
+	fmt.Fprintf(w, "Name:        %s\n", r.Name)
+	fmt.Fprintf(w, "ID:          %s\n", r.ID)
+	fmt.Fprintf(w, "Ruleset:     %s\n", r.Ruleset)
+	fmt.Fprintf(w, "Index:       %d\n", r.Index)
+	fmt.Fprintf(w, "Enabled:     %t\n", r.Enabled)
+	fmt.Fprintf(w, "Action:      %s\n", r.Action)
+	fmt.Fprintf(w, "Protocol:    %s\n", r.Protocol)
+	if r.ICMPType != "" {
+		fmt.Fprintf(w, "ICMP type:   %s\n", r.ICMPType)
+	}
+	fmt.Fprintf(w, "Source:      %s\n", r.Source)
+	fmt.Fprintf(w, "Destination: %s\n", r.Destination)
+	if len(r.States) > 0 {
+		fmt.Fprintf(w, "States:      %s\n", strings.Join(r.States, ", "))
+	}
+	if r.IPSec != "" {
+		fmt.Fprintf(w, "IPsec:       %s\n", r.IPSec)
+	}
+	fmt.Fprintf(w, "Logging:     %t\n", r.Logging)
+}
+
+func runFirewallRulesEnable(args []string) error  { return runFirewallRulesToggle(args, true) }
+func runFirewallRulesDisable(args []string) error { return runFirewallRulesToggle(args, false) }
+
+// runFirewallRulesToggle implements "gofi firewall rules enable|disable".
+func runFirewallRulesToggle(args []string, enable bool) error {
+	verb := "disable"
+	if enable {
+		verb = "enable"
+	}
+
+	var f firewallFlags
+	fs := flag.NewFlagSet("firewall rules "+verb, flag.ExitOnError)
+	f.register(fs, true)
+	fs.Usage = f.usage("rules "+verb, " NAME|ID", "Look up a firewall rule by name or ID and "+verb+" it.", true,
+		fmt.Sprintf(`gofi firewall rules %s -H 192.168.1.1 -k -r LAN_IN "Block IoT to LAN"`, verb))
+	ref, err := f.parse(fs, args, true)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := f.conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	d, err := fetchFirewall(ctx, client, f.conn.site)
+	if err != nil {
+		return err
+	}
+	rule, err := f.findRule(d, ref)
+	if err != nil {
+		return err
+	}
+
+	if enable {
+		err = client.Firewall().EnableRule(ctx, f.conn.site, rule.ID)
+	} else {
+		err = client.Firewall().DisableRule(ctx, f.conn.site, rule.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if f.format == "json" {
+		return writeJSON(map[string]interface{}{"id": rule.ID, "name": rule.Name, "ruleset": rule.Ruleset, "enabled": enable})
+	}
+	fmt.Printf("%sd %s %d %q\n", strings.ToUpper(verb[:1])+verb[1:], rule.Ruleset, rule.RuleIndex, rule.Name)
+	return nil
+}
+
+// runFirewallGroupsList implements "gofi firewall groups list".
+func runFirewallGroupsList(args []string) error {
+	var f firewallFlags
+	fs := flag.NewFlagSet("firewall groups list", flag.ExitOnError)
+	f.register(fs, false)
+	fs.Usage = f.usage("groups list", "", "List firewall groups with their members and the rules that use them.", false,
+		"gofi firewall groups list -H 192.168.1.1 -k")
+	if _, err := f.parse(fs, args, false); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := f.conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	d, err := fetchFirewall(ctx, client, f.conn.site)
+	if err != nil {
+		return err
+	}
+	export := gofi.NewFirewallExport(d.rules, d.groups, d.networks)
+
+	if f.format == "json" {
+		return writeJSON(export.Groups)
+	}
+	for i := range export.Groups {
+		writeGroupLine(os.Stdout, &export.Groups[i])
+	}
+	return nil
+}
+
+// writeGroupLine writes a group as one line.
+func writeGroupLine(w io.Writer, g *gofi.FirewallGroupExport) {
+	used := "unused"
+	if len(g.UsedBy) > 0 {
+		used = "used by " + strings.Join(g.UsedBy, ", ")
+	}
+	fmt.Fprintf(w, "%q (%s): %s; %s\n", g.Name, g.Type, strings.Join(g.Members, ", "), used)
+}
+
+// runFirewallGroupsShow implements "gofi firewall groups show".
+func runFirewallGroupsShow(args []string) error {
+	var f firewallFlags
+	fs := flag.NewFlagSet("firewall groups show", flag.ExitOnError)
+	f.register(fs, false)
+	fs.Usage = f.usage("groups show", " NAME|ID", "Show one firewall group, looked up by name or ID, with one member per line.", false,
+		"gofi firewall groups show -H 192.168.1.1 -k Servers")
+	ref, err := f.parse(fs, args, true)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := f.conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	d, err := fetchFirewall(ctx, client, f.conn.site)
+	if err != nil {
+		return err
+	}
+	group, err := findGroup(d, ref)
+	if err != nil {
+		return err
+	}
+	g := gofi.NewFirewallExport(d.rules, []types.FirewallGroup{*group}, d.networks).Groups[0]
+
+	if f.format == "json" {
+		return writeJSON(struct {
+			ID string `json:"id"`
+			gofi.FirewallGroupExport
+		}{group.ID, g})
+	}
+	fmt.Printf("Name:    %s\n", g.Name)
+	fmt.Printf("ID:      %s\n", group.ID)
+	fmt.Printf("Type:    %s\n", g.Type)
+	fmt.Printf("Members:\n")
+	for _, m := range g.Members {
+		fmt.Printf("  %s\n", m)
+	}
+	if len(g.UsedBy) == 0 {
+		fmt.Printf("Used by: none\n")
+		return nil
+	}
+	fmt.Printf("Used by:\n")
+	for _, u := range g.UsedBy {
+		fmt.Printf("  %s\n", u)
+	}
+	return nil
+}
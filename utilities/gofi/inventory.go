@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/unifi-go/gofi"
+)
+
+// runInventory implements "gofi inventory".
+func runInventory(args []string) error {
+	var (
+		conn       connFlags
+		list       bool
+		allClients bool
+		noClients  bool
+		noDevices  bool
+	)
+
+	fs := flag.NewFlagSet("inventory", flag.ExitOnError)
+	conn.register(fs)
+	fs.BoolVar(&list, "list", true, "Print the whole inventory (the default)")
+	fs.BoolVar(&allClients, "all-clients", false, "Include clients without an alias or fixed IP")
+	fs.BoolVar(&noClients, "no-clients", false, "Leave out clients")
+	fs.BoolVar(&noDevices, "no-devices", false, "Leave out UniFi devices")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi inventory [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Print an Ansible dynamic inventory of devices and clients, grouped by\n")
+		fmt.Fprintf(os.Stderr, "network (network_*), VLAN (vlan_*), type (type_*) and note #tags (tag_*).\n")
+		fmt.Fprintf(os.Stderr, "Host variables are included under _meta, so Ansible never calls --host.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "      --list\t\tPrint the whole inventory (the default)\n")
+		fmt.Fprintf(os.Stderr, "      --all-clients\tInclude clients without an alias or fixed IP\n")
+		fmt.Fprintf(os.Stderr, "      --no-clients\tLeave out clients\n")
+		fmt.Fprintf(os.Stderr, "      --no-devices\tLeave out UniFi devices\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  gofi inventory -H 192.168.1.1 -k\n")
+		fmt.Fprintf(os.Stderr, "  ansible-inventory -i unifi.sh --graph   # unifi.sh: exec gofi inventory -k \"$@\"\n")
+	}
+	fs.Parse(args)
+
+	if noClients && noDevices {
+		return errors.New("--no-clients and --no-devices leave nothing to list")
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	inv, err := gofi.BuildAnsibleInventory(ctx, client, conn.site, gofi.AnsibleInventoryOptions{
+		SkipDevices: noDevices,
+		SkipClients: noClients,
+		AllClients:  allClients,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(inv); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/unifi-go/gofi"
+)
+
+// command is a "gofi <group> <name>" subcommand, or "gofi <group>" when
+// name is empty. name may have several words, as in "rules list".
+type command struct {
+	group string
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"backup", "download", "Create a fresh backup and download it", runBackupDownload},
+	{"wlan", "rotate-psk", "Rotate WLAN passphrases from a policy", runWLANRotatePSK},
+	{"clients", "list", "List clients, filtered by network, SSID or MAC", runClientsList},
+	{"clients", "block", "Block clients", runClientsBlock},
+	{"clients", "unblock", "Unblock clients", runClientsUnblock},
+	{"clients", "kick", "Disconnect clients", runClientsKick},
+	{"clients", "forget", "Forget clients", runClientsForget},
+	{"firewall", "rules list", "List firewall rules by ruleset", runFirewallRulesList},
+	{"firewall", "rules show", "Show a firewall rule", runFirewallRulesShow},
+	{"firewall", "rules enable", "Enable a firewall rule", runFirewallRulesEnable},
+	{"firewall", "rules disable", "Disable a firewall rule", runFirewallRulesDisable},
+	{"firewall", "groups list", "List firewall groups", runFirewallGroupsList},
+	{"firewall", "groups show", "Show a firewall group", runFirewallGroupsShow},
+	{"topology", "", "Show the uplink tree", runTopology},
+	{"inventory", "", "Print an Ansible dynamic inventory", runInventory},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	for _, cmd := range commands {
+		if cmd.group != os.Args[1] {
+			continue
+		}
+		if cmd.name == "" {
+			run(cmd.run, os.Args[2:])
+			return
+		}
+		words := strings.Fields(cmd.name)
+		if len(os.Args) >= 2+len(words) && strings.Join(os.Args[2:2+len(words)], " ") == cmd.name {
+			run(cmd.run, os.Args[2+len(words):])
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", strings.Join(os.Args[1:min(3, len(os.Args))], " "))
+	usage()
+	os.Exit(1)
+}
+
+// errReported is returned by commands that have already told the user what
+// went wrong, such as with their usage text or a per-item error list.
+var errReported = errors.New("error already reported")
+
+// run runs a command and exits with status 1 if it fails. Commands return
+// their errors rather than exiting, so that their deferred Disconnect
+// ends the controller session first.
+func run(fn func(args []string) error, args []string) {
+	err := fn(args)
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, errReported) {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	}
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: gofi <command> [options]\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-20s%s\n", strings.TrimSpace(cmd.group+" "+cmd.name), cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun 'gofi <command> -h' for command options.\n")
+}
+
+// connFlags are the connection options shared by all commands.
+type connFlags struct {
+	host     string
+	port     int
+	site     string
+	insecure bool
+
+	fs *flag.FlagSet // to tell which flags were given
+}
+
+// register adds the connection flags to fs.
+func (c *connFlags) register(fs *flag.FlagSet) {
+	c.fs = fs
+	fs.StringVar(&c.host, "host", "", "UDM Pro host address")
+	fs.StringVar(&c.host, "H", "", "UDM Pro host address (shorthand)")
+	fs.IntVar(&c.port, "port", 443, "UDM Pro port")
+	fs.IntVar(&c.port, "p", 443, "UDM Pro port (shorthand)")
+	fs.StringVar(&c.site, "site", "default", "Site name")
+	fs.StringVar(&c.site, "S", "default", "Site name (shorthand)")
+	fs.BoolVar(&c.insecure, "insecure", false, "Skip TLS certificate verification")
+	fs.BoolVar(&c.insecure, "k", false, "Skip TLS certificate verification (shorthand)")
+}
+
+// printUsage writes the connection section of a command's help text.
+func (c *connFlags) printUsage() {
+	fmt.Fprintf(os.Stderr, "Connection:\n")
+	fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (or set %s)\n", gofi.EnvHost)
+	fmt.Fprintf(os.Stderr, "  -p, --port int\tUDM Pro port (default 443)\n")
+	fmt.Fprintf(os.Stderr, "  -S, --site string\tSite name (or set %s, default \"default\")\n", gofi.EnvSite)
+	fmt.Fprintf(os.Stderr, "  -k, --insecure\tSkip TLS certificate verification (or set %s)\n\n", gofi.EnvInsecure)
+	fmt.Fprintf(os.Stderr, "Environment Variables:\n")
+	fmt.Fprintf(os.Stderr, "  %s\tUDM host (fallback for -H; %s is also read)\n", gofi.EnvHost, gofi.EnvUDMIP)
+	fmt.Fprintf(os.Stderr, "  %s\tUsername (required without an API key)\n", gofi.EnvUsername)
+	fmt.Fprintf(os.Stderr, "  %s\tPassword (required without an API key)\n", gofi.EnvPassword)
+	fmt.Fprintf(os.Stderr, "  %s\tAPI key, used instead of the username and password\n", gofi.EnvAPIKey)
+	fmt.Fprintf(os.Stderr, "  %s\tSite name (fallback for -S)\n", gofi.EnvSite)
+	fmt.Fprintf(os.Stderr, "  %s\tSkip TLS certificate verification if true\n", gofi.EnvInsecure)
+}
+
+// connect builds the config with gofi.ConfigFromEnv, overlays the flags
+// given on the command line and returns a connected client.
+func (c *connFlags) connect(ctx context.Context) (gofi.Client, error) {
+	given := make(map[string]bool)
+	c.fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	// A --host flag stands in for the host variables
+	if c.host != "" {
+		os.Setenv(gofi.EnvHost, c.host)
+	}
+
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("incomplete configuration: %w", err)
+	}
+	if given["port"] || given["p"] {
+		config.Port = c.port
+	}
+	if given["site"] || given["S"] {
+		config.Site = c.site
+	}
+	if given["insecure"] || given["k"] {
+		config.SkipTLSVerify = c.insecure
+	}
+
+	client, err := gofi.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return client, nil
+}
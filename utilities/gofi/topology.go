@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/unifi-go/gofi"
+)
+
+// runTopology implements "gofi topology".
+func runTopology(args []string) error {
+	var (
+		conn      connFlags
+		format    string
+		noClients bool
+	)
+
+	fs := flag.NewFlagSet("topology", flag.ExitOnError)
+	conn.register(fs)
+	fs.StringVar(&format, "format", "tree", "Output format: tree or json")
+	fs.StringVar(&format, "f", "tree", "Output format (shorthand)")
+	fs.BoolVar(&noClients, "no-clients", false, "Show network devices only")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi topology [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Show the uplink tree: gateway, switches, access points and clients.\n")
+		fmt.Fprintf(os.Stderr, "Devices whose uplink cannot be determined are listed as separate roots.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttree or json (default tree)\n")
+		fmt.Fprintf(os.Stderr, "      --no-clients\tShow network devices only\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  gofi topology -H 192.168.1.1 -k\n")
+		fmt.Fprintf(os.Stderr, "  gofi topology -H 192.168.1.1 -k --no-clients -f json\n")
+	}
+	fs.Parse(args)
+
+	if format != "tree" && format != "json" {
+		return fmt.Errorf("unknown format %q (want tree or json)", format)
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	topo, err := gofi.BuildTopology(ctx, client, conn.site, gofi.TopologyOptions{IncludeClients: !noClients})
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(topo)
+	} else {
+		err = topo.WriteTree(os.Stdout)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
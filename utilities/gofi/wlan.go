@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi"
+)
+
+// runWLANRotatePSK implements "gofi wlan rotate-psk".
+func runWLANRotatePSK(args []string) error {
+	var (
+		conn    connFlags
+		sites   string
+		ssids   string
+		prefix  string
+		length  int
+		stagger time.Duration
+		dryRun  bool
+		format  string
+		qr      bool
+	)
+
+	fs := flag.NewFlagSet("wlan rotate-psk", flag.ExitOnError)
+	conn.register(fs)
+	fs.StringVar(&sites, "sites", "", "Comma-separated sites to rotate (default: --site)")
+	fs.StringVar(&ssids, "ssid", "", "Comma-separated SSIDs to rotate (default: all passphrase WLANs)")
+	fs.StringVar(&prefix, "prefix", "", "Passphrase prefix")
+	fs.IntVar(&length, "length", 12, "Number of random characters after the prefix")
+	fs.DurationVar(&stagger, "stagger", 30*time.Second, "Pause between WLAN updates")
+	fs.BoolVar(&dryRun, "dry-run", false, "Generate passphrases without applying them")
+	fs.BoolVar(&dryRun, "n", false, "Generate passphrases without applying them (shorthand)")
+	fs.StringVar(&format, "format", "text", "Output format: text, csv or json")
+	fs.StringVar(&format, "f", "text", "Output format (shorthand)")
+	fs.BoolVar(&qr, "qr", false, "Print a Wi-Fi QR code for each SSID (requires qrencode)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gofi wlan rotate-psk [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Assign new random passphrases to WPA-PSK/WPA3 WLANs and print the new\n")
+		fmt.Fprintf(os.Stderr, "credentials to stdout. Updates are staggered because each one reprovisions\n")
+		fmt.Fprintf(os.Stderr, "the access points broadcasting the SSID.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "      --sites string\tComma-separated sites to rotate (default: --site)\n")
+		fmt.Fprintf(os.Stderr, "      --ssid string\tComma-separated SSIDs (default: all passphrase WLANs)\n")
+		fmt.Fprintf(os.Stderr, "      --prefix string\tPassphrase prefix, e.g. \"guest-\"\n")
+		fmt.Fprintf(os.Stderr, "      --length int\tRandom characters after the prefix (default 12)\n")
+		fmt.Fprintf(os.Stderr, "      --stagger duration\tPause between WLAN updates (default 30s)\n")
+		fmt.Fprintf(os.Stderr, "  -n, --dry-run\t\tGenerate passphrases without applying them\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttext, csv or json (default text)\n")
+		fmt.Fprintf(os.Stderr, "      --qr\t\tAlso print a Wi-Fi QR code per SSID (requires qrencode)\n\n")
+		conn.printUsage()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  gofi wlan rotate-psk -H 192.168.1.1 -k --ssid Guest --prefix guest- --qr\n")
+		fmt.Fprintf(os.Stderr, "  gofi wlan rotate-psk -H 192.168.1.1 -k --sites default,branch -f csv > psk.csv\n")
+	}
+	fs.Parse(args)
+
+	switch format {
+	case "text", "csv", "json":
+	default:
+		return fmt.Errorf("unknown format %q (want text, csv or json)", format)
+	}
+
+	opts := gofi.PSKRotationOptions{
+		Sites:   splitList(sites),
+		SSIDs:   splitList(ssids),
+		Policy:  gofi.PSKPolicy{Prefix: prefix, Length: length},
+		Stagger: stagger,
+		DryRun:  dryRun,
+	}
+	if len(opts.Sites) == 0 {
+		opts.Sites = []string{conn.site}
+	}
+	if err := opts.Policy.Validate(); err != nil {
+		return fmt.Errorf("invalid passphrase policy: %w", err)
+	}
+
+	ctx := context.Background()
+	client, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	rotations, err := gofi.RotatePSKs(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeRotations(os.Stdout, rotations, format); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if qr {
+		printQRCodes(rotations)
+	}
+
+	failed := 0
+	for _, r := range rotations {
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "Error: %s/%s: %v\n", r.Site, r.SSID, r.Err)
+		}
+	}
+
+	switch {
+	case len(rotations) == 0:
+		fmt.Fprintf(os.Stderr, "No passphrase WLANs selected.\n")
+	case dryRun:
+		fmt.Fprintf(os.Stderr, "Dry run: %d passphrase(s) generated, none applied.\n", len(rotations))
+	default:
+		fmt.Fprintf(os.Stderr, "Rotated %d of %d passphrase(s).\n", len(rotations)-failed, len(rotations))
+	}
+	if failed > 0 {
+		return errReported
+	}
+	return nil
+}
+
+// rotationJSON is the JSON representation of a rotation.
+type rotationJSON struct {
+	Site       string `json:"site"`
+	SSID       string `json:"ssid"`
+	Passphrase string `json:"passphrase"`
+	Applied    bool   `json:"applied"`
+	Error      string `json:"error,omitempty"`
+}
+
+// writeRotations writes the new credentials in the given format.
+func writeRotations(w io.Writer, rotations []gofi.PSKRotation, format string) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"site", "ssid", "passphrase", "applied"})
+		for _, r := range rotations {
+			cw.Write([]string{r.Site, r.SSID, r.Passphrase, fmt.Sprint(r.Applied)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "json":
+		items := make([]rotationJSON, len(rotations))
+		for i, r := range rotations {
+			items[i] = rotationJSON{Site: r.Site, SSID: r.SSID, Passphrase: r.Passphrase, Applied: r.Applied}
+			if r.Err != nil {
+				items[i].Error = r.Err.Error()
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	default:
+		for _, r := range rotations {
+			status := ""
+			switch {
+			case r.Err != nil:
+				status = "  (FAILED, not applied)"
+			case !r.Applied:
+				status = "  (not applied)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s%s\n", r.Site, r.SSID, r.Passphrase, status)
+		}
+		return nil
+	}
+}
+
+// printQRCodes renders a Wi-Fi QR code per SSID on stderr using the
+// qrencode tool, or prints the raw WIFI: payloads if it is not installed.
+func printQRCodes(rotations []gofi.PSKRotation) {
+	qrencode, err := exec.LookPath("qrencode")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "qrencode not found; Wi-Fi QR payloads:\n")
+	}
+
+	for _, r := range rotations {
+		if r.Err != nil {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "\n%s / %s\n", r.Site, r.SSID)
+		if qrencode == "" {
+			fmt.Fprintf(os.Stderr, "%s\n", r.WiFiURI())
+			continue
+		}
+		cmd := exec.Command(qrencode, "-t", "ANSIUTF8", r.WiFiURI())
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "qrencode failed: %v\n", err)
+		}
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
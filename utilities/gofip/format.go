@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// File formats for --get and --set.
+const (
+	formatAuto = "auto"
+	formatText = "text"
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	// Export-only formats that also include local DNS records.
+	formatDnsmasq = "dnsmasq"
+	formatHosts   = "hosts"
+)
+
+// csvHeader is the CSV column layout. hostname is informational: it is
+// exported but ignored on import.
+var csvHeader = []string{"ip", "mac", "name", "hostname"}
+
+// jsonEntry is the JSON representation of an assignment.
+type jsonEntry struct {
+	IP       string `json:"ip"`
+	MAC      string `json:"mac"`
+	Name     string `json:"name,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// detectFormat guesses the input format: JSON if it starts with '[',
+// CSV if the first non-comment line contains a comma, text otherwise.
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return formatJSON
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, ",") {
+			return formatCSV
+		}
+		break
+	}
+	return formatText
+}
+
+// decodeEntries reads raw entries in the given format. Each entry records
+// its position in the input for error messages.
+func decodeEntries(data []byte, format string) ([]entry, error) {
+	if format == formatAuto {
+		format = detectFormat(data)
+	}
+
+	switch format {
+	case formatText:
+		return decodeText(data)
+	case formatCSV:
+		return decodeCSV(data)
+	case formatJSON:
+		return decodeJSON(data)
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, csv or json)", format)
+	}
+}
+
+// decodeText reads "IP MAC [name]" lines. The name may contain spaces.
+func decodeText(data []byte) ([]entry, error) {
+	var entries []entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip comments and blank lines
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected 'IP MAC [name]', got %d field(s): %s", lineNum, len(fields), line)
+		}
+
+		entries = append(entries, entry{
+			IP:   fields[0],
+			MAC:  fields[1],
+			Name: strings.Join(fields[2:], " "),
+			pos:  fmt.Sprintf("line %d", lineNum),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+	return entries, nil
+}
+
+// decodeCSV reads CSV with a header row naming at least the ip and mac
+// columns. Lines starting with '#' are ignored.
+func decodeCSV(data []byte) ([]entry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	cols := make(map[string]int)
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	ipCol, hasIP := cols["ip"]
+	macCol, hasMAC := cols["mac"]
+	if !hasIP || !hasMAC {
+		return nil, fmt.Errorf("CSV header must include ip and mac columns, got %s", strings.Join(header, ","))
+	}
+	nameCol, hasName := cols["name"]
+
+	var entries []entry
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+
+		line, _ := r.FieldPos(0)
+		field := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		e := entry{IP: field(ipCol), MAC: field(macCol), pos: fmt.Sprintf("line %d", line)}
+		if hasName {
+			e.Name = field(nameCol)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// decodeJSON reads an array of {"ip", "mac", "name"} objects.
+func decodeJSON(data []byte) ([]entry, error) {
+	var items []jsonEntry
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("reading JSON: %w", err)
+	}
+
+	entries := make([]entry, len(items))
+	for i, item := range items {
+		entries[i] = entry{
+			IP:   strings.TrimSpace(item.IP),
+			MAC:  strings.TrimSpace(item.MAC),
+			Name: strings.TrimSpace(item.Name),
+			pos:  fmt.Sprintf("entry %d", i+1),
+		}
+	}
+	return entries, nil
+}
+
+// writeEntries writes assignments in the given format.
+func writeEntries(w io.Writer, entries []entry, format string) error {
+	switch format {
+	case formatText:
+		return writeText(w, entries)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		for _, e := range entries {
+			cw.Write([]string{e.IP, e.MAC, e.Name, e.Hostname})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		items := make([]jsonEntry, len(entries))
+		for i, e := range entries {
+			items[i] = jsonEntry{IP: e.IP, MAC: e.MAC, Name: e.Name, Hostname: e.Hostname}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	default:
+		return fmt.Errorf("unknown format %q (want text, csv or json)", format)
+	}
+}
+
+// writeText writes the commented "IP MAC [name]" format.
+func writeText(w io.Writer, entries []entry) error {
+	fmt.Fprintln(w, "# gofip fixed IP assignments")
+	fmt.Fprintln(w, "# format: IP MAC [name]")
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "# No fixed IP assignments found on UDM.")
+		fmt.Fprintln(w, "# Example:")
+		fmt.Fprintln(w, "# 192.168.1.10 aa:bb:cc:dd:ee:ff nas")
+		fmt.Fprintln(w, "# 192.168.1.11 11:22:33:44:55:66")
+		return nil
+	}
+
+	for _, e := range entries {
+		if e.Name != "" {
+			fmt.Fprintf(w, "%s %s %s\n", e.IP, e.MAC, e.Name)
+		} else {
+			fmt.Fprintf(w, "%s %s\n", e.IP, e.MAC)
+		}
+	}
+	return nil
+}
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"encoding/binary"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"regexp"
@@ -13,20 +13,24 @@ import (
 	"strings"
 
 	"github.com/unifi-go/gofi"
-	"github.com/unifi-go/gofi/types"
-)
-
-const (
-	envUsername = "UNIFI_USERNAME"
-	envPassword = "UNIFI_PASSWORD"
-	envUDMIP    = "UNIFI_UDM_IP"
 )
 
 var macRegex = regexp.MustCompile(`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`)
 
 type entry struct {
-	IP  string
-	MAC string
+	IP       string
+	MAC      string
+	Name     string
+	Hostname string // exported only
+	pos      string // input position for error messages
+}
+
+// setOptions controls how --set applies the input.
+type setOptions struct {
+	dryRun bool   // report changes without applying them
+	diff   bool   // print changes in diff form without applying them
+	prune  bool   // remove assignments missing from the input
+	format string // input format, or formatAuto
 }
 
 func main() {
@@ -37,6 +41,11 @@ func main() {
 		insecure = flag.Bool("insecure", false, "Skip TLS certificate verification")
 		get      = flag.Bool("get", false, "Export fixed IP assignments to stdout")
 		set      = flag.Bool("set", false, "Import fixed IP assignments from file or stdin")
+		dryRun   = flag.Bool("dry-run", false, "With --set, show what would change without applying")
+		diff     = flag.Bool("diff", false, "With --set, print changes as a diff without applying")
+		prune    = flag.Bool("prune", false, "With --set, remove assignments not in the input")
+		format   = flag.String("format", "", "File format: text, csv or json; --get also dnsmasq or hosts (--set default: auto-detect)")
+		domain   = flag.String("domain", "", "With --format dnsmasq or hosts, also emit names qualified with this domain")
 	)
 
 	flag.StringVar(host, "H", "", "UDM Pro host address (shorthand)")
@@ -45,6 +54,9 @@ func main() {
 	flag.BoolVar(insecure, "k", false, "Skip TLS certificate verification (shorthand)")
 	flag.BoolVar(get, "g", false, "Export fixed IP assignments to stdout (shorthand)")
 	flag.BoolVar(set, "s", false, "Import fixed IP assignments from file or stdin (shorthand)")
+	flag.BoolVar(dryRun, "n", false, "Show what would change without applying (shorthand)")
+	flag.BoolVar(diff, "d", false, "Print changes as a diff without applying (shorthand)")
+	flag.StringVar(format, "f", "", "File format (shorthand)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options] --get\n", os.Args[0])
@@ -52,20 +64,33 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Manage fixed IP (DHCP reservation) assignments on a UniFi UDM Pro.\n\n")
 		fmt.Fprintf(os.Stderr, "Modes:\n")
 		fmt.Fprintf(os.Stderr, "  -g, --get\t\tExport current assignments to stdout\n")
-		fmt.Fprintf(os.Stderr, "  -s, --set\t\tImport assignments from file or stdin\n\n")
+		fmt.Fprintf(os.Stderr, "  -s, --set\t\tImport assignments from file or stdin\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format string\ttext (IP MAC [name]), csv or json; --set auto-detects by default\n")
+		fmt.Fprintf(os.Stderr, "\t\t\t--get also supports dnsmasq and hosts, which add local DNS records\n")
+		fmt.Fprintf(os.Stderr, "      --domain string\tWith dnsmasq or hosts, also emit NAME.DOMAIN for each name\n\n")
+		fmt.Fprintf(os.Stderr, "Set Options:\n")
+		fmt.Fprintf(os.Stderr, "  -n, --dry-run\t\tShow what would change without applying\n")
+		fmt.Fprintf(os.Stderr, "  -d, --diff\t\tPrint changes to stdout as +/~/- lines without applying\n")
+		fmt.Fprintf(os.Stderr, "      --prune\t\tRemove assignments that are not in the input\n\n")
 		fmt.Fprintf(os.Stderr, "Connection:\n")
-		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (or set %s)\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  -H, --host string\tUDM Pro host address (or set %s)\n", gofi.EnvHost)
 		fmt.Fprintf(os.Stderr, "  -p, --port int\tUDM Pro port (default 443)\n")
 		fmt.Fprintf(os.Stderr, "  -S, --site string\tSite name (default \"default\")\n")
 		fmt.Fprintf(os.Stderr, "  -k, --insecure\tSkip TLS certificate verification\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
-		fmt.Fprintf(os.Stderr, "  %s\tUsername (required)\n", envUsername)
-		fmt.Fprintf(os.Stderr, "  %s\tPassword (required)\n", envPassword)
-		fmt.Fprintf(os.Stderr, "  %s\tUDM host (fallback for -H)\n\n", envUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s\tUDM host (fallback for -H; %s is also read)\n", gofi.EnvHost, gofi.EnvUDMIP)
+		fmt.Fprintf(os.Stderr, "  %s\tUsername (required without an API key)\n", gofi.EnvUsername)
+		fmt.Fprintf(os.Stderr, "  %s\tPassword (required without an API key)\n", gofi.EnvPassword)
+		fmt.Fprintf(os.Stderr, "  %s\tAPI key, used instead of the username and password\n", gofi.EnvAPIKey)
+		fmt.Fprintf(os.Stderr, "  %s\tSite name (fallback for -S)\n", gofi.EnvSite)
+		fmt.Fprintf(os.Stderr, "  %s\tSkip TLS certificate verification if true\n\n", gofi.EnvInsecure)
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -g > hosts.txt\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -s hosts.txt\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  cat hosts.txt | %s -H 192.168.1.1 -k -s\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -s --diff --prune hosts.txt\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -g -f csv > hosts.csv\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -H 192.168.1.1 -k -g -f dnsmasq --domain lan > unifi.conf\n", os.Args[0])
 	}
 
 	flag.Parse()
@@ -80,42 +105,39 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Resolve host
-	if *host == "" {
-		*host = os.Getenv(envUDMIP)
-	}
-	if *host == "" {
-		exitError("--host is required (or set " + envUDMIP + ")")
+	if (*dryRun || *diff || *prune) && !*set {
+		exitError("--dry-run, --diff and --prune require --set")
 	}
 
-	// Credentials
-	username := os.Getenv(envUsername)
-	password := os.Getenv(envPassword)
-	if username == "" {
-		exitError(envUsername + " environment variable is required")
+	if (*format == formatDnsmasq || *format == formatHosts) && !*get {
+		exitError("--format " + *format + " is only supported with --get")
 	}
-	if password == "" {
-		exitError(envPassword + " environment variable is required")
+	if *domain != "" && *format != formatDnsmasq && *format != formatHosts {
+		exitError("--domain requires --format dnsmasq or hosts")
 	}
 
-	config := &gofi.Config{
-		Host:          *host,
-		Port:          *port,
-		Username:      username,
-		Password:      password,
-		Site:          *site,
-		SkipTLSVerify: *insecure,
-	}
+	config := configFromEnv(*host, *port, *site, *insecure)
+	*site = config.Site
 
 	if *get {
-		doGet(config, *site)
+		if *format == "" || *format == formatAuto {
+			*format = formatText
+		}
+		if *format == formatDnsmasq || *format == formatHosts {
+			doExportHosts(config, *site, *format, *domain)
+		} else {
+			doGet(config, *site, *format)
+		}
 	} else {
-		doSet(config, *site, flag.Args())
+		if *format == "" {
+			*format = formatAuto
+		}
+		doSet(config, *site, flag.Args(), setOptions{dryRun: *dryRun, diff: *diff, prune: *prune, format: *format})
 	}
 }
 
 // doGet exports current fixed IP assignments to stdout.
-func doGet(config *gofi.Config, site string) {
+func doGet(config *gofi.Config, site, format string) {
 	client, err := gofi.New(config)
 	if err != nil {
 		exitError("failed to create client: " + err.Error())
@@ -135,48 +157,82 @@ func doGet(config *gofi.Config, site string) {
 	var entries []entry
 	for _, u := range users {
 		if u.UseFixedIP && u.FixedIP != "" {
-			entries = append(entries, entry{IP: u.FixedIP, MAC: strings.ToLower(u.MAC)})
+			entries = append(entries, entry{
+				IP:       u.FixedIP,
+				MAC:      strings.ToLower(u.MAC),
+				Name:     u.Name,
+				Hostname: u.Hostname,
+			})
 		}
 	}
 
 	sortEntries(entries)
 
-	fmt.Println("# gofip fixed IP assignments")
-	fmt.Println("# format: IP MAC")
+	if err := writeEntries(os.Stdout, entries, format); err != nil {
+		exitError("failed to write output: " + err.Error())
+	}
 
 	if len(entries) == 0 {
-		fmt.Println("# No fixed IP assignments found on UDM.")
-		fmt.Println("# Example:")
-		fmt.Println("# 192.168.1.10 aa:bb:cc:dd:ee:ff")
-		fmt.Println("# 192.168.1.11 11:22:33:44:55:66")
 		fmt.Fprintf(os.Stderr, "No fixed IP assignments found.\n")
 	} else {
-		for _, e := range entries {
-			fmt.Printf("%s %s\n", e.IP, e.MAC)
-		}
 		fmt.Fprintf(os.Stderr, "Exported %d fixed IP assignment(s).\n", len(entries))
 	}
 }
 
+// doExportHosts writes fixed IP assignments and local DNS records as
+// dnsmasq configuration or an /etc/hosts style file.
+func doExportHosts(config *gofi.Config, site, format, domain string) {
+	client, err := gofi.New(config)
+	if err != nil {
+		exitError("failed to create client: " + err.Error())
+	}
+
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		exitError("failed to connect: " + err.Error())
+	}
+	defer client.Disconnect(ctx)
+
+	inv, err := gofi.CollectHosts(ctx, client, site)
+	if err != nil {
+		exitError(err.Error())
+	}
+
+	if format == formatDnsmasq {
+		err = inv.WriteDnsmasq(os.Stdout, domain)
+	} else {
+		err = inv.WriteHosts(os.Stdout, domain)
+	}
+	if err != nil {
+		exitError("failed to write output: " + err.Error())
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d host(s) and %d alias(es).\n", len(inv.Hosts), len(inv.Aliases))
+}
+
 // doSet imports fixed IP assignments from a file or stdin.
-func doSet(config *gofi.Config, site string, args []string) {
+func doSet(config *gofi.Config, site string, args []string, opts setOptions) {
 	// Determine input source
-	var scanner *bufio.Scanner
+	var input io.Reader = os.Stdin
 	if len(args) > 0 {
 		f, err := os.Open(args[0])
 		if err != nil {
 			exitError("failed to open file: " + err.Error())
 		}
 		defer f.Close()
-		scanner = bufio.NewScanner(f)
+		input = f
 		fmt.Fprintf(os.Stderr, "Reading from %s\n", args[0])
 	} else {
-		scanner = bufio.NewScanner(os.Stdin)
 		fmt.Fprintf(os.Stderr, "Reading from stdin\n")
 	}
 
+	data, err := io.ReadAll(input)
+	if err != nil {
+		exitError("failed to read input: " + err.Error())
+	}
+
 	// Parse and validate all input before connecting
-	entries, err := parseInput(scanner)
+	entries, err := parseInput(data, opts.format)
 	if err != nil {
 		exitError(err.Error())
 	}
@@ -200,160 +256,117 @@ func doSet(config *gofi.Config, site string, args []string) {
 	}
 	defer client.Disconnect(ctx)
 
-	// Fetch existing assignments
-	users, err := client.Users().List(ctx, site)
-	if err != nil {
-		exitError("failed to list users: " + err.Error())
+	desired := make([]gofi.FixedIP, len(entries))
+	for i, e := range entries {
+		desired[i] = gofi.FixedIP{IP: e.IP, MAC: e.MAC, Name: e.Name}
 	}
 
-	existing := make(map[string]*types.User) // keyed by lowercase MAC
-	for i := range users {
-		u := &users[i]
-		if u.UseFixedIP && u.FixedIP != "" {
-			existing[strings.ToLower(u.MAC)] = u
-		}
-	}
-
-	// Fetch networks for subnet detection
-	networks, err := client.Networks().List(ctx, site)
+	plan, err := gofi.PlanFixedIPs(ctx, client, site, desired, gofi.FixedIPSyncOptions{Prune: opts.prune})
 	if err != nil {
-		exitError("failed to list networks: " + err.Error())
+		exitError(err.Error())
 	}
 
-	// Process entries
-	var created, updated, skipped, errored int
-
-	for _, e := range entries {
-		mac := strings.ToLower(e.MAC)
-
-		// Check if already exists with same IP
-		if eu, ok := existing[mac]; ok {
-			if eu.FixedIP == e.IP {
-				fmt.Fprintf(os.Stderr, "  skip: %s %s (unchanged)\n", e.IP, mac)
-				skipped++
-				continue
-			}
-			// Different IP — update
-			networkID, err := detectNetwork(networks, e.IP)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "  error: %s %s: %v\n", e.IP, mac, err)
-				errored++
-				continue
-			}
-			eu.FixedIP = e.IP
-			eu.NetworkID = networkID
-			if _, err := client.Users().Update(ctx, site, eu); err != nil {
-				fmt.Fprintf(os.Stderr, "  error: %s %s: failed to update: %v\n", e.IP, mac, err)
-				errored++
-				continue
+	if opts.diff {
+		for _, c := range plan.Changes {
+			if c.Action != gofi.FixedIPUnchanged {
+				fmt.Println(c.String())
 			}
-			fmt.Fprintf(os.Stderr, "  updated: %s %s\n", e.IP, mac)
-			updated++
-			continue
 		}
+	}
 
-		// New entry — detect network and create
-		networkID, err := detectNetwork(networks, e.IP)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "  error: %s %s: %v\n", e.IP, mac, err)
-			errored++
-			continue
+	if opts.dryRun || opts.diff {
+		for _, c := range plan.Changes {
+			reportChange("would ", c)
 		}
-
-		// Check if user exists without fixed IP
-		existingUser, _ := client.Users().GetByMAC(ctx, site, mac)
-		if existingUser != nil {
-			existingUser.UseFixedIP = true
-			existingUser.FixedIP = e.IP
-			existingUser.NetworkID = networkID
-			if _, err := client.Users().Update(ctx, site, existingUser); err != nil {
-				fmt.Fprintf(os.Stderr, "  error: %s %s: failed to update: %v\n", e.IP, mac, err)
-				errored++
-				continue
-			}
-			fmt.Fprintf(os.Stderr, "  updated: %s %s (added fixed IP to existing user)\n", e.IP, mac)
-			updated++
-		} else {
-			newUser := &types.User{
-				MAC:        mac,
-				UseFixedIP: true,
-				FixedIP:    e.IP,
-				NetworkID:  networkID,
-			}
-			if _, err := client.Users().Create(ctx, site, newUser); err != nil {
-				fmt.Fprintf(os.Stderr, "  error: %s %s: failed to create: %v\n", e.IP, mac, err)
-				errored++
-				continue
-			}
-			fmt.Fprintf(os.Stderr, "  created: %s %s\n", e.IP, mac)
-			created++
+		printSummary(plan, len(entries))
+		if plan.Count(gofi.FixedIPInvalid) > 0 {
+			os.Exit(1)
 		}
+		return
 	}
 
-	fmt.Fprintf(os.Stderr, "\nSummary: %d processed, %d skipped (unchanged), %d created, %d updated, %d errors\n",
-		len(entries), skipped, created, updated, errored)
+	errored := gofi.ApplyFixedIPs(ctx, client, plan)
+	for _, c := range plan.Changes {
+		reportChange("", c)
+	}
+	printSummary(plan, len(entries))
 
 	if errored > 0 {
 		os.Exit(1)
 	}
 }
 
-// parseInput reads and validates all entries from the scanner.
-// Returns an error if any line is malformed or there are duplicates.
-func parseInput(scanner *bufio.Scanner) ([]entry, error) {
-	var entries []entry
-	seenIPs := make(map[string]int)   // IP -> line number
-	seenMACs := make(map[string]int)  // MAC -> line number
-	var dupIPs, dupMACs []string
-	lineNum := 0
-
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
+// reportChange prints one planned or applied change to stderr.
+func reportChange(prefix string, c gofi.FixedIPChange) {
+	switch {
+	case c.Err != nil:
+		fmt.Fprintf(os.Stderr, "  error: %s %s: %v\n", c.IP, c.MAC, c.Err)
+	case c.Action == gofi.FixedIPUnchanged:
+		fmt.Fprintf(os.Stderr, "  skip: %s %s (unchanged)\n", c.IP, c.MAC)
+	case c.Action == gofi.FixedIPCreate:
+		fmt.Fprintf(os.Stderr, "  %screate: %s %s\n", prefix, c.IP, c.MAC)
+	case c.Action == gofi.FixedIPUpdate && c.OldIP == "":
+		fmt.Fprintf(os.Stderr, "  %supdate: %s %s (add fixed IP to existing user)\n", prefix, c.IP, c.MAC)
+	case c.Action == gofi.FixedIPUpdate:
+		fmt.Fprintf(os.Stderr, "  %supdate: %s %s (was %s)\n", prefix, c.IP, c.MAC, c.OldIP)
+	case c.Action == gofi.FixedIPRemove:
+		fmt.Fprintf(os.Stderr, "  %sremove: %s %s\n", prefix, c.OldIP, c.MAC)
+	}
+}
 
-		// Skip comments and blank lines
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+// printSummary prints change counts to stderr.
+func printSummary(plan *gofi.FixedIPPlan, processed int) {
+	errors := 0
+	for _, c := range plan.Changes {
+		if c.Err != nil {
+			errors++
 		}
+	}
+	fmt.Fprintf(os.Stderr, "\nSummary: %d processed, %d skipped (unchanged), %d created, %d updated, %d removed, %d errors\n",
+		processed, plan.Count(gofi.FixedIPUnchanged), plan.Count(gofi.FixedIPCreate),
+		plan.Count(gofi.FixedIPUpdate), plan.Count(gofi.FixedIPRemove), errors)
+}
 
-		fields := strings.Fields(line)
-		if len(fields) != 2 {
-			return nil, fmt.Errorf("line %d: expected 'IP MAC', got %d field(s): %s", lineNum, len(fields), line)
-		}
+// parseInput decodes and validates all entries.
+// Returns an error if any entry is malformed or there are duplicates.
+func parseInput(data []byte, format string) ([]entry, error) {
+	entries, err := decodeEntries(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	seenIPs := make(map[string]string)  // IP -> position
+	seenMACs := make(map[string]string) // MAC -> position
+	var dupIPs, dupMACs []string
 
-		ip := fields[0]
-		mac := strings.ToLower(fields[1])
+	for i := range entries {
+		e := &entries[i]
+		e.MAC = strings.ToLower(e.MAC)
 
 		// Validate IP
-		parsedIP := net.ParseIP(ip)
+		parsedIP := net.ParseIP(e.IP)
 		if parsedIP == nil {
-			return nil, fmt.Errorf("line %d: invalid IP address: %s", lineNum, ip)
+			return nil, fmt.Errorf("%s: invalid IP address: %s", e.pos, e.IP)
 		}
 		if parsedIP.To4() == nil {
-			return nil, fmt.Errorf("line %d: only IPv4 is supported: %s", lineNum, ip)
+			return nil, fmt.Errorf("%s: only IPv4 is supported: %s", e.pos, e.IP)
 		}
 
 		// Validate MAC
-		if !macRegex.MatchString(mac) {
-			return nil, fmt.Errorf("line %d: invalid MAC address: %s (expected aa:bb:cc:dd:ee:ff)", lineNum, fields[1])
+		if !macRegex.MatchString(e.MAC) {
+			return nil, fmt.Errorf("%s: invalid MAC address: %s (expected aa:bb:cc:dd:ee:ff)", e.pos, e.MAC)
 		}
 
 		// Track duplicates
-		if prev, ok := seenIPs[ip]; ok {
-			dupIPs = append(dupIPs, fmt.Sprintf("  IP %s on lines %d and %d", ip, prev, lineNum))
+		if prev, ok := seenIPs[e.IP]; ok {
+			dupIPs = append(dupIPs, fmt.Sprintf("  IP %s on %s and %s", e.IP, prev, e.pos))
 		}
-		seenIPs[ip] = lineNum
+		seenIPs[e.IP] = e.pos
 
-		if prev, ok := seenMACs[mac]; ok {
-			dupMACs = append(dupMACs, fmt.Sprintf("  MAC %s on lines %d and %d", mac, prev, lineNum))
+		if prev, ok := seenMACs[e.MAC]; ok {
+			dupMACs = append(dupMACs, fmt.Sprintf("  MAC %s on %s and %s", e.MAC, prev, e.pos))
 		}
-		seenMACs[mac] = lineNum
-
-		entries = append(entries, entry{IP: ip, MAC: mac})
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("reading input: %w", err)
+		seenMACs[e.MAC] = e.pos
 	}
 
 	// Report all duplicates at once
@@ -371,28 +384,6 @@ func parseInput(scanner *bufio.Scanner) ([]entry, error) {
 	return entries, nil
 }
 
-// detectNetwork finds which network contains the given IP.
-func detectNetwork(networks []types.Network, ip string) (string, error) {
-	parsedIP := net.ParseIP(ip)
-
-	for _, n := range networks {
-		if n.IPSubnet == "" {
-			continue
-		}
-
-		_, subnet, err := net.ParseCIDR(n.IPSubnet)
-		if err != nil {
-			continue
-		}
-
-		if subnet.Contains(parsedIP) {
-			return n.ID, nil
-		}
-	}
-
-	return "", fmt.Errorf("no network found containing IP %s", ip)
-}
-
 // sortEntries sorts entries by IP address numerically.
 func sortEntries(entries []entry) {
 	sort.Slice(entries, func(i, j int) bool {
@@ -413,6 +404,30 @@ func ipToUint32(ipStr string) uint32 {
 	return binary.BigEndian.Uint32(ip4)
 }
 
+// configFromEnv builds the config with gofi.ConfigFromEnv and overlays the
+// connection flags given on the command line.
+func configFromEnv(host string, port int, site string, insecure bool) *gofi.Config {
+	// A --host flag stands in for the host variables
+	if host != "" {
+		os.Setenv(gofi.EnvHost, host)
+	}
+
+	config, err := gofi.ConfigFromEnv()
+	if err != nil {
+		exitError("incomplete configuration: " + err.Error())
+	}
+	config.Port = port
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "site", "S":
+			config.Site = site
+		case "insecure", "k":
+			config.SkipTLSVerify = insecure
+		}
+	})
+	return config
+}
+
 func exitError(msg string) {
 	fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	os.Exit(1)
@@ -0,0 +1,362 @@
+package gofi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// WatchResource is the kind of object a WatchEvent is about.
+type WatchResource string
+
+// Watched resources.
+const (
+	WatchDevice WatchResource = "device"
+	WatchClient WatchResource = "client"
+)
+
+// WatchChange is what happened to the object of a WatchEvent.
+type WatchChange string
+
+// Watch changes.
+const (
+	// WatchAdded means a device was adopted or a client connected.
+	WatchAdded WatchChange = "added"
+
+	// WatchRemoved means a device was removed or a client disconnected.
+	WatchRemoved WatchChange = "removed"
+
+	// WatchUpdated means one of the watched fields changed.
+	WatchUpdated WatchChange = "updated"
+)
+
+// WatchEvent is a change to a device or active client, delivered by Watch
+// whether it came from the event stream or from polling.
+type WatchEvent struct {
+	Resource WatchResource
+	Change   WatchChange
+	Site     string
+
+	// MAC identifies the device or client (lowercase).
+	MAC  string
+	Name string
+
+	// Fields lists the changed fields of an update, named by their JSON
+	// tags. Watched device fields are name, state, ip, version, adopted
+	// and upgradable; client fields are name, hostname, ip, network,
+	// essid, ap_mac and sw_port. When the change came from the event
+	// stream, Old values are nil, as are New values the event does not
+	// carry.
+	Fields []types.Change
+
+	// Event is the stream event the change was derived from, or nil if it
+	// was found by polling.
+	Event *types.Event
+
+	Time time.Time
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+// watchOptions holds options for Watch.
+type watchOptions struct {
+	interval time.Duration
+	polling  bool
+}
+
+// WithWatchInterval sets how often devices and clients are listed when
+// polling (default: 10s).
+func WithWatchInterval(d time.Duration) WatchOption {
+	return func(opts *watchOptions) {
+		opts.interval = d
+	}
+}
+
+// WithWatchPolling polls even if the event stream is available.
+func WithWatchPolling() WatchOption {
+	return func(opts *watchOptions) {
+		opts.polling = true
+	}
+}
+
+// Watch reports changes to a site's devices and active clients until ctx
+// is done, then closes both channels.
+//
+// It subscribes to the event stream if the client offers one and falls
+// back to polling when it does not, when subscribing fails (e.g. classic
+// controllers or proxies that block WebSockets) or when the stream ends.
+// Polling lists devices and clients at the watch interval and diffs them
+// against the previous listing; the first listing is the baseline and
+// produces no events. Errors are reported on the error channel and do
+// not stop the watch. Events are dropped while the consumer falls behind.
+func Watch(ctx context.Context, c Client, site string, opts ...WatchOption) (<-chan WatchEvent, <-chan error, error) {
+	options := &watchOptions{interval: 10 * time.Second}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.interval <= 0 {
+		return nil, nil, fmt.Errorf("watch interval must be positive: %w", ErrInvalidConfig)
+	}
+
+	w := &watcher{
+		client:  c,
+		site:    site,
+		options: options,
+		events:  make(chan WatchEvent, 100),
+		errs:    make(chan error, 10),
+	}
+	go w.run(ctx)
+	return w.events, w.errs, nil
+}
+
+// watcher runs one Watch.
+type watcher struct {
+	client  Client
+	site    string
+	options *watchOptions
+	events  chan WatchEvent
+	errs    chan error
+}
+
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.events)
+	defer close(w.errs)
+
+	if !w.options.polling {
+		w.stream(ctx)
+	}
+	if ctx.Err() == nil {
+		w.poll(ctx)
+	}
+}
+
+// stream forwards stream events until the stream ends. It returns at once
+// if there is no stream.
+func (w *watcher) stream(ctx context.Context) {
+	svc := w.client.Events()
+	if svc == nil {
+		return
+	}
+
+	// The subscription ends with the stream; the service is shared and
+	// stays open
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, errs, err := svc.Subscribe(ctx, w.site)
+	if err != nil {
+		w.error(fmt.Errorf("event stream unavailable, polling instead: %w", err))
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-errs:
+			if ok {
+				w.error(err)
+			} else {
+				errs = nil
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if e, ok := watchEventFromStream(w.site, ev); ok {
+				w.emit(e)
+			}
+		}
+	}
+}
+
+// poll lists devices and clients at the watch interval and emits the
+// differences between consecutive listings.
+func (w *watcher) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.options.interval)
+	defer ticker.Stop()
+
+	var devices, clients map[string]watchedObject
+	for {
+		if d, err := w.client.Devices().List(ctx, w.site); err != nil {
+			w.error(fmt.Errorf("failed to list devices: %w", err))
+		} else {
+			next := watchedDevices(d)
+			if devices != nil {
+				w.diff(WatchDevice, devices, next)
+			}
+			devices = next
+		}
+
+		if cl, err := w.client.Clients().ListActive(ctx, w.site); err != nil {
+			w.error(fmt.Errorf("failed to list clients: %w", err))
+		} else {
+			next := watchedClients(cl)
+			if clients != nil {
+				w.diff(WatchClient, clients, next)
+			}
+			clients = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// diff emits the changes between two listings.
+func (w *watcher) diff(resource WatchResource, before, after map[string]watchedObject) {
+	now := time.Now()
+	for mac, obj := range after {
+		old, ok := before[mac]
+		if !ok {
+			w.emit(WatchEvent{Resource: resource, Change: WatchAdded, Site: w.site, MAC: mac, Name: obj.name, Time: now})
+			continue
+		}
+		if fields := types.Diff(old.fields, obj.fields); len(fields) > 0 {
+			w.emit(WatchEvent{Resource: resource, Change: WatchUpdated, Site: w.site, MAC: mac, Name: obj.name, Fields: fields, Time: now})
+		}
+	}
+	for mac, obj := range before {
+		if _, ok := after[mac]; !ok {
+			w.emit(WatchEvent{Resource: resource, Change: WatchRemoved, Site: w.site, MAC: mac, Name: obj.name, Time: now})
+		}
+	}
+}
+
+func (w *watcher) emit(e WatchEvent) {
+	select {
+	case w.events <- e:
+	default:
+	}
+}
+
+func (w *watcher) error(err error) {
+	select {
+	case w.errs <- err:
+	default:
+	}
+}
+
+// watchedObject is the name and watched fields of a listed object.
+type watchedObject struct {
+	name   string
+	fields any
+}
+
+type watchedDeviceFields struct {
+	Name       string            `json:"name"`
+	State      types.DeviceState `json:"state"`
+	IP         string            `json:"ip"`
+	Version    string            `json:"version"`
+	Adopted    bool              `json:"adopted"`
+	Upgradable bool              `json:"upgradable"`
+}
+
+type watchedClientFields struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname"`
+	IP       string `json:"ip"`
+	Network  string `json:"network"`
+	ESSID    string `json:"essid"`
+	APMAC    string `json:"ap_mac"`
+	SWPort   int    `json:"sw_port"`
+}
+
+func watchedDevices(devices []types.Device) map[string]watchedObject {
+	m := make(map[string]watchedObject, len(devices))
+	for _, d := range devices {
+		m[strings.ToLower(d.MAC)] = watchedObject{name: d.Name, fields: watchedDeviceFields{
+			Name:       d.Name,
+			State:      d.State,
+			IP:         d.IP,
+			Version:    d.Version,
+			Adopted:    d.Adopted,
+			Upgradable: d.Upgradable,
+		}}
+	}
+	return m
+}
+
+func watchedClients(clients []types.Client) map[string]watchedObject {
+	m := make(map[string]watchedObject, len(clients))
+	for _, cl := range clients {
+		name := cl.Name
+		if name == "" {
+			name = cl.Hostname
+		}
+		m[strings.ToLower(cl.MAC)] = watchedObject{name: name, fields: watchedClientFields{
+			Name:     cl.Name,
+			Hostname: cl.Hostname,
+			IP:       cl.IP,
+			Network:  cl.NetworkName,
+			ESSID:    cl.ESSID,
+			APMAC:    strings.ToLower(cl.APMA),
+			SWPort:   cl.SWPORT,
+		}}
+	}
+	return m
+}
+
+// watchEventFromStream maps a stream event to a WatchEvent. Events that do
+// not describe a device or client change are skipped.
+func watchEventFromStream(site string, ev types.Event) (WatchEvent, bool) {
+	e := WatchEvent{Site: site, Event: &ev, Time: ev.Timestamp()}
+	key := ev.Key
+
+	switch {
+	case strings.HasPrefix(key, "EVT_WU_") || strings.HasPrefix(key, "EVT_WG_") || strings.HasPrefix(key, "EVT_LU_") || strings.HasPrefix(key, "EVT_LG_"):
+		e.Resource, e.MAC, e.Name = WatchClient, ev.User, ev.Hostname
+		switch {
+		case strings.HasSuffix(key, "_Connected"):
+			e.Change = WatchAdded
+		case strings.HasSuffix(key, "_Disconnected"):
+			e.Change = WatchRemoved
+		case strings.HasSuffix(key, "_Roam"):
+			e.Change = WatchUpdated
+			e.Fields = []types.Change{{Path: "ap_mac", New: strings.ToLower(ev.APMAC)}}
+		default:
+			return e, false
+		}
+
+	case strings.HasPrefix(key, "EVT_AP_") || strings.HasPrefix(key, "EVT_SW_") || strings.HasPrefix(key, "EVT_GW_"):
+		e.Resource = WatchDevice
+		switch {
+		case ev.AP != "":
+			e.MAC, e.Name = ev.AP, ev.APName
+		case ev.SW != "":
+			e.MAC, e.Name = ev.SW, ev.SWName
+		default:
+			e.MAC, e.Name = ev.GW, ev.GWName
+		}
+		switch {
+		case strings.HasSuffix(key, "_Adopted"):
+			e.Change = WatchAdded
+		case strings.HasSuffix(key, "_Deleted"):
+			e.Change = WatchRemoved
+		case strings.HasSuffix(key, "_Connected"):
+			e.Change = WatchUpdated
+			e.Fields = []types.Change{{Path: "state", New: types.DeviceStateConnected}}
+		case strings.HasSuffix(key, "_Lost_Contact"), strings.HasSuffix(key, "_Disconnected"):
+			e.Change = WatchUpdated
+			e.Fields = []types.Change{{Path: "state", New: types.DeviceStateDisconnected}}
+		case strings.HasSuffix(key, "_Upgraded"):
+			e.Change = WatchUpdated
+			e.Fields = []types.Change{{Path: "version"}}
+		default:
+			return e, false
+		}
+
+	default:
+		return e, false
+	}
+
+	e.MAC = strings.ToLower(e.MAC)
+	return e, e.MAC != ""
+}
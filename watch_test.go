@@ -0,0 +1,125 @@
+package gofi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestWatch_Polling(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.State().AddDevice(&types.Device{ID: "ap", MAC: "AA:00:00:00:00:01", Name: "office-ap", State: types.DeviceStateConnected})
+
+	c := connectMock(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := Watch(ctx, c, "default", WithWatchInterval(20*time.Millisecond), WithWatchPolling())
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond) // let the baseline listing finish
+
+	server.State().AddDevice(&types.Device{ID: "ap", MAC: "AA:00:00:00:00:01", Name: "office-ap", State: types.DeviceStateDisconnected})
+	server.State().AddClient(&types.Client{MAC: "cc:00:00:00:00:01", Hostname: "laptop", LastSeen: types.NewFlexTime(time.Now().Unix())})
+
+	var gotDevice, gotClient bool
+	timeout := time.After(2 * time.Second)
+	for !gotDevice || !gotClient {
+		select {
+		case e := <-events:
+			switch {
+			case e.Resource == WatchDevice && e.Change == WatchUpdated:
+				if e.MAC != "aa:00:00:00:00:01" || len(e.Fields) != 1 || e.Fields[0].Path != "state" {
+					t.Errorf("device event = %+v, want state change of aa:00:00:00:00:01", e)
+				}
+				gotDevice = true
+			case e.Resource == WatchClient && e.Change == WatchAdded:
+				if e.Name != "laptop" || e.Event != nil {
+					t.Errorf("client event = %+v, want polled laptop", e)
+				}
+				gotClient = true
+			default:
+				t.Errorf("unexpected event %+v", e)
+			}
+		case err := <-errs:
+			t.Fatalf("watch error = %v", err)
+		case <-timeout:
+			t.Fatalf("timed out: device event %v, client event %v", gotDevice, gotClient)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatch_StreamLeavesEventsOpen(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c := connectMock(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	events, _, err := Watch(watchCtx, c, "default")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for streaming := false; !streaming; {
+		select {
+		case e := <-events:
+			streaming = e.Event != nil
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a streamed event")
+		}
+	}
+	watchCancel()
+	for range events {
+	}
+
+	// Ending the watch leaves the client's shared event service usable.
+	if _, _, err := c.Events().Subscribe(ctx, "default"); err != nil {
+		t.Errorf("Subscribe() after the watch ended: %v", err)
+	}
+}
+
+func TestWatch_InvalidInterval(t *testing.T) {
+	if _, _, err := Watch(context.Background(), nil, "default", WithWatchInterval(0)); err == nil {
+		t.Error("Watch() with zero interval succeeded, want error")
+	}
+}
+
+func TestWatchEventFromStream(t *testing.T) {
+	tests := []struct {
+		ev       types.Event
+		resource WatchResource
+		change   WatchChange
+		mac      string
+	}{
+		{types.Event{Key: "EVT_WU_Connected", User: "CC:00:00:00:00:01", Hostname: "phone"}, WatchClient, WatchAdded, "cc:00:00:00:00:01"},
+		{types.Event{Key: "EVT_LU_Disconnected", User: "cc:00:00:00:00:02"}, WatchClient, WatchRemoved, "cc:00:00:00:00:02"},
+		{types.Event{Key: "EVT_WU_Roam", User: "cc:00:00:00:00:01", APMAC: "aa:00:00:00:00:02"}, WatchClient, WatchUpdated, "cc:00:00:00:00:01"},
+		{types.Event{Key: "EVT_AP_Lost_Contact", AP: "aa:00:00:00:00:01", APName: "ap"}, WatchDevice, WatchUpdated, "aa:00:00:00:00:01"},
+		{types.Event{Key: "EVT_SW_Adopted", SW: "aa:00:00:00:00:03"}, WatchDevice, WatchAdded, "aa:00:00:00:00:03"},
+	}
+	for _, tt := range tests {
+		e, ok := watchEventFromStream("default", tt.ev)
+		if !ok || e.Resource != tt.resource || e.Change != tt.change || e.MAC != tt.mac || e.Event == nil {
+			t.Errorf("watchEventFromStream(%s) = %+v, %v; want %s %s %s", tt.ev.Key, e, ok, tt.resource, tt.change, tt.mac)
+		}
+	}
+
+	if _, ok := watchEventFromStream("default", types.Event{Key: "EVT_AD_Login"}); ok {
+		t.Error("admin login event mapped to a watch event")
+	}
+}
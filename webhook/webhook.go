@@ -0,0 +1,359 @@
+// Package webhook forwards UniFi events to HTTP endpoints.
+//
+// A Forwarder filters events by key, renders a payload (JSON by default, or
+// a text/template), signs it with HMAC-SHA256 and POSTs it, retrying
+// transient failures with exponential backoff:
+//
+//	f, err := webhook.New(webhook.Config{
+//		Endpoints: []webhook.Endpoint{{
+//			URL:    "https://hooks.example.com/unifi",
+//			Events: []string{"EVT_WU_Connected", "EVT_AP_*"},
+//			Secret: os.Getenv("WEBHOOK_SECRET"),
+//		}},
+//	})
+//	err = f.Subscribe(ctx, client, "default")
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/internal/eventsub"
+	"github.com/unifi-go/gofi/types"
+)
+
+// Headers set on deliveries. The timestamp and signature headers are only
+// sent to endpoints with a Secret.
+const (
+	HeaderEvent     = "X-Gofi-Event"
+	HeaderDelivery  = "X-Gofi-Delivery"
+	HeaderTimestamp = "X-Gofi-Timestamp"
+	HeaderSignature = "X-Gofi-Signature"
+)
+
+// Endpoint is a webhook receiver.
+type Endpoint struct {
+	// Name identifies the endpoint in logs. Defaults to URL.
+	Name string
+
+	// URL is where events are POSTed.
+	URL string
+
+	// Events selects event keys such as "EVT_WU_Connected". A trailing "*"
+	// matches a prefix ("EVT_AP_*"). Empty forwards every event.
+	Events []string
+
+	// Secret, if set, signs each delivery. The signature header is
+	// "sha256=" followed by the hex HMAC-SHA256 of the timestamp header,
+	// a ".", and the body; see Verify.
+	Secret string
+
+	// Template is a text/template rendering the body from a Payload. The
+	// "json" function encodes a value as JSON. Empty sends the Payload
+	// itself as JSON.
+	Template string
+
+	// ContentType defaults to "application/json".
+	ContentType string
+
+	// Headers are added to every request, e.g. for authorization.
+	Headers map[string]string
+}
+
+// Payload is the default body and the data passed to templates.
+type Payload struct {
+	Site  string      `json:"site"`
+	Time  time.Time   `json:"time"`
+	Event types.Event `json:"event"`
+}
+
+// Config configures a Forwarder.
+type Config struct {
+	Endpoints []Endpoint
+
+	// MaxRetries is the number of retries after a failed delivery.
+	// Defaults to 3; negative disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry, doubling on each
+	// further retry up to 30 seconds. Defaults to 1 second.
+	InitialBackoff time.Duration
+
+	// Timeout bounds each delivery attempt. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// QueueSize is the number of events buffered per endpoint by Run
+	// before new events are dropped. Defaults to 100.
+	QueueSize int
+
+	// HTTPClient sends the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Logger reports dropped events and failed deliveries (optional).
+	Logger gofi.Logger
+}
+
+// Forwarder delivers events to webhook endpoints.
+type Forwarder struct {
+	config    Config
+	endpoints []*endpoint
+}
+
+// endpoint is an Endpoint with its template parsed.
+type endpoint struct {
+	Endpoint
+	tmpl *template.Template
+}
+
+// New validates cfg and returns a Forwarder.
+func New(cfg Config) (*Forwarder, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("at least one endpoint is required")
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	f := &Forwarder{config: cfg}
+	for _, ep := range cfg.Endpoints {
+		if ep.URL == "" {
+			return nil, errors.New("endpoint URL is required")
+		}
+		if ep.Name == "" {
+			ep.Name = ep.URL
+		}
+		if ep.ContentType == "" {
+			ep.ContentType = "application/json"
+		}
+
+		e := &endpoint{Endpoint: ep}
+		if ep.Template != "" {
+			tmpl, err := template.New(ep.Name).Funcs(template.FuncMap{"json": toJSON}).Parse(ep.Template)
+			if err != nil {
+				return nil, fmt.Errorf("endpoint %s: invalid template: %w", ep.Name, err)
+			}
+			e.tmpl = tmpl
+		}
+		f.endpoints = append(f.endpoints, e)
+	}
+	return f, nil
+}
+
+// toJSON is the "json" template function.
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// render builds the request body for an event.
+func (e *endpoint) render(p *Payload) ([]byte, error) {
+	if e.tmpl == nil {
+		return json.Marshal(p)
+	}
+	var buf bytes.Buffer
+	if err := e.tmpl.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("endpoint %s: failed to render template: %w", e.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Forward delivers ev to every matching endpoint and waits for the
+// deliveries, including retries, to finish. The returned error joins the
+// failures of all endpoints.
+func (f *Forwarder) Forward(ctx context.Context, site string, ev types.Event) error {
+	p := newPayload(site, ev)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, e := range f.endpoints {
+		if !eventsub.MatchKey(e.Events, ev.Key) {
+			continue
+		}
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			if err := f.deliver(ctx, e, p); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(e)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// newPayload wraps an event with its site and time.
+func newPayload(site string, ev types.Event) *Payload {
+	return &Payload{Site: site, Time: eventsub.Time(ev), Event: ev}
+}
+
+// Run forwards events from the channel until it is closed or ctx is done.
+// Each endpoint has its own queue, so a slow or failing endpoint delays
+// only its own deliveries; when a queue is full the event is dropped for
+// that endpoint. Once ctx is done Run stops taking events, but still
+// delivers the queued ones, with their retries, before it returns.
+func (f *Forwarder) Run(ctx context.Context, site string, events <-chan types.Event) error {
+	deliverCtx := context.WithoutCancel(ctx)
+	queues := make([]chan *Payload, len(f.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range f.endpoints {
+		queues[i] = make(chan *Payload, f.config.QueueSize)
+		wg.Add(1)
+		go func(e *endpoint, q <-chan *Payload) {
+			defer wg.Done()
+			for p := range q {
+				if err := f.deliver(deliverCtx, e, p); err != nil {
+					eventsub.Warn(f.config.Logger, "Webhook delivery failed", "endpoint", e.Name, "event", p.Event.Key, "error", err)
+				}
+			}
+		}(e, queues[i])
+	}
+
+	defer func() {
+		for _, q := range queues {
+			close(q)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			p := newPayload(site, ev)
+			for i, e := range f.endpoints {
+				if !eventsub.MatchKey(e.Events, ev.Key) {
+					continue
+				}
+				select {
+				case queues[i] <- p:
+				default:
+					eventsub.Warn(f.config.Logger, "Webhook queue full, dropping event", "endpoint", e.Name, "event", ev.Key)
+				}
+			}
+		}
+	}
+}
+
+// Subscribe subscribes to the site's event stream and runs the forwarder
+// until ctx is done or the stream ends. Stream errors are logged.
+func (f *Forwarder) Subscribe(ctx context.Context, c gofi.Client, site string) error {
+	return eventsub.Subscribe(ctx, c, site, f.config.Logger, func(ctx context.Context, events <-chan types.Event) error {
+		return f.Run(ctx, site, events)
+	})
+}
+
+// deliver POSTs a payload to one endpoint, retrying network errors, 429
+// and 5xx responses.
+func (f *Forwarder) deliver(ctx context.Context, e *endpoint, p *Payload) error {
+	body, err := e.render(p)
+	if err != nil {
+		return err
+	}
+
+	backoff := f.config.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := f.post(ctx, e, p, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= f.config.MaxRetries {
+			return fmt.Errorf("endpoint %s: %w", e.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("endpoint %s: %w", e.Name, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (f *Forwarder) post(ctx context.Context, e *endpoint, p *Payload, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", e.ContentType)
+	req.Header.Set(HeaderEvent, p.Event.Key)
+	if p.Event.ID != "" {
+		req.Header.Set(HeaderDelivery, p.Event.ID)
+	}
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+	if e.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, Sign(e.Secret, ts, body))
+	}
+
+	resp, err := f.config.HTTPClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("request failed: %w", err)
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("delivery failed with status %d", resp.StatusCode)
+}
+
+// Sign returns the signature header value for a body sent at timestamp
+// (Unix seconds, as in the timestamp header).
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a delivery's signature, for use by receivers. It does not
+// check the timestamp's age; receivers should reject stale timestamps to
+// prevent replays.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,255 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi"
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+// receiver records deliveries made to a test server.
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func (r *receiver) handler(status func(n int) int) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, string(body))
+		n := len(r.requests)
+		r.mu.Unlock()
+		w.WriteHeader(status(n))
+	}
+}
+
+func ok(int) int { return http.StatusOK }
+
+func TestForwarder_Forward(t *testing.T) {
+	var rec receiver
+	server := httptest.NewServer(rec.handler(ok))
+	defer server.Close()
+
+	f, err := New(Config{Endpoints: []Endpoint{{
+		URL:     server.URL,
+		Events:  []string{"EVT_WU_*"},
+		Secret:  "s3cret",
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ev := types.Event{ID: "e1", Key: "EVT_WU_Connected", Message: "User connected", Time: types.NewFlexTime(1700000000000)}
+	if err := f.Forward(context.Background(), "default", ev); err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if err := f.Forward(context.Background(), "default", types.Event{Key: "EVT_AP_Lost_Contact"}); err != nil {
+		t.Fatalf("Forward() of unmatched event error = %v", err)
+	}
+
+	if len(rec.requests) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(rec.requests))
+	}
+	req, body := rec.requests[0], rec.bodies[0]
+
+	if req.Header.Get(HeaderEvent) != "EVT_WU_Connected" || req.Header.Get(HeaderDelivery) != "e1" {
+		t.Errorf("event headers = %v", req.Header)
+	}
+	if req.Header.Get("Authorization") != "Bearer token" {
+		t.Errorf("Authorization = %q, want custom header", req.Header.Get("Authorization"))
+	}
+	if !Verify("s3cret", req.Header.Get(HeaderTimestamp), []byte(body), req.Header.Get(HeaderSignature)) {
+		t.Errorf("signature %q does not verify", req.Header.Get(HeaderSignature))
+	}
+
+	var p Payload
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if p.Site != "default" || p.Event.Message != "User connected" || !p.Time.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("payload = %+v", p)
+	}
+}
+
+func TestForwarder_Template(t *testing.T) {
+	var rec receiver
+	server := httptest.NewServer(rec.handler(ok))
+	defer server.Close()
+
+	f, err := New(Config{Endpoints: []Endpoint{{
+		URL:      server.URL,
+		Template: `{"text": {{json (printf "[%s] %s" .Site .Event.Message)}}}`,
+	}}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := f.Forward(context.Background(), "hq", types.Event{Key: "EVT_AP_Upgraded", Message: `AP "lobby" upgraded`}); err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+
+	want := `{"text": "[hq] AP \"lobby\" upgraded"}`
+	if rec.bodies[0] != want {
+		t.Errorf("body = %s, want %s", rec.bodies[0], want)
+	}
+	if _, err := New(Config{Endpoints: []Endpoint{{URL: server.URL, Template: "{{"}}}); err == nil {
+		t.Error("New() accepted an invalid template")
+	}
+}
+
+func TestForwarder_Retries(t *testing.T) {
+	var rec receiver
+	server := httptest.NewServer(rec.handler(func(n int) int {
+		if n < 3 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	}))
+	defer server.Close()
+
+	f, _ := New(Config{Endpoints: []Endpoint{{URL: server.URL}}, InitialBackoff: time.Millisecond})
+	if err := f.Forward(context.Background(), "default", types.Event{Key: "EVT_GW_WANTransition"}); err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if len(rec.requests) != 3 {
+		t.Errorf("got %d attempts, want 3", len(rec.requests))
+	}
+
+	var bad receiver
+	badServer := httptest.NewServer(bad.handler(func(int) int { return http.StatusBadRequest }))
+	defer badServer.Close()
+
+	f, _ = New(Config{Endpoints: []Endpoint{{Name: "bad", URL: badServer.URL}}, InitialBackoff: time.Millisecond})
+	err := f.Forward(context.Background(), "default", types.Event{Key: "EVT_GW_WANTransition"})
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("Forward() error = %v, want status 400", err)
+	}
+	if len(bad.requests) != 1 {
+		t.Errorf("4xx was retried: got %d attempts", len(bad.requests))
+	}
+}
+
+func TestForwarder_Run(t *testing.T) {
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+	}))
+	defer server.Close()
+
+	f, _ := New(Config{Endpoints: []Endpoint{{URL: server.URL, Events: []string{"EVT_WU_Connected"}}}})
+
+	events := make(chan types.Event, 3)
+	events <- types.Event{Key: "EVT_WU_Connected"}
+	events <- types.Event{Key: "EVT_WU_Disconnected"}
+	events <- types.Event{Key: "EVT_WU_Connected"}
+	close(events)
+
+	if err := f.Run(context.Background(), "default", events); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := delivered.Load(); got != 2 {
+		t.Errorf("delivered %d events, want 2", got)
+	}
+}
+
+func TestForwarder_RunDrainsQueueOnCancel(t *testing.T) {
+	var delivered atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		delivered.Add(1)
+	}))
+	defer server.Close()
+
+	f, _ := New(Config{Endpoints: []Endpoint{{URL: server.URL}}})
+
+	events := make(chan types.Event, 3)
+	for range 3 {
+		events <- types.Event{Key: "EVT_WU_Connected"}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f.Run(ctx, "default", events) }()
+
+	// Cancel once every event is queued behind the first delivery.
+	<-started
+	for len(events) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	close(release)
+
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if got := delivered.Load(); got != 3 {
+		t.Errorf("delivered %d events, want the 3 queued before cancel", got)
+	}
+}
+
+func TestForwarder_SubscribeLeavesEventsOpen(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	c, err := gofi.New(&gofi.Config{Host: server.Host(), Port: server.Port(), Username: "admin", Password: "admin", SkipTLSVerify: true})
+	if err != nil {
+		t.Fatalf("gofi.New() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Disconnect(context.Background())
+
+	delivered := make(chan struct{}, 10)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+	}))
+	defer endpoint.Close()
+	f, _ := New(Config{Endpoints: []Endpoint{{URL: endpoint.URL}}})
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- f.Subscribe(subCtx, c, "default") }()
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for forwarding := false; !forwarding; {
+		select {
+		case <-delivered:
+			forwarding = true
+		case <-tick.C:
+			server.SimulateClientDisconnect("default", "aa:bb:cc:dd:ee:01")
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a delivery")
+		}
+	}
+	subCancel()
+	<-done
+
+	// Ending the forwarder's subscription leaves the client's shared
+	// event service usable.
+	if _, _, err := c.Events().Subscribe(ctx, "default"); err != nil {
+		t.Errorf("Subscribe() after the forwarder stopped: %v", err)
+	}
+}
@@ -26,6 +26,7 @@ type Client struct {
 type Config struct {
 	TLSConfig *tls.Config
 	Headers   http.Header
+	Jar       http.CookieJar
 }
 
 // Option configures a WebSocket client.
@@ -45,6 +46,14 @@ func WithHeaders(headers http.Header) Option {
 	}
 }
 
+// WithCookieJar sets the jar whose cookies for the URL are sent with the
+// handshake, such as a session's.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(c *Config) {
+		c.Jar = jar
+	}
+}
+
 // New creates a new WebSocket client.
 func New(wsURL string, opts ...Option) (*Client, error) {
 	// Parse URL to validate
@@ -68,6 +77,7 @@ func New(wsURL string, opts ...Option) (*Client, error) {
 	dialer := &websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 		TLSClientConfig:  config.TLSConfig,
+		Jar:              config.Jar,
 	}
 
 	c := &Client{
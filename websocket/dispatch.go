@@ -0,0 +1,131 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+// Dispatcher calls the handlers registered for each event it is given,
+// decoding client and device events into typed payloads first. Handlers
+// run in the dispatching goroutine, in the order they were registered.
+type Dispatcher struct {
+	mu        sync.RWMutex
+	keys      map[string][]func(types.Event)
+	clients   map[types.ClientEventKind][]func(types.ClientEvent)
+	devices   []func(types.DeviceEvent)
+	unhandled []func(types.Event)
+}
+
+// NewDispatcher creates a dispatcher with no handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		keys:    make(map[string][]func(types.Event)),
+		clients: make(map[types.ClientEventKind][]func(types.ClientEvent)),
+	}
+}
+
+// On registers a handler for events with key, such as
+// types.EventIPSAlert.
+func (d *Dispatcher) On(key string, handler func(types.Event)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.keys[key] = append(d.keys[key], handler)
+}
+
+// OnClientConnected registers a handler for clients connecting, wired or
+// wireless, guests included.
+func (d *Dispatcher) OnClientConnected(handler func(types.ClientEvent)) {
+	d.onClient(types.ClientEventConnected, handler)
+}
+
+// OnClientDisconnected registers a handler for clients disconnecting.
+func (d *Dispatcher) OnClientDisconnected(handler func(types.ClientEvent)) {
+	d.onClient(types.ClientEventDisconnected, handler)
+}
+
+// OnClientRoamed registers a handler for wireless clients roaming between
+// access points or radios.
+func (d *Dispatcher) OnClientRoamed(handler func(types.ClientEvent)) {
+	d.onClient(types.ClientEventRoamed, handler)
+}
+
+// onClient registers a handler for client events of a kind.
+func (d *Dispatcher) onClient(kind types.ClientEventKind, handler func(types.ClientEvent)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clients[kind] = append(d.clients[kind], handler)
+}
+
+// OnDeviceStateChange registers a handler for access points, switches and
+// gateways connecting, disconnecting, restarting, upgrading or being
+// adopted.
+func (d *Dispatcher) OnDeviceStateChange(handler func(types.DeviceEvent)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.devices = append(d.devices, handler)
+}
+
+// OnUnhandled registers a handler for events no other handler was called
+// for.
+func (d *Dispatcher) OnUnhandled(handler func(types.Event)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unhandled = append(d.unhandled, handler)
+}
+
+// Dispatch calls the handlers for an event: those registered with On for
+// its key, then the typed ones, or the OnUnhandled ones if there were
+// none. It reports whether any handler other than OnUnhandled was called.
+func (d *Dispatcher) Dispatch(event types.Event) bool {
+	d.mu.RLock()
+	keyed := d.keys[event.Key]
+	var clients []func(types.ClientEvent)
+	ce, isClient := event.ClientEvent()
+	if isClient {
+		clients = d.clients[ce.Kind]
+	}
+	var devices []func(types.DeviceEvent)
+	de, isDevice := event.DeviceEvent()
+	if isDevice {
+		devices = d.devices
+	}
+	unhandled := d.unhandled
+	d.mu.RUnlock()
+
+	for _, handler := range keyed {
+		handler(event)
+	}
+	for _, handler := range clients {
+		handler(ce)
+	}
+	for _, handler := range devices {
+		handler(de)
+	}
+
+	handled := len(keyed)+len(clients)+len(devices) > 0
+	if !handled {
+		for _, handler := range unhandled {
+			handler(event)
+		}
+	}
+	return handled
+}
+
+// Run dispatches the events received on events, such as those of
+// EventService.Subscribe, until the channel is closed or ctx is done. It
+// returns ctx.Err() if ctx ended it and nil otherwise.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan types.Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			d.Dispatch(event)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package websocket
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestDispatcher(t *testing.T) {
+	d := NewDispatcher()
+
+	var connected []types.ClientEvent
+	var devices []types.DeviceEvent
+	var keyed, unhandled []string
+	d.OnClientConnected(func(e types.ClientEvent) { connected = append(connected, e) })
+	d.OnDeviceStateChange(func(e types.DeviceEvent) { devices = append(devices, e) })
+	d.On(types.EventIPSAlert, func(e types.Event) { keyed = append(keyed, e.Key) })
+	d.OnUnhandled(func(e types.Event) { unhandled = append(unhandled, e.Key) })
+
+	events := []types.Event{
+		{Key: types.EventWUConnected, User: "aa:bb:cc:dd:ee:01", SSID: "Office", AP: "00:11:22:33:44:55"},
+		{Key: types.EventLUDisconnected, User: "aa:bb:cc:dd:ee:02"},
+		{Key: "EVT_SW_Lost_Contact", SW: "00:11:22:33:44:66", SWName: "Core"},
+		{Key: types.EventIPSAlert},
+	}
+	handled := make([]bool, len(events))
+	for i, event := range events {
+		handled[i] = d.Dispatch(event)
+	}
+
+	if want := []bool{true, false, true, true}; !slices.Equal(handled, want) {
+		t.Errorf("Dispatch() = %v, want %v", handled, want)
+	}
+	if len(connected) != 1 || connected[0].MAC != "aa:bb:cc:dd:ee:01" || connected[0].SSID != "Office" || connected[0].Wired {
+		t.Errorf("OnClientConnected got %+v", connected)
+	}
+	if len(devices) != 1 || devices[0].Kind != types.DeviceEventDisconnected || devices[0].Type != "usw" || devices[0].Name != "Core" {
+		t.Errorf("OnDeviceStateChange got %+v", devices)
+	}
+	if len(keyed) != 1 {
+		t.Errorf("On(EVT_IPS_Alert) got %v", keyed)
+	}
+	if len(unhandled) != 1 || unhandled[0] != types.EventLUDisconnected {
+		t.Errorf("OnUnhandled got %v", unhandled)
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	d := NewDispatcher()
+	got := make(chan types.ClientEvent, 1)
+	d.OnClientRoamed(func(e types.ClientEvent) { got <- e })
+
+	events := make(chan types.Event, 1)
+	events <- types.Event{Key: types.EventWURoam, User: "aa:bb:cc:dd:ee:01", APFrom: "ap1", APTo: "ap2"}
+	close(events)
+
+	if err := d.Run(context.Background(), events); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	select {
+	case e := <-got:
+		if e.AP != "ap2" || e.PreviousAP != "ap1" {
+			t.Errorf("roam = %+v, want ap1 -> ap2", e)
+		}
+	default:
+		t.Fatal("OnClientRoamed was not called")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := d.Run(ctx, make(chan types.Event)); err != context.DeadlineExceeded {
+		t.Errorf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,145 @@
+package gofi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unifi-go/gofi/transport"
+)
+
+// WriteQueueStats describes the per-site write queue enabled by
+// WithSerializedWrites.
+type WriteQueueStats struct {
+	// Depth is the number of writes queued or in flight, by site. Sites
+	// with no pending writes are omitted.
+	Depth map[string]int `json:"depth"`
+
+	// MaxDepth is the highest depth any site has reached.
+	MaxDepth int `json:"max_depth"`
+
+	// Writes counts the writes that went through the queue.
+	Writes uint64 `json:"writes"`
+
+	// Waited counts the writes that had to wait for an earlier one.
+	Waited uint64 `json:"waited"`
+}
+
+// writeQueue lets one write per site through at a time. It is shared by a
+// client and its clones.
+type writeQueue struct {
+	mu       sync.Mutex
+	sites    map[string]*siteQueue
+	maxDepth int
+	writes   uint64
+	waited   uint64
+}
+
+// siteQueue is the queue of one site. The token channel holds one value
+// while no write is in flight.
+type siteQueue struct {
+	token chan struct{}
+	depth int
+}
+
+func newWriteQueue() *writeQueue {
+	return &writeQueue{sites: make(map[string]*siteQueue)}
+}
+
+// acquire waits until site has no write in flight. The returned function
+// must be called when the write is done.
+func (q *writeQueue) acquire(ctx context.Context, site string) (func(), error) {
+	q.mu.Lock()
+	sq := q.sites[site]
+	if sq == nil {
+		sq = &siteQueue{token: make(chan struct{}, 1)}
+		sq.token <- struct{}{}
+		q.sites[site] = sq
+	}
+	sq.depth++
+	q.writes++
+	if sq.depth > 1 {
+		q.waited++
+	}
+	q.maxDepth = max(q.maxDepth, sq.depth)
+	q.mu.Unlock()
+
+	select {
+	case <-sq.token:
+		return func() {
+			sq.token <- struct{}{}
+			q.leave(site, sq)
+		}, nil
+	case <-ctx.Done():
+		q.leave(site, sq)
+		return nil, ctx.Err()
+	}
+}
+
+// leave removes a write from site's depth, dropping the queue once it is
+// empty.
+func (q *writeQueue) leave(site string, sq *siteQueue) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	sq.depth--
+	if sq.depth == 0 {
+		delete(q.sites, site)
+	}
+}
+
+// stats returns a snapshot of the queue.
+func (q *writeQueue) stats() WriteQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s := WriteQueueStats{
+		Depth:    make(map[string]int, len(q.sites)),
+		MaxDepth: q.maxDepth,
+		Writes:   q.writes,
+		Waited:   q.waited,
+	}
+	for site, sq := range q.sites {
+		s.Depth[site] = sq.depth
+	}
+	return s
+}
+
+// writeQueueTransport sends the writes of each site one at a time. Reads,
+// as classified by isRead, and writes that target no site, such as login,
+// are not queued.
+type writeQueueTransport struct {
+	transport transport.Transport
+	queue     *writeQueue
+}
+
+func (t *writeQueueTransport) Do(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	site := siteFromPath(req.Path)
+	if isRead(req) || site == "" {
+		return t.transport.Do(ctx, req)
+	}
+
+	release, err := t.queue.acquire(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.transport.Do(ctx, req)
+}
+
+func (t *writeQueueTransport) Stream(ctx context.Context, req *transport.Request) (*transport.StreamResponse, error) {
+	return transport.Stream(ctx, t.transport, req)
+}
+
+func (t *writeQueueTransport) Stats() transport.Stats {
+	return transport.GetStats(t.transport)
+}
+
+func (t *writeQueueTransport) SetCSRFToken(token string) {
+	t.transport.SetCSRFToken(token)
+}
+
+func (t *writeQueueTransport) GetCSRFToken() string {
+	return t.transport.GetCSRFToken()
+}
+
+func (t *writeQueueTransport) Close() {
+	t.transport.Close()
+}
@@ -0,0 +1,102 @@
+package gofi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unifi-go/gofi/mock"
+	"github.com/unifi-go/gofi/types"
+)
+
+func TestWriteQueue(t *testing.T) {
+	q := newWriteQueue()
+	ctx := context.Background()
+
+	release, err := q.acquire(ctx, "default")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	// Another site is not held up.
+	other, err := q.acquire(ctx, "branch")
+	if err != nil {
+		t.Fatalf("acquire(branch) error = %v", err)
+	}
+	other()
+
+	// A second write to the same site waits for the first.
+	acquired := make(chan func())
+	go func() {
+		r, _ := q.acquire(ctx, "default")
+		acquired <- r
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second write acquired the site while the first was in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if got := q.stats().Depth["default"]; got != 2 {
+		t.Errorf("Depth[default] = %d, want 2", got)
+	}
+
+	// A write that gives up leaves the queue.
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.acquire(cctx, "default"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire() with expired context error = %v, want DeadlineExceeded", err)
+	}
+
+	release()
+	(<-acquired)()
+
+	s := q.stats()
+	if len(s.Depth) != 0 || s.MaxDepth != 3 || s.Writes != 4 || s.Waited != 2 {
+		t.Errorf("stats() = %+v, want empty depth, max 3, 4 writes, 2 waited", s)
+	}
+}
+
+func TestSerializedWrites(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		server.State().AddNetwork(&types.Network{ID: name, Name: name, Purpose: "corporate"})
+	}
+
+	c := connectMock(t, server, WithSerializedWrites())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := c.Networks().Update(ctx, "default", &types.Network{ID: id, Name: id + "-renamed", Purpose: "corporate"}); err != nil {
+				t.Errorf("Update(%s) error = %v", id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	wq := c.ForSite("other").Diagnostics().WriteQueue
+	if wq == nil {
+		t.Fatal("Diagnostics().WriteQueue = nil, want stats")
+	}
+	if wq.Writes != 4 || len(wq.Depth) != 0 {
+		t.Errorf("WriteQueue = %+v, want 4 writes and nothing pending", wq)
+	}
+
+	// Queries POSTed to stat/ endpoints are reads and are not queued.
+	if _, err := c.Events().History(ctx, "default"); err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if wq := c.Diagnostics().WriteQueue; wq.Writes != 4 {
+		t.Errorf("WriteQueue.Writes = %d after History(), want 4", wq.Writes)
+	}
+
+	if connectMock(t, server).Diagnostics().WriteQueue != nil {
+		t.Error("WriteQueue reported without WithSerializedWrites")
+	}
+}